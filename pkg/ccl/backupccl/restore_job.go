@@ -1267,12 +1267,16 @@ func createImportingDescriptors(
 				}
 			}
 
-			// Allocate no schedule to the row-level TTL.
-			// This will be re-written when the descriptor is published.
+			// Allocate no schedule to the row-level TTL or the automatic
+			// partitioning. This will be re-written when the descriptor is
+			// published.
 			for _, table := range mutableTables {
 				if table.HasRowLevelTTL() {
 					table.RowLevelTTL.ScheduleID = 0
 				}
+				if table.HasPartitionMaintenance() {
+					table.PartitionMaintenance.ScheduleID = 0
+				}
 			}
 			descsCol := txn.Descriptors()
 			// Write the new descriptors which are set in the OFFLINE state.
@@ -2376,6 +2380,22 @@ func (r *restoreResumer) publishDescriptors(
 			}
 			mutTable.RowLevelTTL.ScheduleID = j.ScheduleID()
 		}
+		// Assign a partition maintenance schedule before publishing.
+		if mutTable.HasPartitionMaintenance() {
+			j, err := sql.CreatePartitionMaintenanceScheduledJob(
+				ctx,
+				jobsKnobs,
+				jobs.ScheduledJobTxn(txn),
+				user,
+				mutTable,
+				clusterID,
+				version,
+			)
+			if err != nil {
+				return err
+			}
+			mutTable.PartitionMaintenance.ScheduleID = j.ScheduleID()
+		}
 
 		newTables = append(newTables, mutTable.TableDesc())
 
@@ -2715,6 +2735,14 @@ func (r *restoreResumer) dropDescriptors(
 				}
 			}
 		}
+		if tableToDrop.HasPartitionMaintenance() {
+			scheduleID := tableToDrop.PartitionMaintenance.ScheduleID
+			if scheduleID != 0 {
+				if err := scheduledJobs.DeleteByID(ctx, env, scheduleID); err != nil {
+					return err
+				}
+			}
+		}
 
 		// Arrange for fast GC of table data.
 		//
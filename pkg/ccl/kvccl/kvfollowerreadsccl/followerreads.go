@@ -126,7 +126,9 @@ func closedTimestampLikelySufficient(
 }
 
 // canSendToFollower implements the logic for checking whether a batch request
-// may be sent to a follower.
+// may be sent to a follower. Linearizable follower reads can be served by a
+// follower regardless of its closed timestamp, because the follower obtains a
+// read index from the leaseholder when its closed timestamp is insufficient.
 func canSendToFollower(
 	ctx context.Context,
 	st *cluster.Settings,
@@ -135,7 +137,8 @@ func canSendToFollower(
 	ba *kvpb.BatchRequest,
 ) bool {
 	result := kvserver.BatchCanBeEvaluatedOnFollower(ctx, ba) &&
-		closedTimestampLikelySufficient(ctx, st, clock, ctPolicy, ba.RequiredFrontier()) &&
+		(ba.LinearizableFollowerRead ||
+			closedTimestampLikelySufficient(ctx, st, clock, ctPolicy, ba.RequiredFrontier())) &&
 		// NOTE: this call can be expensive, so perform it last. See #62447.
 		checkFollowerReadsEnabled(ctx, st)
 	return result
@@ -192,7 +195,8 @@ func (o *followerReadOracle) useClosestOracle(
 	// BatchRequests in the DistSender. This would hurt performance, but would
 	// not violate correctness.
 	return txn != nil &&
-		closedTimestampLikelySufficient(ctx, o.st, o.clock, ctPolicy, txn.RequiredFrontier()) &&
+		(txn.LinearizableFollowerReads() ||
+			closedTimestampLikelySufficient(ctx, o.st, o.clock, ctPolicy, txn.RequiredFrontier())) &&
 		// NOTE: this call can be expensive, so perform it last. See #62447.
 		checkFollowerReadsEnabled(ctx, o.st)
 }
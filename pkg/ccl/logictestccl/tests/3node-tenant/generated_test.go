@@ -123,6 +123,13 @@ func TestLogic_tmp(t *testing.T) {
 	logictest.RunLogicTests(t, serverArgs, configIdx, glob)
 }
 
+func TestTenantLogic_advisory_lock(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "advisory_lock")
+}
+
 func TestTenantLogic_aggregate(
 	t *testing.T,
 ) {
@@ -130,6 +137,13 @@ func TestTenantLogic_aggregate(
 	runLogicTest(t, "aggregate")
 }
 
+func TestTenantLogic_aggregate_udf(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "aggregate_udf")
+}
+
 func TestTenantLogic_alias_types(
 	t *testing.T,
 ) {
@@ -571,6 +585,13 @@ func TestTenantLogic_default(
 	runLogicTest(t, "default")
 }
 
+func TestTenantLogic_deferrable_constraints(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "deferrable_constraints")
+}
+
 func TestTenantLogic_delete(
 	t *testing.T,
 ) {
@@ -662,6 +683,13 @@ func TestTenantLogic_distsql_tenant(
 	runLogicTest(t, "distsql_tenant")
 }
 
+func TestTenantLogic_domain(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "domain")
+}
+
 func TestTenantLogic_drop_database(
 	t *testing.T,
 ) {
@@ -851,6 +879,13 @@ func TestTenantLogic_float(
 	runLogicTest(t, "float")
 }
 
+func TestTenantLogic_foreign_table(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "foreign_table")
+}
+
 func TestTenantLogic_format(
 	t *testing.T,
 ) {
@@ -963,6 +998,13 @@ func TestTenantLogic_group_join(
 	runLogicTest(t, "group_join")
 }
 
+func TestTenantLogic_grouping_sets(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "grouping_sets")
+}
+
 func TestTenantLogic_hash_join(
 	t *testing.T,
 ) {
@@ -1152,6 +1194,13 @@ func TestTenantLogic_limit(
 	runLogicTest(t, "limit")
 }
 
+func TestTenantLogic_listen_notify(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "listen_notify")
+}
+
 func TestTenantLogic_lock_timeout(
 	t *testing.T,
 ) {
@@ -1187,6 +1236,13 @@ func TestTenantLogic_materialized_view(
 	runLogicTest(t, "materialized_view")
 }
 
+func TestTenantLogic_merge(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "merge")
+}
+
 func TestTenantLogic_merge_join(
 	t *testing.T,
 ) {
@@ -1488,6 +1544,20 @@ func TestTenantLogic_propagate_input_ordering(
 	runLogicTest(t, "propagate_input_ordering")
 }
 
+func TestTenantLogic_publication(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "publication")
+}
+
+func TestTenantLogic_range_types(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "range_types")
+}
+
 func TestTenantLogic_reassign_owned_by(
 	t *testing.T,
 ) {
@@ -2132,6 +2202,13 @@ func TestTenantLogic_timetz(
 	runLogicTest(t, "timetz")
 }
 
+func TestTenantLogic_triggers(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "triggers")
+}
+
 func TestTenantLogic_trigram_builtins(
 	t *testing.T,
 ) {
@@ -2349,6 +2426,13 @@ func TestTenantLogic_udf_regressions(
 	runLogicTest(t, "udf_regressions")
 }
 
+func TestTenantLogic_udf_returns_table_variadic(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "udf_returns_table_variadic")
+}
+
 func TestTenantLogic_udf_rewrite(
 	t *testing.T,
 ) {
@@ -2363,6 +2447,13 @@ func TestTenantLogic_udf_schema_change(
 	runLogicTest(t, "udf_schema_change")
 }
 
+func TestTenantLogic_udf_security_definer(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "udf_security_definer")
+}
+
 func TestTenantLogic_udf_setof(
 	t *testing.T,
 ) {
@@ -2685,6 +2776,20 @@ func TestTenantLogicCCL_plpgsql_cursor(
 	runCCLLogicTest(t, "plpgsql_cursor")
 }
 
+func TestTenantLogicCCL_plpgsql_do(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runCCLLogicTest(t, "plpgsql_do")
+}
+
+func TestTenantLogicCCL_plpgsql_for_loop(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runCCLLogicTest(t, "plpgsql_for_loop")
+}
+
 func TestTenantLogicCCL_plpgsql_into(
 	t *testing.T,
 ) {
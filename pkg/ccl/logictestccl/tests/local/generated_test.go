@@ -169,6 +169,13 @@ func TestCCLLogic_new_schema_changer(
 	runCCLLogicTest(t, "new_schema_changer")
 }
 
+func TestCCLLogic_partition_maintenance(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runCCLLogicTest(t, "partition_maintenance")
+}
+
 func TestCCLLogic_partitioning(
 	t *testing.T,
 ) {
@@ -239,6 +246,20 @@ func TestCCLLogic_plpgsql_cursor(
 	runCCLLogicTest(t, "plpgsql_cursor")
 }
 
+func TestCCLLogic_plpgsql_do(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runCCLLogicTest(t, "plpgsql_do")
+}
+
+func TestCCLLogic_plpgsql_for_loop(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runCCLLogicTest(t, "plpgsql_for_loop")
+}
+
 func TestCCLLogic_plpgsql_into(
 	t *testing.T,
 ) {
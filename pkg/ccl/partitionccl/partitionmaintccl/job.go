@@ -0,0 +1,257 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package partitionmaintccl
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/utilccl"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/keyside"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/duration"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+)
+
+// partitionMaintenanceResumer implements the partition maintenance job. The
+// job deletes the data of a table which has expired according to its
+// partition_retention, and then repartitions the table so that its partitions
+// cover the retained data and the next partition_lookahead intervals.
+type partitionMaintenanceResumer struct {
+	job *jobs.Job
+	st  *cluster.Settings
+}
+
+var _ jobs.Resumer = (*partitionMaintenanceResumer)(nil)
+
+// Resume implements the jobs.Resumer interface.
+func (r partitionMaintenanceResumer) Resume(ctx context.Context, execCtx interface{}) error {
+	jobExecCtx := execCtx.(sql.JobExecContext)
+	execCfg := jobExecCtx.ExecCfg()
+	details := r.job.Details().(jobspb.PartitionMaintenanceDetails)
+
+	if err := utilccl.CheckEnterpriseEnabled(execCfg.Settings, "automatic partitioning"); err != nil {
+		return err
+	}
+
+	var stmt string
+	var expiredSpans roachpb.Spans
+	if err := execCfg.InternalDB.DescsTxn(ctx, func(ctx context.Context, txn descs.Txn) error {
+		stmt, expiredSpans = "", nil
+		desc, err := txn.Descriptors().ByIDWithLeased(txn.KV()).WithoutNonPublic().Get().Table(ctx, details.TableID)
+		if err != nil {
+			return err
+		}
+		if !desc.HasPartitionMaintenance() {
+			return nil
+		}
+		tn, err := descs.GetObjectName(ctx, txn.KV(), txn.Descriptors(), desc)
+		if err != nil {
+			return err
+		}
+		stmt, expiredSpans, err = planMaintenance(execCfg.Codec, desc, tn, details.Now)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	if len(expiredSpans) > 0 {
+		if err := deleteExpiredSpans(ctx, execCfg.DB, expiredSpans); err != nil {
+			return errors.Wrap(err, "deleting expired partitions")
+		}
+	}
+	if stmt == "" {
+		return nil
+	}
+	log.Infof(ctx, "partition maintenance of table %d: %s", details.TableID, stmt)
+	_, err := execCfg.InternalDB.Executor().ExecEx(
+		ctx, "partition-maintenance", nil /* txn */, sessiondata.NodeUserSessionDataOverride, stmt,
+	)
+	return err
+}
+
+// deleteExpiredSpans deletes the data in the given spans with MVCC range
+// tombstones. This is much cheaper than deleting the expired rows one by one,
+// since it writes a single tombstone per span (and range) regardless of the
+// number of rows, and the data is then removed by GC once the table's GC TTL
+// has elapsed. The deletion is not transactional, so rows written to an expired
+// span after the tombstone are deleted by the next run of the job. The range
+// tombstones are idempotent, so the job can be retried safely.
+func deleteExpiredSpans(ctx context.Context, db *kv.DB, spans roachpb.Spans) error {
+	b := &kv.Batch{}
+	for _, sp := range spans {
+		log.VEventf(ctx, 2, "delete range %s", sp)
+		b.AddRawRequest(&kvpb.DeleteRangeRequest{
+			RequestHeader: kvpb.RequestHeader{
+				Key:    sp.Key,
+				EndKey: sp.EndKey,
+			},
+			UseRangeTombstone:   true,
+			IdempotentTombstone: true,
+		})
+	}
+	return db.Run(ctx, b)
+}
+
+// planMaintenance returns the statement which repartitions the given table as
+// of time now, or an empty string if its partitions are up to date, and the
+// spans which contain its expired data. Every index of a table with a retention
+// is prefixed by the partitioning column, so the expired data of each index is
+// the span of its index keys below the cutoff.
+func planMaintenance(
+	codec keys.SQLCodec, desc catalog.TableDescriptor, tn tree.ObjectName, now time.Time,
+) (string, roachpb.Spans, error) {
+	col, err := tabledesc.ValidatePartitionMaintenanceTable(desc)
+	if err != nil {
+		return "", nil, err
+	}
+	pm := desc.GetPartitionMaintenance()
+	interval, err := tabledesc.ParsePartitionInterval("partition_interval", pm.Interval)
+	if err != nil {
+		return "", nil, err
+	}
+	var retention *duration.Duration
+	if pm.Retention != "" {
+		d, err := tabledesc.ParsePartitionInterval("partition_retention", pm.Retention)
+		if err != nil {
+			return "", nil, err
+		}
+		retention = &d
+	}
+
+	partitioning := desc.GetPrimaryIndex().GetPartitioning()
+	if partitioning.NumLists() > 0 {
+		return "", nil, errors.Newf(
+			"automatic partitioning cannot be applied to table %s which is partitioned by LIST",
+			tn.FQString(),
+		)
+	}
+	var existing []string
+	if err := partitioning.ForEachRange(func(name string, _, _ []byte) error {
+		existing = append(existing, name)
+		return nil
+	}); err != nil {
+		return "", nil, err
+	}
+	parts, cutoff, err := planPartitions(now, interval, retention, pm.LookaheadOrDefault(), existing)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var spans roachpb.Spans
+	if !cutoff.IsZero() {
+		cutoffDatum, err := makeBoundDatum(col.GetType(), cutoff)
+		if err != nil {
+			return "", nil, err
+		}
+		for _, idx := range desc.ActiveIndexes() {
+			if !idx.Public() {
+				continue
+			}
+			prefix := rowenc.MakeIndexKeyPrefix(codec, desc.GetID(), idx.GetID())
+			end, err := keyside.Encode(append([]byte(nil), prefix...), cutoffDatum, encoding.Ascending)
+			if err != nil {
+				return "", nil, err
+			}
+			spans = append(spans, roachpb.Span{Key: prefix, EndKey: end})
+		}
+	}
+
+	upToDate := len(parts) == len(existing)
+	for i := 0; upToDate && i < len(parts); i++ {
+		upToDate = parts[i].name == existing[i]
+	}
+	if upToDate {
+		return "", spans, nil
+	}
+	ranges := make([]tree.RangePartition, len(parts))
+	for i, p := range parts {
+		from, err := makeBoundDatum(col.GetType(), p.from)
+		if err != nil {
+			return "", nil, err
+		}
+		to, err := makeBoundDatum(col.GetType(), p.to)
+		if err != nil {
+			return "", nil, err
+		}
+		ranges[i] = tree.RangePartition{
+			Name: tree.Name(p.name),
+			From: tree.Exprs{from},
+			To:   tree.Exprs{to},
+		}
+	}
+	tableName := tree.MakeTableNameWithSchema(
+		tree.Name(tn.Catalog()), tree.Name(tn.Schema()), tree.Name(tn.Object()),
+	)
+	alter := &tree.AlterTable{
+		Table: tableName.ToUnresolvedObjectName(),
+		Cmds: tree.AlterTableCmds{
+			&tree.AlterTablePartitionByTable{
+				PartitionByTable: &tree.PartitionByTable{
+					PartitionBy: &tree.PartitionBy{
+						Fields: tree.NameList{tree.Name(col.GetName())},
+						Range:  ranges,
+					},
+				},
+			},
+		},
+	}
+	return tree.AsStringWithFlags(alter, tree.FmtParsable), spans, nil
+}
+
+// makeBoundDatum returns a datum of the given type for a partition bound.
+func makeBoundDatum(typ *types.T, t time.Time) (tree.Datum, error) {
+	switch typ.Family() {
+	case types.TimestampTZFamily:
+		return tree.MakeDTimestampTZ(t, time.Microsecond)
+	case types.TimestampFamily:
+		return tree.MakeDTimestamp(t, time.Microsecond)
+	case types.DateFamily:
+		return tree.NewDDateFromTime(t)
+	default:
+		return nil, errors.AssertionFailedf("unexpected partition column type %s", typ.SQLString())
+	}
+}
+
+// OnFailOrCancel implements the jobs.Resumer interface.
+func (r partitionMaintenanceResumer) OnFailOrCancel(
+	ctx context.Context, execCtx interface{}, _ error,
+) error {
+	return nil
+}
+
+// CollectProfile implements the jobs.Resumer interface.
+func (r partitionMaintenanceResumer) CollectProfile(_ context.Context, _ interface{}) error {
+	return nil
+}
+
+func init() {
+	jobs.RegisterConstructor(jobspb.TypePartitionMaintenance, func(job *jobs.Job, settings *cluster.Settings) jobs.Resumer {
+		return &partitionMaintenanceResumer{
+			job: job,
+			st:  settings,
+		}
+	}, jobs.UsesTenantCostControl)
+}
@@ -0,0 +1,144 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package partitionmaintccl
+
+import (
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/util/duration"
+	"github.com/cockroachdb/errors"
+)
+
+// maxPartitions is the maximum number of partitions that partition maintenance
+// creates for a single table.
+const maxPartitions = 10000
+
+// The layouts of the names of the partitions, which encode the inclusive lower
+// bound of each partition. The layout depends on the unit of the partition
+// interval.
+const (
+	monthNameLayout = "p2006_01"
+	dayNameLayout   = "p2006_01_02"
+	timeNameLayout  = "p2006_01_02_1504"
+)
+
+// partition is a RANGE partition of an automatically partitioned table, which
+// contains the rows with values in [from, to).
+type partition struct {
+	name     string
+	from, to time.Time
+}
+
+// nameLayout returns the layout of the names of the partitions with the given
+// interval.
+func nameLayout(interval duration.Duration) string {
+	switch {
+	case interval.Months != 0:
+		return monthNameLayout
+	case interval.Days != 0:
+		return dayNameLayout
+	default:
+		return timeNameLayout
+	}
+}
+
+// parsePartitionName returns the lower bound of the partition with the given
+// name, or false if the name was not generated by partition maintenance.
+func parsePartitionName(name string) (time.Time, bool) {
+	for _, layout := range []string{timeNameLayout, dayNameLayout, monthNameLayout} {
+		if t, err := time.Parse(layout, name); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// floorMultiple returns the largest multiple of m that is less than or equal
+// to x. m must be positive.
+func floorMultiple(x, m int64) int64 {
+	q := x / m
+	if x%m < 0 {
+		q--
+	}
+	return q * m
+}
+
+// alignTime returns the lower bound of the partition containing t. Partition
+// bounds are multiples of the interval since the Unix epoch, in UTC, so that
+// they do not depend on when partitions are created.
+func alignTime(t time.Time, interval duration.Duration) time.Time {
+	t = t.UTC()
+	switch {
+	case interval.Months != 0:
+		months := int64(t.Year()-1970)*12 + int64(t.Month()-time.January)
+		months = floorMultiple(months, interval.Months)
+		return time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC).AddDate(0, int(months), 0)
+	case interval.Days != 0:
+		const secsPerDay = 24 * 60 * 60
+		days := floorMultiple(t.Unix(), secsPerDay) / secsPerDay
+		days = floorMultiple(days, interval.Days)
+		return time.Unix(days*secsPerDay, 0).UTC()
+	default:
+		return time.Unix(0, floorMultiple(t.UnixNano(), interval.Nanos())).UTC()
+	}
+}
+
+// planPartitions returns the partitions which a table with the given
+// automatic partitioning config and existing partitions should have at time
+// now, ordered by their bounds. The partitions start at the partition
+// containing the retention cutoff if there is a retention, and at the earliest
+// existing partition otherwise. They end lookahead partitions after the one
+// containing now.
+//
+// If there is a retention, planPartitions also returns the cutoff before which
+// data has expired, which is the lower bound of the first partition.
+// Otherwise, the returned cutoff is zero.
+func planPartitions(
+	now time.Time,
+	interval duration.Duration,
+	retention *duration.Duration,
+	lookahead int64,
+	existing []string,
+) (_ []partition, cutoff time.Time, _ error) {
+	current := alignTime(now, interval)
+	start := current
+	for _, name := range existing {
+		from, ok := parsePartitionName(name)
+		if !ok {
+			return nil, time.Time{}, errors.Newf(
+				"partition %q was not created by automatic partitioning", name,
+			)
+		}
+		if from.Before(start) {
+			start = alignTime(from, interval)
+		}
+	}
+	if retention != nil {
+		cutoff = alignTime(duration.Add(now, retention.Mul(-1)), interval)
+		start = cutoff
+	}
+	end := current
+	for i := int64(0); i <= lookahead; i++ {
+		end = duration.Add(end, interval)
+	}
+
+	layout := nameLayout(interval)
+	var parts []partition
+	for from := start; from.Before(end); {
+		if len(parts) >= maxPartitions {
+			return nil, time.Time{}, errors.Newf(
+				"automatic partitioning would create more than %d partitions", maxPartitions,
+			)
+		}
+		to := duration.Add(from, interval)
+		parts = append(parts, partition{name: from.Format(layout), from: from, to: to})
+		from = to
+	}
+	return parts, cutoff, nil
+}
@@ -0,0 +1,115 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package partitionmaintccl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/util/duration"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanPartitions(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	now := time.Date(2024, time.March, 15, 10, 30, 0, 0, time.UTC)
+	month := duration.MakeDuration(0, 0, 1)
+	year := duration.MakeDuration(0, 0, 12)
+	week := duration.MakeDuration(0, 7, 0)
+	sixHours := duration.MakeDuration(int64(6*time.Hour), 0, 0)
+
+	testCases := []struct {
+		name       string
+		interval   duration.Duration
+		retention  *duration.Duration
+		lookahead  int64
+		existing   []string
+		expected   []string
+		cutoff     time.Time
+		errPattern string
+	}{
+		{
+			name:      "months",
+			interval:  month,
+			lookahead: 2,
+			expected:  []string{"p2024_03", "p2024_04", "p2024_05"},
+		},
+		{
+			name:      "months keeps existing",
+			interval:  month,
+			lookahead: 1,
+			existing:  []string{"p2023_12", "p2024_01"},
+			expected:  []string{"p2023_12", "p2024_01", "p2024_02", "p2024_03", "p2024_04"},
+		},
+		{
+			name:      "months with retention",
+			interval:  month,
+			retention: &year,
+			lookahead: 1,
+			existing:  []string{"p2023_01", "p2023_02", "p2023_03", "p2023_04"},
+			expected: []string{
+				"p2023_03", "p2023_04", "p2023_05", "p2023_06", "p2023_07", "p2023_08", "p2023_09",
+				"p2023_10", "p2023_11", "p2023_12", "p2024_01", "p2024_02", "p2024_03", "p2024_04",
+			},
+			cutoff: time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "weeks are aligned to the epoch",
+			interval:  week,
+			lookahead: 1,
+			expected:  []string{"p2024_03_14", "p2024_03_21"},
+		},
+		{
+			name:      "hours",
+			interval:  sixHours,
+			lookahead: 1,
+			expected:  []string{"p2024_03_15_0600", "p2024_03_15_1200"},
+		},
+		{
+			name:       "unmanaged partition",
+			interval:   month,
+			lookahead:  1,
+			existing:   []string{"old"},
+			errPattern: `partition "old" was not created by automatic partitioning`,
+		},
+		{
+			name:       "too many partitions",
+			interval:   sixHours,
+			lookahead:  1,
+			existing:   []string{"p2000_01"},
+			errPattern: `would create more than 10000 partitions`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			parts, cutoff, err := planPartitions(now, tc.interval, tc.retention, tc.lookahead, tc.existing)
+			if tc.errPattern != "" {
+				require.Error(t, err)
+				require.Regexp(t, tc.errPattern, err.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.cutoff, cutoff)
+			names := make([]string, len(parts))
+			for i, p := range parts {
+				names[i] = p.name
+				require.Equal(t, alignTime(p.from, tc.interval), p.from)
+				if i > 0 {
+					require.Equal(t, parts[i-1].to, p.from)
+				}
+			}
+			require.Equal(t, tc.expected, names)
+		})
+	}
+}
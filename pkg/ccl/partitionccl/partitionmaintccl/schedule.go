@@ -0,0 +1,238 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package partitionmaintccl
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/scheduledjobs"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/util/metric"
+	"github.com/cockroachdb/errors"
+	pbtypes "github.com/gogo/protobuf/types"
+)
+
+type partitionMaintenanceExecutor struct {
+	metrics partitionMaintenanceMetrics
+}
+
+var _ jobs.ScheduledJobController = (*partitionMaintenanceExecutor)(nil)
+
+type partitionMaintenanceMetrics struct {
+	*jobs.ExecutorMetrics
+}
+
+var _ metric.Struct = &partitionMaintenanceMetrics{}
+
+// MetricStruct implements metric.Struct interface.
+func (m *partitionMaintenanceMetrics) MetricStruct() {}
+
+// OnDrop implements the jobs.ScheduledJobController interface.
+func (s partitionMaintenanceExecutor) OnDrop(
+	ctx context.Context,
+	scheduleControllerEnv scheduledjobs.ScheduleControllerEnv,
+	env scheduledjobs.JobSchedulerEnv,
+	schedule *jobs.ScheduledJob,
+	txn isql.Txn,
+	descsCol *descs.Collection,
+) (int, error) {
+	var args catpb.ScheduledPartitionMaintenanceArgs
+	if err := pbtypes.UnmarshalAny(schedule.ExecutionArgs().Args, &args); err != nil {
+		return 0, err
+	}
+
+	desc, err := getScheduledTable(ctx, txn.KV(), descsCol, schedule, args)
+	if err != nil {
+		return 0, err
+	}
+	if desc != nil {
+		tn, err := descs.GetObjectName(ctx, txn.KV(), descsCol, desc)
+		if err != nil {
+			return 0, err
+		}
+		return 0, errors.WithHintf(
+			pgerror.Newf(
+				pgcode.InvalidTableDefinition,
+				"cannot drop a partition maintenance schedule",
+			),
+			`use ALTER TABLE %s RESET (partition_interval) instead`,
+			tn.FQString(),
+		)
+	}
+	return 0, nil
+}
+
+// getScheduledTable returns the table maintained by the given schedule, or nil
+// if the schedule is no longer valid because the table was dropped, its
+// automatic partitioning was removed, or it has a different schedule.
+func getScheduledTable(
+	ctx context.Context,
+	txn *kv.Txn,
+	descsCol *descs.Collection,
+	schedule *jobs.ScheduledJob,
+	args catpb.ScheduledPartitionMaintenanceArgs,
+) (catalog.TableDescriptor, error) {
+	desc, err := descsCol.ByIDWithLeased(txn).WithoutNonPublic().Get().Table(ctx, args.TableID)
+	if err != nil {
+		if sqlerrors.IsUndefinedRelationError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if desc == nil || !desc.HasPartitionMaintenance() ||
+		desc.GetPartitionMaintenance().ScheduleID != schedule.ScheduleID() {
+		return nil, nil
+	}
+	return desc, nil
+}
+
+// ExecuteJob implements the jobs.ScheduledJobController interface.
+func (s partitionMaintenanceExecutor) ExecuteJob(
+	ctx context.Context,
+	txn isql.Txn,
+	cfg *scheduledjobs.JobExecutionConfig,
+	env scheduledjobs.JobSchedulerEnv,
+	sj *jobs.ScheduledJob,
+) error {
+	args := &catpb.ScheduledPartitionMaintenanceArgs{}
+	if err := pbtypes.UnmarshalAny(sj.ExecutionArgs().Args, args); err != nil {
+		return err
+	}
+
+	p, cleanup := cfg.PlanHookMaker(
+		ctx,
+		fmt.Sprintf("invoke-partition-maintenance-%d", args.TableID),
+		txn.KV(),
+		username.NodeUserName(),
+	)
+	defer cleanup()
+	execCfg := p.(sql.PlanHookState).ExecCfg()
+
+	descsCol := descs.FromTxn(txn)
+	desc, err := getScheduledTable(ctx, txn.KV(), descsCol, sj, *args)
+	if err != nil {
+		s.metrics.NumFailed.Inc(1)
+		return err
+	}
+	if desc == nil {
+		// The schedule was left behind by a table which no longer uses it, so
+		// remove it rather than running it forever.
+		return jobs.ScheduledJobTxn(txn).Delete(ctx, sj)
+	}
+	tn, err := descs.GetObjectName(ctx, txn.KV(), descsCol, desc)
+	if err != nil {
+		s.metrics.NumFailed.Inc(1)
+		return err
+	}
+	record := jobs.Record{
+		Description: fmt.Sprintf("partition maintenance for %s", tn.FQString()),
+		Username:    username.NodeUserName(),
+		Details: jobspb.PartitionMaintenanceDetails{
+			TableID: args.TableID,
+			Now:     env.Now(),
+		},
+		Progress: jobspb.PartitionMaintenanceProgress{},
+		CreatedBy: &jobs.CreatedByInfo{
+			ID:   int64(sj.ScheduleID()),
+			Name: jobs.CreatedByScheduledJobs,
+		},
+	}
+	jobID := execCfg.JobRegistry.MakeJobID()
+	if _, err := execCfg.JobRegistry.CreateAdoptableJobWithTxn(ctx, record, jobID, txn); err != nil {
+		s.metrics.NumFailed.Inc(1)
+		return err
+	}
+	s.metrics.NumStarted.Inc(1)
+	return nil
+}
+
+// NotifyJobTermination implements the jobs.ScheduledJobController interface.
+func (s partitionMaintenanceExecutor) NotifyJobTermination(
+	ctx context.Context,
+	txn isql.Txn,
+	jobID jobspb.JobID,
+	jobStatus jobs.Status,
+	details jobspb.Details,
+	env scheduledjobs.JobSchedulerEnv,
+	sj *jobs.ScheduledJob,
+) error {
+	if jobStatus == jobs.StatusFailed {
+		jobs.DefaultHandleFailedRun(
+			sj,
+			"partition maintenance job %d for schedule %q failed",
+			jobID, sj.ScheduleLabel(),
+		)
+		s.metrics.NumFailed.Inc(1)
+		return nil
+	}
+
+	if jobStatus == jobs.StatusSucceeded {
+		s.metrics.NumSucceeded.Inc(1)
+	}
+
+	sj.SetScheduleStatus(string(jobStatus))
+	return nil
+}
+
+// Metrics implements the jobs.ScheduledJobController interface.
+func (s partitionMaintenanceExecutor) Metrics() metric.Struct {
+	return &s.metrics
+}
+
+// GetCreateScheduleStatement implements the jobs.ScheduledJobController interface.
+func (s partitionMaintenanceExecutor) GetCreateScheduleStatement(
+	ctx context.Context, txn isql.Txn, env scheduledjobs.JobSchedulerEnv, sj *jobs.ScheduledJob,
+) (string, error) {
+	descsCol := descs.FromTxn(txn)
+	args := &catpb.ScheduledPartitionMaintenanceArgs{}
+	if err := pbtypes.UnmarshalAny(sj.ExecutionArgs().Args, args); err != nil {
+		return "", err
+	}
+	tbl, err := descsCol.ByIDWithLeased(txn.KV()).WithoutNonPublic().Get().Table(ctx, args.TableID)
+	if err != nil {
+		return "", err
+	}
+	tn, err := descs.GetObjectName(ctx, txn.KV(), descsCol, tbl)
+	if err != nil {
+		return "", err
+	}
+	interval := "..."
+	if pm := tbl.GetPartitionMaintenance(); pm != nil {
+		interval = lexbase.EscapeSQLString(pm.Interval)
+	}
+	return fmt.Sprintf(`ALTER TABLE %s SET (partition_interval = %s, ...)`, tn.FQString(), interval), nil
+}
+
+func init() {
+	jobs.RegisterScheduledJobExecutorFactory(
+		tree.ScheduledPartitionMaintenanceExecutor.InternalName(),
+		func() (jobs.ScheduledJobExecutor, error) {
+			m := jobs.MakeExecutorMetrics(tree.ScheduledPartitionMaintenanceExecutor.InternalName())
+			return &partitionMaintenanceExecutor{
+				metrics: partitionMaintenanceMetrics{
+					ExecutorMetrics: &m,
+				},
+			}, nil
+		},
+	)
+}
@@ -88,6 +88,12 @@ func (e *externalConnectionNotFoundError) Error() string {
 	return fmt.Sprintf("external connection with name %s does not exist", e.connectionName)
 }
 
+// IsExternalConnectionNotFoundError returns true if the error was returned
+// because an External Connection does not exist.
+func IsExternalConnectionNotFoundError(err error) bool {
+	return errors.HasType(err, (*externalConnectionNotFoundError)(nil))
+}
+
 // LoadExternalConnection loads an external connection record from the
 // `system.external_connections` table and returns the read-only interface for
 // interacting with it.
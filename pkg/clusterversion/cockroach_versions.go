@@ -268,6 +268,45 @@ const (
 	// minimum timestamp field.
 	V24_2_LeaseMinTimestamp
 
+	// V24_2_NotificationsTable is the migration that creates the
+	// system.notifications table used by LISTEN and NOTIFY.
+	V24_2_NotificationsTable
+
+	// V24_2_LogicalReplicationTables is the migration that creates the
+	// system.publications and system.replication_slots tables used by the
+	// PostgreSQL logical replication protocol.
+	V24_2_LogicalReplicationTables
+
+	// V24_2_RoutineSecurityAndConfig is the version after which routines can
+	// be defined with SECURITY DEFINER and with SET clauses.
+	V24_2_RoutineSecurityAndConfig
+
+	// V24_2_KVScanPushdown is the version after which the KV layer can
+	// evaluate the filters and the aggregations pushed down by SQL into the
+	// IndexFetchSpec of the direct columnar scans.
+	V24_2_KVScanPushdown
+
+	// V24_2_MultiDimArrays is the version after which multi-dimensional arrays
+	// and arrays with explicit lower bounds can be stored, using the array
+	// value encoding that records their dimensions and lower bounds.
+	V24_2_MultiDimArrays
+
+	// V24_2_WitnessReplicas is the version after which ranges can have witness
+	// replicas, i.e. voters that don't store user data. Before it is active,
+	// the allocator doesn't add witnesses and num_witnesses can't be set.
+	V24_2_WitnessReplicas
+
+	// V24_2_RangeTypes is the version after which columns can use the range
+	// types and tables can have EXCLUDE constraints. Older binaries can't
+	// decode range datums nor enforce exclusion constraints.
+	V24_2_RangeTypes
+
+	// V24_2_Triggers is the version after which tables can have triggers.
+	// Older binaries don't know about the trigger fields of the table and
+	// function descriptors nor about the Trigger elements of the declarative
+	// schema changer.
+	V24_2_Triggers
+
 	// *************************************************
 	// Step (1) Add new versions above this comment.
 	// Do not add new versions to a patch release.
@@ -332,6 +371,14 @@ var versionTable = [numKeys]roachpb.Version{
 	V24_2_TenantRates:                 {Major: 24, Minor: 1, Internal: 8},
 	V24_2_DeleteTenantSettingsVersion: {Major: 24, Minor: 1, Internal: 10},
 	V24_2_LeaseMinTimestamp:           {Major: 24, Minor: 1, Internal: 12},
+	V24_2_NotificationsTable:          {Major: 24, Minor: 1, Internal: 14},
+	V24_2_LogicalReplicationTables:    {Major: 24, Minor: 1, Internal: 16},
+	V24_2_RoutineSecurityAndConfig:    {Major: 24, Minor: 1, Internal: 18},
+	V24_2_KVScanPushdown:              {Major: 24, Minor: 1, Internal: 20},
+	V24_2_MultiDimArrays:              {Major: 24, Minor: 1, Internal: 22},
+	V24_2_WitnessReplicas:             {Major: 24, Minor: 1, Internal: 24},
+	V24_2_RangeTypes:                  {Major: 24, Minor: 1, Internal: 26},
+	V24_2_Triggers:                    {Major: 24, Minor: 1, Internal: 28},
 
 	// *************************************************
 	// Step (2): Add new versions above this comment.
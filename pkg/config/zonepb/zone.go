@@ -397,6 +397,21 @@ func (z *ZoneConfig) Validate() error {
 		}
 	}
 
+	if z.NumWitnesses != nil {
+		if *z.NumWitnesses < 0 {
+			return fmt.Errorf("num_witnesses cannot be negative")
+		}
+		// Witnesses must be a strict minority of the voters, so that every
+		// quorum contains at least one replica holding the data.
+		numVoters := z.NumVoters
+		if numVoters == nil || *numVoters == 0 {
+			numVoters = z.NumReplicas
+		}
+		if numVoters != nil && *numVoters > 0 && 2*(*z.NumWitnesses) >= *numVoters {
+			return fmt.Errorf("num_witnesses must be less than half of the voting replicas (%d)", *numVoters)
+		}
+	}
+
 	if z.RangeMaxBytes != nil && *z.RangeMaxBytes < minRangeMaxBytes {
 		return fmt.Errorf("RangeMaxBytes %d less than minimum allowed %d",
 			*z.RangeMaxBytes, minRangeMaxBytes)
@@ -563,6 +578,11 @@ func (z *ZoneConfig) InheritFromParent(parent *ZoneConfig) {
 			z.NumVoters = proto.Int32(*parent.NumVoters)
 		}
 	}
+	if z.NumWitnesses == nil {
+		if parent.NumWitnesses != nil {
+			z.NumWitnesses = proto.Int32(*parent.NumWitnesses)
+		}
+	}
 	if z.GlobalReads == nil {
 		if parent.GlobalReads != nil {
 			z.GlobalReads = proto.Bool(*parent.GlobalReads)
@@ -612,6 +632,11 @@ func (z *ZoneConfig) CopyFromZone(other ZoneConfig, fieldList []tree.Name) {
 			if other.NumVoters != nil {
 				z.NumVoters = proto.Int32(*other.NumVoters)
 			}
+		case "num_witnesses":
+			z.NumWitnesses = nil
+			if other.NumWitnesses != nil {
+				z.NumWitnesses = proto.Int32(*other.NumWitnesses)
+			}
 		case "range_min_bytes":
 			z.RangeMinBytes = nil
 			if other.RangeMinBytes != nil {
@@ -1250,6 +1275,9 @@ func (z *ZoneConfig) toSpanConfig() (roachpb.SpanConfig, error) {
 	if z.NumVoters != nil {
 		sc.NumVoters = *z.NumVoters
 	}
+	if z.NumWitnesses != nil {
+		sc.NumWitnesses = *z.NumWitnesses
+	}
 
 	toSpanConfigConstraints := func(src []Constraint) ([]roachpb.Constraint, error) {
 		spanConfigConstraints := make([]roachpb.Constraint, len(src))
@@ -285,6 +285,51 @@ func TestZoneConfigValidateNonVoterSpecific(t *testing.T) {
 	}
 }
 
+func TestZoneConfigValidateNumWitnesses(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	testCases := []struct {
+		cfg      ZoneConfig
+		expected string
+	}{
+		{
+			cfg:      ZoneConfig{NumReplicas: proto.Int32(3), NumWitnesses: proto.Int32(1)},
+			expected: "",
+		},
+		{
+			cfg:      ZoneConfig{NumReplicas: proto.Int32(3), NumWitnesses: proto.Int32(0)},
+			expected: "",
+		},
+		{
+			cfg:      ZoneConfig{NumReplicas: proto.Int32(3), NumWitnesses: proto.Int32(-1)},
+			expected: "num_witnesses cannot be negative",
+		},
+		{
+			cfg:      ZoneConfig{NumReplicas: proto.Int32(4), NumWitnesses: proto.Int32(2)},
+			expected: `num_witnesses must be less than half of the voting replicas \(4\)`,
+		},
+		{
+			cfg: ZoneConfig{
+				NumReplicas: proto.Int32(7), NumVoters: proto.Int32(5), NumWitnesses: proto.Int32(2),
+			},
+			expected: "",
+		},
+		{
+			cfg: ZoneConfig{
+				NumReplicas: proto.Int32(7), NumVoters: proto.Int32(3), NumWitnesses: proto.Int32(2),
+			},
+			expected: `num_witnesses must be less than half of the voting replicas \(3\)`,
+		},
+	}
+
+	for i, c := range testCases {
+		err := c.cfg.Validate()
+		if !testutils.IsError(err, c.expected) {
+			t.Errorf("%d: expected %q, got %v", i, c.expected, err)
+		}
+	}
+}
+
 func TestZoneConfigValidateTandemFields(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
@@ -1234,6 +1279,27 @@ func TestZoneConfigToSpanConfigConversion(t *testing.T) {
 				NumReplicas: 3,
 			},
 		},
+		{
+			// Test NumWitnesses set.
+			zoneConfig: ZoneConfig{
+				RangeMinBytes: proto.Int64(100000),
+				RangeMaxBytes: proto.Int64(200000),
+				NumReplicas:   proto.Int32(5),
+				NumWitnesses:  proto.Int32(2),
+				GC: &GCPolicy{
+					TTLSeconds: 2400,
+				},
+			},
+			expectSpanConfig: roachpb.SpanConfig{
+				RangeMinBytes: 100000,
+				RangeMaxBytes: 200000,
+				GCPolicy: roachpb.GCPolicy{
+					TTLSeconds: 2400,
+				},
+				NumReplicas:  5,
+				NumWitnesses: 2,
+			},
+		},
 		{
 			// Test GlobalReads set to false (explicitly).
 			zoneConfig: ZoneConfig{
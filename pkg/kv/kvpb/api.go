@@ -1007,6 +1007,9 @@ func (*BarrierRequest) Method() Method { return Barrier }
 // Method implements the Request interface.
 func (*IsSpanEmptyRequest) Method() Method { return IsSpanEmpty }
 
+// Method implements the Request interface.
+func (*ReadIndexRequest) Method() Method { return ReadIndex }
+
 // ShallowCopy implements the Request interface.
 func (gr *GetRequest) ShallowCopy() Request {
 	shallowCopy := *gr
@@ -1301,6 +1304,12 @@ func (r *IsSpanEmptyRequest) ShallowCopy() Request {
 	return &shallowCopy
 }
 
+// ShallowCopy implements the Request interface.
+func (r *ReadIndexRequest) ShallowCopy() Request {
+	shallowCopy := *r
+	return &shallowCopy
+}
+
 // ShallowCopy implements the Response interface.
 func (gr *GetResponse) ShallowCopy() Response {
 	shallowCopy := *gr
@@ -1593,6 +1602,12 @@ func (r *IsSpanEmptyResponse) ShallowCopy() Response {
 	return &shallowCopy
 }
 
+// ShallowCopy implements the Response interface.
+func (r *ReadIndexResponse) ShallowCopy() Response {
+	shallowCopy := *r
+	return &shallowCopy
+}
+
 // NewLockingGet returns a Request initialized to get the value at key. A lock
 // corresponding to the supplied lock strength and durability is acquired on the
 // key, if it exists.
@@ -2131,6 +2146,10 @@ func (r *BarrierRequest) flags() flag {
 	return flags
 }
 func (*IsSpanEmptyRequest) flags() flag { return isRead | isRange }
+func (*ReadIndexRequest) flags() flag {
+	// The lease applied index is only valid for a single range.
+	return isRead | isRange | isUnsplittable | updatesTSCache
+}
 
 // IsParallelCommit returns whether the EndTxn request is attempting to perform
 // a parallel commit. See txn_interceptor_committer.go for a discussion about
@@ -2274,6 +2293,18 @@ func (rc ReplicationChanges) NonVoterRemovals() []roachpb.ReplicationTarget {
 	return rc.byType(roachpb.REMOVE_NON_VOTER)
 }
 
+// WitnessAdditions returns a slice of all contained replication changes that
+// add witnesses.
+func (rc ReplicationChanges) WitnessAdditions() []roachpb.ReplicationTarget {
+	return rc.byType(roachpb.ADD_WITNESS)
+}
+
+// WitnessRemovals returns a slice of all contained replication changes that
+// remove witnesses.
+func (rc ReplicationChanges) WitnessRemovals() []roachpb.ReplicationTarget {
+	return rc.byType(roachpb.REMOVE_WITNESS)
+}
+
 // Changes returns the changes requested by this AdminChangeReplicasRequest, taking
 // the deprecated method of doing so into account.
 func (acrr *AdminChangeReplicasRequest) Changes() []ReplicationChange {
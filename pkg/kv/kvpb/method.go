@@ -176,6 +176,11 @@ const (
 	// IsSpanEmpty is a non-transaction read request used to determine whether
 	// a span contains any keys whatsoever (garbage or otherwise).
 	IsSpanEmpty
+	// ReadIndex is a read request evaluated by the leaseholder on behalf of a
+	// follower that wants to serve a linearizable read at a timestamp above
+	// its closed timestamp. It returns the lease applied index that the
+	// follower needs to catch up to before serving the read.
+	ReadIndex
 	// MaxMethod is the maximum method.
 	MaxMethod Method = iota - 1
 	// NumMethods represents the total number of API methods.
@@ -1559,7 +1559,7 @@ func (acb *analyzeConstraintsBuf) tryAddingStore(
 	storeID roachpb.StoreID, replicaType roachpb.ReplicaType, locality localityTiers,
 ) {
 	switch replicaType {
-	case roachpb.VOTER_FULL, roachpb.VOTER_INCOMING:
+	case roachpb.VOTER_FULL, roachpb.VOTER_INCOMING, roachpb.WITNESS:
 		acb.replicas[voterIndex] = append(
 			acb.replicas[voterIndex], storeAndLocality{storeID, locality})
 	case roachpb.NON_VOTER, roachpb.VOTER_DEMOTING_NON_VOTER:
@@ -142,11 +142,14 @@ const (
 	AllocatorConsiderRebalance
 	AllocatorRangeUnavailable
 	AllocatorFinalizeAtomicReplicationChange
+	AllocatorAddWitness
+	AllocatorRemoveWitness
+	AllocatorRemoveDeadWitness
 )
 
 // Add indicates an action adding a replica.
 func (a AllocatorAction) Add() bool {
-	return a == AllocatorAddVoter || a == AllocatorAddNonVoter
+	return a == AllocatorAddVoter || a == AllocatorAddNonVoter || a == AllocatorAddWitness
 }
 
 // Replace indicates an action replacing a dead or decommissioning replica.
@@ -164,10 +167,13 @@ func (a AllocatorAction) Remove() bool {
 		a == AllocatorRemoveDeadVoter ||
 		a == AllocatorRemoveDeadNonVoter ||
 		a == AllocatorRemoveDecommissioningVoter ||
-		a == AllocatorRemoveDecommissioningNonVoter
+		a == AllocatorRemoveDecommissioningNonVoter ||
+		a == AllocatorRemoveWitness ||
+		a == AllocatorRemoveDeadWitness
 }
 
-// TargetReplicaType returns that the action is for a voter or non-voter replica.
+// TargetReplicaType returns that the action is for a voter, non-voter or
+// witness replica.
 func (a AllocatorAction) TargetReplicaType() TargetReplicaType {
 	var t TargetReplicaType
 	if a == AllocatorRemoveVoter ||
@@ -184,6 +190,10 @@ func (a AllocatorAction) TargetReplicaType() TargetReplicaType {
 		a == AllocatorReplaceDecommissioningNonVoter ||
 		a == AllocatorRemoveDecommissioningNonVoter {
 		t = NonVoterTarget
+	} else if a == AllocatorAddWitness ||
+		a == AllocatorRemoveWitness ||
+		a == AllocatorRemoveDeadWitness {
+		t = WitnessTarget
 	}
 	return t
 }
@@ -195,12 +205,15 @@ func (a AllocatorAction) ReplicaStatus() ReplicaStatus {
 	if a == AllocatorRemoveVoter ||
 		a == AllocatorRemoveNonVoter ||
 		a == AllocatorAddVoter ||
-		a == AllocatorAddNonVoter {
+		a == AllocatorAddNonVoter ||
+		a == AllocatorAddWitness ||
+		a == AllocatorRemoveWitness {
 		s = Alive
 	} else if a == AllocatorReplaceDeadVoter ||
 		a == AllocatorReplaceDeadNonVoter ||
 		a == AllocatorRemoveDeadVoter ||
-		a == AllocatorRemoveDeadNonVoter {
+		a == AllocatorRemoveDeadNonVoter ||
+		a == AllocatorRemoveDeadWitness {
 		s = Dead
 	} else if a == AllocatorReplaceDecommissioningVoter ||
 		a == AllocatorReplaceDecommissioningNonVoter ||
@@ -229,6 +242,9 @@ var allocatorActionNames = map[AllocatorAction]string{
 	AllocatorConsiderRebalance:               "consider rebalance",
 	AllocatorRangeUnavailable:                "range unavailable",
 	AllocatorFinalizeAtomicReplicationChange: "finalize conf change",
+	AllocatorAddWitness:                      "add witness",
+	AllocatorRemoveWitness:                   "remove witness",
+	AllocatorRemoveDeadWitness:               "remove dead witness",
 }
 
 func (a AllocatorAction) String() string {
@@ -264,6 +280,12 @@ func (a AllocatorAction) Priority() float64 {
 		return 900
 	case AllocatorRemoveVoter:
 		return 800
+	case AllocatorAddWitness:
+		return 780
+	case AllocatorRemoveDeadWitness:
+		return 760
+	case AllocatorRemoveWitness:
+		return 740
 	case AllocatorReplaceDeadNonVoter:
 		return 700
 	case AllocatorAddNonVoter:
@@ -283,8 +305,8 @@ func (a AllocatorAction) Priority() float64 {
 	}
 }
 
-// TargetReplicaType indicates whether the target replica is a voter or
-// non-voter.
+// TargetReplicaType indicates whether the target replica is a voter, a
+// non-voter or a witness.
 type TargetReplicaType int
 
 const (
@@ -293,6 +315,9 @@ const (
 	VoterTarget
 	// NonVoterTarget represents a non-voting target replica.
 	NonVoterTarget
+	// WitnessTarget represents a voting target replica that does not store
+	// user data.
+	WitnessTarget
 )
 
 // ReplicaStatus represents whether a replica is currently alive,
@@ -320,6 +345,8 @@ func (t TargetReplicaType) AddChangeType() roachpb.ReplicaChangeType {
 		return roachpb.ADD_VOTER
 	case NonVoterTarget:
 		return roachpb.ADD_NON_VOTER
+	case WitnessTarget:
+		return roachpb.ADD_WITNESS
 	default:
 		panic(fmt.Sprintf("unknown targetReplicaType %d", t))
 	}
@@ -333,6 +360,8 @@ func (t TargetReplicaType) RemoveChangeType() roachpb.ReplicaChangeType {
 		return roachpb.REMOVE_VOTER
 	case NonVoterTarget:
 		return roachpb.REMOVE_NON_VOTER
+	case WitnessTarget:
+		return roachpb.REMOVE_WITNESS
 	default:
 		panic(fmt.Sprintf("unknown targetReplicaType %d", t))
 	}
@@ -344,6 +373,8 @@ func (t TargetReplicaType) String() string {
 		return "voter"
 	case NonVoterTarget:
 		return "non-voter"
+	case WitnessTarget:
+		return "witness"
 	default:
 		panic(fmt.Sprintf("unknown targetReplicaType %d", t))
 	}
@@ -691,6 +722,22 @@ func GetNeededVoters(zoneConfigVoterCount int32, clusterNodes int) int {
 	return need
 }
 
+// GetNeededWitnesses calculates the number of witnesses a range should have
+// given its zone config and the number of voters (witnesses included) it needs.
+// Witnesses are capped so that a strict majority of the voters always stores
+// the range's data, which matters when the number of needed voters was reduced
+// because of a lack of nodes.
+func GetNeededWitnesses(zoneConfigWitnessCount int32, neededVoters int) int {
+	need := int(zoneConfigWitnessCount)
+	if max := (neededVoters - 1) / 2; need > max {
+		need = max
+	}
+	if need < 0 {
+		need = 0 // Must be non-negative.
+	}
+	return need
+}
+
 // GetNeededNonVoters calculates the number of non-voters a range should have
 // given the number of voting replicas the range has and the number of nodes
 // available for up-replication.
@@ -951,7 +998,7 @@ func (a *Allocator) ComputeAction(
 	}
 
 	return a.computeAction(ctx, storePool, conf, desc.Replicas().VoterDescriptors(),
-		desc.Replicas().NonVoterDescriptors())
+		desc.Replicas().NonVoterDescriptors(), desc.Replicas().WitnessDescriptors())
 }
 
 func (a *Allocator) computeAction(
@@ -960,6 +1007,7 @@ func (a *Allocator) computeAction(
 	conf *roachpb.SpanConfig,
 	voterReplicas []roachpb.ReplicaDescriptor,
 	nonVoterReplicas []roachpb.ReplicaDescriptor,
+	witnessReplicas []roachpb.ReplicaDescriptor,
 ) (action AllocatorAction, adjustedPriority float64) {
 	// NB: The ordering of the checks in this method is intentional. The order in
 	// which these actions are returned by this method determines the relative
@@ -973,15 +1021,29 @@ func (a *Allocator) computeAction(
 	// first handle operations that correspond to repairing/recovering the range.
 	// After that we handle rebalancing related actions, followed by removal
 	// actions.
+	//
+	// Witnesses are voters that don't store user data. voterReplicas only holds
+	// the data-bearing voters, and the witnesses count towards the range's
+	// quorum but are otherwise handled separately, right after the repair of
+	// the data-bearing voters.
 	haveVoters := len(voterReplicas)
+	haveWitnesses := len(witnessReplicas)
 	decommissioningVoters := storePool.DecommissioningReplicas(voterReplicas)
 	postDecommissionVoters := haveVoters - len(decommissioningVoters)
 	// Node count including dead nodes but excluding
 	// decommissioning/decommissioned nodes.
 	clusterNodes := storePool.ClusterNodeCount()
-	neededVoters := GetNeededVoters(conf.GetNumVoters(), clusterNodes)
-	desiredQuorum := computeQuorum(neededVoters)
-	quorum := computeQuorum(haveVoters)
+	neededVotersAndWitnesses := GetNeededVoters(conf.GetNumVoters(), clusterNodes)
+	numWitnesses := conf.NumWitnesses
+	if !a.st.Version.IsActive(ctx, clusterversion.V24_2_WitnessReplicas) {
+		// Nodes running older versions don't know about witnesses, so none are
+		// added until the cluster is upgraded.
+		numWitnesses = 0
+	}
+	neededWitnesses := GetNeededWitnesses(numWitnesses, neededVotersAndWitnesses)
+	neededVoters := neededVotersAndWitnesses - neededWitnesses
+	desiredQuorum := computeQuorum(neededVotersAndWitnesses)
+	quorum := computeQuorum(haveVoters + haveWitnesses)
 
 	// TODO(aayush): When haveVoters < neededVoters but we don't have quorum to
 	// actually execute the addition of a new replica, we should be returning a
@@ -991,7 +1053,7 @@ func (a *Allocator) computeAction(
 		// Priority is adjusted by the difference between the current voter
 		// count and the quorum of the desired voter count.
 		action = AllocatorAddVoter
-		adjustedPriority = action.Priority() + float64(desiredQuorum-haveVoters)
+		adjustedPriority = action.Priority() + float64(desiredQuorum-haveVoters-haveWitnesses)
 		log.KvDistribution.VEventf(ctx, 3, "%s - missing voter need=%d, have=%d, priority=%.2f",
 			action, neededVoters, haveVoters, adjustedPriority)
 		return action, adjustedPriority
@@ -1005,15 +1067,17 @@ func (a *Allocator) computeAction(
 	// elsewhere (for a regular rebalance or for decommissioning).
 	const includeSuspectAndDrainingStores = true
 	liveVoters, deadVoters := storePool.LiveAndDeadReplicas(voterReplicas, includeSuspectAndDrainingStores)
+	liveWitnesses, deadWitnesses := storePool.LiveAndDeadReplicas(witnessReplicas, includeSuspectAndDrainingStores)
 
-	if len(liveVoters) < quorum {
+	if len(liveVoters)+len(liveWitnesses) < quorum {
 		// Do not take any replacement/removal action if we do not have a quorum of
 		// live voters. If we're correctly assessing the unavailable state of the
 		// range, we also won't be able to add replicas as we try above, but hope
 		// springs eternal.
 		action = AllocatorRangeUnavailable
-		log.KvDistribution.VEventf(ctx, 1, "unable to take action - live voters %v don't meet quorum of %d",
-			liveVoters, quorum)
+		log.KvDistribution.VEventf(ctx, 1,
+			"unable to take action - live voters %v and witnesses %v don't meet quorum of %d",
+			liveVoters, liveWitnesses, quorum)
 		return action, action.Priority()
 	}
 
@@ -1036,6 +1100,29 @@ func (a *Allocator) computeAction(
 		return action, action.Priority()
 	}
 
+	// Witnesses are cheap to add since they're not sent any user data, so
+	// rather than replacing dead or decommissioning witnesses in one step, we
+	// add a new witness first and remove the old one once the range is back to
+	// the desired witness count.
+	decommissioningWitnesses := storePool.DecommissioningReplicas(witnessReplicas)
+	healthyWitnesses := len(liveWitnesses)
+	for _, w := range decommissioningWitnesses {
+		for _, l := range liveWitnesses {
+			if w.StoreID == l.StoreID {
+				healthyWitnesses--
+				break
+			}
+		}
+	}
+	if healthyWitnesses < neededWitnesses {
+		action = AllocatorAddWitness
+		log.KvDistribution.VEventf(ctx, 3,
+			"%s - missing witness need=%d, have=%d, dead=%d, num_decommissioning=%d, priority=%.2f",
+			action, neededWitnesses, haveWitnesses, len(deadWitnesses), len(decommissioningWitnesses),
+			action.Priority())
+		return action, action.Priority()
+	}
+
 	// Voting replica removal actions follow.
 	// TODO(aayush): There's an additional case related to dead voters that we
 	// should handle above. If there are one or more dead replicas, have < need,
@@ -1065,17 +1152,35 @@ func (a *Allocator) computeAction(
 		// Ranges with an even number of voters get extra priority because
 		// they have a more fragile quorum.
 		action = AllocatorRemoveVoter
-		adjustedPriority = action.Priority() - float64(haveVoters%2)
+		adjustedPriority = action.Priority() - float64((haveVoters+haveWitnesses)%2)
 		log.KvDistribution.VEventf(ctx, 3, "%s - need=%d, have=%d, priority=%.2f", action, neededVoters,
 			haveVoters, adjustedPriority)
 		return action, adjustedPriority
 	}
 
+	// Witness removal actions follow.
+	if len(deadWitnesses) > 0 {
+		action = AllocatorRemoveDeadWitness
+		log.KvDistribution.VEventf(ctx, 3, "%s - dead=%d, live=%d, priority=%.2f",
+			action, len(deadWitnesses), len(liveWitnesses), action.Priority())
+		return action, action.Priority()
+	}
+
+	if haveWitnesses > neededWitnesses || len(decommissioningWitnesses) > 0 {
+		action = AllocatorRemoveWitness
+		log.KvDistribution.VEventf(ctx, 3,
+			"%s - need=%d, have=%d, num_decommissioning=%d, priority=%.2f",
+			action, neededWitnesses, haveWitnesses, len(decommissioningWitnesses), action.Priority())
+		return action, action.Priority()
+	}
+
 	// Non-voting replica actions follow.
 	//
 	// Non-voting replica addition / replacement.
 	haveNonVoters := len(nonVoterReplicas)
-	neededNonVoters := GetNeededNonVoters(haveVoters, int(conf.GetNumNonVoters()), clusterNodes)
+	neededNonVoters := GetNeededNonVoters(
+		haveVoters+haveWitnesses, int(conf.GetNumNonVoters()), clusterNodes,
+	)
 	if haveNonVoters < neededNonVoters {
 		action = AllocatorAddNonVoter
 		log.KvDistribution.VEventf(ctx, 3, "%s - missing non-voter need=%d, have=%d, priority=%.2f",
@@ -1167,7 +1272,9 @@ func getReplicasForDiversityCalc(
 		// off of all `existingReplicas`), regions A, B, and C would all be equally
 		// likely to get a new voting replica.
 		return existingVoters
-	case NonVoterTarget:
+	case NonVoterTarget, WitnessTarget:
+		// Witnesses take part in the range's quorum, so they're best placed away
+		// from every other replica of the range.
 		return allExistingReplicas
 	default:
 		panic(fmt.Sprintf("unsupported targetReplicaType: %v", t))
@@ -1216,10 +1323,11 @@ func (s *GoodCandidateSelector) selectOne(cl candidateList) *candidate {
 	return cl.selectGood(s.randGen)
 }
 
-// AllocateTarget returns a suitable store for a new allocation of a voting or
-// non-voting replica with the required attributes. Nodes already accommodating
-// voting replicas are ruled out in the voter case, and nodes accommodating
-// _any_ replicas are ruled out in the non-voter case.
+// AllocateTarget returns a suitable store for a new allocation of a voting,
+// non-voting or witness replica with the required attributes. Nodes already
+// accommodating voting replicas are ruled out in the voter case, and nodes
+// accommodating _any_ replicas are ruled out in the non-voter and witness
+// cases.
 func (a *Allocator) AllocateTarget(
 	ctx context.Context,
 	storePool storepool.AllocatorStorePool,
@@ -1377,6 +1485,20 @@ func (a *Allocator) AllocateNonVoter(
 	return a.AllocateTarget(ctx, storePool, conf, existingVoters, existingNonVoters, replacing, replicaStatus, NonVoterTarget)
 }
 
+// AllocateWitness returns a suitable store for a new allocation of a witness
+// replica with the required attributes. The existing voters are expected to
+// include the range's witnesses. Nodes already accommodating _any_ existing
+// replicas are ruled out as targets.
+func (a *Allocator) AllocateWitness(
+	ctx context.Context,
+	storePool storepool.AllocatorStorePool,
+	conf *roachpb.SpanConfig,
+	existingVoters, existingNonVoters []roachpb.ReplicaDescriptor,
+	replicaStatus ReplicaStatus,
+) (roachpb.ReplicationTarget, string, error) {
+	return a.AllocateTarget(ctx, storePool, conf, existingVoters, existingNonVoters, nil /* replacing */, replicaStatus, WitnessTarget)
+}
+
 // AllocateTargetFromList returns a suitable store for a new allocation of a
 // replica of the given type from the set of candidate stores, with the given
 // existing set of voters and non-voters..
@@ -1432,7 +1554,10 @@ func (a *Allocator) allocateTargetFromList(
 
 	var constraintsChecker constraintsCheckFn
 	switch t := targetType; t {
-	case VoterTarget:
+	case VoterTarget, WitnessTarget:
+		// Witnesses are voters, so they're subject to the voter constraints
+		// (`existingVoters` is expected to include the range's witnesses).
+		//
 		// If we are replacing an existing replica, make sure we check the
 		// constraints to ensure we are not going from a state in which a
 		// constraint is satisfied to one in which we are not. In this case, we
@@ -1589,7 +1714,7 @@ func (a Allocator) RemoveTarget(
 
 	var constraintsChecker constraintsCheckFn
 	switch t := targetType; t {
-	case VoterTarget:
+	case VoterTarget, WitnessTarget:
 		// Voting replicas have to abide by both the overall `constraints` (which
 		// apply to all replicas) and `voter_constraints` which apply only to voting
 		// replicas.
@@ -1697,6 +1822,37 @@ func (a Allocator) RemoveNonVoter(
 	)
 }
 
+// RemoveWitness returns a suitable witness replica to remove from the provided
+// set of candidates. The existing voters are expected to include the range's
+// witnesses.
+func (a Allocator) RemoveWitness(
+	ctx context.Context,
+	storePool storepool.AllocatorStorePool,
+	conf *roachpb.SpanConfig,
+	witnessCandidates []roachpb.ReplicaDescriptor,
+	existingVoters []roachpb.ReplicaDescriptor,
+	existingNonVoters []roachpb.ReplicaDescriptor,
+	options ScorerOptions,
+) (roachpb.ReplicationTarget, string, error) {
+	// Retrieve store descriptors for the provided candidates from the StorePool.
+	candidateStoreIDs := make(roachpb.StoreIDSlice, len(witnessCandidates))
+	for i, exist := range witnessCandidates {
+		candidateStoreIDs[i] = exist.StoreID
+	}
+	candidateStoreList, _, _ := storePool.GetStoreListFromIDs(candidateStoreIDs, storepool.StoreFilterNone)
+
+	return a.RemoveTarget(
+		ctx,
+		storePool,
+		conf,
+		candidateStoreList,
+		existingVoters,
+		existingNonVoters,
+		WitnessTarget,
+		options,
+	)
+}
+
 // RebalanceTarget returns a suitable store for a rebalance target (of the given
 // type) with required attributes.
 func (a Allocator) RebalanceTarget(
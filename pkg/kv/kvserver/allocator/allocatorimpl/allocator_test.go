@@ -22,6 +22,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/gossip"
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
@@ -7966,6 +7967,145 @@ func TestAllocatorGetNeededReplicas(t *testing.T) {
 	}
 }
 
+func TestAllocatorGetNeededWitnesses(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	testCases := []struct {
+		numWitnesses int32
+		neededVoters int
+		expected     int
+	}{
+		{0, 3, 0},
+		{0, 5, 0},
+		{1, 1, 0},
+		{1, 2, 0},
+		{1, 3, 1},
+		{1, 5, 1},
+		// Witnesses never make up half or more of the voters, even if the number
+		// of needed voters was reduced because of a lack of nodes.
+		{2, 3, 1},
+		{2, 4, 1},
+		{2, 5, 2},
+		{3, 5, 2},
+		{3, 7, 3},
+	}
+
+	for _, tc := range testCases {
+		if e, a := tc.expected, GetNeededWitnesses(tc.numWitnesses, tc.neededVoters); e != a {
+			t.Errorf(
+				"GetNeededWitnesses(conf.NumWitnesses=%d, neededVoters=%d) got %d; want %d",
+				tc.numWitnesses, tc.neededVoters, a, e)
+		}
+	}
+}
+
+// TestAllocatorComputeActionWitnesses verifies that witnesses are added and
+// removed according to num_witnesses, and that they count towards the range's
+// voters.
+func TestAllocatorComputeActionWitnesses(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	conf := roachpb.SpanConfig{
+		NumReplicas:   5,
+		NumWitnesses:  2,
+		RangeMaxBytes: 64000,
+	}
+	makeDesc := func(voters, witnesses []roachpb.StoreID) roachpb.RangeDescriptor {
+		desc := makeDescriptor(voters)
+		for _, storeID := range witnesses {
+			desc.InternalReplicas = append(desc.InternalReplicas, roachpb.ReplicaDescriptor{
+				StoreID:   storeID,
+				NodeID:    roachpb.NodeID(storeID),
+				ReplicaID: roachpb.ReplicaID(storeID),
+				Type:      roachpb.WITNESS,
+			})
+		}
+		return desc
+	}
+
+	testCases := []struct {
+		name           string
+		desc           roachpb.RangeDescriptor
+		expectedAction AllocatorAction
+	}{
+		{
+			name:           "missing voter",
+			desc:           makeDesc([]roachpb.StoreID{1, 2}, []roachpb.StoreID{4, 5}),
+			expectedAction: AllocatorAddVoter,
+		},
+		{
+			name:           "missing witness",
+			desc:           makeDesc([]roachpb.StoreID{1, 2, 3}, []roachpb.StoreID{4}),
+			expectedAction: AllocatorAddWitness,
+		},
+		{
+			name:           "dead witness is replaced",
+			desc:           makeDesc([]roachpb.StoreID{1, 2, 3}, []roachpb.StoreID{4, 6}),
+			expectedAction: AllocatorAddWitness,
+		},
+		{
+			name:           "dead witness is removed once replaced",
+			desc:           makeDesc([]roachpb.StoreID{1, 2, 3}, []roachpb.StoreID{4, 5, 6}),
+			expectedAction: AllocatorRemoveDeadWitness,
+		},
+		{
+			name:           "extra witness",
+			desc:           makeDesc([]roachpb.StoreID{1, 2, 3}, []roachpb.StoreID{4, 5, 8}),
+			expectedAction: AllocatorRemoveWitness,
+		},
+		{
+			name:           "extra voter",
+			desc:           makeDesc([]roachpb.StoreID{1, 2, 3, 8}, []roachpb.StoreID{4, 5}),
+			expectedAction: AllocatorRemoveVoter,
+		},
+		{
+			name:           "fully replicated",
+			desc:           makeDesc([]roachpb.StoreID{1, 2, 3}, []roachpb.StoreID{4, 5}),
+			expectedAction: AllocatorConsiderRebalance,
+		},
+	}
+
+	ctx := context.Background()
+	stopper, _, sp, a, _ := CreateTestAllocator(ctx, 10, false /* deterministic */)
+	defer stopper.Stop(ctx)
+
+	// Stores six and seven are dead, the rest are alive.
+	mockStorePool(sp,
+		[]roachpb.StoreID{1, 2, 3, 4, 5, 8},
+		nil,
+		[]roachpb.StoreID{6, 7},
+		nil,
+		nil,
+		nil,
+	)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			action, _ := a.ComputeAction(ctx, sp, &conf, &tc.desc)
+			require.Equal(t, tc.expectedAction, action,
+				"expected action %q, got action %q",
+				allocatorActionNames[tc.expectedAction], allocatorActionNames[action])
+		})
+	}
+
+	// Before the cluster is upgraded, no witnesses are added and the voters make
+	// up for them instead.
+	t.Run("before upgrade", func(t *testing.T) {
+		a.st = cluster.MakeTestingClusterSettingsWithVersions(
+			(clusterversion.V24_2_WitnessReplicas - 1).Version(),
+			clusterversion.MinSupported.Version(),
+			true, /* initializeVersion */
+		)
+		desc := makeDesc([]roachpb.StoreID{1, 2, 3}, nil)
+		action, _ := a.ComputeAction(ctx, sp, &conf, &desc)
+		require.Equal(t, AllocatorAddVoter, action,
+			"expected action %q, got action %q",
+			allocatorActionNames[AllocatorAddVoter], allocatorActionNames[action])
+	})
+}
+
 func makeDescriptor(storeList []roachpb.StoreID) roachpb.RangeDescriptor {
 	desc := roachpb.RangeDescriptor{
 		EndKey: roachpb.RKey(keys.SystemPrefix),
@@ -265,6 +265,13 @@ func (rp ReplicaPlanner) PlanOneChange(
 			// Nothing to do.
 			break
 		}
+		// Witnesses are voters as far as placement is concerned, and neither new
+		// voters nor new non-voters should be placed on a store that already has a
+		// witness for the range.
+		remainingLiveVoters = append(
+			remainingLiveVoters[:len(remainingLiveVoters):len(remainingLiveVoters)],
+			desc.Replicas().WitnessDescriptors()...,
+		)
 
 		switch action.TargetReplicaType() {
 		case allocatorimpl.VoterTarget:
@@ -306,6 +313,19 @@ func (rp ReplicaPlanner) PlanOneChange(
 		op, stats, err = rp.removeDead(ctx, repl, deadVoterReplicas, allocatorimpl.VoterTarget)
 	case allocatorimpl.AllocatorRemoveDeadNonVoter:
 		op, stats, err = rp.removeDead(ctx, repl, deadNonVoterReplicas, allocatorimpl.NonVoterTarget)
+
+	// Add and remove witnesses. Witnesses are never replaced in one step: a new
+	// witness is added first and the dead or decommissioning one is removed
+	// afterwards.
+	case allocatorimpl.AllocatorAddWitness:
+		op, stats, err = rp.addWitness(ctx, repl, desc, conf, voterReplicas, nonVoterReplicas, allocatorPrio)
+	case allocatorimpl.AllocatorRemoveWitness:
+		op, stats, err = rp.removeWitness(ctx, repl, desc, conf, voterReplicas, nonVoterReplicas)
+	case allocatorimpl.AllocatorRemoveDeadWitness:
+		_, deadWitnessReplicas := rp.storePool.LiveAndDeadReplicas(
+			desc.Replicas().WitnessDescriptors(), true, /* includeSuspectAndDrainingStores */
+		)
+		op, stats, err = rp.removeDead(ctx, repl, deadWitnessReplicas, allocatorimpl.WitnessTarget)
 	// Rebalance replicas.
 	//
 	// NB: Rebalacing attempts to balance replica counts among stores of
@@ -677,6 +697,100 @@ func (rp ReplicaPlanner) removeNonVoter(
 	return op, stats, nil
 }
 
+// addWitness adds a witness replica to `repl`s range.
+func (rp ReplicaPlanner) addWitness(
+	ctx context.Context,
+	repl AllocatorReplica,
+	desc *roachpb.RangeDescriptor,
+	conf *roachpb.SpanConfig,
+	existingVoters, existingNonVoters []roachpb.ReplicaDescriptor,
+	allocatorPrio float64,
+) (op AllocationOp, stats ReplicateStats, _ error) {
+	existingWitnesses := desc.Replicas().WitnessDescriptors()
+	newWitness, details, err := rp.allocator.AllocateWitness(
+		ctx,
+		rp.storePool,
+		conf,
+		append(existingVoters[:len(existingVoters):len(existingVoters)], existingWitnesses...),
+		existingNonVoters,
+		allocatorimpl.Alive,
+	)
+	if err != nil {
+		return nil, stats, err
+	}
+
+	stats = stats.trackAddReplicaCount(allocatorimpl.WitnessTarget)
+	log.KvDistribution.Infof(ctx, "adding witness %+v: %s",
+		newWitness, rangeRaftProgress(repl.RaftStatus(), existingVoters))
+
+	op = AllocationChangeReplicasOp{
+		LeaseholderStore:  repl.StoreID(),
+		Usage:             repl.RangeUsageInfo(),
+		Chgs:              kvpb.MakeReplicationChanges(roachpb.ADD_WITNESS, newWitness),
+		AllocatorPriority: allocatorPrio,
+		Reason:            kvserverpb.ReasonRangeUnderReplicated,
+		Details:           details,
+	}
+	return op, stats, nil
+}
+
+// removeWitness removes a witness replica from `repl`s range, preferring
+// witnesses on decommissioning stores.
+func (rp ReplicaPlanner) removeWitness(
+	ctx context.Context,
+	repl AllocatorReplica,
+	desc *roachpb.RangeDescriptor,
+	conf *roachpb.SpanConfig,
+	existingVoters, existingNonVoters []roachpb.ReplicaDescriptor,
+) (op AllocationOp, stats ReplicateStats, _ error) {
+	existingWitnesses := desc.Replicas().WitnessDescriptors()
+	if len(existingWitnesses) == 0 {
+		return nil, stats, errors.AssertionFailedf(
+			"range %s was identified as having too many witnesses, but no witnesses were found", repl)
+	}
+
+	var target roachpb.ReplicationTarget
+	var details string
+	reason := kvserverpb.ReasonRangeOverReplicated
+	replicaStatus := allocatorimpl.Alive
+	if decommissioning := rp.storePool.DecommissioningReplicas(existingWitnesses); len(decommissioning) > 0 {
+		target = roachpb.ReplicationTarget{
+			NodeID:  decommissioning[0].NodeID,
+			StoreID: decommissioning[0].StoreID,
+		}
+		reason = kvserverpb.ReasonStoreDecommissioning
+		replicaStatus = allocatorimpl.Decommissioning
+	} else {
+		var err error
+		target, details, err = rp.allocator.RemoveWitness(
+			ctx,
+			rp.storePool,
+			conf,
+			existingWitnesses,
+			append(existingVoters[:len(existingVoters):len(existingVoters)], existingWitnesses...),
+			existingNonVoters,
+			rp.allocator.ScorerOptions(ctx),
+		)
+		if err != nil {
+			return nil, stats, err
+		}
+	}
+	stats = stats.trackRemoveMetric(allocatorimpl.WitnessTarget, replicaStatus)
+
+	log.KvDistribution.Infof(ctx, "removing witness %+v: %s",
+		target, rangeRaftProgress(repl.RaftStatus(), existingVoters))
+
+	op = AllocationChangeReplicasOp{
+		LeaseholderStore:  repl.StoreID(),
+		Usage:             repl.RangeUsageInfo(),
+		Chgs:              kvpb.MakeReplicationChanges(roachpb.REMOVE_WITNESS, target),
+		AllocatorPriority: 0.0, // unused
+		Reason:            reason,
+		Details:           details,
+	}
+	return op, stats, nil
+}
+
 func (rp ReplicaPlanner) removeDecommissioning(
 	ctx context.Context,
 	repl AllocatorReplica,
@@ -825,6 +939,13 @@ func (rp ReplicaPlanner) considerRebalance(
 		log.KvDistribution.VInfof(ctx, 2, "no suitable rebalance target for non-voters")
 		return nil, stats, nil
 	}
+	// The allocator doesn't know about the range's witnesses when rebalancing,
+	// so it may propose a store that already holds one. Witnesses cannot be
+	// promoted, so skip the rebalance instead.
+	if rdesc, found := desc.GetReplicaDescriptor(addTarget.StoreID); found && rdesc.IsWitness() {
+		log.KvDistribution.VInfof(ctx, 2, "rebalance target s%d already has a witness", addTarget.StoreID)
+		return nil, stats, nil
+	}
 	// If we have a valid rebalance action (ok == true) and we haven't
 	// transferred our lease away, find the rebalance changes and return them
 	// in an operation.
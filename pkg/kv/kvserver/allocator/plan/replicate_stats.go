@@ -23,15 +23,19 @@ type ReplicateStats struct {
 	AddReplicaCount                           int64
 	AddVoterReplicaCount                      int64
 	AddNonVoterReplicaCount                   int64
+	AddWitnessReplicaCount                    int64
 	RemoveReplicaCount                        int64
 	RemoveVoterReplicaCount                   int64
 	RemoveNonVoterReplicaCount                int64
+	RemoveWitnessReplicaCount                 int64
 	RemoveDeadReplicaCount                    int64
 	RemoveDeadVoterReplicaCount               int64
 	RemoveDeadNonVoterReplicaCount            int64
+	RemoveDeadWitnessReplicaCount             int64
 	RemoveDecommissioningReplicaCount         int64
 	RemoveDecommissioningVoterReplicaCount    int64
 	RemoveDecommissioningNonVoterReplicaCount int64
+	RemoveDecommissioningWitnessReplicaCount  int64
 	RemoveLearnerReplicaCount                 int64
 	RebalanceReplicaCount                     int64
 	RebalanceVoterReplicaCount                int64
@@ -46,15 +50,19 @@ func (rs ReplicateStats) Merge(other ReplicateStats) ReplicateStats {
 	rs.AddReplicaCount += other.AddReplicaCount
 	rs.AddVoterReplicaCount += other.AddVoterReplicaCount
 	rs.AddNonVoterReplicaCount += other.AddNonVoterReplicaCount
+	rs.AddWitnessReplicaCount += other.AddWitnessReplicaCount
 	rs.RemoveReplicaCount += other.RemoveReplicaCount
 	rs.RemoveVoterReplicaCount += other.RemoveVoterReplicaCount
 	rs.RemoveNonVoterReplicaCount += other.RemoveNonVoterReplicaCount
+	rs.RemoveWitnessReplicaCount += other.RemoveWitnessReplicaCount
 	rs.RemoveDeadReplicaCount += other.RemoveDeadReplicaCount
 	rs.RemoveDeadVoterReplicaCount += other.RemoveDeadVoterReplicaCount
 	rs.RemoveDeadNonVoterReplicaCount += other.RemoveDeadNonVoterReplicaCount
+	rs.RemoveDeadWitnessReplicaCount += other.RemoveDeadWitnessReplicaCount
 	rs.RemoveDecommissioningReplicaCount += other.RemoveDecommissioningReplicaCount
 	rs.RemoveDecommissioningVoterReplicaCount += other.RemoveDecommissioningVoterReplicaCount
 	rs.RemoveDecommissioningNonVoterReplicaCount += other.RemoveDecommissioningNonVoterReplicaCount
+	rs.RemoveDecommissioningWitnessReplicaCount += other.RemoveDecommissioningWitnessReplicaCount
 	rs.RemoveLearnerReplicaCount += other.RemoveLearnerReplicaCount
 	rs.RebalanceReplicaCount += other.RebalanceReplicaCount
 	rs.RebalanceVoterReplicaCount += other.RebalanceVoterReplicaCount
@@ -75,6 +83,8 @@ func (rs ReplicateStats) trackAddReplicaCount(
 		rs.AddVoterReplicaCount++
 	case allocatorimpl.NonVoterTarget:
 		rs.AddNonVoterReplicaCount++
+	case allocatorimpl.WitnessTarget:
+		rs.AddWitnessReplicaCount++
 	default:
 		panic(fmt.Sprintf("unsupported targetReplicaType: %v", targetType))
 	}
@@ -101,7 +111,7 @@ func (rs ReplicateStats) trackRemoveMetric(
 }
 
 // trackRemoveReplicaCount increases the RemoveReplicaCount metric and
-// separately tracks voter/non-voter metrics given a replica targetType.
+// separately tracks voter/non-voter/witness metrics given a replica targetType.
 func (rs ReplicateStats) trackRemoveReplicaCount(
 	targetType allocatorimpl.TargetReplicaType,
 ) ReplicateStats {
@@ -111,6 +121,8 @@ func (rs ReplicateStats) trackRemoveReplicaCount(
 		rs.RemoveVoterReplicaCount++
 	case allocatorimpl.NonVoterTarget:
 		rs.RemoveNonVoterReplicaCount++
+	case allocatorimpl.WitnessTarget:
+		rs.RemoveWitnessReplicaCount++
 	default:
 		panic(fmt.Sprintf("unsupported targetReplicaType: %v", targetType))
 	}
@@ -118,7 +130,7 @@ func (rs ReplicateStats) trackRemoveReplicaCount(
 }
 
 // trackRemoveDeadReplicaCount increases the RemoveDeadReplicaCount metric and
-// separately tracks voter/non-voter metrics given a replica targetType.
+// separately tracks voter/non-voter/witness metrics given a replica targetType.
 func (rs ReplicateStats) trackRemoveDeadReplicaCount(
 	targetType allocatorimpl.TargetReplicaType,
 ) ReplicateStats {
@@ -128,6 +140,8 @@ func (rs ReplicateStats) trackRemoveDeadReplicaCount(
 		rs.RemoveDeadVoterReplicaCount++
 	case allocatorimpl.NonVoterTarget:
 		rs.RemoveDeadNonVoterReplicaCount++
+	case allocatorimpl.WitnessTarget:
+		rs.RemoveDeadWitnessReplicaCount++
 	default:
 		panic(fmt.Sprintf("unsupported targetReplicaType: %v", targetType))
 	}
@@ -136,7 +150,7 @@ func (rs ReplicateStats) trackRemoveDeadReplicaCount(
 
 // trackRemoveDecommissioningReplicaCount increases the
 // RemoveDecommissioningReplicaCount metric and separately tracks
-// voter/non-voter metrics given a replica targetType.
+// voter/non-voter/witness metrics given a replica targetType.
 func (rs ReplicateStats) trackRemoveDecommissioningReplicaCount(
 	targetType allocatorimpl.TargetReplicaType,
 ) ReplicateStats {
@@ -146,6 +160,8 @@ func (rs ReplicateStats) trackRemoveDecommissioningReplicaCount(
 		rs.RemoveDecommissioningVoterReplicaCount++
 	case allocatorimpl.NonVoterTarget:
 		rs.RemoveDecommissioningNonVoterReplicaCount++
+	case allocatorimpl.WitnessTarget:
+		rs.RemoveDecommissioningWitnessReplicaCount++
 	default:
 		panic(fmt.Sprintf("unsupported targetReplicaType: %v", targetType))
 	}
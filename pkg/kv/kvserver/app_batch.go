@@ -18,10 +18,13 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverpb"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/logstore"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/raftlog"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/rditer"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/storage"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/errors"
+	"github.com/cockroachdb/pebble"
 	"golang.org/x/time/rate"
 )
 
@@ -149,11 +152,96 @@ func (b *appBatch) addWriteBatch(
 	return nil
 }
 
+// addWitnessWriteBatch is like addWriteBatch, but for witness replicas. It only
+// stages the mutations that fall outside of the range's user keys, since
+// witnesses don't store user data. This keeps the range-ID local keys,
+// range-local keys (such as the range descriptor and transaction records) and
+// the lock table.
+//
+// Note that the command's MVCC stats delta is still applied in full: the stats
+// are part of the range's replicated state, which must be identical across all
+// replicas. As a result, a witness's stats describe the range's logical data
+// rather than what the witness stores. The allocator accounts for this by not
+// counting witnesses towards a store's logical bytes (see RangeUsageInfo), and
+// the per-tenant storage metrics only count their system data (see
+// tenantStorageStats).
+func (b *appBatch) addWitnessWriteBatch(
+	ctx context.Context, batch storage.Batch, cmd *replicatedCmd, desc *roachpb.RangeDescriptor,
+) error {
+	wb := cmd.Cmd.WriteBatch
+	if wb == nil {
+		return nil
+	}
+	userSpans := rditer.MakeReplicatedKeySpansUserOnly(desc)
+	isUserKey := func(key roachpb.Key) bool {
+		for _, sp := range userSpans {
+			if sp.ContainsKey(key) {
+				return true
+			}
+		}
+		return false
+	}
+	r, err := storage.NewBatchReader(wb.Data)
+	if err != nil {
+		return errors.Wrapf(err, "unable to read WriteBatch")
+	}
+	for r.Next() {
+		ek, err := r.EngineKey()
+		if err != nil {
+			return errors.Wrapf(err, "unable to decode WriteBatch entry")
+		}
+		if isUserKey(ek.Key) {
+			continue
+		}
+		b.numMutations++
+		switch kind := r.KeyKind(); kind {
+		case pebble.InternalKeyKindSet, pebble.InternalKeyKindSetWithDelete,
+			pebble.InternalKeyKindDelete, pebble.InternalKeyKindDeleteSized,
+			pebble.InternalKeyKindSingleDelete, pebble.InternalKeyKindMerge:
+			ik := pebble.MakeInternalKey(r.Key(), 0 /* seqNum */, kind)
+			err = batch.PutInternalPointKey(&ik, r.Value())
+		case pebble.InternalKeyKindRangeDelete:
+			var end []byte
+			if end, err = r.EndKey(); err == nil {
+				err = batch.ClearRawEncodedRange(r.Key(), end)
+			}
+		case pebble.InternalKeyKindRangeKeySet, pebble.InternalKeyKindRangeKeyUnset,
+			pebble.InternalKeyKindRangeKeyDelete:
+			var end []byte
+			if end, err = r.EndKey(); err != nil {
+				break
+			}
+			rangeKeys, rkErr := r.RawRangeKeys()
+			if rkErr != nil {
+				err = rkErr
+				break
+			}
+			for _, rk := range rangeKeys {
+				if err = batch.PutInternalRangeKey(r.Key(), end, rk); err != nil {
+					break
+				}
+			}
+		default:
+			err = errors.AssertionFailedf("unexpected WriteBatch entry key kind %d", kind)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "unable to apply WriteBatch")
+		}
+	}
+	if err := r.Error(); err != nil {
+		return errors.Wrapf(err, "unable to read WriteBatch")
+	}
+	return nil
+}
+
 type postAddEnv struct {
 	st          *cluster.Settings
 	eng         storage.Engine
 	sideloaded  logstore.SideloadStorage
 	bulkLimiter *rate.Limiter
+	// witness is set if the command is applied by a witness replica, which
+	// doesn't ingest user data.
+	witness bool
 }
 
 func (b *appBatch) runPostAddTriggers(
@@ -174,7 +262,7 @@ func (b *appBatch) runPostAddTriggers(
 	// NB: any command which has an AddSSTable is non-trivial and will be
 	// applied in its own batch so it's not possible that any other commands
 	// which precede this command can shadow writes from this SSTable.
-	if res.AddSSTable != nil {
+	if res.AddSSTable != nil && !env.witness {
 		copied := addSSTablePreApply(
 			ctx,
 			env,
@@ -195,7 +283,7 @@ func (b *appBatch) runPostAddTriggers(
 			b.numMutations += int(added)
 		}
 	}
-	if res.LinkExternalSSTable != nil {
+	if res.LinkExternalSSTable != nil && !env.witness {
 		linkExternalSStablePreApply(
 			ctx,
 			env,
@@ -0,0 +1,40 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package batcheval
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/batcheval/result"
+	"github.com/cockroachdb/cockroach/pkg/storage"
+)
+
+func init() {
+	// ReadIndex declares non-locking read latches over its span at the batch
+	// timestamp, so it waits for all conflicting in-flight writes at or below
+	// that timestamp to be applied before it is evaluated.
+	RegisterReadOnlyCommand(kvpb.ReadIndex, DefaultDeclareKeys, ReadIndex)
+}
+
+// ReadIndex returns the lease applied index of the leaseholder, which a
+// follower needs to apply before it can serve a linearizable read over the
+// request's span at the batch timestamp. The timestamp cache is updated by the
+// caller, preventing any writes at or below that timestamp from being
+// performed on the leaseholder afterwards.
+func ReadIndex(
+	_ context.Context, _ storage.Reader, cArgs CommandArgs, response kvpb.Response,
+) (result.Result, error) {
+	resp := response.(*kvpb.ReadIndexResponse)
+	resp.LeaseAppliedIndex = cArgs.EvalCtx.GetLeaseAppliedIndex()
+	resp.RangeDesc = *cArgs.EvalCtx.Desc()
+	return result.Result{}, nil
+}
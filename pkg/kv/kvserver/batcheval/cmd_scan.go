@@ -20,6 +20,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/storage"
 	"github.com/cockroachdb/cockroach/pkg/storage/fs"
 	"github.com/cockroachdb/cockroach/pkg/util/admission/admissionpb"
+	"github.com/cockroachdb/errors"
 )
 
 func init() {
@@ -37,6 +38,10 @@ func Scan(
 	h := cArgs.Header
 	reply := resp.(*kvpb.ScanResponse)
 
+	if err := checkIndexFetchPushdown(h, args.ScanFormat, args.KeyLockingStrength); err != nil {
+		return result.Result{}, err
+	}
+
 	var lockTableForSkipLocked storage.LockTableView
 	if h.WaitPolicy == lock.WaitPolicy_SkipLocked {
 		lockTableForSkipLocked = newRequestBoundLockTableView(
@@ -136,6 +141,30 @@ func Scan(
 	return res, nil
 }
 
+// checkIndexFetchPushdown returns an error if the filter and aggregation
+// pushdown of the IndexFetchSpec (if any) cannot be evaluated by a scan with
+// the given format and locking strength.
+func checkIndexFetchPushdown(
+	h kvpb.Header, scanFormat kvpb.ScanFormat, keyLockingStrength lock.Strength,
+) error {
+	if h.IndexFetchSpec == nil || h.IndexFetchSpec.Pushdown == nil {
+		return nil
+	}
+	if scanFormat != kvpb.COL_BATCH_RESPONSE {
+		return errors.AssertionFailedf(
+			"pushdown in IndexFetchSpec requires %s scan format, found %s",
+			kvpb.COL_BATCH_RESPONSE, scanFormat,
+		)
+	}
+	if keyLockingStrength != lock.None {
+		// Locks are acquired on the keys returned by the scan, but the pushdown
+		// doesn't return the keys of the rows that didn't pass the filter nor
+		// of the aggregated rows.
+		return errors.AssertionFailedf("pushdown in IndexFetchSpec cannot be used with locking scans")
+	}
+	return nil
+}
+
 func ScanReadCategory(ah kvpb.AdmissionHeader) fs.ReadCategory {
 	readCategory := fs.ScanRegularBatchEvalReadCategory
 	if admissionpb.WorkPriority(ah.Priority) < admissionpb.NormalPri {
@@ -0,0 +1,254 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package kvserver_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver"
+	"github.com/cockroachdb/cockroach/pkg/raft/raftpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/storage"
+	"github.com/cockroachdb/cockroach/pkg/storage/fs"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/testcluster"
+	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// getMVCCValue returns the latest value of the given key in the engine, or nil
+// if there is none.
+func getMVCCValue(t *testing.T, eng storage.Reader, key roachpb.Key) *roachpb.Value {
+	t.Helper()
+	res, err := storage.MVCCGet(context.Background(), eng, key, hlc.MaxTimestamp, storage.MVCCGetOptions{})
+	require.NoError(t, err)
+	return res.Value
+}
+
+// countUserKeys returns the number of MVCC point and range keys in the user
+// key span of the range, excluding the lock table.
+func countUserKeys(t *testing.T, eng storage.Reader, desc roachpb.RangeDescriptor) int {
+	t.Helper()
+	var n int
+	require.NoError(t, eng.MVCCIterate(context.Background(),
+		desc.StartKey.AsRawKey(), desc.EndKey.AsRawKey(),
+		storage.MVCCKeyIterKind, storage.IterKeyTypePointsAndRanges, fs.UnknownReadCategory,
+		func(storage.MVCCKeyValue, storage.MVCCRangeKeyStack) error {
+			n++
+			return nil
+		}))
+	return n
+}
+
+// TestWitnessStoresNoUserData checks that a witness drops the writes to user
+// keys of the commands it applies while retaining the range-local keys and the
+// lock table, and that the user data applied by a learner that's being turned
+// into a witness is cleared when it is promoted.
+func TestWitnessStoresNoUserData(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	unblockPromotionCh := make(chan struct{})
+	tc := testcluster.StartTestCluster(t, 3, base.TestClusterArgs{
+		ReplicationMode: base.ReplicationManual,
+		ServerArgs: base.TestServerArgs{
+			Knobs: base.TestingKnobs{Store: &kvserver.StoreTestingKnobs{
+				WitnessAddStopAfterLearnerSnapshot: func([]roachpb.ReplicationTarget) bool {
+					<-unblockPromotionCh
+					return false
+				},
+			}},
+		},
+	})
+	defer tc.Stopper().Stop(ctx)
+	db := tc.Server(0).DB()
+
+	scratch := tc.ScratchRange(t)
+	key := func(s string) roachpb.Key {
+		return append(scratch[:len(scratch):len(scratch)], s...)
+	}
+	tc.AddVotersOrFatal(t, scratch, tc.Target(1))
+	require.NoError(t, db.Put(ctx, key("a"), "a"))
+
+	// Add a witness on n3, and block its promotion while it's still a learner.
+	desc := tc.LookupRangeOrFatal(t, scratch)
+	g := ctxgroup.WithContext(ctx)
+	g.GoCtx(func(ctx context.Context) error {
+		_, err := db.AdminChangeReplicas(ctx, scratch, desc,
+			kvpb.MakeReplicationChanges(roachpb.ADD_WITNESS, tc.Target(2)))
+		return err
+	})
+
+	witnessStore := tc.GetFirstStoreFromServer(t, 2)
+	eng := witnessStore.TODOEngine()
+	var witness *kvserver.Replica
+	testutils.SucceedsSoon(t, func() error {
+		if witness = witnessStore.LookupReplica(roachpb.RKey(scratch)); witness == nil {
+			return errors.New("learner not initialized yet")
+		}
+		return nil
+	})
+
+	// The learner was initialized with a snapshot that excludes user data, but
+	// it applies the user writes from the log.
+	require.Nil(t, getMVCCValue(t, eng, key("a")))
+	require.NoError(t, db.Put(ctx, key("b"), "b"))
+	testutils.SucceedsSoon(t, func() error {
+		if getMVCCValue(t, eng, key("b")) == nil {
+			return errors.New("learner has not applied the write yet")
+		}
+		return nil
+	})
+
+	// Promote the learner to a witness, which clears its user data.
+	close(unblockPromotionCh)
+	require.NoError(t, g.Wait())
+	desc = tc.LookupRangeOrFatal(t, scratch)
+	repDesc, ok := desc.GetReplicaDescriptor(witnessStore.StoreID())
+	require.True(t, ok)
+	require.Equal(t, roachpb.WITNESS, repDesc.Type)
+	testutils.SucceedsSoon(t, func() error {
+		if getMVCCValue(t, eng, key("b")) != nil {
+			return errors.New("witness still has user data")
+		}
+		return nil
+	})
+
+	// Write a value and an intent. The witness drops both, but keeps the lock.
+	require.NoError(t, db.Put(ctx, key("c"), "c"))
+	txn := db.NewTxn(ctx, "witness-test")
+	require.NoError(t, txn.Put(ctx, key("d"), "d"))
+	defer func() { _ = txn.Rollback(ctx) }()
+	testutils.SucceedsSoon(t, func() error {
+		locks, err := storage.ScanLocks(ctx, eng, key("d"), key("d").Next(), 0, 0)
+		if err != nil {
+			return err
+		}
+		if len(locks) != 1 {
+			return errors.Errorf("expected 1 lock on the witness, found %d", len(locks))
+		}
+		return nil
+	})
+	require.Zero(t, countUserKeys(t, eng, desc))
+	require.NotZero(t, countUserKeys(t, tc.GetFirstStoreFromServer(t, 1).TODOEngine(), desc))
+
+	// The range-local keys are retained.
+	descVal := getMVCCValue(t, eng, keys.RangeDescriptorKey(desc.StartKey))
+	require.NotNil(t, descVal)
+
+	// The witness doesn't count the range's user data towards the logical bytes
+	// of its store.
+	_, leaseholder := getFirstStoreReplica(t, tc.Server(0), scratch)
+	require.NotZero(t, leaseholder.RangeUsageInfo().LogicalBytes)
+	require.Zero(t, witness.RangeUsageInfo().LogicalBytes)
+}
+
+// TestWitnessRaftLeaderDelegatesSnapshots checks that a witness that holds the
+// raft leadership when a replica storing user data is lost never sends its own
+// state to a replica that needs a snapshot including user data, and that it
+// eventually hands the leadership over to a replica storing user data.
+func TestWitnessRaftLeaderDelegatesSnapshots(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	st := cluster.MakeTestingClusterSettings()
+	// Without delegation, the coordinator of a snapshot would send it itself,
+	// which a witness must not do.
+	kvserver.NumDelegateLimit.Override(ctx, &st.SV, 0)
+
+	var skipInitialSnapshot, keepWitnessLeader atomic.Bool
+	keepWitnessLeader.Store(true)
+	tc := testcluster.StartTestCluster(t, 4, base.TestClusterArgs{
+		ReplicationMode: base.ReplicationManual,
+		ServerArgs: base.TestServerArgs{
+			Settings: st,
+			Knobs: base.TestingKnobs{Store: &kvserver.StoreTestingKnobs{
+				DisableLeaderFollowsLeaseholder:  true,
+				DisableLeaderTransferFromWitness: keepWitnessLeader.Load,
+				ReplicaSkipInitialSnapshot:       skipInitialSnapshot.Load,
+			}},
+		},
+	})
+	defer tc.Stopper().Stop(ctx)
+	db := tc.Server(0).DB()
+
+	key := tc.ScratchRange(t)
+	tc.AddVotersOrFatal(t, key, tc.Target(1))
+	desc, err := db.AdminChangeReplicas(ctx, key, tc.LookupRangeOrFatal(t, key),
+		kvpb.MakeReplicationChanges(roachpb.ADD_WITNESS, tc.Target(2)))
+	require.NoError(t, err)
+	require.NoError(t, db.Put(ctx, key, "before"))
+
+	// Move the raft leadership to the witness.
+	witnessDesc, ok := desc.GetReplicaDescriptor(tc.Target(2).StoreID)
+	require.True(t, ok)
+	_, leaseholder := getFirstStoreReplica(t, tc.Server(0), key)
+	leaseholderDesc, err := leaseholder.GetReplicaDescriptor()
+	require.NoError(t, err)
+	witnessStore, witness := getFirstStoreReplica(t, tc.Server(2), key)
+	testutils.SucceedsSoon(t, func() error {
+		if status := witness.RaftStatus(); status != nil &&
+			status.Lead == raftpb.PeerID(witnessDesc.ReplicaID) {
+			return nil
+		}
+		leaseholder.TransferRaftLeadership(witnessDesc.ReplicaID)
+		return errors.New("witness is not the raft leader yet")
+	})
+
+	// Kill a replica storing user data while the witness is the leader. The
+	// range keeps its quorum through the leaseholder and the witness.
+	tc.StopServer(1)
+	require.NoError(t, db.Put(ctx, key, "after"))
+
+	// Add a non-voter without sending it an initial snapshot, so that it's
+	// caught up by the raft snapshot queue of the witness. The witness needs to
+	// delegate the snapshot to the leaseholder rather than send its own state.
+	skipInitialSnapshot.Store(true)
+	tc.AddNonVotersOrFatal(t, key, tc.Target(3))
+	skipInitialSnapshot.Store(false)
+	nonVoterEng := tc.GetFirstStoreFromServer(t, 3).TODOEngine()
+	testutils.SucceedsSoon(t, func() error {
+		val := getMVCCValue(t, nonVoterEng, key)
+		if val == nil {
+			return errors.New("non-voter has not received the user data yet")
+		}
+		if b, err := val.GetBytes(); err != nil {
+			return err
+		} else if string(b) != "after" {
+			return errors.Errorf("unexpected value %q on the non-voter", b)
+		}
+		return nil
+	})
+	require.Nil(t, getMVCCValue(t, witnessStore.TODOEngine(), key))
+
+	// Once allowed to, the witness transfers the leadership to the only live
+	// voter that stores user data.
+	keepWitnessLeader.Store(false)
+	testutils.SucceedsSoon(t, func() error {
+		if status := leaseholder.RaftStatus(); status == nil ||
+			status.Lead != raftpb.PeerID(leaseholderDesc.ReplicaID) {
+			return errors.New("leadership has not moved away from the witness yet")
+		}
+		return nil
+	})
+}
@@ -56,6 +56,18 @@ var skipConsitencyQueueForExternalBytes = settings.RegisterBoolSetting(
 	true,
 )
 
+// consistencyCheckRepairEnabled controls whether the consistency checker
+// repairs the replicas it finds to be inconsistent, instead of terminating the
+// nodes that host them.
+var consistencyCheckRepairEnabled = settings.RegisterBoolSetting(
+	settings.SystemOnly,
+	"server.consistency_check.repair.enabled",
+	"if enabled, replicas found to be inconsistent with the majority of their range's "+
+		"replicas are removed from the range and replaced with a fresh copy, instead of "+
+		"terminating the nodes that host them",
+	false,
+	settings.WithPublic)
+
 // consistencyCheckRateBurstFactor we use this to set the burst parameter on the
 // quotapool.RateLimiter. It seems overkill to provide a user setting for this,
 // so we use a factor to scale the burst setting based on the rate defined above.
@@ -351,6 +351,14 @@ func (r *Replica) ForceCampaign(ctx context.Context) {
 	r.forceCampaignLocked(ctx)
 }
 
+// TransferRaftLeadership asks the replica, which must be the raft leader, to
+// transfer the leadership to the given replica.
+func (r *Replica) TransferRaftLeadership(target roachpb.ReplicaID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.mu.internalRaftGroup.TransferLeader(raftpb.PeerID(target))
+}
+
 // LastAssignedLeaseIndexRLocked is like LastAssignedLeaseIndex, but requires
 // b.mu to be held in read mode.
 func (b *propBuf) LastAssignedLeaseIndexRLocked() kvpb.LeaseAppliedIndex {
@@ -418,7 +418,9 @@ func rankReplicasBySurvivability(replicas []loqrecoverypb.ReplicaInfo) rankedRep
 	isVoter := func(desc loqrecoverypb.ReplicaInfo) bool {
 		for _, replica := range desc.Desc.InternalReplicas {
 			if replica.StoreID == desc.StoreID {
-				return replica.IsVoterNewConfig()
+				// Witnesses don't store user data, so they make for a poor
+				// survivor even though they are voters.
+				return replica.IsVoterNewConfig() && !replica.IsWitness()
 			}
 		}
 		// This is suspicious, our descriptor is not in replicas. Panic maybe?
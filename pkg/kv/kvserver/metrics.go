@@ -428,6 +428,12 @@ var (
 		Measurement: "Read Ops",
 		Unit:        metric.Unit_COUNT,
 	}
+	metaLinearizableFollowerReadsCount = metric.Metadata{
+		Name:        "follower_reads.linearizable_count",
+		Help:        "Number of follower reads served above the closed timestamp using a read index obtained from the leaseholder",
+		Measurement: "Read Ops",
+		Unit:        metric.Unit_COUNT,
+	}
 
 	// Server-side transaction metrics.
 	metaCommitWaitBeforeCommitTriggerCount = metric.Metadata{
@@ -1057,6 +1063,12 @@ var (
 		Measurement: "Snapshots",
 		Unit:        metric.Unit_COUNT,
 	}
+	metaRangeSnapshotsAppliedByWitness = metric.Metadata{
+		Name:        "range.snapshots.applied-witness",
+		Help:        "Number of snapshots without user data applied by witness replicas",
+		Measurement: "Snapshots",
+		Unit:        metric.Unit_COUNT,
+	}
 	metaRangeSnapshotRcvdBytes = metric.Metadata{
 		Name:        "range.snapshots.rcvd-bytes",
 		Help:        "Number of snapshot bytes received",
@@ -1876,6 +1888,12 @@ The messages are dropped to help these replicas to recover from I/O overload.`,
 		Measurement: "Processing Time",
 		Unit:        metric.Unit_NANOSECONDS,
 	}
+	metaConsistencyQueueRepairs = metric.Metadata{
+		Name:        "queue.consistency.repairs",
+		Help:        "Number of inconsistent replicas removed by the consistency checker queue to be replaced",
+		Measurement: "Replicas",
+		Unit:        metric.Unit_COUNT,
+	}
 	metaReplicaGCQueueSuccesses = metric.Metadata{
 		Name:        "queue.replicagc.process.success",
 		Help:        "Number of replicas successfully processed by the replica GC queue",
@@ -2589,7 +2607,8 @@ type StoreMetrics struct {
 	RecentReplicaQueriesPerSecond  *metric.ManualWindowHistogram
 
 	// Follower read metrics.
-	FollowerReadsCount *metric.Counter
+	FollowerReadsCount             *metric.Counter
+	LinearizableFollowerReadsCount *metric.Counter
 
 	// Server-side transaction metrics.
 	CommitWaitsBeforeCommitTrigger                           *metric.Counter
@@ -2708,6 +2727,7 @@ type StoreMetrics struct {
 	RangeSnapshotsAppliedByVoters                *metric.Counter
 	RangeSnapshotsAppliedForInitialUpreplication *metric.Counter
 	RangeSnapshotsAppliedByNonVoters             *metric.Counter
+	RangeSnapshotsAppliedByWitnesses             *metric.Counter
 	RangeSnapshotRcvdBytes                       *metric.Counter
 	RangeSnapshotSentBytes                       *metric.Counter
 	RangeSnapshotUnknownRcvdBytes                *metric.Counter
@@ -2810,6 +2830,7 @@ type StoreMetrics struct {
 	RaftSnapshotQueueProcessingNanos          *metric.Counter
 	ConsistencyQueueSuccesses                 *metric.Counter
 	ConsistencyQueueFailures                  *metric.Counter
+	ConsistencyQueueRepairs                   *metric.Counter
 	ConsistencyQueuePending                   *metric.Gauge
 	ConsistencyQueueProcessingNanos           *metric.Counter
 	LeaseQueueSuccesses                       *metric.Counter
@@ -3295,7 +3316,8 @@ func newStoreMetrics(histogramWindow time.Duration) *StoreMetrics {
 		),
 
 		// Follower reads metrics.
-		FollowerReadsCount: metric.NewCounter(metaFollowerReadsCount),
+		FollowerReadsCount:             metric.NewCounter(metaFollowerReadsCount),
+		LinearizableFollowerReadsCount: metric.NewCounter(metaLinearizableFollowerReadsCount),
 
 		// Server-side transaction metrics.
 		CommitWaitsBeforeCommitTrigger:                           metric.NewCounter(metaCommitWaitBeforeCommitTriggerCount),
@@ -3422,6 +3444,7 @@ func newStoreMetrics(histogramWindow time.Duration) *StoreMetrics {
 		RangeSnapshotsAppliedByVoters: metric.NewCounter(metaRangeSnapshotsAppliedByVoters),
 		RangeSnapshotsAppliedForInitialUpreplication: metric.NewCounter(metaRangeSnapshotsAppliedForInitialUpreplication),
 		RangeSnapshotsAppliedByNonVoters:             metric.NewCounter(metaRangeSnapshotsAppliedByNonVoter),
+		RangeSnapshotsAppliedByWitnesses:             metric.NewCounter(metaRangeSnapshotsAppliedByWitness),
 		RangeSnapshotRcvdBytes:                       metric.NewCounter(metaRangeSnapshotRcvdBytes),
 		RangeSnapshotSentBytes:                       metric.NewCounter(metaRangeSnapshotSentBytes),
 		RangeSnapshotUnknownRcvdBytes:                metric.NewCounter(metaRangeSnapshotUnknownRcvdBytes),
@@ -3572,6 +3595,7 @@ func newStoreMetrics(histogramWindow time.Duration) *StoreMetrics {
 		RaftSnapshotQueueProcessingNanos:          metric.NewCounter(metaRaftSnapshotQueueProcessingNanos),
 		ConsistencyQueueSuccesses:                 metric.NewCounter(metaConsistencyQueueSuccesses),
 		ConsistencyQueueFailures:                  metric.NewCounter(metaConsistencyQueueFailures),
+		ConsistencyQueueRepairs:                   metric.NewCounter(metaConsistencyQueueRepairs),
 		ConsistencyQueuePending:                   metric.NewGauge(metaConsistencyQueuePending),
 		ConsistencyQueueProcessingNanos:           metric.NewCounter(metaConsistencyQueueProcessingNanos),
 		LeaseQueueSuccesses:                       metric.NewCounter(metaLeaseQueueSuccesses),
@@ -3758,6 +3782,27 @@ func (sm *TenantsStorageMetrics) subtractMVCCStats(
 	sm.incMVCCGauges(ctx, ref, neg)
 }
 
+// tenantStorageStats returns the part of a replica's MVCC stats (or of a delta
+// of them) that counts towards the per-tenant storage metrics of its store.
+// Witnesses maintain the MVCC stats of the whole range, since they are part of
+// the replicated state, but they only store the range's system data and lock
+// table, so the stats of the user data are left out for them.
+func tenantStorageStats(ms enginepb.MVCCStats, witness bool) enginepb.MVCCStats {
+	if !witness {
+		return ms
+	}
+	return enginepb.MVCCStats{
+		ContainsEstimates: ms.ContainsEstimates,
+		LastUpdateNanos:   ms.LastUpdateNanos,
+		LockAge:           ms.LockAge,
+		LockBytes:         ms.LockBytes,
+		LockCount:         ms.LockCount,
+		SysBytes:          ms.SysBytes,
+		SysCount:          ms.SysCount,
+		AbortSpanBytes:    ms.AbortSpanBytes,
+	}
+}
+
 func (sm *StoreMetrics) updateEngineMetrics(m storage.Metrics) {
 	sm.RdbBlockCacheHits.Update(m.BlockCache.Hits)
 	sm.RdbBlockCacheMisses.Update(m.BlockCache.Misses)
@@ -46,6 +46,30 @@ func TestTenantsStorageMetricsRelease(t *testing.T) {
 	require.Zero(t, m.KeyCount.Value())
 }
 
+// TestTenantStorageStatsWitness checks that the per-tenant storage metrics only
+// count the system data and the locks of witnesses.
+func TestTenantStorageStatsWitness(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	ms := enginepb.MVCCStats{
+		LiveBytes: 10, LiveCount: 1, KeyBytes: 4, KeyCount: 1, ValBytes: 6, ValCount: 1,
+		IntentBytes: 3, IntentCount: 1, LockBytes: 5, LockCount: 1, LockAge: 7,
+		RangeKeyBytes: 2, RangeKeyCount: 1, GCBytesAge: 8,
+		SysBytes: 20, SysCount: 2, AbortSpanBytes: 9,
+	}
+	require.Equal(t, ms, tenantStorageStats(ms, false /* witness */))
+	require.Equal(t, enginepb.MVCCStats{
+		LockBytes: 5, LockCount: 1, LockAge: 7, SysBytes: 20, SysCount: 2, AbortSpanBytes: 9,
+	}, tenantStorageStats(ms, true /* witness */))
+
+	// The stats of a delta are the delta of the stats, so that the contribution
+	// of a witness can be maintained incrementally.
+	delta := ms
+	delta.Add(ms)
+	full := tenantStorageStats(delta, true /* witness */)
+	full.Subtract(tenantStorageStats(ms, true /* witness */))
+	require.Equal(t, tenantStorageStats(ms, true /* witness */), full)
+}
+
 // TestTenantsStorageMetricsConcurrency exercises the concurrency logic of the
 // TenantsStorageMetrics and ensures that none of the assertions are hit.
 // The test doesn't meaningfully exercise the logic which is tested elsewhere.
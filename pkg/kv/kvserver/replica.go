@@ -2360,6 +2360,64 @@ func (r *Replica) maybeTransferRaftLeadershipToLeaseholderLocked(
 	}
 }
 
+// isWitnessRLocked returns true if the replica is a witness, as of its current
+// range descriptor.
+func (r *Replica) isWitnessRLocked() bool {
+	repDesc, ok := r.mu.state.Desc.GetReplicaDescriptorByID(r.replicaID)
+	return ok && repDesc.IsWitness()
+}
+
+// isWitness is like isWitnessRLocked, but acquires the replica's mutex.
+func (r *Replica) isWitness() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.isWitnessRLocked()
+}
+
+// tenantStorageMVCCStats returns the replica's contribution to the per-tenant
+// storage metrics of the store. See tenantStorageStats.
+func (r *Replica) tenantStorageMVCCStats() enginepb.MVCCStats {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return tenantStorageStats(*r.mu.state.Stats, r.isWitnessRLocked())
+}
+
+// maybeTransferRaftLeadershipFromWitnessLocked attempts to transfer the
+// leadership away from this replica if it is a witness. Witnesses are voters,
+// so they can win elections that they don't call themselves (e.g. when the
+// election timeout elapses), but they can't hold the lease nor send snapshots
+// that include user data, so they should not remain the leader of the range.
+// Leadership is transferred to the replica storing user data that is furthest
+// ahead on the log, once it has caught up with the commit index.
+func (r *Replica) maybeTransferRaftLeadershipFromWitnessLocked(ctx context.Context) {
+	if fn := r.store.TestingKnobs().DisableLeaderTransferFromWitness; fn != nil && fn() {
+		return
+	}
+	if !r.isRaftLeaderRLocked() || !r.isWitnessRLocked() { // fast path
+		return
+	}
+	raftStatus := r.raftSparseStatusRLocked()
+	if raftStatus == nil || raftStatus.RaftState != raft.StateLeader {
+		return
+	}
+	var target raftpb.PeerID
+	var targetMatch uint64
+	for _, repDesc := range r.mu.state.Desc.Replicas().VoterDescriptors() {
+		pr, ok := raftStatus.Progress[raftpb.PeerID(repDesc.ReplicaID)]
+		if !ok || !pr.RecentActive {
+			continue
+		}
+		if target == raft.None || pr.Match > targetMatch {
+			target, targetMatch = raftpb.PeerID(repDesc.ReplicaID), pr.Match
+		}
+	}
+	if target != raft.None && targetMatch >= raftStatus.Commit {
+		log.VEventf(ctx, 1, "transferring raft leadership away from witness to replica ID %v", target)
+		r.store.metrics.RangeRaftLeaderTransfers.Inc(1)
+		r.mu.internalRaftGroup.TransferLeader(target)
+	}
+}
+
 func (r *Replica) getReplicaDescriptorByIDRLocked(
 	replicaID roachpb.ReplicaID, fallback roachpb.ReplicaDescriptor,
 ) (roachpb.ReplicaDescriptor, error) {
@@ -2469,8 +2527,15 @@ func (r *Replica) MeasureRaftCPUNanos(start time.Duration) {
 func (r *Replica) RangeUsageInfo() allocator.RangeUsageInfo {
 	loadStats := r.LoadStats()
 	localityInfo := r.loadStats.RequestLocalityInfo()
+	logicalBytes := r.GetMVCCStats().Total()
+	if repDesc, ok := r.Desc().GetReplicaDescriptorByID(r.replicaID); ok && repDesc.IsWitness() {
+		// Witnesses maintain the range's MVCC stats as part of the replicated
+		// state, but they don't store the user data these stats account for, so
+		// the range doesn't contribute to the store's logical bytes.
+		logicalBytes = 0
+	}
 	return allocator.RangeUsageInfo{
-		LogicalBytes:             r.GetMVCCStats().Total(),
+		LogicalBytes:             logicalBytes,
 		QueriesPerSecond:         loadStats.QueriesPerSecond,
 		WritesPerSecond:          loadStats.WriteKeysPerSecond,
 		ReadsPerSecond:           loadStats.ReadKeysPerSecond,
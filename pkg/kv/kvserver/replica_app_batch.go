@@ -20,6 +20,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverbase"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverpb"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvstorage"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/rditer"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/storage"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
@@ -136,8 +137,14 @@ func (b *replicaAppBatch) Stage(
 		return nil, err
 	}
 
-	// Stage the command's write batch in the application batch.
-	if err := b.ab.addWriteBatch(ctx, b.batch, cmd); err != nil {
+	// Stage the command's write batch in the application batch. Witnesses
+	// don't store user data, so they only stage the range-local portion of it.
+	witness := b.isWitness()
+	if witness {
+		if err := b.ab.addWitnessWriteBatch(ctx, b.batch, cmd, b.state.Desc); err != nil {
+			return nil, err
+		}
+	} else if err := b.ab.addWriteBatch(ctx, b.batch, cmd); err != nil {
 		return nil, err
 	}
 
@@ -150,6 +157,7 @@ func (b *replicaAppBatch) Stage(
 		eng:         b.r.store.TODOEngine(),
 		sideloaded:  b.r.raftMu.sideloaded,
 		bulkLimiter: b.r.store.limiters.BulkIOWriteRate,
+		witness:     witness,
 	}); err != nil {
 		return nil, err
 	}
@@ -175,6 +183,25 @@ func (b *replicaAppBatch) Stage(
 	return cmd, nil
 }
 
+// isWitness returns true if the local replica is a witness as of the range
+// descriptor in the batch's state.
+func (b *replicaAppBatch) isWitness() bool {
+	repDesc, ok := b.state.Desc.GetReplicaDescriptor(b.r.store.StoreID())
+	return ok && repDesc.IsWitness()
+}
+
+// changePromotesToWitness returns true if the change turns the replica on the
+// given store into a witness.
+func changePromotesToWitness(
+	desc *roachpb.RangeDescriptor, change *kvserverpb.ChangeReplicas, storeID roachpb.StoreID,
+) bool {
+	if prev, ok := desc.GetReplicaDescriptor(storeID); !ok || prev.IsWitness() {
+		return false
+	}
+	next, ok := change.Desc.GetReplicaDescriptor(storeID)
+	return ok && next.IsWitness()
+}
+
 // changeRemovesStore returns true if any of the removals in this change have storeID.
 func changeRemovesStore(
 	desc *roachpb.RangeDescriptor, change *kvserverpb.ChangeReplicas, storeID roachpb.StoreID,
@@ -519,6 +546,20 @@ func (b *replicaAppBatch) runPostAddTriggersReplicaOnly(
 		}
 	}
 
+	// If this command promotes us from a learner to a witness, clear out the
+	// user data. The learner was initialized with a snapshot that didn't include
+	// any, but it may have applied user writes from the log since.
+	if change := res.ChangeReplicas; change != nil && !b.changeRemovesReplica &&
+		changePromotesToWitness(b.state.Desc, change, b.r.store.StoreID()) {
+		for _, span := range rditer.MakeReplicatedKeySpansUserOnly(b.state.Desc) {
+			if err := b.batch.ClearRawRange(
+				span.Key, span.EndKey, true /* pointKeys */, true, /* rangeKeys */
+			); err != nil {
+				return errors.Wrapf(err, "unable to clear user data for witness")
+			}
+		}
+	}
+
 	// Provide the command's corresponding logical operations to the Replica's
 	// rangefeed. Only do so if the WriteBatch is non-nil, in which case the
 	// rangefeed requires there to be a corresponding logical operation log or
@@ -649,6 +690,7 @@ func (b *replicaAppBatch) ApplyToStateMachine(ctx context.Context) error {
 	needsSplitBySize := r.needsSplitBySizeRLocked()
 	needsMergeBySize := r.needsMergeBySizeRLocked()
 	needsTruncationByLogSize := r.needsRaftLogTruncationLocked()
+	witness := r.isWitnessRLocked()
 	r.mu.Unlock()
 	if closedTimestampUpdated {
 		r.handleClosedTimestampUpdateRaftMuLocked(ctx, b.state.RaftClosedTimestamp)
@@ -657,7 +699,7 @@ func (b *replicaAppBatch) ApplyToStateMachine(ctx context.Context) error {
 	// Record the stats delta in the StoreMetrics.
 	deltaStats := *b.state.Stats
 	deltaStats.Subtract(prevStats)
-	r.store.metrics.addMVCCStats(ctx, r.tenantMetricsRef, deltaStats)
+	r.store.metrics.addMVCCStats(ctx, r.tenantMetricsRef, tenantStorageStats(deltaStats, witness))
 
 	// Record the number of keys written to the replica.
 	b.r.loadStats.RecordWriteKeys(float64(b.ab.numMutations))
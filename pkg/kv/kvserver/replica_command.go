@@ -12,6 +12,7 @@ package kvserver
 
 import (
 	"bytes"
+	"cmp"
 	"context"
 	"fmt"
 	"math/rand"
@@ -1190,6 +1191,17 @@ func (r *Replica) changeReplicasImpl(
 	if err := validateReplicationChanges(desc, chgs); err != nil {
 		return nil, errors.Mark(err, errMarkInvalidReplicationChange)
 	}
+	if !r.ClusterSettings().Version.IsActive(ctx, clusterversion.V24_2_WitnessReplicas) {
+		// Nodes running older versions can't apply a descriptor that contains a
+		// witness.
+		for _, chg := range chgs {
+			if chg.ChangeType == roachpb.ADD_WITNESS {
+				return nil, errors.Mark(errors.Newf(
+					"witness replicas are not supported until the cluster is upgraded to %s",
+					clusterversion.V24_2_WitnessReplicas.Version()), errMarkInvalidReplicationChange)
+			}
+		}
+	}
 	targets := SynthesizeTargetsByChangeType(chgs)
 
 	// NB: As of the time of this writing,`AdminRelocateRange` will only execute
@@ -1203,6 +1215,8 @@ func (r *Replica) changeReplicasImpl(
 	// 3. Voter removals
 	// 4. Non-voter additions
 	// 5. Non-voter removals
+	// 6. Witness additions
+	// 7. Witness removals
 	//
 	// This order is meant to be symmetric with how the allocator prioritizes
 	// these actions. Broadly speaking, we first want to add a missing voter (and
@@ -1314,6 +1328,54 @@ func (r *Replica) changeReplicasImpl(
 		}
 	}
 
+	if adds := targets.WitnessAdditions; len(adds) > 0 {
+		// Witnesses are added as learners and sent a snapshot without any user
+		// data. Once that's done, the learners are promoted to witnesses, at which
+		// point they start voting.
+		desc, err = r.initializeRaftLearners(
+			ctx, desc, senderName, senderQueuePriority, reason, details, adds, roachpb.WITNESS,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if fn := r.store.cfg.TestingKnobs.WitnessAddStopAfterLearnerSnapshot; fn != nil && fn(adds) {
+			return desc, nil
+		}
+		for _, target := range adds {
+			iChgs := []internalReplicationChange{{target: target, typ: internalChangeTypePromoteLearnerToWitness}}
+			desc, err = execChangeReplicasTxn(ctx, r.store.cfg.Tracer(), desc, reason, details, iChgs,
+				changeReplicasTxnArgs{
+					db:                                   r.store.DB(),
+					liveAndDeadReplicas:                  r.store.cfg.StorePool.LiveAndDeadReplicas,
+					logChange:                            r.store.logChange,
+					testForceJointConfig:                 r.store.TestingKnobs().ReplicationAlwaysUseJointConfig,
+					testAllowDangerousReplicationChanges: r.store.TestingKnobs().AllowDangerousReplicationChanges,
+				})
+			if err != nil {
+				log.Infof(ctx, "could not promote %v to witness, rolling back: %v", target, err)
+				r.tryRollbackRaftLearner(ctx, r.Desc(), target, reason, details)
+				return nil, err
+			}
+		}
+	}
+
+	if removals := targets.WitnessRemovals; len(removals) > 0 {
+		for _, rem := range removals {
+			iChgs := []internalReplicationChange{{target: rem, typ: internalChangeTypeRemoveWitness}}
+			desc, err = execChangeReplicasTxn(ctx, r.store.cfg.Tracer(), desc, reason, details, iChgs,
+				changeReplicasTxnArgs{
+					db:                                   r.store.DB(),
+					liveAndDeadReplicas:                  r.store.cfg.StorePool.LiveAndDeadReplicas,
+					logChange:                            r.store.logChange,
+					testForceJointConfig:                 r.store.TestingKnobs().ReplicationAlwaysUseJointConfig,
+					testAllowDangerousReplicationChanges: r.store.TestingKnobs().AllowDangerousReplicationChanges,
+				})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	if len(targets.VoterDemotions) > 0 {
 		// If we demoted or swapped any voters with non-voters, we likely are in a
 		// joint config or have learners on the range. Let's exit the joint config
@@ -1330,6 +1392,7 @@ type TargetsForReplicationChanges struct {
 	VoterDemotions, NonVoterPromotions  []roachpb.ReplicationTarget
 	VoterAdditions, VoterRemovals       []roachpb.ReplicationTarget
 	NonVoterAdditions, NonVoterRemovals []roachpb.ReplicationTarget
+	WitnessAdditions, WitnessRemovals   []roachpb.ReplicationTarget
 }
 
 // SynthesizeTargetsByChangeType groups replication changes in the
@@ -1356,6 +1419,10 @@ func SynthesizeTargetsByChangeType(
 	result.NonVoterAdditions = subtractTargets(chgs.NonVoterAdditions(), chgs.VoterRemovals())
 	result.NonVoterRemovals = subtractTargets(chgs.NonVoterRemovals(), chgs.VoterAdditions())
 
+	// Witnesses are never promoted or demoted.
+	result.WitnessAdditions = chgs.WitnessAdditions()
+	result.WitnessRemovals = chgs.WitnessRemovals()
+
 	return result
 }
 
@@ -1614,6 +1681,11 @@ func validateAdditionsPerStore(
 					return errors.AssertionFailedf(
 						"trying to add a non-voter to a store that already has a %s", t)
 				}
+			case roachpb.WITNESS:
+				// Witnesses can't be promoted or demoted, so there's never a reason
+				// to add another replica to a store that has one.
+				return errors.AssertionFailedf(
+					"trying to add(%+v) to a store that already has a %s", chg, t)
 			default:
 				return errors.AssertionFailedf("store(%d) being added to already contains a"+
 					" replica of an unexpected type: %s", storeID, t)
@@ -1648,6 +1720,11 @@ func validateRemovals(desc *roachpb.RangeDescriptor, chgsByStoreID changesByStor
 					return errors.AssertionFailedf("type of replica being removed (%s) does not match"+
 						" expectation for change: %+v", t, chg)
 				}
+			case roachpb.WITNESS:
+				if chg.ChangeType != roachpb.REMOVE_WITNESS {
+					return errors.AssertionFailedf("type of replica being removed (%s) does not match"+
+						" expectation for change: %+v", t, chg)
+				}
 			default:
 				return errors.AssertionFailedf("unexpected replica type for removal %+v: %s", chg, t)
 			}
@@ -1809,10 +1886,29 @@ func validateReplicationChanges(desc *roachpb.RangeDescriptor, chgs kvpb.Replica
 	if err := validateOneReplicaPerNode(desc, chgsByNodeID); err != nil {
 		return err
 	}
+	if err := validateWitnessChanges(chgs); err != nil {
+		return err
+	}
 
 	return nil
 }
 
+// validateWitnessChanges ensures that additions and removals of witnesses are
+// not combined with any other replication change. Witnesses are always added
+// and removed through simple (non-joint) configuration changes.
+func validateWitnessChanges(chgs kvpb.ReplicationChanges) error {
+	if len(chgs) <= 1 {
+		return nil
+	}
+	for _, chg := range chgs {
+		if chg.ChangeType == roachpb.ADD_WITNESS || chg.ChangeType == roachpb.REMOVE_WITNESS {
+			return errors.AssertionFailedf("witness changes must be carried out one at a"+
+				" time; got %+v", chgs)
+		}
+	}
+	return nil
+}
+
 // changesByStoreID represents a map from StoreID to a slice of replication
 // changes on that store.
 type changesByStoreID map[roachpb.StoreID][]kvpb.ReplicationChange
@@ -1862,7 +1958,9 @@ func (r *Replica) initializeRaftLearners(
 ) (afterDesc *roachpb.RangeDescriptor, err error) {
 	var iChangeType internalChangeType
 	switch replicaType {
-	case roachpb.LEARNER:
+	case roachpb.LEARNER, roachpb.WITNESS:
+		// Witnesses are added as learners and promoted once they've received
+		// their initial snapshot, see changeReplicasImpl.
 		iChangeType = internalChangeTypeAddLearner
 	case roachpb.NON_VOTER:
 		iChangeType = internalChangeTypeAddNonVoter
@@ -1977,7 +2075,8 @@ func (r *Replica) initializeRaftLearners(
 			return nil, errors.Errorf("programming error: replica %v not found in %v", target, desc)
 		}
 
-		if rDesc.Type != replicaType {
+		if expType := replicaType; rDesc.Type != expType &&
+			!(expType == roachpb.WITNESS && rDesc.Type == roachpb.LEARNER) {
 			return nil, errors.Errorf("programming error: cannot promote replica of type %s", rDesc.Type)
 		}
 
@@ -2001,7 +2100,10 @@ func (r *Replica) initializeRaftLearners(
 		// orphaned learner. Second, this tickled some bugs in etcd/raft around
 		// switching between StateSnapshot and StateProbe. Even if we worked through
 		// these, it would be susceptible to future similar issues.
-		if err := r.sendSnapshotUsingDelegate(ctx, rDesc, senderName, senderQueuePriority); err != nil {
+		excludeUserData := replicaType == roachpb.WITNESS
+		if err := r.sendSnapshotUsingDelegate(
+			ctx, rDesc, senderName, senderQueuePriority, excludeUserData,
+		); err != nil {
 			return nil, err
 		}
 	}
@@ -2192,6 +2294,11 @@ const (
 	// https://github.com/cockroachdb/cockroach/pull/40268
 	internalChangeTypeRemoveLearner
 	internalChangeTypeRemoveNonVoter
+	// internalChangeTypePromoteLearnerToWitness turns a learner that was
+	// initialized without user data into a witness. Like the removal of a
+	// witness, it is always carried out as a simple (non-joint) change.
+	internalChangeTypePromoteLearnerToWitness
+	internalChangeTypeRemoveWitness
 )
 
 // internalReplicationChange is a replication target together with an internal
@@ -2318,6 +2425,29 @@ func prepareChangeReplicasTrigger(
 				}
 				rDesc, _, _ = updatedDesc.SetReplicaType(chg.target.NodeID, chg.target.StoreID, roachpb.VOTER_DEMOTING_NON_VOTER)
 				removed = append(removed, rDesc)
+			case internalChangeTypePromoteLearnerToWitness:
+				if chgs.useJoint() {
+					return nil, errors.AssertionFailedf("witness changes cannot use joint consensus")
+				}
+				rDesc, prevTyp, ok := updatedDesc.SetReplicaType(chg.target.NodeID, chg.target.StoreID, roachpb.WITNESS)
+				if !ok || prevTyp != roachpb.LEARNER {
+					return nil, errors.Errorf("cannot promote target %v which is missing as LEARNER",
+						chg.target)
+				}
+				added = append(added, rDesc)
+			case internalChangeTypeRemoveWitness:
+				if chgs.useJoint() {
+					return nil, errors.AssertionFailedf("witness changes cannot use joint consensus")
+				}
+				rDesc, ok := updatedDesc.GetReplicaDescriptor(chg.target.StoreID)
+				if !ok {
+					return nil, errors.Errorf("target %s not found", chg.target)
+				}
+				if prevTyp := rDesc.Type; prevTyp != roachpb.WITNESS {
+					return nil, errors.Errorf("cannot remove target %v of type %s as a witness", chg.target, prevTyp)
+				}
+				rDesc, _ = updatedDesc.RemoveReplica(chg.target.NodeID, chg.target.StoreID)
+				removed = append(removed, rDesc)
 			default:
 				return nil, errors.Errorf("unsupported internal change type %d", chg.typ)
 			}
@@ -2698,8 +2828,13 @@ func recordRangeEventsInLog(
 // follower replica to act as the sender for delegated snapshots. The replicas
 // should be tried in order, and typically the coordinator is the last entry on
 // the list.
+//
+// Witnesses don't store user data, so they are never returned as senders of
+// snapshots that include it. In particular, a witness coordinator (which can
+// happen if the witness is briefly the Raft leader) always delegates to a
+// replica that stores user data, regardless of the NumDelegateLimit setting.
 func (r *Replica) getSenderReplicas(
-	ctx context.Context, recipient roachpb.ReplicaDescriptor,
+	ctx context.Context, recipient roachpb.ReplicaDescriptor, excludeUserData bool,
 ) ([]roachpb.ReplicaDescriptor, error) {
 
 	coordinator, err := r.GetReplicaDescriptor()
@@ -2707,10 +2842,11 @@ func (r *Replica) getSenderReplicas(
 		// If there is no local replica descriptor, return an empty list.
 		return nil, err
 	}
+	coordinatorCanSend := excludeUserData || !coordinator.IsWitness()
 
 	// Check follower snapshots, if zero just self-delegate.
 	numFollowers := int(NumDelegateLimit.Get(&r.ClusterSettings().SV))
-	if numFollowers == 0 {
+	if numFollowers == 0 && coordinatorCanSend {
 		return []roachpb.ReplicaDescriptor{coordinator}, nil
 	}
 
@@ -2726,7 +2862,8 @@ func (r *Replica) getSenderReplicas(
 		}
 	}
 
-	// Include voter and non-voter replicas on healthy stores as candidates.
+	// Include voter and non-voter replicas on healthy stores as candidates. Note
+	// that these never include witnesses.
 	nonRecipientReplicas := rangeDesc.Replicas().Filter(
 		func(rDesc roachpb.ReplicaDescriptor) bool {
 			return rDesc.ReplicaID != recipient.ReplicaID && storePool.IsStoreHealthy(rDesc.StoreID)
@@ -2736,6 +2873,11 @@ func (r *Replica) getSenderReplicas(
 	if len(candidates) == 0 {
 		// Not clear when the coordinator would be considered dead, but if it does
 		// happen, just return the coordinator.
+		if !coordinatorCanSend {
+			return nil, errors.Errorf(
+				"%s: witness %s has no replica with user data to delegate a snapshot to",
+				r, coordinator)
+		}
 		return []roachpb.ReplicaDescriptor{coordinator}, nil
 	}
 
@@ -2777,24 +2919,40 @@ func (r *Replica) getSenderReplicas(
 	pRand := rand.New(rand.NewSource(int64(coordinator.ReplicaID)))
 	pRand.Shuffle(len(tiedReplicas), func(i, j int) { tiedReplicas[i], tiedReplicas[j] = tiedReplicas[j], tiedReplicas[i] })
 
-	// Only keep the top numFollowers replicas.
-	if len(tiedReplicas) > numFollowers {
+	// Only keep the top numFollowers replicas. A witness coordinator can't fall
+	// back to sending the snapshot itself, so it tries all the candidates
+	// instead, the closest ones first.
+	if !coordinatorCanSend {
+		var others []roachpb.ReplicaID
+		for replID, score := range replicaDistance {
+			if score != closestStore {
+				others = append(others, replID)
+			}
+		}
+		slices.SortFunc(others, func(a, b roachpb.ReplicaID) int {
+			return cmp.Compare(replicaDistance[a], replicaDistance[b])
+		})
+		tiedReplicas = append(tiedReplicas, others...)
+	} else if len(tiedReplicas) > numFollowers {
 		tiedReplicas = tiedReplicas[:numFollowers]
 	}
 
 	// Convert to replica descriptors before returning. The list of tiedReplicas
 	// is typically only one element.
-	replicaList := make([]roachpb.ReplicaDescriptor, len(tiedReplicas)+1)
-	for n, replicaId := range tiedReplicas {
+	replicaList := make([]roachpb.ReplicaDescriptor, 0, len(tiedReplicas)+1)
+	for _, replicaId := range tiedReplicas {
 		found := false
 		replDesc, found := rangeDesc.Replicas().GetReplicaDescriptorByID(replicaId)
 		if !found {
 			return nil, errors.Errorf("unable to find replica for replicaId %d", replicaId)
 		}
-		replicaList[n] = replDesc
+		replicaList = append(replicaList, replDesc)
+	}
+	// Set the last replica to be the coordinator, unless it is a witness and
+	// can't send this snapshot.
+	if coordinatorCanSend {
+		replicaList = append(replicaList, coordinator)
 	}
-	// Set the last replica to be the coordinator.
-	replicaList[len(replicaList)-1] = coordinator
 	return replicaList, nil
 }
 
@@ -2925,6 +3083,7 @@ func (r *Replica) sendSnapshotUsingDelegate(
 	recipient roachpb.ReplicaDescriptor,
 	senderQueueName kvserverpb.SnapshotRequest_QueueName,
 	senderQueuePriority float64,
+	excludeUserData bool,
 ) (retErr error) {
 
 	defer func() {
@@ -2987,10 +3146,11 @@ func (r *Replica) sendSnapshotUsingDelegate(
 		DescriptorGeneration: r.Desc().Generation,
 		QueueOnDelegateLen:   MaxQueueOnDelegateLimit.Get(&r.ClusterSettings().SV),
 		SnapId:               snapUUID,
+		ExcludeUserData:      excludeUserData,
 	}
 
 	// Get the list of senders in order.
-	senders, err := r.getSenderReplicas(ctx, recipient)
+	senders, err := r.getSenderReplicas(ctx, recipient, excludeUserData)
 	if err != nil {
 		return err
 	}
@@ -3095,6 +3255,20 @@ func (r *Replica) validateSnapshotDelegationRequest(
 	// the leaseholder, and we haven't yet applied the configuration change that's
 	// adding the recipient to the range, or we are the leaseholder but have
 	// removed the recipient between starting to send the snapshot and this point.
+	// Witnesses don't store user data, so they must never send a snapshot that
+	// is expected to include it. The coordinator doesn't pick a witness as the
+	// delegate for such snapshots, but a replica may have become a witness since
+	// the coordinator last looked at it.
+	if !req.ExcludeUserData {
+		if repDesc, ok := desc.GetReplicaDescriptorByID(r.replicaID); ok && repDesc.IsWitness() {
+			err := errors.Errorf(
+				"%s: witness cannot send a snapshot including user data to %s", r, req.RecipientReplica,
+			)
+			log.VEventf(ctx, 2, "%v", err)
+			return err
+		}
+	}
+
 	if _, ok := desc.GetReplicaDescriptorByID(req.RecipientReplica.ReplicaID); !ok {
 		// Recipient replica not found in the current range descriptor.
 		// The sender replica's descriptor may be lagging behind the coordinator's.
@@ -3291,7 +3465,11 @@ func (r *Replica) followerSendSnapshot(
 	// a snapshot for a non-system range. This allows us to send metadata of
 	// sstables in shared storage as opposed to streaming their contents. Keys
 	// in higher levels of the LSM are still streamed in the snapshot.
-	nonSystemRange := snap.State.Desc.StartKey.AsRawKey().Compare(keys.TableDataMin) >= 0
+	//
+	// Snapshots that exclude user data (i.e. those sent to witnesses) have no
+	// use for either, since neither applies to range-local keys.
+	nonSystemRange := snap.State.Desc.StartKey.AsRawKey().Compare(keys.TableDataMin) >= 0 &&
+		!req.ExcludeUserData
 	sharedReplicate := r.store.cfg.SharedStorageEnabled && nonSystemRange
 
 	// Use external replication if we aren't using shared
@@ -3334,6 +3512,7 @@ func (r *Replica) followerSendSnapshot(
 		SenderQueuePriority: req.SenderQueuePriority,
 		SharedReplicate:     sharedReplicate,
 		ExternalReplicate:   externalReplicate,
+		ExcludeUserData:     req.ExcludeUserData,
 	}
 	newBatchFn := func() storage.WriteBatch {
 		return r.store.TODOEngine().NewWriteBatch()
@@ -37,6 +37,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
 	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
 	"github.com/cockroachdb/cockroach/pkg/util/quotapool"
 	"github.com/cockroachdb/cockroach/pkg/util/stop"
@@ -64,7 +65,9 @@ type replicaChecksum struct {
 // When req.Mode is CHECK_VIA_QUEUE and an inconsistency is detected, the
 // consistency check will be re-run to save storage engine checkpoints and
 // terminate suspicious nodes. This behavior should be lifted to the consistency
-// checker queue in the future.
+// checker queue in the future. If server.consistency_check.repair.enabled is
+// set, the suspicious replicas are instead removed from the range, and the
+// replicate queue replaces them with fresh copies sent by the leaseholder.
 func (r *Replica) CheckConsistency(
 	ctx context.Context, req kvpb.CheckConsistencyRequest,
 ) (kvpb.CheckConsistencyResponse, *kvpb.Error) {
@@ -230,6 +233,15 @@ func (r *Replica) checkConsistencyImpl(
 		return resp, nil
 	}
 
+	if consistencyCheckRepairEnabled.Get(&r.ClusterSettings().SV) {
+		if toRepair, ok := r.inconsistentReplicasToRepair(results, shaToIdxs, minoritySHA); ok {
+			r.repairInconsistentReplicas(ctx, args, res.Detail, toRepair)
+			return resp, nil
+		}
+		log.Warningf(ctx, "consistency check failed; unable to determine which replicas "+
+			"to repair, falling back to terminating the minority")
+	}
+
 	// No checkpoint was requested, so we want to re-run the check with
 	// checkpoints and termination of suspicious nodes. Note that this recursive
 	// call will be terminated in the `args.Checkpoint` branch above.
@@ -264,6 +276,98 @@ func (r *Replica) checkConsistencyImpl(
 	return resp, nil
 }
 
+// inconsistentReplicasToRepair returns the replicas that the consistency check
+// found to be in the minority, if they can be safely repaired. This is the case
+// only if there are exactly two distinct checksums, the majority checksum is
+// reported by a quorum of the replicas that were checked, and this replica (the
+// leaseholder) is part of the majority.
+func (r *Replica) inconsistentReplicasToRepair(
+	results []ConsistencyCheckResult, shaToIdxs map[string][]int, minoritySHA string,
+) ([]roachpb.ReplicaDescriptor, bool) {
+	if len(shaToIdxs) != 2 {
+		return nil, false
+	}
+	var toRepair []roachpb.ReplicaDescriptor
+	for sha, idxs := range shaToIdxs {
+		if sha != minoritySHA {
+			if 2*len(idxs) <= len(results) {
+				return nil, false
+			}
+			continue
+		}
+		for _, idx := range idxs {
+			rDesc := results[idx].Replica
+			if rDesc.ReplicaID == r.replicaID {
+				return nil, false
+			}
+			if typ := rDesc.Type; typ != roachpb.VOTER_FULL && typ != roachpb.NON_VOTER {
+				return nil, false
+			}
+			toRepair = append(toRepair, rDesc)
+		}
+	}
+	return toRepair, true
+}
+
+// repairInconsistentReplicas archives the details of an inconsistency, and
+// removes the given minority replicas from the range. The storage engine
+// checkpoints taken on all replicas, and the replicas themselves until they are
+// garbage collected, preserve the diverged data for investigation. The
+// replicate queue then up-replicates the range with fresh snapshots sent by the
+// leaseholder.
+func (r *Replica) repairInconsistentReplicas(
+	ctx context.Context,
+	args kvpb.ComputeChecksumRequest,
+	detail string,
+	toRepair []roachpb.ReplicaDescriptor,
+) {
+	{
+		var tmp redact.SafeFormatter = roachpb.MakeReplicaSet(toRepair)
+		log.Errorf(ctx, "consistency check failed; fetching details and repairing minority %v", tmp)
+	}
+
+	// Take checkpoints on all replicas, but don't terminate any of them.
+	args.Checkpoint = true
+	if _, pErr := r.checkConsistencyImpl(ctx, args); pErr != nil {
+		log.Errorf(ctx, "replica inconsistency detected; second round failed: %s", pErr)
+	}
+
+	tag := fmt.Sprintf("r%d_%s", r.RangeID, timeutil.Now().UTC().Format("20060102T150405"))
+	path, err := r.store.archiveInconsistency(tag, detail)
+	if err != nil {
+		log.Errorf(ctx, "failed to archive inconsistency details: %v", err)
+	}
+
+	for _, rDesc := range toRepair {
+		changeType := roachpb.REMOVE_VOTER
+		if rDesc.Type == roachpb.NON_VOTER {
+			changeType = roachpb.REMOVE_NON_VOTER
+		}
+		event := &eventpb.RepairInconsistentReplica{
+			NodeID:      int32(r.store.NodeID()),
+			RangeID:     int64(r.RangeID),
+			StoreID:     int32(rDesc.StoreID),
+			ReplicaID:   int32(rDesc.ReplicaID),
+			ArchivePath: path,
+		}
+		if _, err := r.ChangeReplicas(ctx, r.Desc(), kvserverpb.ReasonInconsistentReplica, "", /* details */
+			kvpb.MakeReplicationChanges(changeType, roachpb.ReplicationTarget{
+				NodeID:  rDesc.NodeID,
+				StoreID: rDesc.StoreID,
+			}),
+		); err != nil {
+			log.Errorf(ctx, "failed to remove inconsistent replica %v: %v", rDesc, err)
+			event.ErrorMessage = err.Error()
+		} else {
+			r.store.metrics.ConsistencyQueueRepairs.Inc(1)
+			event.Success = true
+		}
+		r.store.logStructuredEvent(ctx, event)
+	}
+
+	r.store.replicateQueue.MaybeAddAsync(ctx, r, r.store.Clock().NowAsClockTimestamp())
+}
+
 // A ConsistencyCheckResult contains the outcome of a CollectChecksum call.
 type ConsistencyCheckResult struct {
 	Replica  roachpb.ReplicaDescriptor
@@ -307,7 +411,11 @@ func (r *Replica) runConsistencyCheck(
 	}
 	ccRes := res.(*kvpb.ComputeChecksumResponse)
 
-	replicas := r.Desc().Replicas().Descriptors()
+	// Witnesses don't store user data and don't compute checksums, so there's
+	// nothing to compare against.
+	replicas := r.Desc().Replicas().FilterToDescriptors(func(rDesc roachpb.ReplicaDescriptor) bool {
+		return !rDesc.IsWitness()
+	})
 	resultCh := make(chan ConsistencyCheckResult, len(replicas))
 	results := make([]ConsistencyCheckResult, 0, len(replicas))
 
@@ -669,6 +777,11 @@ func CalcReplicaDigest(
 func (r *Replica) computeChecksumPostApply(
 	ctx context.Context, cc kvserverpb.ComputeChecksum,
 ) (err error) {
+	// Witnesses don't store user data, so their checksums can't be compared
+	// against those of the other replicas. They aren't asked for one either.
+	if repDesc, err := r.GetReplicaDescriptor(); err == nil && repDesc.IsWitness() {
+		return nil
+	}
 	c, cleanup := r.trackReplicaChecksum(cc.ChecksumID)
 	defer func() {
 		if err != nil {
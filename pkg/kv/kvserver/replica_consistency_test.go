@@ -350,3 +350,66 @@ func TestReplicaChecksumSHA512(t *testing.T) {
 
 	echotest.Require(t, sb.String(), datapathutils.TestDataPath(t, "replica_consistency_sha512"))
 }
+
+func TestInconsistentReplicasToRepair(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	voter := func(id int) roachpb.ReplicaDescriptor {
+		return roachpb.ReplicaDescriptor{
+			NodeID:    roachpb.NodeID(id),
+			StoreID:   roachpb.StoreID(id),
+			ReplicaID: roachpb.ReplicaID(id),
+			Type:      roachpb.VOTER_FULL,
+		}
+	}
+	learner := voter(4)
+	learner.Type = roachpb.LEARNER
+
+	for _, tc := range []struct {
+		name     string
+		replicas []roachpb.ReplicaDescriptor
+		shas     []string
+		want     []roachpb.ReplicaDescriptor
+	}{{
+		name:     "single-minority",
+		replicas: []roachpb.ReplicaDescriptor{voter(1), voter(2), voter(3)},
+		shas:     []string{"a", "a", "b"},
+		want:     []roachpb.ReplicaDescriptor{voter(3)},
+	}, {
+		name:     "leaseholder-in-minority",
+		replicas: []roachpb.ReplicaDescriptor{voter(1), voter(2), voter(3)},
+		shas:     []string{"a", "b", "b"},
+	}, {
+		name:     "no-majority",
+		replicas: []roachpb.ReplicaDescriptor{voter(1), voter(2), voter(3), voter(4)},
+		shas:     []string{"a", "a", "b", "b"},
+	}, {
+		name:     "three-checksums",
+		replicas: []roachpb.ReplicaDescriptor{voter(1), voter(2), voter(3)},
+		shas:     []string{"a", "b", "c"},
+	}, {
+		name:     "learner-in-minority",
+		replicas: []roachpb.ReplicaDescriptor{voter(1), voter(2), voter(3), learner},
+		shas:     []string{"a", "a", "a", "b"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			results := make([]ConsistencyCheckResult, len(tc.replicas))
+			shaToIdxs := map[string][]int{}
+			for i, rDesc := range tc.replicas {
+				results[i].Replica = rDesc
+				shaToIdxs[tc.shas[i]] = append(shaToIdxs[tc.shas[i]], i)
+			}
+			var minoritySHA string
+			for sha, idxs := range shaToIdxs {
+				if minoritySHA == "" || len(shaToIdxs[minoritySHA]) > len(idxs) {
+					minoritySHA = sha
+				}
+			}
+			r := &Replica{replicaID: 1}
+			got, ok := r.inconsistentReplicasToRepair(results, shaToIdxs, minoritySHA)
+			require.Equal(t, tc.want != nil, ok)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -12,13 +12,17 @@ package kvserver
 
 import (
 	"context"
+	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvbase"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/tracing"
 	"github.com/cockroachdb/cockroach/pkg/util/tracing/tracingpb"
 	"github.com/cockroachdb/redact"
@@ -119,6 +123,9 @@ func (r *Replica) canServeFollowerReadRLocked(ctx context.Context, ba *kvpb.Batc
 	maxClosed := r.getCurrentClosedTimestampLocked(ctx, requiredFrontier /* sufficient */)
 	canServeFollowerRead := requiredFrontier.LessEq(maxClosed)
 	tsDiff := requiredFrontier.GoTime().Sub(maxClosed.GoTime())
+	if !canServeFollowerRead && r.canServeLinearizableFollowerReadRLocked(ctx, ba) {
+		return true
+	}
 	if !canServeFollowerRead {
 		uncertaintyLimitStr := "n/a"
 		if ba.Txn != nil {
@@ -144,6 +151,144 @@ func (r *Replica) canServeFollowerReadRLocked(ctx context.Context, ba *kvpb.Batc
 	return true
 }
 
+// readIndex describes a read index obtained by a follower replica from the
+// leaseholder of its range on behalf of a batch with the
+// LinearizableFollowerRead flag. Once the follower has applied the lease
+// applied index, it has applied all writes to the span at or below the
+// frontier, and the leaseholder's timestamp cache prevents any new writes to
+// the span at or below the frontier. The follower can therefore serve reads
+// over the span up to the frontier, just like it could if its closed timestamp
+// had reached the frontier.
+type readIndex struct {
+	rangeID  roachpb.RangeID
+	span     roachpb.RSpan
+	frontier hlc.Timestamp
+	lai      kvpb.LeaseAppliedIndex
+	wait     time.Duration
+}
+
+type readIndexKey struct{}
+
+// contextWithReadIndex returns a context that carries the given read index
+// through the evaluation of the batch that it was obtained for.
+func contextWithReadIndex(ctx context.Context, ri *readIndex) context.Context {
+	return context.WithValue(ctx, readIndexKey{}, ri)
+}
+
+// readIndexFromContext returns the read index obtained for the batch that is
+// being evaluated with the given context, if any.
+func readIndexFromContext(ctx context.Context) *readIndex {
+	ri, _ := ctx.Value(readIndexKey{}).(*readIndex)
+	return ri
+}
+
+// maybeWaitForReadIndex is called for read-only batches before they are
+// sequenced. If the batch requests a linearizable follower read and the
+// replica can neither serve it under the lease nor under its closed
+// timestamp, it asks the leaseholder for a read index over the batch's span
+// and waits until it has applied it. The returned context carries the read
+// index, which allows canServeFollowerReadRLocked to accept the batch.
+//
+// If a read index cannot be obtained, the original context is returned, and
+// the batch is rejected with a NotLeaseHolderError like any other read that
+// the replica cannot serve.
+func (r *Replica) maybeWaitForReadIndex(
+	ctx context.Context, ba *kvpb.BatchRequest,
+) context.Context {
+	if !ba.LinearizableFollowerRead || !BatchCanBeEvaluatedOnFollower(ctx, ba) ||
+		!FollowerReadsEnabled.Get(&r.store.cfg.Settings.SV) {
+		return ctx
+	}
+	rSpan, err := keys.Range(ba.Requests)
+	if err != nil {
+		return ctx
+	}
+	requiredFrontier := ba.RequiredFrontier()
+	now := r.Clock().NowAsClockTimestamp()
+	if func() bool {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+		if r.ownsValidLeaseRLocked(ctx, now) {
+			return false
+		}
+		maxClosed := r.getCurrentClosedTimestampLocked(ctx, requiredFrontier /* sufficient */)
+		return requiredFrontier.LessEq(maxClosed)
+	}() {
+		// The batch can be served without a read index.
+		return ctx
+	}
+	ctx, sp := tracing.EnsureChildSpan(ctx, r.AmbientContext.Tracer, "read index")
+	defer sp.Finish()
+	start := timeutil.Now()
+	res, pErr := kv.SendWrappedWith(ctx, r.store.DB().NonTransactionalSender(), kvpb.Header{
+		Timestamp: requiredFrontier,
+	}, &kvpb.ReadIndexRequest{
+		RequestHeader: kvpb.RequestHeader{
+			Key:    rSpan.Key.AsRawKey(),
+			EndKey: rSpan.EndKey.AsRawKey(),
+		},
+	})
+	if pErr != nil {
+		log.VEventf(ctx, 2, "unable to obtain read index: %s", pErr)
+		return ctx
+	}
+	resp := res.(*kvpb.ReadIndexResponse)
+	if resp.RangeDesc.RangeID != r.RangeID {
+		// The range has been split or merged, so the lease applied index does not
+		// refer to this replica's log.
+		log.VEventf(ctx, 2, "read index obtained from r%d, not r%d", resp.RangeDesc.RangeID, r.RangeID)
+		return ctx
+	}
+	log.Eventf(ctx, "obtained read index %d at %s", resp.LeaseAppliedIndex, requiredFrontier)
+	if _, err := r.WaitForLeaseAppliedIndex(ctx, resp.LeaseAppliedIndex); err != nil {
+		log.VEventf(ctx, 2, "unable to apply read index: %s", err)
+		return ctx
+	}
+	return contextWithReadIndex(ctx, &readIndex{
+		rangeID:  r.RangeID,
+		span:     rSpan,
+		frontier: requiredFrontier,
+		lai:      resp.LeaseAppliedIndex,
+		wait:     timeutil.Since(start),
+	})
+}
+
+// canServeLinearizableFollowerReadRLocked tests, when a batch cannot be served
+// under the closed timestamp, whether it can be served using the read index
+// obtained for it by maybeWaitForReadIndex.
+func (r *Replica) canServeLinearizableFollowerReadRLocked(
+	ctx context.Context, ba *kvpb.BatchRequest,
+) bool {
+	if !ba.LinearizableFollowerRead {
+		return false
+	}
+	ri := readIndexFromContext(ctx)
+	if ri == nil || ri.rangeID != r.RangeID {
+		return false
+	}
+	rSpan, err := keys.Range(ba.Requests)
+	if err != nil || !ri.span.ContainsKeyRange(rSpan.Key, rSpan.EndKey) {
+		return false
+	}
+	// The batch's timestamp may have been bumped by a server-side refresh since
+	// the read index was obtained.
+	if !ba.RequiredFrontier().LessEq(ri.frontier) || r.mu.state.LeaseAppliedIndex < ri.lai {
+		return false
+	}
+
+	log.Eventf(ctx, "%s; linearizable read at read index %d (waited %s)",
+		redact.Safe(kvbase.FollowerReadServingMsg), ri.lai, ri.wait)
+	r.store.metrics.FollowerReadsCount.Inc(1)
+	r.store.metrics.LinearizableFollowerReadsCount.Inc(1)
+	if sp := tracing.SpanFromContext(ctx); sp.RecordingType() != tracingpb.RecordingOff {
+		sp.RecordStructured(&kvpb.UsedFollowerRead{
+			Linearizable:  true,
+			ReadIndexWait: ri.wait,
+		})
+	}
+	return true
+}
+
 // getCurrentClosedTimestampRLocked is like GetCurrentClosedTimestamp, except
 // that it requires r.mu to be RLocked. It also optionally takes a hint: if
 // sufficient is not empty, getClosedTimestampRLocked might return a timestamp
@@ -104,6 +104,102 @@ func TestCanServeFollowerRead(t *testing.T) {
 	}
 }
 
+// TestCanServeLinearizableFollowerRead verifies that a batch above the closed
+// timestamp can be served by a follower if it carries a read index covering
+// the batch.
+func TestCanServeLinearizableFollowerRead(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	ctx := context.Background()
+
+	manual := timeutil.NewManualTime(timeutil.Unix(0, 5))
+	clock := hlc.NewClockForTesting(manual)
+	tsc := TestStoreConfig(clock)
+	tc := testContext{manualClock: manual}
+	stopper := stop.NewStopper()
+	defer stopper.Stop(ctx)
+	tc.StartWithStoreConfig(ctx, t, stopper, tsc)
+
+	key := roachpb.Key("a")
+	write := putArgs(key, []byte("foo"))
+	_, pErr := tc.SendWrapped(&write)
+	require.NoError(t, pErr.GoError())
+
+	r := tc.repl
+	txn := roachpb.MakeTransaction(
+		"test",
+		key,
+		isolation.Serializable,
+		roachpb.NormalUserPriority,
+		clock.Now(),
+		clock.MaxOffset().Nanoseconds(),
+		0, // coordinatorNodeID
+		0,
+		false, // omitInRangefeeds
+	)
+	gArgs := getArgs(key)
+	ba := &kvpb.BatchRequest{}
+	ba.Header = kvpb.Header{Txn: &txn, LinearizableFollowerRead: true}
+	ba.Add(&gArgs)
+
+	span := roachpb.RSpan{Key: roachpb.RKey("a"), EndKey: roachpb.RKey("b")}
+	for _, c := range []struct {
+		name     string
+		ri       *readIndex
+		flag     bool
+		expServe bool
+	}{
+		{
+			name:     "no read index",
+			flag:     true,
+			expServe: false,
+		},
+		{
+			name:     "read index",
+			ri:       &readIndex{rangeID: r.RangeID, span: span, frontier: ba.RequiredFrontier()},
+			flag:     true,
+			expServe: true,
+		},
+		{
+			name:     "read index without flag",
+			ri:       &readIndex{rangeID: r.RangeID, span: span, frontier: ba.RequiredFrontier()},
+			flag:     false,
+			expServe: false,
+		},
+		{
+			name:     "read index for other range",
+			ri:       &readIndex{rangeID: r.RangeID + 1, span: span, frontier: ba.RequiredFrontier()},
+			flag:     true,
+			expServe: false,
+		},
+		{
+			name: "read index for other span",
+			ri: &readIndex{rangeID: r.RangeID, frontier: ba.RequiredFrontier(),
+				span: roachpb.RSpan{Key: roachpb.RKey("b"), EndKey: roachpb.RKey("c")}},
+			flag:     true,
+			expServe: false,
+		},
+		{
+			name:     "read index below frontier",
+			ri:       &readIndex{rangeID: r.RangeID, span: span, frontier: txn.ReadTimestamp.Prev()},
+			flag:     true,
+			expServe: false,
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			ctx := ctx
+			if c.ri != nil {
+				ctx = contextWithReadIndex(ctx, c.ri)
+			}
+			ba := ba.ShallowCopy()
+			ba.LinearizableFollowerRead = c.flag
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+			require.Equal(t, c.expServe, r.canServeFollowerReadRLocked(ctx, ba))
+		})
+	}
+}
+
 // Test that follower reads are permitted when the replica's lease is invalid.
 func TestCheckExecutionCanProceedAllowsFollowerReadWithInvalidLease(t *testing.T) {
 	defer leaktest.AfterTest(t)()
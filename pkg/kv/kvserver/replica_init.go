@@ -411,6 +411,15 @@ func (r *Replica) setDescLockedRaftMuLocked(ctx context.Context, desc *roachpb.R
 		r.mu.lastReplicaAddedTime = time.Time{}
 	}
 
+	// The per-tenant storage metrics only include the system data of witnesses
+	// (see tenantStorageStats), so move the replica's contribution over when it
+	// becomes or stops being one.
+	wasWitness, isWitness := r.isWitnessRLocked(), found && replDesc.IsWitness()
+	if wasWitness != isWitness && r.mu.state.Desc.IsInitialized() && r.mu.state.Stats != nil {
+		r.store.metrics.subtractMVCCStats(ctx, r.tenantMetricsRef, tenantStorageStats(*r.mu.state.Stats, wasWitness))
+		r.store.metrics.addMVCCStats(ctx, r.tenantMetricsRef, tenantStorageStats(*r.mu.state.Stats, isWitness))
+	}
+
 	r.rangeStr.store(r.replicaID, desc)
 	r.isInitialized.Store(desc.IsInitialized())
 	r.connectionClass.set(rpc.ConnectionClassForKey(desc.StartKey, defRaftConnClass))
@@ -274,11 +274,12 @@ func calcRangeCounter(
 // calcLiveVoterReplicas returns a count of the live voter replicas; a live
 // replica is determined by checking its node in the provided liveness map. This
 // method is used when indicating under-replication so only voter replicas are
-// considered.
+// considered. Witnesses count towards the range's voters.
 func calcLiveVoterReplicas(
 	desc *roachpb.RangeDescriptor, vitalityMap livenesspb.NodeVitalityMap,
 ) int {
-	return calcLiveReplicas(desc.Replicas().VoterDescriptors(), vitalityMap)
+	voters := desc.Replicas().FilterToDescriptors(roachpb.ReplicaDescriptor.IsVoterNewConfig)
+	return calcLiveReplicas(voters, vitalityMap)
 }
 
 // calcLiveNonVoterReplicas returns a count of the live non-voter replicas; a live
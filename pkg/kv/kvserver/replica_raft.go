@@ -1308,6 +1308,7 @@ func (r *Replica) tick(
 	}
 
 	r.maybeTransferRaftLeadershipToLeaseholderLocked(ctx, leaseStatus)
+	r.maybeTransferRaftLeadershipFromWitnessLocked(ctx)
 
 	// Eagerly acquire or extend leases. This only works for unquiesced ranges. We
 	// never quiesce expiration leases, but for epoch leases we fall back to the
@@ -2339,7 +2340,14 @@ func shouldCampaignOnLeaseRequestRedirect(
 // (pre)votes without campaigning themselves. Followers and pre-candidates will
 // also grant any number of pre-votes, both for themselves and anyone else
 // that's eligible.
+//
+// Witnesses never campaign on their own initiative, since they would have to
+// transfer the leadership away immediately after winning.
 func (r *Replica) campaignLocked(ctx context.Context) {
+	if r.isWitnessRLocked() {
+		log.VEventf(ctx, 3, "not campaigning as a witness")
+		return
+	}
 	log.VEventf(ctx, 3, "campaigning")
 	if err := r.mu.internalRaftGroup.Campaign(); err != nil {
 		log.VEventf(ctx, 1, "failed to campaign: %s", err)
@@ -2356,6 +2364,10 @@ func (r *Replica) campaignLocked(ctx context.Context) {
 // caller is certain that the current leader is actually dead, and we're not
 // simply partitioned away from it and/or liveness.
 func (r *Replica) forceCampaignLocked(ctx context.Context) {
+	if r.isWitnessRLocked() {
+		log.VEventf(ctx, 3, "not force campaigning as a witness")
+		return
+	}
 	log.VEventf(ctx, 3, "force campaigning")
 	msg := raftpb.Message{To: raftpb.PeerID(r.replicaID), Type: raftpb.MsgTimeoutNow}
 	if err := r.mu.internalRaftGroup.Step(msg); err != nil {
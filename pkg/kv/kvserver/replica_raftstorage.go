@@ -564,6 +564,8 @@ func (r *Replica) applySnapshot(
 					r.store.metrics.RangeSnapshotsAppliedByVoters.Inc(1)
 				case roachpb.NON_VOTER:
 					r.store.metrics.RangeSnapshotsAppliedByNonVoters.Inc(1)
+				case roachpb.WITNESS:
+					r.store.metrics.RangeSnapshotsAppliedByWitnesses.Inc(1)
 				default:
 					log.Fatalf(ctx, "unexpected replica type %s while applying snapshot", desc.Type)
 				}
@@ -801,9 +803,12 @@ func (r *Replica) applySnapshot(
 	// for a discussion regarding this.
 	r.mu.lastTermNotDurable = invalidLastTerm
 	r.mu.raftLogSize = 0
-	// Update the store stats for the data in the snapshot.
-	r.store.metrics.subtractMVCCStats(ctx, r.tenantMetricsRef, *r.mu.state.Stats)
-	r.store.metrics.addMVCCStats(ctx, r.tenantMetricsRef, *state.Stats)
+	// Update the store stats for the data in the snapshot. The descriptor has
+	// already been updated above, which moved the contribution of the old
+	// stats over if the replica became or stopped being a witness.
+	witness := r.isWitnessRLocked()
+	r.store.metrics.subtractMVCCStats(ctx, r.tenantMetricsRef, tenantStorageStats(*r.mu.state.Stats, witness))
+	r.store.metrics.addMVCCStats(ctx, r.tenantMetricsRef, tenantStorageStats(*state.Stats, witness))
 	lastKnownLease := r.mu.state.Lease
 	// Update the rest of the Raft state. Changes to r.mu.state.Desc must be
 	// managed by r.setDescRaftMuLocked and changes to r.mu.state.Lease must be handled
@@ -174,6 +174,7 @@ func (r *Replica) SendWithWriteBytes(
 	var writeBytes *kvadmission.StoreWriteBytes
 	if isReadOnly {
 		log.Event(ctx, "read-only path")
+		ctx = r.maybeWaitForReadIndex(ctx, ba)
 		fn := (*Replica).executeReadOnlyBatch
 		br, _, pErr = r.executeBatchWithConcurrencyRetries(ctx, ba, fn)
 	} else if ba.IsWrite() {
@@ -108,6 +108,12 @@ var (
 		Measurement: "Replica Additions",
 		Unit:        metric.Unit_COUNT,
 	}
+	metaReplicateQueueAddWitnessReplicaCount = metric.Metadata{
+		Name:        "queue.replicate.addwitnessreplica",
+		Help:        "Number of witness replica additions attempted by the replicate queue",
+		Measurement: "Replica Additions",
+		Unit:        metric.Unit_COUNT,
+	}
 	metaReplicateQueueRemoveReplicaCount = metric.Metadata{
 		Name:        "queue.replicate.removereplica",
 		Help:        "Number of replica removals attempted by the replicate queue (typically in response to a rebalancer-initiated addition)",
@@ -126,6 +132,12 @@ var (
 		Measurement: "Replica Removals",
 		Unit:        metric.Unit_COUNT,
 	}
+	metaReplicateQueueRemoveWitnessReplicaCount = metric.Metadata{
+		Name:        "queue.replicate.removewitnessreplica",
+		Help:        "Number of witness replica removals attempted by the replicate queue",
+		Measurement: "Replica Removals",
+		Unit:        metric.Unit_COUNT,
+	}
 	metaReplicateQueueRemoveDeadReplicaCount = metric.Metadata{
 		Name:        "queue.replicate.removedeadreplica",
 		Help:        "Number of dead replica removals attempted by the replicate queue (typically in response to a node outage)",
@@ -144,6 +156,12 @@ var (
 		Measurement: "Replica Removals",
 		Unit:        metric.Unit_COUNT,
 	}
+	metaReplicateQueueRemoveDeadWitnessReplicaCount = metric.Metadata{
+		Name:        "queue.replicate.removedeadwitnessreplica",
+		Help:        "Number of dead witness replica removals attempted by the replicate queue (typically in response to a node outage)",
+		Measurement: "Replica Removals",
+		Unit:        metric.Unit_COUNT,
+	}
 	metaReplicateQueueRemoveDecommissioningReplicaCount = metric.Metadata{
 		Name:        "queue.replicate.removedecommissioningreplica",
 		Help:        "Number of decommissioning replica removals attempted by the replicate queue (typically in response to a node outage)",
@@ -162,6 +180,12 @@ var (
 		Measurement: "Replica Removals",
 		Unit:        metric.Unit_COUNT,
 	}
+	metaReplicateQueueRemoveDecommissioningWitnessReplicaCount = metric.Metadata{
+		Name:        "queue.replicate.removedecommissioningwitnessreplica",
+		Help:        "Number of decommissioning witness replica removals attempted by the replicate queue (typically in response to a node outage)",
+		Measurement: "Replica Removals",
+		Unit:        metric.Unit_COUNT,
+	}
 	metaReplicateQueueRemoveLearnerReplicaCount = metric.Metadata{
 		Name:        "queue.replicate.removelearnerreplica",
 		Help:        "Number of learner replica removals attempted by the replicate queue (typically due to internal race conditions)",
@@ -302,15 +326,19 @@ type ReplicateQueueMetrics struct {
 	AddReplicaCount                           *metric.Counter
 	AddVoterReplicaCount                      *metric.Counter
 	AddNonVoterReplicaCount                   *metric.Counter
+	AddWitnessReplicaCount                    *metric.Counter
 	RemoveReplicaCount                        *metric.Counter
 	RemoveVoterReplicaCount                   *metric.Counter
 	RemoveNonVoterReplicaCount                *metric.Counter
+	RemoveWitnessReplicaCount                 *metric.Counter
 	RemoveDeadReplicaCount                    *metric.Counter
 	RemoveDeadVoterReplicaCount               *metric.Counter
 	RemoveDeadNonVoterReplicaCount            *metric.Counter
+	RemoveDeadWitnessReplicaCount             *metric.Counter
 	RemoveDecommissioningReplicaCount         *metric.Counter
 	RemoveDecommissioningVoterReplicaCount    *metric.Counter
 	RemoveDecommissioningNonVoterReplicaCount *metric.Counter
+	RemoveDecommissioningWitnessReplicaCount  *metric.Counter
 	RemoveLearnerReplicaCount                 *metric.Counter
 	RebalanceReplicaCount                     *metric.Counter
 	RebalanceVoterReplicaCount                *metric.Counter
@@ -342,16 +370,20 @@ func makeReplicateQueueMetrics() ReplicateQueueMetrics {
 		AddReplicaCount:                           metric.NewCounter(metaReplicateQueueAddReplicaCount),
 		AddVoterReplicaCount:                      metric.NewCounter(metaReplicateQueueAddVoterReplicaCount),
 		AddNonVoterReplicaCount:                   metric.NewCounter(metaReplicateQueueAddNonVoterReplicaCount),
+		AddWitnessReplicaCount:                    metric.NewCounter(metaReplicateQueueAddWitnessReplicaCount),
 		RemoveReplicaCount:                        metric.NewCounter(metaReplicateQueueRemoveReplicaCount),
 		RemoveVoterReplicaCount:                   metric.NewCounter(metaReplicateQueueRemoveVoterReplicaCount),
 		RemoveNonVoterReplicaCount:                metric.NewCounter(metaReplicateQueueRemoveNonVoterReplicaCount),
+		RemoveWitnessReplicaCount:                 metric.NewCounter(metaReplicateQueueRemoveWitnessReplicaCount),
 		RemoveDeadReplicaCount:                    metric.NewCounter(metaReplicateQueueRemoveDeadReplicaCount),
 		RemoveDeadVoterReplicaCount:               metric.NewCounter(metaReplicateQueueRemoveDeadVoterReplicaCount),
 		RemoveDeadNonVoterReplicaCount:            metric.NewCounter(metaReplicateQueueRemoveDeadNonVoterReplicaCount),
+		RemoveDeadWitnessReplicaCount:             metric.NewCounter(metaReplicateQueueRemoveDeadWitnessReplicaCount),
 		RemoveLearnerReplicaCount:                 metric.NewCounter(metaReplicateQueueRemoveLearnerReplicaCount),
 		RemoveDecommissioningReplicaCount:         metric.NewCounter(metaReplicateQueueRemoveDecommissioningReplicaCount),
 		RemoveDecommissioningVoterReplicaCount:    metric.NewCounter(metaReplicateQueueRemoveDecommissioningVoterReplicaCount),
 		RemoveDecommissioningNonVoterReplicaCount: metric.NewCounter(metaReplicateQueueRemoveDecommissioningNonVoterReplicaCount),
+		RemoveDecommissioningWitnessReplicaCount:  metric.NewCounter(metaReplicateQueueRemoveDecommissioningWitnessReplicaCount),
 		RebalanceReplicaCount:                     metric.NewCounter(metaReplicateQueueRebalanceReplicaCount),
 		RebalanceVoterReplicaCount:                metric.NewCounter(metaReplicateQueueRebalanceVoterReplicaCount),
 		RebalanceNonVoterReplicaCount:             metric.NewCounter(metaReplicateQueueRebalanceNonVoterReplicaCount),
@@ -390,6 +422,9 @@ func (metrics *ReplicateQueueMetrics) trackPlanningStats(
 	if stats.AddNonVoterReplicaCount > 0 {
 		metrics.AddNonVoterReplicaCount.Inc(stats.AddNonVoterReplicaCount)
 	}
+	if stats.AddWitnessReplicaCount > 0 {
+		metrics.AddWitnessReplicaCount.Inc(stats.AddWitnessReplicaCount)
+	}
 	if stats.RemoveReplicaCount > 0 {
 		metrics.RemoveReplicaCount.Inc(stats.RemoveReplicaCount)
 	}
@@ -399,6 +434,9 @@ func (metrics *ReplicateQueueMetrics) trackPlanningStats(
 	if stats.RemoveNonVoterReplicaCount > 0 {
 		metrics.RemoveNonVoterReplicaCount.Inc(stats.RemoveNonVoterReplicaCount)
 	}
+	if stats.RemoveWitnessReplicaCount > 0 {
+		metrics.RemoveWitnessReplicaCount.Inc(stats.RemoveWitnessReplicaCount)
+	}
 	if stats.RemoveDeadReplicaCount > 0 {
 		metrics.RemoveDeadReplicaCount.Inc(stats.RemoveDeadReplicaCount)
 	}
@@ -408,6 +446,9 @@ func (metrics *ReplicateQueueMetrics) trackPlanningStats(
 	if stats.RemoveDeadNonVoterReplicaCount > 0 {
 		metrics.RemoveDeadNonVoterReplicaCount.Inc(stats.RemoveDeadNonVoterReplicaCount)
 	}
+	if stats.RemoveDeadWitnessReplicaCount > 0 {
+		metrics.RemoveDeadWitnessReplicaCount.Inc(stats.RemoveDeadWitnessReplicaCount)
+	}
 	if stats.RemoveDecommissioningReplicaCount > 0 {
 		metrics.RemoveDecommissioningReplicaCount.Inc(stats.RemoveDecommissioningReplicaCount)
 	}
@@ -417,6 +458,9 @@ func (metrics *ReplicateQueueMetrics) trackPlanningStats(
 	if stats.RemoveDecommissioningNonVoterReplicaCount > 0 {
 		metrics.RemoveDecommissioningNonVoterReplicaCount.Inc(stats.RemoveDecommissioningNonVoterReplicaCount)
 	}
+	if stats.RemoveDecommissioningWitnessReplicaCount > 0 {
+		metrics.RemoveDecommissioningWitnessReplicaCount.Inc(stats.RemoveDecommissioningWitnessReplicaCount)
+	}
 	if stats.RemoveLearnerReplicaCount > 0 {
 		metrics.RemoveLearnerReplicaCount.Inc(stats.RemoveLearnerReplicaCount)
 	}
@@ -444,13 +488,16 @@ func (metrics *ReplicateQueueMetrics) trackSuccessByAllocatorAction(
 	ctx context.Context, action allocatorimpl.AllocatorAction,
 ) {
 	switch action {
-	case allocatorimpl.AllocatorRemoveVoter, allocatorimpl.AllocatorRemoveNonVoter:
+	case allocatorimpl.AllocatorRemoveVoter, allocatorimpl.AllocatorRemoveNonVoter,
+		allocatorimpl.AllocatorRemoveWitness:
 		metrics.RemoveReplicaSuccessCount.Inc(1)
-	case allocatorimpl.AllocatorAddVoter, allocatorimpl.AllocatorAddNonVoter:
+	case allocatorimpl.AllocatorAddVoter, allocatorimpl.AllocatorAddNonVoter,
+		allocatorimpl.AllocatorAddWitness:
 		metrics.AddReplicaSuccessCount.Inc(1)
 	case allocatorimpl.AllocatorReplaceDeadVoter, allocatorimpl.AllocatorReplaceDeadNonVoter:
 		metrics.ReplaceDeadReplicaSuccessCount.Inc(1)
-	case allocatorimpl.AllocatorRemoveDeadVoter, allocatorimpl.AllocatorRemoveDeadNonVoter:
+	case allocatorimpl.AllocatorRemoveDeadVoter, allocatorimpl.AllocatorRemoveDeadNonVoter,
+		allocatorimpl.AllocatorRemoveDeadWitness:
 		metrics.RemoveDeadReplicaSuccessCount.Inc(1)
 	case allocatorimpl.AllocatorReplaceDecommissioningVoter, allocatorimpl.AllocatorReplaceDecommissioningNonVoter:
 		metrics.ReplaceDecommissioningReplicaSuccessCount.Inc(1)
@@ -472,13 +519,16 @@ func (metrics *ReplicateQueueMetrics) trackErrorByAllocatorAction(
 	ctx context.Context, action allocatorimpl.AllocatorAction,
 ) {
 	switch action {
-	case allocatorimpl.AllocatorRemoveVoter, allocatorimpl.AllocatorRemoveNonVoter:
+	case allocatorimpl.AllocatorRemoveVoter, allocatorimpl.AllocatorRemoveNonVoter,
+		allocatorimpl.AllocatorRemoveWitness:
 		metrics.RemoveReplicaErrorCount.Inc(1)
-	case allocatorimpl.AllocatorAddVoter, allocatorimpl.AllocatorAddNonVoter:
+	case allocatorimpl.AllocatorAddVoter, allocatorimpl.AllocatorAddNonVoter,
+		allocatorimpl.AllocatorAddWitness:
 		metrics.AddReplicaErrorCount.Inc(1)
 	case allocatorimpl.AllocatorReplaceDeadVoter, allocatorimpl.AllocatorReplaceDeadNonVoter:
 		metrics.ReplaceDeadReplicaErrorCount.Inc(1)
-	case allocatorimpl.AllocatorRemoveDeadVoter, allocatorimpl.AllocatorRemoveDeadNonVoter:
+	case allocatorimpl.AllocatorRemoveDeadVoter, allocatorimpl.AllocatorRemoveDeadNonVoter,
+		allocatorimpl.AllocatorRemoveDeadWitness:
 		metrics.RemoveDeadReplicaErrorCount.Inc(1)
 	case allocatorimpl.AllocatorReplaceDecommissioningVoter, allocatorimpl.AllocatorReplaceDecommissioningNonVoter:
 		metrics.ReplaceDecommissioningReplicaErrorCount.Inc(1)
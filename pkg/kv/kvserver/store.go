@@ -73,6 +73,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/spanconfig/spanconfigstore"
 	"github.com/cockroachdb/cockroach/pkg/storage"
 	"github.com/cockroachdb/cockroach/pkg/storage/disk"
+	"github.com/cockroachdb/cockroach/pkg/storage/fs"
 	"github.com/cockroachdb/cockroach/pkg/util/admission"
 	"github.com/cockroachdb/cockroach/pkg/util/admission/admissionpb"
 	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
@@ -84,6 +85,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/limit"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/log/logcrash"
+	"github.com/cockroachdb/cockroach/pkg/util/log/logpb"
 	"github.com/cockroachdb/cockroach/pkg/util/log/severity"
 	"github.com/cockroachdb/cockroach/pkg/util/metamorphic"
 	"github.com/cockroachdb/cockroach/pkg/util/metric"
@@ -1291,6 +1293,10 @@ type StoreConfig struct {
 	// RangeLogWriter is used to write entries to the system.rangelog table.
 	RangeLogWriter RangeLogWriter
 
+	// EventLogger, if set, is used to record notable events in the event log
+	// (system.eventlog table). Otherwise, events are only sent to the logs.
+	EventLogger func(context.Context, logpb.EventPayload)
+
 	// RangeFeedSchedulerConcurrency specifies number of rangefeed scheduler
 	// workers for the store.
 	RangeFeedSchedulerConcurrency int
@@ -2227,7 +2233,7 @@ func (s *Store) Start(ctx context.Context, stopper *stop.Stopper) error {
 		s.metrics.ReplicaCount.Inc(1)
 		// INVARIANT: each initialized Replica is associated to a tenant.
 		if _, ok := rep.TenantID(); ok {
-			s.metrics.addMVCCStats(ctx, rep.tenantMetricsRef, rep.GetMVCCStats())
+			s.metrics.addMVCCStats(ctx, rep.tenantMetricsRef, rep.tenantStorageMVCCStats())
 		} else {
 			return errors.AssertionFailedf("no tenantID for initialized replica %s", rep)
 		}
@@ -3475,6 +3481,38 @@ func (s *Store) checkpoint(tag string, spans []roachpb.Span) (string, error) {
 	return checkpointDir, nil
 }
 
+func (s *Store) inconsistenciesDir() string {
+	return filepath.Join(s.TODOEngine().GetAuxiliaryDir(), "inconsistencies")
+}
+
+// archiveInconsistency writes the details of an inconsistency detected by the
+// consistency checker to a file in the auxiliary directory, with the provided
+// tag used in the filename. Returns the path to the created file.
+func (s *Store) archiveInconsistency(tag string, details string) (string, error) {
+	dir := s.inconsistenciesDir()
+	if err := s.TODOEngine().Env().MkdirAll(dir, os.ModePerm); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, tag+".txt")
+	if err := fs.WriteFile(
+		s.TODOEngine().Env(), path, []byte(details), fs.UnspecifiedWriteCategory,
+	); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// logStructuredEvent records the event in the event log if the store is
+// configured with an EventLogger, and only logs it otherwise.
+func (s *Store) logStructuredEvent(ctx context.Context, event logpb.EventPayload) {
+	event.CommonDetails().Timestamp = timeutil.Now().UnixNano()
+	if fn := s.cfg.EventLogger; fn != nil {
+		fn(ctx, event)
+		return
+	}
+	log.StructuredEvent(ctx, severity.INFO, event)
+}
+
 // computeMetrics is a common metric computation that is used by
 // ComputeMetricsPeriodically and ComputeMetrics to compute metrics.
 func (s *Store) computeMetrics(ctx context.Context) (m storage.Metrics, err error) {
@@ -793,7 +793,9 @@ func (kvSS *kvBatchSnapshotStrategy) Send(
 	sharedReplicate := header.SharedReplicate && rditer.IterateReplicaKeySpansShared != nil
 	externalReplicate := header.ExternalReplicate && rditer.IterateReplicaKeySpansShared != nil
 	replicatedFilter := rditer.ReplicatedSpansAll
-	if sharedReplicate || externalReplicate {
+	if sharedReplicate || externalReplicate || header.ExcludeUserData {
+		// NB: snapshots sent to witnesses exclude user data altogether. The
+		// recipient clears the user key span when ingesting the snapshot.
 		replicatedFilter = rditer.ReplicatedSpansExcludeUser
 	}
 
@@ -188,7 +188,8 @@ func splitPostApply(
 
 	// Update store stats with difference in stats before and after split.
 	if rightReplOrNil != nil {
-		rightReplOrNil.store.metrics.addMVCCStats(ctx, rightReplOrNil.tenantMetricsRef, deltaMS)
+		rightReplOrNil.store.metrics.addMVCCStats(ctx, rightReplOrNil.tenantMetricsRef,
+			tenantStorageStats(deltaMS, rightReplOrNil.isWitness()))
 	}
 
 	now := r.store.Clock().NowAsClockTimestamp()
@@ -254,6 +254,9 @@ type StoreTestingKnobs struct {
 	// leadership when it diverges from the range's leaseholder. This can
 	// also be set via COCKROACH_DISABLE_LEADER_FOLLOWS_LEASEHOLDER.
 	DisableLeaderFollowsLeaseholder bool
+	// DisableLeaderTransferFromWitness, if set and returning true, disables
+	// attempts to transfer raft leadership away from witnesses.
+	DisableLeaderTransferFromWitness func() bool
 	// If set, the above-raft lease transfer safety checks (that verify that
 	// we don't transfer leases to followers that need a snapshot, etc) are
 	// disabled. The proposal-time checks are not affected by this knob.
@@ -342,6 +345,9 @@ type StoreTestingKnobs struct {
 	// This ensures the `*Replica` will be materialized on the Store when it
 	// returns.
 	VoterAddStopAfterLearnerSnapshot func([]roachpb.ReplicationTarget) bool
+	// WitnessAddStopAfterLearnerSnapshot is like VoterAddStopAfterLearnerSnapshot,
+	// but for the learners that are promoted to witnesses.
+	WitnessAddStopAfterLearnerSnapshot func([]roachpb.ReplicationTarget) bool
 	// NonVoterAfterInitialization is called after a newly added non-voting
 	// replica receives its initial snapshot. Note that this knob _can_ be used in
 	// conjunction with ReplicaSkipInitialSnapshot.
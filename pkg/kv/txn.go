@@ -98,6 +98,11 @@ type Txn struct {
 		// The txn has to be committed by this deadline. A zero value indicates no
 		// deadline.
 		deadline hlc.Timestamp
+
+		// linearizableFollowerReads, if set, causes the read-only batches sent
+		// through this txn to be marked as linearizable follower reads (see
+		// kvpb.Header.LinearizableFollowerRead).
+		linearizableFollowerReads bool
 	}
 
 	// admissionHeader is used for admission control for work done in this
@@ -456,6 +461,28 @@ func (txn *Txn) RequiredFrontier() hlc.Timestamp {
 	return txn.mu.sender.RequiredFrontier()
 }
 
+// SetLinearizableFollowerReads configures whether the read-only batches sent
+// through the transaction may be served by follower replicas above their closed
+// timestamps, using a read index obtained from the leaseholder. Unlike
+// stale follower reads, such reads observe all writes that precede them.
+//
+// The setting can be changed between operations on the transaction. It is
+// not propagated through the LeafTxnInputState, so it must be set on leaf
+// transactions separately.
+func (txn *Txn) SetLinearizableFollowerReads(enabled bool) {
+	txn.mu.Lock()
+	defer txn.mu.Unlock()
+	txn.mu.linearizableFollowerReads = enabled
+}
+
+// LinearizableFollowerReads returns whether the read-only batches sent through
+// the transaction are marked as linearizable follower reads.
+func (txn *Txn) LinearizableFollowerReads() bool {
+	txn.mu.Lock()
+	defer txn.mu.Unlock()
+	return txn.mu.linearizableFollowerReads
+}
+
 // DisablePipelining instructs the transaction not to pipeline requests. It
 // should rarely be necessary to call this method.
 //
@@ -1278,7 +1305,11 @@ func (txn *Txn) Send(
 	txn.mu.Lock()
 	requestTxnID := txn.mu.ID
 	sender := txn.mu.sender
+	linearizableFollowerReads := txn.mu.linearizableFollowerReads
 	txn.mu.Unlock()
+	if linearizableFollowerReads && ba.IsReadOnly() {
+		ba.Header.LinearizableFollowerRead = true
+	}
 	br, pErr := txn.db.sendUsingSender(ctx, ba, sender)
 
 	if pErr == nil {
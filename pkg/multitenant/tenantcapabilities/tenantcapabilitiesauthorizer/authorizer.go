@@ -236,6 +236,7 @@ var reqMethodToCap = map[kvpb.Method]tenantcapabilities.ID{
 	kvpb.Migrate:                       onlySystemTenant,
 	kvpb.Probe:                         onlySystemTenant,
 	kvpb.QueryResolvedTimestamp:        onlySystemTenant,
+	kvpb.ReadIndex:                     onlySystemTenant,
 	kvpb.RecomputeStats:                onlySystemTenant,
 	kvpb.RequestLease:                  onlySystemTenant,
 	kvpb.Subsume:                       onlySystemTenant,
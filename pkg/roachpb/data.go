@@ -1648,8 +1648,9 @@ func confChangeImpl(
 			if err := checkNotExists(rDesc); err != nil {
 				return nil, err
 			}
-		case VOTER_FULL:
-			// A voter can't be in the descriptor if it's being removed.
+		case VOTER_FULL, WITNESS:
+			// A voter can't be in the descriptor if it's being removed. Witnesses
+			// are always removed directly, without a joint configuration.
 			if err := checkNotExists(rDesc); err != nil {
 				return nil, err
 			}
@@ -1675,6 +1676,11 @@ func confChangeImpl(
 			// We're adding a voter, but will transition into a joint config
 			// first.
 			changeType = raftpb.ConfChangeAddNode
+		case WITNESS:
+			// We're promoting a learner that received a snapshot without user
+			// data to a witness. Witnesses are never added through a joint
+			// config.
+			changeType = raftpb.ConfChangeAddNode
 		case LEARNER, NON_VOTER:
 			// We're adding a learner or non-voter.
 			// Note that we're guaranteed by virtue of the upstream ChangeReplicas txn
@@ -183,6 +183,17 @@ func TestReplicaDescriptorsConfState(t *testing.T) {
 			[]ReplicaDescriptor{rd(VOTER_OUTGOING, 1), rd(VOTER_DEMOTING_LEARNER, 2), rd(VOTER_INCOMING, 3), rd(VOTER_INCOMING, 4), rd(LEARNER, 5)},
 			"Voters:[3 4] VotersOutgoing:[1 2] Learners:[5] LearnersNext:[2] AutoLeave:false",
 		},
+		// A witness is a regular voter.
+		{
+			[]ReplicaDescriptor{rd(VOTER_FULL, 1), rd(VOTER_FULL, 2), rd(WITNESS, 3)},
+			"Voters:[1 2 3] VotersOutgoing:[] Learners:[] LearnersNext:[] AutoLeave:false",
+		},
+		// Witnesses don't change type in a joint config, so they remain voters on
+		// both sides while n2 is swapped out for n4.
+		{
+			[]ReplicaDescriptor{rd(VOTER_FULL, 1), rd(VOTER_OUTGOING, 2), rd(WITNESS, 3), rd(VOTER_INCOMING, 4)},
+			"Voters:[1 3 4] VotersOutgoing:[1 2 3] Learners:[] LearnersNext:[] AutoLeave:false",
+		},
 	}
 
 	for _, test := range tests {
@@ -278,6 +289,17 @@ func TestReplicaDescriptorsCanMakeProgress(t *testing.T) {
 			{false, rd(LEARNER, 6)},
 			{false, rd(LEARNER, 7)},
 		}, true},
+		// A witness counts towards quorum like any other voter.
+		{[]descWithLiveness{
+			{true, rd(VOTER_FULL, 1)},
+			{false, rd(VOTER_FULL, 2)},
+			{true, rd(WITNESS, 3)},
+		}, true},
+		{[]descWithLiveness{
+			{true, rd(VOTER_FULL, 1)},
+			{false, rd(VOTER_FULL, 2)},
+			{false, rd(WITNESS, 3)},
+		}, false},
 		// Non-joint case that should be live unless the learner is somehow taken
 		// into account.
 		{[]descWithLiveness{
@@ -412,3 +434,21 @@ func TestReplicaSetOperations(t *testing.T) {
 		}
 	})
 }
+
+func TestReplicaSetWitnesses(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	rs := MakeReplicaSet([]ReplicaDescriptor{
+		rd(VOTER_FULL, 1), rd(WITNESS, 2), rd(NON_VOTER, 3), rd(VOTER_FULL, 4),
+	})
+	require.Equal(t, []ReplicaDescriptor{rd(WITNESS, 2)}, rs.WitnessDescriptors())
+	require.Equal(t, []ReplicaDescriptor{rd(VOTER_FULL, 1), rd(VOTER_FULL, 4)}, rs.VoterDescriptors())
+	require.False(t, rs.InAtomicReplicationChange())
+
+	// Witnesses count as voters for replication purposes, but can't hold the
+	// lease.
+	require.Len(t, rs.FilterToDescriptors(ReplicaDescriptor.IsVoterNewConfig), 3)
+	require.NoError(t, CheckCanReceiveLease(rd(VOTER_FULL, 1), rs, false /* wasLastLeaseholder */))
+	require.ErrorIs(t,
+		CheckCanReceiveLease(rd(WITNESS, 2), rs, false /* wasLastLeaseholder */), ErrReplicaCannotHoldLease)
+}
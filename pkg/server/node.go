@@ -589,6 +589,7 @@ func NewNode(
 	}
 	n.versionUpdateMu.updateCh = make(chan struct{})
 	n.perReplicaServer = kvserver.MakeServer(&n.Descriptor, n.stores)
+	n.storeCfg.EventLogger = n.logStructuredEvent
 	return n
 }
 
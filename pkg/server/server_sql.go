@@ -90,6 +90,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/idxusage"
 	"github.com/cockroachdb/cockroach/pkg/sql/isql"
 	"github.com/cockroachdb/cockroach/pkg/sql/optionalnodeliveness"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgnotify"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire"
 	"github.com/cockroachdb/cockroach/pkg/sql/querycache"
 	"github.com/cockroachdb/cockroach/pkg/sql/rangeprober"
@@ -1250,6 +1251,17 @@ func newSQLServer(ctx context.Context, cfg sqlServerArgs) (*SQLServer, error) {
 	)
 	execCfg.StmtDiagnosticsRecorder = stmtDiagnosticsRegistry
 
+	execCfg.NotificationRegistry = pgnotify.NewRegistry(
+		cfg.AmbientCtx,
+		cfg.Settings,
+		codec,
+		cfg.clock,
+		cfg.internalDB,
+		cfg.stopper,
+		cfg.rangeFeedFactory,
+		execCfg.SystemTableIDResolver,
+	)
+
 	var upgradeMgr *upgrademanager.Manager
 	{
 		var c upgrade.Cluster
@@ -1713,6 +1725,9 @@ func (s *SQLServer) preStart(
 		return err
 	}
 	s.stmtDiagnosticsRegistry.Start(ctx, stopper)
+	if err := s.execCfg.NotificationRegistry.Start(ctx); err != nil {
+		return err
+	}
 	if err := s.execCfg.TableStatsCache.Start(ctx, s.execCfg.Codec, s.execCfg.RangeFeedFactory); err != nil {
 		return err
 	}
@@ -53,6 +53,7 @@ var fields = [config.NumFields]Field{
 	constraints,
 	voterConstraints,
 	leasePreferences,
+	numWitnesses,
 }
 
 const (
@@ -65,4 +66,5 @@ const (
 	constraints      = constraintsConjunctionField(config.Constraints)
 	voterConstraints = constraintsConjunctionField(config.VoterConstraints)
 	leasePreferences = leasePreferencesField(config.LeasePreferences)
+	numWitnesses     = int32Field(config.NumWitnesses)
 )
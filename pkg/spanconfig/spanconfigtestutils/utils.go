@@ -669,6 +669,9 @@ func PrintSpanConfigDiffedAgainstDefaults(conf roachpb.SpanConfig) string {
 	if conf.ExcludeDataFromBackup != defaultConf.ExcludeDataFromBackup {
 		diffs = append(diffs, fmt.Sprintf("exclude_data_from_backup=%v", conf.ExcludeDataFromBackup))
 	}
+	if conf.NumWitnesses != defaultConf.NumWitnesses {
+		diffs = append(diffs, fmt.Sprintf("num_witnesses=%d", conf.NumWitnesses))
+	}
 
 	return strings.Join(diffs, " ")
 }
@@ -0,0 +1,106 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/advisorylock"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+)
+
+// AcquireAdvisoryLock is part of the eval.Planner interface.
+func (p *planner) AcquireAdvisoryLock(
+	ctx context.Context, key advisorylock.Key, shared, xact, wait bool,
+) (bool, error) {
+	dbID, err := p.advisoryLockDatabaseID(ctx)
+	if err != nil {
+		return false, err
+	}
+	lockTimeout := p.SessionData().LockTimeout
+	if xact {
+		if p.advisoryLocks == nil {
+			// Without a session, the lock is held by the transaction itself.
+			return advisorylock.AcquireTxnLock(
+				ctx, p.Txn(), p.ExecCfg().Codec, dbID, key, shared, wait, lockTimeout,
+			)
+		}
+		return p.advisoryLocks().AcquireTxnLock(ctx, dbID, key, shared, wait, lockTimeout)
+	}
+	locks, err := p.sessionAdvisoryLocks()
+	if err != nil {
+		return false, err
+	}
+	return locks.Acquire(ctx, dbID, key, shared, wait, lockTimeout)
+}
+
+// ReleaseAdvisoryLock is part of the eval.Planner interface.
+func (p *planner) ReleaseAdvisoryLock(
+	ctx context.Context, key advisorylock.Key, shared bool,
+) (bool, error) {
+	dbID, err := p.advisoryLockDatabaseID(ctx)
+	if err != nil {
+		return false, err
+	}
+	locks, err := p.sessionAdvisoryLocks()
+	if err != nil {
+		return false, err
+	}
+	released, err := locks.Release(ctx, dbID, key, shared)
+	if err != nil || released {
+		return released, err
+	}
+	mode := "ExclusiveLock"
+	if shared {
+		mode = "ShareLock"
+	}
+	p.BufferClientNotice(ctx, pgnotice.NewWithSeverityf(
+		"WARNING", "you don't own a lock of type %s", mode,
+	))
+	return false, nil
+}
+
+// ReleaseAllAdvisoryLocks is part of the eval.Planner interface.
+func (p *planner) ReleaseAllAdvisoryLocks(ctx context.Context) error {
+	locks, err := p.sessionAdvisoryLocks()
+	if err != nil {
+		return err
+	}
+	locks.ReleaseAll(ctx)
+	return nil
+}
+
+// sessionAdvisoryLocks returns the session-level advisory locks of the
+// session.
+func (p *planner) sessionAdvisoryLocks() (*advisorylock.SessionLocks, error) {
+	if p.advisoryLocks == nil {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			"session-level advisory locks are not supported in this context")
+	}
+	return p.advisoryLocks(), nil
+}
+
+// advisoryLockDatabaseID returns the ID of the current database, in which
+// advisory locks are acquired.
+func (p *planner) advisoryLockDatabaseID(ctx context.Context) (descpb.ID, error) {
+	if p.CurrentDatabase() == "" {
+		return 0, sqlerrors.ErrNoDatabase
+	}
+	db, err := p.Descriptors().ByNameWithLeased(p.txn).Get().Database(ctx, p.CurrentDatabase())
+	if err != nil {
+		return 0, err
+	}
+	return db.GetID(), nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAdvisoryLockDowngrade verifies that a session which releases the
+// exclusive hold of an advisory lock it also holds in shared mode keeps
+// holding it in shared mode, without letting a waiting session acquire it in
+// exclusive mode in between.
+func TestAdvisoryLockDowngrade(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	s, sqlDB, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+
+	conn := func() *sqlutils.SQLRunner {
+		c, err := sqlDB.Conn(ctx)
+		require.NoError(t, err)
+		return sqlutils.MakeSQLRunner(c)
+	}
+	holder, waiter, other := conn(), conn(), conn()
+
+	holder.Exec(t, "SELECT pg_advisory_lock_shared(1), pg_advisory_lock(1)")
+
+	acquired := make(chan error, 1)
+	go func() {
+		_, err := waiter.DB.ExecContext(ctx, "SELECT pg_advisory_lock(1)")
+		acquired <- err
+	}()
+	testutils.SucceedsSoon(t, func() error {
+		var waiting int
+		other.QueryRow(t, `
+SELECT count(*) FROM crdb_internal.cluster_locks
+ WHERE database_name = 'defaultdb' AND table_name IS NULL AND NOT granted`,
+		).Scan(&waiting)
+		if waiting == 0 {
+			return errors.New("session is not waiting for the lock yet")
+		}
+		return nil
+	})
+
+	// Releasing the exclusive hold leaves the lock held in shared mode, so the
+	// waiting session keeps waiting.
+	holder.CheckQueryResults(t, "SELECT pg_advisory_unlock(1)", [][]string{{"true"}})
+	select {
+	case err := <-acquired:
+		t.Fatalf("lock acquired in exclusive mode while held in shared mode: %v", err)
+	default:
+	}
+
+	// Releasing the shared hold lets the waiting session acquire the lock.
+	holder.CheckQueryResults(t, "SELECT pg_advisory_unlock_shared(1)", [][]string{{"true"}})
+	require.NoError(t, <-acquired)
+	waiter.CheckQueryResults(t, "SELECT pg_advisory_unlock(1)", [][]string{{"true"}})
+	other.CheckQueryResults(t, "SELECT pg_try_advisory_lock(1), pg_advisory_unlock(1)", [][]string{{"true", "true"}})
+}
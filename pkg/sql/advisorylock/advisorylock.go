@@ -0,0 +1,500 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package advisorylock implements the advisory locks behind the
+// pg_advisory_lock family of builtins.
+//
+// Advisory locks are scoped to a database, as in Postgres, and are held as
+// locks in the KV lock table on keys in the keyspace of the database's
+// descriptor ID, which otherwise contains no data. A lock with a given key is
+// represented by two sets of keys:
+//
+//	/Table/<db>/<space>/<key>/0          the exclusive key
+//	/Table/<db>/<space>/<key>/1/<txn>    a shared key per holder
+//
+// An exclusive lock is acquired by writing an intent on the exclusive key and
+// then scanning the shared keys with a locking scan, and a shared lock is
+// acquired by writing an intent on a shared key of its own and then reading
+// the exclusive key with a locking read. Each acquisition thus conflicts with
+// the intents of the conflicting acquisitions, whatever their timestamps, and
+// waits for them to be released. The intents are never committed, so the keys
+// never contain data.
+//
+// Session-level locks are acquired in a separate transaction per key and mode,
+// which is kept open until the lock is released in that mode, so that a
+// session holding a lock in both modes can release one of them atomically.
+// The transaction-level locks of a SQL transaction are all acquired in a
+// single separate transaction, which is rolled back when the SQL transaction
+// ends. They can thus be acquired in read-only and historical transactions,
+// which cannot write intents. All of them benefit from the lock timeouts and
+// cancellation of the concurrency manager, and deadlocks between the
+// transaction-level locks of different SQL transactions are detected.
+//
+// The locks held by a session, at both levels, do not conflict with each
+// other, except that a session cannot hold a lock in exclusive mode at both
+// levels at once.
+package advisorylock
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/lock"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/errors"
+)
+
+// Key spaces of advisory locks. As in Postgres, a lock identified by a bigint
+// is distinct from a lock identified by a pair of int4s, even if the pair
+// packs into the same bigint.
+const (
+	int8KeySpace     = 1
+	int4PairKeySpace = 2
+)
+
+const (
+	exclusiveSuffix = 0
+	sharedSuffix    = 1
+)
+
+// Key identifies an advisory lock.
+type Key struct {
+	// ID is the identifier of the lock. An identifier given as a pair of int4s
+	// is packed into the high and low 32 bits.
+	ID int64
+	// Pair is true if the identifier was given as a pair of int4s.
+	Pair bool
+}
+
+// MakePairKey returns the Key of the lock identified by the given pair of
+// int4s.
+func MakePairKey(key1, key2 int32) Key {
+	return Key{ID: int64(key1)<<32 | int64(uint32(key2)), Pair: true}
+}
+
+// DatabaseSpan returns the span of the keys of the advisory locks of the given
+// database.
+func DatabaseSpan(codec keys.SQLCodec, dbID descpb.ID) roachpb.Span {
+	prefix := codec.TablePrefix(uint32(dbID))
+	return roachpb.Span{Key: prefix, EndKey: prefix.PrefixEnd()}
+}
+
+// lockKeys are the KV keys of an advisory lock in a database.
+type lockKeys struct {
+	exclusive roachpb.Key
+	shared    roachpb.Span
+}
+
+func makeLockKeys(codec keys.SQLCodec, dbID descpb.ID, k Key) lockKeys {
+	space := uint32(int8KeySpace)
+	if k.Pair {
+		space = int4PairKeySpace
+	}
+	prefix := codec.IndexPrefix(uint32(dbID), space)
+	prefix = encoding.EncodeVarintAscending(prefix, k.ID)
+	shared := encoding.EncodeUvarintAscending(prefix.Clone(), sharedSuffix)
+	return lockKeys{
+		exclusive: encoding.EncodeUvarintAscending(prefix, exclusiveSuffix),
+		shared:    roachpb.Span{Key: shared, EndKey: shared.PrefixEnd()},
+	}
+}
+
+// sharedKey returns the shared key of the given transaction.
+func (k lockKeys) sharedKey(txn *kv.Txn) roachpb.Key {
+	id := txn.ID()
+	return encoding.EncodeBytesAscending(k.shared.Key.Clone(), id.GetBytes())
+}
+
+// ownHolds describes how the lock is held by the other transactions of the
+// session acquiring it. Their keys do not conflict with the acquisition.
+type ownHolds struct {
+	// exclusive is true if the lock is held in exclusive mode.
+	exclusive bool
+	// sharedKeys are the shared keys held, in increasing order.
+	sharedKeys []roachpb.Key
+}
+
+// acquire acquires the lock in the given transaction. If wait is false, it
+// returns false instead of waiting for a conflicting lock to be released. A
+// lock which cannot be acquired leaves no trace in the transaction.
+//
+// The lock must not be held in exclusive mode by another transaction of the
+// session if it is acquired in exclusive mode.
+func acquire(
+	ctx context.Context,
+	txn *kv.Txn,
+	k lockKeys,
+	shared, wait bool,
+	lockTimeout time.Duration,
+	own ownHolds,
+) (bool, error) {
+	sp, err := txn.CreateSavepoint(ctx)
+	if err != nil {
+		return false, err
+	}
+	b := txn.NewBatch()
+	if wait {
+		b.Header.LockTimeout = lockTimeout
+	} else {
+		b.Header.WaitPolicy = lock.WaitPolicy_Error
+	}
+	// The shared locks must be durable: a lock lost to a lease transfer or a
+	// node restart would let a conflicting acquisition through while the lock
+	// is still considered held.
+	if shared {
+		b.Put(k.sharedKey(txn), []byte{})
+		// A lock held in exclusive mode by the session already excludes the
+		// other sessions.
+		if !own.exclusive {
+			b.GetForShare(k.exclusive, kvpb.GuaranteedDurability)
+		}
+	} else {
+		if own.exclusive {
+			return false, errors.AssertionFailedf("advisory lock already held in exclusive mode")
+		}
+		b.Put(k.exclusive, []byte{})
+		start := k.shared.Key
+		for _, key := range own.sharedKeys {
+			b.ScanForShare(start, key, kvpb.GuaranteedDurability)
+			start = key.Next()
+		}
+		b.ScanForShare(start, k.shared.EndKey, kvpb.GuaranteedDurability)
+	}
+	err = txn.Run(ctx, b)
+	if err == nil {
+		return true, nil
+	}
+	var wiErr *kvpb.WriteIntentError
+	if !errors.As(err, &wiErr) {
+		return false, err
+	}
+	if rbErr := txn.RollbackToSavepoint(ctx, sp); rbErr != nil {
+		return false, errors.CombineErrors(err, rbErr)
+	}
+	switch wiErr.Reason {
+	case kvpb.WriteIntentError_REASON_WAIT_POLICY:
+		return false, nil
+	case kvpb.WriteIntentError_REASON_LOCK_TIMEOUT:
+		return false, pgerror.New(pgcode.LockNotAvailable,
+			"canceling statement due to lock timeout on advisory lock")
+	default:
+		return false, pgerror.New(pgcode.LockNotAvailable, "could not obtain advisory lock")
+	}
+}
+
+// AcquireTxnLock acquires a transaction-level advisory lock in the given
+// transaction, which releases it when it ends. If wait is false, it returns
+// false instead of waiting for a conflicting lock to be released. It is used
+// when there is no session, and SessionLocks.AcquireTxnLock otherwise.
+func AcquireTxnLock(
+	ctx context.Context,
+	txn *kv.Txn,
+	codec keys.SQLCodec,
+	dbID descpb.ID,
+	k Key,
+	shared, wait bool,
+	lockTimeout time.Duration,
+) (bool, error) {
+	return acquire(ctx, txn, makeLockKeys(codec, dbID, k), shared, wait, lockTimeout, ownHolds{})
+}
+
+type sessionLockKey struct {
+	dbID descpb.ID
+	Key
+}
+
+// sessionLock is a lock held by a session. A session may acquire a lock at
+// session level multiple times, in both modes, and must release it as many
+// times. The lock is held at session level in each mode by a transaction of
+// its own, which is rolled back once the lock is no longer held in that mode.
+// The lock may also be held at transaction level, by the transaction holding
+// all the transaction-level locks of the session.
+type sessionLock struct {
+	exclusiveTxn *kv.Txn
+	sharedTxn    *kv.Txn
+	exclusive    int
+	shared       int
+
+	xactExclusive bool
+	xactShared    bool
+}
+
+// txn returns the transaction holding the lock at session level in the given
+// mode, or nil.
+func (l *sessionLock) txn(shared bool) *kv.Txn {
+	if shared {
+		return l.sharedTxn
+	}
+	return l.exclusiveTxn
+}
+
+// held returns true if the lock is held at either level.
+func (l *sessionLock) held() bool {
+	return l.exclusive > 0 || l.shared > 0 || l.xactExclusive || l.xactShared
+}
+
+// SessionLocks holds the advisory locks of a session.
+type SessionLocks struct {
+	db    *kv.DB
+	codec keys.SQLCodec
+
+	mu struct {
+		syncutil.Mutex
+		locks map[sessionLockKey]*sessionLock
+		// xactTxn holds the transaction-level locks of the current SQL
+		// transaction, if any.
+		xactTxn *kv.Txn
+	}
+}
+
+// NewSessionLocks returns the SessionLocks of a new session.
+func NewSessionLocks(db *kv.DB, codec keys.SQLCodec) *SessionLocks {
+	s := &SessionLocks{db: db, codec: codec}
+	s.mu.locks = make(map[sessionLockKey]*sessionLock)
+	return s
+}
+
+// errExclusiveAtBothLevels is returned when a session attempts to hold a lock
+// in exclusive mode at both session and transaction level.
+var errExclusiveAtBothLevels = pgerror.New(pgcode.FeatureNotSupported,
+	"advisory lock cannot be held in exclusive mode at both session and transaction level")
+
+// ownHolds returns how the lock is held by the transactions of the session
+// other than the given one.
+func (s *SessionLocks) ownHolds(l *sessionLock, k lockKeys, txn *kv.Txn) ownHolds {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var own ownHolds
+	if l.exclusiveTxn != nil && l.exclusiveTxn != txn {
+		own.exclusive = true
+	}
+	if l.xactExclusive && s.mu.xactTxn != txn {
+		own.exclusive = true
+	}
+	if l.sharedTxn != nil && l.sharedTxn != txn {
+		own.sharedKeys = append(own.sharedKeys, k.sharedKey(l.sharedTxn))
+	}
+	if l.xactShared && s.mu.xactTxn != txn {
+		own.sharedKeys = append(own.sharedKeys, k.sharedKey(s.mu.xactTxn))
+	}
+	sort.Slice(own.sharedKeys, func(i, j int) bool {
+		return own.sharedKeys[i].Compare(own.sharedKeys[j]) < 0
+	})
+	return own
+}
+
+// lock returns the lock with the given key, which is not registered if it is
+// not held yet.
+func (s *SessionLocks) lock(key sessionLockKey) *sessionLock {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if l, ok := s.mu.locks[key]; ok {
+		return l
+	}
+	return &sessionLock{}
+}
+
+// Acquire acquires a session-level advisory lock, which is held until it is
+// released as many times as it was acquired, or until the session ends. If
+// wait is false, it returns false instead of waiting for a conflicting lock to
+// be released.
+func (s *SessionLocks) Acquire(
+	ctx context.Context, dbID descpb.ID, k Key, shared, wait bool, lockTimeout time.Duration,
+) (bool, error) {
+	key := sessionLockKey{dbID: dbID, Key: k}
+	l := s.lock(key)
+	if l.txn(shared) != nil {
+		// The lock is already held in this mode.
+		s.count(l, shared)
+		return true, nil
+	}
+
+	// The session's own locks do not conflict with each other, so the lock is
+	// acquired in a new transaction which ignores the keys of the other
+	// transactions of the session holding the lock, if any.
+	txn := s.db.NewTxn(ctx, "advisory-lock")
+	lk := makeLockKeys(s.codec, dbID, k)
+	own := s.ownHolds(l, lk, txn)
+	if !shared && own.exclusive {
+		s.rollback(ctx, txn)
+		return false, errExclusiveAtBothLevels
+	}
+	acquired, err := acquire(ctx, txn, lk, shared, wait, lockTimeout, own)
+	if !acquired || err != nil {
+		s.rollback(ctx, txn)
+		return false, err
+	}
+	s.count(l, shared)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if shared {
+		l.sharedTxn = txn
+	} else {
+		l.exclusiveTxn = txn
+	}
+	s.mu.locks[key] = l
+	return true, nil
+}
+
+// Release releases a session-level advisory lock once. It returns false if the
+// session does not hold the lock in the given mode.
+func (s *SessionLocks) Release(
+	ctx context.Context, dbID descpb.ID, k Key, shared bool,
+) (bool, error) {
+	key := sessionLockKey{dbID: dbID, Key: k}
+	s.mu.Lock()
+	l, ok := s.mu.locks[key]
+	if !ok || (shared && l.shared == 0) || (!shared && l.exclusive == 0) {
+		s.mu.Unlock()
+		return false, nil
+	}
+	var release *kv.Txn
+	if shared {
+		if l.shared--; l.shared == 0 {
+			release, l.sharedTxn = l.sharedTxn, nil
+		}
+	} else {
+		if l.exclusive--; l.exclusive == 0 {
+			release, l.exclusiveTxn = l.exclusiveTxn, nil
+		}
+	}
+	if !l.held() {
+		delete(s.mu.locks, key)
+	}
+	s.mu.Unlock()
+
+	// Rolling back the transaction which holds the lock in the released mode
+	// leaves the lock held in the other mode, if any, without a window in
+	// which another session could acquire it.
+	if release != nil {
+		s.rollback(ctx, release)
+	}
+	return true, nil
+}
+
+// ReleaseAll releases all the session-level advisory locks of the session.
+// The transaction-level locks are left held.
+func (s *SessionLocks) ReleaseAll(ctx context.Context) {
+	var release []*kv.Txn
+	s.mu.Lock()
+	for key, l := range s.mu.locks {
+		for _, txn := range []*kv.Txn{l.exclusiveTxn, l.sharedTxn} {
+			if txn != nil {
+				release = append(release, txn)
+			}
+		}
+		l.exclusiveTxn, l.sharedTxn = nil, nil
+		l.exclusive, l.shared = 0, 0
+		if !l.held() {
+			delete(s.mu.locks, key)
+		}
+	}
+	s.mu.Unlock()
+	for _, txn := range release {
+		s.rollback(ctx, txn)
+	}
+}
+
+// AcquireTxnLock acquires a transaction-level advisory lock, which is held
+// until ReleaseTxnLocks is called when the SQL transaction ends. If wait is
+// false, it returns false instead of waiting for a conflicting lock to be
+// released.
+func (s *SessionLocks) AcquireTxnLock(
+	ctx context.Context, dbID descpb.ID, k Key, shared, wait bool, lockTimeout time.Duration,
+) (bool, error) {
+	key := sessionLockKey{dbID: dbID, Key: k}
+	l := s.lock(key)
+	s.mu.Lock()
+	if (shared && l.xactShared) || (!shared && l.xactExclusive) {
+		// The lock is already held in this mode.
+		s.mu.Unlock()
+		return true, nil
+	}
+	if s.mu.xactTxn == nil {
+		s.mu.xactTxn = s.db.NewTxn(ctx, "advisory-xact-lock")
+	}
+	txn := s.mu.xactTxn
+	s.mu.Unlock()
+
+	lk := makeLockKeys(s.codec, dbID, k)
+	own := s.ownHolds(l, lk, txn)
+	if !shared && own.exclusive {
+		return false, errExclusiveAtBothLevels
+	}
+	acquired, err := acquire(ctx, txn, lk, shared, wait, lockTimeout, own)
+	if err != nil {
+		// The transaction holding the transaction-level locks may have been
+		// aborted, for example to break a deadlock, in which case all of them
+		// were lost.
+		if errors.HasType(err, (*kvpb.TransactionRetryWithProtoRefreshError)(nil)) {
+			s.ReleaseTxnLocks(ctx)
+		}
+		return false, err
+	}
+	if !acquired {
+		return false, nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if shared {
+		l.xactShared = true
+	} else {
+		l.xactExclusive = true
+	}
+	s.mu.locks[key] = l
+	return true, nil
+}
+
+// ReleaseTxnLocks releases all the transaction-level advisory locks of the
+// session. It is called when the SQL transaction ends.
+func (s *SessionLocks) ReleaseTxnLocks(ctx context.Context) {
+	s.mu.Lock()
+	txn := s.mu.xactTxn
+	s.mu.xactTxn = nil
+	for key, l := range s.mu.locks {
+		l.xactExclusive, l.xactShared = false, false
+		if !l.held() {
+			delete(s.mu.locks, key)
+		}
+	}
+	s.mu.Unlock()
+	if txn != nil {
+		s.rollback(ctx, txn)
+	}
+}
+
+// count records that the session acquired the given lock once more.
+func (s *SessionLocks) count(l *sessionLock, shared bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if shared {
+		l.shared++
+	} else {
+		l.exclusive++
+	}
+}
+
+func (s *SessionLocks) rollback(ctx context.Context, txn *kv.Txn) {
+	if err := txn.Rollback(ctx); err != nil {
+		// The intents of the transaction are cleaned up by the transactions
+		// which encounter them once its record expires.
+		log.Warningf(ctx, "failed to release advisory lock: %v", err)
+	}
+}
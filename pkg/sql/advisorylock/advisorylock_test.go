@@ -0,0 +1,58 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package advisorylock
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLockKeys(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	codec := keys.MakeSQLCodec(roachpb.MustMakeTenantID(10))
+	const dbID = descpb.ID(104)
+	span := DatabaseSpan(codec, dbID)
+
+	require.Equal(t, Key{ID: 1<<32 | 2, Pair: true}, MakePairKey(1, 2))
+	require.Equal(t, Key{ID: -1<<32 | 0xffffffff, Pair: true}, MakePairKey(-1, -1))
+
+	seen := make(map[string]Key)
+	for _, k := range []Key{
+		{ID: 0},
+		{ID: 1},
+		{ID: -1},
+		{ID: 1<<32 | 2},
+		MakePairKey(0, 0),
+		MakePairKey(1, 2),
+		MakePairKey(-1, -1),
+	} {
+		lk := makeLockKeys(codec, dbID, k)
+		require.True(t, span.ContainsKey(lk.exclusive))
+		require.True(t, span.Contains(lk.shared))
+		require.False(t, lk.shared.ContainsKey(lk.exclusive))
+		for _, key := range []roachpb.Key{lk.exclusive, lk.shared.Key} {
+			other, ok := seen[string(key)]
+			require.False(t, ok, "%v and %v have the same key %s", k, other, key)
+			seen[string(key)] = k
+		}
+	}
+
+	// The locks of different databases do not overlap.
+	require.False(t, span.Overlaps(DatabaseSpan(codec, dbID+1)))
+}
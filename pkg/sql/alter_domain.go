@@ -0,0 +1,216 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/errors"
+)
+
+type alterDomainNode struct {
+	n    *tree.AlterDomain
+	desc *typedesc.Mutable
+}
+
+// alterDomainNode implements planNode. We set n here to satisfy the linter.
+var _ planNode = &alterDomainNode{n: nil}
+
+// AlterDomain applies a schema change on a domain.
+// Privileges: ownership of the domain.
+func (p *planner) AlterDomain(ctx context.Context, n *tree.AlterDomain) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"ALTER DOMAIN",
+	); err != nil {
+		return nil, err
+	}
+
+	// Resolve the domain.
+	_, desc, err := p.ResolveMutableTypeDescriptor(ctx, n.Domain, true /* required */)
+	if err != nil {
+		return nil, err
+	}
+	if desc.Kind != descpb.TypeDescriptor_DOMAIN {
+		return nil, pgerror.Newf(pgcode.WrongObjectType,
+			"%q is not a domain", tree.AsStringWithFQNames(n.Domain, &p.semaCtx.Annotations))
+	}
+
+	// Renaming, changing the schema and changing the owner of a domain behave
+	// exactly as they do for any other type.
+	if cmd, ok := n.Cmd.(tree.AlterTypeCmd); ok {
+		return p.AlterType(ctx, &tree.AlterType{Type: n.Domain, Cmd: cmd})
+	}
+
+	// The user needs ownership privilege to alter the domain.
+	if err := p.canModifyType(ctx, desc); err != nil {
+		return nil, err
+	}
+	return &alterDomainNode{n: n, desc: desc}, nil
+}
+
+func (n *alterDomainNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeAlterCounterWithExtra("domain", n.n.Cmd.TelemetryName()))
+
+	desc := n.desc
+	domainName := tree.AsStringWithFQNames(n.n.Domain, params.p.Ann())
+	switch t := n.n.Cmd.(type) {
+	case *tree.AlterDomainSetNotNull:
+		if desc.Domain.NotNull {
+			return nil
+		}
+		if err := params.p.validateDomainColumns(params.ctx, desc, nil /* check */); err != nil {
+			return err
+		}
+		desc.Domain.NotNull = true
+
+	case *tree.AlterDomainDropNotNull:
+		if !desc.Domain.NotNull {
+			return nil
+		}
+		desc.Domain.NotNull = false
+
+	case *tree.AlterDomainAddConstraint:
+		check, err := makeDomainCheck(params.ctx, params.p, desc.Name, desc.Domain, &t.Constraint)
+		if err != nil {
+			return err
+		}
+		if err := params.p.validateDomainColumns(params.ctx, desc, t.Constraint.Check); err != nil {
+			return err
+		}
+		desc.Domain.Checks = append(desc.Domain.Checks, check)
+
+	case *tree.AlterDomainDropConstraint:
+		idx := -1
+		for i := range desc.Domain.Checks {
+			if desc.Domain.Checks[i].Name == string(t.Constraint) {
+				idx = i
+				break
+			}
+		}
+		if idx == -1 {
+			if t.IfExists {
+				params.p.BufferClientNotice(params.ctx, pgnotice.Newf(
+					"constraint %q of domain %q does not exist, skipping", t.Constraint, desc.Name,
+				))
+				return nil
+			}
+			return pgerror.Newf(pgcode.UndefinedObject,
+				"constraint %q of domain %q does not exist", t.Constraint, desc.Name)
+		}
+		desc.Domain.Checks = append(desc.Domain.Checks[:idx], desc.Domain.Checks[idx+1:]...)
+
+	default:
+		return errors.AssertionFailedf("unknown alter domain cmd %s", t)
+	}
+
+	if err := params.p.writeTypeSchemaChange(
+		params.ctx, desc, tree.AsStringWithFQNames(n.n, params.p.Ann()),
+	); err != nil {
+		return err
+	}
+	return params.p.logEvent(params.ctx, desc.ID, &eventpb.AlterType{
+		TypeName: domainName,
+	})
+}
+
+// validateDomainColumns verifies that the existing values of all the table
+// columns of the given domain type satisfy a new constraint of the domain. If
+// check is nil, the new constraint is NOT NULL; otherwise it is the given CHECK
+// constraint expression.
+func (p *planner) validateDomainColumns(
+	ctx context.Context, desc *typedesc.Mutable, check tree.Expr,
+) error {
+	// Values of the domain stored in arrays would also need to be validated.
+	arrayDesc, err := p.Descriptors().ByIDWithLeased(p.txn).Get().Type(ctx, desc.ArrayTypeID)
+	if err != nil {
+		return err
+	}
+	if len(arrayDesc.GetReferencingDescriptorIDs()) > 0 {
+		return unimplemented.NewWithIssue(27796,
+			"cannot add a constraint to a domain whose array type is in use")
+	}
+	domainOID := catid.TypeIDToOID(desc.ID)
+	for _, id := range desc.ReferencingDescriptorIDs {
+		tbl, err := p.Descriptors().ByIDWithLeased(p.txn).Get().Table(ctx, id)
+		if err != nil {
+			// The domain may be referenced by descriptors other than tables, such
+			// as functions, which store no values.
+			if errors.Is(err, catalog.ErrDescriptorWrongType) {
+				continue
+			}
+			return err
+		}
+		if !tbl.IsTable() {
+			continue
+		}
+		for _, col := range tbl.PublicColumns() {
+			if col.GetType().Oid() != domainOID {
+				continue
+			}
+			colRef := tree.NewUnresolvedName(col.GetName())
+			var query string
+			if check == nil {
+				query = fmt.Sprintf(`SELECT 1 FROM [%d AS t] WHERE %s IS NULL LIMIT 1`,
+					tbl.GetID(), tree.Serialize(colRef))
+			} else {
+				expr, err := schemaexpr.ReplaceDomainValue(check, colRef)
+				if err != nil {
+					return err
+				}
+				query = fmt.Sprintf(`SELECT 1 FROM [%d AS t] WHERE NOT (%s) LIMIT 1`,
+					tbl.GetID(), tree.Serialize(expr))
+			}
+			row, err := p.InternalSQLTxn().QueryRowEx(
+				ctx,
+				"validate domain constraint",
+				p.txn,
+				sessiondata.NodeUserSessionDataOverride,
+				query,
+			)
+			if err != nil {
+				return err
+			}
+			if row == nil {
+				continue
+			}
+			if check == nil {
+				return pgerror.Newf(pgcode.NotNullViolation,
+					"column %q of table %q contains null values", col.GetName(), tbl.GetName())
+			}
+			return pgerror.Newf(pgcode.CheckViolation,
+				"column %q of table %q contains values that violate the new constraint",
+				col.GetName(), tbl.GetName())
+		}
+	}
+	return nil
+}
+
+func (n *alterDomainNode) Next(params runParams) (bool, error) { return false, nil }
+func (n *alterDomainNode) Values() tree.Datums                 { return tree.Datums{} }
+func (n *alterDomainNode) Close(ctx context.Context)           {}
+func (n *alterDomainNode) ReadingOwnWrites()                   {}
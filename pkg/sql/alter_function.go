@@ -538,7 +538,10 @@ func toSchemaOverloadSignature(fnDesc *funcdesc.Mutable) descpb.SchemaDescriptor
 		if tree.IsInParamClass(class) {
 			ret.ArgTypes = append(ret.ArgTypes, param.Type)
 		}
-		if class == tree.RoutineParamOut {
+		if class == tree.RoutineParamVariadic {
+			ret.IsVariadic = true
+		}
+		if tree.IsOutOnlyParamClass(class) {
 			ret.OutParamOrdinals = append(ret.OutParamOrdinals, int32(paramIdx))
 			ret.OutParamTypes = append(ret.OutParamTypes, param.Type)
 		}
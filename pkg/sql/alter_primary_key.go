@@ -68,6 +68,14 @@ func (p *planner) AlterPrimaryKey(
 		return err
 	}
 
+	// Changing the primary key rewrites the secondary indexes, including those
+	// backing exclusion constraints, which would have to be updated to refer to
+	// the new indexes.
+	if len(tableDesc.GetExclusionConstraints()) > 0 {
+		return unimplemented.NewWithIssuef(46657,
+			"cannot change the primary key of table %s, which has exclusion constraints", tableDesc.Name)
+	}
+
 	if alterPrimaryKeyLocalitySwap != nil {
 		if err := p.checkNoRegionChangeUnderway(
 			ctx,
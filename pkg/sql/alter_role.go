@@ -673,23 +673,33 @@ func (n *alterRoleSetNode) makeNewSettings(
 // performs validation to make sure the session variable exists and is
 // configurable with the given value.
 func (n *alterRoleSetNode) getSessionVarVal(params runParams) (string, error) {
-	if n.varName == "" || n.typedValues == nil {
+	return evalSessionVarVal(params, n.varName, n.sVar, n.typedValues)
+}
+
+// evalSessionVarVal evaluates the typedValues returned by
+// processSetOrResetClause to get a string value that can be persisted and
+// later applied to a session. It validates the value without applying it to
+// any real session.
+func evalSessionVarVal(
+	params runParams, varName string, sVar sessionVar, typedValues []tree.TypedExpr,
+) (string, error) {
+	if varName == "" || typedValues == nil {
 		return "", nil
 	}
-	for i, v := range n.typedValues {
+	for i, v := range typedValues {
 		d, err := eval.Expr(params.ctx, params.EvalContext(), v)
 		if err != nil {
 			return "", err
 		}
-		n.typedValues[i] = d
+		typedValues[i] = d
 	}
 	var strVal string
 	var err error
-	if n.sVar.GetStringVal != nil {
-		strVal, err = n.sVar.GetStringVal(params.ctx, params.extendedEvalCtx, n.typedValues, params.p.Txn())
+	if sVar.GetStringVal != nil {
+		strVal, err = sVar.GetStringVal(params.ctx, params.extendedEvalCtx, typedValues, params.p.Txn())
 	} else {
 		// No string converter defined, use the default one.
-		strVal, err = getStringVal(params.ctx, params.EvalContext(), n.varName, n.typedValues)
+		strVal, err = getStringVal(params.ctx, params.EvalContext(), varName, typedValues)
 	}
 	if err != nil {
 		return "", err
@@ -697,7 +707,7 @@ func (n *alterRoleSetNode) getSessionVarVal(params runParams) (string, error) {
 
 	// Validate the new string value, but don't actually apply it to any real
 	// session.
-	if err := CheckSessionVariableValueValid(params.ctx, params.ExecCfg().Settings, n.varName, strVal); err != nil {
+	if err := CheckSessionVariableValueValid(params.ctx, params.ExecCfg().Settings, varName, strVal); err != nil {
 		return "", err
 	}
 	return strVal, nil
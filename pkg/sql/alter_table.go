@@ -96,6 +96,11 @@ func (p *planner) AlterTable(ctx context.Context, n *tree.AlterTable) (planNode,
 		return newZeroNode(nil /* columns */), nil
 	}
 
+	if tableDesc.IsForeignTable() {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"cannot alter foreign table %q", tableDesc.GetName())
+	}
+
 	// This check for CREATE privilege is kept for backwards compatibility.
 	if err := p.CheckPrivilege(ctx, tableDesc, privilege.CREATE); err != nil {
 		return nil, pgerror.Wrapf(err, pgcode.InsufficientPrivilege,
@@ -232,6 +237,9 @@ func (n *alterTableNode) startExec(params runParams) error {
 					}
 					continue
 				}
+				if d.Deferrable != tree.ConstraintNotDeferrable {
+					return errDeferrableUniqueIndex
+				}
 
 				if d.PrimaryKey {
 					if t.ValidationBehavior == tree.ValidationSkip {
@@ -479,6 +487,10 @@ func (n *alterTableNode) startExec(params runParams) error {
 				// 	return err
 				// }
 
+			case *tree.ExclusionConstraintTableDef:
+				return unimplemented.NewWithIssue(46657,
+					"adding an exclusion constraint to an existing table is not supported")
+
 			default:
 				return errors.AssertionFailedf(
 					"unsupported constraint: %T", t.ConstraintDef)
@@ -536,6 +548,25 @@ func (n *alterTableNode) startExec(params runParams) error {
 			droppedViews = append(droppedViews, colDroppedViews...)
 		case *tree.AlterTableDropConstraint:
 			name := string(t.Constraint)
+			if ec := findExclusionConstraintByName(n.tableDesc, name); ec != nil {
+				// Drop the index backing the constraint along with it, which also
+				// drops the constraint.
+				if idx := catalog.FindIndexByID(n.tableDesc, ec.IndexID); ec.IndexID != 0 && idx != nil {
+					jobDesc := fmt.Sprintf(
+						"removing index %q backing exclusion constraint %q; full details: %s",
+						idx.GetName(), name, tree.AsStringWithFQNames(tn, params.Ann()),
+					)
+					if err := params.p.dropIndexByName(
+						params.ctx, tn, tree.UnrestrictedName(idx.GetName()), n.tableDesc, false, /* ifExists */
+						t.DropBehavior, ignoreIdxConstraint, jobDesc,
+					); err != nil {
+						return err
+					}
+				}
+				n.tableDesc.DropExclusionConstraint(name)
+				descriptorChanged = true
+				continue
+			}
 			c := catalog.FindConstraintByName(n.tableDesc, name)
 			if c == nil {
 				if t.IfExists {
@@ -732,6 +763,7 @@ func (n *alterTableNode) startExec(params runParams) error {
 			}
 
 		case *tree.AlterTableSetStorageParams:
+			partitionMaintenanceBefore := n.tableDesc.GetPartitionMaintenance()
 			setter := tablestorageparam.NewSetter(n.tableDesc)
 			if err := storageparam.Set(
 				params.ctx,
@@ -753,8 +785,14 @@ func (n *alterTableNode) startExec(params runParams) error {
 			if err != nil {
 				return err
 			}
+			if err := handlePartitionMaintenanceStorageParamChange(
+				params, setter.TableDesc, partitionMaintenanceBefore,
+			); err != nil {
+				return err
+			}
 
 		case *tree.AlterTableResetStorageParams:
+			partitionMaintenanceBefore := n.tableDesc.GetPartitionMaintenance()
 			setter := tablestorageparam.NewSetter(n.tableDesc)
 			if err := storageparam.Reset(
 				params.ctx,
@@ -775,6 +813,11 @@ func (n *alterTableNode) startExec(params runParams) error {
 			if err != nil {
 				return err
 			}
+			if err := handlePartitionMaintenanceStorageParamChange(
+				params, setter.TableDesc, partitionMaintenanceBefore,
+			); err != nil {
+				return err
+			}
 
 		case *tree.AlterTableRenameColumn:
 			tableDesc := n.tableDesc
@@ -1637,6 +1680,9 @@ func validateConstraintNameIsNotUsed(
 	case *tree.ForeignKeyConstraintTableDef:
 		name = d.Name
 		hasIfNotExists = d.IfNotExists
+	case *tree.ExclusionConstraintTableDef:
+		name = d.Name
+		hasIfNotExists = d.IfNotExists
 	case *tree.UniqueConstraintTableDef:
 		name = d.Name
 		hasIfNotExists = d.IfNotExists
@@ -1765,6 +1811,20 @@ func (p *planner) updateFKBackReferenceName(
 	return errors.Errorf("missing backreference for foreign key %s", ref.Name)
 }
 
+// findExclusionConstraintByName returns the exclusion constraint of the table
+// with the given name, or nil if there is none.
+func findExclusionConstraintByName(
+	desc catalog.TableDescriptor, name string,
+) *descpb.ExclusionConstraint {
+	exclusionConstraints := desc.GetExclusionConstraints()
+	for i := range exclusionConstraints {
+		if exclusionConstraints[i].Name == name {
+			return &exclusionConstraints[i]
+		}
+	}
+	return nil
+}
+
 func dropColumnImpl(
 	params runParams,
 	tn *tree.TableName,
@@ -1908,6 +1968,12 @@ func dropColumnImpl(
 				return nil, sqlerrors.NewColumnReferencedByPartialIndex(string(colToDrop.ColName()), idx.GetName())
 			}
 		}
+		// Also drop the indexes backing exclusion constraints which reference
+		// the column, since the constraints are dropped below.
+		if ec := exclusionConstraintBackedByIndex(tableDesc, idx.GetID()); ec != nil &&
+			descpb.ColumnIDs(ec.ColumnIDs).Contains(colToDrop.GetID()) {
+			containsThisColumn = true
+		}
 		// Perform the DROP.
 		if containsThisColumn {
 			idxNamesToDelete = append(idxNamesToDelete, idx.GetName())
@@ -1988,6 +2054,15 @@ func dropColumnImpl(
 		}
 	}
 
+	// Drop exclusion constraints which reference the column.
+	exclusionConstraints := tableDesc.ExclusionConstraints[:0]
+	for _, ec := range tableDesc.ExclusionConstraints {
+		if !descpb.ColumnIDs(ec.ColumnIDs).Contains(colToDrop.GetID()) {
+			exclusionConstraints = append(exclusionConstraints, ec)
+		}
+	}
+	tableDesc.ExclusionConstraints = exclusionConstraints
+
 	if err := params.p.deleteComment(
 		params.ctx, tableDesc.ID, uint32(colToDrop.GetPGAttributeNum()), catalogkeys.ColumnCommentType,
 	); err != nil {
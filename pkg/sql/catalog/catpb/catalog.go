@@ -131,3 +131,19 @@ func (rowLevelTTL *RowLevelTTL) GetTTLExpr() Expression {
 	}
 	return DefaultTTLExpirationExpr
 }
+
+// DefaultPartitionLookahead is the number of partitions created ahead of the
+// partition containing the current time if partition_lookahead is not set.
+const DefaultPartitionLookahead = 3
+
+// PartitionMaintenanceCron is the schedule on which partition maintenance
+// jobs run.
+const PartitionMaintenanceCron = "@hourly"
+
+// LookaheadOrDefault returns the Lookahead or the default.
+func (m *PartitionMaintenance) LookaheadOrDefault() int64 {
+	if m.Lookahead != 0 {
+		return m.Lookahead
+	}
+	return DefaultPartitionLookahead
+}
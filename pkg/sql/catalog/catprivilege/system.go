@@ -78,6 +78,9 @@ var (
 		catconstants.MVCCStatistics,
 		catconstants.TxnExecInsightsTableName,
 		catconstants.StmtExecInsightsTableName,
+		catconstants.NotificationsTableName,
+		catconstants.PublicationsTableName,
+		catconstants.ReplicationSlotsTableName,
 	}
 
 	readWriteSystemSequences = []catconstants.SystemTableName{
@@ -20,6 +20,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/errors"
@@ -92,9 +93,12 @@ func ValidateColumnDefType(ctx context.Context, st *cluster.Settings, t *types.T
 		}
 
 	case types.ArrayFamily:
-		if t.ArrayContents().Family() == types.ArrayFamily {
-			// Nested arrays are not supported as a column type.
-			return errors.Errorf("nested array unsupported as column type: %s", t.String())
+		if t.ArrayContents().Family() == types.ArrayFamily &&
+			!st.Version.IsActive(ctx, clusterversion.V24_2_MultiDimArrays) {
+			return pgerror.Newf(
+				pgcode.FeatureNotSupported,
+				"multi-dimensional arrays not supported until version 24.2",
+			)
 		}
 		if t.ArrayContents().Family() == types.JsonFamily {
 			// JSON arrays are not supported as a column type.
@@ -113,6 +117,14 @@ func ValidateColumnDefType(ctx context.Context, st *cluster.Settings, t *types.T
 		types.TSQueryFamily, types.TSVectorFamily, types.PGLSNFamily, types.RefCursorFamily:
 	// These types are OK.
 
+	case types.RangeFamily:
+		if !st.Version.IsActive(ctx, clusterversion.V24_2_RangeTypes) {
+			return pgerror.Newf(
+				pgcode.FeatureNotSupported,
+				"range types not supported until version 24.2",
+			)
+		}
+
 	case types.TupleFamily:
 		if !t.UserDefined() {
 			return pgerror.New(pgcode.InvalidTableDefinition, "cannot use anonymous record type as table column")
@@ -140,6 +152,24 @@ func ValidateColumnDefType(ctx context.Context, st *cluster.Settings, t *types.T
 	return nil
 }
 
+// ValidateArrayValue returns an error if d is an array that cannot be stored
+// until the cluster has been upgraded to V24_2_MultiDimArrays, i.e. an array
+// with several dimensions or with lower bounds other than the default. The
+// value encoding of such arrays cannot be decoded by older nodes.
+func ValidateArrayValue(ctx context.Context, st *cluster.Settings, d tree.Datum) error {
+	arr, ok := d.(*tree.DArray)
+	if !ok || st.Version.IsActive(ctx, clusterversion.V24_2_MultiDimArrays) {
+		return nil
+	}
+	if len(arr.Dimensions()) > 1 || !arr.HasDefaultLowerBounds() {
+		return pgerror.Newf(
+			pgcode.FeatureNotSupported,
+			"multi-dimensional arrays and arrays with explicit lower bounds not supported until version 24.2",
+		)
+	}
+	return nil
+}
+
 // ColumnTypeIsIndexable returns whether the type t is valid as an indexed column.
 func ColumnTypeIsIndexable(t *types.T) bool {
 	// NB: .IsAmbiguous checks the content type of array types.
@@ -164,8 +194,10 @@ func ColumnTypeIsIndexable(t *types.T) bool {
 func ColumnTypeIsInvertedIndexable(t *types.T) bool {
 	switch t.Family() {
 	case types.ArrayFamily:
-		return t.ArrayContents().Family() != types.RefCursorFamily
-	case types.JsonFamily, types.StringFamily:
+		// Inverted indexes on multi-dimensional arrays index their scalar
+		// elements, since containment is defined in terms of them.
+		return tree.ArrayScalarType(t.ArrayContents()).Family() != types.RefCursorFamily
+	case types.JsonFamily, types.StringFamily, types.RangeFamily:
 		return true
 	}
 	return ColumnTypeIsOnlyInvertedIndexable(t)
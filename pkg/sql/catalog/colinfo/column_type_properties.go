@@ -57,6 +57,8 @@ func CanHaveCompositeKeyEncoding(typ *types.T) bool {
 		return true
 	case types.ArrayFamily:
 		return CanHaveCompositeKeyEncoding(typ.ArrayContents())
+	case types.RangeFamily:
+		return CanHaveCompositeKeyEncoding(typ.RangeContents())
 	case types.TupleFamily:
 		for _, t := range typ.TupleContents() {
 			if CanHaveCompositeKeyEncoding(t) {
@@ -86,6 +88,7 @@ func CanHaveCompositeKeyEncoding(typ *types.T) bool {
 		types.PGVectorFamily,
 		types.RefCursorFamily,
 		types.VoidFamily,
+		types.TriggerFamily,
 		types.EncodedKeyFamily,
 		types.TSQueryFamily,
 		types.TSVectorFamily:
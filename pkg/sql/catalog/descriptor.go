@@ -328,6 +328,10 @@ type TableDescriptor interface {
 	// virtual Table (like the information_schema tables) and thus doesn't
 	// need to be physically stored.
 	IsVirtualTable() bool
+	// IsForeignTable returns true if the TableDescriptor describes a foreign
+	// table, whose rows are read from files in external storage. A foreign
+	// table has a primary index, but no data is ever written to it.
+	IsForeignTable() bool
 	// IsPhysicalTable returns true if the TableDescriptor actually describes a
 	// physical Table that needs to be stored in the kv layer, as opposed to a
 	// different resource like a view or a virtual table. Physical tables have
@@ -552,6 +556,11 @@ type TableDescriptor interface {
 	// GetNextConstraintID returns the next unused constraint ID for this table.
 	// Constraint IDs are unique per table, but not unique globally.
 	GetNextConstraintID() descpb.ConstraintID
+	// GetNextTriggerID returns the next unused trigger ID for this table.
+	// Trigger IDs are unique per table, but not unique globally.
+	GetNextTriggerID() descpb.TriggerID
+	// GetTriggers returns the triggers defined on this table.
+	GetTriggers() []descpb.TriggerDescriptor
 	// IsShardColumn returns true if col corresponds to a non-dropped hash sharded
 	// index. This method assumes that col is currently a member of desc.
 	IsShardColumn(col Column) bool
@@ -718,6 +727,17 @@ type TableDescriptor interface {
 	GetRowLevelTTL() *catpb.RowLevelTTL
 	// HasRowLevelTTL returns where there is a row-level TTL config for the table.
 	HasRowLevelTTL() bool
+	// GetPartitionMaintenance returns the automatic time-based partitioning
+	// config for the table.
+	GetPartitionMaintenance() *catpb.PartitionMaintenance
+	// HasPartitionMaintenance returns whether there is an automatic time-based
+	// partitioning config for the table.
+	HasPartitionMaintenance() bool
+	// GetForeignTable returns the server and options of a foreign table, or nil
+	// if the table is not a foreign table.
+	GetForeignTable() *descpb.ForeignTableDescriptor
+	// GetExclusionConstraints returns the EXCLUDE constraints of the table.
+	GetExclusionConstraints() []descpb.ExclusionConstraint
 	// GetExcludeDataFromBackup returns true if the table's row data is configured
 	// to be excluded during backup.
 	GetExcludeDataFromBackup() bool
@@ -820,6 +840,14 @@ type TypeDescriptor interface {
 	// nil otherwise.
 	AsAliasTypeDescriptor() AliasTypeDescriptor
 
+	// AsDomainTypeDescriptor returns this instance cast to
+	// DomainTypeDescriptor if this type is a domain type, nil otherwise.
+	AsDomainTypeDescriptor() DomainTypeDescriptor
+
+	// AsRangeTypeDescriptor returns this instance cast to RangeTypeDescriptor
+	// if this type is a user-defined range type, nil otherwise.
+	AsRangeTypeDescriptor() RangeTypeDescriptor
+
 	// AsCompositeTypeDescriptor returns this instance cast to
 	// CompositeTypeDescriptor if this type is a composite type,
 	// nil otherwise.
@@ -912,6 +940,37 @@ type CompositeTypeDescriptor interface {
 	GetElementType(ordinal int) *types.T
 }
 
+// DomainTypeDescriptor is the TypeDescriptor subtype for domain types.
+type DomainTypeDescriptor interface {
+	NonAliasTypeDescriptor
+
+	// BaseType returns the type underlying the domain.
+	BaseType() *types.T
+
+	// NotNull returns true if the domain does not allow NULL values.
+	NotNull() bool
+
+	// NumChecks returns the number of CHECK constraints of the domain.
+	NumChecks() int
+
+	// GetCheckName returns the name of the CHECK constraint at the given
+	// ordinal.
+	GetCheckName(ordinal int) string
+
+	// GetCheckExpr returns the serialized expression of the CHECK constraint at
+	// the given ordinal.
+	GetCheckExpr(ordinal int) string
+}
+
+// RangeTypeDescriptor is the TypeDescriptor subtype for user-defined range
+// types.
+type RangeTypeDescriptor interface {
+	NonAliasTypeDescriptor
+
+	// RangeSubtype returns the type of the bounds of the range type.
+	RangeSubtype() *types.T
+}
+
 // TableImplicitRecordTypeDescriptor is the TypeDescriptor subtype for the
 // record type implicitly defined by a table.
 type TableImplicitRecordTypeDescriptor interface {
@@ -962,6 +1021,13 @@ type FunctionDescriptor interface {
 	// GetFunctionBody returns the function body string.
 	GetFunctionBody() string
 
+	// GetSecurity returns the function's security attribute.
+	GetSecurity() catpb.Function_Security
+
+	// GetConfig returns the session variable settings of the function, in the
+	// form "name=value".
+	GetConfig() []string
+
 	// GetParams returns a list of all parameters of the function.
 	GetParams() []descpb.FunctionDescriptor_Parameter
 
@@ -996,6 +1062,10 @@ type FunctionDescriptor interface {
 	// IsProcedure returns true if the descriptor represents a procedure. It
 	// returns false if the descriptor represents a user-defined function.
 	IsProcedure() bool
+
+	// IsAggregate returns true if the descriptor represents a user-defined
+	// aggregate function.
+	IsAggregate() bool
 }
 
 // FilterDroppedDescriptor returns an error if the descriptor state is DROP.
@@ -73,3 +73,32 @@ func (c *IndexFetchSpec_KeyColumn) EncodingDirection() encoding.Direction {
 	}
 	return encoding.Ascending
 }
+
+// HasAggregations returns whether the pushdown computes any aggregations, in
+// which case the fetch produces the partial aggregates rather than the fetched
+// columns.
+func (p *IndexFetchSpec_Pushdown) HasAggregations() bool {
+	return p != nil && len(p.Aggregations) > 0
+}
+
+// AggregateResultTypes returns the types of the partial aggregates produced by
+// the pushdown given the types of the fetched columns.
+func (p *IndexFetchSpec_Pushdown) AggregateResultTypes(fetchedTypes []*types.T) []*types.T {
+	res := make([]*types.T, len(p.Aggregations))
+	for i, agg := range p.Aggregations {
+		switch agg.Func {
+		case IndexFetchSpec_Pushdown_COUNT_ROWS, IndexFetchSpec_Pushdown_COUNT:
+			res[i] = types.Int
+		case IndexFetchSpec_Pushdown_SUM:
+			// This matches the return types of the sum builtin.
+			if fetchedTypes[agg.ColIdx].Family() == types.FloatFamily {
+				res[i] = types.Float
+			} else {
+				res[i] = types.Decimal
+			}
+		default:
+			res[i] = fetchedTypes[agg.ColIdx]
+		}
+	}
+	return res
+}
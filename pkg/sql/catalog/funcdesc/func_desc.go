@@ -12,6 +12,7 @@ package funcdesc
 
 import (
 	"sort"
+	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
@@ -216,6 +217,12 @@ func (desc *immutable) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 	vp := funcinfo.MakeVolatilityProperties(desc.Volatility, desc.LeakProof)
 	vea.Report(vp.Validate())
 
+	for i, setting := range desc.Config {
+		if name, _, ok := strings.Cut(setting, "="); !ok || name == "" {
+			vea.Report(errors.AssertionFailedf("malformed session variable setting %q in config #%d", setting, i))
+		}
+	}
+
 	for i, dep := range desc.DependedOnBy {
 		if dep.ID == descpb.InvalidID {
 			vea.Report(errors.AssertionFailedf("invalid relation id %d in depended-on-by references #%d", dep.ID, i))
@@ -233,6 +240,45 @@ func (desc *immutable) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 			vea.Report(errors.AssertionFailedf("invalid type id %d in depends-on-types references #%d", typeID, i))
 		}
 	}
+
+	if agg := desc.Aggregate; agg != nil {
+		if desc.IsProcedure() {
+			vea.Report(errors.AssertionFailedf("procedure cannot be an aggregate"))
+		}
+		if desc.ReturnType.ReturnSet {
+			vea.Report(errors.AssertionFailedf("aggregate cannot return a set"))
+		}
+		if desc.FunctionBody != "" {
+			vea.Report(errors.AssertionFailedf("aggregate cannot have a function body"))
+		}
+		if agg.StateType == nil {
+			vea.Report(errors.AssertionFailedf("aggregate state type not set"))
+		}
+		funcIDs := []descpb.ID{agg.StateFuncID, agg.FinalFuncID, agg.CombineFuncID}
+		if agg.StateFuncID == descpb.InvalidID {
+			vea.Report(errors.AssertionFailedf("aggregate state transition function not set"))
+		}
+		for _, id := range funcIDs {
+			if id == descpb.InvalidID {
+				continue
+			}
+			if id == desc.GetID() {
+				vea.Report(errors.AssertionFailedf("aggregate cannot reference itself"))
+			}
+			var found bool
+			for _, depID := range desc.DependsOnFunctions {
+				if depID == id {
+					found = true
+					break
+				}
+			}
+			if !found {
+				vea.Report(errors.AssertionFailedf(
+					"aggregate function %d missing from depends-on-functions references", id,
+				))
+			}
+		}
+	}
 }
 
 // ValidateForwardReferences implements the catalog.Descriptor interface.
@@ -330,9 +376,9 @@ func (desc *immutable) validateInboundFunctionRef(
 			backrefFunctionDesc.GetName(), backrefFunctionDesc.GetID())
 	}
 	// Validate all other references are unset.
-	if ref.ColumnIDs != nil || ref.IndexIDs != nil || ref.ConstraintIDs != nil {
-		return errors.AssertionFailedf("function reference has invalid references (%v, %v %v)",
-			ref.ColumnIDs, ref.IndexIDs, ref.ConstraintIDs)
+	if ref.ColumnIDs != nil || ref.IndexIDs != nil || ref.ConstraintIDs != nil || ref.TriggerIDs != nil {
+		return errors.AssertionFailedf("function reference has invalid references (%v, %v, %v, %v)",
+			ref.ColumnIDs, ref.IndexIDs, ref.ConstraintIDs, ref.TriggerIDs)
 	}
 	// Validate a reference exists to this function.
 	for _, refID := range backrefFunctionDesc.GetDependsOnFunctions() {
@@ -409,6 +455,24 @@ func (desc *immutable) validateInboundTableRef(
 			cstID, backRefTbl.GetName(), backRefTbl.GetID(), desc.GetName(), desc.GetID(),
 		)
 	}
+
+	for _, triggerID := range by.TriggerIDs {
+		trigger := catalog.FindTriggerByID(backRefTbl, triggerID)
+		if trigger == nil {
+			return errors.AssertionFailedf("depended-on-by relation %q (%d) does not have a trigger with ID %d",
+				backRefTbl.GetName(), by.ID, triggerID)
+		}
+		fnIDs := catalog.MakeDescriptorIDSet(trigger.DependsOnRoutines...)
+		fnIDs.Add(trigger.FuncID)
+		if fnIDs.Contains(desc.GetID()) {
+			foundInTable = true
+			continue
+		}
+		return errors.AssertionFailedf(
+			"trigger %d in depended-on-by relation %q (%d) does not have reference to function %q (%d)",
+			triggerID, backRefTbl.GetName(), backRefTbl.GetID(), desc.GetName(), desc.GetID(),
+		)
+	}
 	if foundInTable {
 		return nil
 	}
@@ -463,6 +527,11 @@ func (desc *immutable) ForEachUDTDependentForHydration(fn func(t *types.T) error
 			return iterutil.Map(err)
 		}
 	}
+	if agg := desc.Aggregate; agg != nil && catid.IsOIDUserDefined(agg.StateType.Oid()) {
+		if err := fn(agg.StateType); err != nil {
+			return iterutil.Map(err)
+		}
+	}
 	if !catid.IsOIDUserDefined(desc.ReturnType.Type.Oid()) {
 		return nil
 	}
@@ -566,6 +635,36 @@ func (desc *Mutable) SetNullInputBehavior(v catpb.Function_NullInputBehavior) {
 	desc.NullInputBehavior = v
 }
 
+// SetSecurity sets the security attribute.
+func (desc *Mutable) SetSecurity(v catpb.Function_Security) {
+	desc.Security = v
+}
+
+// SetConfigValue sets the value of the given session variable for the
+// duration of calls to the function, replacing any existing setting for the
+// variable.
+func (desc *Mutable) SetConfigValue(name, value string) {
+	desc.ResetConfigValue(name)
+	desc.Config = append(desc.Config, name+"="+value)
+}
+
+// ResetConfigValue removes the setting for the given session variable, if
+// any.
+func (desc *Mutable) ResetConfigValue(name string) {
+	var config []string
+	for _, setting := range desc.Config {
+		if settingName, _, _ := strings.Cut(setting, "="); settingName != name {
+			config = append(config, setting)
+		}
+	}
+	desc.Config = config
+}
+
+// ResetConfig removes all session variable settings.
+func (desc *Mutable) ResetConfig() {
+	desc.Config = nil
+}
+
 // SetLang sets the function language.
 func (desc *Mutable) SetLang(v catpb.Function_Language) {
 	desc.Lang = v
@@ -630,6 +729,63 @@ func (desc *Mutable) RemoveConstraintReference(id descpb.ID, constraintID descpb
 	}
 }
 
+// AddTriggerReference adds back reference to a trigger to the function.
+func (desc *Mutable) AddTriggerReference(id descpb.ID, triggerID descpb.TriggerID) error {
+	for _, dep := range desc.DependsOn {
+		if dep == id {
+			return pgerror.Newf(pgcode.InvalidFunctionDefinition,
+				"cannot add dependency from descriptor %d to function %s (%d) because there will be a dependency cycle", id, desc.GetName(), desc.GetID(),
+			)
+		}
+	}
+	for i := range desc.DependedOnBy {
+		if desc.DependedOnBy[i].ID == id {
+			for _, existing := range desc.DependedOnBy[i].TriggerIDs {
+				if existing == triggerID {
+					return nil
+				}
+			}
+			desc.DependedOnBy[i].TriggerIDs = append(desc.DependedOnBy[i].TriggerIDs, triggerID)
+			sort.Slice(desc.DependedOnBy[i].TriggerIDs, func(a, b int) bool {
+				return desc.DependedOnBy[i].TriggerIDs[a] < desc.DependedOnBy[i].TriggerIDs[b]
+			})
+			return nil
+		}
+	}
+	desc.DependedOnBy = append(
+		desc.DependedOnBy,
+		descpb.FunctionDescriptor_Reference{
+			ID:         id,
+			TriggerIDs: []descpb.TriggerID{triggerID},
+		},
+	)
+	sort.Slice(desc.DependedOnBy, func(i, j int) bool {
+		return desc.DependedOnBy[i].ID < desc.DependedOnBy[j].ID
+	})
+	return nil
+}
+
+// RemoveTriggerReference removes back reference to a trigger from the
+// function.
+func (desc *Mutable) RemoveTriggerReference(id descpb.ID, triggerID descpb.TriggerID) {
+	for i := range desc.DependedOnBy {
+		if desc.DependedOnBy[i].ID == id {
+			ids := desc.DependedOnBy[i].TriggerIDs[:0]
+			for _, existing := range desc.DependedOnBy[i].TriggerIDs {
+				if existing != triggerID {
+					ids = append(ids, existing)
+				}
+			}
+			if len(ids) == 0 {
+				ids = nil
+			}
+			desc.DependedOnBy[i].TriggerIDs = ids
+			desc.maybeRemoveTableReference(id)
+			return
+		}
+	}
+}
+
 // AddFunctionReference adds back reference for a function invoking this function.
 func (desc *Mutable) AddFunctionReference(id descpb.ID) error {
 	for _, f := range desc.DependsOnFunctions {
@@ -705,13 +861,14 @@ func (desc *Mutable) RemoveColumnReference(id descpb.ID, colID descpb.ColumnID)
 }
 
 // maybeRemoveTableReference removes a table's references from the function if
-// the column, index and constraint references are all empty. This function is
-// only used internally when removing an individual column, index or constraint
-// reference.
+// the column, index, constraint and trigger references are all empty. This
+// function is only used internally when removing an individual column, index,
+// constraint or trigger reference.
 func (desc *Mutable) maybeRemoveTableReference(id descpb.ID) {
 	var ret []descpb.FunctionDescriptor_Reference
 	for _, ref := range desc.DependedOnBy {
-		if ref.ID == id && len(ref.ColumnIDs) == 0 && len(ref.IndexIDs) == 0 && len(ref.ConstraintIDs) == 0 {
+		if ref.ID == id && len(ref.ColumnIDs) == 0 && len(ref.IndexIDs) == 0 &&
+			len(ref.ConstraintIDs) == 0 && len(ref.TriggerIDs) == 0 {
 			continue
 		}
 		ret = append(ret, ref)
@@ -795,6 +952,9 @@ func (desc *immutable) ToOverload() (ret *tree.Overload, err error) {
 		if tree.IsInParamClass(class) {
 			signatureTypes = append(signatureTypes, tree.ParamType{Name: param.Name, Typ: param.Type})
 		}
+		if class == tree.RoutineParamVariadic {
+			ret.Variadic = true
+		}
 		routineParam := tree.RoutineParam{
 			Name:  tree.Name(param.Name),
 			Type:  param.Type,
@@ -819,9 +979,28 @@ func (desc *immutable) ToOverload() (ret *tree.Overload, err error) {
 	if err != nil {
 		return nil, err
 	}
+	if desc.Security == catpb.Function_DEFINER {
+		ret.SecurityDefiner = true
+		ret.Owner = desc.Privileges.Owner()
+	}
+	ret.Config = desc.Config
 	if desc.ReturnType.ReturnSet {
 		ret.Class = tree.GeneratorClass
 	}
+	if agg := desc.Aggregate; agg != nil {
+		ret.Class = tree.AggregateClass
+		ret.UDFAggregate = &tree.UDFAggregate{
+			StateFunc: catid.FuncIDToOID(agg.StateFuncID),
+			StateType: agg.StateType,
+			InitCond:  agg.InitCond,
+		}
+		if agg.FinalFuncID != descpb.InvalidID {
+			ret.UDFAggregate.FinalFunc = catid.FuncIDToOID(agg.FinalFuncID)
+		}
+		if agg.CombineFuncID != descpb.InvalidID {
+			ret.UDFAggregate.CombineFunc = catid.FuncIDToOID(agg.CombineFuncID)
+		}
+	}
 
 	return ret, nil
 }
@@ -885,12 +1064,22 @@ func (desc *immutable) ToCreateExpr() (ret *tree.CreateRoutine, err error) {
 			}
 		}
 	}
-	// We only store 5 function attributes at the moment. We may extend the
-	// pre-allocated capacity in the future.
-	ret.Options = make(tree.RoutineOptions, 0, 5)
+	// We store 5 function attributes in addition to the security attribute
+	// and the session variable settings.
+	ret.Options = make(tree.RoutineOptions, 0, 5+1+len(desc.Config))
 	ret.Options = append(ret.Options, desc.getCreateExprVolatility())
 	ret.Options = append(ret.Options, tree.RoutineLeakproof(desc.LeakProof))
 	ret.Options = append(ret.Options, desc.getCreateExprNullInputBehavior())
+	if desc.Security == catpb.Function_DEFINER {
+		ret.Options = append(ret.Options, tree.RoutineSecurityDefiner)
+	}
+	for _, setting := range desc.Config {
+		name, value, _ := strings.Cut(setting, "=")
+		ret.Options = append(ret.Options, &tree.SetVar{
+			Name:   name,
+			Values: tree.Exprs{tree.NewStrVal(value)},
+		})
+	}
 	ret.Options = append(ret.Options, tree.RoutineBodyStr(desc.FunctionBody))
 	ret.Options = append(ret.Options, desc.getCreateExprLang())
 	return ret, nil
@@ -901,6 +1090,11 @@ func (desc *immutable) IsProcedure() bool {
 	return desc.FunctionDescriptor.IsProcedure
 }
 
+// IsAggregate implements the FunctionDescriptor interface.
+func (desc *immutable) IsAggregate() bool {
+	return desc.FunctionDescriptor.Aggregate != nil
+}
+
 func (desc *immutable) getCreateExprLang() tree.RoutineLanguage {
 	switch desc.Lang {
 	case catpb.Function_SQL:
@@ -949,6 +1143,8 @@ func ToTreeRoutineParamClass(class catpb.Function_Param_Class) tree.RoutineParam
 		return tree.RoutineParamInOut
 	case catpb.Function_Param_VARIADIC:
 		return tree.RoutineParamVariadic
+	case catpb.Function_Param_TABLE:
+		return tree.RoutineParamTable
 	}
 	return 0
 }
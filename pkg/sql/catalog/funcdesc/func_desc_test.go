@@ -249,6 +249,22 @@ func TestValidateFuncDesc(t *testing.T) {
 				Volatility: catpb.Function_VOLATILE,
 			},
 		},
+		{
+			`malformed session variable setting "search_path" in config #0`,
+			descpb.FunctionDescriptor{
+				Name:           "f",
+				ID:             funcDescID,
+				ParentID:       dbID,
+				ParentSchemaID: schemaID,
+				Privileges:     defaultPrivileges,
+				ReturnType: descpb.FunctionDescriptor_ReturnType{
+					Type: types.Int,
+				},
+				Volatility: catpb.Function_VOLATILE,
+				Security:   catpb.Function_DEFINER,
+				Config:     []string{"search_path"},
+			},
+		},
 		{
 			"invalid relation id 0 in depended-on-by references #0",
 			descpb.FunctionDescriptor{
@@ -762,6 +778,34 @@ func TestToOverload(t *testing.T) {
 				},
 			},
 		},
+		{
+			// Test SECURITY DEFINER and session variable settings.
+			desc: descpb.FunctionDescriptor{
+				ID:                1,
+				ReturnType:        descpb.FunctionDescriptor_ReturnType{Type: types.Int},
+				Volatility:        catpb.Function_VOLATILE,
+				NullInputBehavior: catpb.Function_CALLED_ON_NULL_INPUT,
+				FunctionBody:      "ANY QUERIES",
+				Lang:              catpb.Function_SQL,
+				Privileges:        catpb.NewBasePrivilegeDescriptor(username.TestUserName()),
+				Security:          catpb.Function_DEFINER,
+				Config:            []string{"search_path=public"},
+			},
+			expected: tree.Overload{
+				Oid:               oid.Oid(100001),
+				Types:             tree.ParamTypes{},
+				ReturnType:        tree.FixedReturnType(types.Int),
+				Volatility:        volatility.Volatile,
+				Body:              "ANY QUERIES",
+				Type:              tree.UDFRoutine,
+				CalledOnNullInput: true,
+				Language:          tree.RoutineLangSQL,
+				RoutineParams:     tree.RoutineParams{},
+				SecurityDefiner:   true,
+				Owner:             username.TestUserName(),
+				Config:            []string{"search_path=public"},
+			},
+		},
 		{
 			// Test failure on non-immutable but leakproof function.
 			desc: descpb.FunctionDescriptor{
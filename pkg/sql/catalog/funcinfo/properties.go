@@ -103,6 +103,19 @@ func NullInputBehaviorToProto(
 	return -1, errors.AssertionFailedf("Unknown function null input behavior %q", v)
 }
 
+// SecurityToProto converts sql statement input security mode to protobuf
+// type.
+func SecurityToProto(v tree.RoutineSecurity) (catpb.Function_Security, error) {
+	switch v {
+	case tree.RoutineSecurityInvoker:
+		return catpb.Function_INVOKER, nil
+	case tree.RoutineSecurityDefiner:
+		return catpb.Function_DEFINER, nil
+	}
+
+	return -1, errors.AssertionFailedf("Unknown function security %q", v)
+}
+
 // FunctionLangToProto converts sql statement input language to protobuf type.
 func FunctionLangToProto(v tree.RoutineLanguage) (catpb.Function_Language, error) {
 	switch v {
@@ -131,6 +144,8 @@ func ParamClassToProto(v tree.RoutineParamClass) (catpb.Function_Param_Class, er
 		return catpb.Function_Param_IN_OUT, nil
 	case tree.RoutineParamVariadic:
 		return catpb.Function_Param_VARIADIC, nil
+	case tree.RoutineParamTable:
+		return catpb.Function_Param_TABLE, nil
 	}
 
 	return -1, errors.AssertionFailedf("unknown function parameter class %q", v)
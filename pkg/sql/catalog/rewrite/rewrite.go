@@ -198,6 +198,13 @@ func TableDescs(
 			}
 		}
 
+		// Rewrite the references of the triggers defined on the table.
+		for i := range table.Triggers {
+			if err := rewriteTriggerReferences(table, &table.Triggers[i], descriptorRewrites); err != nil {
+				return err
+			}
+		}
+
 		// Rewrite unique_without_index in both `UniqueWithoutIndexConstraints`
 		// and `Mutations` slice.
 		origUniqueWithoutIndexConstraints := table.UniqueWithoutIndexConstraints
@@ -462,6 +469,34 @@ func rewriteSequencesInExpr(expr string, rewrites jobspb.DescRewriteMap) (string
 	return newExpr.String(), nil
 }
 
+// rewriteTriggerReferences rewrites the IDs of the function and the other
+// objects referenced by a trigger.
+func rewriteTriggerReferences(
+	table *tabledesc.Mutable, trigger *descpb.TriggerDescriptor, descriptorRewrites jobspb.DescRewriteMap,
+) error {
+	rewriteIDs := func(ids []descpb.ID, kind string) error {
+		for i, id := range ids {
+			rewrite, ok := descriptorRewrites[id]
+			if !ok {
+				return errors.AssertionFailedf(
+					"cannot restore %q because %s %d referenced by trigger %q was not found",
+					table.Name, kind, id, trigger.Name)
+			}
+			ids[i] = rewrite.ID
+		}
+		return nil
+	}
+	funcID := []descpb.ID{trigger.FuncID}
+	if err := rewriteIDs(funcID, "function"); err != nil {
+		return err
+	}
+	trigger.FuncID = funcID[0]
+	if err := rewriteIDs(trigger.DependsOnTypes, "type"); err != nil {
+		return err
+	}
+	return rewriteIDs(trigger.DependsOnRoutines, "function")
+}
+
 func rewriteFunctionsInExpr(expr string, rewrites jobspb.DescRewriteMap) (string, error) {
 	parsed, err := parser.ParseExpr(expr)
 	if err != nil {
@@ -657,7 +692,8 @@ func TypeDescs(types []*typedesc.Mutable, descriptorRewrites jobspb.DescRewriteM
 			}
 		}
 		switch t := typ.Kind; t {
-		case descpb.TypeDescriptor_ENUM, descpb.TypeDescriptor_COMPOSITE, descpb.TypeDescriptor_MULTIREGION_ENUM:
+		case descpb.TypeDescriptor_ENUM, descpb.TypeDescriptor_COMPOSITE, descpb.TypeDescriptor_MULTIREGION_ENUM,
+			descpb.TypeDescriptor_DOMAIN, descpb.TypeDescriptor_RANGE:
 			if rw, ok := descriptorRewrites[typ.ArrayTypeID]; ok {
 				typ.ArrayTypeID = rw.ID
 			}
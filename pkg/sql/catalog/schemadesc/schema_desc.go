@@ -513,7 +513,8 @@ func (desc *Mutable) ReplaceOverload(
 		sig := fn.Signatures[i]
 		match := existing.Types.Length() == len(sig.ArgTypes) &&
 			len(existing.OutParamOrdinals) == len(sig.OutParamOrdinals) &&
-			len(existing.DefaultExprs) == len(sig.DefaultExprs)
+			len(existing.DefaultExprs) == len(sig.DefaultExprs) &&
+			existing.Variadic == sig.IsVariadic
 		for j := 0; match && j < len(sig.ArgTypes); j++ {
 			match = existing.Types.GetAt(j).Equivalent(sig.ArgTypes[j])
 		}
@@ -578,9 +579,12 @@ func (desc *immutable) GetResolvedFuncDefinition(
 			Type:                     routineType,
 			UDFContainsOnlySignature: true,
 			OutParamOrdinals:         sig.OutParamOrdinals,
+			Variadic:                 sig.IsVariadic,
 		}
 		if funcDescPb.Signatures[i].ReturnSet {
 			overload.Class = tree.GeneratorClass
+		} else if sig.IsAggregate {
+			overload.Class = tree.AggregateClass
 		}
 		// There is no need to look at the parameter classes since ArgTypes
 		// already contains only parameters that are included into the
@@ -0,0 +1,70 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package schemaexpr
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// DomainValueColumnName is the name by which a domain CHECK constraint
+// expression refers to the value being checked. It is spelled VALUE in the
+// constraint definition, which parses as an unqualified column reference.
+const DomainValueColumnName = "value"
+
+// ValidateDomainCheckExpr verifies that the given expression is a valid CHECK
+// constraint for a domain with the given base type, and returns its serialized
+// form. The expression must evaluate to a boolean and may not reference any
+// columns other than VALUE, nor contain subqueries, aggregates, window
+// functions or set-returning functions.
+func ValidateDomainCheckExpr(
+	ctx context.Context, expr tree.Expr, baseType *types.T, semaCtx *tree.SemaContext,
+) (string, error) {
+	// Replace references to VALUE with a typed NULL, so that the expression can
+	// be type-checked without a table.
+	replaced, err := ReplaceDomainValue(expr, tree.NewTypedCastExpr(tree.DNull, baseType))
+	if err != nil {
+		return "", err
+	}
+	if _, err := SanitizeVarFreeExpr(
+		ctx, replaced, types.Bool, tree.CheckConstraintExpr, semaCtx, volatility.Volatile,
+		false, /* allowAssignmentCast */
+	); err != nil {
+		return "", err
+	}
+	return tree.Serialize(expr), nil
+}
+
+// ReplaceDomainValue returns a copy of the given domain CHECK constraint
+// expression in which all references to VALUE are replaced with the given
+// expression. It returns an error if the expression references any other
+// columns or contains a subquery.
+func ReplaceDomainValue(expr tree.Expr, value tree.Expr) (tree.Expr, error) {
+	return tree.SimpleVisit(expr, func(e tree.Expr) (recurse bool, newExpr tree.Expr, err error) {
+		switch t := e.(type) {
+		case *tree.UnresolvedName:
+			if t.NumParts == 1 && !t.Star && t.Parts[0] == DomainValueColumnName {
+				return false, value, nil
+			}
+			return false, nil, pgerror.New(pgcode.InvalidColumnReference,
+				"cannot use column references in domain check constraint")
+		case *tree.Subquery:
+			return false, nil, pgerror.New(pgcode.FeatureNotSupported,
+				"cannot use subquery in check constraint")
+		}
+		return true, e, nil
+	})
+}
@@ -1036,6 +1036,53 @@ CREATE TABLE system.span_stats_tenant_boundaries (
 ) ;
 `
 
+	// NotificationsTableSchema stores the notifications sent by NOTIFY. Rows
+	// are delivered to the listening sessions of every node through a rangefeed,
+	// and are deleted once they are older than sql.notifications.retention.
+	NotificationsTableSchema = `
+CREATE TABLE system.notifications (
+	id UUID NOT NULL DEFAULT gen_random_uuid(),
+	channel STRING NOT NULL,
+	payload STRING NOT NULL,
+	sender_pid INT8 NOT NULL,
+	created TIMESTAMPTZ NOT NULL DEFAULT now():::TIMESTAMPTZ,
+	CONSTRAINT "primary" PRIMARY KEY (id),
+	INDEX notifications_created_idx (created ASC),
+	FAMILY "primary" (id, channel, payload, sender_pid, created)
+);`
+
+	// PublicationsTableSchema stores the publications created by CREATE
+	// PUBLICATION. A publication belongs to a database and publishes either
+	// every table of the database or the tables listed in table_ids.
+	PublicationsTableSchema = `
+CREATE TABLE system.publications (
+	database_id INT8 NOT NULL,
+	name STRING NOT NULL,
+	owner STRING NOT NULL,
+	all_tables BOOL NOT NULL,
+	table_ids INT8[] NOT NULL,
+	created TIMESTAMPTZ NOT NULL DEFAULT now():::TIMESTAMPTZ,
+	CONSTRAINT "primary" PRIMARY KEY (database_id, name),
+	FAMILY "primary" (database_id, name, owner, all_tables, table_ids, created)
+);`
+
+	// ReplicationSlotsTableSchema stores the logical replication slots created
+	// over replication connections. confirmed_flush_lsn is the position up to
+	// which the consumer of the slot has acknowledged the changes it received,
+	// and pts_record_id is the protected timestamp record which keeps the
+	// changes after this position from being garbage collected.
+	ReplicationSlotsTableSchema = `
+CREATE TABLE system.replication_slots (
+	slot_name STRING NOT NULL,
+	plugin STRING NOT NULL,
+	database_id INT8 NOT NULL,
+	confirmed_flush_lsn INT8 NOT NULL,
+	pts_record_id UUID NOT NULL,
+	created TIMESTAMPTZ NOT NULL DEFAULT now():::TIMESTAMPTZ,
+	CONSTRAINT "primary" PRIMARY KEY (slot_name),
+	FAMILY "primary" (slot_name, plugin, database_id, confirmed_flush_lsn, pts_record_id, created)
+);`
+
 	SystemMVCCStatisticsSchema = `
 CREATE TABLE system.mvcc_statistics (
 	created_at TIMESTAMPTZ NOT NULL DEFAULT now():::TIMESTAMPTZ,
@@ -1227,7 +1274,7 @@ const SystemDatabaseName = catconstants.SystemDatabaseName
 // release version).
 //
 // NB: Don't set this to clusterversion.Latest; use a specific version instead.
-var SystemDatabaseSchemaBootstrapVersion = clusterversion.V24_2_DeleteTenantSettingsVersion.Version()
+var SystemDatabaseSchemaBootstrapVersion = clusterversion.V24_2_LogicalReplicationTables.Version()
 
 // MakeSystemDatabaseDesc constructs a copy of the system database
 // descriptor.
@@ -1418,6 +1465,9 @@ func MakeSystemTables() []SystemTable {
 		SystemMVCCStatisticsTable,
 		StatementExecInsightsTable,
 		TransactionExecInsightsTable,
+		NotificationsTable,
+		PublicationsTable,
+		ReplicationSlotsTable,
 	}
 }
 
@@ -4349,6 +4399,114 @@ var (
 		),
 	)
 
+	NotificationsTable = makeSystemTable(
+		NotificationsTableSchema,
+		systemTable(
+			catconstants.NotificationsTableName,
+			descpb.InvalidID, // dynamically assigned
+			[]descpb.ColumnDescriptor{
+				{Name: "id", ID: 1, Type: types.Uuid, DefaultExpr: &genRandomUUIDString},
+				{Name: "channel", ID: 2, Type: types.String},
+				{Name: "payload", ID: 3, Type: types.String},
+				{Name: "sender_pid", ID: 4, Type: types.Int},
+				{Name: "created", ID: 5, Type: types.TimestampTZ, DefaultExpr: &nowTZString},
+			},
+			[]descpb.ColumnFamilyDescriptor{
+				{
+					Name:        "primary",
+					ID:          0,
+					ColumnNames: []string{"id", "channel", "payload", "sender_pid", "created"},
+					ColumnIDs:   []descpb.ColumnID{1, 2, 3, 4, 5},
+				},
+			},
+			descpb.IndexDescriptor{
+				Name:                "primary",
+				ID:                  1,
+				Unique:              true,
+				KeyColumnNames:      []string{"id"},
+				KeyColumnDirections: singleASC,
+				KeyColumnIDs:        singleID1,
+			},
+			// Index for the deletion of expired notifications.
+			descpb.IndexDescriptor{
+				Name:                "notifications_created_idx",
+				ID:                  2,
+				Unique:              false,
+				KeyColumnNames:      []string{"created"},
+				KeyColumnDirections: singleASC,
+				KeyColumnIDs:        []descpb.ColumnID{5},
+				KeySuffixColumnIDs:  []descpb.ColumnID{1},
+				Version:             descpb.StrictIndexColumnIDGuaranteesVersion,
+			},
+		),
+	)
+
+	PublicationsTable = makeSystemTable(
+		PublicationsTableSchema,
+		systemTable(
+			catconstants.PublicationsTableName,
+			descpb.InvalidID, // dynamically assigned
+			[]descpb.ColumnDescriptor{
+				{Name: "database_id", ID: 1, Type: types.Int},
+				{Name: "name", ID: 2, Type: types.String},
+				{Name: "owner", ID: 3, Type: types.String},
+				{Name: "all_tables", ID: 4, Type: types.Bool},
+				{Name: "table_ids", ID: 5, Type: types.IntArray},
+				{Name: "created", ID: 6, Type: types.TimestampTZ, DefaultExpr: &nowTZString},
+			},
+			[]descpb.ColumnFamilyDescriptor{
+				{
+					Name:        "primary",
+					ID:          0,
+					ColumnNames: []string{"database_id", "name", "owner", "all_tables", "table_ids", "created"},
+					ColumnIDs:   []descpb.ColumnID{1, 2, 3, 4, 5, 6},
+				},
+			},
+			descpb.IndexDescriptor{
+				Name:           "primary",
+				ID:             1,
+				Unique:         true,
+				KeyColumnNames: []string{"database_id", "name"},
+				KeyColumnDirections: []catenumpb.IndexColumn_Direction{
+					catenumpb.IndexColumn_ASC, catenumpb.IndexColumn_ASC,
+				},
+				KeyColumnIDs: []descpb.ColumnID{1, 2},
+			},
+		),
+	)
+
+	ReplicationSlotsTable = makeSystemTable(
+		ReplicationSlotsTableSchema,
+		systemTable(
+			catconstants.ReplicationSlotsTableName,
+			descpb.InvalidID, // dynamically assigned
+			[]descpb.ColumnDescriptor{
+				{Name: "slot_name", ID: 1, Type: types.String},
+				{Name: "plugin", ID: 2, Type: types.String},
+				{Name: "database_id", ID: 3, Type: types.Int},
+				{Name: "confirmed_flush_lsn", ID: 4, Type: types.Int},
+				{Name: "pts_record_id", ID: 5, Type: types.Uuid},
+				{Name: "created", ID: 6, Type: types.TimestampTZ, DefaultExpr: &nowTZString},
+			},
+			[]descpb.ColumnFamilyDescriptor{
+				{
+					Name:        "primary",
+					ID:          0,
+					ColumnNames: []string{"slot_name", "plugin", "database_id", "confirmed_flush_lsn", "pts_record_id", "created"},
+					ColumnIDs:   []descpb.ColumnID{1, 2, 3, 4, 5, 6},
+				},
+			},
+			descpb.IndexDescriptor{
+				Name:                "primary",
+				ID:                  1,
+				Unique:              true,
+				KeyColumnNames:      []string{"slot_name"},
+				KeyColumnDirections: singleASC,
+				KeyColumnIDs:        singleID1,
+			},
+		),
+	)
+
 	SystemMVCCStatisticsTable = makeSystemTable(
 		SystemMVCCStatisticsSchema,
 		systemTable(
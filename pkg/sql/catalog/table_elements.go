@@ -586,6 +586,10 @@ type ForeignKeyConstraint interface {
 
 	// Match returns the type of algorithm used to match composite keys.
 	Match() semenumpb.Match
+
+	// Deferrability returns whether the checks of the foreign key can be
+	// deferred until the transaction commits.
+	Deferrability() semenumpb.Deferrability
 }
 
 // UniqueWithoutIndexConstraint is an interface around a unique constraint
@@ -599,6 +603,10 @@ type UniqueWithoutIndexConstraint interface {
 
 	// ParentTableID returns the ID of the table this constraint applies to.
 	ParentTableID() descpb.ID
+
+	// Deferrability returns whether the checks of the unique constraint can
+	// be deferred until the transaction commits.
+	Deferrability() semenumpb.Deferrability
 }
 
 // PrimaryKeySwap is an interface around a primary key swap mutation.
@@ -1171,6 +1179,29 @@ func MustFindConstraintWithName(tbl TableDescriptor, name string) (Constraint, e
 // silence the linter
 var _ = MustFindConstraintWithName
 
+// FindTriggerByID traverses the triggers defined on the table and returns the
+// trigger with the desired ID, or nil if none was found.
+func FindTriggerByID(tbl TableDescriptor, id descpb.TriggerID) *descpb.TriggerDescriptor {
+	triggers := tbl.GetTriggers()
+	for i := range triggers {
+		if triggers[i].ID == id {
+			return &triggers[i]
+		}
+	}
+	return nil
+}
+
+// FindTriggerByName is like FindTriggerByID but with names instead of IDs.
+func FindTriggerByName(tbl TableDescriptor, name string) *descpb.TriggerDescriptor {
+	triggers := tbl.GetTriggers()
+	for i := range triggers {
+		if triggers[i].Name == name {
+			return &triggers[i]
+		}
+	}
+	return nil
+}
+
 // FindFamilyByID traverses the family descriptors on the table descriptor
 // and returns the first column family with the desired ID, or nil if none was
 // found.
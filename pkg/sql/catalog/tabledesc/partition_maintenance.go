@@ -0,0 +1,200 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tabledesc
+
+import (
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catenumpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/duration"
+)
+
+// MaxPartitionLookahead is the maximum value of partition_lookahead.
+const MaxPartitionLookahead = 1000
+
+// ParsePartitionInterval parses the value of the partition_interval or
+// partition_retention storage parameter with the given key.
+func ParsePartitionInterval(key string, s string) (duration.Duration, error) {
+	d, err := tree.ParseDInterval(duration.IntervalStyle_POSTGRES, s)
+	if err != nil || d == nil {
+		return duration.Duration{}, pgerror.Newf(
+			pgcode.InvalidParameterValue,
+			`value of %q must be an interval`,
+			key,
+		)
+	}
+	if d.Duration.Compare(duration.MakeDuration(0, 0, 0)) <= 0 {
+		return duration.Duration{}, pgerror.Newf(
+			pgcode.InvalidParameterValue,
+			`value of %q must be greater than zero`,
+			key,
+		)
+	}
+	return d.Duration, nil
+}
+
+// ValidatePartitionInterval validates the value of partition_interval.
+// Partitions are aligned to multiples of the interval, so it must consist of
+// only months, only days, or only a time of at least one hour.
+func ValidatePartitionInterval(key string, d duration.Duration) error {
+	var numUnits int
+	for _, v := range []int64{d.Months, d.Days, d.Nanos()} {
+		if v != 0 {
+			numUnits++
+		}
+	}
+	if numUnits != 1 || (d.Nanos() != 0 && d.Nanos() < int64(time.Hour)) {
+		return pgerror.Newf(
+			pgcode.InvalidParameterValue,
+			`value of %q must be a number of months, a number of days, or a time of at least one hour`,
+			key,
+		)
+	}
+	return nil
+}
+
+// ValidatePartitionLookahead validates the value of partition_lookahead.
+func ValidatePartitionLookahead(key string, val int64) error {
+	if val < 1 || val > MaxPartitionLookahead {
+		return pgerror.Newf(
+			pgcode.InvalidParameterValue,
+			`"%s" must be between 1 and %d`,
+			key,
+			MaxPartitionLookahead,
+		)
+	}
+	return nil
+}
+
+// ValidatePartitionMaintenance validates that the automatic partitioning
+// options are valid.
+func ValidatePartitionMaintenance(pm *catpb.PartitionMaintenance) error {
+	if pm == nil {
+		return nil
+	}
+	if pm.Interval == "" {
+		return pgerror.Newf(
+			pgcode.InvalidParameterValue,
+			`"partition_interval" must be set`,
+		)
+	}
+	interval, err := ParsePartitionInterval("partition_interval", pm.Interval)
+	if err != nil {
+		return err
+	}
+	if err := ValidatePartitionInterval("partition_interval", interval); err != nil {
+		return err
+	}
+	if pm.Retention != "" {
+		if _, err := ParsePartitionInterval("partition_retention", pm.Retention); err != nil {
+			return err
+		}
+	}
+	if pm.Lookahead != 0 {
+		if err := ValidatePartitionLookahead("partition_lookahead", pm.Lookahead); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidatePartitionMaintenanceTable validates that the automatic partitioning
+// configured on the given table can be applied to it, and returns the column
+// by which the table is partitioned. The table is partitioned by RANGE on the
+// first column of its primary key, which must be an ascending TIMESTAMP,
+// TIMESTAMPTZ or DATE column. If the table has a retention, expired data is
+// deleted from every index with range deletes, so every index must be prefixed
+// by that column as well, and no foreign key may reference the table since the
+// range deletes don't apply foreign key actions.
+func ValidatePartitionMaintenanceTable(desc catalog.TableDescriptor) (catalog.Column, error) {
+	pm := desc.GetPartitionMaintenance()
+	if pm == nil {
+		return nil, nil
+	}
+	if desc.IsLocalityRegionalByRow() || desc.IsPartitionAllBy() {
+		return nil, pgerror.Newf(
+			pgcode.InvalidTableDefinition,
+			"automatic partitioning is not supported on tables with PARTITION ALL BY or REGIONAL BY ROW",
+		)
+	}
+	pk := desc.GetPrimaryIndex()
+	if pk.GetPartitioning().NumImplicitColumns() > 0 {
+		return nil, pgerror.Newf(
+			pgcode.InvalidTableDefinition,
+			"automatic partitioning is not supported on tables with implicitly partitioned primary keys",
+		)
+	}
+	col, err := catalog.MustFindColumnByID(desc, pk.GetKeyColumnID(0))
+	if err != nil {
+		return nil, err
+	}
+	switch col.GetType().Family() {
+	case types.TimestampFamily, types.TimestampTZFamily, types.DateFamily:
+	default:
+		return nil, pgerror.Newf(
+			pgcode.InvalidTableDefinition,
+			"automatic partitioning requires the first primary key column to be a "+
+				"TIMESTAMP, TIMESTAMPTZ or DATE column, but %s has type %s",
+			col.GetName(), col.GetType().SQLString(),
+		)
+	}
+	if pk.GetKeyColumnDirection(0) != catenumpb.IndexColumn_ASC {
+		return nil, pgerror.Newf(
+			pgcode.InvalidTableDefinition,
+			"automatic partitioning requires the first primary key column %s to be in ascending order",
+			col.GetName(),
+		)
+	}
+	if col.GetType().Family() == types.DateFamily {
+		interval, err := ParsePartitionInterval("partition_interval", pm.Interval)
+		if err != nil {
+			return nil, err
+		}
+		if interval.Nanos() != 0 {
+			return nil, pgerror.Newf(
+				pgcode.InvalidParameterValue,
+				`value of "partition_interval" must be a number of months or days for DATE column %s`,
+				col.GetName(),
+			)
+		}
+	}
+	if pm.Retention != "" {
+		for _, idx := range desc.PublicNonPrimaryIndexes() {
+			if idx.GetType() != descpb.IndexDescriptor_FORWARD ||
+				idx.GetPartitioning().NumImplicitColumns() > 0 ||
+				idx.GetKeyColumnID(0) != col.GetID() ||
+				idx.GetKeyColumnDirection(0) != catenumpb.IndexColumn_ASC {
+				return nil, pgerror.Newf(
+					pgcode.InvalidTableDefinition,
+					`"partition_retention" requires every index to be prefixed by column %s in `+
+						`ascending order, but index %s is not`,
+					col.GetName(), idx.GetName(),
+				)
+			}
+		}
+		if fks := desc.InboundForeignKeys(); len(fks) > 0 {
+			return nil, pgerror.Newf(
+				pgcode.InvalidTableDefinition,
+				`"partition_retention" cannot be set on a table referenced by a foreign key, `+
+					`but %s is referenced by foreign key %s`,
+				desc.GetName(), fks[0].GetName(),
+			)
+		}
+	}
+	return col, nil
+}
@@ -315,6 +315,15 @@ func ForEachExprStringInTableDesc(descI catalog.TableDescriptor, f func(expr *st
 		}
 	}
 
+	// Process trigger WHEN clauses.
+	for i := range desc.Triggers {
+		if desc.Triggers[i].WhenExpr != "" {
+			if err := f(&desc.Triggers[i].WhenExpr); err != nil {
+				return err
+			}
+		}
+	}
+
 	// Process all non-index mutations.
 	for _, mut := range desc.Mutations {
 		if c := mut.GetColumn(); c != nil {
@@ -363,6 +372,11 @@ func (desc *wrapper) GetAllReferencedTypeIDs(
 	for _, id := range desc.DependsOnTypes {
 		ids.Add(id)
 	}
+	for i := range desc.Triggers {
+		for _, id := range desc.Triggers[i].DependsOnTypes {
+			ids.Add(id)
+		}
+	}
 
 	return ids.Ordered(), referencedInColumns, nil
 }
@@ -382,6 +396,13 @@ func (desc *wrapper) GetAllReferencedFunctionIDs() (catalog.DescriptorIDSet, err
 			ret.Add(id)
 		}
 	}
+	for i := range desc.Triggers {
+		trigger := &desc.Triggers[i]
+		ret.Add(trigger.FuncID)
+		for _, id := range trigger.DependsOnRoutines {
+			ret.Add(id)
+		}
+	}
 	// TODO(chengxiong): add logic to extract references from indexes when UDFs
 	// are allowed in them.
 	return ret.Union(catalog.MakeDescriptorIDSet(desc.DependsOnFunctions...)), nil
@@ -1250,6 +1271,20 @@ func (desc *Mutable) FindActiveOrNewColumnByName(name tree.Name) (catalog.Column
 	return nil, colinfo.NewUndefinedColumnError(string(name))
 }
 
+// DropExclusionConstraint removes the exclusion constraint with the given name
+// from the table descriptor, and returns whether there was such a constraint.
+// Exclusion constraints only restrict writes, so they can be dropped without a
+// schema change. The index backing the constraint, if any, is not dropped.
+func (desc *Mutable) DropExclusionConstraint(name string) bool {
+	for i := range desc.ExclusionConstraints {
+		if desc.ExclusionConstraints[i].Name == name {
+			desc.ExclusionConstraints = append(desc.ExclusionConstraints[:i], desc.ExclusionConstraints[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
 // DropConstraint drops a constraint, either by removing it from the table
 // descriptor or by queuing a mutation for a schema change.
 func (desc *Mutable) DropConstraint(
@@ -2381,6 +2416,26 @@ func (desc *wrapper) HasRowLevelTTL() bool {
 	return desc.RowLevelTTL != nil
 }
 
+// GetPartitionMaintenance implements the TableDescriptor interface.
+func (desc *wrapper) GetPartitionMaintenance() *catpb.PartitionMaintenance {
+	return desc.PartitionMaintenance
+}
+
+// HasPartitionMaintenance implements the TableDescriptor interface.
+func (desc *wrapper) HasPartitionMaintenance() bool {
+	return desc.PartitionMaintenance != nil
+}
+
+// GetForeignTable implements the TableDescriptor interface.
+func (desc *wrapper) GetForeignTable() *descpb.ForeignTableDescriptor {
+	return desc.ForeignTable
+}
+
+// GetExclusionConstraints implements the TableDescriptor interface.
+func (desc *wrapper) GetExclusionConstraints() []descpb.ExclusionConstraint {
+	return desc.ExclusionConstraints
+}
+
 // GetExcludeDataFromBackup implements the TableDescriptor interface.
 func (desc *wrapper) GetExcludeDataFromBackup() bool {
 	return desc.ExcludeDataFromBackup
@@ -2434,6 +2489,15 @@ func (desc *wrapper) GetStorageParams(spaceBetweenEqual bool) []string {
 			appendStorageParam(`ttl_disable_changefeed_replication`, fmt.Sprintf("%t", ttl.DisableChangefeedReplication))
 		}
 	}
+	if pm := desc.GetPartitionMaintenance(); pm != nil {
+		appendStorageParam(`partition_interval`, lexbase.EscapeSQLString(pm.Interval))
+		if pm.Retention != "" {
+			appendStorageParam(`partition_retention`, lexbase.EscapeSQLString(pm.Retention))
+		}
+		if pm.Lookahead != 0 {
+			appendStorageParam(`partition_lookahead`, fmt.Sprintf(`%d`, pm.Lookahead))
+		}
+	}
 	if exclude := desc.GetExcludeDataFromBackup(); exclude {
 		appendStorageParam(`exclude_data_from_backup`, `true`)
 	}
@@ -855,6 +855,9 @@ func maybeAddConstraintIDs(desc *descpb.TableDescriptor) (hasChanged bool) {
 		idPtrs = append(idPtrs, &uwoi.ConstraintID)
 		uwoiByName[uwoi.Name] = uwoi
 	}
+	for i := range desc.ExclusionConstraints {
+		idPtrs = append(idPtrs, &desc.ExclusionConstraints[i].ConstraintID)
+	}
 	for _, m := range desc.GetMutations() {
 		if idx := m.GetIndex(); idx != nil && idx.Unique && !idx.UseDeletePreservingEncoding {
 			idPtrs = append(idPtrs, &idx.ConstraintID)
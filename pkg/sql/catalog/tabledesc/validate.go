@@ -118,6 +118,13 @@ func (desc *wrapper) GetReferencedDescIDs() (catalog.DescriptorIDSet, error) {
 	for _, ref := range desc.GetDependedOnBy() {
 		ids.Add(ref.ID)
 	}
+	// Add trigger dependencies.
+	for i := range desc.Triggers {
+		trigger := &desc.Triggers[i]
+		for _, id := range trigger.DependsOnTypes {
+			ids.Add(id)
+		}
+	}
 	// Add sequence dependencies
 	return ids, nil
 }
@@ -199,6 +206,18 @@ func (desc *wrapper) ValidateForwardReferences(
 		}
 	}
 
+	// Check all functions referenced by triggers exist.
+	for i := range desc.Triggers {
+		trigger := &desc.Triggers[i]
+		vea.Report(desc.validateOutboundFuncRef(trigger.FuncID, vdg))
+		for _, id := range trigger.DependsOnRoutines {
+			vea.Report(desc.validateOutboundFuncRef(id, vdg))
+		}
+		for _, id := range trigger.DependsOnTypes {
+			vea.Report(desc.validateOutboundTypeRef(id, vdg))
+		}
+	}
+
 	// Check enforced outbound foreign keys.
 	for _, fk := range desc.EnforcedOutboundForeignKeys() {
 		vea.Report(desc.validateOutboundFK(fk.ForeignKeyDesc(), vdg))
@@ -263,6 +282,21 @@ func (desc *wrapper) ValidateBackReferences(
 		}
 	}
 
+	// Check back-references in functions referenced by triggers.
+	for i := range desc.Triggers {
+		trigger := &desc.Triggers[i]
+		fnIDs := catalog.MakeDescriptorIDSet(trigger.DependsOnRoutines...)
+		fnIDs.Add(trigger.FuncID)
+		for _, fnID := range fnIDs.Ordered() {
+			fn, err := vdg.GetFunctionDescriptor(fnID)
+			if err != nil {
+				vea.Report(err)
+				continue
+			}
+			vea.Report(desc.validateOutboundFuncRefBackReferenceForTrigger(fn, trigger.ID))
+		}
+	}
+
 	// For views, check dependent relations.
 	if desc.IsView() {
 		for _, id := range desc.DependsOnTypes {
@@ -376,6 +410,23 @@ func (desc *wrapper) validateOutboundFuncRefBackReferenceForColumn(
 		ref.GetName(), ref.GetID())
 }
 
+func (desc *wrapper) validateOutboundFuncRefBackReferenceForTrigger(
+	ref catalog.FunctionDescriptor, triggerID descpb.TriggerID,
+) error {
+	for _, dep := range ref.GetDependedOnBy() {
+		if dep.ID != desc.GetID() {
+			continue
+		}
+		for _, id := range dep.TriggerIDs {
+			if id == triggerID {
+				return nil
+			}
+		}
+	}
+	return errors.AssertionFailedf("depends-on function %q (%d) has no corresponding depended-on-by back reference",
+		ref.GetName(), ref.GetID())
+}
+
 func (desc *wrapper) validateInboundFunctionRef(
 	by descpb.TableDescriptor_Reference, vdg catalog.ValidationDescGetter,
 ) error {
@@ -865,10 +916,12 @@ func (desc *wrapper) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 	// actually a table, not if it's just a view.
 	if desc.IsPhysicalTable() {
 		desc.validateConstraintNamesAndIDs(vea)
+		desc.validateTriggers(vea)
 		newErrs := []error{
 			desc.validateColumnFamilies(columnsByID),
 			desc.validateCheckConstraints(columnsByID),
 			desc.validateUniqueWithoutIndexConstraints(columnsByID),
+			desc.validateExclusionConstraints(columnsByID),
 			desc.validateTableIndexes(columnsByID, vea.IsActive),
 			desc.validatePartitioning(),
 		}
@@ -978,6 +1031,7 @@ func (desc *wrapper) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 	// initialized to validate the storage parameters.
 	vea.Report(ValidateTTLExpirationExpr(desc))
 	vea.Report(ValidateTTLExpirationColumn(desc))
+	vea.Report(ValidatePartitionMaintenance(desc.GetPartitionMaintenance()))
 
 	// Validate that there are no column with both a foreign key ON UPDATE and an
 	// ON UPDATE expression. This check is made to ensure that we know which ON
@@ -1101,6 +1155,44 @@ func (desc *wrapper) validateConstraintNamesAndIDs(vea catalog.ValidationErrorAc
 
 }
 
+func (desc *wrapper) validateTriggers(vea catalog.ValidationErrorAccumulator) {
+	names := make(map[string]descpb.TriggerID, len(desc.Triggers))
+	idToName := make(map[descpb.TriggerID]string, len(desc.Triggers))
+	for i := range desc.Triggers {
+		trigger := &desc.Triggers[i]
+		if trigger.ID == 0 {
+			vea.Report(errors.AssertionFailedf(
+				"trigger ID was missing for trigger %q", trigger.Name))
+		} else if trigger.ID >= desc.NextTriggerID {
+			vea.Report(errors.AssertionFailedf(
+				"trigger %q has ID %d not less than NextTriggerID value %d for table",
+				trigger.Name, trigger.ID, desc.NextTriggerID))
+		}
+		if trigger.Name == "" {
+			vea.Report(pgerror.Newf(pgcode.Syntax, "empty trigger name"))
+		}
+		if otherID, found := names[trigger.Name]; found && trigger.ID != otherID {
+			vea.Report(pgerror.Newf(pgcode.DuplicateObject,
+				"duplicate trigger name: %q", trigger.Name))
+		}
+		names[trigger.Name] = trigger.ID
+		if other, found := idToName[trigger.ID]; found {
+			vea.Report(pgerror.Newf(pgcode.DuplicateObject,
+				"trigger ID %d in trigger %q already in use by %q",
+				trigger.ID, trigger.Name, other))
+		}
+		idToName[trigger.ID] = trigger.Name
+		if trigger.FuncID == descpb.InvalidID {
+			vea.Report(errors.AssertionFailedf(
+				"invalid function ID %d in trigger %q", trigger.FuncID, trigger.Name))
+		}
+		if len(trigger.Events) == 0 {
+			vea.Report(errors.AssertionFailedf(
+				"trigger %q has no events", trigger.Name))
+		}
+	}
+}
+
 func (desc *wrapper) validateColumns() error {
 	columnIDs := make(map[descpb.ColumnID]*descpb.ColumnDescriptor, len(desc.Columns))
 	columnNames := make(map[string]descpb.ColumnID, len(desc.Columns))
@@ -1387,6 +1479,87 @@ func (desc *wrapper) validateUniqueWithoutIndexConstraints(
 	return nil
 }
 
+// validateExclusionConstraints validates that exclusion constraints are well
+// formed. Checks include validating the column IDs and operators, and that
+// the constraint names and IDs are not used by other constraints.
+func (desc *wrapper) validateExclusionConstraints(
+	columnsByID map[descpb.ColumnID]catalog.Column,
+) error {
+	names := make(map[string]struct{})
+	ids := make(map[descpb.ConstraintID]struct{})
+	for _, c := range desc.AllConstraints() {
+		if !c.Dropped() {
+			names[c.GetName()] = struct{}{}
+		}
+		ids[c.GetConstraintID()] = struct{}{}
+	}
+	for i := range desc.ExclusionConstraints {
+		c := &desc.ExclusionConstraints[i]
+		if len(c.Name) == 0 {
+			return pgerror.Newf(pgcode.Syntax, "empty exclusion constraint name")
+		}
+		if _, ok := names[c.Name]; ok {
+			return pgerror.Newf(pgcode.DuplicateObject, "duplicate constraint name: %q", c.Name)
+		}
+		names[c.Name] = struct{}{}
+		if c.ConstraintID == 0 {
+			return errors.AssertionFailedf("constraint ID was missing for constraint %q", c.Name)
+		} else if c.ConstraintID >= desc.NextConstraintID {
+			return errors.AssertionFailedf(
+				"constraint %q has ID %d not less than NextConstraintID value %d for table",
+				c.Name, c.ConstraintID, desc.NextConstraintID)
+		}
+		if _, ok := ids[c.ConstraintID]; ok {
+			return pgerror.Newf(pgcode.DuplicateObject,
+				"constraint ID %d in constraint %q already in use", c.ConstraintID, c.Name)
+		}
+		ids[c.ConstraintID] = struct{}{}
+
+		if len(c.ColumnIDs) == 0 || len(c.ColumnIDs) != len(c.Operators) {
+			return errors.Newf(
+				"exclusion constraint %q has %d columns and %d operators",
+				c.Name, len(c.ColumnIDs), len(c.Operators),
+			)
+		}
+		for j, colID := range c.ColumnIDs {
+			col, ok := columnsByID[colID]
+			if !ok {
+				return errors.Newf(
+					"exclusion constraint %q contains unknown column \"%d\"", c.Name, colID,
+				)
+			}
+			switch c.Operators[j] {
+			case "=":
+			case "&&", "-|-":
+				if col.GetType().Family() != types.RangeFamily {
+					return errors.Newf(
+						"exclusion constraint %q uses operator %s on column %q of type %s",
+						c.Name, c.Operators[j], col.GetName(), col.GetType().SQLString(),
+					)
+				}
+			default:
+				return errors.Newf(
+					"exclusion constraint %q contains unknown operator %q", c.Name, c.Operators[j],
+				)
+			}
+		}
+		if c.IndexID != 0 {
+			idx := catalog.FindIndexByID(desc, c.IndexID)
+			if idx == nil || idx.Dropped() {
+				return errors.Newf(
+					"exclusion constraint %q is backed by unknown index \"%d\"", c.Name, c.IndexID,
+				)
+			}
+			if idx.Primary() {
+				return errors.Newf(
+					"exclusion constraint %q cannot be backed by the primary index", c.Name,
+				)
+			}
+		}
+	}
+	return nil
+}
+
 // validateTableIndexes validates that indexes are well formed. Checks include
 // validating the columns involved in the index, verifying the index names and
 // IDs are unique, and the family of the primary key is 0. This does not check
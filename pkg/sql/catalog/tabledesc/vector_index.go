@@ -0,0 +1,127 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tabledesc
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/vector"
+	"github.com/cockroachdb/errors"
+)
+
+// RewriteVectorIndex rewrites a CREATE INDEX ... USING lsh statement into the
+// equivalent expression index, which is keyed on the list of each vector and
+// stores the vector. Vectors are assigned to lists by locality-sensitive
+// hashing of their direction (see vector.LSHList), so the index can only be
+// used to find the nearest neighbors by cosine distance:
+//
+//	CREATE INDEX ... ((crdb_internal.vector_lsh_list(v, lists))) STORING (v)
+//
+// The optimizer recognizes indexes of this form and uses them to find the
+// approximate nearest neighbors of a vector. colType returns the type of the
+// column with the given name.
+func RewriteVectorIndex(
+	ctx context.Context,
+	semaCtx *tree.SemaContext,
+	evalCtx *eval.Context,
+	n *tree.CreateIndex,
+	colType func(tree.Name) (*types.T, error),
+) error {
+	if !n.Vector {
+		return nil
+	}
+	switch {
+	case n.Unique:
+		return pgerror.New(pgcode.InvalidSQLStatementName, "vector indexes can't be unique")
+	case n.Sharded != nil:
+		return pgerror.New(pgcode.InvalidSQLStatementName, "vector indexes don't support hash sharding")
+	case len(n.Columns) != 1:
+		return pgerror.New(pgcode.FeatureNotSupported, "vector indexes must have exactly one column")
+	}
+	elem := n.Columns[0]
+	if elem.Expr != nil {
+		return pgerror.New(pgcode.FeatureNotSupported, "vector indexes do not support expressions")
+	}
+	if elem.Direction != tree.DefaultDirection || elem.NullsOrder != tree.DefaultNullsOrder {
+		return pgerror.New(pgcode.FeatureNotSupported, "vector indexes do not support ordering options")
+	}
+	switch elem.OpClass {
+	case "", "vector_cosine_ops":
+	case "vector_l2_ops", "vector_ip_ops":
+		// The list of a vector only depends on its direction, so the nearest
+		// neighbors of a vector by Euclidean distance or inner product, which
+		// also depend on the magnitude of the vectors, can be in any list.
+		return errors.WithHint(
+			pgerror.Newf(pgcode.FeatureNotSupported,
+				"operator class %q is not supported by lsh indexes", elem.OpClass),
+			"lsh indexes only support vector_cosine_ops.",
+		)
+	default:
+		return pgerror.Newf(pgcode.UndefinedObject, "operator class %q does not exist", elem.OpClass)
+	}
+	typ, err := colType(elem.Column)
+	if err != nil {
+		return err
+	}
+	if typ.Family() != types.PGVectorFamily {
+		return pgerror.Newf(pgcode.DatatypeMismatch,
+			"column %s of type %s cannot be indexed with a vector index", elem.Column, typ.SQLString())
+	}
+
+	lists := int64(vector.DefaultLSHLists)
+	var params tree.StorageParams
+	for _, param := range n.StorageParams {
+		if param.Key != `lists` {
+			params = append(params, param)
+			continue
+		}
+		typedExpr, err := schemaexpr.SanitizeVarFreeExpr(
+			ctx, param.Value, types.Int, "lists", semaCtx, volatility.Volatile, false, /*allowAssignmentCast*/
+		)
+		if err != nil {
+			return err
+		}
+		d, err := eval.Expr(ctx, evalCtx, typedExpr)
+		if err != nil {
+			return err
+		}
+		lists = int64(tree.MustBeDInt(d))
+	}
+	if err := vector.ValidateLSHLists(lists); err != nil {
+		return err
+	}
+
+	n.Columns = tree.IndexElemList{{
+		Expr: &tree.FuncExpr{
+			Func: tree.WrapFunction(builtinconstants.VectorLSHListBuiltinName),
+			Exprs: tree.Exprs{
+				&tree.ColumnItem{ColumnName: elem.Column},
+				tree.NewDInt(tree.DInt(lists)),
+			},
+		},
+	}}
+	storing := append(tree.NameList(nil), n.Storing...)
+	if !storing.Contains(elem.Column) {
+		storing = append(storing, elem.Column)
+	}
+	n.Storing = storing
+	n.StorageParams = params
+	n.Vector = false
+	return nil
+}
@@ -136,6 +136,19 @@ func ensureTypeMetadataIsHydrated(
 		tm.ImplicitRecordType = true
 		return
 	}
+	if d := maybeDesc.AsDomainTypeDescriptor(); d != nil {
+		tm.DomainData = &types.DomainMetadata{
+			NotNull: d.NotNull(),
+			Checks:  make([]types.DomainCheck, d.NumChecks()),
+		}
+		for i := range tm.DomainData.Checks {
+			tm.DomainData.Checks[i] = types.DomainCheck{
+				Name: d.GetCheckName(i),
+				Expr: d.GetCheckExpr(i),
+			}
+		}
+		return
+	}
 	if e := maybeDesc.AsEnumTypeDescriptor(); e != nil {
 		if imm, ok := e.(*immutable); ok {
 			// Fast-path for immutable enum descriptors. We can use a pointer into the
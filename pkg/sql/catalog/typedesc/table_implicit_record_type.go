@@ -329,6 +329,16 @@ func (v *tableImplicitRecordType) AsAliasTypeDescriptor() catalog.AliasTypeDescr
 	return nil
 }
 
+// AsDomainTypeDescriptor implements the catalog.TypeDescriptor interface.
+func (v *tableImplicitRecordType) AsDomainTypeDescriptor() catalog.DomainTypeDescriptor {
+	return nil
+}
+
+// AsRangeTypeDescriptor implements the catalog.TypeDescriptor interface.
+func (v *tableImplicitRecordType) AsRangeTypeDescriptor() catalog.RangeTypeDescriptor {
+	return nil
+}
+
 // AsCompositeTypeDescriptor implements the catalog.TypeDescriptor interface.
 func (v *tableImplicitRecordType) AsCompositeTypeDescriptor() catalog.CompositeTypeDescriptor {
 	return nil
@@ -43,6 +43,8 @@ var _ catalog.EnumTypeDescriptor = (*immutable)(nil)
 var _ catalog.RegionEnumTypeDescriptor = (*immutable)(nil)
 var _ catalog.AliasTypeDescriptor = (*immutable)(nil)
 var _ catalog.CompositeTypeDescriptor = (*immutable)(nil)
+var _ catalog.DomainTypeDescriptor = (*immutable)(nil)
+var _ catalog.RangeTypeDescriptor = (*immutable)(nil)
 var _ catalog.TypeDescriptor = (*Mutable)(nil)
 var _ catalog.MutableDescriptor = (*Mutable)(nil)
 
@@ -498,6 +500,34 @@ func (desc *immutable) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 		if desc.Composite == nil {
 			vea.Report(errors.AssertionFailedf("COMPOSITE type desc has nil composite type"))
 		}
+	case descpb.TypeDescriptor_DOMAIN:
+		if desc.Domain == nil || desc.Domain.BaseType == nil {
+			vea.Report(errors.AssertionFailedf("DOMAIN type desc has nil base type"))
+		} else if desc.Domain.BaseType.UserDefined() {
+			vea.Report(errors.AssertionFailedf(
+				"DOMAIN type desc has user-defined base type %d", desc.Domain.BaseType.Oid(),
+			))
+		}
+		if desc.Domain != nil {
+			names := make(map[string]struct{}, len(desc.Domain.Checks))
+			for _, c := range desc.Domain.Checks {
+				if _, ok := names[c.Name]; ok {
+					vea.Report(errors.AssertionFailedf("duplicate domain constraint name %q", c.Name))
+				}
+				names[c.Name] = struct{}{}
+			}
+		}
+	case descpb.TypeDescriptor_RANGE:
+		if desc.RegionConfig != nil {
+			vea.Report(errors.AssertionFailedf("found region config on %s type desc", desc.Kind.String()))
+		}
+		if desc.Range == nil || desc.Range.Subtype == nil {
+			vea.Report(errors.AssertionFailedf("RANGE type desc has nil subtype"))
+		} else if desc.Range.Subtype.UserDefined() {
+			vea.Report(errors.AssertionFailedf(
+				"RANGE type desc has user-defined subtype %d", desc.Range.Subtype.Oid(),
+			))
+		}
 	case descpb.TypeDescriptor_TABLE_IMPLICIT_RECORD_TYPE:
 		vea.Report(errors.AssertionFailedf("invalid type descriptor: kind %s should never be serialized or validated", desc.Kind.String()))
 	default:
@@ -765,6 +795,18 @@ func (desc *immutable) AsTypesT() *types.T {
 			contents,
 			labels,
 		)
+	case descpb.TypeDescriptor_DOMAIN:
+		return types.MakeDomain(
+			catid.TypeIDToOID(desc.GetID()),
+			catid.TypeIDToOID(desc.ArrayTypeID),
+			desc.Domain.BaseType,
+		)
+	case descpb.TypeDescriptor_RANGE:
+		return types.MakeRange(
+			catid.TypeIDToOID(desc.GetID()),
+			catid.TypeIDToOID(desc.ArrayTypeID),
+			desc.Range.Subtype,
+		)
 	}
 	panic(errors.AssertionFailedf("unsupported descriptor kind %s", desc.Kind.String()))
 }
@@ -995,6 +1037,22 @@ func (desc *immutable) AsAliasTypeDescriptor() catalog.AliasTypeDescriptor {
 	return nil
 }
 
+// AsDomainTypeDescriptor implements the catalog.TypeDescriptor interface.
+func (desc *immutable) AsDomainTypeDescriptor() catalog.DomainTypeDescriptor {
+	if desc.Kind == descpb.TypeDescriptor_DOMAIN {
+		return desc
+	}
+	return nil
+}
+
+// AsRangeTypeDescriptor implements the catalog.TypeDescriptor interface.
+func (desc *immutable) AsRangeTypeDescriptor() catalog.RangeTypeDescriptor {
+	if desc.Kind == descpb.TypeDescriptor_RANGE {
+		return desc
+	}
+	return nil
+}
+
 // AsCompositeTypeDescriptor implements the catalog.TypeDescriptor interface.
 func (desc *immutable) AsCompositeTypeDescriptor() catalog.CompositeTypeDescriptor {
 	if desc.Kind == descpb.TypeDescriptor_COMPOSITE {
@@ -1029,6 +1087,36 @@ func (desc *immutable) GetElementType(ordinal int) *types.T {
 	return desc.Composite.Elements[ordinal].ElementType
 }
 
+// BaseType implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) BaseType() *types.T {
+	return desc.Domain.BaseType
+}
+
+// NotNull implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) NotNull() bool {
+	return desc.Domain.NotNull
+}
+
+// NumChecks implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) NumChecks() int {
+	return len(desc.Domain.Checks)
+}
+
+// GetCheckName implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) GetCheckName(ordinal int) string {
+	return desc.Domain.Checks[ordinal].Name
+}
+
+// GetCheckExpr implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) GetCheckExpr(ordinal int) string {
+	return desc.Domain.Checks[ordinal].Expr
+}
+
+// RangeSubtype implements the catalog.RangeTypeDescriptor interface.
+func (desc *immutable) RangeSubtype() *types.T {
+	return desc.Range.Subtype
+}
+
 // ForEachRegionInSuperRegion implements the catalog.RegionEnumTypeDescriptor
 // interface.
 func (desc *immutable) ForEachRegionInSuperRegion(
@@ -15,6 +15,7 @@ import (
 	"sort"
 
 	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/config"
 	"github.com/cockroachdb/cockroach/pkg/config/zonepb"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
@@ -41,6 +42,16 @@ type ZoneConfigOption struct {
 	CheckAllowed func(context.Context, *cluster.Settings, tree.Datum) error
 }
 
+// CheckNumWitnessesAllowed returns an error if the num_witnesses field can't be
+// set yet, since nodes running older versions don't support witness replicas.
+func CheckNumWitnessesAllowed(ctx context.Context, settings *cluster.Settings) error {
+	if !settings.Version.IsActive(ctx, clusterversion.V24_2_WitnessReplicas) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"num_witnesses not supported until version 24.2")
+	}
+	return nil
+}
+
 func loadYAML(dst interface{}, yamlString string) {
 	if err := yaml.UnmarshalStrict([]byte(yamlString), dst); err != nil {
 		panic(err)
@@ -94,6 +105,14 @@ func init() {
 			RequiredType: types.Int,
 			Setter:       func(c *zonepb.ZoneConfig, d tree.Datum) { c.NumVoters = proto.Int32(int32(tree.MustBeDInt(d))) },
 		},
+		{
+			Field:        config.NumWitnesses,
+			RequiredType: types.Int,
+			Setter:       func(c *zonepb.ZoneConfig, d tree.Datum) { c.NumWitnesses = proto.Int32(int32(tree.MustBeDInt(d))) },
+			CheckAllowed: func(ctx context.Context, settings *cluster.Settings, d tree.Datum) error {
+				return CheckNumWitnessesAllowed(ctx, settings)
+			},
+		},
 		{
 			Field:        config.GCTTL,
 			RequiredType: types.Int,
@@ -172,6 +172,9 @@ func supportedNatively(core *execinfrapb.ProcessorCoreUnion) error {
 			if agg.FilterColIdx != nil {
 				return errFilteringAggregation
 			}
+			if agg.Func == execinfrapb.UserDefined && hasUserDefinedAggregateRoutines(&agg) {
+				return errUserDefinedAggregateRoutine
+			}
 		}
 		return nil
 
@@ -226,6 +229,18 @@ func supportedNatively(core *execinfrapb.ProcessorCoreUnion) error {
 	}
 }
 
+// hasUserDefinedAggregateRoutines returns whether any of the support functions
+// of the given user-defined aggregate is a routine. Routines are evaluated by
+// the planner, so they are only supported by the row-based aggregator.
+func hasUserDefinedAggregateRoutines(agg *execinfrapb.AggregatorSpec_Aggregation) bool {
+	for _, fn := range []*execinfrapb.Expression{&agg.StateFunc, &agg.FinalFunc, &agg.CombineFunc} {
+		if _, ok := fn.LocalExpr.(*tree.RoutineExpr); ok {
+			return true
+		}
+	}
+	return false
+}
+
 var (
 	errCoreUnsupportedNatively        = errors.New("unsupported processor core")
 	errLocalPlanNodeWrap              = errors.New("LocalPlanNode core needs to be wrapped")
@@ -241,6 +256,7 @@ var (
 	errWrappedCast                    = errors.New("mismatched types in NewColOperator and unsupported casts")
 	errLookupJoinUnsupported          = errors.New("lookup join reader is unsupported in vectorized")
 	errFilteringAggregation           = errors.New("filtering aggregation not supported")
+	errUserDefinedAggregateRoutine    = errors.New("user-defined aggregates with routines not supported")
 	errNonInnerHashJoinWithOnExpr     = errors.New("can't plan vectorized non-inner hash joins with ON expressions")
 	errNonInnerMergeJoinWithOnExpr    = errors.New("can't plan vectorized non-inner merge joins with ON expressions")
 	errWindowFunctionFilterClause     = errors.New("window functions with FILTER clause are not supported")
@@ -262,6 +278,7 @@ func canWrap(mode sessiondatapb.VectorizeExecMode, core *execinfrapb.ProcessorCo
 	case core.MergeJoiner != nil:
 	case core.HashJoiner != nil:
 	case core.Values != nil:
+	case core.ForeignScan != nil:
 	case core.Backfiller != nil:
 		return errBackfillerWrap
 	case core.ReadImport != nil:
@@ -86,6 +86,14 @@ func GetCastOperator(
 	evalCtx *eval.Context,
 ) (colexecop.Operator, error) {
 	input = colexecutils.NewVectorTypeEnforcer(allocator, input, toType, resultIdx)
+	// Casts to and from domain types are performed like casts to and from their
+	// base types.
+	if base := fromType.DomainBaseType(); base != nil {
+		fromType = base
+	}
+	if base := toType.DomainBaseType(); base != nil {
+		toType = base
+	}
 	base := castOpBase{
 		OneInputInitCloserHelper: colexecop.MakeOneInputInitCloserHelper(input),
 		allocator:                allocator,
@@ -640,6 +648,12 @@ func GetCastOperator(
 }
 
 func IsCastSupported(fromType, toType *types.T) bool {
+	if base := fromType.DomainBaseType(); base != nil {
+		fromType = base
+	}
+	if base := toType.DomainBaseType(); base != nil {
+		toType = base
+	}
 	if fromType.Family() == types.UnknownFamily {
 		return true
 	}
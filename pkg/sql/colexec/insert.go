@@ -18,6 +18,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/col/coldata"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverbase"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/colenc"
 	"github.com/cockroachdb/cockroach/pkg/sql/colexecerror"
@@ -136,6 +137,9 @@ func (v *vectorInserter) Next() coldata.Batch {
 			colexecerror.ExpectedError(err)
 		}
 	}
+	if err := v.checkArrayValues(ctx, b); err != nil {
+		colexecerror.ExpectedError(err)
+	}
 	partialIndexColMap := v.getPartialIndexMap(b)
 
 	kvba := row.KVBatchAdapter{}
@@ -224,3 +228,28 @@ func (v *vectorInserter) checkMutationInput(ctx context.Context, b coldata.Batch
 	}
 	return nil
 }
+
+// checkArrayValues returns an error if the batch contains an array that
+// cannot be stored until the cluster is upgraded. See
+// colinfo.ValidateArrayValue.
+func (v *vectorInserter) checkArrayValues(ctx context.Context, b coldata.Batch) error {
+	for i, col := range v.insertCols {
+		if col.GetType().Family() != types.ArrayFamily {
+			continue
+		}
+		vec := b.ColVec(i)
+		datums := vec.Datum()
+		nulls := vec.Nulls()
+		for r := 0; r < b.Length(); r++ {
+			if nulls.NullAt(r) {
+				continue
+			}
+			if err := colinfo.ValidateArrayValue(
+				ctx, v.flowCtx.Cfg.Settings, datums.Get(r).(tree.Datum),
+			); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
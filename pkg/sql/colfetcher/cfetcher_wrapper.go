@@ -72,6 +72,21 @@ type cFetcherWrapper struct {
 	// serialize indicates whether the batches must be serialized.
 	serialize bool
 
+	// pushdown, if set, evaluates the filter and the aggregations pushed down
+	// into the IndexFetchSpec over the batches produced by the cFetcher.
+	pushdown *pushdownEvaluator
+	// pushdownDone is set once the single batch with the partial aggregates
+	// has been emitted.
+	pushdownDone bool
+	// resultAllocator is used to allocate the batches produced by the pushdown
+	// (either the partial aggregates or the compacted batches that only
+	// contain rows that passed the filter). Unlike the allocator of the
+	// cFetcher, it performs the accounting against acc directly.
+	resultAllocator *colmem.Allocator
+	// compactedBatch is reused across NextBatch calls when serializing
+	// batches with a selection vector set by the pushed down filter.
+	compactedBatch coldata.Batch
+
 	// Fields below are only used when serializing the batches.
 	// TODO(yuzefovich): consider extracting a serializer component that would
 	// be also reused by the colrpc.Outbox.
@@ -101,44 +116,29 @@ func (c *cFetcherWrapper) NextBatch(ctx context.Context) (_ []byte, _ coldata.Ba
 			retErr = storage.IncludeStartKeyIntoErr(c.startKey, retErr)
 		}
 	}()
-	prevBatchMemUsage := c.detachedFetcherAcc.Used()
-	// cFetcher propagates some errors as "internal" panics, so we have to wrap
-	// a call to cFetcher.NextBatch with a panic-catcher.
-	c.adapter.ctx = ctx
-	if err := colexecerror.CatchVectorizedRuntimeError(c.nextBatchAdapter); err != nil {
-		return nil, nil, err
+	var batch coldata.Batch
+	var err error
+	if c.pushdown == nil {
+		batch, err = c.fetch(ctx, &includeStartKeyIntoErr)
+	} else {
+		batch, err = c.nextPushdownBatch(ctx, &includeStartKeyIntoErr)
 	}
-	if c.adapter.err != nil {
-		// If an error is propagated in a "regular" fashion, as a return
-		// parameter, then we don't include the start key - the pebble MVCC
-		// scanner has already done so if needed.
-		includeStartKeyIntoErr = false
-		return nil, nil, c.adapter.err
+	if err != nil {
+		return nil, nil, err
 	}
-	if c.adapter.batch.Length() == 0 {
+	if batch == nil {
 		return nil, nil, nil
 	}
 	if !c.serialize {
-		// Perform the accounting for this batch. Note that when we're not
-		// serializing the response, the cFetcher always allocates a new batch,
-		// so we always grow the account by the footprint of the batch.
-		if err := c.acc.Grow(ctx, c.detachedFetcherAcc.Used()); err != nil {
-			return nil, nil, err
-		}
-		return nil, c.adapter.batch, nil
-	}
-	// Update the memory account based on possibly changed footprint of the
-	// batch (which the cFetcher reuses).
-	if err := c.acc.Resize(ctx, prevBatchMemUsage, c.detachedFetcherAcc.Used()); err != nil {
-		return nil, nil, err
+		return nil, batch, nil
 	}
-	data, err := c.converter.BatchToArrow(ctx, c.adapter.batch)
+	data, err := c.converter.BatchToArrow(ctx, batch)
 	if err != nil {
 		return nil, nil, err
 	}
 	oldBufCap := c.buf.Cap()
 	c.buf.Reset()
-	_, _, err = c.serializer.Serialize(&c.buf, data, c.adapter.batch.Length())
+	_, _, err = c.serializer.Serialize(&c.buf, data, batch.Length())
 	if err != nil {
 		return nil, nil, err
 	}
@@ -160,6 +160,108 @@ func (c *cFetcherWrapper) NextBatch(ctx context.Context) (_ []byte, _ coldata.Ba
 	return b, nil, nil
 }
 
+// fetch returns the next batch produced by the cFetcher (nil if the fetcher
+// has been exhausted) and performs the memory accounting for it.
+func (c *cFetcherWrapper) fetch(
+	ctx context.Context, includeStartKeyIntoErr *bool,
+) (coldata.Batch, error) {
+	prevBatchMemUsage := c.detachedFetcherAcc.Used()
+	// cFetcher propagates some errors as "internal" panics, so we have to wrap
+	// a call to cFetcher.NextBatch with a panic-catcher.
+	c.adapter.ctx = ctx
+	if err := colexecerror.CatchVectorizedRuntimeError(c.nextBatchAdapter); err != nil {
+		return nil, err
+	}
+	if c.adapter.err != nil {
+		// If an error is propagated in a "regular" fashion, as a return
+		// parameter, then we don't include the start key - the pebble MVCC
+		// scanner has already done so if needed.
+		*includeStartKeyIntoErr = false
+		return nil, c.adapter.err
+	}
+	if c.adapter.batch.Length() == 0 {
+		return nil, nil
+	}
+	if !c.serialize && (c.pushdown == nil || !c.pushdown.aggregating()) {
+		// Perform the accounting for this batch. Note that when we're not
+		// serializing the response, the cFetcher always allocates a new batch,
+		// so we always grow the account by the footprint of the batch.
+		if err := c.acc.Grow(ctx, c.detachedFetcherAcc.Used()); err != nil {
+			return nil, err
+		}
+		return c.adapter.batch, nil
+	}
+	// Update the memory account based on possibly changed footprint of the
+	// batch (which the cFetcher reuses).
+	if err := c.acc.Resize(ctx, prevBatchMemUsage, c.detachedFetcherAcc.Used()); err != nil {
+		return nil, err
+	}
+	return c.adapter.batch, nil
+}
+
+// nextPushdownBatch returns the next batch to be emitted when the pushdown is
+// set on the IndexFetchSpec (nil if there are no more batches).
+//
+// When aggregating, all batches produced by the cFetcher are consumed, and a
+// single batch with the partial aggregates is returned. Otherwise, only the
+// batches that have at least one row passing the filter are returned; when
+// serializing, these batches are compacted since the serialization ignores
+// the selection vector.
+func (c *cFetcherWrapper) nextPushdownBatch(
+	ctx context.Context, includeStartKeyIntoErr *bool,
+) (coldata.Batch, error) {
+	if c.pushdown.aggregating() {
+		if c.pushdownDone {
+			return nil, nil
+		}
+		for {
+			batch, err := c.fetch(ctx, includeStartKeyIntoErr)
+			if err != nil {
+				return nil, err
+			}
+			if batch == nil {
+				break
+			}
+			if err = c.pushdown.process(ctx, batch); err != nil {
+				return nil, err
+			}
+		}
+		c.pushdownDone = true
+		return c.pushdown.finish(c.resultAllocator), nil
+	}
+	for {
+		batch, err := c.fetch(ctx, includeStartKeyIntoErr)
+		if err != nil || batch == nil {
+			return nil, err
+		}
+		if err = c.pushdown.process(ctx, batch); err != nil {
+			return nil, err
+		}
+		if batch.Length() == 0 {
+			continue
+		}
+		sel := batch.Selection()
+		if !c.serialize || sel == nil {
+			return batch, nil
+		}
+		n := batch.Length()
+		c.compactedBatch, _ = c.resultAllocator.ResetMaybeReallocateNoMemLimit(
+			c.pushdown.outputTypes, c.compactedBatch, n,
+		)
+		c.resultAllocator.PerformOperation(c.compactedBatch.ColVecs(), func() {
+			for i, vec := range c.compactedBatch.ColVecs() {
+				vec.Copy(coldata.SliceArgs{
+					Src:       batch.ColVec(i),
+					Sel:       sel,
+					SrcEndIdx: n,
+				})
+			}
+		})
+		c.compactedBatch.SetLength(n)
+		return c.compactedBatch, nil
+	}
+}
+
 // Close implements the storage.CFetcherWrapper interface.
 func (c *cFetcherWrapper) Close(ctx context.Context) {
 	if c.fetcher != nil {
@@ -232,8 +334,9 @@ func newCFetcherWrapper(
 	// Since we're using the cFetcher on the KV server side, we don't collect
 	// any statistics on it (these stats are about the SQL layer).
 	const collectStats = false
-	// We cannot reuse batches if we're not serializing the response.
-	alwaysReallocate := !mustSerialize
+	// We cannot reuse batches if we're not serializing the response, unless
+	// the batches are only used to compute the pushed down aggregations.
+	alwaysReallocate := !mustSerialize && !fetchSpec.Pushdown.HasAggregations()
 	// TODO(yuzefovich, 23.1): think through estimatedRowCount (#94850) and
 	// traceKV arguments.
 	fetcher.cFetcherArgs = cFetcherArgs{
@@ -257,8 +360,8 @@ func newCFetcherWrapper(
 	detachedFetcherAcc := detachedFetcherMon.MakeBoundAccount()
 
 	// We don't need to provide the eval context here since we will only decode
-	// bytes into datums and then serialize them, without ever comparing datums
-	// (at least until we implement the filter pushdown).
+	// bytes into datums and then serialize them. The pushdown (if any) compares
+	// the datums using its own eval context.
 	allocator := colmem.NewAllocator(ctx, &detachedFetcherAcc, coldataext.NewExtendedColumnFactoryNoEvalCtx())
 	if err = fetcher.Init(allocator, nextKVer, tableArgs); err != nil {
 		return nil, err
@@ -272,16 +375,25 @@ func newCFetcherWrapper(
 		detachedFetcherAcc: &detachedFetcherAcc,
 		detachedFetcherMon: detachedFetcherMon,
 	}
+	resultTypes := tableArgs.typs
+	if fetchSpec.Pushdown != nil {
+		wrapper.pushdown = &pushdownEvaluator{}
+		if err = wrapper.pushdown.init(ctx, fetchSpec.Pushdown, tableArgs.typs, newKVPushdownEvalCtx(st)); err != nil {
+			return nil, err
+		}
+		wrapper.resultAllocator = colmem.NewAllocator(ctx, acc, coldataext.NewExtendedColumnFactoryNoEvalCtx())
+		resultTypes = wrapper.pushdown.outputTypes
+	}
 	if mustSerialize {
 		// Note that it is ok to use the same memory account for the
 		// ArrowBatchConverter as for everything else since the converter only
 		// grows / shrinks the account according to its own usage and never
 		// relies on the total used value.
-		wrapper.converter, err = colserde.NewArrowBatchConverter(tableArgs.typs, colserde.BatchToArrowOnly, acc)
+		wrapper.converter, err = colserde.NewArrowBatchConverter(resultTypes, colserde.BatchToArrowOnly, acc)
 		if err != nil {
 			return nil, err
 		}
-		wrapper.serializer, err = colserde.NewRecordBatchSerializer(tableArgs.typs)
+		wrapper.serializer, err = colserde.NewRecordBatchSerializer(resultTypes)
 		if err != nil {
 			return nil, err
 		}
@@ -314,9 +426,14 @@ func deserializeColumnarBatchesFromArrow(
 		return nil, err
 	}
 
+	typs := tableArgs.typs
+	if req.IndexFetchSpec.Pushdown.HasAggregations() {
+		typs = req.IndexFetchSpec.Pushdown.AggregateResultTypes(typs)
+	}
+
 	result := make([]coldata.Batch, 0, len(serializedColBatches))
 	var d colexecutils.Deserializer
-	if err = d.Init(allocator, tableArgs.typs, true /* alwaysReallocate */); err != nil {
+	if err = d.Init(allocator, typs, true /* alwaysReallocate */); err != nil {
 		return nil, err
 	}
 	defer d.Close(ctx)
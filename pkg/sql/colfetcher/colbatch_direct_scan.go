@@ -48,6 +48,11 @@ type ColBatchDirectScan struct {
 
 	deserializer            colexecutils.Deserializer
 	deserializerInitialized bool
+
+	// pushdown, if set, combines the partial aggregates computed by the KV
+	// layer when the aggregations were pushed down into the IndexFetchSpec.
+	pushdown     *pushdownEvaluator
+	pushdownDone bool
 }
 
 var _ ScanOperator = &ColBatchDirectScan{}
@@ -71,7 +76,30 @@ func (s *ColBatchDirectScan) Init(ctx context.Context) {
 }
 
 // Next implements the colexecop.Operator interface.
-func (s *ColBatchDirectScan) Next() (ret coldata.Batch) {
+func (s *ColBatchDirectScan) Next() coldata.Batch {
+	if s.pushdown == nil {
+		return s.nextBatch()
+	}
+	if s.pushdownDone {
+		return coldata.ZeroBatch
+	}
+	// Each KV response contains the partial aggregates over the part of the
+	// key spans it has scanned, so we have to combine all of them.
+	for {
+		batch := s.nextBatch()
+		if batch.Length() == 0 {
+			break
+		}
+		if err := s.pushdown.merge(s.Ctx, batch); err != nil {
+			colexecerror.InternalError(err)
+		}
+	}
+	s.pushdownDone = true
+	return s.pushdown.finish(s.allocator)
+}
+
+// nextBatch returns the next batch as produced by the KV layer.
+func (s *ColBatchDirectScan) nextBatch() coldata.Batch {
 	var res row.KVBatchFetcherResponse
 	var err error
 	for {
@@ -220,8 +248,17 @@ func NewColBatchDirectScan(
 		kvFetcherMemAcc,
 		flowCtx.EvalCtx.TestingKnobs.ForceProductionValues,
 	)
+	resultTypes := tableArgs.typs
+	var pushdown *pushdownEvaluator
+	if fetchSpec.Pushdown.HasAggregations() {
+		pushdown = &pushdownEvaluator{}
+		if err = pushdown.init(ctx, fetchSpec.Pushdown, tableArgs.typs, flowCtx.EvalCtx); err != nil {
+			return nil, nil, err
+		}
+		resultTypes = pushdown.outputTypes
+	}
 	var hasDatumVec bool
-	for _, t := range tableArgs.typs {
+	for _, t := range resultTypes {
 		if typeconv.TypeFamilyToCanonicalTypeFamily(t.Family()) == typeconv.DatumVecCanonicalTypeFamily {
 			hasDatumVec = true
 			break
@@ -236,8 +273,9 @@ func NewColBatchDirectScan(
 		fetcher:          fetcher,
 		allocator:        allocator,
 		spec:             &fetchSpec,
-		resultTypes:      tableArgs.typs,
+		resultTypes:      resultTypes,
 		hasDatumVec:      hasDatumVec,
 		cpuStopWatch:     cpuStopWatch,
-	}, tableArgs.typs, nil
+		pushdown:         pushdown,
+	}, resultTypes, nil
 }
@@ -205,6 +205,13 @@ func newColBatchScanBase(
 type ColBatchScan struct {
 	*colBatchScanBase
 	cf *cFetcher
+
+	// pushdown, if set, evaluates the filter and the aggregations that were
+	// pushed down into the IndexFetchSpec. This happens when the direct
+	// columnar scans couldn't be used, so the pushdown is evaluated locally.
+	pushdown     *pushdownEvaluator
+	pushdownDone bool
+	allocator    *colmem.Allocator
 }
 
 // ScanOperator combines common interfaces between operators that perform KV
@@ -240,6 +247,41 @@ func (s *ColBatchScan) Init(ctx context.Context) {
 
 // Next is part of the colexecop.Operator interface.
 func (s *ColBatchScan) Next() coldata.Batch {
+	if s.pushdown == nil {
+		return s.nextBatch()
+	}
+	if s.pushdown.aggregating() {
+		if s.pushdownDone {
+			return coldata.ZeroBatch
+		}
+		for {
+			bat := s.nextBatch()
+			if bat.Length() == 0 {
+				break
+			}
+			if err := s.pushdown.process(s.Ctx, bat); err != nil {
+				colexecerror.ExpectedError(err)
+			}
+		}
+		s.pushdownDone = true
+		return s.pushdown.finish(s.allocator)
+	}
+	for {
+		bat := s.nextBatch()
+		if bat.Length() == 0 {
+			return bat
+		}
+		if err := s.pushdown.process(s.Ctx, bat); err != nil {
+			colexecerror.ExpectedError(err)
+		}
+		if bat.Length() > 0 {
+			return bat
+		}
+	}
+}
+
+// nextBatch returns the next batch produced by the cFetcher.
+func (s *ColBatchScan) nextBatch() coldata.Batch {
 	bat, err := s.cf.NextBatch(s.Ctx)
 	if err != nil {
 		colexecerror.InternalError(err)
@@ -353,8 +395,20 @@ func NewColBatchScan(
 		fetcher.Release()
 		return nil, nil, err
 	}
+	resultTypes := tableArgs.typs
+	var pushdown *pushdownEvaluator
+	if spec.FetchSpec.Pushdown != nil {
+		pushdown = &pushdownEvaluator{}
+		if err = pushdown.init(ctx, spec.FetchSpec.Pushdown, tableArgs.typs, flowCtx.EvalCtx); err != nil {
+			fetcher.Release()
+			return nil, nil, err
+		}
+		resultTypes = pushdown.outputTypes
+	}
 	return &ColBatchScan{
 		colBatchScanBase: base,
 		cf:               fetcher,
-	}, tableArgs.typs, nil
+		pushdown:         pushdown,
+		allocator:        fetcherAllocator,
+	}, resultTypes, nil
 }
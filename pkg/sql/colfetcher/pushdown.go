@@ -0,0 +1,357 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package colfetcher
+
+import (
+	"context"
+
+	"github.com/cockroachdb/apd/v3"
+	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/fetchpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/colconv"
+	"github.com/cockroachdb/cockroach/pkg/sql/colmem"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/intsets"
+	"github.com/cockroachdb/errors"
+)
+
+// PushdownEnabled is a cluster setting that controls whether filters and
+// simple aggregations can be pushed down into the KV layer when using the
+// direct columnar scans.
+var PushdownEnabled = settings.RegisterBoolSetting(
+	settings.ApplicationLevel,
+	"sql.distsql.direct_columnar_scans.pushdown.enabled",
+	"set to true to allow filters and simple aggregations (count, sum, min, max) "+
+		"to be evaluated in the KV layer when using the 'direct' columnar scans",
+	false,
+)
+
+// pushdownEvaluator evaluates a fetchpb.IndexFetchSpec_Pushdown over the
+// batches produced by the cFetcher. It is used on the KV server side by the
+// cFetcherWrapper as well as on the SQL side by the ColBatchScan (when the
+// direct columnar scans couldn't be used) and by the ColBatchDirectScan (in
+// order to combine the partial aggregates from multiple responses).
+type pushdownEvaluator struct {
+	spec        *fetchpb.IndexFetchSpec_Pushdown
+	evalCtx     *eval.Context
+	outputTypes []*types.T
+
+	hasFilter bool
+	filter    execinfrapb.ExprHelper
+	// filterCols are the ordinals of the fetched columns referenced by the
+	// filter.
+	filterCols []int
+	// row is the scratch space used to evaluate the filter. Only the columns
+	// referenced by the filter are populated.
+	row rowenc.EncDatumRow
+
+	// converter converts the columns referenced by the filter and the
+	// aggregations of the fetched batches.
+	converter *colconv.VecToDatumConverter
+	// partialsConverter converts all columns of the batches with the partial
+	// aggregates. It is lazily allocated.
+	partialsConverter *colconv.VecToDatumConverter
+
+	aggs []pushdownAggregate
+}
+
+// pushdownAggregate is the state of a single aggregate function.
+type pushdownAggregate struct {
+	count    int64
+	sum      apd.Decimal
+	floatSum float64
+	// datum is the current minimum or maximum.
+	datum tree.Datum
+	// seen is set once at least one non-NULL value has been aggregated.
+	seen bool
+}
+
+// init initializes the pushdownEvaluator for the fetched columns of the given
+// types.
+func (e *pushdownEvaluator) init(
+	ctx context.Context,
+	spec *fetchpb.IndexFetchSpec_Pushdown,
+	fetchedTypes []*types.T,
+	evalCtx *eval.Context,
+) error {
+	*e = pushdownEvaluator{
+		spec:    spec,
+		evalCtx: evalCtx,
+		aggs:    make([]pushdownAggregate, len(spec.Aggregations)),
+	}
+	var neededCols intsets.Fast
+	if spec.Filter != "" {
+		semaCtx := tree.MakeSemaContext(nil /* resolver */)
+		if err := e.filter.Init(
+			ctx, execinfrapb.Expression{Expr: spec.Filter}, fetchedTypes, &semaCtx, evalCtx,
+		); err != nil {
+			return err
+		}
+		e.hasFilter = true
+		var filterCols intsets.Fast
+		v := ivarCollector{cols: &filterCols}
+		tree.WalkExprConst(&v, e.filter.Expr())
+		e.filterCols = filterCols.Ordered()
+		neededCols.UnionWith(filterCols)
+		e.row = make(rowenc.EncDatumRow, len(fetchedTypes))
+	}
+	for _, agg := range spec.Aggregations {
+		if agg.Func == fetchpb.IndexFetchSpec_Pushdown_COUNT_ROWS {
+			continue
+		}
+		if int(agg.ColIdx) >= len(fetchedTypes) {
+			return errors.AssertionFailedf(
+				"aggregation argument %d is out of range for %d fetched columns", agg.ColIdx, len(fetchedTypes),
+			)
+		}
+		neededCols.Add(int(agg.ColIdx))
+	}
+	if spec.HasAggregations() {
+		e.outputTypes = spec.AggregateResultTypes(fetchedTypes)
+	} else {
+		e.outputTypes = fetchedTypes
+	}
+	e.converter = colconv.NewVecToDatumConverter(
+		len(fetchedTypes), neededCols.Ordered(), false, /* willRelease */
+	)
+	return nil
+}
+
+// newKVPushdownEvalCtx returns the eval context used to evaluate the pushdown
+// on the KV server. The filters are only pushed down if they are immutable, so
+// the session data doesn't influence the evaluation.
+func newKVPushdownEvalCtx(st *cluster.Settings) *eval.Context {
+	return &eval.Context{
+		SessionDataStack: sessiondata.NewStack(&sessiondata.SessionData{}),
+		Settings:         st,
+	}
+}
+
+// ivarCollector is a tree.Visitor that collects the ordinals of all
+// tree.IndexedVars in an expression.
+type ivarCollector struct {
+	cols *intsets.Fast
+}
+
+var _ tree.Visitor = &ivarCollector{}
+
+// VisitPre is part of the tree.Visitor interface.
+func (v *ivarCollector) VisitPre(expr tree.Expr) (recurse bool, newExpr tree.Expr) {
+	if iv, ok := expr.(*tree.IndexedVar); ok {
+		v.cols.Add(iv.Idx)
+		return false, expr
+	}
+	return true, expr
+}
+
+// VisitPost is part of the tree.Visitor interface.
+func (v *ivarCollector) VisitPost(expr tree.Expr) tree.Expr { return expr }
+
+// aggregating returns whether the pushdown computes the aggregations.
+func (e *pushdownEvaluator) aggregating() bool {
+	return len(e.aggs) > 0
+}
+
+// process evaluates the pushdown over a batch of fetched rows. The batch is
+// updated (via a selection vector) to include only the rows that passed the
+// filter, and these rows are accumulated into the aggregates (if any).
+func (e *pushdownEvaluator) process(ctx context.Context, batch coldata.Batch) error {
+	n := batch.Length()
+	if n == 0 {
+		return nil
+	}
+	if batch.Selection() != nil {
+		return errors.AssertionFailedf("unexpectedly a selection vector is set on the fetched batch")
+	}
+	e.converter.ConvertBatchAndDeselect(batch)
+	if e.hasFilter {
+		batch.SetSelection(true)
+		sel := batch.Selection()
+		var numPassed int
+		for i := 0; i < n; i++ {
+			for _, colIdx := range e.filterCols {
+				e.row[colIdx] = rowenc.EncDatum{Datum: e.converter.GetDatumColumn(colIdx)[i]}
+			}
+			passed, err := e.filter.EvalFilter(ctx, e.row)
+			if err != nil {
+				return err
+			}
+			if passed {
+				sel[numPassed] = i
+				numPassed++
+			}
+		}
+		if numPassed == n {
+			batch.SetSelection(false)
+		}
+		batch.SetLength(numPassed)
+	}
+	if !e.aggregating() {
+		return nil
+	}
+	sel := batch.Selection()
+	for aggIdx, agg := range e.spec.Aggregations {
+		if agg.Func == fetchpb.IndexFetchSpec_Pushdown_COUNT_ROWS {
+			e.aggs[aggIdx].count += int64(batch.Length())
+			continue
+		}
+		datums := e.converter.GetDatumColumn(int(agg.ColIdx))
+		for i := 0; i < batch.Length(); i++ {
+			rowIdx := i
+			if sel != nil {
+				rowIdx = sel[i]
+			}
+			d := datums[rowIdx]
+			if d == tree.DNull {
+				continue
+			}
+			if agg.Func == fetchpb.IndexFetchSpec_Pushdown_COUNT {
+				e.aggs[aggIdx].count++
+				continue
+			}
+			if err := e.add(ctx, aggIdx, d); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// merge combines a batch of partial aggregates (produced by finish) into the
+// current state of the aggregates.
+func (e *pushdownEvaluator) merge(ctx context.Context, batch coldata.Batch) error {
+	n := batch.Length()
+	if n == 0 {
+		return nil
+	}
+	if e.partialsConverter == nil {
+		allCols := make([]int, len(e.outputTypes))
+		for i := range allCols {
+			allCols[i] = i
+		}
+		e.partialsConverter = colconv.NewVecToDatumConverter(
+			len(e.outputTypes), allCols, false, /* willRelease */
+		)
+	}
+	e.partialsConverter.ConvertBatchAndDeselect(batch)
+	for aggIdx, agg := range e.spec.Aggregations {
+		datums := e.partialsConverter.GetDatumColumn(aggIdx)
+		for i := 0; i < n; i++ {
+			d := datums[i]
+			if d == tree.DNull {
+				continue
+			}
+			switch agg.Func {
+			case fetchpb.IndexFetchSpec_Pushdown_COUNT_ROWS, fetchpb.IndexFetchSpec_Pushdown_COUNT:
+				e.aggs[aggIdx].count += int64(tree.MustBeDInt(d))
+			default:
+				if err := e.add(ctx, aggIdx, d); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// add accumulates a non-NULL value into the SUM, MIN, or MAX aggregate.
+func (e *pushdownEvaluator) add(ctx context.Context, aggIdx int, d tree.Datum) error {
+	a := &e.aggs[aggIdx]
+	switch e.spec.Aggregations[aggIdx].Func {
+	case fetchpb.IndexFetchSpec_Pushdown_SUM:
+		switch t := d.(type) {
+		case *tree.DInt:
+			var x apd.Decimal
+			x.SetInt64(int64(*t))
+			if _, err := tree.ExactCtx.Add(&a.sum, &a.sum, &x); err != nil {
+				return err
+			}
+		case *tree.DDecimal:
+			if _, err := tree.ExactCtx.Add(&a.sum, &a.sum, &t.Decimal); err != nil {
+				return err
+			}
+		case *tree.DFloat:
+			a.floatSum += float64(*t)
+		default:
+			return errors.AssertionFailedf("unexpected type %s for SUM pushdown", d.ResolvedType())
+		}
+	case fetchpb.IndexFetchSpec_Pushdown_MIN, fetchpb.IndexFetchSpec_Pushdown_MAX:
+		if a.seen {
+			cmp, err := d.Compare(ctx, e.evalCtx, a.datum)
+			if err != nil {
+				return err
+			}
+			isMin := e.spec.Aggregations[aggIdx].Func == fetchpb.IndexFetchSpec_Pushdown_MIN
+			if (isMin && cmp >= 0) || (!isMin && cmp <= 0) {
+				return nil
+			}
+		}
+		a.datum = d
+	default:
+		return errors.AssertionFailedf("unexpected aggregate function %s", e.spec.Aggregations[aggIdx].Func)
+	}
+	a.seen = true
+	return nil
+}
+
+// result returns the current value of the given aggregate.
+func (e *pushdownEvaluator) result(aggIdx int) tree.Datum {
+	a := &e.aggs[aggIdx]
+	switch e.spec.Aggregations[aggIdx].Func {
+	case fetchpb.IndexFetchSpec_Pushdown_COUNT_ROWS, fetchpb.IndexFetchSpec_Pushdown_COUNT:
+		return tree.NewDInt(tree.DInt(a.count))
+	case fetchpb.IndexFetchSpec_Pushdown_SUM:
+		if !a.seen {
+			return tree.DNull
+		}
+		if e.outputTypes[aggIdx].Family() == types.FloatFamily {
+			return tree.NewDFloat(tree.DFloat(a.floatSum))
+		}
+		d := &tree.DDecimal{}
+		d.Set(&a.sum)
+		return d
+	default:
+		if !a.seen {
+			return tree.DNull
+		}
+		return a.datum
+	}
+}
+
+// finish returns a single-row batch that contains the partial aggregates for
+// all rows processed (or merged) since the last call to finish, and it resets
+// the aggregates.
+func (e *pushdownEvaluator) finish(allocator *colmem.Allocator) coldata.Batch {
+	batch := allocator.NewMemBatchWithFixedCapacity(e.outputTypes, 1 /* capacity */)
+	allocator.PerformOperation(batch.ColVecs(), func() {
+		for aggIdx := range e.aggs {
+			vec := batch.ColVec(aggIdx)
+			d := e.result(aggIdx)
+			if d == tree.DNull {
+				vec.Nulls().SetNull(0)
+				continue
+			}
+			coldata.SetValueAt(vec, colconv.GetDatumToPhysicalFn(e.outputTypes[aggIdx])(d), 0 /* rowIdx */)
+		}
+	})
+	batch.SetLength(1)
+	for i := range e.aggs {
+		e.aggs[i] = pushdownAggregate{}
+	}
+	return batch
+}
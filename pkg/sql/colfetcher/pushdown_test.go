@@ -0,0 +1,81 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package colfetcher_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/sql/colfetcher"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+// TestScanPushdown verifies that the filters and the aggregations pushed down
+// into the direct columnar scans produce the same results as when they are
+// evaluated by SQL.
+func TestScanPushdown(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	s, conn, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+
+	// Use a single connection so that the session variable set below applies
+	// to all queries.
+	sqlConn, err := conn.Conn(ctx)
+	require.NoError(t, err)
+	defer sqlConn.Close()
+	runner := sqlutils.MakeSQLRunner(sqlConn)
+	runner.Exec(t, `
+CREATE TABLE t (k INT PRIMARY KEY, i INT, f FLOAT, d DECIMAL, s STRING, b BOOL);
+INSERT INTO t
+  SELECT
+    g,
+    CASE WHEN g % 7 = 0 THEN NULL ELSE g % 100 END,
+    -- Use values that are exactly representable so that the float sums
+    -- don't depend on the order of additions.
+    (g % 50)::FLOAT / 4,
+    CASE WHEN g % 5 = 0 THEN NULL ELSE (g::DECIMAL / 7)::DECIMAL(10, 2) END,
+    'str' || (g % 13)::STRING,
+    g % 2 = 0
+  FROM generate_series(1, 3000) AS g;
+ALTER TABLE t SPLIT AT VALUES (1000), (2000);
+`)
+
+	queries := []string{
+		`SELECT count(*) FROM t`,
+		`SELECT count(*), count(i), count(d) FROM t`,
+		`SELECT sum(i), sum(f), sum(d), min(s), max(s) FROM t`,
+		`SELECT min(k), max(k), min(d), max(f) FROM t WHERE b`,
+		`SELECT count(*), sum(i) FROM t WHERE i > 50 AND s <> 'str3'`,
+		`SELECT count(*), sum(d) FROM t WHERE i IS NULL OR d IS NULL`,
+		`SELECT count(*), min(i) FROM t WHERE s IN ('str1', 'str2') AND NOT b`,
+		`SELECT count(*), sum(i), max(d) FROM t WHERE k > 5000`,
+		`SELECT k, i, s FROM t WHERE i = 42 ORDER BY k`,
+		`SELECT k, d FROM t WHERE d > 400 AND k % 2 = 0 ORDER BY k`,
+	}
+	var expected [][][]string
+	for _, q := range queries {
+		expected = append(expected, runner.QueryStr(t, q))
+	}
+
+	colfetcher.PushdownEnabled.Override(ctx, &s.ClusterSettings().SV, true)
+	runner.Exec(t, `SET direct_columnar_scans_enabled = true`)
+	for i, q := range queries {
+		require.Equal(t, expected[i], runner.QueryStr(t, q), "query: %s", q)
+	}
+}
@@ -33,6 +33,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/advisorylock"
 	"github.com/cockroachdb/cockroach/pkg/sql/appstatspb"
 	"github.com/cockroachdb/cockroach/pkg/sql/auditlogging"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catsessiondata"
@@ -50,6 +51,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser/statements"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgnotify"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
@@ -1261,6 +1263,12 @@ func (ex *connExecutor) close(ctx context.Context, closeType closeType) {
 	if err := ex.extraTxnState.sqlCursors.closeAll(cursorCloseForExplicitClose); err != nil {
 		log.Warningf(ctx, "error closing cursors: %v", err)
 	}
+	if ex.notificationListener != nil {
+		ex.notificationListener.Close()
+	}
+	if ex.advisoryLocks != nil {
+		ex.advisoryLocks.ReleaseAll(ctx)
+	}
 
 	// Free any memory used by the stats collector.
 	ex.statsCollector.Free(ctx)
@@ -1444,6 +1452,10 @@ type connExecutor struct {
 
 		jobs *txnJobsCollection
 
+		// deferredConstraints tracks the SET CONSTRAINTS modes of the
+		// transaction and the deferred constraint checks to run at commit.
+		deferredConstraints deferredConstraints
+
 		// firstStmtExecuted indicates that the first statement inside this
 		// transaction has been executed.
 		firstStmtExecuted bool
@@ -1745,6 +1757,14 @@ type connExecutor struct {
 	// going to find a suitable time to close the connection.
 	draining bool
 
+	// notificationListener receives the notifications sent on the channels the
+	// session is listening on. It is created on the first LISTEN.
+	notificationListener *pgnotify.Listener
+
+	// advisoryLocks holds the advisory locks of the session. It is created on
+	// the first pg_advisory_lock call.
+	advisoryLocks *advisorylock.SessionLocks
+
 	// executorType is set to whether this executor is an ordinary executor which
 	// responds to user queries or an internal one.
 	executorType executorType
@@ -2065,6 +2085,12 @@ func (ex *connExecutor) resetExtraTxnState(ctx context.Context, ev txnEvent, pay
 	ex.extraTxnState.upgradedToSerializable = false
 	ex.extraTxnState.hasAdminRoleCache = HasAdminRoleCache{}
 	ex.extraTxnState.createdSequences = nil
+	ex.extraTxnState.deferredConstraints.reset()
+	if ex.advisoryLocks != nil {
+		// Transaction-level advisory locks are released when the transaction
+		// ends or restarts.
+		ex.advisoryLocks.ReleaseTxnLocks(ctx)
+	}
 
 	if ex.extraTxnState.skipResettingSchemaObjects {
 		if ex.extraTxnState.shouldResetSyntheticDescriptors {
@@ -2519,6 +2545,14 @@ func (ex *connExecutor) execCmd() (retErr error) {
 		//   was created when the statement started executing (via the
 		//   reset() method).
 		ex.statsCollector.PhaseTimes().SetSessionPhaseTime(sessionphase.SessionQueryServiced, timeutil.Now())
+	case StartReplication:
+		ex.phaseTimes.SetSessionPhaseTime(sessionphase.SessionQueryReceived, tcmd.TimeReceived)
+		replRes := ex.clientComm.CreateStartReplicationResult(tcmd, pos)
+		res = replRes
+		stmtCtx := withStatement(ctx, tcmd.Stmt)
+		if err := ex.execStartReplication(stmtCtx, tcmd); err != nil {
+			replRes.SetError(err)
+		}
 	case DrainRequest:
 		// We received a drain request. We terminate immediately if we're not in a
 		// transaction. If we are in a transaction, we'll finish as soon as a Sync
@@ -2532,6 +2566,10 @@ func (ex *connExecutor) execCmd() (retErr error) {
 	case Flush:
 		// Closing the res will flush the connection's buffer.
 		res = ex.clientComm.CreateFlushResult(pos)
+	case DeliverNotifications:
+		// The pending notifications are buffered below, if the session is idle,
+		// and closing the res will flush them.
+		res = ex.clientComm.CreateFlushResult(pos)
 	default:
 		panic(errors.AssertionFailedf("unsupported command type: %T", cmd))
 	}
@@ -2621,6 +2659,10 @@ func (ex *connExecutor) execCmd() (retErr error) {
 				}
 			}
 		}
+		switch cmd.(type) {
+		case Sync, DeliverNotifications:
+			ex.bufferNotifications(ctx)
+		}
 		res.Close(ctx, stateToTxnStatusIndicator(ex.machine.CurState()))
 	} else {
 		res.Discard()
@@ -2797,10 +2839,14 @@ func (ex *connExecutor) updateTxnRewindPosMaybe(
 				// Can't advance.
 			case CopyOut:
 				// Can't advance.
+			case StartReplication:
+				// Can't advance.
 			case DrainRequest:
 				canAdvance = true
 			case Flush:
 				canAdvance = true
+			case DeliverNotifications:
+				canAdvance = true
 			default:
 				panic(errors.AssertionFailedf("unsupported cmd: %T", cmd))
 			}
@@ -3743,6 +3789,7 @@ func (ex *connExecutor) initEvalCtx(ctx context.Context, evalCtx *extendedEvalCo
 		Descs:                ex.extraTxnState.descCollection,
 		TxnModesSetter:       ex,
 		jobs:                 ex.extraTxnState.jobs,
+		deferredConstraints:  &ex.extraTxnState.deferredConstraints,
 		validateDbZoneConfig: &ex.extraTxnState.validateDbZoneConfig,
 		statsProvider:        ex.server.sqlStats,
 		indexUsageStats:      ex.indexUsageStats,
@@ -3771,6 +3818,11 @@ func (ex *connExecutor) resetEvalCtx(evalCtx *extendedEvalContext, txn *kv.Txn,
 		defer ex.state.mu.Unlock()
 		evalCtx.TxnIsoLevel = ex.state.mu.isolationLevel
 	}()
+	// Constraint checks are only deferred in explicit transactions of client
+	// sessions. Internal executors run in an outer transaction which they do
+	// not commit, so they could not validate the deferred checks.
+	evalCtx.ConstraintModes = ex.extraTxnState.deferredConstraints.modes
+	evalCtx.ConstraintModes.ExplicitTxn = ex.executorType == executorTypeExec && !ex.implicitTxn()
 	if newTxn || !ex.implicitTxn() {
 		// Only update the stmt timestamp if in a new txn or an explicit txn. This is because this gets
 		// called multiple times during an extended protocol implicit txn, but we
@@ -3825,6 +3877,8 @@ func (ex *connExecutor) initPlanner(ctx context.Context, p *planner) {
 	p.noticeSender = nil
 	p.preparedStatements = ex.getPrepStmtsAccessor()
 	p.sqlCursors = ex.getCursorAccessor()
+	p.notificationListener = ex.getNotificationListener
+	p.advisoryLocks = ex.getAdvisoryLocks
 	p.storedProcTxnState = ex.getStoredProcTxnStateAccessor()
 	p.createdSequences = ex.getCreatedSequencesAccessor()
 
@@ -3867,6 +3921,11 @@ func (ex *connExecutor) resetPlanner(
 ) {
 	p.resetPlanner(ctx, txn, ex.sessionData(), ex.state.mon, ex.sessionMon)
 	ex.maybeAdjustMaxTimestampBound(p, txn)
+	if txn != nil {
+		// The session setting can change in the middle of a transaction, so it
+		// is applied to the txn before every statement.
+		txn.SetLinearizableFollowerReads(ex.sessionData().LinearizableFollowerReadsEnabled)
+	}
 	// Make sure the default locality specifies the actual gateway region at the
 	// start of query compilation. It could have been overridden to a remote
 	// region when the enforce_home_region session setting is true.
@@ -4322,6 +4381,51 @@ func (ex *connExecutor) getCursorAccessor() sqlCursors {
 	}
 }
 
+// getNotificationListener returns the listener which receives the
+// notifications for the session, creating it if needed. It returns nil for
+// internal executors, which cannot receive notifications.
+func (ex *connExecutor) getNotificationListener() *pgnotify.Listener {
+	if ex.executorType == executorTypeInternal || ex.server.cfg.NotificationRegistry == nil {
+		return nil
+	}
+	if ex.notificationListener == nil {
+		stmtBuf := ex.stmtBuf
+		ex.notificationListener = ex.server.cfg.NotificationRegistry.NewListener(func() {
+			// The session will deliver the notifications once it processes this
+			// command, or at the end of the current transaction.
+			_ /* err */ = stmtBuf.Push(context.Background(), DeliverNotifications{})
+		})
+	}
+	return ex.notificationListener
+}
+
+// getAdvisoryLocks returns the advisory locks of the session, creating them if
+// needed.
+func (ex *connExecutor) getAdvisoryLocks() *advisorylock.SessionLocks {
+	if ex.advisoryLocks == nil {
+		ex.advisoryLocks = advisorylock.NewSessionLocks(ex.server.cfg.DB, ex.server.cfg.Codec)
+	}
+	return ex.advisoryLocks
+}
+
+// bufferNotifications sends the pending notifications to the client, unless
+// the session is in a transaction. As in Postgres, notifications are only
+// delivered between transactions.
+func (ex *connExecutor) bufferNotifications(ctx context.Context) {
+	if ex.notificationListener == nil {
+		return
+	}
+	if _, noTxn := ex.machine.CurState().(stateNoTxn); !noTxn {
+		return
+	}
+	for _, n := range ex.notificationListener.Drain() {
+		if err := ex.clientComm.BufferNotification(n); err != nil {
+			log.Warningf(ctx, "failed to send notification: %v", err)
+			return
+		}
+	}
+}
+
 func (ex *connExecutor) getStoredProcTxnStateAccessor() storedProcTxnStateAccessor {
 	return storedProcTxnStateAccessor{
 		ex: ex,
@@ -1582,6 +1582,14 @@ func (ex *connExecutor) commitSQLTransactionInternal(ctx context.Context) (retEr
 		ex.state.mu.txn.ConfigureStepping(ctx, prevSteppingMode)
 	}
 
+	if ex.extraTxnState.deferredConstraints.hasPending() {
+		if err := ex.extraTxnState.deferredConstraints.validate(
+			ctx, &ex.planner, nil, /* stillDeferred */
+		); err != nil {
+			return err
+		}
+	}
+
 	if err := ex.createJobs(ctx); err != nil {
 		return err
 	}
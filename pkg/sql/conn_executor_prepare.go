@@ -495,6 +495,12 @@ func (ex *connExecutor) execBind(
 							}
 						}
 					}
+					if pt, ok := ps.ValueType(k); ok && pt.Family() == types.ArrayFamily && pt.Oid() == t {
+						// Multi-dimensional array types have the same OID as the
+						// one-dimensional array type of their scalar elements, so
+						// use the type of the placeholder to decode the argument.
+						typ = pt
+					}
 					d, err := pgwirebase.DecodeDatum(
 						ctx,
 						ex.planner.EvalContext(),
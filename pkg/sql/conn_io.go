@@ -20,6 +20,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/col/coldata"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser/statements"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgnotify"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgrepltree"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -434,6 +436,41 @@ func (c CopyOut) String() string {
 
 var _ Command = CopyOut{}
 
+// StartReplication is the command for the execution of START_REPLICATION,
+// which streams the changes of a logical replication slot to the client
+// using the Copy-both pgwire subprotocol.
+type StartReplication struct {
+	ParsedStmt statements.Statement[tree.Statement]
+	Stmt       *pgrepltree.StartReplication
+	// Conn is the network connection. Execution of the StartReplication
+	// command takes control of the connection until the client ends the
+	// stream.
+	Conn pgwirebase.Conn
+	// ReplicationDone is decremented once execution finishes, which signals
+	// that control of the connection is being handed back to the network
+	// routine.
+	ReplicationDone *sync.WaitGroup
+	// TimeReceived is the time at which the message was received
+	// from the client. Used to compute the service latency.
+	TimeReceived time.Time
+}
+
+// command implements the Command interface.
+func (StartReplication) command() string { return "start replication" }
+
+// isExtendedProtocolCmd implements the Command interface.
+func (StartReplication) isExtendedProtocolCmd() bool { return false }
+
+func (c StartReplication) String() string {
+	s := "(empty)"
+	if c.Stmt != nil {
+		s = c.Stmt.String()
+	}
+	return fmt.Sprintf("StartReplication: %s", s)
+}
+
+var _ Command = StartReplication{}
+
 // DrainRequest represents a notice that the server is draining and command
 // processing should stop soon.
 //
@@ -452,6 +489,26 @@ func (DrainRequest) String() string {
 
 var _ Command = DrainRequest{}
 
+// DeliverNotifications is pushed by the notification registry when there are
+// notifications pending for a session listening on their channels. It causes
+// the notifications to be sent to the client if the session is idle;
+// otherwise they are sent once the current transaction ends.
+//
+// DeliverNotifications commands don't produce results.
+type DeliverNotifications struct{}
+
+// command implements the Command interface.
+func (DeliverNotifications) command() string { return "deliver notifications" }
+
+// isExtendedProtocolCmd implements the Command interface.
+func (DeliverNotifications) isExtendedProtocolCmd() bool { return false }
+
+func (DeliverNotifications) String() string {
+	return "DeliverNotifications"
+}
+
+var _ Command = DeliverNotifications{}
+
 // SendError is a command that, upon execution, send a specific error to the
 // client. This is used by pgwire to schedule errors to be sent at an
 // appropriate time.
@@ -760,8 +817,15 @@ type ClientComm interface {
 	CreateCopyInResult(cmd CopyIn, pos CmdPos) CopyInResult
 	// CreateCopyOutResult creates a result for a Copy-out command.
 	CreateCopyOutResult(cmd CopyOut, pos CmdPos) CopyOutResult
+	// CreateStartReplicationResult creates a result for a StartReplication
+	// command.
+	CreateStartReplicationResult(cmd StartReplication, pos CmdPos) StartReplicationResult
 	// CreateDrainResult creates a result for a Drain command.
 	CreateDrainResult(pos CmdPos) DrainResult
+	// BufferNotification buffers an asynchronous notification received by a
+	// session listening on its channel. The notification is delivered to the
+	// client with the next flush.
+	BufferNotification(pgnotify.Notification) error
 
 	// LockCommunication ensures that no further results are delivered to the
 	// client. The returned ClientLock can be queried to see what results have
@@ -987,6 +1051,12 @@ type CopyInResult interface {
 	SetRowsAffected(ctx context.Context, n int)
 }
 
+// StartReplicationResult represents the result of a StartReplication
+// command. Closing this result sends a CommandComplete message to the client.
+type StartReplicationResult interface {
+	ResultBase
+}
+
 // CopyOutResult represents the result of a CopyOut command. Closing this result
 // sends a CommandComplete message to the client.
 type CopyOutResult interface {
@@ -1002,6 +1072,17 @@ type CopyOutResult interface {
 
 	// SendCopyDone sends the copy done response to the client.
 	SendCopyDone(ctx context.Context) error
+
+	// SetRowsAffected sets the number of rows written by a COPY TO 'file',
+	// whose rows aren't sent to the client.
+	SetRowsAffected(ctx context.Context, n int)
+
+	// AppendCopyBinaryRow appends the binary COPY representation of row to buf
+	// and returns the extended buffer. The row is written as a field count
+	// followed by each field in the pgwire binary format of its column type.
+	AppendCopyBinaryRow(
+		ctx context.Context, buf []byte, row tree.Datums, cols colinfo.ResultColumns, loc *time.Location,
+	) ([]byte, error)
 }
 
 // ClientLock is an interface returned by ClientComm.lockCommunication(). It
@@ -1262,6 +1343,13 @@ func (r *streamingCommandResult) SendCopyDone(ctx context.Context) error {
 	return errors.AssertionFailedf("streamingCommandResult does not implement SendCopyDone")
 }
 
+// AppendCopyBinaryRow is part of the sql.CopyOutResult interface.
+func (r *streamingCommandResult) AppendCopyBinaryRow(
+	ctx context.Context, buf []byte, row tree.Datums, cols colinfo.ResultColumns, loc *time.Location,
+) ([]byte, error) {
+	return nil, errors.AssertionFailedf("streamingCommandResult does not implement AppendCopyBinaryRow")
+}
+
 // BulkJobInfoKey are for keys stored in pgwire.commandResult.bulkJobInfo.
 type BulkJobInfoKey string
 
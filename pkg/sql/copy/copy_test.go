@@ -107,10 +107,14 @@ func TestDataDriven(t *testing.T) {
 			data := strings.Join(lines[1:], "\n")
 			st, err := parser.ParseOne(stmt)
 			require.NoError(t, err)
+			// Rows that don't satisfy the WHERE clause of the COPY aren't
+			// inserted.
+			var filtered bool
 			if copy, ok := st.AST.(*tree.CopyFrom); ok {
 				if copy.Options.HasHeader {
 					expectedRows--
 				}
+				filtered = copy.Where != nil
 			}
 
 			if kvtrace {
@@ -125,7 +129,9 @@ func TestDataDriven(t *testing.T) {
 			switch d.Cmd {
 			case "copy-from":
 				require.NoError(t, err, "%s\n%s\n", d.Cmd, d.Input)
-				require.Equal(t, int(rows), expectedRows, "Not all rows were inserted")
+				if !filtered {
+					require.Equal(t, int(rows), expectedRows, "Not all rows were inserted")
+				}
 				return fmt.Sprintf("%d", rows)
 			case "copy-from-error":
 				require.Error(t, err, "copy-from-error didn't return and error!")
@@ -20,6 +20,8 @@ import (
 	"unicode/utf8"
 
 	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/cloud/cloudpb"
 	"github.com/cockroachdb/cockroach/pkg/col/coldata"
 	"github.com/cockroachdb/cockroach/pkg/col/coldataext"
 	"github.com/cockroachdb/cockroach/pkg/kv"
@@ -39,11 +41,11 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/syntheticprivilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/buildutil"
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
 	"github.com/cockroachdb/cockroach/pkg/util/encoding/csv"
-	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/metamorphic"
 	"github.com/cockroachdb/cockroach/pkg/util/mon"
@@ -86,6 +88,7 @@ type copyMachineInterface interface {
 
 type copyOptions struct {
 	csvEscape       rune
+	csvQuote        rune
 	csvExpectHeader bool
 
 	delimiter byte
@@ -121,8 +124,25 @@ func processCopyOptions(
 		if c.format != tree.CopyFormatCSV {
 			return c, pgerror.Newf(pgcode.FeatureNotSupported, "QUOTE only supported with CSV format")
 		}
-		if opts.Quote.RawString() != `"` {
-			return c, unimplemented.NewWithIssuef(85574, `QUOTE value %s unsupported`, opts.Quote.RawString())
+		s := opts.Quote.RawString()
+		if len(s) != 1 {
+			return c, pgerror.Newf(
+				pgcode.FeatureNotSupported,
+				"QUOTE must be a single one-byte character",
+			)
+		}
+		c.csvQuote, _ = utf8.DecodeRuneInString(s)
+	}
+
+	if c.format != tree.CopyFormatCSV {
+		if opts.ForceQuote != nil {
+			return c, pgerror.Newf(pgcode.FeatureNotSupported, "FORCE_QUOTE only supported with CSV format")
+		}
+		if opts.ForceNotNull != nil {
+			return c, pgerror.Newf(pgcode.FeatureNotSupported, "FORCE_NOT_NULL only supported with CSV format")
+		}
+		if opts.ForceNull != nil {
+			return c, pgerror.Newf(pgcode.FeatureNotSupported, "FORCE_NULL only supported with CSV format")
 		}
 	}
 
@@ -178,6 +198,17 @@ func processCopyOptions(
 		c.csvEscape, _ = utf8.DecodeRuneInString(s)
 	}
 
+	if c.csvQuote != 0 && c.csvQuote == rune(c.delimiter) {
+		return c, pgerror.Newf(
+			pgcode.InvalidParameterValue,
+			"COPY delimiter and quote must be different",
+		)
+	}
+	if c.csvQuote != 0 && c.csvEscape == 0 {
+		// As in Postgres, the escape character defaults to the quote character.
+		c.csvEscape = c.csvQuote
+	}
+
 	if opts.Destination != nil {
 		return c, pgerror.Newf(
 			pgcode.FeatureNotSupported,
@@ -199,6 +230,50 @@ func processCopyOptions(
 	return c, nil
 }
 
+// checkCopyExternalStoragePrivileges checks that the user is allowed to access
+// the external storage URI of a COPY FROM 'file' or COPY TO 'file'.
+// Like EXPORT, it mirrors cloudprivilege.CheckDestinationPrivileges, which can't
+// be used here because of a circular dependency with pkg/sql.
+func checkCopyExternalStoragePrivileges(ctx context.Context, p *planner, uri string) error {
+	admin, err := p.HasAdminRole(ctx)
+	if err != nil {
+		return err
+	}
+	if admin {
+		return nil
+	}
+	conf, err := cloud.ExternalStorageConfFromURI(uri, p.User())
+	if err != nil {
+		return err
+	}
+
+	// Check if the URI requires the user to be an admin or have the
+	// EXTERNALIOIMPLICITACCESS privilege.
+	if !conf.AccessIsWithExplicitAuth() &&
+		!p.ExecCfg().ExternalIODirConfig.EnableNonAdminImplicitAndArbitraryOutbound {
+		if err := p.CheckPrivilege(
+			ctx, syntheticprivilege.GlobalPrivilegeObject, privilege.EXTERNALIOIMPLICITACCESS,
+		); err != nil {
+			return pgerror.Newf(
+				pgcode.InsufficientPrivilege,
+				"only users with the admin role or the EXTERNALIOIMPLICITACCESS system privilege "+
+					"are allowed to access the specified %s URI", conf.Provider.String())
+		}
+	}
+
+	// If the URI refers to an External Connection, check that the user is
+	// allowed to use it.
+	if conf.Provider == cloudpb.ExternalStorageProvider_external {
+		ecPrivilege := &syntheticprivilege.ExternalConnectionPrivilege{
+			ConnectionName: conf.ExternalConnectionConfig.Name,
+		}
+		if err := p.CheckPrivilege(ctx, ecPrivilege, privilege.USAGE); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // copyMachine supports the Copy-in pgwire subprotocol (COPY...FROM STDIN). The
 // machine is created by the Executor when that statement is executed; from that
 // moment on, the machine takes control of the pgwire connection until
@@ -222,12 +297,18 @@ type copyMachine struct {
 	// textDelim is delimiter converted to a []byte so that we don't have to do that per row.
 	textDelim   []byte
 	binaryState binaryState
+	// binaryFields is scratch space for the fields of a binary tuple.
+	binaryFields [][]byte
 	// forceNotNull disables converting values matching the null string to
 	// NULL. The spec says this is only supported for CSV, and also must specify
 	// which columns it applies to.
 	forceNotNull bool
-	csvInput     bytes.Buffer
-	csvReader    *csv.Reader
+	// csvForceNotNull and csvForceNull are indexed by result column and
+	// record the columns named by the FORCE_NOT_NULL and FORCE_NULL options.
+	csvForceNotNull []bool
+	csvForceNull    []bool
+	csvInput        bytes.Buffer
+	csvReader       *csv.Reader
 	// buf is used to parse input data into rows. It also accumulates a partial
 	// row between protocol messages.
 	buf []byte
@@ -236,6 +317,9 @@ type copyMachine struct {
 	// insertedRows keeps track of the total number of rows inserted by the
 	// machine.
 	insertedRows int
+	// batchFilteredRows is the number of rows of the current batch that were
+	// skipped because they didn't satisfy the WHERE clause.
+	batchFilteredRows int
 	// copyMon tracks copy's memory usage.
 	copyMon *mon.BytesMonitor
 	// rowsMemAcc accounts for memory used by `rows`.
@@ -294,6 +378,9 @@ func newCopyMachine(
 	if err != nil {
 		return nil, err
 	}
+	if n.Options.ForceQuote != nil {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported, "FORCE_QUOTE cannot be used with COPY FROM")
+	}
 	c := &copyMachine{
 		conn:        conn,
 		copyFromAST: n,
@@ -327,6 +414,11 @@ func newCopyMachine(
 	if err := c.p.CheckPrivilege(ctx, tableDesc, privilege.INSERT); err != nil {
 		return nil, err
 	}
+	if n.File != nil {
+		if err := checkCopyExternalStoragePrivileges(ctx, c.p, n.File.RawString()); err != nil {
+			return nil, err
+		}
+	}
 	cols, err := colinfo.ProcessTargetColumns(tableDesc, n.Columns,
 		true /* ensureColumns */, false /* allowMutations */)
 	if err != nil {
@@ -344,6 +436,16 @@ func newCopyMachine(
 		typs[i] = col.GetType()
 	}
 	c.typs = typs
+	if c.csvForceNotNull, err = resolveCopyForceColumns(
+		"FORCE_NOT_NULL", n.Options.ForceNotNull, c.resultColumns,
+	); err != nil {
+		return nil, err
+	}
+	if c.csvForceNull, err = resolveCopyForceColumns(
+		"FORCE_NULL", n.Options.ForceNull, c.resultColumns,
+	); err != nil {
+		return nil, err
+	}
 	// If there are no column specifiers and we expect non-visible columns
 	// to have field data then we have to populate the expectedHiddenColumnIdxs
 	// field with the columns indexes we expect to be hidden.
@@ -412,8 +514,9 @@ var copyMaxCommandSizeFraction = settings.RegisterFloatSetting(
 )
 
 func (c *copyMachine) canSupportVectorized(table catalog.TableDescriptor) bool {
-	// TODO(cucaroach): support vectorized binary.
-	if c.format == tree.CopyFormatBinary {
+	// The rows of a COPY FROM ... WHERE are filtered by a SELECT, which the
+	// vectorized insert fast path doesn't support.
+	if c.copyFromAST.Where != nil {
 		return false
 	}
 	// Vectorized requires avoiding materializing the rows for the optimizer.
@@ -558,23 +661,9 @@ func (c *copyMachine) Close(ctx context.Context) {
 	c.copyMon.Stop(ctx)
 }
 
-// run consumes all the copy-in data from the network connection and inserts it
-// in the database.
+// run consumes all the copy-in data from the network connection, or from the
+// external storage file of a COPY FROM 'file', and inserts it in the database.
 func (c *copyMachine) run(ctx context.Context) error {
-	format := pgwirebase.FormatText
-	if c.format == tree.CopyFormatBinary {
-		format = pgwirebase.FormatBinary
-	}
-	// Send the message describing the columns to the client.
-	if err := c.conn.BeginCopyIn(ctx, c.resultColumns, format); err != nil {
-		return err
-	}
-
-	// Read from the connection until we see an ClientMsgCopyDone.
-	readBuf := pgwirebase.MakeReadBuffer(
-		pgwirebase.ReadBufferOptionWithClusterSettings(&c.p.execCfg.Settings.SV),
-	)
-
 	switch c.format {
 	case tree.CopyFormatText:
 		c.textDelim = []byte{c.delimiter}
@@ -584,11 +673,32 @@ func (c *copyMachine) run(ctx context.Context) error {
 		c.csvReader.Comma = rune(c.delimiter)
 		c.csvReader.ReuseRecord = true
 		c.csvReader.FieldsPerRecord = len(c.resultColumns) + len(c.expectedHiddenColumnIdxs)
+		if c.csvQuote != 0 {
+			c.csvReader.Quote = c.csvQuote
+		}
 		if c.csvEscape != 0 {
 			c.csvReader.Escape = c.csvEscape
 		}
 	}
 
+	if c.copyFromAST != nil && c.copyFromAST.File != nil {
+		return c.readFile(ctx)
+	}
+
+	format := pgwirebase.FormatText
+	if c.format == tree.CopyFormatBinary {
+		format = pgwirebase.FormatBinary
+	}
+	// Send the message describing the columns to the client.
+	if err := c.conn.BeginCopyIn(ctx, c.resultColumns, format); err != nil {
+		return err
+	}
+
+	// Read from the connection until we see an ClientMsgCopyDone.
+	readBuf := pgwirebase.MakeReadBuffer(
+		pgwirebase.ReadBufferOptionWithClusterSettings(&c.p.execCfg.Settings.SV),
+	)
+
 Loop:
 	for {
 		typ, _, err := readBuf.ReadTypedMsg(c.conn.Rd())
@@ -648,6 +758,49 @@ Loop:
 	return nil
 }
 
+// copyFileChunkSize is the size of the chunks in which a COPY FROM 'file'
+// reads its external storage file.
+const copyFileChunkSize = 64 << 10
+
+// readFile consumes all the copy-in data from the external storage file of a
+// COPY FROM 'file' and inserts it in the database.
+func (c *copyMachine) readFile(ctx context.Context) error {
+	store, err := c.p.execCfg.DistSQLSrv.ExternalStorageFromURI(
+		ctx, c.copyFromAST.File.RawString(), c.p.User(),
+	)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	r, _, err := store.ReadFile(ctx, "", cloud.ReadOptions{NoFileSize: true})
+	if err != nil {
+		return err
+	}
+	defer r.Close(ctx)
+
+	chunk := make([]byte, copyFileChunkSize)
+	for {
+		n, readErr := r.Read(ctx, chunk)
+		if n > 0 {
+			// processCopyData copies the data into its own buffer, so the chunk
+			// can be reused.
+			if err := c.processCopyData(
+				ctx, encoding.UnsafeConvertBytesToString(chunk[:n]), false, /* final */
+			); err != nil {
+				return err
+			}
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return readErr
+		}
+	}
+	return c.processCopyData(ctx, "" /* data */, true /* final */)
+}
+
 const (
 	lineDelim = '\n'
 	endOfData = `\.`
@@ -795,23 +948,23 @@ func (c *copyMachine) readCSVData(ctx context.Context, final bool) (brk bool, er
 		fullLine = append(fullLine, line...)
 		// Now we need to calculate if we have reached the end of the quote.
 		// If so, break out.
-		if c.csvEscape == 0 {
-			// CSV escape is not specified and hence defaults to '"'.¥
+		quote := byte('"')
+		if c.csvQuote != 0 {
+			quote = byte(c.csvQuote)
+		}
+		if c.csvEscape == 0 || c.csvEscape == rune(quote) {
+			// CSV escape is not specified and hence defaults to the QUOTE char.
 			// At this point, we know fullLine ends in '\n'. Keep track of the total
 			// number of QUOTE chars in fullLine -- if it is even, then it means that
 			// the quotes are balanced and '\n' is not in a quoted field.
-			// Currently, the QUOTE char and ESCAPE char are both always equal to '"'
-			// and are not configurable. As per the COPY spec, any appearance of the
-			// QUOTE or ESCAPE characters in an actual value must be preceded by an
-			// ESCAPE character. This means that an escaped '"' also results in an even
-			// number of '"' characters.
-			// This branch is kept in the interests of "backporting safely" - this
-			// was the old code. Users who use COPY ... ESCAPE will be the only
-			// ones hitting the new code below.
-			quoteCharsSeen += bytes.Count(line, []byte{'"'})
+			// As per the COPY spec, any appearance of the QUOTE or ESCAPE
+			// characters in an actual value must be preceded by an ESCAPE
+			// character. This means that an escaped QUOTE also results in an even
+			// number of QUOTE characters.
+			quoteCharsSeen += bytes.Count(line, []byte{quote})
 		} else {
-			// Otherwise, we have to do a manual count of double quotes and
-			// ignore any escape characters preceding quotes for counting.
+			// Otherwise, we have to do a manual count of quotes and ignore any
+			// escape characters preceding quotes for counting.
 			// For example, if the escape character is '\', we should ignore
 			// the intermediate quotes in a string such as `"start"\"\"end"`.
 			skipNextChar := false
@@ -820,7 +973,7 @@ func (c *copyMachine) readCSVData(ctx context.Context, final bool) (brk bool, er
 					skipNextChar = false
 					continue
 				}
-				if ch == '"' {
+				if ch == quote {
 					quoteCharsSeen++
 				}
 				if rune(ch) == c.csvEscape {
@@ -870,6 +1023,48 @@ func (c *copyMachine) maybeIgnoreHiddenColumnsStr(in []csv.Record) []csv.Record
 	return ret
 }
 
+// csvIsNull returns whether the CSV field s of the i-th result column is NULL.
+// Unquoted fields matching the null string are NULL unless the column is named
+// by FORCE_NOT_NULL, and quoted ones are NULL only if it is named by FORCE_NULL.
+func (c *copyMachine) csvIsNull(i int, s csv.Record) bool {
+	if s.Val != c.null {
+		return false
+	}
+	if s.Quoted {
+		return c.csvForceNull != nil && c.csvForceNull[i]
+	}
+	return c.csvForceNotNull == nil || !c.csvForceNotNull[i]
+}
+
+// resolveCopyForceColumns returns which of the given columns are named by the
+// FORCE_QUOTE, FORCE_NOT_NULL or FORCE_NULL option opt, or nil if the option
+// wasn't specified.
+func resolveCopyForceColumns(
+	opt string, force *tree.CopyForceColumns, cols colinfo.ResultColumns,
+) ([]bool, error) {
+	if force == nil {
+		return nil, nil
+	}
+	ret := make([]bool, len(cols))
+	for i := range cols {
+		ret[i] = force.Contains(tree.Name(cols[i].Name))
+	}
+	for _, name := range force.Columns {
+		found := false
+		for i := range cols {
+			if cols[i].Name == string(name) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, pgerror.Newf(pgcode.InvalidColumnReference,
+				"%s column %q not referenced by COPY", opt, string(name))
+		}
+	}
+	return ret, nil
+}
+
 func (c *copyMachine) readCSVTuple(ctx context.Context, record []csv.Record) error {
 	if expected := len(c.resultColumns) + len(c.expectedHiddenColumnIdxs); expected != len(record) {
 		return pgerror.Newf(pgcode.BadCopyFileFormat,
@@ -879,9 +1074,7 @@ func (c *copyMachine) readCSVTuple(ctx context.Context, record []csv.Record) err
 	if c.vectorized {
 		vh := c.valueHandlers
 		for i, s := range record {
-			// NB: When we implement FORCE_NULL, then quoted values also are allowed
-			// to be treated as NULL.
-			if !s.Quoted && s.Val == c.null {
+			if c.csvIsNull(i, s) {
 				vh[i].Null()
 				continue
 			}
@@ -893,9 +1086,7 @@ func (c *copyMachine) readCSVTuple(ctx context.Context, record []csv.Record) err
 	} else {
 		datums := c.scratchRow
 		for i, s := range record {
-			// NB: When we implement FORCE_NULL, then quoted values also are allowed
-			// to be treated as NULL.
-			if !s.Quoted && s.Val == c.null {
+			if c.csvIsNull(i, s) {
 				datums[i] = tree.DNull
 				continue
 			}
@@ -932,7 +1123,12 @@ func (c *copyMachine) readBinaryData(ctx context.Context, final bool) (brk bool,
 			return false, err
 		}
 		c.buf = c.buf[n:]
-		return false, nil
+		// The header isn't a row, so move on to the first tuple if there is
+		// one.
+		if len(c.buf) == 0 {
+			return true, nil
+		}
+		return c.readBinaryData(ctx, final)
 	case binaryStateRead:
 		n, err := c.readBinaryTuple(ctx)
 		if err != nil {
@@ -945,6 +1141,11 @@ func (c *copyMachine) readBinaryData(ctx context.Context, final bool) (brk bool,
 			return false, errors.Wrapf(err, "read binary tuple")
 		}
 		c.buf = c.buf[n:]
+		// The trailer isn't a row either, any data following it is rejected the
+		// next time around.
+		if c.binaryState == binaryStateFoundTrailer {
+			return true, nil
+		}
 		return false, nil
 	case binaryStateFoundTrailer:
 		if !final {
@@ -957,15 +1158,15 @@ func (c *copyMachine) readBinaryData(ctx context.Context, final bool) (brk bool,
 	}
 }
 
+// readBinaryTuple reads a single tuple, or the trailer, from the buffer. If
+// the buffer doesn't hold the complete tuple, io.ErrUnexpectedEOF is returned
+// without adding anything to the current batch.
 func (c *copyMachine) readBinaryTuple(ctx context.Context) (bytesRead int, err error) {
-	var fieldCount int16
-	var fieldCountBytes [2]byte
-	n := copy(fieldCountBytes[:], c.buf[bytesRead:])
-	bytesRead += n
-	if n < len(fieldCountBytes) {
-		return bytesRead, io.ErrUnexpectedEOF
-	}
-	fieldCount = int16(binary.BigEndian.Uint16(fieldCountBytes[:]))
+	if len(c.buf) < 2 {
+		return 0, io.ErrUnexpectedEOF
+	}
+	fieldCount := int16(binary.BigEndian.Uint16(c.buf))
+	bytesRead += 2
 	if fieldCount == -1 {
 		c.binaryState = binaryStateFoundTrailer
 		return bytesRead, nil
@@ -974,62 +1175,125 @@ func (c *copyMachine) readBinaryTuple(ctx context.Context) (bytesRead int, err e
 		return bytesRead, pgerror.Newf(pgcode.BadCopyFileFormat,
 			"unexpected field count: %d", fieldCount)
 	}
-	datums := make(tree.Datums, fieldCount)
-	var byteCount int32
-	var byteCountBytes [4]byte
-	for i := range datums {
-		n := copy(byteCountBytes[:], c.buf[bytesRead:])
-		bytesRead += n
-		if n < len(byteCountBytes) {
+	if expected := len(c.resultColumns); expected != int(fieldCount) {
+		return bytesRead, pgerror.Newf(pgcode.BadCopyFileFormat,
+			"expected %d values, got %d", expected, fieldCount)
+	}
+	// Find all the fields of the tuple before decoding any of them so that an
+	// incomplete tuple doesn't leave a partially set row behind. NULL fields
+	// are recorded as nil slices.
+	fields := c.binaryFields[:0]
+	for i := 0; i < int(fieldCount); i++ {
+		if len(c.buf)-bytesRead < 4 {
 			return bytesRead, io.ErrUnexpectedEOF
 		}
-		byteCount = int32(binary.BigEndian.Uint32(byteCountBytes[:]))
+		byteCount := int32(binary.BigEndian.Uint32(c.buf[bytesRead:]))
+		bytesRead += 4
 		if byteCount == -1 {
-			datums[i] = tree.DNull
+			fields = append(fields, nil)
 			continue
 		}
-		data := make([]byte, byteCount)
-		n = copy(data, c.buf[bytesRead:])
-		bytesRead += n
-		if n < len(data) {
+		if byteCount < 0 {
+			return bytesRead, pgerror.Newf(pgcode.BadCopyFileFormat,
+				"invalid field size: %d", byteCount)
+		}
+		if len(c.buf)-bytesRead < int(byteCount) {
 			return bytesRead, io.ErrUnexpectedEOF
 		}
-		d, err := pgwirebase.DecodeDatum(
-			ctx,
-			c.parsingEvalCtx,
-			c.resultColumns[i].Typ,
-			pgwirebase.FormatBinary,
-			data,
-			c.p.datumAlloc,
-		)
-		if err != nil {
-			return bytesRead, pgerror.Wrapf(err, pgcode.BadCopyFileFormat,
-				"decode datum as %s: %s", c.resultColumns[i].Typ.SQLString(), data)
+		fields = append(fields, c.buf[bytesRead:bytesRead+int(byteCount):bytesRead+int(byteCount)])
+		bytesRead += int(byteCount)
+	}
+	c.binaryFields = fields
+
+	if c.vectorized {
+		for i, field := range fields {
+			d, err := c.decodeBinaryField(ctx, i, field)
+			if err != nil {
+				return bytesRead, err
+			}
+			if err := tree.SetValueHandlerDatum(c.resultColumns[i].Typ, d, c.valueHandlers[i]); err != nil {
+				return bytesRead, err
+			}
 		}
-		datums[i] = d
+		c.batch.SetLength(c.batch.Length() + 1)
+		return bytesRead, nil
 	}
-	_, err = c.rows.AddRow(ctx, datums)
-	if err != nil {
+	datums := c.scratchRow
+	for i, field := range fields {
+		if datums[i], err = c.decodeBinaryField(ctx, i, field); err != nil {
+			return bytesRead, err
+		}
+	}
+	if _, err := c.rows.AddRow(ctx, datums); err != nil {
 		return bytesRead, err
 	}
 	return bytesRead, nil
 }
 
+// decodeBinaryField decodes the binary representation of the i-th column's
+// value. A nil field is NULL.
+func (c *copyMachine) decodeBinaryField(
+	ctx context.Context, i int, field []byte,
+) (tree.Datum, error) {
+	if field == nil {
+		return tree.DNull, nil
+	}
+	d, err := pgwirebase.DecodeDatum(
+		ctx,
+		c.parsingEvalCtx,
+		c.resultColumns[i].Typ,
+		pgwirebase.FormatBinary,
+		field,
+		c.p.datumAlloc,
+	)
+	if err != nil {
+		return nil, pgerror.Wrapf(err, pgcode.BadCopyFileFormat,
+			"decode datum as %s: %s", c.resultColumns[i].Typ.SQLString(), field)
+	}
+	return d, nil
+}
+
 // This is the standard 11-byte binary signature with the flags and
-// header 32-bit integers appended since we only support the zero value
-// of them.
+// header extension length 32-bit integers appended. COPY TO writes it as is,
+// since it never sets any flags or header extension.
 var copyBinarySignature = [19]byte{'P', 'G', 'C', 'O', 'P', 'Y', '\n', '\377', '\r', '\n', '\000', '\x00', '\x00', '\x00', '\x00', '\x00', '\x00', '\x00', '\x00'}
 
+const (
+	// copyBinarySignatureLen is the length of the signature proper, without
+	// the flags and header extension length.
+	copyBinarySignatureLen = 11
+	// copyBinaryFlagOIDs is the header flag that indicates that each tuple
+	// includes its OID.
+	copyBinaryFlagOIDs = 1 << 16
+	// copyBinaryCriticalFlags are the header flags that a reader must reject
+	// if it doesn't recognize them.
+	copyBinaryCriticalFlags = 0xffff
+)
+
+// readBinarySignature reads the header of binary COPY data: the signature,
+// the flags field and the header extension, which is skipped.
 func (c *copyMachine) readBinarySignature() (int, error) {
-	var sig [19]byte
-	n := copy(sig[:], c.buf)
-	if n < len(sig) {
-		return n, io.ErrUnexpectedEOF
+	if len(c.buf) < len(copyBinarySignature) {
+		return 0, io.ErrUnexpectedEOF
 	}
-	if sig != copyBinarySignature {
-		return n, pgerror.New(pgcode.BadCopyFileFormat,
+	if !bytes.Equal(c.buf[:copyBinarySignatureLen], copyBinarySignature[:copyBinarySignatureLen]) {
+		return len(copyBinarySignature), pgerror.New(pgcode.BadCopyFileFormat,
 			"unrecognized binary copy signature")
 	}
+	flags := binary.BigEndian.Uint32(c.buf[copyBinarySignatureLen:])
+	if flags&copyBinaryFlagOIDs != 0 {
+		return len(copyBinarySignature), pgerror.New(pgcode.FeatureNotSupported,
+			"binary copy with OIDs is not supported")
+	}
+	if flags&copyBinaryCriticalFlags != 0 {
+		return len(copyBinarySignature), pgerror.New(pgcode.BadCopyFileFormat,
+			"unrecognized critical flags in binary copy header")
+	}
+	extensionLen := binary.BigEndian.Uint32(c.buf[copyBinarySignatureLen+4:])
+	n := len(copyBinarySignature) + int(extensionLen)
+	if len(c.buf) < n {
+		return 0, io.ErrUnexpectedEOF
+	}
 	c.binaryState = binaryStateRead
 	return n, nil
 }
@@ -1107,7 +1371,8 @@ func (p *planner) preparePlannerForCopy(
 // doneWithRows resets the buffered data (either the columnar batch or the row
 // container) for reuse. It also updates insertedRows accordingly.
 func (c *copyMachine) doneWithRows(ctx context.Context) error {
-	c.insertedRows += c.currentBatchSize()
+	c.insertedRows += c.currentBatchSize() - c.batchFilteredRows
+	c.batchFilteredRows = 0
 	if c.vectorized {
 		var realloc bool
 		if err := colexecerror.CatchVectorizedRuntimeError(func() {
@@ -1234,6 +1499,10 @@ func (c *copyMachine) insertRowsInternal(ctx context.Context, finalBatch bool) (
 		vc = &tree.ValuesClause{Rows: exprs}
 	}
 
+	if c.copyFromAST.Where != nil {
+		vc = c.filterRows(vc)
+	}
+
 	c.p.stmt = Statement{}
 	c.p.stmt.AST = &tree.Insert{
 		Table:   c.table,
@@ -1258,13 +1527,36 @@ func (c *copyMachine) insertRowsInternal(ctx context.Context, finalBatch bool) (
 		return err
 	}
 
-	if rows := res.RowsAffected(); rows != numRows {
+	rows := res.RowsAffected()
+	if c.copyFromAST.Where != nil {
+		// Rows that don't satisfy the WHERE clause are skipped.
+		c.batchFilteredRows = numRows - rows
+	} else if rows != numRows {
 		return errors.AssertionFailedf("COPY didn't insert all buffered rows and yet no error was reported. "+
 			"Inserted %d out of %d rows.", rows, numRows)
 	}
 	return nil
 }
 
+// filterRows wraps a batch of rows in a SELECT that applies the WHERE clause
+// of the COPY FROM statement to them.
+func (c *copyMachine) filterRows(vc tree.SelectStatement) tree.SelectStatement {
+	cols := make(tree.ColumnDefList, len(c.resultColumns))
+	for i := range c.resultColumns {
+		cols[i] = tree.ColumnDef{Name: tree.Name(c.resultColumns[i].Name)}
+	}
+	return &tree.SelectClause{
+		Exprs: tree.SelectExprs{tree.StarSelectExpr()},
+		From: tree.From{
+			Tables: tree.TableExprs{&tree.AliasedTableExpr{
+				Expr: &tree.Subquery{Select: &tree.ParenSelect{Select: &tree.Select{Select: vc}}},
+				As:   tree.AliasClause{Alias: "copy_from", Cols: cols},
+			}},
+		},
+		Where: c.copyFromAST.Where,
+	}
+}
+
 func (c *copyMachine) maybeIgnoreHiddenColumnsBytes(in [][]byte) [][]byte {
 	if len(c.expectedHiddenColumnIdxs) == 0 {
 		return in
@@ -14,27 +14,33 @@ import (
 	"bytes"
 	"context"
 	"io"
+	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/util/encoding/csv"
-	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 )
 
 // copyToTranslater translates datums into the appropriate format for CopyTo.
 type copyToTranslater interface {
-	translateRow(datums tree.Datums, rcs colinfo.ResultColumns) ([]byte, error)
+	translateRow(ctx context.Context, datums tree.Datums, rcs colinfo.ResultColumns) ([]byte, error)
 	// headerRow returns the header row bytes, if applicable, and a bool to
 	// determine whether one needs to be written.
 	headerRow(rcs colinfo.ResultColumns) ([]byte, bool, error)
+	// trailerRow returns the trailer bytes, if applicable, and a bool to
+	// determine whether they need to be written.
+	trailerRow() ([]byte, bool, error)
 }
 
 var _ copyToTranslater = (*textCopyToTranslater)(nil)
 var _ copyToTranslater = (*csvCopyToTranslater)(nil)
+var _ copyToTranslater = (*binaryCopyToTranslater)(nil)
 
 // textCopyToTranslater is the default text representation of COPY TO from postgres.
 type textCopyToTranslater struct {
@@ -44,7 +50,7 @@ type textCopyToTranslater struct {
 }
 
 func (t *textCopyToTranslater) translateRow(
-	datums tree.Datums, rcs colinfo.ResultColumns,
+	ctx context.Context, datums tree.Datums, rcs colinfo.ResultColumns,
 ) ([]byte, error) {
 	t.rowBuffer.Reset()
 	t.fmtCtx.Buffer.Reset()
@@ -70,20 +76,27 @@ func (t *textCopyToTranslater) headerRow(rcs colinfo.ResultColumns) ([]byte, boo
 	return nil, false, nil
 }
 
+func (t *textCopyToTranslater) trailerRow() ([]byte, bool, error) {
+	return nil, false, nil
+}
+
 // csvCopyToTranslater is the CSV representation of COPY TO from postgres.
 type csvCopyToTranslater struct {
 	copyOptions
 	b      bytes.Buffer
 	fmtCtx *tree.FmtCtx
 	w      *csv.Writer
+	// forceQuote is indexed by column and records the columns named by the
+	// FORCE_QUOTE option, whose non-NULL values are always quoted.
+	forceQuote []bool
 }
 
 func (c *csvCopyToTranslater) translateRow(
-	datums tree.Datums, rcs colinfo.ResultColumns,
+	ctx context.Context, datums tree.Datums, rcs colinfo.ResultColumns,
 ) ([]byte, error) {
 	c.b.Reset()
 	c.fmtCtx.Buffer.Reset()
-	for _, d := range datums {
+	for i, d := range datums {
 		if d == tree.DNull {
 			if err := c.w.WriteField(bytes.NewBufferString(c.null)); err != nil {
 				return nil, err
@@ -97,6 +110,10 @@ func (c *csvCopyToTranslater) translateRow(
 			if err := c.w.ForceEmptyField(); err != nil {
 				return nil, err
 			}
+		} else if c.forceQuote != nil && c.forceQuote[i] {
+			if err := c.w.WriteQuotedField(bytes.NewBuffer(c.fmtCtx.Buffer.Bytes())); err != nil {
+				return nil, err
+			}
 		} else {
 			if err := c.w.WriteField(bytes.NewBuffer(c.fmtCtx.Buffer.Bytes())); err != nil {
 				return nil, err
@@ -128,6 +145,40 @@ func (c *csvCopyToTranslater) headerRow(rcs colinfo.ResultColumns) ([]byte, bool
 	return c.b.Bytes(), true, nil
 }
 
+func (c *csvCopyToTranslater) trailerRow() ([]byte, bool, error) {
+	return nil, false, nil
+}
+
+// binaryCopyToTranslater is the binary representation of COPY TO from
+// postgres. Each field is encoded using the pgwire binary format of its type.
+type binaryCopyToTranslater struct {
+	res       CopyOutResult
+	loc       *time.Location
+	rowBuffer []byte
+}
+
+func (b *binaryCopyToTranslater) translateRow(
+	ctx context.Context, datums tree.Datums, rcs colinfo.ResultColumns,
+) ([]byte, error) {
+	var err error
+	b.rowBuffer, err = b.res.AppendCopyBinaryRow(ctx, b.rowBuffer[:0], datums, rcs, b.loc)
+	return b.rowBuffer, err
+}
+
+func (b *binaryCopyToTranslater) headerRow(rcs colinfo.ResultColumns) ([]byte, bool, error) {
+	// The header is the signature followed by the flags field and the header
+	// extension length, both of which are zero.
+	return copyBinarySignature[:], true, nil
+}
+
+// copyBinaryTrailer is the field count of -1 which terminates binary COPY
+// data.
+var copyBinaryTrailer = []byte{0xff, 0xff}
+
+func (b *binaryCopyToTranslater) trailerRow() ([]byte, bool, error) {
+	return copyBinaryTrailer, true, nil
+}
+
 func runCopyTo(
 	ctx context.Context, p *planner, txn *kv.Txn, cmd CopyOut, res CopyOutResult,
 ) (numOutputRows int, retErr error) {
@@ -135,23 +186,33 @@ func runCopyTo(
 	if err != nil {
 		return 0, err
 	}
+	if cmd.Stmt.Options.ForceNotNull != nil {
+		return 0, pgerror.Newf(pgcode.FeatureNotSupported, "FORCE_NOT_NULL cannot be used with COPY TO")
+	}
+	if cmd.Stmt.Options.ForceNull != nil {
+		return 0, pgerror.Newf(pgcode.FeatureNotSupported, "FORCE_NULL cannot be used with COPY TO")
+	}
 
 	wireFormat := pgwirebase.FormatText
 	var t copyToTranslater
+	var csvTranslater *csvCopyToTranslater
 	switch cmd.Stmt.Options.CopyFormat {
 	case tree.CopyFormatBinary:
-		// wireFormat = pgwirebase.FormatBinary
-		return 0, unimplemented.NewWithIssue(
-			97180,
-			"binary format for COPY TO not implemented",
-		)
+		wireFormat = pgwirebase.FormatBinary
+		t = &binaryCopyToTranslater{
+			res: res,
+			loc: p.EvalContext().GetLocation(),
+		}
 	case tree.CopyFormatCSV:
-		csvTranslater := &csvCopyToTranslater{
+		csvTranslater = &csvCopyToTranslater{
 			copyOptions: copyOptions,
 			fmtCtx:      p.EvalContext().FmtCtx(tree.FmtPgwireText),
 		}
 		csvTranslater.w = csv.NewWriter(&csvTranslater.b)
 		csvTranslater.w.Comma = rune(copyOptions.delimiter)
+		if copyOptions.csvQuote != 0 {
+			csvTranslater.w.Quote = copyOptions.csvQuote
+		}
 		if copyOptions.csvEscape != 0 {
 			csvTranslater.w.Escape = copyOptions.csvEscape
 		}
@@ -164,6 +225,43 @@ func runCopyTo(
 		t = textTranslater
 	}
 
+	// For COPY TO 'file', the data is written to the external storage file
+	// instead of being sent to the client.
+	var fileWriter io.WriteCloser
+	if cmd.Stmt.File != nil {
+		uri := cmd.Stmt.File.RawString()
+		if err := checkCopyExternalStoragePrivileges(ctx, p, uri); err != nil {
+			return 0, err
+		}
+		store, err := p.execCfg.DistSQLSrv.ExternalStorageFromURI(ctx, uri, p.User())
+		if err != nil {
+			return 0, err
+		}
+		defer store.Close()
+		writeCtx, cancelWrite := context.WithCancel(ctx)
+		defer cancelWrite()
+		if fileWriter, err = store.Writer(writeCtx, ""); err != nil {
+			return 0, err
+		}
+		defer func() {
+			if retErr != nil {
+				// Canceling the write before closing the writer makes sure that a
+				// partially written file isn't left behind.
+				cancelWrite()
+				_ = fileWriter.Close()
+				return
+			}
+			retErr = fileWriter.Close()
+		}()
+	}
+	sendCopyData := func(row []byte, isHeader bool) error {
+		if fileWriter != nil {
+			_, err := fileWriter.Write(row)
+			return err
+		}
+		return res.SendCopyData(ctx, row, isHeader)
+	}
+
 	var q string
 	if cmd.Stmt.Statement != nil {
 		q = cmd.Stmt.Statement.String()
@@ -202,9 +300,19 @@ func runCopyTo(
 		}
 	}()
 
+	if csvTranslater != nil {
+		if csvTranslater.forceQuote, err = resolveCopyForceColumns(
+			"FORCE_QUOTE", cmd.Stmt.Options.ForceQuote, it.Types(),
+		); err != nil {
+			return 0, err
+		}
+	}
+
 	// Send the message describing the columns to the client.
-	if err := res.SendCopyOut(ctx, it.Types(), wireFormat); err != nil {
-		return 0, err
+	if fileWriter == nil {
+		if err := res.SendCopyOut(ctx, it.Types(), wireFormat); err != nil {
+			return 0, err
+		}
 	}
 
 	if err := func() error {
@@ -213,7 +321,7 @@ func runCopyTo(
 		if row, ok, err := t.headerRow(it.Types()); err != nil {
 			return err
 		} else if ok {
-			if err := res.SendCopyData(ctx, row, true /* isHeader */); err != nil {
+			if err := sendCopyData(row, true /* isHeader */); err != nil {
 				return err
 			}
 		}
@@ -227,11 +335,20 @@ func runCopyTo(
 				break
 			}
 			numOutputRows++
-			row, err := t.translateRow(it.Cur(), it.Types())
+			row, err := t.translateRow(ctx, it.Cur(), it.Types())
 			if err != nil {
 				return err
 			}
-			if err := res.SendCopyData(ctx, row, false /* isHeader */); err != nil {
+			if err := sendCopyData(row, false /* isHeader */); err != nil {
+				return err
+			}
+		}
+
+		if row, ok, err := t.trailerRow(); err != nil {
+			return err
+		} else if ok {
+			// The trailer isn't a row, so it is sent like the header is.
+			if err := sendCopyData(row, true /* isHeader */); err != nil {
 				return err
 			}
 		}
@@ -239,6 +356,10 @@ func runCopyTo(
 	}(); err != nil {
 		return 0, err
 	}
+	if fileWriter != nil {
+		res.SetRowsAffected(ctx, numOutputRows)
+		return numOutputRows, nil
+	}
 	return numOutputRows, res.SendCopyDone(ctx)
 }
 
@@ -48,6 +48,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/server/status/statuspb"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/advisorylock"
 	"github.com/cockroachdb/cockroach/pkg/sql/appstatspb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catalogkeys"
@@ -3540,6 +3541,58 @@ func writeCreateTypeDescRow(
 			tree.DNull,                           // enum_members
 		)
 	}
+	if d := typeDesc.AsDomainTypeDescriptor(); d != nil {
+		name, err := tree.NewUnresolvedObjectName(2, [3]string{d.GetName(), sc.GetName()}, 0)
+		if err != nil {
+			return false, err
+		}
+		node := &tree.CreateDomain{
+			TypeName: name,
+			Type:     d.BaseType(),
+		}
+		if d.NotNull() {
+			node.Constraints = append(node.Constraints, tree.DomainConstraint{NotNull: true})
+		}
+		for i := 0; i < d.NumChecks(); i++ {
+			expr, err := parser.ParseExpr(d.GetCheckExpr(i))
+			if err != nil {
+				return false, err
+			}
+			node.Constraints = append(node.Constraints, tree.DomainConstraint{
+				Name:  tree.Name(d.GetCheckName(i)),
+				Check: expr,
+			})
+		}
+		return true, addRow(
+			tree.NewDInt(tree.DInt(db.GetID())),  // database_id
+			tree.NewDString(db.GetName()),        // database_name
+			tree.NewDString(sc.GetName()),        // schema_name
+			tree.NewDInt(tree.DInt(d.GetID())),   // descriptor_id
+			tree.NewDString(d.GetName()),         // descriptor_name
+			tree.NewDString(tree.AsString(node)), // create_statement
+			tree.DNull,                           // enum_members
+		)
+	}
+	if r := typeDesc.AsRangeTypeDescriptor(); r != nil {
+		name, err := tree.NewUnresolvedObjectName(2, [3]string{r.GetName(), sc.GetName()}, 0)
+		if err != nil {
+			return false, err
+		}
+		node := &tree.CreateType{
+			Variety:      tree.Range,
+			TypeName:     name,
+			RangeSubtype: r.RangeSubtype(),
+		}
+		return true, addRow(
+			tree.NewDInt(tree.DInt(db.GetID())),  // database_id
+			tree.NewDString(db.GetName()),        // database_name
+			tree.NewDString(sc.GetName()),        // schema_name
+			tree.NewDInt(tree.DInt(r.GetID())),   // descriptor_id
+			tree.NewDString(r.GetName()),         // descriptor_name
+			tree.NewDString(tree.AsString(node)), // create_statement
+			tree.DNull,                           // enum_members
+		)
+	}
 	return false, errors.AssertionFailedf("unknown type descriptor kind %s", typeDesc.GetKind())
 }
 
@@ -3679,6 +3732,10 @@ func createRoutinePopulate(
 				// otherwise.
 				continue
 			}
+			if fnDesc.IsAggregate() {
+				// Aggregates cannot be represented as CREATE FUNCTION statements.
+				continue
+			}
 			treeNode, err := fnDesc.ToCreateExpr()
 			treeNode.Name.ObjectNamePrefix = tree.ObjectNamePrefix{
 				ExplicitSchema: true,
@@ -7969,6 +8026,16 @@ func genClusterLocksGenerator(
 					continue
 				}
 				spansToQuery = append(spansToQuery, desc.TableSpan(p.execCfg.Codec))
+			case catalog.DatabaseDescriptor:
+				// The advisory locks of a database are held on keys in its own
+				// keyspace, and are reported without a table.
+				if filters.tableName != nil || filters.tableID != nil {
+					continue
+				}
+				if filters.databaseName != nil && *filters.databaseName != desc.GetName() {
+					continue
+				}
+				spansToQuery = append(spansToQuery, advisorylock.DatabaseSpan(p.execCfg.Codec, desc.GetID()))
 			}
 		}
 
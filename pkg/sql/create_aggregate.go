@@ -0,0 +1,523 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catprivilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemadesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/errors"
+)
+
+type createAggregateNode struct {
+	n      *tree.CreateAggregate
+	dbDesc catalog.DatabaseDescriptor
+	scDesc catalog.SchemaDescriptor
+}
+
+// Use to satisfy the linter.
+var _ planNode = &createAggregateNode{n: nil}
+
+// CreateAggregate creates a user-defined aggregate function.
+// Privileges: CREATE on the schema, and EXECUTE on the support functions.
+func (p *planner) CreateAggregate(ctx context.Context, n *tree.CreateAggregate) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE AGGREGATE",
+	); err != nil {
+		return nil, err
+	}
+
+	db, sc, prefix, err := p.ResolveTargetObject(ctx, n.Name.ToUnresolvedObjectName())
+	if err != nil {
+		return nil, err
+	}
+	n.Name.ObjectNamePrefix = prefix
+	return &createAggregateNode{n: n, dbDesc: db, scDesc: sc}, nil
+}
+
+// aggregateSpec is the validated form of the options of a CREATE AGGREGATE
+// statement.
+type aggregateSpec struct {
+	params     []descpb.FunctionDescriptor_Parameter
+	argTypes   []*types.T
+	stateType  *types.T
+	returnType *types.T
+	stateFunc  catalog.FunctionDescriptor
+	finalFunc  catalog.FunctionDescriptor
+	combFunc   catalog.FunctionDescriptor
+	initCond   *string
+}
+
+func (n *createAggregateNode) startExec(params runParams) error {
+	if err := params.p.canCreateOnSchema(
+		params.ctx, n.scDesc.GetID(), n.dbDesc.GetID(), params.p.User(), skipCheckPublicSchema,
+	); err != nil {
+		return err
+	}
+	if n.scDesc.SchemaKind() == catalog.SchemaTemporary {
+		return unimplemented.NewWithIssue(104687, "cannot create UDFs under a temporary schema")
+	}
+
+	var spec aggregateSpec
+	var retErr error
+	params.p.runWithOptions(resolveFlags{contextDatabaseID: n.dbDesc.GetID()}, func() {
+		spec, retErr = n.makeAggregateSpec(params)
+	})
+	if retErr != nil {
+		return retErr
+	}
+
+	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("aggregate"))
+
+	mutScDesc, err := params.p.descCollection.MutableByName(params.p.Txn()).Schema(
+		params.ctx, n.dbDesc, n.scDesc.GetName(),
+	)
+	if err != nil {
+		return err
+	}
+
+	routineObj := tree.RoutineObj{
+		FuncName: n.n.Name,
+		Params:   n.n.Params,
+	}
+	existing, err := params.p.matchRoutine(
+		params.ctx, &routineObj, false, /* required */
+		tree.UDFRoutine|tree.ProcedureRoutine, false, /* inDropContext */
+	)
+	if err != nil {
+		return err
+	}
+
+	var aggDesc *funcdesc.Mutable
+	if existing != nil {
+		if !n.n.Replace {
+			return pgerror.Newf(
+				pgcode.DuplicateFunction,
+				"function %q already exists with same argument types",
+				n.n.Name.Object(),
+			)
+		}
+		aggDesc, err = params.p.checkPrivilegesForDropFunction(
+			params.ctx, funcdesc.UserDefinedFunctionOIDToID(existing.Oid),
+		)
+		if err != nil {
+			return err
+		}
+		if err := n.replaceAggregate(params, aggDesc, &spec); err != nil {
+			return err
+		}
+	} else {
+		aggDesc, err = n.createNewAggregate(params, mutScDesc, &spec)
+		if err != nil {
+			return err
+		}
+	}
+
+	fnName := tree.MakeQualifiedRoutineName(n.dbDesc.GetName(), n.scDesc.GetName(), n.n.Name.String())
+	return params.p.logEvent(params.ctx, aggDesc.GetID(), &eventpb.CreateFunction{
+		FunctionName: fnName.FQString(),
+		IsReplace:    existing != nil,
+	})
+}
+
+// makeAggregateSpec validates the options of the CREATE AGGREGATE statement
+// and resolves its support functions.
+func (n *createAggregateNode) makeAggregateSpec(params runParams) (aggregateSpec, error) {
+	var spec aggregateSpec
+	var stateFuncName, finalFuncName, combFuncName *tree.RoutineName
+	var stateTypeRef tree.ResolvableTypeReference
+	conflictingErr := func(opt tree.AggregateOption) error {
+		return errors.Wrapf(tree.ErrConflictingRoutineOption, "%s", tree.AsString(opt))
+	}
+	for _, option := range n.n.Options {
+		switch t := option.(type) {
+		case *tree.AggregateStateFunc:
+			if stateFuncName != nil {
+				return spec, conflictingErr(option)
+			}
+			stateFuncName = &t.Name
+		case *tree.AggregateStateType:
+			if stateTypeRef != nil {
+				return spec, conflictingErr(option)
+			}
+			stateTypeRef = t.Type
+		case *tree.AggregateFinalFunc:
+			if finalFuncName != nil {
+				return spec, conflictingErr(option)
+			}
+			finalFuncName = &t.Name
+		case *tree.AggregateCombineFunc:
+			if combFuncName != nil {
+				return spec, conflictingErr(option)
+			}
+			combFuncName = &t.Name
+		case tree.AggregateInitCond:
+			if spec.initCond != nil {
+				return spec, conflictingErr(option)
+			}
+			initCond := string(t)
+			spec.initCond = &initCond
+		default:
+			return spec, pgerror.Newf(pgcode.InvalidParameterValue,
+				"unknown aggregate option: %s", tree.AsString(option))
+		}
+	}
+	if stateTypeRef == nil {
+		return spec, pgerror.New(pgcode.InvalidFunctionDefinition, "aggregate stype must be specified")
+	}
+	if stateFuncName == nil {
+		return spec, pgerror.New(pgcode.InvalidFunctionDefinition, "aggregate sfunc must be specified")
+	}
+
+	// Resolve the arguments and the state type.
+	var err error
+	spec.params = make([]descpb.FunctionDescriptor_Parameter, len(n.n.Params))
+	for i, param := range n.n.Params {
+		if param.Class != tree.RoutineParamDefault && param.Class != tree.RoutineParamIn {
+			return spec, pgerror.New(pgcode.InvalidFunctionDefinition,
+				"aggregates can only have input arguments")
+		}
+		spec.params[i], err = makeFunctionParam(params.ctx, params.p.SemaCtx(), param, params.p)
+		if err != nil {
+			return spec, err
+		}
+		spec.argTypes = append(spec.argTypes, spec.params[i].Type)
+	}
+	if len(spec.argTypes) == 0 {
+		return spec, unimplemented.New("zero-argument aggregates",
+			"aggregates without arguments are not supported")
+	}
+	spec.stateType, err = tree.ResolveType(params.ctx, stateTypeRef, params.p)
+	if err != nil {
+		return spec, err
+	}
+	for _, typ := range append([]*types.T{spec.stateType}, spec.argTypes...) {
+		if typ.IsPolymorphicType() {
+			return spec, unimplemented.New("polymorphic aggregates",
+				"aggregates with polymorphic argument or state types are not supported")
+		}
+	}
+	if spec.stateType.Family() == types.VoidFamily {
+		return spec, pgerror.New(pgcode.InvalidFunctionDefinition,
+			"aggregate transition data type cannot be void")
+	}
+
+	// The state transition function takes the state followed by the
+	// aggregate arguments, and returns the new state.
+	spec.stateFunc, err = n.resolveSupportFunc(
+		params, stateFuncName, append([]*types.T{spec.stateType}, spec.argTypes...),
+	)
+	if err != nil {
+		return spec, err
+	}
+	if retType := spec.stateFunc.GetReturnType().Type; !retType.Equivalent(spec.stateType) {
+		return spec, pgerror.Newf(pgcode.DatatypeMismatch,
+			"return type of transition function %s is not %s",
+			spec.stateFunc.GetName(), spec.stateType.SQLString())
+	}
+	// A strict state transition function is not called until the state is
+	// non-NULL. Without an initial condition the state is initialized from the
+	// first argument, which must therefore be of the state type.
+	if spec.initCond == nil && spec.stateFunc.GetNullInputBehavior() != catpb.Function_CALLED_ON_NULL_INPUT {
+		if len(spec.argTypes) != 1 || !spec.argTypes[0].Equivalent(spec.stateType) {
+			return spec, pgerror.New(pgcode.InvalidFunctionDefinition,
+				"must not omit initial value when transition function is strict "+
+					"and transition type is not compatible with input type")
+		}
+	}
+
+	// The final function takes the final state and returns the result of the
+	// aggregate. Without a final function the final state is the result.
+	spec.returnType = spec.stateType
+	if finalFuncName != nil {
+		spec.finalFunc, err = n.resolveSupportFunc(params, finalFuncName, []*types.T{spec.stateType})
+		if err != nil {
+			return spec, err
+		}
+		spec.returnType = spec.finalFunc.GetReturnType().Type
+	}
+
+	// The combine function merges two partial states into one.
+	if combFuncName != nil {
+		spec.combFunc, err = n.resolveSupportFunc(
+			params, combFuncName, []*types.T{spec.stateType, spec.stateType},
+		)
+		if err != nil {
+			return spec, err
+		}
+		if retType := spec.combFunc.GetReturnType().Type; !retType.Equivalent(spec.stateType) {
+			return spec, pgerror.Newf(pgcode.InvalidFunctionDefinition,
+				"return type of combine function %s is not %s",
+				spec.combFunc.GetName(), spec.stateType.SQLString())
+		}
+	}
+
+	if spec.initCond != nil {
+		if _, _, err := tree.ParseAndRequireString(
+			spec.stateType, *spec.initCond, params.EvalContext(),
+		); err != nil {
+			return spec, errors.Wrapf(err, "invalid initial condition for aggregate %s",
+				n.n.Name.Object())
+		}
+	}
+	return spec, nil
+}
+
+// resolveSupportFunc resolves one of the support functions of an aggregate,
+// which must be a user-defined function with exactly the given argument types.
+func (n *createAggregateNode) resolveSupportFunc(
+	params runParams, name *tree.RoutineName, argTypes []*types.T,
+) (catalog.FunctionDescriptor, error) {
+	routineObj := tree.RoutineObj{
+		FuncName: *name,
+		Params:   make(tree.RoutineParams, len(argTypes)),
+	}
+	for i, typ := range argTypes {
+		routineObj.Params[i] = tree.RoutineParam{Type: typ}
+	}
+	path := params.p.CurrentSearchPath()
+	unresolvedName := name.ToUnresolvedObjectName().ToUnresolvedName()
+	fnDef, err := params.p.ResolveFunction(
+		params.ctx, tree.MakeUnresolvedFunctionName(unresolvedName), &path,
+	)
+	if err != nil {
+		return nil, err
+	}
+	ol, err := fnDef.MatchOverload(
+		params.ctx, params.p, &routineObj, &path, tree.BuiltinRoutine|tree.UDFRoutine,
+		false /* inDropContext */, false, /* tryDefaultExprs */
+	)
+	if err != nil {
+		return nil, err
+	}
+	if ol.Type == tree.BuiltinRoutine {
+		return nil, unimplemented.Newf("aggregate builtin support function",
+			"builtin function %s cannot be used by a user-defined aggregate", fnDef.Name)
+	}
+	fnDesc, err := params.p.Descriptors().ByIDWithLeased(params.p.Txn()).Get().Function(
+		params.ctx, funcdesc.UserDefinedFunctionOIDToID(ol.Oid),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if fnDesc.IsAggregate() {
+		return nil, pgerror.Newf(pgcode.WrongObjectType,
+			"%s is an aggregate function", fnDesc.GetName())
+	}
+	if fnDesc.GetReturnType().ReturnSet {
+		return nil, pgerror.Newf(pgcode.InvalidFunctionDefinition,
+			"function %s returns a set", fnDesc.GetName())
+	}
+	if dbID := fnDesc.GetParentID(); dbID != n.dbDesc.GetID() && dbID != keys.SystemDatabaseID {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"dependent function %s cannot be from another database", fnDesc.GetName())
+	}
+	if err := params.p.CheckPrivilege(params.ctx, fnDesc, privilege.EXECUTE); err != nil {
+		return nil, err
+	}
+	return fnDesc, nil
+}
+
+func (n *createAggregateNode) createNewAggregate(
+	params runParams, scDesc *schemadesc.Mutable, spec *aggregateSpec,
+) (*funcdesc.Mutable, error) {
+	id, err := params.EvalContext().DescIDGenerator.GenerateUniqueDescID(params.ctx)
+	if err != nil {
+		return nil, err
+	}
+	privileges, err := catprivilege.CreatePrivilegesFromDefaultPrivileges(
+		n.dbDesc.GetDefaultPrivilegeDescriptor(),
+		n.scDesc.GetDefaultPrivilegeDescriptor(),
+		n.dbDesc.GetID(),
+		params.SessionData().User(),
+		privilege.Routines,
+	)
+	if err != nil {
+		return nil, err
+	}
+	newDesc := funcdesc.NewMutableFunctionDescriptor(
+		id,
+		n.dbDesc.GetID(),
+		n.scDesc.GetID(),
+		string(n.n.Name.ObjectName),
+		spec.params,
+		spec.returnType,
+		false, /* returnSet */
+		false, /* isProcedure */
+		privileges,
+	)
+	aggDesc := &newDesc
+	if err := n.setAggregate(params, aggDesc, spec); err != nil {
+		return nil, err
+	}
+	if err := params.p.createDescriptor(
+		params.ctx, aggDesc, tree.AsStringWithFQNames(&n.n.Name, params.Ann()),
+	); err != nil {
+		return nil, err
+	}
+
+	scDesc.AddFunction(
+		aggDesc.GetName(),
+		descpb.SchemaDescriptor_FunctionSignature{
+			ID:          aggDesc.GetID(),
+			ArgTypes:    spec.argTypes,
+			ReturnType:  spec.returnType,
+			IsAggregate: true,
+		},
+	)
+	if err := params.p.writeSchemaDescChange(params.ctx, scDesc, "Create Aggregate"); err != nil {
+		return nil, err
+	}
+	return aggDesc, nil
+}
+
+func (n *createAggregateNode) replaceAggregate(
+	params runParams, aggDesc *funcdesc.Mutable, spec *aggregateSpec,
+) error {
+	if !aggDesc.IsAggregate() {
+		formatStr := "%q is a function"
+		if aggDesc.IsProcedure() {
+			formatStr = "%q is a procedure"
+		}
+		return errors.WithDetailf(
+			pgerror.Newf(pgcode.WrongObjectType, "cannot change routine kind"),
+			formatStr,
+			aggDesc.Name,
+		)
+	}
+	if !spec.returnType.Equivalent(aggDesc.ReturnType.Type) {
+		return pgerror.Newf(pgcode.InvalidFunctionDefinition,
+			"cannot change return type of existing function")
+	}
+
+	// Remove the existing references before adding the new ones.
+	for _, id := range aggDesc.DependsOnFunctions {
+		backRefMutable, err := params.p.Descriptors().MutableByID(params.p.txn).Function(params.ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := backRefMutable.RemoveFunctionReference(aggDesc.ID); err != nil {
+			return err
+		}
+		if err := params.p.writeFuncSchemaChange(params.ctx, backRefMutable); err != nil {
+			return err
+		}
+	}
+	jobDesc := fmt.Sprintf("updating type back reference %d for aggregate %d", aggDesc.DependsOnTypes, aggDesc.ID)
+	if err := params.p.removeTypeBackReferences(params.ctx, aggDesc.DependsOnTypes, aggDesc.ID, jobDesc); err != nil {
+		return err
+	}
+
+	aggDesc.Params = spec.params
+	if err := n.setAggregate(params, aggDesc, spec); err != nil {
+		return err
+	}
+	return params.p.writeFuncSchemaChange(params.ctx, aggDesc)
+}
+
+// setAggregate populates the aggregate-specific fields of the descriptor and
+// adds the references to its support functions and types.
+func (n *createAggregateNode) setAggregate(
+	params runParams, aggDesc *funcdesc.Mutable, spec *aggregateSpec,
+) error {
+	aggDesc.Aggregate = &descpb.FunctionDescriptor_Aggregate{
+		StateFuncID: spec.stateFunc.GetID(),
+		StateType:   spec.stateType,
+		InitCond:    spec.initCond,
+	}
+	supportFuncs := []catalog.FunctionDescriptor{spec.stateFunc}
+	if spec.finalFunc != nil {
+		aggDesc.Aggregate.FinalFuncID = spec.finalFunc.GetID()
+		supportFuncs = append(supportFuncs, spec.finalFunc)
+	}
+	if spec.combFunc != nil {
+		aggDesc.Aggregate.CombineFuncID = spec.combFunc.GetID()
+		supportFuncs = append(supportFuncs, spec.combFunc)
+	}
+
+	// The aggregate is as volatile as the most volatile of its support
+	// functions.
+	vol := catpb.Function_IMMUTABLE
+	for _, fn := range supportFuncs {
+		switch fn.GetVolatility() {
+		case catpb.Function_VOLATILE:
+			vol = catpb.Function_VOLATILE
+		case catpb.Function_STABLE:
+			if vol == catpb.Function_IMMUTABLE {
+				vol = catpb.Function_STABLE
+			}
+		}
+	}
+	aggDesc.SetVolatility(vol)
+	aggDesc.SetLeakProof(false)
+	aggDesc.SetNullInputBehavior(catpb.Function_CALLED_ON_NULL_INPUT)
+
+	var funcDeps catalog.DescriptorIDSet
+	for _, fn := range supportFuncs {
+		funcDeps.Add(fn.GetID())
+	}
+	aggDesc.DependsOnFunctions = funcDeps.Ordered()
+	for _, id := range aggDesc.DependsOnFunctions {
+		backRefDesc, err := params.p.Descriptors().MutableByID(params.p.Txn()).Function(params.ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := backRefDesc.AddFunctionReference(aggDesc.ID); err != nil {
+			return err
+		}
+		if err := params.p.writeFuncSchemaChange(params.ctx, backRefDesc); err != nil {
+			return err
+		}
+	}
+
+	var typeDeps catalog.DescriptorIDSet
+	for _, typ := range append([]*types.T{spec.stateType, spec.returnType}, spec.argTypes...) {
+		typeDeps = typeDeps.Union(typedesc.GetTypeDescriptorClosure(typ))
+	}
+	aggDesc.DependsOnTypes = typeDeps.Ordered()
+	for _, id := range aggDesc.DependsOnTypes {
+		if isTable, err := params.p.descIsTable(params.ctx, id); err != nil {
+			return err
+		} else if isTable {
+			return unimplemented.New("aggregate implicit record type",
+				"aggregates cannot use the implicit record type of a table")
+		}
+		jobDesc := fmt.Sprintf("updating type back reference %d for aggregate %d", id, aggDesc.ID)
+		if err := params.p.addTypeBackReference(params.ctx, id, aggDesc.ID, jobDesc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *createAggregateNode) Next(params runParams) (bool, error) { return false, nil }
+func (n *createAggregateNode) Values() tree.Datums                 { return tree.Datums{} }
+func (n *createAggregateNode) Close(ctx context.Context)           {}
+func (n *createAggregateNode) ReadingOwnWrites()                   {}
@@ -0,0 +1,208 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catprivilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+)
+
+type createDomainNode struct {
+	n        *tree.CreateDomain
+	typeName *tree.TypeName
+	dbDesc   catalog.DatabaseDescriptor
+}
+
+// Use to satisfy the linter.
+var _ planNode = &createDomainNode{n: nil}
+
+// CreateDomain creates a domain type.
+// Privileges: CREATE on the database and schema.
+func (p *planner) CreateDomain(ctx context.Context, n *tree.CreateDomain) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE DOMAIN",
+	); err != nil {
+		return nil, err
+	}
+
+	// Resolve the desired new type name.
+	typeName, db, err := resolveNewTypeName(ctx, p, n.TypeName)
+	if err != nil {
+		return nil, err
+	}
+	n.TypeName.SetAnnotation(&p.semaCtx.Annotations, typeName)
+	return &createDomainNode{
+		n:        n,
+		typeName: typeName,
+		dbDesc:   db,
+	}, nil
+}
+
+func (n *createDomainNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("domain"))
+
+	// Generate a stable ID for the new type.
+	id, err := params.EvalContext().DescIDGenerator.GenerateUniqueDescID(params.ctx)
+	if err != nil {
+		return err
+	}
+	schema, err := getCreateTypeParams(params.ctx, params.p, n.typeName, n.dbDesc)
+	if err != nil {
+		return err
+	}
+	typeDesc, err := createDomainTypeDesc(params, id, n.n, n.dbDesc, schema, n.typeName)
+	if err != nil {
+		return err
+	}
+	return params.p.finishCreateType(
+		params.ctx, params.EvalContext(), n.typeName, typeDesc, n.dbDesc, schema,
+	)
+}
+
+// createDomainTypeDesc creates a new domain type descriptor.
+func createDomainTypeDesc(
+	params runParams,
+	id descpb.ID,
+	n *tree.CreateDomain,
+	dbDesc catalog.DatabaseDescriptor,
+	schema catalog.SchemaDescriptor,
+	typeName *tree.TypeName,
+) (*typedesc.Mutable, error) {
+	baseType, err := tree.ResolveType(params.ctx, n.Type, params.p.semaCtx.TypeResolver)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateDomainBaseType(params.ctx, params.p, baseType); err != nil {
+		return nil, err
+	}
+
+	domain := &descpb.TypeDescriptor_Domain{BaseType: baseType}
+	var sawNull bool
+	for i := range n.Constraints {
+		c := &n.Constraints[i]
+		switch {
+		case c.NotNull:
+			domain.NotNull = true
+		case c.Null:
+			sawNull = true
+		default:
+			check, err := makeDomainCheck(params.ctx, params.p, typeName.Type(), domain, c)
+			if err != nil {
+				return nil, err
+			}
+			domain.Checks = append(domain.Checks, check)
+		}
+		if domain.NotNull && sawNull {
+			return nil, pgerror.New(pgcode.Syntax, "conflicting NULL/NOT NULL constraints")
+		}
+	}
+
+	privs, err := catprivilege.CreatePrivilegesFromDefaultPrivileges(
+		dbDesc.GetDefaultPrivilegeDescriptor(),
+		schema.GetDefaultPrivilegeDescriptor(),
+		dbDesc.GetID(),
+		params.SessionData().User(),
+		privilege.Types,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return typedesc.NewBuilder(&descpb.TypeDescriptor{
+		Name:           typeName.Type(),
+		ID:             id,
+		ParentID:       dbDesc.GetID(),
+		ParentSchemaID: schema.GetID(),
+		Kind:           descpb.TypeDescriptor_DOMAIN,
+		Domain:         domain,
+		Version:        1,
+		Privileges:     privs,
+	}).BuildCreatedMutableType(), nil
+}
+
+// validateDomainBaseType returns an error if the given type cannot be used as
+// the base type of a domain.
+func validateDomainBaseType(ctx context.Context, p *planner, typ *types.T) error {
+	if err := tree.CheckUnsupportedType(ctx, &p.semaCtx, typ); err != nil {
+		return err
+	}
+	switch typ.Family() {
+	case types.UnknownFamily, types.AnyFamily, types.VoidFamily, types.TriggerFamily:
+		return pgerror.Newf(pgcode.DatatypeMismatch,
+			"%q is not a valid base type for a domain", typ.SQLString())
+	case types.ArrayFamily:
+		return unimplemented.NewWithIssue(27796, "domains over array types are not yet supported")
+	case types.TupleFamily:
+		return unimplemented.NewWithIssue(27796, "domains over composite types are not yet supported")
+	}
+	if typ.UserDefined() {
+		return unimplemented.NewWithIssue(27796,
+			"domains over user-defined types are not yet supported")
+	}
+	return nil
+}
+
+// makeDomainCheck validates the given CHECK constraint of a domain and returns
+// its descriptor representation. If the constraint is unnamed, a name is
+// generated following the Postgres convention of <domain>_check, with a
+// numeric suffix added to avoid collisions with the existing constraints.
+func makeDomainCheck(
+	ctx context.Context,
+	p *planner,
+	domainName string,
+	domain *descpb.TypeDescriptor_Domain,
+	c *tree.DomainConstraint,
+) (descpb.TypeDescriptor_Domain_Check, error) {
+	inUse := func(name string) bool {
+		for i := range domain.Checks {
+			if domain.Checks[i].Name == name {
+				return true
+			}
+		}
+		return false
+	}
+	name := string(c.Name)
+	if name == "" {
+		name = domainName + "_check"
+		for i := 1; inUse(name); i++ {
+			name = fmt.Sprintf("%s_check%d", domainName, i)
+		}
+	} else if inUse(name) {
+		return descpb.TypeDescriptor_Domain_Check{}, pgerror.Newf(pgcode.DuplicateObject,
+			"constraint %q for domain %q already exists", name, domainName)
+	}
+	expr, err := schemaexpr.ValidateDomainCheckExpr(ctx, c.Check, domain.BaseType, &p.semaCtx)
+	if err != nil {
+		return descpb.TypeDescriptor_Domain_Check{}, err
+	}
+	return descpb.TypeDescriptor_Domain_Check{Name: name, Expr: expr}, nil
+}
+
+func (n *createDomainNode) Next(params runParams) (bool, error) { return false, nil }
+func (n *createDomainNode) Values() tree.Datums                 { return tree.Datums{} }
+func (n *createDomainNode) Close(ctx context.Context)           {}
+func (n *createDomainNode) ReadingOwnWrites()                   {}
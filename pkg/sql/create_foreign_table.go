@@ -0,0 +1,320 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/cloud/externalconn"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/syntheticprivilege"
+	"github.com/cockroachdb/cockroach/pkg/util"
+	"github.com/cockroachdb/errors"
+)
+
+// cloudStorageWrapper is the name of the only supported foreign-data wrapper.
+// Its servers are External Connections to an ExternalStorage URI.
+const cloudStorageWrapper = "cloud_storage"
+
+// The options that can be given to CREATE SERVER and CREATE FOREIGN TABLE.
+const (
+	serverOptionURI = "uri"
+
+	foreignTableOptionLocation    = "location"
+	foreignTableOptionFormat      = "format"
+	foreignTableOptionDelimiter   = "delimiter"
+	foreignTableOptionHeader      = "header"
+	foreignTableOptionNull        = "null"
+	foreignTableOptionComment     = "comment"
+	foreignTableOptionCompression = "compression"
+)
+
+// CreateServer creates a foreign server. A server is stored as an External
+// Connection, so a server can be used wherever an External Connection can,
+// and vice versa.
+// Privileges: EXTERNALCONNECTION system privilege.
+func (p *planner) CreateServer(ctx context.Context, n *tree.CreateServer) (planNode, error) {
+	if n.Wrapper != cloudStorageWrapper {
+		return nil, pgerror.Newf(pgcode.UndefinedObject,
+			"foreign-data wrapper %q does not exist", n.Wrapper)
+	}
+	var uri tree.Expr
+	for _, opt := range n.Options {
+		if opt.Key != serverOptionURI {
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+				"invalid option %q for server", opt.Key)
+		}
+		if uri != nil {
+			return nil, pgerror.Newf(pgcode.Syntax, "option %q provided more than once", opt.Key)
+		}
+		uri = opt.Value
+	}
+	if uri == nil {
+		return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+			"option %q is required for servers of foreign-data wrapper %q", serverOptionURI, n.Wrapper)
+	}
+	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("server"))
+	return &createExternalConnectionNode{n: &tree.CreateExternalConnection{
+		ConnectionLabelSpec: tree.LabelSpec{
+			IfNotExists: n.IfNotExists,
+			Label:       tree.NewStrVal(string(n.Name)),
+		},
+		As: uri,
+	}}, nil
+}
+
+type dropServerNode struct {
+	n *tree.DropServer
+}
+
+// DropServer drops foreign servers. Foreign tables that read through a
+// dropped server are not dropped, and fail to be scanned until a server with
+// the same name is created again.
+// Privileges: DROP on the External Connection.
+func (p *planner) DropServer(_ context.Context, n *tree.DropServer) (planNode, error) {
+	return &dropServerNode{n: n}, nil
+}
+
+func (n *dropServerNode) startExec(params runParams) error {
+	for _, name := range n.n.Names {
+		if _, err := externalconn.LoadExternalConnection(
+			params.ctx, string(name), params.p.InternalSQLTxn(),
+		); err != nil {
+			if !externalconn.IsExternalConnectionNotFoundError(err) {
+				return err
+			}
+			if n.n.IfExists {
+				continue
+			}
+			return pgerror.Newf(pgcode.UndefinedObject, "server %q does not exist", name)
+		}
+		if err := params.p.dropExternalConnection(params, &tree.DropExternalConnection{
+			ConnectionLabel: tree.NewStrVal(string(name)),
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *dropServerNode) Next(runParams) (bool, error) { return false, nil }
+func (n *dropServerNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *dropServerNode) Close(context.Context)        {}
+
+// CreateForeignTable creates a foreign table. The table is created like a
+// regular table with a hidden rowid primary key, but its rows are read from
+// the files named by its options whenever it is scanned.
+// Privileges: CREATE on the database and schema, and USAGE on the server.
+func (p *planner) CreateForeignTable(
+	ctx context.Context, n *tree.CreateForeignTable,
+) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE FOREIGN TABLE",
+	); err != nil {
+		return nil, err
+	}
+
+	for _, def := range n.Defs {
+		col, ok := def.(*tree.ColumnTableDef)
+		if !ok {
+			return nil, pgerror.New(pgcode.InvalidTableDefinition,
+				"foreign tables can only contain column definitions")
+		}
+		if col.PrimaryKey.IsPrimaryKey || col.Unique.IsUnique || col.References.Table != nil ||
+			len(col.CheckExprs) > 0 || col.Nullable.Nullability == tree.NotNull {
+			return nil, pgerror.Newf(pgcode.InvalidTableDefinition,
+				"constraints are not supported on foreign table column %q", col.Name)
+		}
+		if col.HasDefaultExpr() || col.HasOnUpdateExpr() || col.IsComputed() ||
+			col.GeneratedIdentity.IsGeneratedAsIdentity || col.HasColumnFamily() || col.IsSerial {
+			return nil, pgerror.Newf(pgcode.InvalidTableDefinition,
+				"foreign table column %q cannot have a default, computed or generated value", col.Name)
+		}
+		typ, err := tree.ResolveType(ctx, col.Type, p.semaCtx.GetTypeResolver())
+		if err != nil {
+			return nil, err
+		}
+		if typ.UserDefined() {
+			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"foreign table column %q cannot have user-defined type %s", col.Name, typ.SQLString())
+		}
+	}
+
+	foreignTable := &descpb.ForeignTableDescriptor{Server: string(n.Server)}
+	exprEval := p.ExprEvaluator("CREATE FOREIGN TABLE")
+	for _, opt := range n.Options {
+		value, err := exprEval.String(ctx, opt.Value)
+		if err != nil {
+			return nil, err
+		}
+		foreignTable.Options = append(foreignTable.Options, descpb.ForeignTableDescriptor_Option{
+			Key:   string(opt.Key),
+			Value: value,
+		})
+	}
+	if _, _, err := foreignTableFileFormat(foreignTable); err != nil {
+		return nil, err
+	}
+	if _, err := externalconn.LoadExternalConnection(
+		ctx, foreignTable.Server, p.InternalSQLTxn(),
+	); err != nil {
+		if externalconn.IsExternalConnectionNotFoundError(err) {
+			return nil, pgerror.Newf(pgcode.UndefinedObject, "server %q does not exist", n.Server)
+		}
+		return nil, err
+	}
+	if err := p.checkForeignServerUsage(ctx, foreignTable.Server); err != nil {
+		return nil, err
+	}
+
+	un := n.Table.ToUnresolvedObjectName()
+	dbDesc, _, prefix, err := p.ResolveTargetObject(ctx, un)
+	if err != nil {
+		return nil, err
+	}
+	n.Table.ObjectNamePrefix = prefix
+
+	return &createTableNode{
+		n: &tree.CreateTable{
+			IfNotExists: n.IfNotExists,
+			Table:       n.Table,
+			Defs:        n.Defs,
+		},
+		dbDesc:       dbDesc,
+		foreignTable: foreignTable,
+	}, nil
+}
+
+// checkForeignServerUsage checks that the current user has the USAGE privilege
+// on the External Connection of the given server. The files of a foreign table
+// are read through the credentials of its server, so this must be checked both
+// when the table is created and whenever it is scanned.
+func (p *planner) checkForeignServerUsage(ctx context.Context, server string) error {
+	ecPrivilege := &syntheticprivilege.ExternalConnectionPrivilege{
+		ConnectionName: server,
+	}
+	return p.CheckPrivilege(ctx, ecPrivilege, privilege.USAGE)
+}
+
+// foreignTableFileFormat returns the location of the files of a foreign table,
+// relative to its server, and the format they are read with.
+func foreignTableFileFormat(
+	ft *descpb.ForeignTableDescriptor,
+) (location string, format roachpb.IOFileFormat, _ error) {
+	seen := make(map[string]struct{}, len(ft.Options))
+	var hasFormat, hasLocation bool
+	for _, opt := range ft.Options {
+		if _, ok := seen[opt.Key]; ok {
+			return "", format, pgerror.Newf(pgcode.Syntax, "option %q provided more than once", opt.Key)
+		}
+		seen[opt.Key] = struct{}{}
+		switch opt.Key {
+		case foreignTableOptionLocation:
+			hasLocation = true
+			location = opt.Value
+		case foreignTableOptionFormat:
+			hasFormat = true
+			switch strings.ToLower(opt.Value) {
+			case "csv":
+				format.Format = roachpb.IOFileFormat_CSV
+			case "avro":
+				format.Format = roachpb.IOFileFormat_Avro
+				format.Avro.Format = roachpb.AvroOptions_OCF
+			case "parquet":
+				format.Format = roachpb.IOFileFormat_Parquet
+			default:
+				return "", format, pgerror.Newf(pgcode.InvalidParameterValue,
+					"unsupported foreign table format %q", opt.Value)
+			}
+		case foreignTableOptionCompression:
+			found := false
+			for name, value := range roachpb.IOFileFormat_Compression_value {
+				if strings.EqualFold(name, opt.Value) {
+					format.Compression = roachpb.IOFileFormat_Compression(value)
+					found = true
+					break
+				}
+			}
+			if !found {
+				return "", format, pgerror.Newf(pgcode.InvalidParameterValue,
+					"unsupported compression value: %q", opt.Value)
+			}
+		case foreignTableOptionDelimiter, foreignTableOptionHeader,
+			foreignTableOptionNull, foreignTableOptionComment:
+			// These are validated below, once the format is known.
+		default:
+			return "", format, pgerror.Newf(pgcode.InvalidParameterValue,
+				"invalid option %q for foreign table", opt.Key)
+		}
+	}
+	if !hasLocation {
+		return "", format, pgerror.Newf(pgcode.InvalidParameterValue,
+			"option %q is required for foreign tables", foreignTableOptionLocation)
+	}
+	if !hasFormat {
+		return "", format, pgerror.Newf(pgcode.InvalidParameterValue,
+			"option %q is required for foreign tables", foreignTableOptionFormat)
+	}
+
+	format.Csv.Comma = ','
+	for _, opt := range ft.Options {
+		switch opt.Key {
+		case foreignTableOptionDelimiter, foreignTableOptionHeader,
+			foreignTableOptionNull, foreignTableOptionComment:
+		default:
+			continue
+		}
+		if format.Format != roachpb.IOFileFormat_CSV {
+			return "", format, pgerror.Newf(pgcode.InvalidParameterValue,
+				"option %q is only supported for CSV foreign tables", opt.Key)
+		}
+		switch opt.Key {
+		case foreignTableOptionDelimiter:
+			comma, err := util.GetSingleRune(opt.Value)
+			if err != nil {
+				return "", format, pgerror.Wrap(err, pgcode.Syntax, "invalid delimiter value")
+			}
+			format.Csv.Comma = comma
+		case foreignTableOptionComment:
+			comment, err := util.GetSingleRune(opt.Value)
+			if err != nil {
+				return "", format, pgerror.Wrap(err, pgcode.Syntax, "invalid comment value")
+			}
+			format.Csv.Comment = comment
+		case foreignTableOptionNull:
+			null := opt.Value
+			format.Csv.NullEncoding = &null
+		case foreignTableOptionHeader:
+			header, err := strconv.ParseBool(opt.Value)
+			if err != nil {
+				return "", format, pgerror.Wrapf(err, pgcode.InvalidParameterValue,
+					"%s requires a Boolean value", foreignTableOptionHeader)
+			}
+			if header {
+				format.Csv.Skip = 1
+			}
+		default:
+			return "", format, errors.AssertionFailedf("unexpected option %q", opt.Key)
+		}
+	}
+	return location, format, nil
+}
@@ -160,12 +160,16 @@ func (n *createFunctionNode) createNewFunction(
 	var outParamOrdinals []int32
 	var outParamTypes []*types.T
 	var defaultExprs []string
+	var isVariadic bool
 	for paramIdx, param := range udfDesc.Params {
 		class := funcdesc.ToTreeRoutineParamClass(param.Class)
 		if tree.IsInParamClass(class) {
 			signatureTypes = append(signatureTypes, param.Type)
 		}
-		if class == tree.RoutineParamOut {
+		if class == tree.RoutineParamVariadic {
+			isVariadic = true
+		}
+		if tree.IsOutOnlyParamClass(class) {
 			outParamOrdinals = append(outParamOrdinals, int32(paramIdx))
 			outParamTypes = append(outParamTypes, param.Type)
 		}
@@ -184,6 +188,7 @@ func (n *createFunctionNode) createNewFunction(
 			OutParamOrdinals: outParamOrdinals,
 			OutParamTypes:    outParamTypes,
 			DefaultExprs:     defaultExprs,
+			IsVariadic:       isVariadic,
 		},
 	)
 	if err := params.p.writeSchemaDescChange(params.ctx, scDesc, "Create Function"); err != nil {
@@ -247,12 +252,16 @@ func (n *createFunctionNode) replaceFunction(
 	var outParamOrdinals []int32
 	var outParamTypes []*types.T
 	var defaultExprs []string
+	var isVariadic bool
 	for i, p := range n.cf.Params {
 		udfDesc.Params[i], err = makeFunctionParam(params.ctx, params.p.SemaCtx(), p, params.p)
 		if err != nil {
 			return err
 		}
-		if p.Class == tree.RoutineParamOut {
+		if p.Class == tree.RoutineParamVariadic {
+			isVariadic = true
+		}
+		if tree.IsOutOnlyParamClass(p.Class) {
 			outParamOrdinals = append(outParamOrdinals, int32(i))
 			outParamTypes = append(outParamTypes, udfDesc.Params[i].Type)
 		}
@@ -311,7 +320,7 @@ func (n *createFunctionNode) replaceFunction(
 	}
 
 	signatureChanged := len(existing.OutParamOrdinals) != len(outParamOrdinals) ||
-		len(existing.DefaultExprs) != len(defaultExprs)
+		len(existing.DefaultExprs) != len(defaultExprs) || existing.Variadic != isVariadic
 	for i := 0; !signatureChanged && i < len(outParamOrdinals); i++ {
 		signatureChanged = existing.OutParamOrdinals[i] != outParamOrdinals[i] ||
 			!existing.OutParamTypes.GetAt(i).Equivalent(outParamTypes[i])
@@ -339,6 +348,7 @@ func (n *createFunctionNode) replaceFunction(
 				OutParamOrdinals: outParamOrdinals,
 				OutParamTypes:    outParamTypes,
 				DefaultExprs:     defaultExprs,
+				IsVariadic:       isVariadic,
 			},
 		); err != nil {
 			return err
@@ -546,6 +556,9 @@ func (n *createFunctionNode) addUDFReferences(udfDesc *funcdesc.Mutable, params
 func setFuncOptions(
 	params runParams, udfDesc *funcdesc.Mutable, options tree.RoutineOptions,
 ) error {
+	if err := checkRoutineSecurityAndConfigSupported(params, options); err != nil {
+		return err
+	}
 	var err error
 	var body string
 	var lang catpb.Function_Language
@@ -575,6 +588,16 @@ func setFuncOptions(
 			// Handle the body after the loop, since we don't yet know what language
 			// it is.
 			body = string(t)
+		case tree.RoutineSecurity:
+			v, err := funcinfo.SecurityToProto(t)
+			if err != nil {
+				return err
+			}
+			udfDesc.SetSecurity(v)
+		case *tree.SetVar:
+			if err := setFuncConfigOption(params, udfDesc, t); err != nil {
+				return err
+			}
 		default:
 			return pgerror.Newf(pgcode.InvalidParameterValue, "Unknown function option %q", t)
 		}
@@ -596,11 +619,62 @@ func setFuncOptions(
 	return nil
 }
 
+// setFuncConfigOption applies a SET or RESET clause of a routine to the
+// session variable settings stored in the function descriptor.
+func setFuncConfigOption(params runParams, udfDesc *funcdesc.Mutable, n *tree.SetVar) error {
+	kind, varName, sVar, typedValues, err := params.p.processSetOrResetClause(params.ctx, n)
+	if err != nil {
+		return err
+	}
+	switch kind {
+	case resetAllVars:
+		udfDesc.ResetConfig()
+	case resetSingleVar:
+		udfDesc.ResetConfigValue(varName)
+	case setSingleVar:
+		strVal, err := evalSessionVarVal(params, varName, sVar, typedValues)
+		if err != nil {
+			return err
+		}
+		udfDesc.SetConfigValue(varName, strVal)
+	default:
+		return errors.AssertionFailedf("unexpected SET or RESET clause %s", n)
+	}
+	return nil
+}
+
+// checkRoutineSecurityAndConfigSupported returns an error if the options
+// include SECURITY DEFINER or SET clauses, and the cluster has not been
+// upgraded to a version that supports them.
+func checkRoutineSecurityAndConfigSupported(
+	params runParams, options tree.RoutineOptions,
+) error {
+	if params.p.ExecCfg().Settings.Version.IsActive(params.ctx, clusterversion.V24_2_RoutineSecurityAndConfig) {
+		return nil
+	}
+	for _, option := range options {
+		switch t := option.(type) {
+		case tree.RoutineSecurity:
+			if t != tree.RoutineSecurityDefiner {
+				continue
+			}
+		case *tree.SetVar:
+		default:
+			continue
+		}
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"%s is only supported after the v24.2 upgrade is finalized", tree.AsString(option))
+	}
+	return nil
+}
+
 // resetFuncOption sets all function options to default values.
 func resetFuncOption(udfDesc *funcdesc.Mutable) {
 	udfDesc.SetVolatility(catpb.Function_VOLATILE)
 	udfDesc.SetNullInputBehavior(catpb.Function_CALLED_ON_NULL_INPUT)
 	udfDesc.SetLeakProof(false)
+	udfDesc.SetSecurity(catpb.Function_INVOKER)
+	udfDesc.ResetConfig()
 }
 
 func makeFunctionParam(
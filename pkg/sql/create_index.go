@@ -69,6 +69,10 @@ func (p *planner) CreateIndex(ctx context.Context, n *tree.CreateIndex) (planNod
 		return nil, pgerror.Newf(pgcode.WrongObjectType, "%q is not a table or materialized view", tableDesc.Name)
 	}
 
+	if tableDesc.IsForeignTable() {
+		return nil, pgerror.Newf(pgcode.WrongObjectType, "cannot create index on foreign table %q", tableDesc.Name)
+	}
+
 	if tableDesc.MaterializedView() {
 		if n.Sharded != nil {
 			return nil, pgerror.New(pgcode.InvalidObjectDefinition,
@@ -147,6 +151,20 @@ func makeIndexDescriptor(
 			`"bucket_count" storage param should only be set with "USING HASH" for hash sharded index`,
 		)
 	}
+	// Vector indexes are built as expression indexes on the list of each
+	// vector.
+	if err := tabledesc.RewriteVectorIndex(
+		params.ctx, params.p.SemaCtx(), params.EvalContext(), &n,
+		func(name tree.Name) (*types.T, error) {
+			col, err := catalog.MustFindColumnByTreeName(tableDesc, name)
+			if err != nil {
+				return nil, err
+			}
+			return col.GetType(), nil
+		},
+	); err != nil {
+		return nil, err
+	}
 	// Since we mutate the columns below, we make copies of them
 	// here so that on retry we do not attempt to validate the
 	// mutated columns.
@@ -418,6 +436,12 @@ func populateInvertedIndexDescriptor(
 		default:
 			return newUndefinedOpclassError(invCol.OpClass)
 		}
+	case types.RangeFamily:
+		switch invCol.OpClass {
+		case "range_ops", "":
+		default:
+			return newUndefinedOpclassError(invCol.OpClass)
+		}
 	default:
 		return tabledesc.NewInvalidInvertedColumnError(column.GetName(), column.GetType().Name())
 	}
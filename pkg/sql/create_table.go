@@ -74,6 +74,8 @@ type createTableNode struct {
 	n          *tree.CreateTable
 	dbDesc     catalog.DatabaseDescriptor
 	sourcePlan planNode
+	// foreignTable is set if the table is created by CREATE FOREIGN TABLE.
+	foreignTable *descpb.ForeignTableDescriptor
 }
 
 // ReadingOwnWrites implements the planNodeReadingOwnWrites interface.
@@ -398,6 +400,7 @@ func (n *createTableNode) startExec(params runParams) error {
 		if err != nil {
 			return err
 		}
+		desc.ForeignTable = n.foreignTable
 
 		if desc.Adding() {
 			// if this table and all its references are created in the same
@@ -648,6 +651,16 @@ const (
 	NonEmptyTable
 )
 
+// errDeferrableUniqueIndex is returned for DEFERRABLE unique constraints which
+// would be enforced by an index. Unique indexes reject duplicate keys as soon
+// as they are written, so their checks cannot be deferred.
+var errDeferrableUniqueIndex = errors.WithHint(
+	pgerror.New(pgcode.FeatureNotSupported,
+		"unique constraints enforced by an index cannot be DEFERRABLE",
+	),
+	"use UNIQUE WITHOUT INDEX to create a deferrable unique constraint",
+)
+
 // addUniqueWithoutIndexColumnTableDef runs various checks on the given
 // ColumnTableDef before adding it as a UNIQUE WITHOUT INDEX constraint to the
 // given table descriptor.
@@ -672,6 +685,7 @@ func addUniqueWithoutIndexColumnTableDef(
 		string(d.Unique.ConstraintName),
 		[]string{string(d.Name)},
 		"", /* predicate */
+		tree.ConstraintNotDeferrable,
 		ts,
 		validationBehavior,
 	); err != nil {
@@ -735,7 +749,7 @@ func addUniqueWithoutIndexTableDef(
 		colNames[i] = string(d.Columns[i].Column)
 	}
 	if err := ResolveUniqueWithoutIndexConstraint(
-		ctx, desc, string(d.Name), colNames, predicate, ts, validationBehavior,
+		ctx, desc, string(d.Name), colNames, predicate, d.Deferrable, ts, validationBehavior,
 	); err != nil {
 		return err
 	}
@@ -755,6 +769,7 @@ func ResolveUniqueWithoutIndexConstraint(
 	constraintName string,
 	colNames []string,
 	predicate string,
+	deferrability tree.ConstraintDeferrability,
 	ts TableState,
 	validationBehavior tree.ValidationBehavior,
 ) error {
@@ -803,12 +818,13 @@ func ResolveUniqueWithoutIndexConstraint(
 	}
 
 	uc := descpb.UniqueWithoutIndexConstraint{
-		Name:         constraintName,
-		TableID:      tbl.ID,
-		ColumnIDs:    columnIDs,
-		Predicate:    predicate,
-		Validity:     validity,
-		ConstraintID: tbl.NextConstraintID,
+		Name:          constraintName,
+		TableID:       tbl.ID,
+		ColumnIDs:     columnIDs,
+		Predicate:     predicate,
+		Validity:      validity,
+		ConstraintID:  tbl.NextConstraintID,
+		Deferrability: tree.ConstraintDeferrabilityValue[deferrability],
 	}
 	tbl.NextConstraintID++
 	if ts == NewTable {
@@ -820,6 +836,142 @@ func ResolveUniqueWithoutIndexConstraint(
 	return nil
 }
 
+// addExclusionConstraintTableDef looks up the columns mentioned in an EXCLUDE
+// constraint and adds the constraint to the descriptor of a new table.
+// Exclusion constraints are enforced by the mutation planner, which checks that
+// new rows do not conflict with existing ones. To find the existing rows that
+// may conflict, the constraint is backed by an index named after it: an
+// inverted index on the first column compared with && or -|-, prefixed by the
+// columns compared with =, or a forward index on the columns compared with =
+// if there is no such column.
+func addExclusionConstraintTableDef(
+	ctx context.Context,
+	evalCtx *eval.Context,
+	d *tree.ExclusionConstraintTableDef,
+	desc *tabledesc.Mutable,
+	tn tree.TableName,
+	indexEncodingVersion descpb.IndexDescriptorVersion,
+) error {
+	if !evalCtx.Settings.Version.IsActive(ctx, clusterversion.V24_2_RangeTypes) {
+		return pgerror.Newf(
+			pgcode.FeatureNotSupported,
+			"exclusion constraints not supported until version 24.2",
+		)
+	}
+	if d.Method != "gist" {
+		return unimplemented.NewWithIssuef(46657,
+			"exclusion constraints using access method %q are not supported", d.Method)
+	}
+	if desc.PartitionAllBy {
+		return unimplemented.NewWithIssue(46657,
+			"exclusion constraints are not supported on tables with PARTITION ALL BY or "+
+				"LOCALITY REGIONAL BY ROW")
+	}
+	ec := descpb.ExclusionConstraint{
+		Name:      string(d.Name),
+		ColumnIDs: make(descpb.ColumnIDs, len(d.Elems)),
+		Operators: make([]string, len(d.Elems)),
+	}
+	colNames := make([]string, len(d.Elems))
+	// The key columns of the backing index are the indexable columns compared
+	// with =, followed by the first range column if there is one.
+	var indexElems tree.IndexElemList
+	var indexCols catalog.TableColSet
+	var invertedElem *tree.IndexElem
+	for i, elem := range d.Elems {
+		col, err := catalog.MustFindColumnByTreeName(desc, elem.Column)
+		if err != nil {
+			return err
+		}
+		switch elem.Operator.Symbol {
+		case treecmp.EQ:
+			if colinfo.ColumnTypeIsIndexable(col.GetType()) && !indexCols.Contains(col.GetID()) {
+				indexElems = append(indexElems, tree.IndexElem{Column: elem.Column})
+				indexCols.Add(col.GetID())
+			}
+		case treecmp.Overlaps, treecmp.Adjacent:
+			if col.GetType().Family() != types.RangeFamily {
+				return pgerror.Newf(pgcode.UndefinedFunction,
+					"unsupported comparison operator: <%s> %s <%s>",
+					col.GetType(), elem.Operator, col.GetType())
+			}
+			if invertedElem == nil {
+				invertedElem = &tree.IndexElem{Column: elem.Column}
+			}
+		default:
+			return pgerror.Newf(pgcode.WrongObjectType,
+				"operator %s is not supported by exclusion constraints", elem.Operator)
+		}
+		ec.ColumnIDs[i] = col.GetID()
+		ec.Operators[i] = elem.Operator.Symbol.String()
+		colNames[i] = col.GetName()
+	}
+
+	nameInUse := func(name string) bool {
+		if catalog.FindConstraintByName(desc, name) != nil || catalog.FindIndexByName(desc, name) != nil {
+			return true
+		}
+		for i := range desc.ExclusionConstraints {
+			if desc.ExclusionConstraints[i].Name == name {
+				return true
+			}
+		}
+		return false
+	}
+	if ec.Name == "" {
+		ec.Name = tabledesc.GenerateUniqueName(
+			fmt.Sprintf("%s_%s_excl", tn.Table(), strings.Join(colNames, "_")), nameInUse,
+		)
+	} else if nameInUse(ec.Name) {
+		return pgerror.Newf(pgcode.DuplicateObject, "duplicate constraint name: %q", ec.Name)
+	}
+
+	if invertedElem != nil {
+		indexElems = append(indexElems, *invertedElem)
+	}
+	if len(indexElems) > 0 {
+		idx := descpb.IndexDescriptor{
+			Name:    ec.Name,
+			Version: indexEncodingVersion,
+		}
+		if invertedElem != nil {
+			idx.Type = descpb.IndexDescriptor_INVERTED
+		}
+		if err := idx.FillColumns(indexElems); err != nil {
+			return err
+		}
+		if invertedElem != nil {
+			column, err := catalog.MustFindColumnByName(desc, idx.InvertedColumnName())
+			if err != nil {
+				return err
+			}
+			if err := populateInvertedIndexDescriptor(
+				ctx, evalCtx.Settings, column, &idx, *invertedElem,
+			); err != nil {
+				return err
+			}
+		}
+		if err := desc.AddSecondaryIndex(idx); err != nil {
+			return err
+		}
+		if err := desc.AllocateIDsWithoutValidation(ctx, false /* createMissingPrimaryKey */); err != nil {
+			return err
+		}
+		ec.IndexID = catalog.FindIndexByName(desc, ec.Name).GetID()
+	}
+
+	ec.ConstraintID = desc.NextConstraintID
+	desc.NextConstraintID++
+	desc.ExclusionConstraints = append(desc.ExclusionConstraints, ec)
+	if ec.IndexID == 0 {
+		evalCtx.ClientNoticeSender.BufferClientNotice(ctx, pgnotice.Newf(
+			"exclusion constraint %q is not backed by an index, so checking it may scan all rows of %s "+
+				"for every INSERT, UPDATE and UPSERT", ec.Name, tn.Table(),
+		))
+	}
+	return nil
+}
+
 // ResolveFK looks up the tables and columns mentioned in a `REFERENCES`
 // constraint and adds metadata representing that constraint to the descriptor.
 // It may, in doing so, add to or alter descriptors in the passed in `backrefs`
@@ -1063,6 +1215,7 @@ func ResolveFK(
 		OnUpdate:            tree.ForeignKeyReferenceActionValue[d.Actions.Update],
 		Match:               tree.CompositeKeyMatchMethodValue[d.Match],
 		ConstraintID:        tbl.NextConstraintID,
+		Deferrability:       tree.ConstraintDeferrabilityValue[d.Deferrable],
 	}
 	tbl.NextConstraintID++
 	if ts == NewTable {
@@ -2050,7 +2203,8 @@ func NewTableDesc(
 					return nil, err
 				}
 			}
-		case *tree.CheckConstraintTableDef, *tree.ForeignKeyConstraintTableDef, *tree.FamilyTableDef:
+		case *tree.CheckConstraintTableDef, *tree.ForeignKeyConstraintTableDef, *tree.FamilyTableDef,
+			*tree.ExclusionConstraintTableDef:
 			// pass, handled below.
 
 		default:
@@ -2216,6 +2370,8 @@ func NewTableDesc(
 				); err != nil {
 					return nil, err
 				}
+			} else if d.Deferrable != tree.ConstraintNotDeferrable {
+				return nil, errDeferrableUniqueIndex
 			}
 
 		case *tree.IndexTableDef, *tree.FamilyTableDef, *tree.LikeTableDef:
@@ -2236,6 +2392,13 @@ func NewTableDesc(
 				return nil, err
 			}
 
+		case *tree.ExclusionConstraintTableDef:
+			if err := addExclusionConstraintTableDef(
+				ctx, evalCtx, d, &desc, n.Table, indexEncodingVersion,
+			); err != nil {
+				return nil, err
+			}
+
 		default:
 			return nil, errors.Errorf("unsupported table def: %T", def)
 		}
@@ -2440,6 +2603,29 @@ func newTableDesc(
 		}
 		ttl.ScheduleID = j.ScheduleID()
 	}
+
+	// Automatically partitioned tables require a scheduled job as well.
+	if ret.HasPartitionMaintenance() {
+		if err := checkPartitionMaintenanceEnabled(params.ExecCfg().Settings); err != nil {
+			return nil, err
+		}
+		if _, err := tabledesc.ValidatePartitionMaintenanceTable(ret); err != nil {
+			return nil, err
+		}
+		j, err := CreatePartitionMaintenanceScheduledJob(
+			params.ctx,
+			params.ExecCfg().JobsKnobs(),
+			jobs.ScheduledJobTxn(params.p.InternalSQLTxn()),
+			params.p.User(),
+			ret,
+			params.p.extendedEvalCtx.ClusterID,
+			params.p.execCfg.Settings.Version.ActiveVersion(params.ctx),
+		)
+		if err != nil {
+			return nil, err
+		}
+		ret.PartitionMaintenance.ScheduleID = j.ScheduleID()
+	}
 	return ret, nil
 }
 
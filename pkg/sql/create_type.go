@@ -14,6 +14,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
@@ -237,6 +238,10 @@ func CreateUserDefinedArrayTypeDesc(
 			labels[i] = e.ElementLabel
 		}
 		elemTyp = types.NewCompositeType(catid.TypeIDToOID(typDesc.GetID()), catid.TypeIDToOID(id), contents, labels)
+	case descpb.TypeDescriptor_DOMAIN:
+		elemTyp = types.MakeDomain(catid.TypeIDToOID(typDesc.GetID()), catid.TypeIDToOID(id), typDesc.Domain.BaseType)
+	case descpb.TypeDescriptor_RANGE:
+		elemTyp = types.MakeRange(catid.TypeIDToOID(typDesc.GetID()), catid.TypeIDToOID(id), typDesc.Range.Subtype)
 	default:
 		return nil, errors.AssertionFailedf("cannot make array type for kind %s", t.String())
 	}
@@ -318,6 +323,14 @@ func (p *planner) createUserDefinedType(params runParams, n *createTypeNode) err
 		return params.p.createCompositeWithID(
 			params, id, n.n.CompositeTypeList, n.dbDesc, n.typeName,
 		)
+	case tree.Range:
+		if !params.ExecCfg().Settings.Version.IsActive(params.ctx, clusterversion.V24_2_RangeTypes) {
+			return pgerror.Newf(
+				pgcode.FeatureNotSupported,
+				"range types not supported until version 24.2",
+			)
+		}
+		return params.p.createRangeWithID(params, id, n.n.RangeSubtype, n.dbDesc, n.typeName)
 	}
 	return unimplemented.NewWithIssue(25123, "CREATE TYPE")
 }
@@ -459,6 +472,68 @@ func createCompositeTypeDesc(
 	}).BuildCreatedMutableType(), nil
 }
 
+// checkRangeSubtype returns an error if range types can't be defined over the
+// given type. The bounds of range values are compared without an evaluation
+// context, which only the types below allow.
+func checkRangeSubtype(typ *types.T) error {
+	if typ.UserDefined() {
+		return unimplemented.NewWithIssue(27791,
+			"range types over user-defined types not yet supported")
+	}
+	switch typ.Family() {
+	case types.IntFamily, types.FloatFamily, types.DecimalFamily, types.DateFamily,
+		types.TimestampFamily, types.TimestampTZFamily:
+		return nil
+	}
+	return unimplemented.NewWithIssuef(27791,
+		"range types over %s not yet supported", typ.SQLString())
+}
+
+// createRangeTypeDesc creates a new range type descriptor.
+func createRangeTypeDesc(
+	params runParams,
+	id descpb.ID,
+	subtype tree.ResolvableTypeReference,
+	dbDesc catalog.DatabaseDescriptor,
+	schema catalog.SchemaDescriptor,
+	typeName *tree.TypeName,
+) (*typedesc.Mutable, error) {
+	typ, err := tree.ResolveType(params.ctx, subtype, params.p.semaCtx.TypeResolver)
+	if err != nil {
+		return nil, err
+	}
+	if err := tree.CheckUnsupportedType(params.ctx, &params.p.semaCtx, typ); err != nil {
+		return nil, err
+	}
+	if err := checkRangeSubtype(typ); err != nil {
+		return nil, err
+	}
+
+	privs, err := catprivilege.CreatePrivilegesFromDefaultPrivileges(
+		dbDesc.GetDefaultPrivilegeDescriptor(),
+		schema.GetDefaultPrivilegeDescriptor(),
+		dbDesc.GetID(),
+		params.SessionData().User(),
+		privilege.Types,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return typedesc.NewBuilder(&descpb.TypeDescriptor{
+		Name:           typeName.Type(),
+		ID:             id,
+		ParentID:       dbDesc.GetID(),
+		ParentSchemaID: schema.GetID(),
+		Kind:           descpb.TypeDescriptor_RANGE,
+		Range: &descpb.TypeDescriptor_Range{
+			Subtype: typ,
+		},
+		Version:    1,
+		Privileges: privs,
+	}).BuildCreatedMutableType(), nil
+}
+
 func (p *planner) createEnumWithID(
 	ctx context.Context,
 	evalCtx *eval.Context,
@@ -512,6 +587,27 @@ func (p *planner) createCompositeWithID(
 	return nil
 }
 
+func (p *planner) createRangeWithID(
+	params runParams,
+	id descpb.ID,
+	subtype tree.ResolvableTypeReference,
+	dbDesc catalog.DatabaseDescriptor,
+	typeName *tree.TypeName,
+) error {
+	// Generate a key in the namespace table and a new id for this type.
+	schema, err := getCreateTypeParams(params.ctx, p, typeName, dbDesc)
+	if err != nil {
+		return err
+	}
+
+	typeDesc, err := createRangeTypeDesc(params, id, subtype, dbDesc, schema, typeName)
+	if err != nil {
+		return err
+	}
+
+	return p.finishCreateType(params.ctx, params.EvalContext(), typeName, typeDesc, dbDesc, schema)
+}
+
 func (p *planner) finishCreateType(
 	ctx context.Context,
 	evalCtx *eval.Context,
@@ -0,0 +1,512 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec/execbuilder"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/norm"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/optbuilder"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/props/physical"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/xform"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/semenumpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+)
+
+// deferredCheckMaxKeys is the maximum number of keys queued for a deferred
+// constraint check. Once a transaction writes more keys than this, the keys
+// are discarded and the whole table is validated at commit time instead,
+// which bounds the memory used by the queued keys.
+const deferredCheckMaxKeys = 10000
+
+// deferredConstraints tracks the state of deferrable constraints in a
+// transaction: the modes set by SET CONSTRAINTS, and the checks that were
+// skipped by mutations because their constraints were deferred. The skipped
+// checks are run when the transaction commits, or when the constraints are
+// switched back to IMMEDIATE.
+type deferredConstraints struct {
+	modes eval.ConstraintModes
+
+	// pending contains the checks of the deferred constraints that may have
+	// been violated by the mutations of the transaction, at most one for each
+	// constraint.
+	pending []*deferredCheck
+}
+
+// deferredCheck contains the keys of the rows which must be checked for a
+// deferred constraint. Like the checks planned by the optimizer for
+// mutations, it only looks at the rows affected by the transaction: when it
+// is validated, only the rows identified by rowKeys and refKeys are looked up.
+type deferredCheck struct {
+	tableID      descpb.ID
+	constraintID descpb.ConstraintID
+
+	// rowKeys are the primary keys of the rows of the constrained table that
+	// were written with new values for the columns of the constraint.
+	rowKeys deferredKeySet
+	// refKeys are the values of the referenced columns of a foreign key which
+	// were removed from the referenced table by deletes and updates. The rows
+	// of the constrained table which reference them must be checked.
+	refKeys deferredKeySet
+
+	// validateAll is true if the keys to check could not be tracked, because
+	// there were too many of them or because a mutation did not fetch the
+	// columns of the constraint. The whole table is validated in that case.
+	validateAll bool
+}
+
+// deferredKeySet is a set of keys, which are the values of a list of columns.
+type deferredKeySet struct {
+	keys []tree.Datums
+	// seen contains the keys of the set, formatted as strings, in order to
+	// deduplicate them.
+	seen map[string]struct{}
+}
+
+func (d *deferredConstraints) reset() {
+	*d = deferredConstraints{}
+}
+
+// queue records the deferrable constraints of the table which is mutated by a
+// statement whose checks were skipped because they are deferred according to
+// modes. insertion is true if the statement may write new values, which can
+// violate the outbound foreign keys and the unique constraints of the table.
+// deletion is true if the statement may remove existing values, which can
+// violate the inbound foreign keys.
+//
+// It returns the recorder that the table writer of the statement uses to queue
+// the keys of the rows it writes, or nil if no constraint is deferred.
+func (d *deferredConstraints) queue(
+	modes *eval.ConstraintModes, desc catalog.TableDescriptor, insertion, deletion bool,
+) *deferredKeyRecorder {
+	if d == nil || !modes.ExplicitTxn {
+		return nil
+	}
+	var r deferredKeyRecorder
+	if insertion {
+		for _, fk := range desc.OutboundForeignKeys() {
+			if c := d.maybeAdd(
+				modes, desc.GetID(), fk.GetConstraintID(), fk.GetName(), fk.Deferrability(),
+			); c != nil {
+				r.rowChecks = append(r.rowChecks, deferredColumnsCheck{
+					check: c, colIDs: fk.ForeignKeyDesc().OriginColumnIDs,
+				})
+			}
+		}
+		for _, uc := range desc.EnforcedUniqueConstraintsWithoutIndex() {
+			if c := d.maybeAdd(
+				modes, desc.GetID(), uc.GetConstraintID(), uc.GetName(), uc.Deferrability(),
+			); c != nil {
+				// The rows of a partial constraint can also be affected by changes
+				// to the columns of its predicate, so they are always checked.
+				var colIDs []descpb.ColumnID
+				if !uc.IsPartial() {
+					colIDs = uc.CollectKeyColumnIDs().Ordered()
+				}
+				r.rowChecks = append(r.rowChecks, deferredColumnsCheck{check: c, colIDs: colIDs})
+			}
+		}
+	}
+	if deletion {
+		for _, fk := range desc.InboundForeignKeys() {
+			if c := d.maybeAdd(
+				modes, fk.GetOriginTableID(), fk.GetConstraintID(), fk.GetName(), fk.Deferrability(),
+			); c != nil {
+				r.refChecks = append(r.refChecks, deferredColumnsCheck{
+					check: c, colIDs: fk.ForeignKeyDesc().ReferencedColumnIDs,
+				})
+			}
+		}
+	}
+	if len(r.rowChecks) == 0 && len(r.refChecks) == 0 {
+		return nil
+	}
+	r.pkColIDs = desc.GetPrimaryIndex().IndexDesc().KeyColumnIDs
+	return &r
+}
+
+// maybeAdd returns the pending check for the given constraint, which is added
+// if necessary. It returns nil if the constraint is not deferred.
+func (d *deferredConstraints) maybeAdd(
+	modes *eval.ConstraintModes,
+	tableID descpb.ID,
+	constraintID descpb.ConstraintID,
+	name string,
+	deferrability semenumpb.Deferrability,
+) *deferredCheck {
+	if deferrability == semenumpb.Deferrability_NOT_DEFERRABLE ||
+		!modes.IsDeferred(name, deferrability == semenumpb.Deferrability_INITIALLY_DEFERRED) {
+		return nil
+	}
+	for _, c := range d.pending {
+		if c.tableID == tableID && c.constraintID == constraintID {
+			return c
+		}
+	}
+	c := &deferredCheck{tableID: tableID, constraintID: constraintID}
+	d.pending = append(d.pending, c)
+	return c
+}
+
+// hasPending returns true if there are deferred constraints left to validate.
+func (d *deferredConstraints) hasPending() bool {
+	return len(d.pending) > 0
+}
+
+// validate runs the deferred checks of the pending constraints and removes
+// them from the pending set. If stillDeferred is not nil, the constraints for
+// which it returns true are skipped and remain pending.
+func (d *deferredConstraints) validate(
+	ctx context.Context, p *planner, stillDeferred func(name string, initiallyDeferred bool) bool,
+) error {
+	txn := p.InternalSQLTxn()
+	var remaining []*deferredCheck
+	for _, c := range d.pending {
+		tbl, err := txn.Descriptors().ByIDWithLeased(txn.KV()).Get().Table(ctx, c.tableID)
+		if err != nil {
+			return err
+		}
+		if tbl.Dropped() {
+			continue
+		}
+		switch cst := catalog.FindConstraintByID(tbl, c.constraintID); {
+		case cst == nil:
+			// The constraint was dropped later in the transaction.
+			continue
+
+		case cst.AsForeignKey() != nil:
+			fk := cst.AsForeignKey()
+			if stillDeferred != nil &&
+				stillDeferred(fk.GetName(), fk.Deferrability() == semenumpb.Deferrability_INITIALLY_DEFERRED) {
+				remaining = append(remaining, c)
+				continue
+			}
+			if c.validateAll {
+				targetTbl, err := txn.Descriptors().ByIDWithLeased(txn.KV()).Get().Table(ctx, fk.GetReferencedTableID())
+				if err != nil {
+					return err
+				}
+				err = validateForeignKey(
+					ctx, txn, tbl, targetTbl, fk.ForeignKeyDesc(), 0, /* indexIDForValidation */
+				)
+			} else {
+				err = c.validateForeignKey(ctx, p, fk.GetName())
+			}
+			if err != nil {
+				return err
+			}
+
+		case cst.AsUniqueWithoutIndex() != nil:
+			uc := cst.AsUniqueWithoutIndex()
+			if stillDeferred != nil &&
+				stillDeferred(uc.GetName(), uc.Deferrability() == semenumpb.Deferrability_INITIALLY_DEFERRED) {
+				remaining = append(remaining, c)
+				continue
+			}
+			if c.validateAll {
+				err = validateUniqueConstraint(
+					ctx,
+					tbl,
+					uc.GetName(),
+					uc.CollectKeyColumnIDs().Ordered(),
+					uc.GetPredicate(),
+					0, /* indexIDForValidation */
+					txn,
+					p.User(),
+					true, /* preExisting */
+				)
+			} else {
+				err = c.validateUniqueConstraint(ctx, p, uc.GetName())
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	d.pending = remaining
+	return nil
+}
+
+// validateForeignKey runs the checks of the given foreign key for the queued
+// keys: the insertion check for the rows written by the transaction, and the
+// deletion check for the referenced values it removed. They are planned by the
+// optimizer like the FK checks of a mutation.
+func (c *deferredCheck) validateForeignKey(ctx context.Context, p *planner, name string) error {
+	for _, outbound := range []bool{true, false} {
+		keys := c.rowKeys.keys
+		if !outbound {
+			keys = c.refKeys.keys
+		}
+		if len(keys) == 0 {
+			continue
+		}
+		var private *memo.FKChecksItemPrivate
+		if err := p.planAndRunDeferredCheck(ctx, name,
+			func(catalog cat.Catalog, factory *norm.Factory) (check memo.RelExpr, err error) {
+				check, private, err = optbuilder.BuildDeferredFKCheck(
+					ctx, p.SemaCtx(), p.EvalContext(), catalog, factory,
+					cat.StableID(c.tableID), name, outbound, keys,
+				)
+				return check, err
+			},
+			func(eb *execbuilder.Builder) (exec.Plan, error) {
+				return eb.BuildDeferredFKCheck(private)
+			},
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateUniqueConstraint runs the check of the given unique constraint for
+// the rows written by the transaction. It is planned by the optimizer like the
+// uniqueness checks of a mutation.
+func (c *deferredCheck) validateUniqueConstraint(
+	ctx context.Context, p *planner, name string,
+) error {
+	if len(c.rowKeys.keys) == 0 {
+		return nil
+	}
+	var private *memo.UniqueChecksItemPrivate
+	return p.planAndRunDeferredCheck(ctx, name,
+		func(catalog cat.Catalog, factory *norm.Factory) (check memo.RelExpr, err error) {
+			check, private, err = optbuilder.BuildDeferredUniqueCheck(
+				ctx, p.SemaCtx(), p.EvalContext(), catalog, factory,
+				cat.StableID(c.tableID), name, c.rowKeys.keys,
+			)
+			return check, err
+		},
+		func(eb *execbuilder.Builder) (exec.Plan, error) {
+			return eb.BuildDeferredUniqueCheck(private)
+		},
+	)
+}
+
+// planAndRunDeferredCheck plans the check of a deferred constraint in a new
+// memo, the same way as the cascades and checks of a mutation are planned, and
+// runs it. buildCheck builds the check query, or returns nil if no check is
+// needed, and buildPlan execbuilds the optimized query.
+func (p *planner) planAndRunDeferredCheck(
+	ctx context.Context,
+	constraintName string,
+	buildCheck func(catalog cat.Catalog, factory *norm.Factory) (memo.RelExpr, error),
+	buildPlan func(eb *execbuilder.Builder) (exec.Plan, error),
+) error {
+	log.VEventf(ctx, 2, "validating deferred constraint %q", constraintName)
+	var catalog optCatalog
+	catalog.init(p)
+	catalog.reset()
+
+	var o xform.Optimizer
+	o.Init(ctx, p.EvalContext(), &catalog)
+	check, err := buildCheck(&catalog, o.Factory())
+	if err != nil || check == nil {
+		return err
+	}
+	o.Memo().SetRoot(check, &physical.Required{})
+	optimizedExpr, err := o.Optimize()
+	if err != nil {
+		return err
+	}
+
+	eb := execbuilder.New(
+		ctx, newExecFactory(ctx, p), &o, o.Memo(), &catalog, optimizedExpr,
+		p.SemaCtx(), p.EvalContext(), false /* allowAutoCommit */, false, /* isANSIDML */
+	)
+	plan, err := buildPlan(eb)
+	if err != nil {
+		return err
+	}
+	return runPlanInsidePlan(
+		ctx, runParams{ctx: ctx, extendedEvalCtx: &p.extendedEvalCtx, p: p},
+		plan.(*planComponents), &errOnlyResultWriter{},
+		nil /* deferredRoutineSender */, "", /* stmtForDistSQLDiagram */
+	)
+}
+
+// add queues a key in the given key set of the check, unless it is already
+// queued.
+func (c *deferredCheck) add(set *deferredKeySet, key tree.Datums) {
+	if c.validateAll {
+		return
+	}
+	str := tree.AsStringWithFlags(&key, tree.FmtSerializable)
+	if _, ok := set.seen[str]; ok {
+		return
+	}
+	if len(c.rowKeys.keys)+len(c.refKeys.keys) >= deferredCheckMaxKeys {
+		c.fallBack()
+		return
+	}
+	if set.seen == nil {
+		set.seen = make(map[string]struct{})
+	}
+	set.seen[str] = struct{}{}
+	set.keys = append(set.keys, key)
+}
+
+// fallBack discards the queued keys of the check, so that the whole table is
+// validated instead.
+func (c *deferredCheck) fallBack() {
+	c.validateAll = true
+	c.rowKeys = deferredKeySet{}
+	c.refKeys = deferredKeySet{}
+}
+
+// deferredKeyRecorder queues the keys of the rows written by a mutation for
+// the deferred checks of the mutated table. A nil recorder records nothing.
+type deferredKeyRecorder struct {
+	// pkColIDs are the primary key columns of the mutated table.
+	pkColIDs []descpb.ColumnID
+	// rowChecks are the deferred checks of the outbound foreign keys and the
+	// unique constraints of the table. They are given the primary keys of the
+	// rows written with new values for their columns.
+	rowChecks []deferredColumnsCheck
+	// refChecks are the deferred checks of the inbound foreign keys of the
+	// table. They are given the old values of their referenced columns for the
+	// rows which are deleted, or updated with new values for these columns.
+	refChecks []deferredColumnsCheck
+}
+
+// deferredColumnsCheck is a deferred check, along with the columns of the
+// mutated table whose changes can violate it. If colIDs is nil, any change
+// to a row can violate it.
+type deferredColumnsCheck struct {
+	check  *deferredCheck
+	colIDs []descpb.ColumnID
+}
+
+// inserted records a row inserted by a mutation. The values are ordered
+// according to colMap.
+func (r *deferredKeyRecorder) inserted(values tree.Datums, colMap catalog.TableColMap) {
+	if r == nil {
+		return
+	}
+	for i := range r.rowChecks {
+		r.queueRowKey(r.rowChecks[i].check, values, colMap)
+	}
+}
+
+// deleted records a row deleted by a mutation. The values are ordered
+// according to colMap.
+func (r *deferredKeyRecorder) deleted(values tree.Datums, colMap catalog.TableColMap) {
+	if r == nil {
+		return
+	}
+	for i := range r.refChecks {
+		r.queueRefKey(r.refChecks[i], values, colMap)
+	}
+}
+
+// updated records a row updated by a mutation. Both the old and the new values
+// are ordered according to colMap.
+func (r *deferredKeyRecorder) updated(
+	oldValues, newValues tree.Datums, colMap catalog.TableColMap,
+) {
+	if r == nil {
+		return
+	}
+	// A row whose primary key changes must be checked again under its new key,
+	// since a key queued by an earlier statement no longer identifies it.
+	pkChanged := deferredColumnsChanged(r.pkColIDs, oldValues, newValues, colMap)
+	for _, c := range r.rowChecks {
+		if pkChanged || c.colIDs == nil ||
+			deferredColumnsChanged(c.colIDs, oldValues, newValues, colMap) {
+			r.queueRowKey(c.check, newValues, colMap)
+		}
+	}
+	for _, c := range r.refChecks {
+		if deferredColumnsChanged(c.colIDs, oldValues, newValues, colMap) {
+			r.queueRefKey(c, oldValues, colMap)
+		}
+	}
+}
+
+func (r *deferredKeyRecorder) queueRowKey(
+	c *deferredCheck, values tree.Datums, colMap catalog.TableColMap,
+) {
+	key, ok, _ := deferredCheckKey(r.pkColIDs, values, colMap)
+	if !ok {
+		c.fallBack()
+		return
+	}
+	c.add(&c.rowKeys, key)
+}
+
+func (r *deferredKeyRecorder) queueRefKey(
+	c deferredColumnsCheck, values tree.Datums, colMap catalog.TableColMap,
+) {
+	key, ok, hasNull := deferredCheckKey(c.colIDs, values, colMap)
+	if !ok {
+		c.check.fallBack()
+		return
+	}
+	// A key with NULL values cannot be referenced.
+	if !hasNull {
+		c.check.add(&c.check.refKeys, key)
+	}
+}
+
+// deferredCheckKey returns the values of the given columns. It returns false
+// if any of the columns is missing from colMap. hasNull is true if any of the
+// values is NULL.
+func deferredCheckKey(
+	colIDs []descpb.ColumnID, values tree.Datums, colMap catalog.TableColMap,
+) (key tree.Datums, ok, hasNull bool) {
+	key = make(tree.Datums, len(colIDs))
+	for i, id := range colIDs {
+		idx, found := colMap.Get(id)
+		if !found {
+			return nil, false, false
+		}
+		key[i] = values[idx]
+		hasNull = hasNull || values[idx] == tree.DNull
+	}
+	return key, true, hasNull
+}
+
+// deferredColumnsChanged returns true if any of the given columns has
+// different old and new values. Columns missing from colMap are not updated.
+func deferredColumnsChanged(
+	colIDs []descpb.ColumnID, oldValues, newValues tree.Datums, colMap catalog.TableColMap,
+) bool {
+	for _, id := range colIDs {
+		idx, ok := colMap.Get(id)
+		if !ok {
+			continue
+		}
+		if tree.AsStringWithFlags(oldValues[idx], tree.FmtSerializable) !=
+			tree.AsStringWithFlags(newValues[idx], tree.FmtSerializable) {
+			return true
+		}
+	}
+	return false
+}
+
+// queueDeferredConstraintChecks records the deferred constraints of the given
+// table which a mutation could violate, and returns the recorder for the keys
+// of the rows it writes. See deferredConstraints.queue.
+func (p *planner) queueDeferredConstraintChecks(
+	desc catalog.TableDescriptor, insertion, deletion bool,
+) *deferredKeyRecorder {
+	return p.extendedEvalCtx.deferredConstraints.queue(
+		&p.EvalContext().ConstraintModes, desc, insertion, deletion,
+	)
+}
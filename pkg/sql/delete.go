@@ -73,6 +73,7 @@ func (d *deleteNode) startExec(params runParams) error {
 			params.p.Mon().MakeBoundAccount(),
 			colinfo.ColTypeInfoFromResCols(d.columns))
 	}
+	d.run.td.deferredKeys = params.p.queueDeferredConstraintChecks(d.run.td.tableDesc(), false /* insertion */, true /* deletion */)
 	return d.run.td.init(params.ctx, params.p.txn, params.EvalContext())
 }
 
@@ -266,6 +266,9 @@ func (ds *ServerImpl) setupFlow(
 	monitor.Start(ctx, parentMonitor, reserved)
 	diskMonitor = execinfra.NewMonitor(ctx, ds.ParentDiskMonitor, "flow-disk-monitor")
 
+	// sd is the session data of the flow. It is set below, before any leaf txn
+	// is created.
+	var sd *sessiondata.SessionData
 	makeLeaf := func() (*kv.Txn, error) {
 		tis := req.LeafTxnInputState
 		if tis == nil {
@@ -279,7 +282,11 @@ func (ds *ServerImpl) setupFlow(
 		}
 		// The flow will run in a LeafTxn because we do not want each distributed
 		// Txn to heartbeat the transaction.
-		return kv.NewLeafTxn(ctx, ds.DB.KV(), roachpb.NodeID(req.Flow.Gateway), tis), nil
+		leaf := kv.NewLeafTxn(ctx, ds.DB.KV(), roachpb.NodeID(req.Flow.Gateway), tis)
+		if sd != nil {
+			leaf.SetLinearizableFollowerReads(sd.LinearizableFollowerReadsEnabled)
+		}
+		return leaf, nil
 	}
 
 	var evalCtx *eval.Context
@@ -290,6 +297,7 @@ func (ds *ServerImpl) setupFlow(
 		// this allows us to avoid an unnecessary deserialization of the eval
 		// context proto.
 		evalCtx = localState.EvalContext
+		sd = evalCtx.SessionData()
 		// We're about to mutate the evalCtx and we want to restore its original
 		// state once the flow cleans up. Note that we could have made a copy of
 		// the whole evalContext, but that isn't free, so we choose to restore
@@ -318,7 +326,8 @@ func (ds *ServerImpl) setupFlow(
 				"EvalContext expected to be populated when IsLocal is set")
 		}
 
-		sd, err := sessiondata.UnmarshalNonLocal(req.EvalContext.SessionData)
+		var err error
+		sd, err = sessiondata.UnmarshalNonLocal(req.EvalContext.SessionData)
 		if err != nil {
 			return ctx, nil, nil, err
 		}
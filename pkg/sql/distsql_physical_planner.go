@@ -452,6 +452,7 @@ func (dsp *DistSQLPlanner) mustWrapNode(planCtx *PlanningCtx, node planNode) boo
 	case *distinctNode:
 	case *exportNode:
 	case *filterNode:
+	case *foreignScanNode:
 	case *groupNode:
 	case *indexJoinNode:
 	case *invertedFilterNode:
@@ -537,6 +538,17 @@ func checkSupportForPlanNode(
 		}
 		return checkSupportForPlanNode(n.source.plan, distSQLVisitor)
 
+	case *foreignScanNode:
+		if n.filter != nil {
+			if err := checkExprForDistSQL(n.filter, distSQLVisitor); err != nil {
+				return cannotDistribute, err
+			}
+		}
+		if n.hardLimit != 0 {
+			return canDistribute, nil
+		}
+		return shouldDistribute, nil
+
 	case *groupNode:
 		rec, err := checkSupportForPlanNode(n.plan, distSQLVisitor)
 		if err != nil {
@@ -546,6 +558,18 @@ func checkSupportForPlanNode(
 			if agg.distsqlBlocklist {
 				return cannotDistribute, newQueryNotSupportedErrorf("aggregate %q cannot be executed with distsql", agg.funcName)
 			}
+			if agg.userDefined != nil {
+				// The support functions of a user-defined aggregate that can be
+				// distributed are inlined expressions, which must be supported
+				// by distSQL as well.
+				for _, fn := range []tree.TypedExpr{
+					agg.userDefined.StateFunc, agg.userDefined.FinalFunc, agg.userDefined.CombineFunc,
+				} {
+					if err := checkExprForDistSQL(fn, distSQLVisitor); err != nil {
+						return cannotDistribute, err
+					}
+				}
+			}
 		}
 		// Distribute aggregations if possible.
 		return rec.compose(shouldDistribute), nil
@@ -2274,6 +2298,11 @@ func (dsp *DistSQLPlanner) planTableReaders(
 	for i := range typs {
 		typs[i] = info.spec.FetchSpec.FetchedColumns[i].Type
 	}
+	if info.spec.FetchSpec.Pushdown.HasAggregations() {
+		// The table readers output the partial aggregates rather than the
+		// fetched columns.
+		typs = info.spec.FetchSpec.Pushdown.AggregateResultTypes(typs)
+	}
 
 	// Note: we will set a merge ordering below.
 	p.AddNoInputStage(corePlacement, info.post, typs, execinfrapb.Ordering{})
@@ -2391,11 +2420,19 @@ func (dsp *DistSQLPlanner) addAggregators(
 	aggregations := make([]execinfrapb.AggregatorSpec_Aggregation, len(n.funcs))
 	argumentsColumnTypes := make([][]*types.T, len(n.funcs))
 	for i, fholder := range n.funcs {
-		funcIdx, err := execinfrapb.GetAggregateFuncIdx(fholder.funcName)
-		if err != nil {
-			return err
+		if fholder.userDefined != nil {
+			if err := dsp.addUserDefinedAggregation(
+				ctx, planCtx, &aggregations[i], fholder.userDefined,
+			); err != nil {
+				return err
+			}
+		} else {
+			funcIdx, err := execinfrapb.GetAggregateFuncIdx(fholder.funcName)
+			if err != nil {
+				return err
+			}
+			aggregations[i].Func = execinfrapb.AggregatorSpec_Func(funcIdx)
 		}
-		aggregations[i].Func = execinfrapb.AggregatorSpec_Func(funcIdx)
 		aggregations[i].Distinct = fholder.isDistinct
 		for _, renderIdx := range fholder.argRenderIdxs {
 			aggregations[i].ColIdx = append(aggregations[i].ColIdx, uint32(p.PlanToStreamColMap[renderIdx]))
@@ -2430,6 +2467,65 @@ func (dsp *DistSQLPlanner) addAggregators(
 	})
 }
 
+// addUserDefinedAggregation populates the given aggregation with the support
+// functions of a user-defined aggregate.
+func (dsp *DistSQLPlanner) addUserDefinedAggregation(
+	ctx context.Context,
+	planCtx *PlanningCtx,
+	aggregation *execinfrapb.AggregatorSpec_Aggregation,
+	info *exec.UserDefinedAggInfo,
+) (err error) {
+	var ef physicalplan.ExprFactory
+	ef.Init(ctx, planCtx, nil /* indexVarMap */)
+	aggregation.Func = execinfrapb.UserDefined
+	aggregation.StateType = info.StateType
+	if aggregation.StateFunc, err = ef.Make(info.StateFunc); err != nil {
+		return err
+	}
+	if info.FinalFunc != nil {
+		if aggregation.FinalFunc, err = ef.Make(info.FinalFunc); err != nil {
+			return err
+		}
+	}
+	if info.CombineFunc != nil {
+		if aggregation.CombineFunc, err = ef.Make(info.CombineFunc); err != nil {
+			return err
+		}
+	}
+	if info.InitCond != tree.DNull {
+		if aggregation.InitCond, err = ef.Make(info.InitCond); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// userDefinedDistAggregationInfo describes the local and final stages of a
+// user-defined aggregate with a combine function. The local stage invokes the
+// state transition function of the aggregate, and the final stage merges the
+// partial states with the combine function. The support functions of each
+// stage are set up by planAggregators.
+var userDefinedDistAggregationInfo = physicalplan.DistAggregationInfo{
+	LocalStage: []execinfrapb.AggregatorSpec_Func{execinfrapb.UserDefined},
+	FinalStage: []physicalplan.FinalStageInfo{{
+		Fn:        execinfrapb.UserDefined,
+		LocalIdxs: []uint32{0},
+	}},
+}
+
+// getDistAggregationInfo returns the blueprint for planning the given
+// aggregation in a local and a final stage, and whether the aggregation
+// supports a local stage at all.
+func getDistAggregationInfo(
+	e *execinfrapb.AggregatorSpec_Aggregation,
+) (physicalplan.DistAggregationInfo, bool) {
+	if e.Func == execinfrapb.UserDefined {
+		return userDefinedDistAggregationInfo, !e.CombineFunc.Empty()
+	}
+	info, ok := physicalplan.DistAggregationTable[e.Func]
+	return info, ok
+}
+
 // planAggregators plans the aggregator processors. An evaluator stage is added
 // if necessary.
 // Invariants assumed:
@@ -2618,7 +2714,8 @@ func (dsp *DistSQLPlanner) planAggregators(
 	// We either have a local stage on each stream followed by a final stage, or
 	// just a final stage. We only use a local stage if:
 	//  - the previous stage is distributed on multiple nodes, and
-	//  - all aggregation functions support it, and
+	//  - all aggregation functions support it (user-defined aggregates only
+	//    do if they have a combine function), and
 	//  - no function is performing distinct aggregation.
 	//  TODO(radu): we could relax this by splitting the aggregation into two
 	//  different paths and joining on the results.
@@ -2630,7 +2727,7 @@ func (dsp *DistSQLPlanner) planAggregators(
 				break
 			}
 			// Check that the function supports a local stage.
-			if _, ok := physicalplan.DistAggregationTable[e.Func]; !ok {
+			if _, ok := getDistAggregationInfo(&e); !ok {
 				multiStage = false
 				break
 			}
@@ -2670,7 +2767,7 @@ func (dsp *DistSQLPlanner) planAggregators(
 		nFinalAgg := 0
 		needRender := false
 		for _, e := range info.aggregations {
-			info := physicalplan.DistAggregationTable[e.Func]
+			info, _ := getDistAggregationInfo(&e)
 			nLocalAgg += len(info.LocalStage)
 			nFinalAgg += len(info.FinalStage)
 			if info.FinalRendering != nil {
@@ -2705,7 +2802,7 @@ func (dsp *DistSQLPlanner) planAggregators(
 		// to all final aggregations.
 		finalIdx := 0
 		for _, e := range info.aggregations {
-			info := physicalplan.DistAggregationTable[e.Func]
+			info, _ := getDistAggregationInfo(&e)
 
 			// relToAbsLocalIdx maps each local stage for the given
 			// aggregation e to its final index in localAggs.  This
@@ -2727,6 +2824,14 @@ func (dsp *DistSQLPlanner) planAggregators(
 					ColIdx:       e.ColIdx,
 					FilterColIdx: e.FilterColIdx,
 				}
+				if localFunc == execinfrapb.UserDefined {
+					// The local stage of a user-defined aggregate only invokes
+					// its state transition function, so it outputs the partial
+					// state of the aggregate.
+					localAgg.StateFunc = e.StateFunc
+					localAgg.StateType = e.StateType
+					localAgg.InitCond = e.InitCond
+				}
 
 				isNewAgg := true
 				for j, prevLocalAgg := range localAggs {
@@ -2753,7 +2858,13 @@ func (dsp *DistSQLPlanner) planAggregators(
 					for _, c := range e.ColIdx {
 						argTypes = append(argTypes, inputTypes[c])
 					}
-					outputType, err := execagg.GetAggregateOutputType(localFunc, argTypes)
+					var outputType *types.T
+					var err error
+					if localFunc == execinfrapb.UserDefined {
+						outputType, err = execagg.GetUserDefinedAggregateOutputType(&localAgg)
+					} else {
+						outputType, err = execagg.GetAggregateOutputType(localFunc, argTypes)
+					}
 					if err != nil {
 						return err
 					}
@@ -2777,6 +2888,15 @@ func (dsp *DistSQLPlanner) planAggregators(
 					Func:   finalInfo.Fn,
 					ColIdx: argIdxs,
 				}
+				if finalInfo.Fn == execinfrapb.UserDefined {
+					// The final stage of a user-defined aggregate merges the
+					// partial states with its combine function, starting from
+					// the initial state, and then invokes its final function.
+					finalAgg.StateFunc = e.CombineFunc
+					finalAgg.StateType = e.StateType
+					finalAgg.FinalFunc = e.FinalFunc
+					finalAgg.InitCond = e.InitCond
+				}
 
 				isNewAgg := true
 				for i, prevFinalAgg := range finalAggs {
@@ -2804,7 +2924,13 @@ func (dsp *DistSQLPlanner) planAggregators(
 							// types for the current aggregation e.
 							argTypes = append(argTypes, intermediateTypes[argIdxs[i]])
 						}
-						outputType, err := execagg.GetAggregateOutputType(finalInfo.Fn, argTypes)
+						var outputType *types.T
+						var err error
+						if finalInfo.Fn == execinfrapb.UserDefined {
+							outputType, err = execagg.GetUserDefinedAggregateOutputType(&finalAgg)
+						} else {
+							outputType, err = execagg.GetAggregateOutputType(finalInfo.Fn, argTypes)
+						}
 						if err != nil {
 							return err
 						}
@@ -2923,7 +3049,7 @@ func (dsp *DistSQLPlanner) planAggregators(
 			var ef physicalplan.ExprFactory
 			ef.Init(ctx, planCtx, nil /* indexVarMap */)
 			for i, e := range info.aggregations {
-				info := physicalplan.DistAggregationTable[e.Func]
+				info, _ := getDistAggregationInfo(&e)
 				if info.FinalRendering == nil {
 					// mappedIdx corresponds to the index
 					// location of the result for this
@@ -2976,7 +3102,13 @@ func (dsp *DistSQLPlanner) planAggregators(
 			argTypes = append(argTypes, inputTypes[c])
 		}
 		argTypes = append(argTypes, info.argumentsColumnTypes[i]...)
-		returnTyp, err := execagg.GetAggregateOutputType(agg.Func, argTypes)
+		var returnTyp *types.T
+		var err error
+		if agg.Func == execinfrapb.UserDefined {
+			returnTyp, err = execagg.GetUserDefinedAggregateOutputType(&agg)
+		} else {
+			returnTyp, err = execagg.GetAggregateOutputType(agg.Func, argTypes)
+		}
 		if err != nil {
 			return err
 		}
@@ -3803,6 +3935,10 @@ func (dsp *DistSQLPlanner) createPhysPlanForPlanNode(
 		plan, err = dsp.createPlanForExport(ctx, planCtx, n)
 
 	case *filterNode:
+		var pushedDown bool
+		if plan, pushedDown, err = dsp.maybePlanFilterPushdown(ctx, planCtx, n); err != nil || pushedDown {
+			break
+		}
 		plan, err = dsp.createPhysPlanForPlanNode(ctx, planCtx, n.source.plan)
 		if err != nil {
 			return nil, err
@@ -3812,7 +3948,14 @@ func (dsp *DistSQLPlanner) createPhysPlanForPlanNode(
 			return nil, err
 		}
 
+	case *foreignScanNode:
+		plan, err = dsp.createPlanForForeignScan(ctx, planCtx, n)
+
 	case *groupNode:
+		var pushedDown bool
+		if plan, pushedDown, err = dsp.maybePlanAggregationPushdown(ctx, planCtx, n); err != nil || pushedDown {
+			break
+		}
 		plan, err = dsp.createPhysPlanForPlanNode(ctx, planCtx, n.plan)
 		if err != nil {
 			return nil, err
@@ -4900,6 +5043,13 @@ func (c *localScanParallelizationChecker) enterNode(
 	case *groupNode:
 		for _, f := range n.funcs {
 			c.prohibitParallelization = f.hasFilter()
+			if f.userDefined != nil && f.userDefined.HasRoutines() {
+				// Routines are evaluated by the planner, which doesn't support
+				// concurrency, and aggregates with routines are only supported
+				// by the row-based aggregator.
+				c.prohibitParallelization = true
+				return false, nil
+			}
 		}
 		return true, nil
 	case *indexJoinNode:
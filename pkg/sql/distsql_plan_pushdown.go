@@ -0,0 +1,339 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/fetchpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/colfetcher"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// canPushDownIntoScan returns whether a filter and / or aggregations can be
+// pushed down into the KV layer for the given scanNode. The pushdown is
+// evaluated by the direct columnar scans (or, if those can't be used, by the
+// ColBatchScan on the SQL side), so it requires the vectorized engine.
+func (dsp *DistSQLPlanner) canPushDownIntoScan(
+	ctx context.Context, planCtx *PlanningCtx, n *scanNode,
+) bool {
+	if !colfetcher.PushdownEnabled.Get(&dsp.st.SV) ||
+		!dsp.st.Version.IsActive(ctx, clusterversion.V24_2_KVScanPushdown) {
+		return false
+	}
+	evalCtx := planCtx.EvalContext()
+	if evalCtx == nil {
+		return false
+	}
+	sd := evalCtx.SessionData()
+	if !sd.DirectColumnarScansEnabled || sd.VectorizeMode == sessiondatapb.VectorizeOff {
+		return false
+	}
+	// The limits are enforced by the scanner on the number of KVs that were
+	// scanned, regardless of whether the rows pass the filter, so we cannot
+	// push down into scans with limits.
+	if n.hardLimit != 0 || n.softLimit != 0 || len(n.spans) == 0 {
+		return false
+	}
+	// The direct columnar scans don't support non-default locking.
+	if n.lockingStrength != descpb.ScanLockingStrength_FOR_NONE ||
+		n.lockingWaitPolicy != descpb.ScanLockingWaitPolicy_BLOCK {
+		return false
+	}
+	if n.containsSystemColumns || n.index.GetType() != descpb.IndexDescriptor_FORWARD {
+		return false
+	}
+	return true
+}
+
+// isPushdownSafeType returns whether values of the given type can be compared
+// and aggregated by the KV layer. User-defined types are excluded since they
+// require hydration, and the types whose comparison depends on the session
+// (like collated strings) are excluded too.
+func isPushdownSafeType(t *types.T) bool {
+	if t.UserDefined() {
+		return false
+	}
+	switch t.Family() {
+	case types.BoolFamily, types.IntFamily, types.FloatFamily, types.DecimalFamily,
+		types.DateFamily, types.TimestampFamily, types.TimestampTZFamily,
+		types.IntervalFamily, types.StringFamily, types.BytesFamily, types.UuidFamily:
+		return true
+	}
+	return false
+}
+
+// pushdownFilterChecker is a tree.Visitor that checks whether a filter
+// expression only consists of the expressions that the KV layer can evaluate:
+// comparisons of the columns and constants, combined with the boolean
+// operators.
+type pushdownFilterChecker struct {
+	ok bool
+}
+
+var _ tree.Visitor = &pushdownFilterChecker{}
+
+// VisitPre is part of the tree.Visitor interface.
+func (c *pushdownFilterChecker) VisitPre(expr tree.Expr) (recurse bool, newExpr tree.Expr) {
+	if !c.ok {
+		return false, expr
+	}
+	switch t := expr.(type) {
+	case *tree.IndexedVar:
+		c.ok = isPushdownSafeType(t.ResolvedType())
+		return false, expr
+
+	case *tree.AndExpr, *tree.OrExpr, *tree.NotExpr, *tree.ParenExpr,
+		*tree.IsNullExpr, *tree.IsNotNullExpr:
+		return true, expr
+
+	case *tree.ComparisonExpr:
+		left := t.TypedLeft().ResolvedType()
+		switch t.Operator.Symbol {
+		case treecmp.EQ, treecmp.NE, treecmp.LT, treecmp.LE, treecmp.GT, treecmp.GE,
+			treecmp.IsDistinctFrom, treecmp.IsNotDistinctFrom:
+			right := t.TypedRight().ResolvedType()
+			if left.Family() != types.UnknownFamily && right.Family() != types.UnknownFamily &&
+				!left.Equivalent(right) {
+				c.ok = false
+			}
+			return c.ok, expr
+		case treecmp.In, treecmp.NotIn:
+			var elems []tree.Expr
+			switch r := t.Right.(type) {
+			case *tree.DTuple:
+				for _, d := range r.D {
+					elems = append(elems, d)
+				}
+			case *tree.Tuple:
+				elems = r.Exprs
+			default:
+				c.ok = false
+				return false, expr
+			}
+			for _, e := range elems {
+				d, ok := e.(tree.Datum)
+				if !ok || (d != tree.DNull && !left.Equivalent(d.ResolvedType())) {
+					c.ok = false
+					return false, expr
+				}
+			}
+			// The elements of the tuple have been checked above, so only the
+			// left side needs to be visited.
+			tree.WalkExprConst(c, t.Left)
+			return false, expr
+		}
+		c.ok = false
+		return false, expr
+
+	case tree.Datum:
+		c.ok = t == tree.DNull || isPushdownSafeType(t.ResolvedType())
+		return false, expr
+	}
+	c.ok = false
+	return false, expr
+}
+
+// VisitPost is part of the tree.Visitor interface.
+func (c *pushdownFilterChecker) VisitPost(expr tree.Expr) tree.Expr { return expr }
+
+// serializePushdownFilter serializes the filter (which refers to the columns of
+// the scanNode) so that it can be included into the IndexFetchSpec. Note that
+// the filter is always serialized, even for local plans, since it is sent to
+// the KV layer. The boolean is false if the filter cannot be pushed down.
+func serializePushdownFilter(
+	ctx context.Context, planCtx *PlanningCtx, filter tree.TypedExpr,
+) (string, bool) {
+	c := pushdownFilterChecker{ok: true}
+	tree.WalkExprConst(&c, filter)
+	if !c.ok {
+		return "", false
+	}
+	fmtCtx := execinfrapb.ExprFmtCtxBase(ctx, planCtx.EvalContext())
+	fmtCtx.FormatNode(filter)
+	return fmtCtx.CloseAndGetString(), true
+}
+
+// createTableReadersWithPushdown is like createTableReaders but includes the
+// given pushdown into the IndexFetchSpec of the table readers.
+func (dsp *DistSQLPlanner) createTableReadersWithPushdown(
+	ctx context.Context,
+	planCtx *PlanningCtx,
+	n *scanNode,
+	pushdown *fetchpb.IndexFetchSpec_Pushdown,
+) (*PhysicalPlan, error) {
+	spec, post, err := initTableReaderSpecTemplate(n, planCtx.ExtendedEvalCtx.Codec)
+	if err != nil {
+		return nil, err
+	}
+	spec.FetchSpec.Pushdown = pushdown
+	reqOrdering := n.reqOrdering
+	if pushdown.HasAggregations() {
+		// Each table reader produces a single row with the partial aggregates.
+		reqOrdering = nil
+	}
+
+	p := planCtx.NewPhysicalPlan()
+	err = dsp.planTableReaders(
+		ctx,
+		planCtx,
+		p,
+		&tableReaderPlanningInfo{
+			spec:              spec,
+			post:              post,
+			desc:              n.desc,
+			spans:             n.spans,
+			reverse:           n.reverse,
+			parallelize:       n.parallelize,
+			estimatedRowCount: n.estimatedRowCount,
+			reqOrdering:       reqOrdering,
+		},
+	)
+	return p, err
+}
+
+// maybePlanFilterPushdown plans the table readers for a filterNode on top of a
+// scanNode, with the filter pushed down into the KV layer. The boolean is false
+// if the filter cannot be pushed down, in which case the plan is nil.
+func (dsp *DistSQLPlanner) maybePlanFilterPushdown(
+	ctx context.Context, planCtx *PlanningCtx, n *filterNode,
+) (*PhysicalPlan, bool, error) {
+	scan, ok := n.source.plan.(*scanNode)
+	if !ok || !dsp.canPushDownIntoScan(ctx, planCtx, scan) {
+		return nil, false, nil
+	}
+	filter, ok := serializePushdownFilter(ctx, planCtx, n.filter)
+	if !ok {
+		return nil, false, nil
+	}
+	plan, err := dsp.createTableReadersWithPushdown(
+		ctx, planCtx, scan, &fetchpb.IndexFetchSpec_Pushdown{Filter: filter},
+	)
+	if err != nil {
+		return nil, false, err
+	}
+	return plan, true, nil
+}
+
+// maybePlanAggregationPushdown plans a scalar groupNode with only count, sum,
+// min, and max aggregate functions on top of a scanNode (possibly with a
+// filterNode in between) such that the table readers compute the partial
+// aggregates in the KV layer, and only the final aggregation stage is planned
+// on the SQL side. The boolean is false if the aggregations cannot be pushed
+// down, in which case the plan is nil.
+func (dsp *DistSQLPlanner) maybePlanAggregationPushdown(
+	ctx context.Context, planCtx *PlanningCtx, n *groupNode,
+) (*PhysicalPlan, bool, error) {
+	if !n.isScalar || len(n.groupCols) > 0 {
+		return nil, false, nil
+	}
+	var filter tree.TypedExpr
+	scan, ok := n.plan.(*scanNode)
+	if !ok {
+		f, isFilter := n.plan.(*filterNode)
+		if !isFilter {
+			return nil, false, nil
+		}
+		if scan, ok = f.source.plan.(*scanNode); !ok {
+			return nil, false, nil
+		}
+		filter = f.filter
+	}
+	if !dsp.canPushDownIntoScan(ctx, planCtx, scan) {
+		return nil, false, nil
+	}
+
+	pushdown := &fetchpb.IndexFetchSpec_Pushdown{
+		Aggregations: make([]fetchpb.IndexFetchSpec_Pushdown_Aggregation, len(n.funcs)),
+	}
+	// The final aggregations combine the partial aggregates, the i-th of which
+	// is in the i-th column of the table readers' output.
+	aggregations := make([]execinfrapb.AggregatorSpec_Aggregation, len(n.funcs))
+	argumentsColumnTypes := make([][]*types.T, len(n.funcs))
+	for i, fholder := range n.funcs {
+		if fholder.isDistinct || fholder.hasFilter() || fholder.userDefined != nil ||
+			len(fholder.arguments) > 0 {
+			return nil, false, nil
+		}
+		agg := &pushdown.Aggregations[i]
+		switch fholder.funcName {
+		case "count_rows":
+			agg.Func = fetchpb.IndexFetchSpec_Pushdown_COUNT_ROWS
+			aggregations[i].Func = execinfrapb.SumInt
+		case "count":
+			agg.Func = fetchpb.IndexFetchSpec_Pushdown_COUNT
+			aggregations[i].Func = execinfrapb.SumInt
+		case "sum":
+			agg.Func = fetchpb.IndexFetchSpec_Pushdown_SUM
+			aggregations[i].Func = execinfrapb.Sum
+		case "min":
+			agg.Func = fetchpb.IndexFetchSpec_Pushdown_MIN
+			aggregations[i].Func = execinfrapb.Min
+		case "max":
+			agg.Func = fetchpb.IndexFetchSpec_Pushdown_MAX
+			aggregations[i].Func = execinfrapb.Max
+		default:
+			return nil, false, nil
+		}
+		if agg.Func == fetchpb.IndexFetchSpec_Pushdown_COUNT_ROWS {
+			if len(fholder.argRenderIdxs) != 0 {
+				return nil, false, nil
+			}
+		} else {
+			if len(fholder.argRenderIdxs) != 1 {
+				return nil, false, nil
+			}
+			colIdx := int(fholder.argRenderIdxs[0])
+			typ := scan.cols[colIdx].GetType()
+			if !isPushdownSafeType(typ) {
+				return nil, false, nil
+			}
+			if agg.Func == fetchpb.IndexFetchSpec_Pushdown_SUM {
+				switch typ.Family() {
+				case types.IntFamily, types.FloatFamily, types.DecimalFamily:
+				default:
+					return nil, false, nil
+				}
+			}
+			agg.ColIdx = uint32(colIdx)
+		}
+		aggregations[i].ColIdx = []uint32{uint32(i)}
+	}
+	if filter != nil {
+		if pushdown.Filter, ok = serializePushdownFilter(ctx, planCtx, filter); !ok {
+			return nil, false, nil
+		}
+	}
+
+	plan, err := dsp.createTableReadersWithPushdown(ctx, planCtx, scan, pushdown)
+	if err != nil {
+		return nil, false, err
+	}
+	// Note that every table reader produces exactly one row, even if it
+	// didn't scan any rows, so the final SUM_INT of the partial counts is
+	// never NULL.
+	if err = dsp.planAggregators(ctx, planCtx, plan, &aggregatorPlanningInfo{
+		aggregations:         aggregations,
+		argumentsColumnTypes: argumentsColumnTypes,
+		isScalar:             true,
+		reqOrdering:          n.reqOrdering,
+		estimatedRowCount:    n.estimatedRowCount,
+	}); err != nil {
+		return nil, false, err
+	}
+	return plan, true, nil
+}
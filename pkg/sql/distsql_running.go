@@ -2110,7 +2110,13 @@ func (dsp *DistSQLPlanner) PlanAndRunCascadesAndChecks(
 			}
 		}
 
-		log.VEventf(ctx, 2, "executing cascade for constraint %s", plan.cascades[i].FKConstraint.Name())
+		if fk := plan.cascades[i].FKConstraint; fk != nil {
+			log.VEventf(ctx, 2, "executing cascade for constraint %s", fk.Name())
+		} else if trigger := plan.cascades[i].Trigger; trigger != nil {
+			log.VEventf(ctx, 2, "executing AFTER trigger %s", trigger.Name())
+		} else {
+			log.VEventf(ctx, 2, "executing MERGE delete")
+		}
 
 		// We place a sequence point before every cascade, so that each subsequent
 		// cascade can observe the writes by the previous step. However, The
@@ -179,6 +179,9 @@ func (e *distSQLSpecExecFactory) ConstructScan(
 			},
 		)
 	}
+	if table.IsForeignTable() {
+		return nil, unimplemented.NewWithIssue(47473, "experimental opt-driven distsql planning: foreign table scan")
+	}
 
 	// Although we don't yet recommend distributing plans where soft limits
 	// propagate to scan nodes because we don't have infrastructure to only
@@ -77,6 +77,16 @@ func (p *planner) DropFunction(ctx context.Context, n *tree.DropRoutine) (ret pl
 		if err != nil {
 			return nil, err
 		}
+		if n.Aggregate && !mut.IsAggregate() {
+			return nil, pgerror.Newf(
+				pgcode.WrongObjectType, "function %s is not an aggregate", mut.Name,
+			)
+		} else if !n.Aggregate && mut.IsAggregate() {
+			return nil, errors.WithHint(
+				pgerror.Newf(pgcode.WrongObjectType, "%q is an aggregate function", mut.Name),
+				"Use DROP AGGREGATE to drop aggregate functions.",
+			)
+		}
 		if n.DropBehavior != tree.DropCascade && len(mut.DependedOnBy) > 0 {
 			dependedOnByIDs := make([]descpb.ID, 0, len(mut.DependedOnBy))
 			for _, ref := range mut.DependedOnBy {
@@ -307,6 +307,21 @@ const (
 	ignoreIdxConstraint dropIndexConstraintBehavior = false
 )
 
+// exclusionConstraintBackedByIndex returns the exclusion constraint of the
+// table which is backed by the index with the given ID, or nil if there is
+// none.
+func exclusionConstraintBackedByIndex(
+	desc catalog.TableDescriptor, indexID descpb.IndexID,
+) *descpb.ExclusionConstraint {
+	exclusionConstraints := desc.GetExclusionConstraints()
+	for i := range exclusionConstraints {
+		if exclusionConstraints[i].IndexID == indexID {
+			return &exclusionConstraints[i]
+		}
+	}
+	return nil
+}
+
 func (p *planner) dropIndexByName(
 	ctx context.Context,
 	tn *tree.TableName,
@@ -350,6 +365,17 @@ func (p *planner) dropIndexByName(
 		)
 	}
 
+	if ec := exclusionConstraintBackedByIndex(tableDesc, idx.GetID()); ec != nil {
+		if behavior != tree.DropCascade && constraintBehavior != ignoreIdxConstraint {
+			return errors.WithHint(
+				pgerror.Newf(pgcode.DependentObjectsStillExist,
+					"index %q is in use as exclusion constraint", idx.GetName()),
+				"use CASCADE if you really want to drop it.",
+			)
+		}
+		tableDesc.DropExclusionConstraint(ec.Name)
+	}
+
 	// Check if requires CCL binary for eventual zone config removal.
 	_, zone, _, err := GetZoneConfigInTxn(
 		ctx, p.txn, p.Descriptors(), tableDesc.ID, nil /* index */, "", false,
@@ -38,10 +38,28 @@ type dropTypeNode struct {
 var _ planNode = &dropTypeNode{n: nil}
 
 func (p *planner) DropType(ctx context.Context, n *tree.DropType) (planNode, error) {
+	return p.dropTypeOrDomain(ctx, n, "DROP TYPE", false /* domain */)
+}
+
+// DropDomain drops one or more domains.
+// Privileges: ownership of the domains.
+func (p *planner) DropDomain(ctx context.Context, n *tree.DropDomain) (planNode, error) {
+	return p.dropTypeOrDomain(ctx, &tree.DropType{
+		Names:        n.Names,
+		IfExists:     n.IfExists,
+		DropBehavior: n.DropBehavior,
+	}, "DROP DOMAIN", true /* domain */)
+}
+
+// dropTypeOrDomain plans the dropping of the types named in n. If domain is
+// true, all of the types must be domains, and otherwise none of them may be.
+func (p *planner) dropTypeOrDomain(
+	ctx context.Context, n *tree.DropType, stmtTag string, domain bool,
+) (planNode, error) {
 	if err := checkSchemaChangeEnabled(
 		ctx,
 		p.ExecCfg(),
-		"DROP TYPE",
+		stmtTag,
 	); err != nil {
 		return nil, err
 	}
@@ -51,7 +69,7 @@ func (p *planner) DropType(ctx context.Context, n *tree.DropType) (planNode, err
 		toDrop: make(map[descpb.ID]*typedesc.Mutable),
 	}
 	if n.DropBehavior == tree.DropCascade {
-		return nil, unimplemented.NewWithIssue(51480, "DROP TYPE CASCADE is not yet supported")
+		return nil, unimplemented.NewWithIssuef(51480, "%s CASCADE is not yet supported", stmtTag)
 	}
 	for _, name := range n.Names {
 		// Resolve the desired type descriptor.
@@ -66,6 +84,15 @@ func (p *planner) DropType(ctx context.Context, n *tree.DropType) (planNode, err
 		if _, ok := node.toDrop[typeDesc.ID]; ok {
 			continue
 		}
+		if isDomain := typeDesc.Kind == descpb.TypeDescriptor_DOMAIN; isDomain != domain {
+			if domain {
+				return nil, pgerror.Newf(pgcode.WrongObjectType, "%q is not a domain", name)
+			}
+			return nil, errors.WithHint(
+				pgerror.Newf(pgcode.WrongObjectType, "%q is not a type", name),
+				"Use DROP DOMAIN to remove a domain.",
+			)
+		}
 		switch typeDesc.Kind {
 		case descpb.TypeDescriptor_ALIAS:
 			// The implicit array types are not directly droppable.
@@ -84,6 +84,10 @@ func GetAggregateConstructor(
 	aggInfo *execinfrapb.AggregatorSpec_Aggregation,
 	inputTypes []*types.T,
 ) (constructor AggregateConstructor, arguments tree.Datums, outputType *types.T, err error) {
+	if aggInfo.Func == execinfrapb.UserDefined {
+		constructor, outputType, err = getUserDefinedAggregateInfo(ctx, evalCtx, semaCtx, aggInfo, inputTypes)
+		return constructor, nil /* arguments */, outputType, err
+	}
 	argTypes := make([]*types.T, len(aggInfo.ColIdx)+len(aggInfo.Arguments))
 	for j, c := range aggInfo.ColIdx {
 		if c >= uint32(len(inputTypes)) {
@@ -124,6 +128,175 @@ func GetAggregateOutputType(
 	return outputType, err
 }
 
+// getUserDefinedAggregateInfo returns the aggregate constructor and the return
+// type for the given user-defined aggregate.
+func getUserDefinedAggregateInfo(
+	ctx context.Context,
+	evalCtx *eval.Context,
+	semaCtx *tree.SemaContext,
+	aggInfo *execinfrapb.AggregatorSpec_Aggregation,
+	inputTypes []*types.T,
+) (AggregateConstructor, *types.T, error) {
+	// The parameters of the state transition function are the state followed
+	// by the arguments of the aggregate.
+	stateTypes := []*types.T{aggInfo.StateType}
+	stateFuncTypes := make([]*types.T, 0, len(aggInfo.ColIdx)+1)
+	stateFuncTypes = append(stateFuncTypes, aggInfo.StateType)
+	for _, c := range aggInfo.ColIdx {
+		if c >= uint32(len(inputTypes)) {
+			return nil, nil, errors.Errorf("ColIdx out of range (%d)", aggInfo.ColIdx)
+		}
+		stateFuncTypes = append(stateFuncTypes, inputTypes[c])
+	}
+	stateFunc, err := makeUserDefinedAggregateFunc(
+		ctx, evalCtx, semaCtx, aggInfo.StateFunc, stateFuncTypes,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	outputType := stateFunc.ResolvedType()
+	var finalFunc tree.TypedExpr
+	if !aggInfo.FinalFunc.Empty() {
+		finalFunc, err = makeUserDefinedAggregateFunc(
+			ctx, evalCtx, semaCtx, aggInfo.FinalFunc, stateTypes,
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+		outputType = finalFunc.ResolvedType()
+	}
+	initCond := tree.DNull
+	if !aggInfo.InitCond.Empty() {
+		h := execinfrapb.ExprHelper{}
+		if err := h.Init(ctx, aggInfo.InitCond, nil /* types */, semaCtx, evalCtx); err != nil {
+			return nil, nil, errors.Wrapf(err, "%s", aggInfo.InitCond)
+		}
+		if initCond, err = h.Eval(ctx, nil /* row */); err != nil {
+			return nil, nil, errors.Wrapf(err, "%s", aggInfo.InitCond)
+		}
+	}
+	// A routine is strict if it is not called on NULL input. Inlined support
+	// functions are always called on NULL input.
+	var strict bool
+	if routine, ok := stateFunc.(*tree.RoutineExpr); ok {
+		strict = !routine.CalledOnNullInput
+	}
+	constructor := func(evalCtx *eval.Context, _ tree.Datums) eval.AggregateFunc {
+		return builtins.NewUserDefinedAggregate(
+			evalCtx,
+			newUserDefinedAggregateFunc(stateFunc),
+			newUserDefinedAggregateFunc(finalFunc),
+			strict,
+			initCond,
+		)
+	}
+	return constructor, outputType, nil
+}
+
+// GetUserDefinedAggregateOutputType returns the output type of the given
+// user-defined aggregate. It must only be called during physical planning,
+// when the support functions of the aggregate are available locally.
+func GetUserDefinedAggregateOutputType(
+	aggInfo *execinfrapb.AggregatorSpec_Aggregation,
+) (*types.T, error) {
+	fn := aggInfo.StateFunc.LocalExpr
+	if !aggInfo.FinalFunc.Empty() {
+		fn = aggInfo.FinalFunc.LocalExpr
+	}
+	if fn == nil {
+		return nil, errors.AssertionFailedf(
+			"expected local support functions for a user-defined aggregate",
+		)
+	}
+	return fn.ResolvedType(), nil
+}
+
+// makeUserDefinedAggregateFunc returns the given support function of a
+// user-defined aggregate. The function is either a routine, which is only
+// available when the aggregate is planned on the gateway, or an inlined
+// expression whose parameters have the given types.
+func makeUserDefinedAggregateFunc(
+	ctx context.Context,
+	evalCtx *eval.Context,
+	semaCtx *tree.SemaContext,
+	fn execinfrapb.Expression,
+	paramTypes []*types.T,
+) (tree.TypedExpr, error) {
+	if routine, ok := fn.LocalExpr.(*tree.RoutineExpr); ok {
+		return routine, nil
+	}
+	if fn.LocalExpr == nil && fn.Expr == "" {
+		return nil, errors.AssertionFailedf(
+			"expected support function for a user-defined aggregate",
+		)
+	}
+	h := execinfrapb.ExprHelper{}
+	if err := h.Init(ctx, fn, paramTypes, semaCtx, evalCtx); err != nil {
+		return nil, errors.Wrapf(err, "%s", fn)
+	}
+	return h.Expr(), nil
+}
+
+// newUserDefinedAggregateFunc wraps the given support function of a
+// user-defined aggregate so that it can be invoked by the aggregate. It
+// returns nil if fn is nil.
+func newUserDefinedAggregateFunc(fn tree.TypedExpr) builtins.UserDefinedAggregateFunc {
+	switch t := fn.(type) {
+	case nil:
+		return nil
+	case *tree.RoutineExpr:
+		return userDefinedAggregateRoutine{routine: t}
+	default:
+		return &userDefinedAggregateExpr{expr: t}
+	}
+}
+
+// userDefinedAggregateRoutine is a support function of a user-defined
+// aggregate which is evaluated as a routine by the planner.
+type userDefinedAggregateRoutine struct {
+	routine *tree.RoutineExpr
+}
+
+var _ builtins.UserDefinedAggregateFunc = userDefinedAggregateRoutine{}
+
+// Eval is part of the builtins.UserDefinedAggregateFunc interface.
+func (r userDefinedAggregateRoutine) Eval(
+	ctx context.Context, evalCtx *eval.Context, args tree.Datums,
+) (tree.Datum, error) {
+	return evalCtx.Planner.EvalRoutineExpr(ctx, r.routine, args)
+}
+
+// userDefinedAggregateExpr is an inlined support function of a user-defined
+// aggregate. The i-th argument of the function is the value of the IndexedVar
+// with ordinal i.
+type userDefinedAggregateExpr struct {
+	expr tree.TypedExpr
+	args tree.Datums
+}
+
+var _ builtins.UserDefinedAggregateFunc = &userDefinedAggregateExpr{}
+var _ eval.IndexedVarContainer = &userDefinedAggregateExpr{}
+
+// Eval is part of the builtins.UserDefinedAggregateFunc interface.
+func (e *userDefinedAggregateExpr) Eval(
+	ctx context.Context, evalCtx *eval.Context, args tree.Datums,
+) (tree.Datum, error) {
+	e.args = args
+	evalCtx.PushIVarContainer(e)
+	defer evalCtx.PopIVarContainer()
+	return eval.Expr(ctx, evalCtx, e.expr)
+}
+
+// IndexedVarEval is part of the eval.IndexedVarContainer interface.
+func (e *userDefinedAggregateExpr) IndexedVarEval(idx int) (tree.Datum, error) {
+	return e.args[idx], nil
+}
+
+// IndexedVarResolvedType is part of the tree.IndexedVarContainer interface.
+func (e *userDefinedAggregateExpr) IndexedVarResolvedType(idx int) *types.T {
+	return e.args[idx].ResolvedType()
+}
+
 // GetWindowFunctionInfo returns windowFunc constructor and the return type
 // when given fn is applied to given inputTypes.
 func GetWindowFunctionInfo(
@@ -179,6 +179,26 @@ func (tr *TableReaderSpec) summary() (string, []string) {
 		details = append(details, spanStr.String())
 	}
 
+	if p := tr.FetchSpec.Pushdown; p != nil {
+		if p.Filter != "" {
+			details = append(details, fmt.Sprintf("Pushdown filter: %s", p.Filter))
+		}
+		if len(p.Aggregations) > 0 {
+			var aggStr strings.Builder
+			aggStr.WriteString("Pushdown aggregations: ")
+			for i, agg := range p.Aggregations {
+				if i > 0 {
+					aggStr.WriteString(", ")
+				}
+				aggStr.WriteString(agg.Func.String())
+				if agg.Func != fetchpb.IndexFetchSpec_Pushdown_COUNT_ROWS {
+					fmt.Fprintf(&aggStr, "(@%d)", agg.ColIdx+1)
+				}
+			}
+			details = append(details, aggStr.String())
+		}
+	}
+
 	return "TableReader", details
 }
 
@@ -517,6 +537,16 @@ func (c *ReadImportDataSpec) summary() (string, []string) {
 	return "ReadImportData", ss
 }
 
+// summary implements the diagramCellType interface.
+func (s *ForeignScanSpec) summary() (string, []string) {
+	details := []string{s.Table.Name}
+	if !s.Filter.Empty() {
+		details = append(details, fmt.Sprintf("Filter: %s", s.Filter))
+	}
+	details = append(details, s.URIs...)
+	return "ForeignScan", details
+}
+
 // summary implements the diagramCellType interface.
 func (s *StreamIngestionDataSpec) summary() (string, []string) {
 	const (
@@ -64,6 +64,20 @@ func (a AggregatorSpec_Aggregation) Equals(b AggregatorSpec_Aggregation) bool {
 			return false
 		}
 	}
+	if a.Func == UserDefined {
+		// The support functions of user-defined aggregates may be routines,
+		// which are not serialized, so they are compared by identity.
+		for _, p := range [][2]*Expression{
+			{&a.StateFunc, &b.StateFunc},
+			{&a.FinalFunc, &b.FinalFunc},
+			{&a.CombineFunc, &b.CombineFunc},
+			{&a.InitCond, &b.InitCond},
+		} {
+			if p[0].Expr != p[1].Expr || p[0].LocalExpr != p[1].LocalExpr {
+				return false
+			}
+		}
+	}
 	return true
 }
 
@@ -19,6 +19,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/advisorylock"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
@@ -570,6 +571,30 @@ func (ep *DummyEvalPlanner) InsertTemporarySchema(
 
 }
 
+// SendNotification is part of the eval.Planner interface.
+func (ep *DummyEvalPlanner) SendNotification(context.Context, string, string) error {
+	return errors.WithStack(errEvalPlanner)
+}
+
+// AcquireAdvisoryLock is part of the eval.Planner interface.
+func (ep *DummyEvalPlanner) AcquireAdvisoryLock(
+	context.Context, advisorylock.Key, bool, bool, bool,
+) (bool, error) {
+	return false, errors.WithStack(errEvalPlanner)
+}
+
+// ReleaseAdvisoryLock is part of the eval.Planner interface.
+func (ep *DummyEvalPlanner) ReleaseAdvisoryLock(
+	context.Context, advisorylock.Key, bool,
+) (bool, error) {
+	return false, errors.WithStack(errEvalPlanner)
+}
+
+// ReleaseAllAdvisoryLocks is part of the eval.Planner interface.
+func (ep *DummyEvalPlanner) ReleaseAllAdvisoryLocks(context.Context) error {
+	return errors.WithStack(errEvalPlanner)
+}
+
 // DummyPrivilegedAccessor implements the tree.PrivilegedAccessor interface by returning errors.
 type DummyPrivilegedAccessor struct{}
 
@@ -0,0 +1,224 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/physicalplan"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+)
+
+// A foreignScanNode reads the rows of a foreign table from the files named by
+// its options. Like scanNode, it is only ever executed as part of a DistSQL
+// flow, where it is planned as a set of ForeignScan processors.
+type foreignScanNode struct {
+	desc catalog.TableDescriptor
+
+	// The table columns produced by the scan.
+	cols []catalog.Column
+	// There is a 1-1 correspondence between cols and resultColumns.
+	resultColumns colinfo.ResultColumns
+
+	// filter, if set, is applied to the rows as they are read. It refers to
+	// resultColumns by ordinal.
+	filter tree.TypedExpr
+
+	// if non-zero, hardLimit indicates that the foreignScanNode only needs to
+	// provide this many rows.
+	hardLimit int64
+}
+
+func (n *foreignScanNode) startExec(params runParams) error {
+	panic("foreignScanNode can't be run in local mode")
+}
+
+func (n *foreignScanNode) Next(params runParams) (bool, error) {
+	panic("foreignScanNode can't be run in local mode")
+}
+
+func (n *foreignScanNode) Values() tree.Datums {
+	panic("foreignScanNode can't be run in local mode")
+}
+
+func (n *foreignScanNode) Close(context.Context) {}
+
+// constructForeignScan creates a foreignScanNode for a scan of a foreign
+// table. Foreign tables have no indexes that can be used to constrain or order
+// the scan, and their rows cannot be locked.
+func (ef *execFactory) constructForeignScan(
+	table cat.Table, params exec.ScanParams, reqOrdering exec.OutputOrdering,
+) (exec.Node, error) {
+	tabDesc := table.(*optTable).desc
+	colCfg := makeScanColumnsConfig(table, params.NeededCols)
+	cols, err := initColsForScan(tabDesc, colCfg)
+	if err != nil {
+		return nil, err
+	}
+	resultColumns := colinfo.ResultColumnsFromColumns(tabDesc.GetID(), cols)
+	if params.IndexConstraint != nil && params.IndexConstraint.IsContradiction() {
+		return newZeroNode(resultColumns), nil
+	}
+	switch {
+	case params.IndexConstraint != nil || params.InvertedConstraint != nil:
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"constrained scans of foreign table %q are not supported", tabDesc.GetName())
+	case len(reqOrdering) > 0:
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"ordered scans of foreign table %q are not supported", tabDesc.GetName())
+	case params.Locking.IsLocking():
+		return nil, pgerror.Newf(pgcode.WrongObjectType,
+			"cannot lock rows in foreign table %q", tabDesc.GetName())
+	}
+	for _, col := range cols {
+		if col.IsSystemColumn() {
+			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"system column %q is not supported on foreign table %q", col.GetName(), tabDesc.GetName())
+		}
+	}
+	return &foreignScanNode{
+		desc:          tabDesc,
+		cols:          cols,
+		resultColumns: resultColumns,
+		hardLimit:     params.HardLimit,
+	}, nil
+}
+
+// createPlanForForeignScan plans the ForeignScan processors of a
+// foreignScanNode. The files of the table are distributed round-robin over
+// the available SQL instances, unless the plan is local or limited, in which
+// case all files are read on the gateway. The user must be allowed to use the
+// table's server, since the files are read with its credentials.
+func (dsp *DistSQLPlanner) createPlanForForeignScan(
+	ctx context.Context, planCtx *PlanningCtx, n *foreignScanNode,
+) (*PhysicalPlan, error) {
+	ft := n.desc.GetForeignTable()
+	if err := planCtx.planner.checkForeignServerUsage(ctx, ft.Server); err != nil {
+		return nil, err
+	}
+	location, format, err := foreignTableFileFormat(ft)
+	if err != nil {
+		return nil, err
+	}
+	uris, err := expandForeignTableLocation(ctx, planCtx, ft.Server, location)
+	if err != nil {
+		return nil, err
+	}
+
+	var post execinfrapb.PostProcessSpec
+	instances := []base.SQLInstanceID{dsp.gatewaySQLInstanceID}
+	if n.hardLimit != 0 {
+		post.Limit = uint64(n.hardLimit)
+	} else if !planCtx.isLocal && len(uris) > 1 {
+		all, err := dsp.GetAllInstancesByLocality(ctx, roachpb.Locality{})
+		if err != nil {
+			return nil, err
+		}
+		instances = instances[:0]
+		for i := range all {
+			instances = append(instances, all[i].InstanceID)
+		}
+		if len(instances) > len(uris) {
+			instances = instances[:len(uris)]
+		}
+	}
+
+	columnIDs := make([]descpb.ColumnID, len(n.cols))
+	for i, col := range n.cols {
+		columnIDs[i] = col.GetID()
+	}
+	var filter execinfrapb.Expression
+	if n.filter != nil {
+		filter, err = physicalplan.MakeExpression(ctx, n.filter, planCtx, nil /* indexVarMap */)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	corePlacement := make([]physicalplan.ProcessorCorePlacement, len(instances))
+	for i, instance := range instances {
+		spec := &execinfrapb.ForeignScanSpec{
+			Table:     *n.desc.TableDesc(),
+			Format:    format,
+			ColumnIDs: columnIDs,
+			Filter:    filter,
+			UserProto: planCtx.planner.User().EncodeProto(),
+		}
+		for j := i; j < len(uris); j += len(instances) {
+			spec.URIs = append(spec.URIs, uris[j])
+		}
+		corePlacement[i].SQLInstanceID = instance
+		corePlacement[i].Core.ForeignScan = spec
+	}
+
+	typs := getTypesFromResultColumns(n.resultColumns)
+	p := planCtx.NewPhysicalPlan()
+	p.AddNoInputStage(corePlacement, post, typs, execinfrapb.Ordering{})
+	p.PlanToStreamColMap = identityMap(make([]int, len(typs)), len(typs))
+	return p, nil
+}
+
+// expandForeignTableLocation returns the URIs of the files of a foreign table
+// read through the given server. The location may contain glob patterns, in
+// which case it is expanded to all matching files.
+func expandForeignTableLocation(
+	ctx context.Context, planCtx *PlanningCtx, server, location string,
+) ([]string, error) {
+	location = strings.TrimPrefix(location, "/")
+	prefix := cloud.GetPrefixBeforeWildcard(location)
+	if len(prefix) == len(location) {
+		return []string{foreignTableURI(server, location)}, nil
+	}
+	pattern := location[len(prefix):]
+	store, err := planCtx.ExtendedEvalCtx.ExecCfg.DistSQLSrv.ExternalStorageFromURI(
+		ctx, foreignTableURI(server, prefix), planCtx.planner.User(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := store.Close(); err != nil {
+			log.Warningf(ctx, "failed to close external storage: %v", err)
+		}
+	}()
+	var uris []string
+	if err := store.List(ctx, "", "", func(s string) error {
+		ok, err := path.Match(pattern, strings.TrimPrefix(s, "/"))
+		if ok {
+			uris = append(uris, foreignTableURI(server, prefix+strings.TrimPrefix(s, "/")))
+		}
+		return err
+	}); err != nil {
+		return nil, errors.Wrapf(err, "listing files of foreign table location %q", location)
+	}
+	return uris, nil
+}
+
+// foreignTableURI returns the URI of the file at location on the given
+// server, which is an External Connection.
+func foreignTableURI(server, location string) string {
+	return "external://" + server + "/" + location
+}
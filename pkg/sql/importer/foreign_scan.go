@@ -0,0 +1,589 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra/execopnode"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowexec"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding/csv"
+	"github.com/cockroachdb/cockroach/pkg/util/ioctx"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/parquet"
+	"github.com/cockroachdb/errors"
+	"github.com/linkedin/goavro/v2"
+)
+
+// foreignScanProcessor is a processor that reads the rows of a foreign table
+// from the files named in its spec. Files are read one at a time, in order.
+type foreignScanProcessor struct {
+	execinfra.ProcessorBase
+
+	spec    execinfrapb.ForeignScanSpec
+	evalCtx *eval.Context
+
+	// cols are the table columns produced by the processor. targets contains,
+	// for each of them, its ordinal among the visible columns of the table,
+	// which is also its position in the files. Hidden columns, such as the
+	// rowid, have a target of -1, and are generated rather than read.
+	cols           []catalog.Column
+	targets        []int
+	numVisibleCols int
+
+	filter    execinfrapb.ExprHelper
+	hasFilter bool
+
+	// fileIdx is the index in spec.URIs of the next file to read.
+	fileIdx int
+	file    foreignFileReader
+
+	datums tree.Datums
+	row    rowenc.EncDatumRow
+}
+
+var _ execinfra.Processor = &foreignScanProcessor{}
+var _ execinfra.RowSource = &foreignScanProcessor{}
+var _ execopnode.OpNode = &foreignScanProcessor{}
+
+const foreignScanProcName = "foreign scan"
+
+// foreignFileReader reads the rows of a single file of a foreign table.
+type foreignFileReader interface {
+	// next fills in the datums of the next row of the file at the positions
+	// with a non-negative target. It returns false once the file is exhausted.
+	next(ctx context.Context, datums tree.Datums) (bool, error)
+	close() error
+}
+
+func newForeignScanProcessor(
+	ctx context.Context,
+	flowCtx *execinfra.FlowCtx,
+	processorID int32,
+	spec execinfrapb.ForeignScanSpec,
+	post *execinfrapb.PostProcessSpec,
+) (execinfra.Processor, error) {
+	desc := tabledesc.NewUnsafeImmutable(&spec.Table)
+	p := &foreignScanProcessor{
+		spec: spec,
+		// Make a copy of the eval context since we're going to pass it to the
+		// ExprHelper later (which might modify it).
+		evalCtx:        flowCtx.NewEvalCtx(),
+		cols:           make([]catalog.Column, len(spec.ColumnIDs)),
+		targets:        make([]int, len(spec.ColumnIDs)),
+		numVisibleCols: len(desc.VisibleColumns()),
+	}
+	typs := make([]*types.T, len(spec.ColumnIDs))
+	for i, id := range spec.ColumnIDs {
+		col, err := catalog.MustFindColumnByID(desc, id)
+		if err != nil {
+			return nil, err
+		}
+		p.cols[i] = col
+		p.targets[i] = -1
+		for j, visible := range desc.VisibleColumns() {
+			if visible.GetID() == id {
+				p.targets[i] = j
+				break
+			}
+		}
+		typs[i] = col.GetType()
+	}
+	p.datums = make(tree.Datums, len(typs))
+	p.row = make(rowenc.EncDatumRow, len(typs))
+
+	if err := p.InitWithEvalCtx(
+		ctx, p, post, typs, flowCtx, p.evalCtx, processorID, nil /* memMonitor */, execinfra.ProcStateOpts{
+			TrailingMetaCallback: func() []execinfrapb.ProducerMetadata {
+				p.close()
+				return nil
+			},
+		},
+	); err != nil {
+		return nil, err
+	}
+	if !spec.Filter.Empty() {
+		if err := p.filter.Init(ctx, spec.Filter, typs, &p.SemaCtx, p.evalCtx); err != nil {
+			return nil, err
+		}
+		p.hasFilter = true
+	}
+	return p, nil
+}
+
+// Start is part of the RowSource interface.
+func (p *foreignScanProcessor) Start(ctx context.Context) {
+	p.StartInternal(ctx, foreignScanProcName)
+}
+
+// Next is part of the RowSource interface.
+func (p *foreignScanProcessor) Next() (rowenc.EncDatumRow, *execinfrapb.ProducerMetadata) {
+	for p.State == execinfra.StateRunning {
+		row, err := p.nextRow()
+		if err != nil {
+			p.MoveToDraining(err)
+			break
+		}
+		if row == nil {
+			p.MoveToDraining(nil /* err */)
+			break
+		}
+		if p.hasFilter {
+			passes, err := p.filter.EvalFilter(p.Ctx(), row)
+			if err != nil {
+				p.MoveToDraining(err)
+				break
+			}
+			if !passes {
+				continue
+			}
+		}
+		if outRow := p.ProcessRowHelper(row); outRow != nil {
+			return outRow, nil
+		}
+	}
+	return nil, p.DrainHelper()
+}
+
+// nextRow returns the next row of the table, opening the next file as
+// needed, or nil once all files have been read.
+func (p *foreignScanProcessor) nextRow() (rowenc.EncDatumRow, error) {
+	for {
+		if p.file == nil {
+			if p.fileIdx == len(p.spec.URIs) {
+				return nil, nil
+			}
+			uri := p.spec.URIs[p.fileIdx]
+			p.fileIdx++
+			file, err := p.openFile(p.Ctx(), uri)
+			if err != nil {
+				return nil, errors.Wrapf(err, "%s", uri)
+			}
+			p.file = file
+		}
+		ok, err := p.file.next(p.Ctx(), p.datums)
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s", p.spec.URIs[p.fileIdx-1])
+		}
+		if !ok {
+			if err := p.closeFile(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		for i := range p.datums {
+			if p.targets[i] == -1 {
+				p.datums[i] = p.generateHiddenValue(p.cols[i])
+			}
+			p.row[i] = rowenc.DatumToEncDatum(p.cols[i].GetType(), p.datums[i])
+		}
+		return p.row, nil
+	}
+}
+
+// generateHiddenValue returns the value of a hidden column, which does not
+// appear in the files of the table. The only hidden column of a foreign
+// table is its rowid, which is given a unique value. The value is generated
+// anew every time the file is read, so the optimizer doesn't use the rowid of a
+// foreign table as a key.
+func (p *foreignScanProcessor) generateHiddenValue(col catalog.Column) tree.Datum {
+	if col.GetType().Family() != types.IntFamily {
+		return tree.DNull
+	}
+	return tree.NewDInt(builtins.GenerateUniqueInt(
+		builtins.ProcessUniqueID(p.evalCtx.NodeID.SQLInstanceID()),
+	))
+}
+
+// openFile opens a file of the table for reading.
+func (p *foreignScanProcessor) openFile(ctx context.Context, uri string) (foreignFileReader, error) {
+	es, err := p.FlowCtx.Cfg.ExternalStorageFromURI(ctx, uri, p.spec.User())
+	if err != nil {
+		return nil, err
+	}
+	raw, _, err := es.ReadFile(ctx, "", cloud.ReadOptions{NoFileSize: true})
+	if err != nil {
+		return nil, errors.CombineErrors(err, es.Close())
+	}
+	decompressed, err := decompressingReader(
+		ioctx.ReaderCtxAdapter(ctx, raw), uri, p.spec.Format.Compression,
+	)
+	if err != nil {
+		return nil, errors.CombineErrors(err, errors.CombineErrors(raw.Close(ctx), es.Close()))
+	}
+	closeFn := func() error {
+		err := decompressed.Close()
+		err = errors.CombineErrors(err, raw.Close(ctx))
+		return errors.CombineErrors(err, es.Close())
+	}
+
+	var file foreignFileReader
+	switch p.spec.Format.Format {
+	case roachpb.IOFileFormat_CSV:
+		file = p.newCSVFileReader(decompressed)
+	case roachpb.IOFileFormat_Avro:
+		file, err = p.newAvroFileReader(decompressed)
+	case roachpb.IOFileFormat_Parquet:
+		file, err = p.newParquetFileReader(decompressed)
+	default:
+		err = errors.AssertionFailedf("unsupported foreign table format %s", p.spec.Format.Format)
+	}
+	if err != nil {
+		return nil, errors.CombineErrors(err, closeFn())
+	}
+	return &closingFileReader{foreignFileReader: file, closeFn: closeFn}, nil
+}
+
+// closeFile closes the file being read, if any.
+func (p *foreignScanProcessor) closeFile() error {
+	if p.file == nil {
+		return nil
+	}
+	err := p.file.close()
+	p.file = nil
+	return err
+}
+
+func (p *foreignScanProcessor) close() {
+	if p.InternalClose() {
+		if err := p.closeFile(); err != nil {
+			log.Warningf(p.Ctx(), "error closing foreign table file: %v", err)
+		}
+	}
+}
+
+// ConsumerClosed is part of the RowSource interface.
+func (p *foreignScanProcessor) ConsumerClosed() {
+	// The consumer is done, Next() will not be called again.
+	p.close()
+}
+
+// ChildCount is part of the execopnode.OpNode interface.
+func (p *foreignScanProcessor) ChildCount(verbose bool) int {
+	return 0
+}
+
+// Child is part of the execopnode.OpNode interface.
+func (p *foreignScanProcessor) Child(nth int, verbose bool) execopnode.OpNode {
+	panic(errors.AssertionFailedf("invalid index %d", nth))
+}
+
+// closingFileReader closes the underlying storage of a file along with its
+// reader.
+type closingFileReader struct {
+	foreignFileReader
+	closeFn func() error
+}
+
+func (r *closingFileReader) close() error {
+	return errors.CombineErrors(r.foreignFileReader.close(), r.closeFn())
+}
+
+// csvFileReader reads the rows of a CSV file.
+type csvFileReader struct {
+	p      *foreignScanProcessor
+	csv    *csv.Reader
+	opts   roachpb.CSVOptions
+	rowNum int64
+}
+
+func (p *foreignScanProcessor) newCSVFileReader(r io.Reader) *csvFileReader {
+	opts := p.spec.Format.Csv
+	cr := csv.NewReader(r)
+	if opts.Comma != 0 {
+		cr.Comma = opts.Comma
+	}
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = !opts.StrictQuotes
+	cr.Comment = opts.Comment
+	return &csvFileReader{p: p, csv: cr, opts: opts}
+}
+
+func (r *csvFileReader) next(ctx context.Context, datums tree.Datums) (bool, error) {
+	for {
+		record, err := r.csv.Read()
+		if err == io.EOF {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		r.rowNum++
+		if r.rowNum <= int64(r.opts.Skip) {
+			continue
+		}
+		numCols := r.p.numVisibleCols
+		if len(record) == numCols+1 && record[numCols].Val == "" && !record[numCols].Quoted {
+			// Line has the optional trailing comma, ignore the empty field.
+			record = record[:numCols]
+		}
+		if len(record) != numCols {
+			return false, makeRowErr(r.rowNum, pgcode.DataException,
+				"expected %d fields, got %d", numCols, len(record))
+		}
+		nullEncoding := ""
+		if r.opts.NullEncoding != nil {
+			nullEncoding = *r.opts.NullEncoding
+		}
+		for i, target := range r.p.targets {
+			if target == -1 {
+				continue
+			}
+			field := record[target]
+			if !field.Quoted && field.Val == nullEncoding {
+				datums[i] = tree.DNull
+				continue
+			}
+			typ := r.p.cols[i].GetType()
+			datums[i], err = rowenc.ParseDatumStringAs(ctx, typ, field.Val, r.p.evalCtx, &r.p.SemaCtx)
+			if err != nil {
+				// Fallback to parsing as a string literal, as IMPORT does.
+				var err2 error
+				datums[i], _, err2 = tree.ParseAndRequireString(typ, field.Val, r.p.evalCtx)
+				if err2 != nil {
+					return false, wrapRowErr(errors.CombineErrors(err, err2), r.rowNum, pgcode.Uncategorized,
+						"parse %q as %s", r.p.cols[i].GetName(), typ.SQLString())
+				}
+			}
+		}
+		return true, nil
+	}
+}
+
+func (r *csvFileReader) close() error {
+	return nil
+}
+
+// avroFileReader reads the records of an Avro object container file.
+type avroFileReader struct {
+	p   *foreignScanProcessor
+	ocf *goavro.OCFReader
+	// fieldNameToIdx maps the name of each column produced by the processor
+	// that is read from the file to its position in the output.
+	fieldNameToIdx map[string]int
+	rowNum         int64
+}
+
+func (p *foreignScanProcessor) newAvroFileReader(r io.Reader) (*avroFileReader, error) {
+	ocf, err := goavro.NewOCFReader(bufio.NewReaderSize(r, 64<<10))
+	if err != nil {
+		return nil, err
+	}
+	fieldNameToIdx := make(map[string]int, len(p.cols))
+	for i, col := range p.cols {
+		if p.targets[i] != -1 {
+			fieldNameToIdx[col.GetName()] = i
+		}
+	}
+	return &avroFileReader{p: p, ocf: ocf, fieldNameToIdx: fieldNameToIdx}, nil
+}
+
+func (r *avroFileReader) next(ctx context.Context, datums tree.Datums) (bool, error) {
+	if !r.ocf.Scan() {
+		return false, r.ocf.Err()
+	}
+	r.rowNum++
+	native, err := r.ocf.Read()
+	if err != nil {
+		return false, err
+	}
+	record, ok := native.(map[string]interface{})
+	if !ok {
+		return false, fmt.Errorf("unexpected native type; expected map[string]interface{} found %T instead", native)
+	}
+	for i := range datums {
+		if r.p.targets[i] != -1 {
+			// Fields missing from the record are NULL.
+			datums[i] = tree.DNull
+		}
+	}
+	for f, v := range record {
+		idx, ok := r.fieldNameToIdx[lexbase.NormalizeName(f)]
+		if !ok {
+			continue
+		}
+		typ := r.p.cols[idx].GetType()
+		avroT, ok := familyToAvroT[typ.Family()]
+		if !ok {
+			return false, makeRowErr(r.rowNum, pgcode.DatatypeMismatch,
+				"cannot convert avro value %v to col %s", v, typ.Name())
+		}
+		datums[idx], err = nativeToDatum(ctx, v, typ, avroT, r.p.evalCtx, &r.p.SemaCtx)
+		if err != nil {
+			return false, wrapRowErr(err, r.rowNum, pgcode.Uncategorized, "")
+		}
+	}
+	return true, nil
+}
+
+func (r *avroFileReader) close() error {
+	return nil
+}
+
+// parquetFileReader reads the rows of a parquet file. Parquet files cannot
+// be read as a stream, so the whole file is buffered in memory.
+type parquetFileReader struct {
+	p       *foreignScanProcessor
+	reader  *parquet.Reader
+	outIdxs []int
+}
+
+func (p *foreignScanProcessor) newParquetFileReader(r io.Reader) (*parquetFileReader, error) {
+	buf, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	var typs []*types.T
+	var outIdxs []int
+	for i, col := range p.cols {
+		if p.targets[i] != -1 {
+			names = append(names, col.GetName())
+			typs = append(typs, col.GetType())
+			outIdxs = append(outIdxs, i)
+		}
+	}
+	reader, err := parquet.NewReader(bytes.NewReader(buf), names, typs)
+	if err != nil {
+		return nil, err
+	}
+	if filter := p.parquetRowGroupFilter(outIdxs); filter != nil {
+		reader.SetRowGroupFilter(filter)
+	}
+	return &parquetFileReader{p: p, reader: reader, outIdxs: outIdxs}, nil
+}
+
+// parquetColumnBound is a condition of the processor's filter which compares
+// a column read from a parquet file to a constant.
+type parquetColumnBound struct {
+	// col is the index of the column among the columns read from the file.
+	col int
+	op  treecmp.ComparisonOperatorSymbol
+	val tree.Datum
+}
+
+// parquetRowGroupFilter returns a filter that skips the row groups of a
+// parquet file in which no row can pass the processor's filter, according to
+// the bounds of their columns. outIdxs is the position in the output of each
+// column read from the file. The returned filter is nil if the processor's
+// filter has no conjunct that compares one of these columns to a constant.
+//
+// The optimizer normalizes comparisons so that constants are on the right, so
+// only conjuncts of the form @i op constant are considered.
+func (p *foreignScanProcessor) parquetRowGroupFilter(outIdxs []int) parquet.RowGroupFilter {
+	if !p.hasFilter {
+		return nil
+	}
+	var conds []parquetColumnBound
+	var walk func(expr tree.TypedExpr)
+	walk = func(expr tree.TypedExpr) {
+		switch t := expr.(type) {
+		case *tree.AndExpr:
+			walk(t.TypedLeft())
+			walk(t.TypedRight())
+		case *tree.ComparisonExpr:
+			switch t.Operator.Symbol {
+			case treecmp.EQ, treecmp.LT, treecmp.LE, treecmp.GT, treecmp.GE:
+			default:
+				return
+			}
+			v, ok := t.Left.(*tree.IndexedVar)
+			if !ok {
+				return
+			}
+			d, ok := t.Right.(tree.Datum)
+			if !ok || d == tree.DNull || d.ResolvedType().Family() != p.cols[v.Idx].GetType().Family() {
+				return
+			}
+			for col, outIdx := range outIdxs {
+				if outIdx == v.Idx {
+					conds = append(conds, parquetColumnBound{col: col, op: t.Operator.Symbol, val: d})
+				}
+			}
+		}
+	}
+	walk(p.filter.Expr())
+	if len(conds) == 0 {
+		return nil
+	}
+	return func(bounds []parquet.ColumnBounds) (bool, error) {
+		for _, cond := range conds {
+			b := bounds[cond.col]
+			if b.Min == nil || b.Max == nil {
+				continue
+			}
+			cmpMin, err := b.Min.Compare(p.Ctx(), p.evalCtx, cond.val)
+			if err != nil {
+				return false, err
+			}
+			cmpMax, err := b.Max.Compare(p.Ctx(), p.evalCtx, cond.val)
+			if err != nil {
+				return false, err
+			}
+			// NULLs never pass a comparison, so the row group can be skipped if
+			// none of its non-NULL values do.
+			var skip bool
+			switch cond.op {
+			case treecmp.EQ:
+				skip = cmpMin > 0 || cmpMax < 0
+			case treecmp.LT:
+				skip = cmpMin >= 0
+			case treecmp.LE:
+				skip = cmpMin > 0
+			case treecmp.GT:
+				skip = cmpMax <= 0
+			case treecmp.GE:
+				skip = cmpMax < 0
+			}
+			if skip {
+				return false, nil
+			}
+		}
+		return true, nil
+	}
+}
+
+func (r *parquetFileReader) next(ctx context.Context, datums tree.Datums) (bool, error) {
+	row, err := r.reader.Next()
+	if err != nil || row == nil {
+		return false, err
+	}
+	for i, d := range row {
+		datums[r.outIdxs[i]] = d
+	}
+	return true, nil
+}
+
+func (r *parquetFileReader) close() error {
+	return r.reader.Close()
+}
+
+func init() {
+	rowexec.NewForeignScanProcessor = newForeignScanProcessor
+}
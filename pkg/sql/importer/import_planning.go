@@ -811,6 +811,10 @@ func importPlanHook(
 			if err != nil {
 				return err
 			}
+			if found.IsForeignTable() {
+				return pgerror.Newf(pgcode.WrongObjectType,
+					"cannot IMPORT INTO foreign table %q", found.GetName())
+			}
 
 			err = ensureRequiredPrivileges(ctx, importIntoRequiredPrivileges, p, found)
 			if err != nil {
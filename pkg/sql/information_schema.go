@@ -1472,12 +1472,16 @@ https://www.postgresql.org/docs/9.5/infoschema-table-constraints.html`,
 
 				for _, c := range table.AllConstraints() {
 					kind := catconstants.ConstraintTypeUnique
+					deferrability := semenumpb.Deferrability_NOT_DEFERRABLE
 					if c.AsCheck() != nil {
 						kind = catconstants.ConstraintTypeCheck
-					} else if c.AsForeignKey() != nil {
+					} else if fk := c.AsForeignKey(); fk != nil {
 						kind = catconstants.ConstraintTypeFK
+						deferrability = fk.Deferrability()
 					} else if u := c.AsUniqueWithIndex(); u != nil && u.Primary() {
 						kind = catconstants.ConstraintTypePK
+					} else if uwoi := c.AsUniqueWithoutIndex(); uwoi != nil {
+						deferrability = uwoi.Deferrability()
 					}
 					if err := addRow(
 						dbNameStr,                     // constraint_catalog
@@ -1487,8 +1491,8 @@ https://www.postgresql.org/docs/9.5/infoschema-table-constraints.html`,
 						scNameStr,                     // table_schema
 						tbNameStr,                     // table_name
 						tree.NewDString(string(kind)), // constraint_type
-						yesOrNoDatum(false),           // is_deferrable
-						yesOrNoDatum(false),           // initially_deferred
+						yesOrNoDatum(deferrability != semenumpb.Deferrability_NOT_DEFERRABLE),     // is_deferrable
+						yesOrNoDatum(deferrability == semenumpb.Deferrability_INITIALLY_DEFERRED), // initially_deferred
 					); err != nil {
 						return err
 					}
@@ -199,6 +199,9 @@ func (r *insertRun) processSourceRow(params runParams, rowVals tree.Datums) erro
 	if err := enforceLocalColumnConstraints(rowVals, r.insertCols); err != nil {
 		return err
 	}
+	if err := enforceArrayValuesSupported(params.ctx, params.ExecCfg().Settings, rowVals); err != nil {
+		return err
+	}
 
 	// Create a set of partial index IDs to not write to. Indexes should not be
 	// written to when they are partial indexes and the row does not satisfy the
@@ -268,6 +271,7 @@ func (n *insertNode) startExec(params runParams) error {
 
 	n.run.initRowContainer(params, n.columns)
 
+	n.run.ti.deferredKeys = params.p.queueDeferredConstraintChecks(n.run.ti.tableDesc(), true /* insertion */, false /* deletion */)
 	return n.run.ti.init(params.ctx, params.p.txn, params.EvalContext())
 }
 
@@ -453,6 +453,7 @@ func (n *insertFastPathNode) startExec(params runParams) error {
 		n.run.uniqSpanInfo = make([]insertFastPathFKUniqSpanInfo, 0, maxSpans)
 	}
 
+	n.run.ti.deferredKeys = params.p.queueDeferredConstraintChecks(n.run.ti.tableDesc(), true /* insertion */, false /* deletion */)
 	return n.run.ti.init(params.ctx, params.p.txn, params.EvalContext())
 }
 
@@ -0,0 +1,64 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgnotify"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// Listen implements the LISTEN statement.
+// See https://www.postgresql.org/docs/current/sql-listen.html for details.
+func (p *planner) Listen(ctx context.Context, n *tree.Listen) (planNode, error) {
+	if err := p.checkNotificationsSupported(ctx, "LISTEN"); err != nil {
+		return nil, err
+	}
+	if err := pgnotify.ValidateChannel(string(n.ChannelName)); err != nil {
+		return nil, err
+	}
+	var listener *pgnotify.Listener
+	if p.notificationListener != nil {
+		listener = p.notificationListener()
+	}
+	if listener == nil {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			"LISTEN is not supported in this context")
+	}
+	return &listenNode{listener: listener, channel: string(n.ChannelName)}, nil
+}
+
+// checkNotificationsSupported returns an error if the cluster has not been
+// upgraded to a version which supports LISTEN and NOTIFY.
+func (p *planner) checkNotificationsSupported(ctx context.Context, stmt string) error {
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V24_2_NotificationsTable) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"%s is only supported after the v24.2 upgrade is finalized", stmt)
+	}
+	return nil
+}
+
+type listenNode struct {
+	listener *pgnotify.Listener
+	channel  string
+}
+
+func (n *listenNode) startExec(params runParams) error {
+	return n.listener.Listen(params.ctx, params.p.Txn(), n.channel)
+}
+
+func (n *listenNode) Next(_ runParams) (bool, error) { return false, nil }
+func (n *listenNode) Values() tree.Datums            { return nil }
+func (n *listenNode) Close(_ context.Context)        {}
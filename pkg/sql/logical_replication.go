@@ -0,0 +1,796 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"strings"
+	"time"
+	"unsafe"
+
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/rangefeed"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/fetchpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsnutil"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgoutput"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgrepltree"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
+	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
+	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
+	"github.com/cockroachdb/cockroach/pkg/util/ctxlog"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
+)
+
+// walSenderKeepaliveInterval is the interval at which keepalive messages are
+// sent to the client of a replication stream.
+const walSenderKeepaliveInterval = 10 * time.Second
+
+// walSenderPersistInterval is the minimum interval between two updates of
+// the confirmed flush position of a replication slot.
+const walSenderPersistInterval = time.Second
+
+// walSenderEventBufferSize is the number of rangefeed events buffered before
+// the rangefeed is blocked.
+const walSenderEventBufferSize = 1024
+
+// walSenderMaxBufferedBytes limits the memory used by a replication stream to
+// buffer the changes which are not known to be complete yet.
+var walSenderMaxBufferedBytes = settings.RegisterByteSizeSetting(
+	settings.ApplicationLevel,
+	"sql.logical_replication.max_buffered_bytes",
+	"maximum amount of memory a logical replication stream can use to buffer "+
+		"the changes which have not been sent yet",
+	64<<20, /* 64 MiB */
+	settings.PositiveInt,
+)
+
+// walSenderValueOverhead is the memory accounted for each buffered change in
+// addition to its keys and values.
+const walSenderValueOverhead = int64(unsafe.Sizeof(kvpb.RangeFeedValue{}))
+
+// execStartReplication executes START_REPLICATION, which streams the changes
+// to the tables published by the requested publications until the client
+// ends the stream. The connection is handed back to the network routine once
+// it returns.
+func (ex *connExecutor) execStartReplication(ctx context.Context, cmd StartReplication) error {
+	// When we're done, unblock the network connection.
+	defer cmd.ReplicationDone.Done()
+
+	if _, isNoTxn := ex.machine.CurState().(stateNoTxn); !isNoTxn {
+		return pgerror.New(pgcode.ActiveSQLTransaction,
+			"START_REPLICATION cannot be executed inside a transaction")
+	}
+	ws, err := ex.newWalSender(ctx, cmd)
+	if err != nil {
+		return err
+	}
+
+	var cancelQuery context.CancelFunc
+	ctx, cancelQuery = ctxlog.WithCancel(ctx)
+	defer cancelQuery()
+	queryID := ex.server.cfg.GenerateID()
+	ex.addActiveQuery(cmd.ParsedStmt, nil /* placeholders */, queryID, cancelQuery)
+	defer ex.removeActiveQuery(queryID, cmd.Stmt)
+
+	return ws.run(ctx)
+}
+
+// walSender streams the changes of a logical replication slot to a client
+// using the pgoutput protocol.
+//
+// The changes are read from a rangefeed over each published table, starting
+// after the confirmed flush position of the slot. The changes are buffered
+// until the rangefeed frontier passes them, then sorted and grouped into
+// transactions by commit timestamp. The rangefeed does not expose transaction
+// IDs, so the changes of distinct transactions which committed at the exact
+// same timestamp are sent as a single transaction. The set of published
+// tables is determined when the stream starts.
+//
+// A LSN is the wall time of a HLC timestamp, so it can be shared by several
+// transactions. See groupTransactions for how positions are assigned to them.
+type walSender struct {
+	cfg      *ExecutorConfig
+	conn     pgwirebase.Conn
+	slotName string
+	// parentMon is the monitor of the session running the stream.
+	parentMon *mon.BytesMonitor
+	// startTS is the timestamp after which changes are streamed.
+	startTS hlc.Timestamp
+	// tableIDs are the IDs of the published tables.
+	tableIDs []descpb.ID
+	// tables caches the decoding state of the published tables.
+	tables map[descpb.ID]*walSenderTable
+
+	// pending holds the changes which have not been sent yet. Their memory
+	// is accounted for in pendingAcc.
+	pending    []*kvpb.RangeFeedValue
+	pendingAcc mon.BoundAccount
+	// walEnd is the position up to which all the changes have been sent.
+	walEnd lsn.LSN
+	// xid is the pseudo transaction ID of the last transaction sent.
+	xid uint32
+	// flushed is the position up to which the client has acknowledged the
+	// changes, and persisted is the last such position written to the slot.
+	flushed, persisted lsn.LSN
+	lastPersist        time.Time
+	buf                []byte
+}
+
+// walSenderTable holds the state used to decode the changes of a version of
+// a published table.
+type walSenderTable struct {
+	version        descpb.DescriptorVersion
+	primaryIndexID descpb.IndexID
+	rel            pgoutput.Relation
+	fetcher        row.Fetcher
+	// relationSent is true once the Relation message describing this version
+	// of the table has been sent.
+	relationSent bool
+}
+
+// walSenderEvent is an event produced by the rangefeed of a walSender.
+type walSenderEvent struct {
+	value *kvpb.RangeFeedValue
+	// resolved is set when the rangefeed frontier advances.
+	resolved hlc.Timestamp
+}
+
+// walSenderClientMsg is a message received from the client of a walSender.
+type walSenderClientMsg struct {
+	update pgoutput.StandbyStatusUpdate
+	// done is true if the client ended the stream with CopyDone.
+	done bool
+	err  error
+}
+
+// newWalSender validates the START_REPLICATION command and resolves the slot
+// and the tables to stream.
+func (ex *connExecutor) newWalSender(ctx context.Context, cmd StartReplication) (*walSender, error) {
+	sd := ex.sessionData()
+	if cmd.Stmt.Kind != pgrepltree.LogicalReplication {
+		return nil, unimplemented.New("physical replication",
+			"physical replication is not supported")
+	}
+	if sd.ReplicationMode != sessiondatapb.ReplicationMode_REPLICATION_MODE_DATABASE || sd.Database == "" {
+		return nil, pgerror.New(pgcode.ObjectNotInPrerequisiteState,
+			"logical decoding requires a database connection")
+	}
+	if cmd.Stmt.Slot == "" {
+		return nil, pgerror.New(pgcode.Syntax, "logical replication requires a replication slot")
+	}
+	var pubNames []string
+	protoVersionSet := false
+	for _, o := range cmd.Stmt.Options {
+		value := replicationOptionValue(o)
+		switch key := strings.ToLower(string(o.Key)); key {
+		case "proto_version":
+			if value != "1" {
+				return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+					"client sent proto_version=%s but server only supports protocol %d",
+					value, pgoutput.ProtoVersion)
+			}
+			protoVersionSet = true
+		case "publication_names":
+			var err error
+			if pubNames, err = pgoutput.ParsePublicationNames(value); err != nil {
+				return nil, pgerror.WithCandidateCode(err, pgcode.InvalidName)
+			}
+		case "binary", "messages", "streaming":
+			if b, err := tree.ParseDBool(value); err != nil {
+				return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+					"invalid value for %s: %q", key, value)
+			} else if *b {
+				return nil, unimplemented.Newf(key,
+					"pgoutput option %s is not supported", key)
+			}
+		default:
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+				"unrecognized pgoutput option: %s", key)
+		}
+	}
+	if !protoVersionSet {
+		return nil, pgerror.New(pgcode.InvalidParameterValue, "proto_version option missing")
+	}
+	if len(pubNames) == 0 {
+		return nil, pgerror.New(pgcode.InvalidParameterValue, "publication_names parameter missing")
+	}
+
+	ws := &walSender{
+		cfg:       ex.server.cfg,
+		conn:      cmd.Conn,
+		slotName:  string(cmd.Stmt.Slot),
+		parentMon: ex.sessionMon,
+		tables:    make(map[descpb.ID]*walSenderTable),
+	}
+	if err := DescsTxn(ctx, ex.server.cfg, func(ctx context.Context, txn isql.Txn, col *descs.Collection) error {
+		ws.tableIDs = ws.tableIDs[:0]
+		db, err := col.ByNameWithLeased(txn.KV()).Get().Database(ctx, sd.Database)
+		if err != nil {
+			return err
+		}
+		slot, err := getReplicationSlot(ctx, txn, ws.slotName)
+		if err != nil {
+			return err
+		}
+		if slot == nil {
+			return pgerror.Newf(pgcode.UndefinedObject,
+				"replication slot %q does not exist", ws.slotName)
+		}
+		if slot.databaseID != db.GetID() {
+			return pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+				"replication slot %q was not created in this database", ws.slotName)
+		}
+		start := slot.confirmedFlushLSN
+		if cmd.Stmt.LSN > start {
+			start = cmd.Stmt.LSN
+		}
+		ws.startTS = lsnutil.LSNToHLC(start)
+		ws.walEnd = start
+		ws.flushed, ws.persisted = slot.confirmedFlushLSN, slot.confirmedFlushLSN
+
+		allTables := false
+		ids := make(map[descpb.ID]struct{})
+		for _, name := range pubNames {
+			pub, err := getPublication(ctx, txn, db.GetID(), name)
+			if err != nil {
+				return err
+			}
+			if pub == nil {
+				return pgerror.Newf(pgcode.UndefinedObject, "publication %q does not exist", name)
+			}
+			allTables = allTables || pub.allTables
+			for _, id := range pub.tableIDs {
+				ids[id] = struct{}{}
+			}
+		}
+		if allTables {
+			tables, err := col.GetAllTablesInDatabase(ctx, txn.KV(), db)
+			if err != nil {
+				return err
+			}
+			if err := tables.ForEachDescriptor(func(desc catalog.Descriptor) error {
+				if table, ok := desc.(catalog.TableDescriptor); ok && table.IsTable() &&
+					!table.IsTemporary() && !table.Dropped() {
+					ids[table.GetID()] = struct{}{}
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		for id := range ids {
+			ws.tableIDs = append(ws.tableIDs, id)
+		}
+		sort.Slice(ws.tableIDs, func(i, j int) bool { return ws.tableIDs[i] < ws.tableIDs[j] })
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return ws, nil
+}
+
+// replicationOptionValue returns the value of an option of a replication
+// command as a string.
+func replicationOptionValue(o pgrepltree.Option) string {
+	switch v := o.Value.(type) {
+	case nil:
+		return ""
+	case *tree.StrVal:
+		return v.RawString()
+	case *tree.NumVal:
+		return v.String()
+	default:
+		return tree.AsStringWithFlags(v, tree.FmtBareStrings)
+	}
+}
+
+// run streams the changes until the client ends the stream or an error
+// occurs.
+func (ws *walSender) run(ctx context.Context) error {
+	// The feed covers the whole span of each table, since its primary index
+	// may change over time. The changes to other indexes are filtered out.
+	spans := make([]roachpb.Span, 0, len(ws.tableIDs))
+	for _, id := range ws.tableIDs {
+		if _, err := ws.tableAt(ctx, id, ws.startTS); err != nil {
+			return err
+		}
+		prefix := ws.cfg.Codec.TablePrefix(uint32(id))
+		spans = append(spans, roachpb.Span{Key: prefix, EndKey: prefix.PrefixEnd()})
+	}
+
+	memMon := mon.NewMonitorInheritWithLimit(
+		"wal-sender", walSenderMaxBufferedBytes.Get(&ws.cfg.Settings.SV), ws.parentMon,
+		false, /* longLiving */
+	)
+	memMon.StartNoReserved(ctx, ws.parentMon)
+	defer memMon.Stop(ctx)
+	ws.pendingAcc = memMon.MakeBoundAccount()
+	defer ws.pendingAcc.Close(ctx)
+
+	events := make(chan walSenderEvent, walSenderEventBufferSize)
+	feedErrCh := make(chan error, 1)
+	if len(spans) > 0 {
+		send := func(ctx context.Context, ev walSenderEvent) {
+			select {
+			case events <- ev:
+			case <-ctx.Done():
+			}
+		}
+		feed, err := ws.cfg.RangeFeedFactory.RangeFeed(ctx,
+			"logical-replication-"+ws.slotName,
+			spans,
+			ws.startTS,
+			func(ctx context.Context, value *kvpb.RangeFeedValue) {
+				send(ctx, walSenderEvent{value: value})
+			},
+			rangefeed.WithDiff(true),
+			rangefeed.WithOnFrontierAdvance(func(ctx context.Context, ts hlc.Timestamp) {
+				send(ctx, walSenderEvent{resolved: ts})
+			}),
+			rangefeed.WithOnInternalError(func(ctx context.Context, err error) {
+				select {
+				case feedErrCh <- err:
+				default:
+				}
+			}),
+		)
+		if err != nil {
+			return err
+		}
+		defer feed.Close()
+	}
+
+	if err := ws.conn.BeginCopyBoth(ctx); err != nil {
+		return err
+	}
+
+	// The client messages are read by a separate goroutine, which exits once
+	// the client ends the stream or the connection fails.
+	clientMsgs := make(chan walSenderClientMsg, 16)
+	g := ctxgroup.WithContext(ctx)
+	g.GoCtx(func(ctx context.Context) error {
+		ws.readClientMessages(clientMsgs)
+		return nil
+	})
+
+	streamErr := ws.stream(ctx, events, feedErrCh, clientMsgs)
+	if !errors.Is(streamErr, errWalSenderClientDone) {
+		// The server ends the stream, and the client is expected to respond
+		// with CopyDone.
+		if err := ws.conn.SendCopyDone(ctx); err != nil {
+			log.Warningf(ctx, "failed to end replication stream: %v", err)
+		}
+		for msg := range clientMsgs {
+			if msg.done || msg.err != nil {
+				break
+			}
+		}
+	} else {
+		streamErr = ws.conn.SendCopyDone(ctx)
+	}
+	_ = g.Wait()
+	if err := ws.persistFlushed(ctx, true /* force */); err != nil && streamErr == nil {
+		streamErr = err
+	}
+	return streamErr
+}
+
+// errWalSenderClientDone is returned by stream when the client ends the
+// stream.
+var errWalSenderClientDone = errors.New("client ended the replication stream")
+
+// stream sends the changes and keepalives to the client, and processes the
+// status updates of the client.
+func (ws *walSender) stream(
+	ctx context.Context,
+	events <-chan walSenderEvent,
+	feedErrCh <-chan error,
+	clientMsgs <-chan walSenderClientMsg,
+) error {
+	var keepalive timeutil.Timer
+	defer keepalive.Stop()
+	keepalive.Reset(walSenderKeepaliveInterval)
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-feedErrCh:
+			return err
+		case ev := <-events:
+			if ev.value != nil {
+				// The changes cannot be sent before they are complete, so a
+				// stream which falls too far behind fails rather than
+				// buffering them without bound.
+				if err := ws.pendingAcc.Grow(ctx, walSenderValueSize(ev.value)); err != nil {
+					return errors.Wrap(err, "buffering replication changes")
+				}
+				ws.pending = append(ws.pending, ev.value)
+				continue
+			}
+			if err := ws.flushResolved(ctx, ev.resolved); err != nil {
+				return err
+			}
+		case msg := <-clientMsgs:
+			if msg.err != nil {
+				return msg.err
+			}
+			if msg.done {
+				return errWalSenderClientDone
+			}
+			if msg.update.FlushedLSN > ws.flushed {
+				ws.flushed = msg.update.FlushedLSN
+				if ws.flushed > ws.walEnd {
+					ws.flushed = ws.walEnd
+				}
+			}
+			if err := ws.persistFlushed(ctx, false /* force */); err != nil {
+				return err
+			}
+			if msg.update.ReplyRequested {
+				if err := ws.sendKeepalive(ctx, false /* replyRequested */); err != nil {
+					return err
+				}
+			}
+		case <-keepalive.C:
+			keepalive.Read = true
+			keepalive.Reset(walSenderKeepaliveInterval)
+			if err := ws.sendKeepalive(ctx, true /* replyRequested */); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// readClientMessages reads the messages sent by the client until it ends the
+// stream or the connection fails.
+func (ws *walSender) readClientMessages(msgs chan<- walSenderClientMsg) {
+	defer close(msgs)
+	var readBuf pgwirebase.ReadBuffer
+	for {
+		typ, _, err := readBuf.ReadTypedMsg(ws.conn.Rd())
+		if err != nil {
+			msgs <- walSenderClientMsg{err: err}
+			return
+		}
+		switch typ {
+		case pgwirebase.ClientMsgCopyData:
+			update, ok, err := pgoutput.ParseStandbyMessage(readBuf.Msg)
+			if err != nil {
+				msgs <- walSenderClientMsg{err: pgwirebase.NewProtocolViolationErrorf("%v", err)}
+				continue
+			}
+			if ok {
+				msgs <- walSenderClientMsg{update: update}
+			}
+		case pgwirebase.ClientMsgCopyDone:
+			msgs <- walSenderClientMsg{done: true}
+			return
+		case pgwirebase.ClientMsgFlush, pgwirebase.ClientMsgSync:
+			// Ignored, as in the Copy-in subprotocol.
+		default:
+			msgs <- walSenderClientMsg{
+				err: pgwirebase.NewUnrecognizedMsgTypeErr(typ),
+			}
+			return
+		}
+	}
+}
+
+// flushResolved sends the pending changes which are known to be complete
+// once the rangefeed frontier reached the given timestamp.
+func (ws *walSender) flushResolved(ctx context.Context, resolved hlc.Timestamp) error {
+	// Changes at a later timestamp with the same wall time as the frontier may
+	// still arrive, so only the LSNs strictly below it are complete.
+	end := lsnutil.HLCToLSN(resolved) - 1
+	if end <= ws.walEnd {
+		return nil
+	}
+	sort.Slice(ws.pending, func(i, j int) bool {
+		if c := ws.pending[i].Timestamp().Compare(ws.pending[j].Timestamp()); c != 0 {
+			return c < 0
+		}
+		return bytes.Compare(ws.pending[i].Key, ws.pending[j].Key) < 0
+	})
+	txns, n := groupTransactions(ws.pending, end)
+	var sent int64
+	for _, txn := range txns {
+		if err := ws.sendTransaction(ctx, txn); err != nil {
+			return err
+		}
+		for _, v := range txn.values {
+			sent += walSenderValueSize(v)
+		}
+	}
+	ws.pendingAcc.Shrink(ctx, sent)
+	ws.pending = append(ws.pending[:0], ws.pending[n:]...)
+	ws.walEnd = end
+	return nil
+}
+
+// walSenderValueSize returns the memory accounted for a buffered change.
+func walSenderValueSize(v *kvpb.RangeFeedValue) int64 {
+	return walSenderValueOverhead + int64(len(v.Key)+len(v.Value.RawBytes)+len(v.PrevValue.RawBytes))
+}
+
+// walSenderTxn is a group of changes sent as a single transaction.
+type walSenderTxn struct {
+	// lsn is the position of the transaction in the stream.
+	lsn lsn.LSN
+	// commitTS is the commit timestamp shared by the changes.
+	commitTS hlc.Timestamp
+	values   []*kvpb.RangeFeedValue
+}
+
+// groupTransactions groups the given changes, which must be sorted by
+// timestamp, into transactions by commit timestamp. Only the changes whose
+// LSN is at most end are grouped. It returns the transactions and the number
+// of changes they contain.
+//
+// The position of a transaction is the LSN of its commit timestamp, except
+// when a later transaction has the same LSN: a client which restarts from a
+// given position skips every change at or below it, so all the transactions
+// sharing a LSN but the last one are positioned just before it. A client
+// restarting from one of them receives the following ones again rather than
+// missing them.
+func groupTransactions(pending []*kvpb.RangeFeedValue, end lsn.LSN) (txns []walSenderTxn, n int) {
+	for n < len(pending) {
+		ts := pending[n].Timestamp()
+		if lsnutil.HLCToLSN(ts) > end {
+			break
+		}
+		j := n + 1
+		for j < len(pending) && pending[j].Timestamp().Equal(ts) {
+			j++
+		}
+		txnLSN := lsnutil.HLCToLSN(ts)
+		if j < len(pending) && lsnutil.HLCToLSN(pending[j].Timestamp()) == txnLSN {
+			txnLSN--
+		}
+		txns = append(txns, walSenderTxn{lsn: txnLSN, commitTS: ts, values: pending[n:j]})
+		n = j
+	}
+	return txns, n
+}
+
+// sendTransaction sends the changes of the given transaction.
+func (ws *walSender) sendTransaction(ctx context.Context, txn walSenderTxn) error {
+	commitTime := timeutil.Unix(0, txn.commitTS.WallTime)
+	ws.xid++
+	if err := ws.sendMessage(ctx, txn.lsn, pgoutput.AppendBegin(nil, txn.lsn, commitTime, ws.xid)); err != nil {
+		return err
+	}
+	for _, v := range txn.values {
+		msg, err := ws.encodeChange(ctx, txn.lsn, v)
+		if err != nil {
+			return err
+		}
+		if msg == nil {
+			continue
+		}
+		if err := ws.sendMessage(ctx, txn.lsn, msg); err != nil {
+			return err
+		}
+	}
+	return ws.sendMessage(ctx, txn.lsn, pgoutput.AppendCommit(nil, txn.lsn, txn.lsn, commitTime))
+}
+
+// encodeChange encodes the given change as an Insert, Update or Delete
+// message. A Relation message is sent first if needed. It returns nil if the
+// change is of no interest.
+func (ws *walSender) encodeChange(
+	ctx context.Context, txnLSN lsn.LSN, v *kvpb.RangeFeedValue,
+) ([]byte, error) {
+	_, tableID, indexID, err := ws.cfg.Codec.DecodeIndexPrefix(v.Key)
+	if err != nil {
+		return nil, err
+	}
+	table, err := ws.tableAt(ctx, descpb.ID(tableID), v.Timestamp())
+	if err != nil {
+		return nil, err
+	}
+	if descpb.IndexID(indexID) != table.primaryIndexID {
+		return nil, nil
+	}
+	if !table.relationSent {
+		if err := ws.sendMessage(ctx, txnLSN, pgoutput.AppendRelation(nil, &table.rel)); err != nil {
+			return nil, err
+		}
+		table.relationSent = true
+	}
+	relID := table.rel.ID
+	switch {
+	case v.Value.IsPresent():
+		newTuple, err := table.decode(ctx, v.Key, v.Value, false /* keyOnly */)
+		if err != nil {
+			return nil, err
+		}
+		if v.PrevValue.IsPresent() {
+			return pgoutput.AppendUpdate(nil, relID, nil /* oldTuple */, newTuple), nil
+		}
+		return pgoutput.AppendInsert(nil, relID, newTuple), nil
+	case v.PrevValue.IsPresent():
+		oldTuple, err := table.decode(ctx, v.Key, v.PrevValue, true /* keyOnly */)
+		if err != nil {
+			return nil, err
+		}
+		return pgoutput.AppendDelete(nil, relID, oldTuple, false /* isOld */), nil
+	default:
+		// Deletion of a row which did not exist.
+		return nil, nil
+	}
+}
+
+// tableAt returns the decoding state of the version of the given table which
+// is current at the given timestamp.
+func (ws *walSender) tableAt(
+	ctx context.Context, id descpb.ID, ts hlc.Timestamp,
+) (*walSenderTable, error) {
+	leased, err := ws.cfg.LeaseManager.Acquire(ctx, ts, id)
+	if err != nil {
+		return nil, err
+	}
+	version := leased.Underlying().GetVersion()
+	leased.Release(ctx)
+	if t, ok := ws.tables[id]; ok && t.version == version {
+		return t, nil
+	}
+
+	// Load the hydrated descriptor as of the timestamp of the change.
+	var t *walSenderTable
+	if err := DescsTxn(ctx, ws.cfg, func(ctx context.Context, txn isql.Txn, col *descs.Collection) error {
+		if err := txn.KV().SetFixedTimestamp(ctx, ts); err != nil {
+			return err
+		}
+		desc, err := col.ByIDWithLeased(txn.KV()).WithoutNonPublic().Get().Table(ctx, id)
+		if err != nil {
+			return err
+		}
+		sc, err := col.ByIDWithLeased(txn.KV()).WithoutNonPublic().Get().Schema(ctx, desc.GetParentSchemaID())
+		if err != nil {
+			return err
+		}
+		t, err = ws.makeTable(ctx, desc, sc.GetName())
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	ws.tables[id] = t
+	return t, nil
+}
+
+// makeTable initializes the decoding state of the given table.
+func (ws *walSender) makeTable(
+	ctx context.Context, desc catalog.TableDescriptor, schemaName string,
+) (*walSenderTable, error) {
+	// Each change to a table with several column families only contains the
+	// families which were written, so the rows cannot be decoded from the
+	// changes. Such tables cannot be added to a publication, but they can be
+	// published FOR ALL TABLES or gain families after they were added.
+	if len(desc.GetFamilies()) > 1 {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"logical replication of relation %q with multiple column families is not supported",
+			desc.GetName())
+	}
+	t := &walSenderTable{
+		version:        desc.GetVersion(),
+		primaryIndexID: desc.GetPrimaryIndexID(),
+		rel: pgoutput.Relation{
+			ID:        oid.Oid(desc.GetID()),
+			Namespace: schemaName,
+			Name:      desc.GetName(),
+		},
+	}
+	keyCols := desc.GetPrimaryIndex().CollectKeyColumnIDs()
+	var colIDs []descpb.ColumnID
+	for _, col := range desc.PublicColumns() {
+		// Virtual columns are not stored, so their values are not available.
+		if col.IsInaccessible() || col.IsVirtual() {
+			continue
+		}
+		colIDs = append(colIDs, col.GetID())
+		t.rel.Columns = append(t.rel.Columns, pgoutput.Column{
+			Name:    col.GetName(),
+			TypeOID: col.GetType().Oid(),
+			TypeMod: col.GetType().TypeModifier(),
+			IsKey:   keyCols.Contains(col.GetID()),
+		})
+	}
+	var spec fetchpb.IndexFetchSpec
+	if err := rowenc.InitIndexFetchSpec(&spec, ws.cfg.Codec, desc, desc.GetPrimaryIndex(), colIDs); err != nil {
+		return nil, err
+	}
+	if err := t.fetcher.Init(ctx, row.FetcherInitArgs{
+		WillUseKVProvider: true,
+		Alloc:             &tree.DatumAlloc{},
+		Spec:              &spec,
+	}); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// decode decodes the row with the given key and value. If keyOnly is true,
+// the columns which are not part of the key are NULL.
+func (t *walSenderTable) decode(
+	ctx context.Context, key roachpb.Key, value roachpb.Value, keyOnly bool,
+) (pgoutput.Tuple, error) {
+	if err := t.fetcher.ConsumeKVProvider(ctx, &row.KVProvider{
+		KVs: []roachpb.KeyValue{{Key: key, Value: value}},
+	}); err != nil {
+		return nil, err
+	}
+	datums, err := t.fetcher.NextRowDecoded(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if datums == nil {
+		return nil, errors.AssertionFailedf("no row decoded for key %s", key)
+	}
+	tuple := make(pgoutput.Tuple, len(datums))
+	for i, d := range datums {
+		if d == tree.DNull || (keyOnly && !t.rel.Columns[i].IsKey) {
+			tuple[i].Null = true
+			continue
+		}
+		tuple[i].Text = tree.AsStringWithFlags(d, tree.FmtPgwireText)
+	}
+	return tuple, nil
+}
+
+// sendMessage sends a logical replication message in a XLogData message.
+func (ws *walSender) sendMessage(ctx context.Context, at lsn.LSN, msg []byte) error {
+	ws.buf = pgoutput.AppendXLogData(ws.buf[:0], at, at, timeutil.Now(), msg)
+	return ws.conn.SendCopyData(ctx, ws.buf)
+}
+
+// sendKeepalive sends a keepalive message carrying the position up to which
+// all the changes have been sent.
+func (ws *walSender) sendKeepalive(ctx context.Context, replyRequested bool) error {
+	ws.buf = pgoutput.AppendPrimaryKeepalive(ws.buf[:0], ws.walEnd, timeutil.Now(), replyRequested)
+	return ws.conn.SendCopyData(ctx, ws.buf)
+}
+
+// persistFlushed writes the position acknowledged by the client to the slot,
+// at most once per walSenderPersistInterval unless force is true.
+func (ws *walSender) persistFlushed(ctx context.Context, force bool) error {
+	if ws.flushed <= ws.persisted {
+		return nil
+	}
+	if !force && timeutil.Since(ws.lastPersist) < walSenderPersistInterval {
+		return nil
+	}
+	if err := advanceReplicationSlot(ctx, ws.cfg, ws.slotName, ws.flushed); err != nil {
+		return err
+	}
+	ws.persisted = ws.flushed
+	ws.lastPersist = timeutil.Now()
+	return nil
+}
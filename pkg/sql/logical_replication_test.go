@@ -0,0 +1,75 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalSenderGroupTransactions(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	change := func(key string, wallTime int64, logical int32) *kvpb.RangeFeedValue {
+		v := &kvpb.RangeFeedValue{Key: roachpb.Key(key)}
+		v.Value.Timestamp = hlc.Timestamp{WallTime: wallTime, Logical: logical}
+		return v
+	}
+	pending := []*kvpb.RangeFeedValue{
+		change("a", 10, 0),
+		change("b", 10, 0),
+		change("a", 20, 0),
+		change("b", 20, 1),
+		change("c", 20, 1),
+		change("a", 30, 0),
+	}
+
+	type txn struct {
+		lsn  lsn.LSN
+		keys []string
+	}
+	group := func(end lsn.LSN) (res []txn, n int) {
+		txns, n := groupTransactions(pending, end)
+		for _, tx := range txns {
+			var keys []string
+			for _, v := range tx.values {
+				require.Equal(t, tx.commitTS, v.Timestamp())
+				keys = append(keys, string(v.Key))
+			}
+			res = append(res, txn{lsn: tx.lsn, keys: keys})
+		}
+		return res, n
+	}
+
+	// The changes committed at distinct timestamps are sent as distinct
+	// transactions, even if the timestamps have the same wall time. All but
+	// the last one of the transactions sharing a LSN are positioned before it.
+	txns, n := group(29)
+	require.Equal(t, 5, n)
+	require.Equal(t, []txn{
+		{lsn: 10, keys: []string{"a", "b"}},
+		{lsn: 19, keys: []string{"a"}},
+		{lsn: 20, keys: []string{"b", "c"}},
+	}, txns)
+
+	// The changes beyond the end position are left pending.
+	txns, n = group(9)
+	require.Zero(t, n)
+	require.Empty(t, txns)
+}
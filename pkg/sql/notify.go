@@ -0,0 +1,59 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgnotify"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// Notify implements the NOTIFY statement.
+// See https://www.postgresql.org/docs/current/sql-notify.html for details.
+func (p *planner) Notify(ctx context.Context, n *tree.Notify) (planNode, error) {
+	if err := p.checkNotificationsSupported(ctx, "NOTIFY"); err != nil {
+		return nil, err
+	}
+	notification := p.makeNotification(string(n.ChannelName), n.Payload)
+	if err := notification.Validate(); err != nil {
+		return nil, err
+	}
+	return &notifyNode{notification: notification}, nil
+}
+
+// SendNotification is part of the eval.Planner interface.
+func (p *planner) SendNotification(ctx context.Context, channel, payload string) error {
+	if err := p.checkNotificationsSupported(ctx, "pg_notify"); err != nil {
+		return err
+	}
+	return pgnotify.Notify(ctx, p.InternalSQLTxn(), p.makeNotification(channel, payload))
+}
+
+func (p *planner) makeNotification(channel, payload string) pgnotify.Notification {
+	return pgnotify.Notification{
+		Channel:   channel,
+		Payload:   payload,
+		SenderPID: int32(p.EvalContext().QueryCancelKey.GetPGBackendPID()),
+	}
+}
+
+type notifyNode struct {
+	notification pgnotify.Notification
+}
+
+func (n *notifyNode) startExec(params runParams) error {
+	return pgnotify.Notify(params.ctx, params.p.InternalSQLTxn(), n.notification)
+}
+
+func (n *notifyNode) Next(_ runParams) (bool, error) { return false, nil }
+func (n *notifyNode) Values() tree.Datums            { return nil }
+func (n *notifyNode) Close(_ context.Context)        {}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 )
 
@@ -51,6 +52,11 @@ type Table interface {
 	// that they cannot be mutated.
 	IsMaterializedView() bool
 
+	// IsForeignTable returns true if this table is a foreign table, whose rows
+	// are read from files in external storage. Foreign tables cannot be
+	// mutated.
+	IsForeignTable() bool
+
 	// ColumnCount returns the number of columns in the table. This includes
 	// public columns, write-only columns, etc.
 	ColumnCount() int
@@ -180,6 +186,21 @@ type Table interface {
 	// IsHypothetical returns true if this is a hypothetical table (used when
 	// searching for index recommendations).
 	IsHypothetical() bool
+
+	// TriggerCount returns the number of triggers defined on this table.
+	TriggerCount() int
+
+	// Trigger returns the ith trigger defined on this table, where
+	// i < TriggerCount.
+	Trigger(i int) Trigger
+
+	// ExclusionCount returns the number of exclusion constraints defined on
+	// this table.
+	ExclusionCount() int
+
+	// Exclusion returns the ith exclusion constraint defined on this table,
+	// where i < ExclusionCount.
+	Exclusion(i int) ExclusionConstraint
 }
 
 // CheckConstraint represents a check constraint on a table. Check constraints
@@ -203,6 +224,28 @@ type CheckConstraint interface {
 	ColumnOrdinal(i int) int
 }
 
+// ExclusionConstraint represents an exclusion constraint on a table. No two
+// rows of the table may conflict, where two rows conflict if comparing the
+// values of each column of the constraint with its operator yields true for
+// all columns. For example, this exclusion constraint ensures that no two
+// bookings of the same room overlap:
+//
+//	CREATE TABLE b (room INT, during TSTZRANGE, EXCLUDE USING gist (room WITH =, during WITH &&))
+type ExclusionConstraint interface {
+	// Name is the name of the constraint.
+	Name() string
+
+	// ColumnCount returns the number of columns in this constraint.
+	ColumnCount() int
+
+	// ColumnOrdinal returns the table column ordinal of the ith column in this
+	// constraint.
+	ColumnOrdinal(i int) int
+
+	// Operator returns the operator used to compare the ith column of two rows.
+	Operator(i int) treecmp.ComparisonOperatorSymbol
+}
+
 // TableStatistic is an interface to a table statistic. Each statistic is
 // associated with a set of columns.
 type TableStatistic interface {
@@ -328,6 +371,11 @@ type ForeignKeyConstraint interface {
 	// UpdateReferenceAction returns the action to be performed if the foreign key
 	// constraint would be violated by an update.
 	UpdateReferenceAction() tree.ReferenceAction
+
+	// Deferrability returns whether the checks of the foreign key can be
+	// deferred until the end of the transaction with SET CONSTRAINTS, and
+	// whether they are deferred by default.
+	Deferrability() tree.ConstraintDeferrability
 }
 
 // UniqueConstraint represents a uniqueness constraint. UniqueConstraints may
@@ -375,6 +423,12 @@ type UniqueConstraint interface {
 	// satisfied when building functional dependencies for the table. This enables
 	// additional optimizations, such as omission of uniqueness checks.
 	UniquenessGuaranteedByAnotherIndex() bool
+
+	// Deferrability returns whether the uniqueness checks can be deferred
+	// until the end of the transaction with SET CONSTRAINTS, and whether they
+	// are deferred by default. Only constraints which are not enforced by an
+	// index can be deferrable.
+	Deferrability() tree.ConstraintDeferrability
 }
 
 // UniqueOrdinal identifies a unique constraint (in the context of a Table).
@@ -0,0 +1,68 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cat
+
+import "github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+
+// Trigger is an interface to a table trigger, which executes a trigger function
+// in response to a mutation of the table. It exposes only the information
+// needed by the query optimizer.
+type Trigger interface {
+	// Name is the name of the trigger. It is unique within the table.
+	Name() tree.Name
+
+	// ActionTime determines whether the trigger fires before, after, or instead
+	// of the triggering event.
+	ActionTime() tree.TriggerActionTime
+
+	// EventCount returns the number of events that fire the trigger.
+	EventCount() int
+
+	// Event returns the ith event that fires the trigger, where
+	// i < EventCount.
+	Event(i int) TriggerEvent
+
+	// ForEachRow is true if the trigger fires once for each row affected by the
+	// triggering event, and false if it fires once per statement.
+	ForEachRow() bool
+
+	// WhenExpr is the serialized text of the optional condition that determines
+	// whether the trigger fires. It is empty if the trigger always fires.
+	WhenExpr() string
+
+	// FuncID is the ID of the trigger function.
+	FuncID() StableID
+
+	// FuncArgs returns the arguments passed to the trigger function through
+	// TG_ARGV.
+	FuncArgs() []string
+
+	// Enabled is true if the trigger fires in response to its events.
+	Enabled() bool
+}
+
+// TriggerEvent is an event that fires a trigger. For UPDATE events, Columns
+// optionally restricts the trigger to updates that target one of the listed
+// columns.
+type TriggerEvent struct {
+	EventType tree.TriggerEventType
+	Columns   []tree.Name
+}
+
+// HasTriggerEvent returns true if the trigger fires on the given event type.
+func HasTriggerEvent(trigger Trigger, eventType tree.TriggerEventType) bool {
+	for i, n := 0, trigger.EventCount(); i < n; i++ {
+		if trigger.Event(i).EventType == eventType {
+			return true
+		}
+	}
+	return false
+}
@@ -152,9 +152,16 @@ func makeCascadeBuilder(b *Builder, mutationWithID opt.WithID) (*cascadeBuilder,
 
 // setupCascade fills in an exec.Cascade struct for the given cascade.
 func (cb *cascadeBuilder) setupCascade(cascade *memo.FKCascade) exec.Cascade {
+	buffer := cb.mutationBuffer
+	if cascade.FKConstraint == nil && cascade.WithID == 0 {
+		// Statement-level AFTER triggers do not read the mutation input, and must
+		// fire even if no rows were modified, so they are not tied to the buffer.
+		buffer = nil
+	}
 	return exec.Cascade{
 		FKConstraint: cascade.FKConstraint,
-		Buffer:       cb.mutationBuffer,
+		Trigger:      cascade.Trigger,
+		Buffer:       buffer,
 		PlanFn: func(
 			ctx context.Context,
 			semaCtx *tree.SemaContext,
@@ -24,6 +24,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/row"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil"
 	"github.com/cockroachdb/cockroach/pkg/util/intsets"
 	"github.com/cockroachdb/errors"
 )
@@ -135,6 +136,10 @@ func (b *Builder) buildInsert(ins *memo.InsertExpr) (_ execPlan, outputCols colO
 		return execPlan{}, colOrdMap{}, err
 	}
 
+	if err := b.buildFKCascades(ins.WithID, ins.FKCascades); err != nil {
+		return execPlan{}, colOrdMap{}, err
+	}
+
 	return ep, outputCols, nil
 }
 
@@ -158,6 +163,10 @@ func (b *Builder) tryBuildFastPathInsert(
 	if len(ins.UniqueChecks) != len(ins.FastPathUniqueChecks) {
 		return execPlan{}, colOrdMap{}, false, nil
 	}
+	// Post-queries, such as AFTER triggers, cannot be run by the fast path.
+	if len(ins.FKCascades) > 0 {
+		return execPlan{}, colOrdMap{}, false, nil
+	}
 
 	insInput := ins.Input
 	values, ok := insInput.(*memo.ValuesExpr)
@@ -854,10 +863,65 @@ func (b *Builder) buildFKChecks(checks memo.FKChecksExpr) error {
 	return nil
 }
 
+// BuildDeferredFKCheck builds a plan for the check of a deferred foreign key
+// constraint, whose query is the root expression (see
+// optbuilder.BuildDeferredFKCheck). The check is built like the FK checks of a
+// mutation, except that it is the main query of the plan rather than a
+// post-query: the plan produces no rows, and returns an error if the check
+// query returns any.
+func (b *Builder) BuildDeferredFKCheck(private *memo.FKChecksItemPrivate) (exec.Plan, error) {
+	return b.buildDeferredCheck(func() error {
+		return b.buildFKChecks(memo.FKChecksExpr{{
+			Check:               b.e.(memo.RelExpr),
+			FKChecksItemPrivate: *private,
+		}})
+	})
+}
+
+// BuildDeferredUniqueCheck builds a plan for the check of a deferred unique
+// constraint, whose query is the root expression. See BuildDeferredFKCheck.
+func (b *Builder) BuildDeferredUniqueCheck(
+	private *memo.UniqueChecksItemPrivate,
+) (exec.Plan, error) {
+	return b.buildDeferredCheck(func() error {
+		return b.buildUniqueChecks(memo.UniqueChecksExpr{{
+			Check:                   b.e.(memo.RelExpr),
+			UniqueChecksItemPrivate: *private,
+		}})
+	})
+}
+
+func (b *Builder) buildDeferredCheck(buildCheck func() error) (_ exec.Plan, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			// See Builder.build.
+			if ok, e := errorutil.ShouldCatch(r); ok {
+				err = e
+			} else {
+				panic(r)
+			}
+		}
+	}()
+	if err := buildCheck(); err != nil {
+		return nil, err
+	}
+	if len(b.checks) != 1 {
+		return nil, errors.AssertionFailedf("expected a single check, found %d", len(b.checks))
+	}
+	root := b.checks[0]
+	b.checks = nil
+	return b.factory.ConstructPlan(
+		root, b.subqueries, b.cascades, b.checks, 0 /* rootRowCount */, b.flags,
+	)
+}
+
 // mkUniqueCheckErr generates a user-friendly error describing a uniqueness
 // violation. The keyVals are the values that correspond to the
 // cat.UniqueConstraint columns.
 func mkUniqueCheckErr(md *opt.Metadata, c *memo.UniqueChecksItem, keyVals tree.Datums) error {
+	if c.Exclusion {
+		return mkExclusionCheckErr(md, c, keyVals)
+	}
 	tabMeta := md.TableMeta(c.Table)
 	uc := tabMeta.Table.Unique(c.CheckOrdinal)
 	constraintName := uc.Name()
@@ -896,6 +960,62 @@ func mkUniqueCheckErr(md *opt.Metadata, c *memo.UniqueChecksItem, keyVals tree.D
 	)
 }
 
+// mkExclusionCheckErr generates a user-friendly error describing an exclusion
+// constraint violation. The keyVals are the values of the
+// cat.ExclusionConstraint columns of the new row, followed by those of the
+// existing row it conflicts with.
+func mkExclusionCheckErr(md *opt.Metadata, c *memo.UniqueChecksItem, keyVals tree.Datums) error {
+	tabMeta := md.TableMeta(c.Table)
+	ec := tabMeta.Table.Exclusion(c.CheckOrdinal)
+	constraintName := ec.Name()
+	var msg, cols, details bytes.Buffer
+
+	// Generate an error of the form:
+	//   ERROR:  conflicting key value violates exclusion constraint "foo"
+	//   DETAIL: Key (r)=([1,5)) conflicts with existing key (r)=([3,7)).
+	msg.WriteString("conflicting key value violates exclusion constraint ")
+	lexbase.EncodeEscapedSQLIdent(&msg, constraintName)
+
+	cols.WriteString("(")
+	for i := 0; i < ec.ColumnCount(); i++ {
+		if i > 0 {
+			cols.WriteString(", ")
+		}
+		col := tabMeta.Table.Column(ec.ColumnOrdinal(i))
+		cols.WriteString(string(col.ColName()))
+	}
+	cols.WriteString(")")
+	writeVals := func(vals tree.Datums) {
+		details.WriteString("(")
+		for i, d := range vals {
+			if i > 0 {
+				details.WriteString(", ")
+			}
+			details.WriteString(d.String())
+		}
+		details.WriteString(")")
+	}
+
+	n := ec.ColumnCount()
+	details.WriteString("Key ")
+	details.Write(cols.Bytes())
+	details.WriteString("=")
+	writeVals(keyVals[:n])
+	details.WriteString(" conflicts with existing key ")
+	details.Write(cols.Bytes())
+	details.WriteString("=")
+	writeVals(keyVals[n:])
+	details.WriteString(".")
+
+	return errors.WithDetail(
+		pgerror.WithConstraintName(
+			pgerror.Newf(pgcode.ExclusionViolation, "%s", msg.String()),
+			constraintName,
+		),
+		details.String(),
+	)
+}
+
 // mkFastPathUniqueCheckErr is a wrapper for mkUniqueCheckErr in the insert fast
 // path flow, which reorders the keyVals row according to the ordering of the
 // key columns in index `idx`. This is needed because mkUniqueCheckErr assumes
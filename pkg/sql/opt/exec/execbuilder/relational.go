@@ -1654,12 +1654,30 @@ func (b *Builder) buildGroupBy(groupBy memo.RelExpr) (_ execPlan, outputCols col
 			agg = aggDistinct.Input
 		}
 
-		name, overload := memo.FindAggregateOverload(agg)
+		var name string
+		var distsqlBlocklist bool
+		var userDefined *exec.UserDefinedAggInfo
+		var args opt.Expr = agg
+		if uda, ok := agg.(*memo.UserDefinedAggExpr); ok {
+			// Support functions that are routines can only be evaluated by the
+			// gateway node, so the aggregate can only be evaluated in
+			// distributed fashion if all of them could be inlined.
+			userDefined, err = b.buildUserDefinedAggInfo(uda)
+			if err != nil {
+				return execPlan{}, colOrdMap{}, err
+			}
+			name, distsqlBlocklist = uda.Name, userDefined.HasRoutines()
+			args = &uda.Args
+		} else {
+			var overload *tree.Overload
+			name, overload = memo.FindAggregateOverload(agg)
+			distsqlBlocklist = overload.DistsqlBlocklist
+		}
 
 		// Accumulate variable arguments in argCols and constant arguments in
 		// constArgs. Constant arguments must follow variable arguments.
-		for j, n := 0, agg.ChildCount(); j < n; j++ {
-			child := agg.Child(j)
+		for j, n := 0, args.ChildCount(); j < n; j++ {
+			child := args.Child(j)
 			if variable, ok := child.(*memo.VariableExpr); ok {
 				if len(constArgs) != 0 {
 					return execPlan{}, colOrdMap{}, errors.Errorf("constant args must come after variable args")
@@ -1688,7 +1706,8 @@ func (b *Builder) buildGroupBy(groupBy memo.RelExpr) (_ execPlan, outputCols col
 			ArgCols:          argCols[:len(argCols):len(argCols)],
 			ConstArgs:        constArgs[:len(constArgs):len(constArgs)],
 			Filter:           filterOrd,
-			DistsqlBlocklist: overload.DistsqlBlocklist,
+			DistsqlBlocklist: distsqlBlocklist,
+			UserDefined:      userDefined,
 		}
 		outputCols.Set(item.Col, len(groupingColIdx)+i)
 		// Slice argCols and constArgs so the rest of their capacity can be
@@ -3419,7 +3438,9 @@ func (b *Builder) buildCall(c *memo.CallExpr) (_ execPlan, outputCols colOrdMap,
 		false, /* blockStart */
 		nil,   /* blockState */
 		nil,   /* cursorDeclaration */
+		nil,   /* resultBuffer */
 	)
+	r.SessionSettings = udf.Def.SessionSettings
 
 	var ep execPlan
 	ep.root, err = b.factory.ConstructCall(r)
@@ -715,6 +715,7 @@ func (b *Builder) buildExistsSubquery(
 				false, /* blockStart */
 				nil,   /* blockState */
 				nil,   /* cursorDeclaration */
+				nil,   /* resultBuffer */
 			),
 			tree.DBoolFalse,
 		}, types.Bool), nil
@@ -836,6 +837,7 @@ func (b *Builder) buildSubquery(
 			false, /* blockStart */
 			nil,   /* blockState */
 			nil,   /* cursorDeclaration */
+			nil,   /* resultBuffer */
 		), nil
 	}
 
@@ -896,6 +898,7 @@ func (b *Builder) buildSubquery(
 			false, /* blockStart */
 			nil,   /* blockState */
 			nil,   /* cursorDeclaration */
+			nil,   /* resultBuffer */
 		), nil
 	}
 
@@ -973,6 +976,13 @@ func (b *Builder) buildUDF(ctx *buildScalarCtx, scalar opt.ScalarExpr) (tree.Typ
 			"expected more than one body statement for a routine that opens a cursor",
 		))
 	}
+	// Similarly, a nested routine that adds to a result buffer must have more
+	// than one body statement.
+	if udf.Def.ResultBuffer != nil && !udf.Def.SetReturning && len(udf.Def.Body) <= 1 {
+		panic(errors.AssertionFailedf(
+			"expected more than one body statement for a routine that adds to a result buffer",
+		))
+	}
 
 	// Create a tree.RoutinePlanFn that can plan the statements in the UDF body.
 	// TODO(mgartner): Add support for WITH expressions inside UDF bodies.
@@ -994,7 +1004,7 @@ func (b *Builder) buildUDF(ctx *buildScalarCtx, scalar opt.ScalarExpr) (tree.Typ
 	// routine is in tail-call position.
 	_, tailCall := b.tailCalls[udf]
 
-	return tree.NewTypedRoutineExpr(
+	r := tree.NewTypedRoutineExpr(
 		udf.Def.Name,
 		args,
 		planGen,
@@ -1008,7 +1018,167 @@ func (b *Builder) buildUDF(ctx *buildScalarCtx, scalar opt.ScalarExpr) (tree.Typ
 		udf.Def.BlockStart,
 		blockState,
 		udf.Def.CursorDeclaration,
-	), nil
+		udf.Def.ResultBuffer,
+	)
+	r.SessionSettings = udf.Def.SessionSettings
+	return r, nil
+}
+
+// buildUserDefinedAggInfo builds the support functions of the given
+// user-defined aggregate. Support functions that can be inlined are built as
+// scalar expressions, and all others are built as routines. The arguments of
+// the support functions are supplied by the aggregate during execution.
+func (b *Builder) buildUserDefinedAggInfo(
+	uda *memo.UserDefinedAggExpr,
+) (info *exec.UserDefinedAggInfo, err error) {
+	info = &exec.UserDefinedAggInfo{
+		StateType: uda.Def.StateType,
+		InitCond:  tree.DNull,
+	}
+	if info.StateFunc, err = b.buildAggregateSupportFunc(uda.Def.StateFunc); err != nil {
+		return nil, err
+	}
+	if uda.Def.FinalFunc != nil {
+		if info.FinalFunc, err = b.buildAggregateSupportFunc(uda.Def.FinalFunc); err != nil {
+			return nil, err
+		}
+	}
+	if uda.Def.CombineFunc != nil {
+		if info.CombineFunc, err = b.buildAggregateSupportFunc(uda.Def.CombineFunc); err != nil {
+			return nil, err
+		}
+	}
+	if uda.Def.InitCond != nil {
+		info.InitCond = uda.Def.InitCond
+	}
+	return info, nil
+}
+
+// buildAggregateSupportFunc builds the given support function of a
+// user-defined aggregate. If the function can be inlined, it is built as a
+// scalar expression in which the i-th parameter is represented by an
+// IndexedVar with ordinal i. Otherwise, it is built as a routine.
+func (b *Builder) buildAggregateSupportFunc(def *memo.UDFDefinition) (tree.TypedExpr, error) {
+	if scalar := inlinableAggregateSupportFunc(b.evalCtx, def); scalar != nil {
+		colMap := b.colOrdsAlloc.Alloc()
+		defer b.colOrdsAlloc.Free(colMap)
+		for i, col := range def.Params {
+			colMap.Set(col, i)
+		}
+		ctx := buildScalarCtx{
+			ivh:     tree.MakeIndexedVarHelper(nil /* container */, len(def.Params)),
+			ivarMap: colMap,
+		}
+		return b.buildScalar(&ctx, scalar)
+	}
+	return b.buildAggregateSupportRoutine(def), nil
+}
+
+// inlinableAggregateSupportFunc returns the scalar expression computing the
+// result of the given support function of a user-defined aggregate, if the
+// function can be inlined. Unlike a routine, an inlined support function can
+// be serialized and evaluated by any node. A support function can be inlined
+// if:
+//
+//  1. It is a non-volatile SQL function with a single statement in its body
+//     which always returns exactly one row.
+//  2. It is called on NULL input. The aggregate relies on the strictness of
+//     the state transition function to skip rows, which is not known for a
+//     scalar expression.
+//  3. Its result is computed by a scalar expression which only references the
+//     parameters of the function, and which does not contain subqueries or
+//     calls to other user-defined functions.
+//
+// nil is returned if the function cannot be inlined.
+func inlinableAggregateSupportFunc(
+	evalCtx *eval.Context, def *memo.UDFDefinition,
+) opt.ScalarExpr {
+	if def.IsRecursive || def.Volatility == volatility.Volatile || !def.CalledOnNullInput ||
+		def.RoutineLang != tree.RoutineLangSQL || len(def.Body) != 1 || def.SetReturning ||
+		def.MultiColDataSource || def.SessionSettings != nil || def.BlockState != nil {
+		return nil
+	}
+	body := def.Body[0]
+	if !body.Relational().Cardinality.IsOne() {
+		return nil
+	}
+	col := def.BodyProps[0].Presentation[0].ID
+	var scalar opt.ScalarExpr
+	for scalar == nil {
+		switch t := body.(type) {
+		case *memo.LimitExpr:
+			body = t.Input
+		case *memo.ProjectExpr:
+			if t.Passthrough.Contains(col) {
+				body = t.Input
+				continue
+			}
+			for i := range t.Projections {
+				if t.Projections[i].Col == col {
+					scalar = t.Projections[i].Element
+					break
+				}
+			}
+			if scalar == nil {
+				return nil
+			}
+		case *memo.ValuesExpr:
+			idx, ok := t.Cols.Find(col)
+			if !ok || len(t.Rows) != 1 {
+				return nil
+			}
+			scalar = t.Rows[0].(*memo.TupleExpr).Elems[idx]
+		default:
+			return nil
+		}
+	}
+	var shared props.Shared
+	memo.BuildSharedProps(scalar, &shared, evalCtx)
+	if shared.HasSubquery || shared.HasUDF || shared.HasPlaceholder ||
+		!shared.OuterCols.SubsetOf(def.Params.ToSet()) || !scalar.DataType().Identical(def.Typ) {
+		return nil
+	}
+	return scalar
+}
+
+// buildAggregateSupportRoutine builds a routine without arguments for the
+// given support function of a user-defined aggregate.
+func (b *Builder) buildAggregateSupportRoutine(def *memo.UDFDefinition) *tree.RoutineExpr {
+	for _, s := range def.Body {
+		if s.Relational().CanMutate {
+			b.flags.Set(exec.PlanFlagContainsMutation)
+			break
+		}
+	}
+	blockState := def.BlockState
+	if blockState != nil {
+		blockState.VariableCount = len(def.Params)
+		b.initRoutineExceptionHandler(blockState, def.ExceptionBlock)
+	}
+	planGen := b.buildRoutinePlanGenerator(
+		def.Params,
+		def.Body,
+		def.BodyProps,
+		def.BodyStmts,
+		false, /* allowOuterWithRefs */
+		nil,   /* wrapRootExpr */
+	)
+	return tree.NewTypedRoutineExpr(
+		def.Name,
+		nil, /* args */
+		planGen,
+		def.Typ,
+		def.Volatility == volatility.Volatile, /* enableStepping */
+		def.CalledOnNullInput,
+		false, /* multiColOutput */
+		false, /* generator */
+		false, /* tailCall */
+		false, /* procedure */
+		def.BlockStart,
+		blockState,
+		def.CursorDeclaration,
+		def.ResultBuffer,
+	)
 }
 
 func (b *Builder) buildRoutineArgs(
@@ -1064,6 +1234,7 @@ func (b *Builder) initRoutineExceptionHandler(
 			false, /* blockStart */
 			nil,   /* blockState */
 			nil,   /* cursorDeclaration */
+			nil,   /* resultBuffer */
 		)
 	}
 	blockState.ExceptionHandler = exceptionHandler
@@ -90,6 +90,13 @@ func TestLogic_tmp(t *testing.T) {
 	logictest.RunLogicTests(t, serverArgs, configIdx, glob)
 }
 
+func TestExecBuild_aggregate_udf(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runExecBuildLogicTest(t, "aggregate_udf")
+}
+
 func TestExecBuild_dist_union(
 	t *testing.T,
 ) {
@@ -121,8 +121,20 @@ func emitInternal(
 	}
 
 	for _, cascade := range plan.Cascades {
-		ob.EnterMetaNode("fk-cascade")
-		ob.Attr("fk", cascade.FKConstraint.Name())
+		// Come up with a custom "id" for this FK or trigger.
+		var cascadeID string
+		if fk := cascade.FKConstraint; fk != nil {
+			ob.EnterMetaNode("fk-cascade")
+			ob.Attr("fk", fk.Name())
+			cascadeID = fmt.Sprintf("%d%s", fk.OriginTableID(), fk.Name())
+		} else if trigger := cascade.Trigger; trigger != nil {
+			ob.EnterMetaNode("after-trigger")
+			ob.Attr("trigger", trigger.Name())
+			cascadeID = fmt.Sprintf("trigger%d%s", trigger.FuncID(), trigger.Name())
+		} else {
+			ob.EnterMetaNode("merge-delete")
+			cascadeID = "merge-delete"
+		}
 		// Here we do want to allow creation of the plans for the cascades to be
 		// able to include them into the EXPLAIN output.
 		const createPlanIfMissing = true
@@ -132,18 +144,15 @@ func emitInternal(
 			if visitedFKsByCascades == nil {
 				visitedFKsByCascades = make(map[string]struct{})
 			}
-			fk := cascade.FKConstraint
-			// Come up with a custom "id" for this FK.
-			fkID := fmt.Sprintf("%d%s", fk.OriginTableID(), fk.Name())
-			if _, visited := visitedFKsByCascades[fkID]; visited {
+			if _, visited := visitedFKsByCascades[cascadeID]; visited {
 				// If we have already visited this particular FK cascade, we
 				// don't recurse into it again to prevent infinite recursion.
 				if buffer := cascade.Buffer; buffer != nil {
 					ob.Attr("input", buffer.(*Node).args.(*bufferArgs).Label)
 				}
 			} else {
-				visitedFKsByCascades[fkID] = struct{}{}
-				defer delete(visitedFKsByCascades, fkID)
+				visitedFKsByCascades[cascadeID] = struct{}{}
+				defer delete(visitedFKsByCascades, cascadeID)
 				if err = emitInternal(ctx, cascadePlan.(*Plan), ob, spanFormatFn, visitedFKsByCascades); err != nil {
 					return err
 				}
@@ -509,6 +509,10 @@ func (u *unknownTable) IsMaterializedView() bool {
 	return false
 }
 
+func (u *unknownTable) IsForeignTable() bool {
+	return false
+}
+
 func (u *unknownTable) ColumnCount() int {
 	return 0
 }
@@ -628,6 +632,26 @@ func (u *unknownTable) IsHypothetical() bool {
 	return false
 }
 
+// TriggerCount is part of the cat.Table interface.
+func (u *unknownTable) TriggerCount() int {
+	return 0
+}
+
+// Trigger is part of the cat.Table interface.
+func (u *unknownTable) Trigger(i int) cat.Trigger {
+	panic(errors.AssertionFailedf("not implemented"))
+}
+
+// ExclusionCount is part of the cat.Table interface.
+func (u *unknownTable) ExclusionCount() int {
+	return 0
+}
+
+// Exclusion is part of the cat.Table interface.
+func (u *unknownTable) Exclusion(i int) cat.ExclusionConstraint {
+	panic(errors.AssertionFailedf("not implemented"))
+}
+
 var _ cat.Table = &unknownTable{}
 
 // unknownTable implements the cat.Index interface and is used to represent
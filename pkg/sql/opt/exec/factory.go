@@ -216,6 +216,47 @@ type AggInfo struct {
 	// DistsqlBlocklist is set to true when this aggregate function cannot be
 	// evaluated in distributed fashion.
 	DistsqlBlocklist bool
+
+	// UserDefined is set if this is a user-defined aggregate, in which case
+	// FuncName is the name of the aggregate.
+	UserDefined *UserDefinedAggInfo
+}
+
+// UserDefinedAggInfo represents the support functions of a user-defined
+// aggregate that must be passed through to the execution engine. Each support
+// function is either a routine or, if the function could be inlined, a scalar
+// expression in which the i-th parameter of the function is represented by an
+// IndexedVar with ordinal i.
+type UserDefinedAggInfo struct {
+	// StateFunc is the state transition function of the aggregate.
+	StateFunc tree.TypedExpr
+
+	// FinalFunc is the final function of the aggregate. It is nil if the
+	// aggregate has no final function.
+	FinalFunc tree.TypedExpr
+
+	// CombineFunc is the combine function of the aggregate, which merges two
+	// partial states. It is nil if the aggregate has no combine function.
+	CombineFunc tree.TypedExpr
+
+	// StateType is the type of the state of the aggregate.
+	StateType *types.T
+
+	// InitCond is the initial state of the aggregate, which is NULL if the
+	// aggregate has no initial condition.
+	InitCond tree.Datum
+}
+
+// HasRoutines returns true if any of the support functions of the aggregate
+// is a routine. Routines can only be evaluated by the gateway node, so such an
+// aggregate cannot be evaluated in distributed fashion.
+func (u *UserDefinedAggInfo) HasRoutines() bool {
+	for _, fn := range []tree.TypedExpr{u.StateFunc, u.FinalFunc, u.CombineFunc} {
+		if _, ok := fn.(*tree.RoutineExpr); ok {
+			return true
+		}
+	}
+	return false
 }
 
 // WindowInfo represents the information about a window function that must be
@@ -277,8 +318,14 @@ type ApplyJoinPlanRightSideFn func(ctx context.Context, ef Factory, leftRow tree
 // ConstructBuffer as an input; it should only be triggered if this buffer is
 // not empty.
 type Cascade struct {
+	// FKConstraint is the foreign key constraint that is enforced by the
+	// cascade. It is nil if the cascade fires an AFTER trigger instead.
 	FKConstraint cat.ForeignKeyConstraint
 
+	// Trigger is the AFTER trigger fired by the cascade, if FKConstraint is nil.
+	// If both are nil, the cascade deletes rows for a MERGE statement.
+	Trigger cat.Trigger
+
 	// Buffer is the Node returned by ConstructBuffer which stores the input to
 	// the mutation. It is nil if the cascade does not require a buffer.
 	Buffer Node
@@ -46,6 +46,9 @@ func NewDatumsToInvertedExpr(
 	if !geoConfig.IsEmpty() {
 		return NewGeoDatumsToInvertedExpr(ctx, evalCtx, colTypes, expr, geoConfig)
 	}
+	if isRangeInvertedExpr(expr) {
+		return NewRangeDatumsToInvertedExpr(ctx, evalCtx, colTypes, expr)
+	}
 
 	return NewJSONOrArrayDatumsToInvertedExpr(ctx, evalCtx, colTypes, expr)
 }
@@ -146,6 +149,12 @@ func TryFilterInvertedIndex(
 				index:           index,
 				computedColumns: computedColumns,
 			}
+		case types.RangeFamily:
+			filterPlanner = &rangeFilterPlanner{
+				tabID:           tabID,
+				index:           index,
+				computedColumns: computedColumns,
+			}
 		default:
 			return nil, nil, nil, nil, false
 		}
@@ -477,11 +486,22 @@ func TryJoinInvertedIndex(
 			getSpanExpr: getSpanExprForGeometryIndex,
 		}
 	} else {
-		joinPlanner = &jsonOrArrayJoinPlanner{
-			factory:   factory,
-			tabID:     tabID,
-			index:     index,
-			inputCols: inputCols,
+		col := index.InvertedColumn().InvertedSourceColumnOrdinal()
+		typ := factory.Metadata().Table(tabID).Column(col).DatumType()
+		if typ.Family() == types.RangeFamily {
+			joinPlanner = &rangeJoinPlanner{
+				factory:   factory,
+				tabID:     tabID,
+				index:     index,
+				inputCols: inputCols,
+			}
+		} else {
+			joinPlanner = &jsonOrArrayJoinPlanner{
+				factory:   factory,
+				tabID:     tabID,
+				index:     index,
+				inputCols: inputCols,
+			}
 		}
 	}
 
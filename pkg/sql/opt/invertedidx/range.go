@@ -0,0 +1,313 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package invertedidx
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/inverted"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/invertedexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/norm"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// getInvertedExprForRangeIndex gets an inverted.Expression that constrains a
+// range index to the ranges that may overlap (&&) or be adjacent to (-|-) the
+// given constant, depending on the given operator. The returned expression is
+// nil if no range can satisfy the operator.
+func getInvertedExprForRangeIndex(
+	ctx context.Context, evalCtx *eval.Context, op treecmp.ComparisonOperatorSymbol, d tree.Datum,
+) inverted.Expression {
+	var invertedExpr inverted.Expression
+	var err error
+	switch op {
+	case treecmp.Overlaps:
+		invertedExpr, err = rowenc.EncodeOverlapsInvertedIndexSpans(ctx, evalCtx, d)
+	case treecmp.Adjacent:
+		invertedExpr, err = rowenc.EncodeAdjacentInvertedIndexSpans(d)
+	default:
+		panic(errors.AssertionFailedf("%s cannot be index-accelerated by a range index", op))
+	}
+	if err != nil {
+		panic(err)
+	}
+	return invertedExpr
+}
+
+// rangeOperands returns the operator and arguments of the given expression if
+// it can be accelerated by a range index.
+func rangeOperands(
+	expr opt.ScalarExpr,
+) (op treecmp.ComparisonOperatorSymbol, left, right opt.ScalarExpr, ok bool) {
+	switch t := expr.(type) {
+	case *memo.OverlapsExpr:
+		return treecmp.Overlaps, t.Left, t.Right, true
+	case *memo.AdjacentExpr:
+		return treecmp.Adjacent, t.Left, t.Right, true
+	default:
+		return 0, nil, nil, false
+	}
+}
+
+type rangeFilterPlanner struct {
+	tabID           opt.TableID
+	index           cat.Index
+	computedColumns map[opt.ColumnID]opt.ScalarExpr
+}
+
+var _ invertedFilterPlanner = &rangeFilterPlanner{}
+
+// extractInvertedFilterConditionFromLeaf is part of the invertedFilterPlanner
+// interface.
+func (r *rangeFilterPlanner) extractInvertedFilterConditionFromLeaf(
+	ctx context.Context, evalCtx *eval.Context, expr opt.ScalarExpr,
+) (
+	invertedExpr inverted.Expression,
+	remainingFilters opt.ScalarExpr,
+	_ *invertedexpr.PreFiltererStateForInvertedFilterer,
+) {
+	op, left, right, ok := rangeOperands(expr)
+	if !ok {
+		// Only the above types are supported.
+		return inverted.NonInvertedColExpression{}, expr, nil
+	}
+	// Both operators are commutative, so the index column can be on either
+	// side.
+	var constantVal opt.ScalarExpr
+	if isIndexColumn(r.tabID, r.index, left, r.computedColumns) && memo.CanExtractConstDatum(right) {
+		constantVal = right
+	} else if isIndexColumn(r.tabID, r.index, right, r.computedColumns) && memo.CanExtractConstDatum(left) {
+		constantVal = left
+	} else {
+		// Can only accelerate with a single constant value.
+		return inverted.NonInvertedColExpression{}, expr, nil
+	}
+	invertedExpr = getInvertedExprForRangeIndex(ctx, evalCtx, op, memo.ExtractConstDatum(constantVal))
+	if invertedExpr == nil {
+		// An inverted expression could not be extracted.
+		return inverted.NonInvertedColExpression{}, expr, nil
+	}
+
+	// The index is never tight, so the original expression must be applied
+	// after the inverted index scan. We do not currently support pre-filtering
+	// for range indexes, so the returned pre-filter state is nil.
+	return invertedExpr, expr, nil
+}
+
+type rangeJoinPlanner struct {
+	factory   *norm.Factory
+	tabID     opt.TableID
+	index     cat.Index
+	inputCols opt.ColSet
+}
+
+var _ invertedJoinPlanner = &rangeJoinPlanner{}
+
+// extractInvertedJoinConditionFromLeaf is part of the invertedJoinPlanner
+// interface. It returns an expression in which the index column is the left
+// argument, commuting the arguments of the given expression if necessary.
+func (r *rangeJoinPlanner) extractInvertedJoinConditionFromLeaf(
+	_ context.Context, expr opt.ScalarExpr,
+) opt.ScalarExpr {
+	op, left, right, ok := rangeOperands(expr)
+	if !ok {
+		return nil
+	}
+	var indexCol, val opt.ScalarExpr
+	if isIndexColumn(r.tabID, r.index, left, nil /* computedColumns */) {
+		indexCol, val = left, right
+	} else if isIndexColumn(r.tabID, r.index, right, nil /* computedColumns */) {
+		indexCol, val = right, left
+	} else {
+		return nil
+	}
+	// The non-indexed argument should either come from the input or be a
+	// constant.
+	var p props.Shared
+	memo.BuildSharedProps(val, &p, r.factory.EvalContext())
+	if !p.OuterCols.Empty() {
+		if !p.OuterCols.SubsetOf(r.inputCols) {
+			return nil
+		}
+	} else if !memo.CanExtractConstDatum(val) {
+		return nil
+	}
+	if indexCol == left {
+		return expr
+	}
+	if op == treecmp.Overlaps {
+		return r.factory.ConstructOverlaps(indexCol, val)
+	}
+	return r.factory.ConstructAdjacent(indexCol, val)
+}
+
+// isRangeInvertedExpr returns whether the given expression, an expression tree
+// of And, Or, and leaf comparison expressions produced by the rangeJoinPlanner,
+// compares a range index column.
+func isRangeInvertedExpr(expr tree.TypedExpr) bool {
+	switch t := expr.(type) {
+	case *tree.AndExpr:
+		return isRangeInvertedExpr(t.TypedLeft())
+	case *tree.OrExpr:
+		return isRangeInvertedExpr(t.TypedLeft())
+	case *tree.ComparisonExpr:
+		return t.TypedLeft().ResolvedType().Family() == types.RangeFamily
+	default:
+		return false
+	}
+}
+
+type rangeInvertedExpr struct {
+	tree.ComparisonExpr
+
+	nonIndexParam tree.TypedExpr
+
+	// spanExpr is the result of evaluating the comparison expression represented
+	// by this rangeInvertedExpr if the non-indexed argument is a constant. It is
+	// nil otherwise.
+	spanExpr *inverted.SpanExpression
+}
+
+var _ tree.TypedExpr = &rangeInvertedExpr{}
+
+// rangeDatumsToInvertedExpr implements invertedexpr.DatumsToInvertedExpr for
+// range columns.
+type rangeDatumsToInvertedExpr struct {
+	evalCtx      *eval.Context
+	colTypes     []*types.T
+	invertedExpr tree.TypedExpr
+
+	row   rowenc.EncDatumRow
+	alloc tree.DatumAlloc
+}
+
+var _ invertedexpr.DatumsToInvertedExpr = &rangeDatumsToInvertedExpr{}
+var _ eval.IndexedVarContainer = &rangeDatumsToInvertedExpr{}
+
+// IndexedVarEval is part of the eval.IndexedVarContainer interface.
+func (g *rangeDatumsToInvertedExpr) IndexedVarEval(idx int) (tree.Datum, error) {
+	err := g.row[idx].EnsureDecoded(g.colTypes[idx], &g.alloc)
+	if err != nil {
+		return nil, err
+	}
+	return g.row[idx].Datum, nil
+}
+
+// IndexedVarResolvedType is part of the IndexedVarContainer interface.
+func (g *rangeDatumsToInvertedExpr) IndexedVarResolvedType(idx int) *types.T {
+	return g.colTypes[idx]
+}
+
+// NewRangeDatumsToInvertedExpr returns a new rangeDatumsToInvertedExpr.
+func NewRangeDatumsToInvertedExpr(
+	ctx context.Context, evalCtx *eval.Context, colTypes []*types.T, expr tree.TypedExpr,
+) (invertedexpr.DatumsToInvertedExpr, error) {
+	g := &rangeDatumsToInvertedExpr{
+		evalCtx:  evalCtx,
+		colTypes: colTypes,
+	}
+
+	// getInvertedExprLeaf pre-computes the span expressions of the comparisons
+	// that have a constant as the non-indexed argument, so that they don't need
+	// to be recomputed for every input row.
+	getInvertedExprLeaf := func(expr tree.TypedExpr) (tree.TypedExpr, error) {
+		t, ok := expr.(*tree.ComparisonExpr)
+		if !ok {
+			return nil, fmt.Errorf("unsupported expression %v", expr)
+		}
+		if t.Operator.Symbol != treecmp.Overlaps && t.Operator.Symbol != treecmp.Adjacent {
+			return nil, fmt.Errorf("%s cannot be index-accelerated", t)
+		}
+		// We know that the non-index param is the second param, because the
+		// optimizer already commuted the arguments of any comparisons where that
+		// was not the case. See rangeJoinPlanner for details.
+		nonIndexParam := t.TypedRight()
+		var spanExpr *inverted.SpanExpression
+		if d, ok := nonIndexParam.(tree.Datum); ok {
+			if invertedExpr := getInvertedExprForRangeIndex(ctx, evalCtx, t.Operator.Symbol, d); invertedExpr != nil {
+				spanExpr = invertedExpr.(*inverted.SpanExpression)
+			}
+		}
+		return &rangeInvertedExpr{
+			ComparisonExpr: *t,
+			nonIndexParam:  nonIndexParam,
+			spanExpr:       spanExpr,
+		}, nil
+	}
+
+	var err error
+	g.invertedExpr, err = getInvertedExpr(expr, getInvertedExprLeaf)
+	if err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// Convert implements the invertedexpr.DatumsToInvertedExpr interface.
+func (g *rangeDatumsToInvertedExpr) Convert(
+	ctx context.Context, datums rowenc.EncDatumRow,
+) (*inverted.SpanExpressionProto, interface{}, error) {
+	g.row = datums
+	g.evalCtx.PushIVarContainer(g)
+	defer g.evalCtx.PopIVarContainer()
+
+	evalInvertedExprLeaf := func(expr tree.TypedExpr) (inverted.Expression, error) {
+		t, ok := expr.(*rangeInvertedExpr)
+		if !ok {
+			return nil, fmt.Errorf("unsupported expression %v", expr)
+		}
+		if t.spanExpr != nil {
+			// We call Copy so the caller can modify the returned expression.
+			return t.spanExpr.Copy(), nil
+		}
+		d, err := eval.Expr(ctx, g.evalCtx, t.nonIndexParam)
+		if err != nil {
+			return nil, err
+		}
+		return getInvertedExprForRangeIndex(ctx, g.evalCtx, t.Operator.Symbol, d), nil
+	}
+
+	invertedExpr, err := evalInvertedExpr(g.invertedExpr, evalInvertedExprLeaf)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if invertedExpr == nil {
+		return nil, nil, nil
+	}
+
+	spanExpr, ok := invertedExpr.(*inverted.SpanExpression)
+	if !ok {
+		return nil, nil, fmt.Errorf("unable to construct span expression")
+	}
+
+	return spanExpr.ToProto(), nil, nil
+}
+
+func (g *rangeDatumsToInvertedExpr) CanPreFilter() bool {
+	return false
+}
+
+func (g *rangeDatumsToInvertedExpr) PreFilter(
+	enc inverted.EncVal, preFilters []interface{}, result []bool,
+) (bool, error) {
+	return false, errors.AssertionFailedf("PreFilter called on rangeDatumsToInvertedExpr")
+}
@@ -0,0 +1,224 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package invertedidx_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/invertedidx"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/norm"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/testutils"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/testutils/testcat"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTryJoinRangeIndex(t *testing.T) {
+	semaCtx := tree.MakeSemaContext(nil /* resolver */)
+	st := cluster.MakeTestingClusterSettings()
+	evalCtx := eval.NewTestingEvalContext(st)
+
+	tc := testcat.New()
+
+	// Create the input table.
+	if _, err := tc.ExecuteDDL(
+		"CREATE TABLE t1 (r1 INT8RANGE, r11 INT8RANGE, i1 INT)",
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	// Create the indexed table.
+	if _, err := tc.ExecuteDDL(
+		"CREATE TABLE t2 (r2 INT8RANGE, i2 INT, INVERTED INDEX (r2))",
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	var f norm.Factory
+	f.Init(context.Background(), evalCtx, tc)
+	md := f.Metadata()
+	tn1 := tree.NewUnqualifiedTableName("t1")
+	tn2 := tree.NewUnqualifiedTableName("t2")
+	tab1 := md.AddTable(tc.Table(tn1), tn1)
+	tab2 := md.AddTable(tc.Table(tn2), tn2)
+	rangeOrd := 1
+
+	testCases := []struct {
+		filters      string
+		invertedExpr string
+	}{
+		{
+			filters:      "r2 && r1",
+			invertedExpr: "r2 && r1",
+		},
+		{
+			// Indexed column can be on either side of &&.
+			filters:      "r1 && r2",
+			invertedExpr: "r2 && r1",
+		},
+		{
+			filters:      "r2 -|- r1",
+			invertedExpr: "r2 -|- r1",
+		},
+		{
+			// Indexed column can be on either side of -|-.
+			filters:      "r1 -|- r2",
+			invertedExpr: "r2 -|- r1",
+		},
+		{
+			// This is the condition used to check an exclusion constraint on
+			// (r WITH &&, r WITH -|-).
+			filters:      "r2 && r1 OR r2 -|- r1",
+			invertedExpr: "r2 && r1 OR r2 -|- r1",
+		},
+		{
+			filters:      "r2 && r1 AND r2 && r11",
+			invertedExpr: "r2 && r1 AND r2 && r11",
+		},
+		{
+			// Join conditions can be combined with index constraints.
+			filters:      "r2 && r1 AND r2 && '[1,10)'::INT8RANGE",
+			invertedExpr: "r2 && r1 AND r2 && '[1,10)'::INT8RANGE",
+		},
+		{
+			// At least one column from the input is required.
+			filters:      "r2 && '[1,10)'::INT8RANGE",
+			invertedExpr: "",
+		},
+		{
+			// Containment can't be index-accelerated.
+			filters:      "r2 @> r1",
+			invertedExpr: "",
+		},
+		{
+			// AND with a non-range condition.
+			filters:      "r2 && r1 AND i1 = i2",
+			invertedExpr: "r2 && r1",
+		},
+		{
+			// OR with a non-range condition.
+			filters:      "r2 && r1 OR i1 = i2",
+			invertedExpr: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Logf("test case: %v", tc)
+		filters := testutils.BuildFilters(t, &f, &semaCtx, evalCtx, tc.filters)
+
+		var inputCols opt.ColSet
+		for i, n := 0, md.Table(tab1).ColumnCount(); i < n; i++ {
+			inputCols.Add(tab1.ColumnID(i))
+		}
+
+		actInvertedExpr := invertedidx.TryJoinInvertedIndex(
+			context.Background(), &f, filters, tab2, md.Table(tab2).Index(rangeOrd), inputCols,
+		)
+
+		if actInvertedExpr == nil {
+			if tc.invertedExpr != "" {
+				t.Fatalf("expected %s, got <nil>", tc.invertedExpr)
+			}
+			continue
+		}
+
+		if tc.invertedExpr == "" {
+			t.Fatalf("expected <nil>, got %v", actInvertedExpr)
+		}
+
+		expInvertedExpr := testutils.BuildScalar(t, &f, &semaCtx, evalCtx, tc.invertedExpr)
+		if actInvertedExpr.String() != expInvertedExpr.String() {
+			t.Errorf("expected %v, got %v", expInvertedExpr, actInvertedExpr)
+		}
+	}
+}
+
+func TestTryFilterRangeIndex(t *testing.T) {
+	semaCtx := tree.MakeSemaContext(nil /* resolver */)
+	st := cluster.MakeTestingClusterSettings()
+	evalCtx := eval.NewTestingEvalContext(st)
+
+	tc := testcat.New()
+	if _, err := tc.ExecuteDDL(
+		"CREATE TABLE t (r INT8RANGE, d DATERANGE, INVERTED INDEX (r))",
+	); err != nil {
+		t.Fatal(err)
+	}
+	var f norm.Factory
+	f.Init(context.Background(), evalCtx, tc)
+	md := f.Metadata()
+	tn := tree.NewUnqualifiedTableName("t")
+	tab := md.AddTable(tc.Table(tn), tn)
+	rangeOrd := 1
+
+	// If we can create an inverted filter with the given filter expression and
+	// index, ok=true. The index is never tight, so the remaining filters always
+	// contain the original filters.
+	testCases := []struct {
+		filters string
+		ok      bool
+	}{
+		{filters: "r && '[1,10)'::INT8RANGE", ok: true},
+		{filters: "'[1,10)'::INT8RANGE && r", ok: true},
+		{filters: "r -|- '[1,10)'::INT8RANGE", ok: true},
+		{filters: "'[1,10)'::INT8RANGE -|- r", ok: true},
+		{filters: "r && '[1,10)'::INT8RANGE OR r -|- '[20,30)'::INT8RANGE", ok: true},
+		{filters: "r && '[1,10)'::INT8RANGE AND r && '[5,15)'::INT8RANGE", ok: true},
+		{filters: "r && '(,)'::INT8RANGE", ok: true},
+		{filters: "r -|- '[1,)'::INT8RANGE", ok: true},
+
+		// Nothing overlaps an empty range, and nothing is adjacent to a range
+		// without finite bounds.
+		{filters: "r && 'empty'::INT8RANGE", ok: false},
+		{filters: "r -|- '(,)'::INT8RANGE", ok: false},
+
+		// Only overlaps and adjacency can be index-accelerated.
+		{filters: "r @> '[1,10)'::INT8RANGE", ok: false},
+		{filters: "r = '[1,10)'::INT8RANGE", ok: false},
+
+		// The other range column is not indexed.
+		{filters: "d && '[2024-01-01,2024-02-01)'::DATERANGE", ok: false},
+		{filters: "r && '[1,10)'::INT8RANGE OR d && '[2024-01-01,2024-02-01)'::DATERANGE", ok: false},
+	}
+
+	for _, tc := range testCases {
+		t.Logf("test case: %v", tc)
+		filters := testutils.BuildFilters(t, &f, &semaCtx, evalCtx, tc.filters)
+
+		spanExpr, _, remainingFilters, _, ok := invertedidx.TryFilterInvertedIndex(
+			context.Background(),
+			evalCtx,
+			&f,
+			filters,
+			nil, /* optionalFilters */
+			tab,
+			md.Table(tab).Index(rangeOrd),
+			nil,       /* computedColumns */
+			func() {}, /* checkCancellation */
+		)
+		if tc.ok != ok {
+			t.Fatalf("expected %v, got %v", tc.ok, ok)
+		}
+		if !ok {
+			continue
+		}
+
+		if spanExpr.Tight {
+			t.Fatalf("For (%s), expected tight=false, but got true", tc.filters)
+		}
+		require.Equal(t, filters.String(), remainingFilters.String(),
+			"mismatched remaining filters")
+	}
+}
@@ -760,6 +760,19 @@ type UDFDefinition struct {
 	// result of the routine. This invariant is enforced when the PLpgSQL routine
 	// is built. CursorDeclaration may be unset.
 	CursorDeclaration *tree.RoutineOpenCursor
+
+	// ResultBuffer is shared between the routines that make up a set-returning
+	// PL/pgSQL function. It is set for the root routine of the function, as well
+	// as for the routines that implement RETURN NEXT and RETURN QUERY. In the
+	// latter case, the result of the *first* body statement is added to the
+	// buffer, so there will be at least two body statements. ResultBuffer may be
+	// unset.
+	ResultBuffer *tree.RoutineResultBuffer
+
+	// SessionSettings contains the changes to the session that are in effect
+	// while the routine executes, for a SECURITY DEFINER routine or a routine
+	// with SET clauses. It is only set for the root routine, and may be unset.
+	SessionSettings *tree.RoutineSessionSettings
 }
 
 // ExceptionBlock contains the information needed to match and handle errors in
@@ -776,6 +789,37 @@ type ExceptionBlock struct {
 	Actions []*UDFDefinition
 }
 
+// UDADefinition stores the support functions of a user-defined aggregate,
+// which are built as routines. The state transition function is invoked once
+// per input row, the combine function once per partial state when the
+// aggregation is planned in multiple stages, and the final function once per
+// group.
+type UDADefinition struct {
+	// StateFunc is the state transition function of the aggregate. Its
+	// parameters are the current state followed by the aggregate arguments, and
+	// it returns the next state.
+	StateFunc *UDFDefinition
+
+	// StateType is the type of the state of the aggregate.
+	StateType *types.T
+
+	// FinalFunc is the final function of the aggregate, which computes the
+	// result of the aggregate from the final state. It is nil if the aggregate
+	// has no final function, in which case the final state is the result.
+	FinalFunc *UDFDefinition
+
+	// CombineFunc is the combine function of the aggregate, which merges two
+	// partial states into one. It is nil if the aggregate has no combine
+	// function, in which case the aggregation cannot be split into a local and
+	// a final stage.
+	CombineFunc *UDFDefinition
+
+	// InitCond is the initial state of the aggregate. It is nil if the
+	// aggregate has no initial condition, in which case the initial state is
+	// NULL.
+	InitCond tree.Datum
+}
+
 // WindowFrame denotes the definition of a window frame for an individual
 // window function, excluding the OFFSET expressions, if present.
 type WindowFrame struct {
@@ -1337,9 +1381,17 @@ type FKCascades []FKCascade
 
 // FKCascade stores metadata necessary for building a cascading query.
 // Cascading queries are built as needed, after the original query is executed.
+//
+// The same mechanism is used to fire AFTER triggers once the original query
+// has executed. In that case FKConstraint is nil and Trigger is set. It is also
+// used to delete the rows matched by the DELETE clauses of a MERGE statement,
+// in which case both FKConstraint and Trigger are nil.
 type FKCascade struct {
 	FKConstraint cat.ForeignKeyConstraint
 
+	// Trigger is the AFTER trigger fired by this query, if FKConstraint is nil.
+	Trigger cat.Trigger
+
 	// Builder is an object that can be used as the "optbuilder" for the cascading
 	// query.
 	Builder CascadeBuilder
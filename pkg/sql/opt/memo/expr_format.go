@@ -965,6 +965,9 @@ func (f *ExprFmtCtx) formatScalarWithLabel(
 				if i == 0 && def.CursorDeclaration != nil {
 					// The first statement is opening a cursor.
 					stmtNode = n.Child("open-cursor")
+				} else if i == 0 && def.ResultBuffer != nil && !def.SetReturning {
+					// The first statement adds rows to the result of the function.
+					stmtNode = n.Child("add-to-result")
 				}
 				prevTailCalls := f.tailCalls
 				if i == len(def.Body)-1 {
@@ -1290,6 +1293,19 @@ func (f *ExprFmtCtx) formatScalarPrivate(scalar opt.ScalarExpr) {
 
 	case *UniqueChecksItem:
 		tab := f.Memo.metadata.TableMeta(t.Table)
+		if t.Exclusion {
+			constraint := tab.Table.Exclusion(t.CheckOrdinal)
+			fmt.Fprintf(f.Buffer, ": %s exclude(", tab.Alias.ObjectName)
+			for i := 0; i < constraint.ColumnCount(); i++ {
+				if i > 0 {
+					f.Buffer.WriteByte(',')
+				}
+				col := tab.Table.Column(constraint.ColumnOrdinal(i))
+				fmt.Fprintf(f.Buffer, "%s %s", col.ColName(), constraint.Operator(i))
+			}
+			f.Buffer.WriteByte(')')
+			break
+		}
 		constraint := tab.Table.Unique(t.CheckOrdinal)
 		fmt.Fprintf(f.Buffer, ": %s(", tab.Alias.ObjectName)
 		for i := 0; i < constraint.ColumnCount(); i++ {
@@ -1542,7 +1558,13 @@ func (f *ExprFmtCtx) formatMutationCommon(tp treeprinter.Node, p *MutationPrivat
 	if len(p.FKCascades) > 0 {
 		c := tp.Childf("cascades")
 		for i := range p.FKCascades {
-			c.Child(p.FKCascades[i].FKConstraint.Name())
+			if fk := p.FKCascades[i].FKConstraint; fk != nil {
+				c.Child(fk.Name())
+			} else if trigger := p.FKCascades[i].Trigger; trigger != nil {
+				c.Childf("trigger %s", trigger.Name())
+			} else {
+				c.Child("merge delete")
+			}
 		}
 	}
 }
@@ -1791,6 +1813,9 @@ func FormatPrivate(f *ExprFmtCtx, private interface{}, physProps *physical.Requi
 	case *FunctionPrivate:
 		fmt.Fprintf(f.Buffer, " %s", t.Name)
 
+	case *UserDefinedAggPrivate:
+		fmt.Fprintf(f.Buffer, " %s", t.Name)
+
 	case *WindowsItemPrivate:
 		fmt.Fprintf(f.Buffer, " frame=%q", &t.Frame)
 
@@ -135,8 +135,13 @@ func ExtractAggInputColumns(e opt.ScalarExpr) opt.ColSet {
 		panic(errors.AssertionFailedf("not an Aggregate"))
 	}
 
-	for i, n := 0, e.ChildCount(); i < n; i++ {
-		if variable, ok := e.Child(i).(*VariableExpr); ok {
+	// The arguments of a UserDefinedAgg are stored in a list.
+	var args opt.Expr = e
+	if uda, ok := e.(*UserDefinedAggExpr); ok {
+		args = &uda.Args
+	}
+	for i, n := 0, args.ChildCount(); i < n; i++ {
+		if variable, ok := args.Child(i).(*VariableExpr); ok {
 			res.Add(variable.Col)
 		}
 	}
@@ -162,8 +167,12 @@ func AddAggInputColumns(cols opt.ColSet, e opt.ScalarExpr) opt.ColSet {
 		panic(errors.AssertionFailedf("not an Aggregate"))
 	}
 
-	for i, n := 0, e.ChildCount(); i < n; i++ {
-		if variable, ok := e.Child(i).(*VariableExpr); ok {
+	var args opt.Expr = e
+	if uda, ok := e.(*UserDefinedAggExpr); ok {
+		args = &uda.Args
+	}
+	for i, n := 0, args.ChildCount(); i < n; i++ {
+		if variable, ok := args.Child(i).(*VariableExpr); ok {
 			cols.Add(variable.Col)
 		}
 	}
@@ -174,12 +183,15 @@ func AddAggInputColumns(cols opt.ColSet, e opt.ScalarExpr) opt.ColSet {
 // ExtractAggFirstVar is given an aggregate expression and returns the Variable
 // expression for the first argument, skipping past modifiers like AggDistinct.
 func ExtractAggFirstVar(e opt.ScalarExpr) *VariableExpr {
-	e = ExtractAggFunc(e)
-	if e.ChildCount() == 0 {
+	var args opt.Expr = ExtractAggFunc(e)
+	if uda, ok := args.(*UserDefinedAggExpr); ok {
+		args = &uda.Args
+	}
+	if args.ChildCount() == 0 {
 		panic(errors.AssertionFailedf("aggregate does not have any arguments"))
 	}
 
-	if variable, ok := e.Child(0).(*VariableExpr); ok {
+	if variable, ok := args.Child(0).(*VariableExpr); ok {
 		return variable
 	}
 
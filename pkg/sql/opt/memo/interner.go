@@ -791,6 +791,10 @@ func (h *hasher) HashUDFDefinition(val *UDFDefinition) {
 	h.HashUint64(uint64(reflect.ValueOf(val).Pointer()))
 }
 
+func (h *hasher) HashUDADefinition(val *UDADefinition) {
+	h.HashUint64(uint64(reflect.ValueOf(val).Pointer()))
+}
+
 func (h *hasher) HashStoredProcTxnOp(val tree.StoredProcTxnOp) {
 	h.HashUint64(uint64(val))
 }
@@ -1305,6 +1309,10 @@ func (h *hasher) IsLiteralRowsEqual(l, r *opt.LiteralRows) bool {
 	return l == r
 }
 
+func (h *hasher) IsUDADefinitionEqual(l, r *UDADefinition) bool {
+	return l == r
+}
+
 func (h *hasher) IsUDFDefinitionEqual(l, r *UDFDefinition) bool {
 	if len(l.Body) != len(r.Body) {
 		return false
@@ -1344,6 +1352,10 @@ func (h *hasher) IsUDFDefinitionEqual(l, r *UDFDefinition) bool {
 	} else if r.CursorDeclaration != nil {
 		return false
 	}
+	if l.ResultBuffer != r.ResultBuffer {
+		// Routines that add to different result buffers are not interchangeable.
+		return false
+	}
 	return h.IsColListEqual(l.Params, r.Params) && l.IsRecursive == r.IsRecursive
 }
 
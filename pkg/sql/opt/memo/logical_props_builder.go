@@ -1748,6 +1748,16 @@ func BuildSharedProps(e opt.Expr, shared *props.Shared, evalCtx *eval.Context) {
 		shared.HasUDF = true
 		shared.VolatilitySet.Add(t.Def.Volatility)
 
+	case *UserDefinedAggExpr:
+		shared.HasUDF = true
+		shared.VolatilitySet.Add(t.Def.StateFunc.Volatility)
+		if t.Def.FinalFunc != nil {
+			shared.VolatilitySet.Add(t.Def.FinalFunc.Volatility)
+		}
+		if t.Def.CombineFunc != nil {
+			shared.VolatilitySet.Add(t.Def.CombineFunc.Volatility)
+		}
+
 	default:
 		if opt.IsUnaryOp(e) {
 			inputType := e.Child(0).(opt.ScalarExpr).DataType()
@@ -1879,9 +1889,11 @@ func MakeTableFuncDep(md *opt.Metadata, tabID opt.TableID) *props.FuncDepSet {
 		allCols.Add(tabID.ColumnID(i))
 	}
 	var excludeColumn opt.ColumnID
-	if tab.IsVirtualTable() {
+	if tab.IsVirtualTable() || tab.IsForeignTable() {
 		// Don't advertise any functional dependencies for virtual table primary
-		// keys, since they are composed of a fake, unusable column.
+		// keys, since they are composed of a fake, unusable column. Likewise, the
+		// rowid of a foreign table is generated anew every time its files are
+		// read, so it cannot be used as a key.
 		dummyPKOrd := tab.Index(cat.PrimaryIndex).Column(0).Ordinal()
 		excludeColumn = tabID.ColumnID(dummyPKOrd)
 	}
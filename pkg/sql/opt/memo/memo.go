@@ -200,12 +200,20 @@ type Memo struct {
 	proveImplicationWithVirtualComputedCols    bool
 	pushOffsetIntoIndexJoin                    bool
 	usePolymorphicParameterFix                 bool
+	vectorSearchProbes                         int64
 
 	// txnIsoLevel is the isolation level under which the plan was created. This
 	// affects the planning of some locking operations, so it must be included in
 	// memo staleness calculation.
 	txnIsoLevel isolation.Level
 
+	// constraintModes are the SET CONSTRAINTS modes of the transaction in which
+	// the plan was created. They determine which constraint checks are planned,
+	// but only if the plan mutates tables with deferrable constraints, in which
+	// case usesConstraintModes is true.
+	constraintModes     eval.ConstraintModes
+	usesConstraintModes bool
+
 	// curRank is the highest currently in-use scalar expression rank.
 	curRank opt.ScalarRank
 
@@ -286,7 +294,9 @@ func (m *Memo) Init(ctx context.Context, evalCtx *eval.Context) {
 		proveImplicationWithVirtualComputedCols:    evalCtx.SessionData().OptimizerProveImplicationWithVirtualComputedColumns,
 		pushOffsetIntoIndexJoin:                    evalCtx.SessionData().OptimizerPushOffsetIntoIndexJoin,
 		usePolymorphicParameterFix:                 evalCtx.SessionData().OptimizerUsePolymorphicParameterFix,
+		vectorSearchProbes:                         evalCtx.SessionData().VectorSearchProbes,
 		txnIsoLevel:                                evalCtx.TxnIsoLevel,
+		constraintModes:                            evalCtx.ConstraintModes,
 	}
 	m.metadata.Init()
 	m.logPropsBuilder.init(ctx, evalCtx, m)
@@ -316,6 +326,14 @@ func (m *Memo) NotifyOnNewGroup(fn func(opt.Expr)) {
 	m.newGroupFn = fn
 }
 
+// IsConstraintDeferred returns true if the checks of the deferrable constraint
+// with the given name are deferred until the transaction commits. It records
+// that the memo depends on the SET CONSTRAINTS modes of the transaction.
+func (m *Memo) IsConstraintDeferred(name string, initiallyDeferred bool) bool {
+	m.usesConstraintModes = true
+	return m.constraintModes.IsDeferred(name, initiallyDeferred)
+}
+
 // IsEmpty returns true if there are no expressions in the memo.
 func (m *Memo) IsEmpty() bool {
 	// Root expression can be nil before optimization and interner is empty after
@@ -450,9 +468,13 @@ func (m *Memo) IsStale(
 		m.proveImplicationWithVirtualComputedCols != evalCtx.SessionData().OptimizerProveImplicationWithVirtualComputedColumns ||
 		m.pushOffsetIntoIndexJoin != evalCtx.SessionData().OptimizerPushOffsetIntoIndexJoin ||
 		m.usePolymorphicParameterFix != evalCtx.SessionData().OptimizerUsePolymorphicParameterFix ||
+		m.vectorSearchProbes != evalCtx.SessionData().VectorSearchProbes ||
 		m.txnIsoLevel != evalCtx.TxnIsoLevel {
 		return true, nil
 	}
+	if m.usesConstraintModes && !m.constraintModes.Equal(&evalCtx.ConstraintModes) {
+		return true, nil
+	}
 
 	// Memo is stale if the fingerprint of any object in the memo's metadata has
 	// changed, or if the current user no longer has sufficient privilege to
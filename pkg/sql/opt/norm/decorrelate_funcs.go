@@ -444,16 +444,16 @@ func (c *CustomFuncs) EnsureKey(in memo.RelExpr) memo.RelExpr {
 	return c.f.ConstructOrdinality(in, &private)
 }
 
-// TryAddKeyToScan checks whether the input expression is a non-virtual table
-// Scan, either alone or wrapped in a Select. If so, it returns a new Scan
-// (possibly wrapped in a Select) augmented with the preexisting primary key
-// for the table.
+// TryAddKeyToScan checks whether the input expression is a Scan of a table
+// that is neither virtual nor foreign, either alone or wrapped in a Select. If
+// so, it returns a new Scan (possibly wrapped in a Select) augmented with the
+// preexisting primary key for the table.
 func (c *CustomFuncs) TryAddKeyToScan(in memo.RelExpr) (_ memo.RelExpr, ok bool) {
 	augmentScan := func(scan *memo.ScanExpr) (_ memo.RelExpr, ok bool) {
 		private := scan.ScanPrivate
 		tableID := private.Table
 		table := c.f.Metadata().Table(tableID)
-		if !table.IsVirtualTable() {
+		if !table.IsVirtualTable() && !table.IsForeignTable() {
 			keyCols := c.PrimaryKeyCols(tableID)
 			private.Cols = private.Cols.Union(keyCols)
 			return c.f.ConstructScan(&private), true
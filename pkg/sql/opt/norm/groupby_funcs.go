@@ -78,8 +78,12 @@ func (c *CustomFuncs) CanRemoveAggDistinctForKeys(
 	if agg.ChildCount() == 0 {
 		return false
 	}
+	variable, ok := agg.Child(0).(*memo.VariableExpr)
+	if !ok {
+		// The arguments of a UserDefinedAgg are not direct children.
+		return false
+	}
 	inputFDs := &input.Relational().FuncDeps
-	variable := agg.Child(0).(*memo.VariableExpr)
 	cols := c.AddColToSet(private.GroupingCols, variable.Col)
 	return inputFDs.ColsAreStrictKey(cols)
 }
@@ -79,6 +79,12 @@ func (c *CustomFuncs) neededMutationCols(
 		cols.Add(private.CanaryCol)
 	}
 
+	// Add any input columns that are read by cascades.
+	for i := range private.FKCascades {
+		cols.UnionWith(private.FKCascades[i].OldValues.ToSet())
+		cols.UnionWith(private.FKCascades[i].NewValues.ToSet())
+	}
+
 	if private.WithID != 0 {
 		for i := range uniqueChecks {
 			withUses := memo.WithUses(uniqueChecks[i].Check)
@@ -146,6 +152,25 @@ func (c *CustomFuncs) NeededMutationFetchCols(
 		}
 	}
 
+	// Retain any FetchCols that are passed to AFTER triggers or MERGE deletes.
+	// The old values of updated and deleted rows are always fetched, and so are
+	// the new values of columns that are not updated.
+	addTriggerCols := func(triggerCols opt.ColList) {
+		for _, triggerCol := range triggerCols {
+			for ord, col := range private.FetchCols {
+				if col != 0 && col == triggerCol {
+					cols.Add(tabMeta.MetaID.ColumnID(ord))
+				}
+			}
+		}
+	}
+	for i := range private.FKCascades {
+		if private.FKCascades[i].FKConstraint == nil {
+			addTriggerCols(private.FKCascades[i].OldValues)
+			addTriggerCols(private.FKCascades[i].NewValues)
+		}
+	}
+
 	switch op {
 	case opt.UpdateOp, opt.UpsertOp:
 		// Determine set of target table columns that need to be updated.
@@ -0,0 +1,127 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/intsets"
+	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
+)
+
+// castUserDefinedAggArgs adds casts to the arguments of the given call to a
+// user-defined aggregate where necessary, so that they have the parameter
+// types of the aggregate. The arguments are passed as-is to the state
+// transition function of the aggregate during execution.
+func (s *scope) castUserDefinedAggArgs(f *tree.FuncExpr) {
+	paramTypes, ok := f.ResolvedOverload().Types.(tree.ParamTypes)
+	if !ok {
+		panic(errors.AssertionFailedf("expected user-defined aggregate to have fixed parameters"))
+	}
+	for i := range f.Exprs {
+		texpr := f.Exprs[i].(tree.TypedExpr)
+		if typ := paramTypes[i].Typ; !texpr.ResolvedType().Identical(typ) {
+			f.Exprs[i] = tree.NewTypedCastExpr(texpr, typ)
+		}
+	}
+}
+
+// constructUserDefinedAgg constructs a UserDefinedAgg operator for the given
+// call to a user-defined aggregate, with the given arguments. The support
+// functions of the aggregate are built as routines, which are invoked by the
+// aggregate during execution.
+func (b *Builder) constructUserDefinedAgg(
+	agg *aggregateInfo, args []opt.ScalarExpr,
+) opt.ScalarExpr {
+	o := agg.def.Overload
+	if err := b.catalog.CheckExecutionPrivilege(b.ctx, o.Oid); err != nil {
+		panic(err)
+	}
+	argTypes := make([]*types.T, len(args))
+	for i := range args {
+		argTypes[i] = args[i].DataType()
+	}
+	b.factory.Metadata().AddUserDefinedFunction(o, argTypes, agg.Func.ReferenceByName)
+	if b.trackSchemaDeps {
+		b.schemaFunctionDeps.Add(int(o.Oid))
+	}
+
+	uda := o.UDFAggregate
+	def := &memo.UDADefinition{
+		StateFunc: b.buildAggregateSupportFunc(uda.StateFunc),
+		StateType: uda.StateType,
+	}
+	if uda.FinalFunc != 0 {
+		def.FinalFunc = b.buildAggregateSupportFunc(uda.FinalFunc)
+	}
+	if uda.CombineFunc != 0 {
+		def.CombineFunc = b.buildAggregateSupportFunc(uda.CombineFunc)
+	}
+	if uda.InitCond != nil {
+		initCond, _, err := tree.ParseAndRequireString(uda.StateType, *uda.InitCond, b.evalCtx)
+		if err != nil {
+			panic(err)
+		}
+		def.InitCond = initCond
+	}
+	return b.factory.ConstructUserDefinedAgg(
+		args,
+		&memo.UserDefinedAggPrivate{
+			Name: agg.def.Name,
+			Typ:  agg.col.typ,
+			Def:  def,
+		},
+	)
+}
+
+// buildAggregateSupportFunc builds the support function of a user-defined
+// aggregate with the given OID as a routine. The arguments of the routine are
+// only known during execution, so it is built with NULL arguments which are
+// then discarded.
+func (b *Builder) buildAggregateSupportFunc(funcOID oid.Oid) *memo.UDFDefinition {
+	funcName, o, err := b.catalog.ResolveFunctionByOID(b.ctx, funcOID)
+	if err != nil {
+		panic(err)
+	}
+	paramTypes, ok := o.Types.(tree.ParamTypes)
+	if !ok {
+		panic(errors.AssertionFailedf("expected aggregate support function to have fixed parameters"))
+	}
+	args := make(tree.TypedExprs, len(paramTypes))
+	for i := range paramTypes {
+		args[i] = tree.NewTypedCastExpr(tree.DNull, paramTypes[i].Typ)
+	}
+	def := &tree.ResolvedFunctionDefinition{Name: funcName.Object()}
+	f := tree.NewTypedFuncExpr(
+		tree.ResolvableFunctionReference{FunctionReference: def},
+		0, /* aggQualifier */
+		args,
+		nil, /* filter */
+		nil, /* windowDef */
+		o.FixedReturnType(),
+		&o.FunctionProperties,
+		o,
+	)
+	// The arguments are constant, so the call would be inlined if the support
+	// function is inlinable. The definition of the function is needed instead,
+	// so inlining is disabled while the routine is built. The execbuilder
+	// inlines support functions when possible.
+	var routine opt.ScalarExpr
+	var disabledRules intsets.Fast
+	disabledRules.Add(int(opt.InlineUDF))
+	b.factory.DisableOptimizationRulesTemporarily(disabledRules, func() {
+		routine = b.buildRoutine(f, def, b.allocScope(), nil /* outScope */, nil /* colRefs */)
+	})
+	return routine.(*memo.UDFCallExpr).Def
+}
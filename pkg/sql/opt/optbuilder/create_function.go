@@ -13,6 +13,7 @@ package optbuilder
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcinfo"
@@ -27,6 +28,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/cast"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/plpgsqltree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/intsets"
@@ -175,6 +177,7 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 	// labels in the output RECORD type.
 	var outParamNames []string
 	var sawDefaultExpr, sawPolymorphicInParam, sawPolymorphicOutParam bool
+	var sawVariadicParam, sawOutParam, sawTableColumn bool
 	for i := range cf.Params {
 		param := &cf.Params[i]
 		typ, err := tree.ResolveType(b.ctx, param.Type, b.semaCtx.TypeResolver)
@@ -184,6 +187,31 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 		if param.Class == tree.RoutineParamInOut && param.Name == "" {
 			panic(unimplemented.NewWithIssue(121251, "unnamed INOUT parameters are not yet supported"))
 		}
+		switch param.Class {
+		case tree.RoutineParamOut, tree.RoutineParamInOut:
+			sawOutParam = true
+		case tree.RoutineParamTable:
+			sawTableColumn = true
+		}
+		if sawOutParam && sawTableColumn {
+			panic(pgerror.New(pgcode.InvalidFunctionDefinition,
+				"OUT and INOUT arguments aren't allowed in TABLE functions"))
+		}
+		if sawVariadicParam && param.IsInParam() {
+			panic(pgerror.New(pgcode.InvalidFunctionDefinition,
+				"VARIADIC parameter must be the last input parameter"))
+		}
+		if param.Class == tree.RoutineParamVariadic {
+			sawVariadicParam = true
+			if typ.Family() != types.ArrayFamily {
+				panic(pgerror.New(pgcode.InvalidFunctionDefinition,
+					"VARIADIC parameter must be an array"))
+			}
+			if param.DefaultVal != nil {
+				panic(unimplemented.NewWithIssue(88947,
+					"VARIADIC parameters with DEFAULT expressions are not yet supported"))
+			}
+		}
 		if param.IsInParam() && typ.IsPolymorphicType() {
 			sawPolymorphicInParam = true
 		}
@@ -211,7 +239,7 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 				))
 			}
 		}
-		if param.DefaultVal != nil && param.Class == tree.RoutineParamOut {
+		if param.DefaultVal != nil && tree.IsOutOnlyParamClass(param.Class) {
 			panic(pgerror.Newf(pgcode.InvalidFunctionDefinition,
 				"only input parameters can have default values"))
 		}
@@ -273,6 +301,17 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 		}
 	}
 
+	if sawVariadicParam {
+		if sawPolymorphicInParam || sawPolymorphicOutParam {
+			panic(unimplemented.NewWithIssue(88947,
+				"VARIADIC parameters in routines with polymorphic parameters are not yet supported"))
+		}
+		if cf.IsProcedure && len(outParamTypes) > 0 {
+			panic(unimplemented.NewWithIssue(88947,
+				"VARIADIC parameters in procedures with output parameters are not yet supported"))
+		}
+	}
+
 	// Determine OUT parameter based return type.
 	var outParamType *types.T
 	if (cf.IsProcedure && len(outParamTypes) > 0) || len(outParamTypes) > 1 {
@@ -297,7 +336,8 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 		// CREATE correctly.
 		funcReturnType = outParamType
 		cf.ReturnType = &tree.RoutineReturnType{
-			Type: outParamType,
+			Type:  outParamType,
+			SetOf: cf.ReturnType != nil && cf.ReturnType.SetOf,
 		}
 	} else if funcReturnType == nil {
 		if cf.IsProcedure {
@@ -342,6 +382,21 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 		} else if language == tree.RoutineLangPLpgSQL {
 			panic(pgerror.New(pgcode.InvalidFunctionDefinition, "PL/pgSQL functions cannot return type unknown"))
 		}
+	} else if funcReturnType.Family() == types.TriggerFamily {
+		// Trigger functions must be written in PL/pgSQL, and receive their
+		// arguments through TG_ARGV rather than declared parameters.
+		if language != tree.RoutineLangPLpgSQL {
+			panic(pgerror.New(pgcode.InvalidFunctionDefinition, "SQL functions cannot return type trigger"))
+		}
+		if len(cf.Params) > 0 {
+			panic(errors.WithHint(
+				pgerror.New(pgcode.InvalidFunctionDefinition, "trigger functions cannot have declared arguments"),
+				"The arguments of the trigger can be accessed through TG_NARGS and TG_ARGV instead.",
+			))
+		}
+		if cf.ReturnType.SetOf {
+			panic(pgerror.New(pgcode.InvalidFunctionDefinition, "trigger functions cannot return a set"))
+		}
 	}
 	// Collect the user defined type dependency of the return type.
 	typedesc.GetTypeDescriptorClosure(funcReturnType).ForEach(func(id descpb.ID) {
@@ -356,6 +411,14 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 	}(b.insideSQLRoutine)
 	b.insideSQLRoutine = language == tree.RoutineLangSQL
 
+	// Names in the body are resolved using the search path given by a SET
+	// search_path clause, since it is in effect when the routine is invoked.
+	if paths, ok := routineSearchPath(cf.Options); ok {
+		defer b.pushRoutineSessionSettings(&tree.RoutineSessionSettings{
+			Config: []string{"search_path=" + sessiondata.FormatSearchPaths(paths)},
+		})()
+	}
+
 	// Validate each statement and collect the dependencies.
 	var stmtScope *scope
 	switch language {
@@ -389,42 +452,47 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 			afterBuildStmt()
 		}
 	case tree.RoutineLangPLpgSQL:
-		if cf.ReturnType != nil && cf.ReturnType.SetOf {
-			panic(unimplemented.NewWithIssueDetail(105240,
-				"set-returning PL/pgSQL functions",
-				"set-returning PL/pgSQL functions are not yet supported",
-			))
-		}
-
 		// Parse the function body.
 		stmt, err := plpgsqlparser.Parse(funcBodyStr)
 		if err != nil {
 			panic(err)
 		}
 
-		// Check for transaction control statements in UDFs.
-		if !cf.IsProcedure {
+		// Check for transaction control statements in UDFs, and in procedures
+		// that change the current user or session variables.
+		if !cf.IsProcedure || routineChangesSession(cf.Options) {
 			var tc transactionControlVisitor
 			plpgsqltree.Walk(&tc, stmt.AST)
 			if tc.foundTxnControlStatement {
-				panic(errors.WithDetailf(
+				detail := "transaction control statements are only allowed in procedures"
+				if cf.IsProcedure {
+					detail = "transaction control statements are not allowed in procedures " +
+						"with SECURITY DEFINER or SET clauses"
+				}
+				panic(errors.WithDetail(
 					pgerror.Newf(pgcode.InvalidTransactionTermination, "invalid transaction termination"),
-					"transaction control statements are only allowed in procedures",
+					detail,
 				))
 			}
 		}
 
-		// We need to disable stable function folding because we want to catch the
-		// volatility of stable functions. If folded, we only get a scalar and lose
-		// the volatility.
-		b.factory.FoldingControl().TemporarilyDisallowStableFolds(func() {
-			plBuilder := newPLpgSQLBuilder(
-				b, cf.Name.Object(), stmt.AST.Label, nil, /* colRefs */
-				routineParams, funcReturnType, cf.IsProcedure, nil, /* outScope */
-			)
-			stmtScope = plBuilder.buildRootBlock(stmt.AST, bodyScope, routineParams)
-		})
-		checkStmtVolatility(targetVolatility, stmtScope, stmt)
+		// The body of a trigger function is only built when the trigger fires,
+		// since the types of the NEW and OLD variables depend on the table the
+		// trigger is attached to.
+		if funcReturnType.Family() != types.TriggerFamily {
+			// We need to disable stable function folding because we want to catch
+			// the volatility of stable functions. If folded, we only get a scalar
+			// and lose the volatility.
+			b.factory.FoldingControl().TemporarilyDisallowStableFolds(func() {
+				plBuilder := newPLpgSQLBuilder(
+					b, cf.Name.Object(), stmt.AST.Label, nil, /* colRefs */
+					routineParams, funcReturnType, cf.IsProcedure,
+					cf.ReturnType != nil && cf.ReturnType.SetOf, nil, /* outScope */
+				)
+				stmtScope = plBuilder.buildRootBlock(stmt.AST, bodyScope, routineParams)
+			})
+			checkStmtVolatility(targetVolatility, stmtScope, stmt)
+		}
 
 		// Format the statements with qualified datasource names.
 		formatFuncBodyStmt(fmtCtx, stmt.AST, language, false /* newLine */)
@@ -632,3 +700,53 @@ func checkDuplicateParamName(param tree.RoutineParam, seen map[tree.Name]struct{
 	}
 	seen[param.Name] = struct{}{}
 }
+
+// routineChangesSession returns true if the given routine options include
+// SECURITY DEFINER or a SET clause.
+func routineChangesSession(options tree.RoutineOptions) bool {
+	for _, option := range options {
+		switch t := option.(type) {
+		case tree.RoutineSecurity:
+			if t == tree.RoutineSecurityDefiner {
+				return true
+			}
+		case *tree.SetVar:
+			if !t.Reset && !t.ResetAll {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// routineSearchPath returns the search path given by the SET search_path
+// clauses in the given routine options. It returns ok=false if the options do
+// not set the search path.
+func routineSearchPath(options tree.RoutineOptions) (paths []string, ok bool) {
+	for _, option := range options {
+		t, isSetVar := option.(*tree.SetVar)
+		if !isSetVar {
+			continue
+		}
+		if t.ResetAll || (t.Reset && strings.EqualFold(t.Name, "search_path")) {
+			paths, ok = nil, false
+			continue
+		}
+		if !strings.EqualFold(t.Name, "search_path") {
+			continue
+		}
+		paths, ok = make([]string, len(t.Values)), true
+		for i, v := range t.Values {
+			switch v := v.(type) {
+			case *tree.StrVal:
+				paths[i] = v.RawString()
+			case *tree.UnresolvedName:
+				paths[i] = v.String()
+			default:
+				panic(pgerror.Newf(pgcode.InvalidParameterValue,
+					"invalid value for parameter \"search_path\": %s", v))
+			}
+		}
+	}
+	return paths, ok
+}
@@ -0,0 +1,283 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// BuildDeferredFKCheck builds the check query for a foreign key constraint
+// whose checks were deferred until the end of the transaction. The check is
+// built with the same helpers as the FK checks of a mutation, except that its
+// input is made of the keys queued by the mutations of the transaction rather
+// than of a WithScan of the mutation input.
+//
+// The FK is identified by the origin table and its name. If outbound is true,
+// keys are the primary keys of the rows of the origin table which were written
+// by the transaction, and the insertion check is built for these rows, as they
+// are at the time of the check. Otherwise, keys are the values of the
+// referenced columns which were removed from the referenced table, and the
+// deletion check is built for the values which are still absent from it.
+//
+// The returned expression produces the rows that violate the constraint, if
+// any. It is nil if no check is needed (e.g. because one of the tables is in
+// the process of being added).
+func BuildDeferredFKCheck(
+	ctx context.Context,
+	semaCtx *tree.SemaContext,
+	evalCtx *eval.Context,
+	catalog cat.Catalog,
+	factoryI interface{},
+	originTableID cat.StableID,
+	fkName string,
+	outbound bool,
+	keys []tree.Datums,
+) (memo.RelExpr, *memo.FKChecksItemPrivate, error) {
+	var private *memo.FKChecksItemPrivate
+	check, err := buildCascadeHelper(ctx, semaCtx, evalCtx, catalog, factoryI,
+		func(b *Builder) memo.RelExpr {
+			origin := resolveTable(ctx, catalog, originTableID)
+			if origin == nil {
+				return nil
+			}
+			fkOrdinal := findOutboundFK(origin, fkName)
+			if outbound {
+				var mb mutationBuilder
+				mb.init(b, "insert or update", origin, tree.MakeUnqualifiedTableName(origin.Name()))
+				mb.buildInputForDeferredCheck(keys)
+
+				var h fkCheckHelper
+				if !h.initWithOutboundFK(&mb, fkOrdinal) {
+					return nil
+				}
+				item := h.buildInsertionCheck()
+				private = &item.FKChecksItemPrivate
+				return mb.wrapDeferredCheck(item.Check)
+			}
+
+			referenced := resolveTable(ctx, catalog, origin.OutboundForeignKey(fkOrdinal).ReferencedTableID())
+			if referenced == nil {
+				return nil
+			}
+			var mb mutationBuilder
+			mb.init(b, "update or delete", referenced, tree.MakeUnqualifiedTableName(referenced.Name()))
+
+			var h fkCheckHelper
+			if !h.initWithInboundFK(&mb, findInboundFK(referenced, originTableID, fkName)) {
+				return nil
+			}
+			removedScope := mb.buildDeferredKeys(keys, h.tabOrdinals)
+
+			// Values which were removed and added back by the transaction do not
+			// orphan any rows; only keep the values which are still absent from
+			// the referenced table.
+			scanScope := b.buildScan(
+				b.addTable(referenced, &mb.alias),
+				h.tabOrdinals,
+				&tree.IndexFlags{IgnoreForeignKeys: true},
+				noRowLocking,
+				b.allocScope(),
+				true, /* disableNotVisibleIndex */
+			)
+			f := b.factory
+			on := make(memo.FiltersExpr, len(h.tabOrdinals))
+			for i := range on {
+				on[i] = f.ConstructFiltersItem(f.ConstructEq(
+					f.ConstructVariable(removedScope.cols[i].id),
+					f.ConstructVariable(scanScope.cols[i].id),
+				))
+			}
+			removed := f.ConstructAntiJoin(
+				removedScope.expr, scanScope.expr, on, memo.EmptyJoinPrivate,
+			)
+			item := h.buildDeletionCheck(removed, removedScope.colList())
+			private = &item.FKChecksItemPrivate
+			return item.Check
+		},
+	)
+	if err != nil || check == nil {
+		return nil, nil, err
+	}
+	return check, private, nil
+}
+
+// BuildDeferredUniqueCheck builds the check query for a UNIQUE WITHOUT INDEX
+// constraint whose checks were deferred until the end of the transaction. It
+// is the same check as for the rows written by a mutation, for the rows of the
+// table whose primary keys were queued by the mutations of the transaction.
+//
+// The returned expression produces the values of the unique columns that are
+// duplicated, if any. It is nil if no check is needed.
+func BuildDeferredUniqueCheck(
+	ctx context.Context,
+	semaCtx *tree.SemaContext,
+	evalCtx *eval.Context,
+	catalog cat.Catalog,
+	factoryI interface{},
+	tableID cat.StableID,
+	uniqueName string,
+	keys []tree.Datums,
+) (memo.RelExpr, *memo.UniqueChecksItemPrivate, error) {
+	var private *memo.UniqueChecksItemPrivate
+	check, err := buildCascadeHelper(ctx, semaCtx, evalCtx, catalog, factoryI,
+		func(b *Builder) memo.RelExpr {
+			tab := resolveTable(ctx, catalog, tableID)
+			if tab == nil {
+				return nil
+			}
+			uniqueOrdinal := -1
+			for i, n := 0, tab.UniqueCount(); i < n; i++ {
+				if uc := tab.Unique(i); uc.WithoutIndex() && uc.Name() == uniqueName {
+					uniqueOrdinal = i
+					break
+				}
+			}
+			if uniqueOrdinal == -1 {
+				panic(errors.AssertionFailedf(
+					"unique constraint %q not found in table %q", uniqueName, tab.Name(),
+				))
+			}
+
+			var mb mutationBuilder
+			mb.init(b, "insert or update", tab, tree.MakeUnqualifiedTableName(tab.Name()))
+			mb.buildInputForDeferredCheck(keys)
+
+			var h uniqueCheckHelper
+			if !h.init(&mb, uniqueOrdinal) {
+				return nil
+			}
+			item, _ := h.buildInsertionCheck(false /* buildFastPathCheck */)
+			private = &item.UniqueChecksItemPrivate
+			return mb.wrapDeferredCheck(item.Check)
+		},
+	)
+	if err != nil || check == nil {
+		return nil, nil, err
+	}
+	return check, private, nil
+}
+
+// buildInputForDeferredCheck builds the input for the deferred checks of the
+// table: the rows of the table with the given primary keys. The rows are
+// fetched as if they were mutated by the statement, so that the checks can be
+// built as for a mutation:
+//
+//	SELECT * FROM tab WHERE (pk1, pk2, ...) IN (<keys>)
+func (mb *mutationBuilder) buildInputForDeferredCheck(keys []tree.Datums) {
+	mb.fetchScope = mb.b.buildScan(
+		mb.b.addTable(mb.tab, &mb.alias),
+		tableOrdinals(mb.tab, columnKinds{
+			includeMutations: false,
+			includeSystem:    false,
+			includeInverted:  false,
+		}),
+		&tree.IndexFlags{IgnoreForeignKeys: true},
+		noRowLocking,
+		mb.b.allocScope(),
+		true, /* disableNotVisibleIndex */
+	)
+	mb.setFetchColIDs(mb.fetchScope.cols)
+
+	primary := mb.tab.Index(cat.PrimaryIndex)
+	pkOrdinals := make([]int, primary.KeyColumnCount())
+	for i := range pkOrdinals {
+		pkOrdinals[i] = primary.Column(i).Ordinal()
+	}
+	keysScope := mb.buildDeferredKeys(keys, pkOrdinals)
+
+	f := mb.b.factory
+	on := make(memo.FiltersExpr, len(pkOrdinals))
+	for i, ord := range pkOrdinals {
+		on[i] = f.ConstructFiltersItem(f.ConstructEq(
+			f.ConstructVariable(mb.fetchColIDs[ord]),
+			f.ConstructVariable(keysScope.cols[i].id),
+		))
+	}
+	mb.outScope = mb.fetchScope.replace()
+	mb.outScope.appendColumnsFromScope(mb.fetchScope)
+	mb.outScope.expr = f.ConstructSemiJoin(
+		mb.fetchScope.expr, keysScope.expr, on, memo.EmptyJoinPrivate,
+	)
+}
+
+// buildDeferredKeys builds a Values expression with the given keys, which
+// contain the values of the columns of the table with the given ordinals.
+func (mb *mutationBuilder) buildDeferredKeys(keys []tree.Datums, tabOrdinals []int) *scope {
+	outScope := mb.b.allocScope()
+	colTypes := make([]*types.T, len(tabOrdinals))
+	for i, ord := range tabOrdinals {
+		col := mb.tab.Column(ord)
+		colTypes[i] = col.DatumType()
+		mb.b.synthesizeColumn(outScope, scopeColName(col.ColName()), colTypes[i], nil, nil /* scalar */)
+	}
+
+	f := mb.b.factory
+	tupleTyp := types.MakeTuple(colTypes)
+	rows := make(memo.ScalarListExpr, len(keys))
+	for i, key := range keys {
+		elems := make(memo.ScalarListExpr, len(key))
+		for j := range key {
+			elems[j] = f.ConstructConstVal(key[j], colTypes[j])
+		}
+		rows[i] = f.ConstructTuple(elems, tupleTyp)
+	}
+	outScope.expr = f.ConstructValues(rows, &memo.ValuesPrivate{
+		Cols: outScope.colList(),
+		ID:   f.Metadata().NextUniqueID(),
+	})
+	return outScope
+}
+
+// wrapDeferredCheck returns the given check in a With expression which binds
+// the input built by buildInputForDeferredCheck, which the check reads with a
+// WithScan. The check must have been built after the input.
+func (mb *mutationBuilder) wrapDeferredCheck(check memo.RelExpr) memo.RelExpr {
+	if mb.withID == 0 {
+		return check
+	}
+	return mb.b.factory.ConstructWith(mb.outScope.expr, check, &memo.WithPrivate{
+		ID:   mb.withID,
+		Name: "deferred-check-input",
+	})
+}
+
+// findOutboundFK returns the ordinal of the outbound FK of the table with the
+// given name.
+func findOutboundFK(tab cat.Table, name string) int {
+	for i, n := 0, tab.OutboundForeignKeyCount(); i < n; i++ {
+		if tab.OutboundForeignKey(i).Name() == name {
+			return i
+		}
+	}
+	panic(errors.AssertionFailedf(
+		"foreign key %q not found in table %q", name, tab.Name(),
+	))
+}
+
+// findInboundFK returns the ordinal of the inbound FK of the table with the
+// given name and origin table.
+func findInboundFK(tab cat.Table, originTableID cat.StableID, name string) int {
+	for i, n := 0, tab.InboundForeignKeyCount(); i < n; i++ {
+		if fk := tab.InboundForeignKey(i); fk.OriginTableID() == originTableID && fk.Name() == name {
+			return i
+		}
+	}
+	panic(errors.AssertionFailedf(
+		"foreign key %q referencing table %q not found", name, tab.Name(),
+	))
+}
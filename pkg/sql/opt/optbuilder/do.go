@@ -0,0 +1,120 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/props/physical"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/plpgsql"
+	plpgsqlparser "github.com/cockroachdb/cockroach/pkg/sql/plpgsql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/plpgsqltree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+)
+
+// doBlockName is the name given to the routine built for a DO statement. It
+// matches the name used by Postgres in error messages.
+const doBlockName = "inline_code_block"
+
+// buildDo builds a DO statement. The code block is built in the same way as
+// the body of a PL/pgSQL procedure with no parameters, and is invoked once by
+// a CALL expression. No function descriptor is created, and the block runs in
+// the current transaction.
+//
+// Like the body of a routine, the whole block is planned before it runs, rather
+// than one statement at a time as in Postgres. As a result, a statement in the
+// block cannot reference a table or column that is created by an earlier
+// statement in the same block, e.g. to backfill a column that the block adds.
+// Such statements must be run in a separate statement or DO block.
+func (b *Builder) buildDo(do *tree.DoBlock, inScope *scope) *scope {
+	// The routine is not added to the metadata, so memo reuse would not be able
+	// to detect when the statement is stale.
+	b.DisableMemoReuse = true
+
+	if _, err := funcinfo.FunctionLangToProto(do.Language); err != nil {
+		panic(err)
+	}
+	if do.Language != tree.RoutineLangPLpgSQL {
+		panic(pgerror.New(pgcode.FeatureNotSupported,
+			"language \"sql\" does not support inline code execution"))
+	}
+	if err := plpgsql.CheckClusterSupportsPLpgSQL(b.evalCtx.Settings); err != nil {
+		panic(err)
+	}
+	stmt, err := plpgsqlparser.Parse(do.Code)
+	if err != nil {
+		panic(err)
+	}
+	var tc transactionControlVisitor
+	plpgsqltree.Walk(&tc, stmt.AST)
+	if tc.foundTxnControlStatement {
+		panic(unimplemented.NewWithIssue(122266, "transaction control statements in DO blocks"))
+	}
+
+	// The code block cannot reference anything from the outer scope, and its
+	// dependencies are not tracked, since it is not persisted.
+	defer func(trackSchemaDeps, insideUDF, insideDataSource, insideSQLRoutine bool) {
+		b.trackSchemaDeps = trackSchemaDeps
+		b.insideUDF = insideUDF
+		b.insideDataSource = insideDataSource
+		b.insideSQLRoutine = insideSQLRoutine
+	}(b.trackSchemaDeps, b.insideUDF, b.insideDataSource, b.insideSQLRoutine)
+	b.trackSchemaDeps = false
+	b.insideUDF = true
+	b.insideDataSource = false
+	b.insideSQLRoutine = false
+
+	bodyScope := b.allocScope()
+	plBuilder := newPLpgSQLBuilder(
+		b, doBlockName, stmt.AST.Label, nil /* colRefs */, nil /* routineParams */, types.Void,
+		false /* isProcedure */, false /* isSetReturning */, nil, /* outScope */
+	)
+	stmtScope := plBuilder.buildRootBlock(stmt.AST, bodyScope, nil /* routineParams */)
+
+	// Add a LIMIT 1 to the last statement, as is done for a routine that is
+	// not set-returning. See finishBuildLastStmt.
+	b.buildLimit(&tree.Limit{Count: tree.NewDInt(1)}, b.allocScope(), stmtScope)
+	expr, physProps := stmtScope.expr, stmtScope.makePhysicalProps()
+	physProps.Ordering = props.OrderingChoice{}
+	expr, physProps = b.maybeAddRoutineAssignmentCasts(
+		physProps.Presentation, bodyScope, types.Void, expr, physProps, false, /* insideDataSource */
+	)
+	var bodyStmts []string
+	if b.verboseTracing {
+		bodyStmts = []string{stmt.String()}
+	}
+
+	routine := b.factory.ConstructUDFCall(
+		nil, /* args */
+		&memo.UDFCallPrivate{
+			Def: &memo.UDFDefinition{
+				Name:              doBlockName,
+				Typ:               types.Void,
+				Volatility:        volatility.Volatile,
+				CalledOnNullInput: true,
+				RoutineType:       tree.ProcedureRoutine,
+				RoutineLang:       tree.RoutineLangPLpgSQL,
+				Body:              []memo.RelExpr{expr},
+				BodyProps:         []*physical.Required{physProps},
+				BodyStmts:         bodyStmts,
+			},
+		},
+	)
+	outScope := inScope.push()
+	outScope.expr = b.factory.ConstructCall(routine, &memo.CallPrivate{})
+	return outScope
+}
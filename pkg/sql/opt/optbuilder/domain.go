@@ -0,0 +1,160 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinsregistry"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// domainValueColName is the name by which the CHECK constraints of a domain
+// refer to the value being checked.
+const domainValueColName = "value"
+
+// buildDomainConstraints wraps the given scalar expression, which produces
+// values of the given domain type, in calls to the
+// crdb_internal.assert_domain_constraint builtin function. Each call returns
+// its input value unchanged if the corresponding NOT NULL or CHECK constraint
+// of the domain is satisfied, and raises an error otherwise. If typ is not a
+// domain type, or if the domain has no constraints, value is returned as-is.
+func (b *Builder) buildDomainConstraints(value opt.ScalarExpr, typ *types.T) opt.ScalarExpr {
+	if !typ.IsDomain() || typ.TypeMeta.DomainData == nil {
+		return value
+	}
+	domain := typ.TypeMeta.DomainData
+	if !domain.NotNull && len(domain.Checks) == 0 {
+		return value
+	}
+
+	// Add the domain to the metadata so that the memo is invalidated if the
+	// constraints of the domain are changed.
+	b.factory.Metadata().AddUserDefinedType(typ, nil /* name */)
+
+	// The value is referenced by every constraint, so it is evaluated more
+	// than once. A volatile expression could produce a different value each
+	// time, so it is instead evaluated once and projected as a column of a
+	// single-row subquery, which checks the column:
+	//
+	//   (SELECT assert_domain_constraint(value, ...) FROM (SELECT <expr> AS value))
+	//
+	var p props.Shared
+	memo.BuildSharedProps(value, &p, b.evalCtx)
+	if !p.VolatilitySet.HasVolatile() {
+		return b.buildDomainAssertions(value, typ)
+	}
+	md := b.factory.Metadata()
+	valueCol := md.AddColumn(domainValueColName, typ)
+	input := b.factory.ConstructProject(
+		b.factory.ConstructNoColsRow(),
+		memo.ProjectionsExpr{b.factory.ConstructProjectionsItem(value, valueCol)},
+		opt.ColSet{}, /* passthrough */
+	)
+	checkedCol := md.AddColumn(domainValueColName, typ)
+	checked := b.buildDomainAssertions(b.factory.ConstructVariable(valueCol), typ)
+	return b.factory.ConstructSubquery(
+		b.factory.ConstructProject(
+			input,
+			memo.ProjectionsExpr{b.factory.ConstructProjectionsItem(checked, checkedCol)},
+			opt.ColSet{}, /* passthrough */
+		),
+		&memo.SubqueryPrivate{},
+	)
+}
+
+// buildDomainAssertions builds the calls to
+// crdb_internal.assert_domain_constraint for the NOT NULL and CHECK
+// constraints of the given domain type. See buildDomainConstraints.
+func (b *Builder) buildDomainAssertions(value opt.ScalarExpr, typ *types.T) opt.ScalarExpr {
+	domain := typ.TypeMeta.DomainData
+	domainName := typ.Name()
+	if domain.NotNull {
+		satisfied := b.factory.ConstructIsNot(value, memo.NullSingleton)
+		value = b.makeAssertDomainConstraintFn(
+			value, satisfied, pgcode.NotNullViolation,
+			fmt.Sprintf("domain %s does not allow null values", domainName),
+		)
+	}
+	for i := range domain.Checks {
+		check := &domain.Checks[i]
+		satisfied := b.buildDomainCheckExpr(check.Expr, value, typ.DomainBaseType())
+		value = b.makeAssertDomainConstraintFn(
+			value, satisfied, pgcode.CheckViolation,
+			fmt.Sprintf("value for domain %s violates check constraint %q", domainName, check.Name),
+		)
+	}
+	return value
+}
+
+// buildDomainCheckExpr builds the given serialized CHECK constraint expression
+// of a domain, substituting the given scalar expression for references to the
+// domain value.
+func (b *Builder) buildDomainCheckExpr(
+	exprStr string, value opt.ScalarExpr, baseType *types.T,
+) opt.ScalarExpr {
+	expr, err := parser.ParseExpr(exprStr)
+	if err != nil {
+		panic(err)
+	}
+
+	// Build the expression in a scope with a single column which represents
+	// the domain value, and then replace references to that column with the
+	// value itself.
+	valScope := b.allocScope()
+	valCol := b.synthesizeColumn(
+		valScope, scopeColName(domainValueColName), baseType, nil /* expr */, nil, /* scalar */
+	)
+	texpr := valScope.resolveAndRequireType(expr, types.Bool)
+	check := b.buildScalar(texpr, valScope, nil /* outScope */, nil /* outCol */, nil /* colRefs */)
+
+	var replace func(e opt.Expr) opt.Expr
+	replace = func(e opt.Expr) opt.Expr {
+		if v, ok := e.(*memo.VariableExpr); ok && v.Col == valCol.id {
+			return value
+		}
+		return b.factory.Replace(e, replace)
+	}
+	return replace(check).(opt.ScalarExpr)
+}
+
+// makeAssertDomainConstraintFn builds a call to the
+// crdb_internal.assert_domain_constraint builtin function.
+func (b *Builder) makeAssertDomainConstraintFn(
+	value, satisfied opt.ScalarExpr, code pgcode.Code, msg string,
+) opt.ScalarExpr {
+	const assertFnName = "crdb_internal.assert_domain_constraint"
+	fnProps, overloads := builtinsregistry.GetBuiltinProperties(assertFnName)
+	if len(overloads) != 1 {
+		panic(errors.AssertionFailedf("expected one overload for %s", assertFnName))
+	}
+	return b.factory.ConstructFunction(
+		memo.ScalarListExpr{
+			value,
+			satisfied,
+			b.factory.ConstructConstVal(tree.NewDString(code.String()), types.String),
+			b.factory.ConstructConstVal(tree.NewDString(msg), types.String),
+		},
+		&memo.FunctionPrivate{
+			Name:       assertFnName,
+			Typ:        value.DataType(),
+			Properties: fnProps,
+			Overload:   &overloads[0],
+		},
+	)
+}
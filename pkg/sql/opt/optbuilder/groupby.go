@@ -100,6 +100,16 @@ type groupby struct {
 	// It is used to ensure that the builder does not throw a grouping error
 	// prematurely.
 	buildingGroupingCols bool
+
+	// groupingSets contains the grouping sets of a GROUP BY clause with ROLLUP,
+	// CUBE or GROUPING SETS items, each as the set of grouping columns that it
+	// contains. It is nil for a plain GROUP BY clause. See grouping_sets.go.
+	groupingSets []opt.ColSet
+
+	// groupingSetCol is the column containing the index in groupingSets of the
+	// grouping set that each row of the aggregation belongs to. It is only set
+	// if groupingSets is not nil, in which case it is the last grouping column.
+	groupingSetCol opt.ColumnID
 }
 
 // groupByStrSet is a set of stringified GROUP BY expressions that map to the
@@ -126,18 +136,27 @@ func (g *groupby) hasNonCommutativeAggregates() bool {
 	return false
 }
 
+// numGroupingCols returns the number of grouping columns, which includes the
+// grouping set column if there are grouping sets.
+func (g *groupby) numGroupingCols() int {
+	if g.groupingSetCol != 0 {
+		return len(g.groupStrs) + 1
+	}
+	return len(g.groupStrs)
+}
+
 // groupingCols returns the columns in the aggInScope corresponding to grouping
 // columns.
 func (g *groupby) groupingCols() []scopeColumn {
 	// Grouping cols are always clustered at the end of the column list.
-	return g.aggInScope.cols[len(g.aggInScope.cols)-len(g.groupStrs):]
+	return g.aggInScope.cols[len(g.aggInScope.cols)-g.numGroupingCols():]
 }
 
 // getAggregateArgCols returns the columns in the aggInScope corresponding to
 // arguments to aggregate functions. If the aggregate has a filter, the column
 // corresponding to the filter's input will immediately follow the arguments.
 func (g *groupby) aggregateArgCols() []scopeColumn {
-	return g.aggInScope.cols[:len(g.aggInScope.cols)-len(g.groupStrs)]
+	return g.aggInScope.cols[:len(g.aggInScope.cols)-g.numGroupingCols()]
 }
 
 // getAggregateResultCols returns the columns in the aggOutScope corresponding
@@ -332,7 +351,10 @@ func (b *Builder) buildGroupingColumns(sel *tree.SelectClause, projectionsScope,
 	g := fromScope.groupby
 
 	// The "from" columns are visible to any grouping expressions.
-	b.buildGroupingList(sel.GroupBy, sel.Exprs, projectionsScope, fromScope)
+	sets := b.buildGroupingList(sel.GroupBy, sel.Exprs, projectionsScope, fromScope)
+	if sets != nil {
+		b.buildGroupingSetColumns(g, sets)
+	}
 
 	// Copy the grouping columns to the aggOutScope.
 	g.aggOutScope.appendColumns(g.groupingCols())
@@ -351,6 +373,20 @@ func (b *Builder) buildAggregation(having opt.ScalarExpr, fromScope *scope) (out
 		groupingColSet.Add(groupingCols[i].id)
 	}
 
+	if g.groupingSets != nil {
+		return b.buildGroupingSetsAggregation(groupingColSet, having, fromScope)
+	}
+	return b.buildGroupByAggregation(groupingColSet, having, fromScope)
+}
+
+// buildGroupByAggregation builds the aggregation operators for the given
+// grouping columns and constructs the GroupBy expression. Returns the output
+// scope for the aggregation operation.
+func (b *Builder) buildGroupByAggregation(
+	groupingColSet opt.ColSet, having opt.ScalarExpr, fromScope *scope,
+) *scope {
+	g := fromScope.groupby
+
 	// If there are any aggregates that are ordering sensitive, build the
 	// aggregations as window functions over each group.
 	if g.hasNonCommutativeAggregates() {
@@ -385,7 +421,11 @@ func (b *Builder) buildAggregation(having opt.ScalarExpr, fromScope *scope) (out
 
 		// Construct the aggregate function from its name and arguments and store
 		// it in the corresponding scope column.
-		aggCols[i].scalar = b.constructAggregate(agg.def.Name, args)
+		if agg.def.Overload.UDFAggregate != nil {
+			aggCols[i].scalar = b.constructUserDefinedAgg(&aggInfos[i], args)
+		} else {
+			aggCols[i].scalar = b.constructAggregate(agg.def.Name, args)
+		}
 
 		// Wrap the aggregate function with an AggDistinct operator if DISTINCT
 		// was specified in the query.
@@ -482,7 +522,9 @@ func (b *Builder) buildHaving(having tree.TypedExpr, fromScope *scope) opt.Scala
 
 // buildGroupingList builds a set of memo groups that represent a list of
 // GROUP BY expressions, adding the group-by expressions as columns to
-// aggInScope and populating groupStrs.
+// aggInScope and populating groupStrs. If the list contains ROLLUP, CUBE or
+// GROUPING SETS items, it returns the grouping sets of the GROUP BY clause.
+// Otherwise, it returns nil.
 //
 // groupBy   The given GROUP BY expressions.
 // selects   The select expressions are needed in case one of the GROUP BY
@@ -494,7 +536,7 @@ func (b *Builder) buildHaving(having tree.TypedExpr, fromScope *scope) opt.Scala
 // fromScope The scope for the input to the aggregation (the FROM clause).
 func (b *Builder) buildGroupingList(
 	groupBy tree.GroupBy, selects tree.SelectExprs, projectionsScope *scope, fromScope *scope,
-) {
+) (sets []opt.ColSet) {
 	g := fromScope.groupby
 	g.groupStrs = make(groupByStrSet, len(groupBy))
 	if g.aggInScope.cols == nil {
@@ -510,15 +552,34 @@ func (b *Builder) buildGroupingList(
 	// used in an aggregate function`. The builder cannot know whether there is
 	// a grouping error until the grouping columns are fully built.
 	g.buildingGroupingCols = true
+	defer func() { g.buildingGroupingCols = false }()
+
+	// The grouping sets of the GROUP BY clause are the cross product of the
+	// grouping sets of each of its items. A plain expression has a single
+	// grouping set.
+	sets = []opt.ColSet{{}}
+	hasGroupingSets := false
 	for _, e := range groupBy {
-		b.buildGrouping(e, selects, projectionsScope, fromScope, g.aggInScope)
+		if gs, ok := e.(*tree.GroupingSet); ok {
+			hasGroupingSets = true
+			sets = crossGroupingSets(sets, b.buildGroupingSet(gs, selects, projectionsScope, fromScope))
+			continue
+		}
+		cols := b.buildGrouping(e, selects, projectionsScope, fromScope, g.aggInScope)
+		for i := range sets {
+			sets[i].UnionWith(cols)
+		}
+	}
+	if !hasGroupingSets {
+		return nil
 	}
-	g.buildingGroupingCols = false
+	return sets
 }
 
 // buildGrouping builds a set of memo groups that represent a GROUP BY
 // expression. The expression (or expressions, if we have a star) is added to
-// groupStrs and to the aggInScope.
+// groupStrs and to the aggInScope. Returns the set of grouping columns for the
+// expression.
 //
 // groupBy          The given GROUP BY expression.
 // selects          The select expressions are needed in case the GROUP BY
@@ -538,7 +599,7 @@ func (b *Builder) buildGroupingList(
 //	as the aggregate function arguments.
 func (b *Builder) buildGrouping(
 	groupBy tree.Expr, selects tree.SelectExprs, projectionsScope, fromScope, aggInScope *scope,
-) {
+) (cols opt.ColSet) {
 	// Unwrap parenthesized expressions like "((a))" to "a".
 	groupBy = tree.StripParens(groupBy)
 	alias := ""
@@ -636,7 +697,8 @@ func (b *Builder) buildGrouping(
 		// If a grouping column has already been added, don't add it again.
 		// GROUP BY a, a is semantically equivalent to GROUP BY a.
 		exprStr := symbolicExprStr(e)
-		if _, ok := fromScope.groupby.groupStrs[exprStr]; ok {
+		if col, ok := fromScope.groupby.groupStrs[exprStr]; ok {
+			cols.Add(col.id)
 			continue
 		}
 
@@ -646,7 +708,9 @@ func (b *Builder) buildGrouping(
 		col := aggInScope.addColumn(scopeColName(tree.Name(alias)), e)
 		b.buildScalar(e, fromScope, aggInScope, col, nil)
 		fromScope.groupby.groupStrs[exprStr] = col
+		cols.Add(col.id)
 	}
+	return cols
 }
 
 // buildAggArg builds a scalar expression which is used as an input in some form
@@ -940,6 +1004,11 @@ func newGroupingError(name tree.Name) error {
 // In the unique index or unique without index cases, all key columns must be
 // marked as NOT NULL to allow the implicit grouping.
 func (b *Builder) allowImplicitGroupingColumn(colID opt.ColumnID, g *groupby) bool {
+	if g.groupingSets != nil {
+		// The grouping columns are NULL in the rows of grouping sets that don't
+		// include them, so they don't determine the other columns of the table.
+		return false
+	}
 	md := b.factory.Metadata()
 	colMeta := md.ColumnMeta(colID)
 	if colMeta.Table == 0 {
@@ -0,0 +1,411 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+// This file has builder code specific to GROUP BY clauses with ROLLUP, CUBE
+// and GROUPING SETS items.
+//
+// Rather than building a separate aggregation for each grouping set and
+// combining the results with UNION ALL, which would read the input once per
+// grouping set, we build a single GroupBy operator over an expansion of the
+// input:
+//
+//  - the input is cross joined with a VALUES clause which has one row for
+//    each grouping set, containing the index of the grouping set. This
+//    replicates each input row once per grouping set.
+//
+//  - the pre-projection replaces each grouping column with NULL in the rows
+//    of the grouping sets that don't include it.
+//
+//  - the GroupBy groups by all the grouping columns plus the grouping set
+//    index, so that each group of the output belongs to a single grouping set.
+//
+// For example:
+//   SELECT a, b, sum(c) FROM t GROUP BY ROLLUP (a, b)
+//
+//   grouping sets:   (a, b), (a), ()
+//   expansion:       t CROSS JOIN (VALUES (0), (1), (2)) AS v(grouping_set)
+//   pre-projection:  CASE WHEN grouping_set IN (2) THEN NULL ELSE a END (as a'),
+//                    CASE WHEN grouping_set IN (1, 2) THEN NULL ELSE b END (as b'),
+//                    c, grouping_set
+//   aggregation:     group by a', b', grouping_set, calculate sum(c)
+//
+// An empty grouping set produces a row even if the input is empty, like a
+// GROUP BY without grouping columns. Since the GroupBy doesn't produce any rows
+// for empty input, its output is full outer joined with a VALUES clause which
+// has one row for each empty grouping set, and the aggregates which don't
+// return NULL for empty input (like count) are replaced by their value for
+// empty input in the rows produced by the join.
+//
+// The expansion and the aggregation are executed by the vectorized cross
+// joiner and hash aggregator, so the input is only read once no matter how
+// many grouping sets there are. No dedicated execution operator is needed for
+// the expansion: the cross joiner already emits each input batch once per row
+// of the (tiny) VALUES clause, which is the same work that an "expand"
+// operator would do, and the CASE, IN and COALESCE expressions of the
+// projections as well as the full outer join with the empty grouping sets are
+// all supported by colexec. A specialized operator would only save the
+// projection of the grouping set index, which is negligible compared to the
+// aggregation.
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// maxGroupingSets is the maximum number of grouping sets of a GROUP BY clause.
+// It matches the limit imposed by Postgres.
+const maxGroupingSets = 4096
+
+// maxGroupingArgs is the maximum number of arguments of a GROUPING operation,
+// so that the bit mask fits in an INT4 like in Postgres.
+const maxGroupingArgs = 31
+
+var errTooManyGroupingSets = pgerror.Newf(pgcode.StatementTooComplex,
+	"too many grouping sets present (maximum %d)", maxGroupingSets)
+
+// buildGroupingSet builds the grouping columns of a ROLLUP, CUBE or GROUPING
+// SETS item of a GROUP BY clause, and returns the grouping sets of the item.
+// See buildGrouping for a description of the arguments.
+func (b *Builder) buildGroupingSet(
+	gs *tree.GroupingSet, selects tree.SelectExprs, projectionsScope, fromScope *scope,
+) []opt.ColSet {
+	g := fromScope.groupby
+	switch gs.Type {
+	case tree.RollupGroupingSet:
+		// ROLLUP (a, b, c) is equivalent to GROUPING SETS ((a, b, c), (a, b),
+		// (a), ()).
+		sets := make([]opt.ColSet, len(gs.Exprs)+1)
+		for i, e := range gs.Exprs {
+			sets[i+1] = sets[i].Union(b.buildGrouping(e, selects, projectionsScope, fromScope, g.aggInScope))
+		}
+		for i, j := 0, len(sets)-1; i < j; i, j = i+1, j-1 {
+			sets[i], sets[j] = sets[j], sets[i]
+		}
+		return sets
+
+	case tree.CubeGroupingSet:
+		// CUBE (a, b) is equivalent to GROUPING SETS ((a, b), (a), (b), ()).
+		if len(gs.Exprs) > 12 {
+			panic(errTooManyGroupingSets)
+		}
+		elems := make([]opt.ColSet, len(gs.Exprs))
+		for i, e := range gs.Exprs {
+			elems[i] = b.buildGrouping(e, selects, projectionsScope, fromScope, g.aggInScope)
+		}
+		sets := make([]opt.ColSet, 0, 1<<len(elems))
+		for mask := 1<<len(elems) - 1; mask >= 0; mask-- {
+			var set opt.ColSet
+			for i := range elems {
+				if mask&(1<<(len(elems)-1-i)) != 0 {
+					set.UnionWith(elems[i])
+				}
+			}
+			sets = append(sets, set)
+		}
+		return sets
+
+	case tree.ExplicitGroupingSet:
+		// Nested ROLLUP, CUBE and GROUPING SETS items contribute all of their
+		// grouping sets.
+		var sets []opt.ColSet
+		for _, e := range gs.Exprs {
+			if nested, ok := e.(*tree.GroupingSet); ok {
+				sets = append(sets, b.buildGroupingSet(nested, selects, projectionsScope, fromScope)...)
+			} else {
+				sets = append(sets, b.buildGrouping(e, selects, projectionsScope, fromScope, g.aggInScope))
+			}
+			if len(sets) > maxGroupingSets {
+				panic(errTooManyGroupingSets)
+			}
+		}
+		return sets
+
+	default:
+		panic(errors.AssertionFailedf("unknown grouping set type %d", gs.Type))
+	}
+}
+
+// crossGroupingSets returns the grouping sets of the concatenation of two
+// GROUP BY items with the given grouping sets. Each returned grouping set is
+// the union of a grouping set of left and a grouping set of right.
+func crossGroupingSets(left, right []opt.ColSet) []opt.ColSet {
+	if len(left)*len(right) > maxGroupingSets {
+		panic(errTooManyGroupingSets)
+	}
+	sets := make([]opt.ColSet, 0, len(left)*len(right))
+	for i := range left {
+		for j := range right {
+			sets = append(sets, left[i].Union(right[j]))
+		}
+	}
+	return sets
+}
+
+// buildGroupingSetColumns adds the grouping set column to the grouping columns
+// of the aggInScope, and replaces each grouping column that is not part of all
+// the grouping sets with a column which is NULL in the rows of the grouping
+// sets that don't include it. The given grouping sets are updated with the new
+// columns and stored in the groupby.
+func (b *Builder) buildGroupingSetColumns(g *groupby, sets []opt.ColSet) {
+	md := b.factory.Metadata()
+	groupingSetColName := scopeColName("").WithMetadataName("grouping_set")
+	groupingSetCol := md.AddColumn(groupingSetColName.MetadataName(), types.Int)
+
+	groupingCols := g.groupingCols()
+	for i := range groupingCols {
+		col := &groupingCols[i]
+		var excluded memo.ScalarListExpr
+		for j := range sets {
+			if !sets[j].Contains(col.id) {
+				excluded = append(excluded, b.factory.ConstructConstVal(tree.NewDInt(tree.DInt(j)), types.Int))
+			}
+		}
+		if len(excluded) == 0 {
+			continue
+		}
+
+		// CASE WHEN grouping_set IN (excluded...) THEN NULL ELSE col END
+		input := col.scalar
+		if input == nil {
+			input = b.factory.ConstructVariable(col.id)
+		}
+		excludedTyp := make([]*types.T, len(excluded))
+		for j := range excludedTyp {
+			excludedTyp[j] = types.Int
+		}
+		scalar := b.factory.ConstructCase(
+			memo.TrueSingleton,
+			memo.ScalarListExpr{
+				b.factory.ConstructWhen(
+					b.factory.ConstructIn(
+						b.factory.ConstructVariable(groupingSetCol),
+						b.factory.ConstructTuple(excluded, types.MakeTuple(excludedTyp)),
+					),
+					b.factory.ConstructNull(col.typ),
+				),
+			},
+			input,
+		)
+		oldID := col.id
+		b.populateSynthesizedColumn(col, scalar)
+		for j := range sets {
+			if sets[j].Contains(oldID) {
+				sets[j].Remove(oldID)
+				sets[j].Add(col.id)
+			}
+		}
+	}
+
+	// The grouping set column is a pass-through column which is produced by
+	// the VALUES clause built by buildGroupingSetsAggregation. It must be added
+	// after the grouping columns have been updated above, since groupStrs
+	// points to them.
+	g.aggInScope.cols = append(g.aggInScope.cols, scopeColumn{
+		name: groupingSetColName,
+		typ:  types.Int,
+		id:   groupingSetCol,
+	})
+	g.groupingSets = sets
+	g.groupingSetCol = groupingSetCol
+}
+
+// buildGroupingSetsAggregation builds the aggregation operators for a GROUP BY
+// clause with grouping sets. See the comment at the top of this file.
+func (b *Builder) buildGroupingSetsAggregation(
+	groupingColSet opt.ColSet, having opt.ScalarExpr, fromScope *scope,
+) *scope {
+	g := fromScope.groupby
+	md := b.factory.Metadata()
+
+	// Replicate each input row once per grouping set.
+	rows := make(memo.ScalarListExpr, len(g.groupingSets))
+	var emptySets memo.ScalarListExpr
+	for i := range g.groupingSets {
+		idx := b.factory.ConstructConstVal(tree.NewDInt(tree.DInt(i)), types.Int)
+		rows[i] = b.factory.ConstructTuple(memo.ScalarListExpr{idx}, types.MakeTuple([]*types.T{types.Int}))
+		if g.groupingSets[i].Empty() {
+			emptySets = append(emptySets, rows[i])
+		}
+	}
+	values := b.factory.ConstructValues(rows, &memo.ValuesPrivate{
+		Cols: opt.ColList{g.groupingSetCol},
+		ID:   md.NextUniqueID(),
+	})
+	fromScope.expr = b.factory.ConstructInnerJoin(
+		fromScope.expr, values, memo.TrueFilter, memo.EmptyJoinPrivate,
+	)
+	if len(emptySets) == 0 {
+		return b.buildGroupByAggregation(groupingColSet, having, fromScope)
+	}
+
+	// The aggregates which don't return NULL for empty input are computed into
+	// new columns, and their output columns are projected on top of the outer
+	// join below.
+	aggCols := g.aggregateResultCols()
+	var projections memo.ProjectionsExpr
+	var renamed []int
+	for i := range g.aggs {
+		defaultVal, ok := b.overrideDefaultNullValue(g.aggs[i])
+		if !ok {
+			continue
+		}
+		innerCol := md.AddColumn(aggCols[i].name.MetadataName(), aggCols[i].typ)
+		projections = append(projections, b.factory.ConstructProjectionsItem(
+			b.factory.ConstructCoalesce(memo.ScalarListExpr{
+				b.factory.ConstructVariable(innerCol), defaultVal,
+			}),
+			aggCols[i].id,
+		))
+		aggCols[i].id = innerCol
+		g.aggs[i].col.id = innerCol
+		renamed = append(renamed, i)
+	}
+
+	outScope := b.buildGroupByAggregation(groupingColSet, nil /* having */, fromScope)
+
+	// Restore the output columns of the renamed aggregates, which are produced
+	// by the projection below.
+	for i, aggIdx := range renamed {
+		aggCols[aggIdx].id = projections[i].Col
+		g.aggs[aggIdx].col.id = projections[i].Col
+	}
+
+	// Add a row for each empty grouping set which has no group in the output
+	// of the aggregation, which is the case if the input is empty. In those
+	// rows, the grouping set column is NULL.
+	emptySetCol := md.AddColumn("empty_grouping_set", types.Int)
+	emptySetValues := b.factory.ConstructValues(emptySets, &memo.ValuesPrivate{
+		Cols: opt.ColList{emptySetCol},
+		ID:   md.NextUniqueID(),
+	})
+	on := memo.FiltersExpr{b.factory.ConstructFiltersItem(b.factory.ConstructEq(
+		b.factory.ConstructVariable(g.groupingSetCol),
+		b.factory.ConstructVariable(emptySetCol),
+	))}
+	input := b.factory.ConstructFullJoin(outScope.expr, emptySetValues, on, memo.EmptyJoinPrivate)
+
+	passthrough := outScope.colSet()
+	for i := range projections {
+		passthrough.Remove(projections[i].Col)
+	}
+	outScope.expr = b.factory.ConstructProject(input, projections, passthrough)
+
+	// Wrap with having filter if it exists.
+	if having != nil {
+		filters := memo.FiltersExpr{b.factory.ConstructFiltersItem(having)}
+		outScope.expr = b.factory.ConstructSelect(outScope.expr, filters)
+	}
+	return outScope
+}
+
+// groupingInfo stores information about a GROUPING operation.
+type groupingInfo struct {
+	*tree.GroupingExpr
+
+	// args contains the type checked arguments of the GROUPING operation.
+	args tree.TypedExprs
+}
+
+// Walk is part of the tree.Expr interface.
+func (g *groupingInfo) Walk(v tree.Visitor) tree.Expr {
+	return g
+}
+
+// TypeCheck is part of the tree.Expr interface.
+func (g *groupingInfo) TypeCheck(
+	ctx context.Context, semaCtx *tree.SemaContext, desired *types.T,
+) (tree.TypedExpr, error) {
+	return g, nil
+}
+
+// ResolvedType is part of the tree.TypedExpr interface.
+func (g *groupingInfo) ResolvedType() *types.T {
+	return types.Int
+}
+
+// Eval is part of the tree.TypedExpr interface.
+func (g *groupingInfo) Eval(_ context.Context, _ tree.ExprEvaluator) (tree.Datum, error) {
+	panic(errors.AssertionFailedf("groupingInfo must be replaced before evaluation"))
+}
+
+var _ tree.Expr = &groupingInfo{}
+var _ tree.TypedExpr = &groupingInfo{}
+
+var errGroupingArgs = pgerror.New(pgcode.Grouping,
+	"arguments to GROUPING must be grouping expressions of the associated query level")
+
+// buildGroupingExpr builds a GROUPING operation. GROUPING returns a bit mask
+// with a bit for each argument, from the most significant to the least
+// significant, which is set if the argument is not part of the grouping set
+// of the current row. The result is computed from the grouping set column:
+//
+//	CASE grouping_set WHEN 0 THEN 0 WHEN 1 THEN 1 ELSE 3 END
+func (b *Builder) buildGroupingExpr(t *groupingInfo, inScope *scope) opt.ScalarExpr {
+	if inScope.inAgg {
+		panic(pgerror.New(pgcode.Grouping,
+			"aggregate function calls cannot contain grouping operations"))
+	}
+	g := inScope.groupby
+	if g == nil || g.groupStrs == nil || g.buildingGroupingCols {
+		panic(errGroupingArgs)
+	}
+	if len(t.args) > maxGroupingArgs {
+		panic(pgerror.Newf(pgcode.TooManyArguments,
+			"GROUPING must have fewer than %d arguments", maxGroupingArgs+1))
+	}
+	cols := make([]opt.ColumnID, len(t.args))
+	for i, arg := range t.args {
+		col, ok := g.groupStrs[symbolicExprStr(arg)]
+		if !ok {
+			panic(errGroupingArgs)
+		}
+		cols[i] = col.id
+	}
+
+	// Without grouping sets, all the arguments are part of the grouping set of
+	// every row.
+	if g.groupingSets == nil {
+		return b.factory.ConstructConstVal(tree.NewDInt(0), types.Int)
+	}
+
+	// The rows added by buildGroupingSetsAggregation for empty grouping sets
+	// have a NULL grouping set column, so they fall into the ELSE branch where
+	// none of the arguments are part of the grouping set.
+	allArgs := 1<<len(cols) - 1
+	var whens memo.ScalarListExpr
+	for i := range g.groupingSets {
+		var mask int
+		for j, col := range cols {
+			if !g.groupingSets[i].Contains(col) {
+				mask |= 1 << (len(cols) - 1 - j)
+			}
+		}
+		if mask != allArgs {
+			whens = append(whens, b.factory.ConstructWhen(
+				b.factory.ConstructConstVal(tree.NewDInt(tree.DInt(i)), types.Int),
+				b.factory.ConstructConstVal(tree.NewDInt(tree.DInt(mask)), types.Int),
+			))
+		}
+	}
+	allArgsVal := b.factory.ConstructConstVal(tree.NewDInt(tree.DInt(allArgs)), types.Int)
+	if len(whens) == 0 {
+		return allArgsVal
+	}
+	return b.factory.ConstructCase(b.factory.ConstructVariable(g.groupingSetCol), whens, allArgsVal)
+}
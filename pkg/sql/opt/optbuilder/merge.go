@@ -0,0 +1,633 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/cast"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/errors"
+)
+
+// duplicateMergeErrText is error text used when a target row is matched by
+// more than one source row of a MERGE statement.
+const duplicateMergeErrText = "MERGE command cannot affect row a second time"
+
+// buildMerge builds a memo group for a MERGE statement. MERGE is built as an
+// Upsert operator whose input left-joins the source rows to the target table
+// using the ON condition. A column is projected that contains the ordinal of
+// the WHEN clause that applies to each row, and the insert and update values
+// are selected with CASE expressions on that column. For example:
+//
+//	CREATE TABLE t (a INT PRIMARY KEY, b INT)
+//	MERGE INTO t USING s ON t.a = s.a
+//	WHEN MATCHED AND s.b IS NULL THEN DELETE
+//	WHEN MATCHED THEN UPDATE SET b = s.b
+//	WHEN NOT MATCHED THEN INSERT VALUES (s.a, s.b)
+//
+// would create an Upsert input similar to this SQL:
+//
+//	SELECT
+//	  t.a, t.b,
+//	  CASE merge_clause WHEN 3 THEN s.a ELSE t.a END AS a_new,
+//	  CASE merge_clause WHEN 3 THEN s.b ELSE t.b END AS b_new,
+//	  CASE merge_clause WHEN 2 THEN s.b ELSE t.b END AS b_upd,
+//	  merge_clause IN (1) AS merge_delete
+//	FROM (
+//	  SELECT *, CASE WHEN t.a IS NOT NULL THEN
+//	    CASE WHEN s.b IS NULL THEN 1 WHEN true THEN 2 END
+//	  ELSE
+//	    CASE WHEN true THEN 3 END
+//	  END AS merge_clause
+//	  FROM s LEFT JOIN t ON t.a = s.a
+//	)
+//	WHERE merge_clause IS NOT NULL
+//
+// The first primary key column of the target table is the canary column, so
+// the Upsert inserts the rows that did not match and updates the rows that
+// did. Rows that match a DELETE clause are passed through the Upsert with
+// their existing values, and are deleted afterwards by a post-query built by
+// mergeDeleteBuilder.
+func (b *Builder) buildMerge(merge *tree.Merge, inScope *scope) (outScope *scope) {
+	// Find which table we're working on, check the permissions. Existing rows
+	// are always read in order to match them with the source rows.
+	tab, depName, alias, refColumns := b.resolveTableForMutation(merge.Table, privilege.SELECT)
+
+	if tab.IsVirtualTable() {
+		panic(pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+			"cannot merge into view \"%s\"", tab.Name(),
+		))
+	}
+
+	if refColumns != nil {
+		panic(pgerror.Newf(pgcode.Syntax,
+			"cannot specify a list of column IDs with MERGE"))
+	}
+
+	if tab.TriggerCount() > 0 {
+		panic(unimplemented.Newf("merge triggers",
+			"MERGE is not supported on tables with triggers"))
+	}
+
+	// Check the privileges required by the WHEN clauses.
+	for _, when := range merge.Whens {
+		switch when.Action {
+		case tree.MergeActionInsert:
+			b.checkPrivilege(depName, tab, privilege.INSERT)
+		case tree.MergeActionUpdate:
+			b.checkPrivilege(depName, tab, privilege.UPDATE)
+		case tree.MergeActionDelete:
+			b.checkPrivilege(depName, tab, privilege.DELETE)
+		}
+	}
+
+	// Check if this table has already been mutated in another subquery.
+	b.checkMultipleMutations(tab, generalMutation)
+
+	// The ON condition and WHEN clauses should reject aggregates, generators,
+	// etc.
+	scalarProps := &b.semaCtx.Properties
+	defer scalarProps.Restore(*scalarProps)
+
+	var mb mutationBuilder
+	mb.init(b, "merge", tab, alias)
+
+	// Left-join the source rows to the target table:
+	//
+	//   SELECT <cols> FROM <source> LEFT JOIN <table> ON <on>
+	//
+	sourceScope := mb.buildInputForMerge(inScope, merge.Table, merge.Source, merge.On)
+
+	// Determine the WHEN clause that applies to each row, and remove the rows
+	// that are not modified.
+	b.semaCtx.Properties.Require("MERGE", tree.RejectSpecial)
+	clauseColID := mb.projectMergeClause(merge.Whens, sourceScope)
+	deleteColID := mb.projectMergeDeleteCol(merge.Whens, clauseColID)
+
+	// Build the INSERT and UPDATE SET expressions, along with any default and
+	// computed columns.
+	mb.addMergeCols(merge.Whens, sourceScope, clauseColID)
+
+	// Rows matched by a DELETE clause are deleted by a post-query that reads
+	// the buffered input of the Upsert.
+	if deleteColID != 0 {
+		mb.ensureWithID()
+		primaryIndex := tab.Index(cat.PrimaryIndex)
+		oldValues := make(opt.ColList, 0, primaryIndex.KeyColumnCount()+1)
+		for i, n := 0, primaryIndex.KeyColumnCount(); i < n; i++ {
+			oldValues = append(oldValues, mb.fetchColIDs[primaryIndex.Column(i).Ordinal()])
+		}
+		oldValues = append(oldValues, deleteColID)
+		mb.cascades = append(mb.cascades, memo.FKCascade{
+			Builder:   &mergeDeleteBuilder{mutatedTable: tab},
+			WithID:    mb.withID,
+			OldValues: oldValues,
+		})
+	}
+
+	// Build the final upsert statement.
+	mb.buildUpsert(nil /* returning */)
+
+	return mb.outScope
+}
+
+// buildInputForMerge left-joins the source of a MERGE statement to the target
+// table using the ON condition. The first primary key column of the target
+// table is recorded as the canary column, since it is null only for source rows
+// that do not match any target row. The returned scope contains only the
+// source columns, which are the only ones visible to WHEN NOT MATCHED clauses.
+func (mb *mutationBuilder) buildInputForMerge(
+	inScope *scope, texpr tree.TableExpr, source tree.TableExpr, on tree.Expr,
+) (sourceScope *scope) {
+	var indexFlags *tree.IndexFlags
+	if t, ok := texpr.(*tree.AliasedTableExpr); ok && t.IndexFlags != nil {
+		indexFlags = t.IndexFlags
+		telemetry.Inc(sqltelemetry.IndexHintUseCounter)
+	}
+
+	// NOTE: Include mutation columns, but be careful to never use them for any
+	//       reason other than as "fetch columns". See buildScan comment.
+	mb.fetchScope = mb.b.buildScan(
+		mb.b.addTable(mb.tab, &mb.alias),
+		tableOrdinals(mb.tab, columnKinds{
+			includeMutations: true,
+			includeSystem:    true,
+			includeInverted:  false,
+		}),
+		indexFlags,
+		noRowLocking,
+		inScope,
+		false, /* disableNotVisibleIndex */
+	)
+
+	// Set list of columns that will be fetched by the input expression.
+	mb.setFetchColIDs(mb.fetchScope.cols)
+
+	sourceScope = mb.b.buildFromTables(tree.TableExprs{source}, noLocking, inScope)
+
+	// Check that the same table name is not used for both the source and the
+	// target.
+	mb.b.validateJoinTableNames(sourceScope, mb.fetchScope)
+
+	// We create a new scope so that fetchScope is not modified. It will be
+	// used later to build partial index predicate expressions, and we do not
+	// want ambiguities with column names in the source.
+	mb.outScope = mb.fetchScope.replace()
+	mb.outScope.appendColumnsFromScope(sourceScope)
+	mb.outScope.appendColumnsFromScope(mb.fetchScope)
+
+	// Do not allow special functions in the ON clause.
+	mb.b.semaCtx.Properties.Require(
+		exprKindOn.String(),
+		tree.RejectGenerators|tree.RejectWindowApplications|tree.RejectProcedures,
+	)
+	mb.outScope.context = exprKindOn
+	filter := mb.b.buildScalar(
+		mb.outScope.resolveAndRequireType(on, types.Bool), mb.outScope, nil, nil, nil,
+	)
+	mb.outScope.context = exprKindNone
+
+	mb.outScope.expr = mb.b.factory.ConstructLeftJoin(
+		sourceScope.expr,
+		mb.fetchScope.expr,
+		memo.FiltersExpr{mb.b.factory.ConstructFiltersItem(filter)},
+		memo.EmptyJoinPrivate,
+	)
+
+	// Record a not-null "canary" column. After the left-join, this will be null
+	// if the source row did not match any target row.
+	mb.canaryColID = mb.fetchColIDs[mb.tab.Index(cat.PrimaryIndex).Column(0).Ordinal()]
+	return sourceScope
+}
+
+// projectMergeClause projects a column that contains the 1-based ordinal of
+// the WHEN clause that applies to each row of the MERGE input. Matched rows
+// are handled by the first WHEN MATCHED clause whose condition is true, and
+// source rows that did not match by the first such WHEN NOT MATCHED clause:
+//
+//	CASE WHEN canary IS NOT NULL THEN
+//	  CASE WHEN <cond1> THEN 1 WHEN <cond2> THEN 2 ... END
+//	ELSE
+//	  CASE WHEN <cond3> THEN 3 ... END
+//	END
+//
+// DO NOTHING clauses produce NULL, and rows with a NULL clause are filtered
+// out. An error is raised at runtime if a target row is matched by more than
+// one of the remaining rows. The ID of the new column is returned.
+func (mb *mutationBuilder) projectMergeClause(
+	whens tree.MergeWhens, sourceScope *scope,
+) opt.ColumnID {
+	f := mb.b.factory
+	var matched, notMatched memo.ScalarListExpr
+	for i, when := range whens {
+		// WHEN NOT MATCHED clauses can only reference the source columns.
+		inScope := mb.outScope
+		if !when.Matched {
+			inScope = sourceScope
+		}
+
+		var cond opt.ScalarExpr = memo.TrueSingleton
+		if when.Cond != nil {
+			texpr := inScope.resolveAndRequireType(when.Cond, types.Bool)
+			cond = mb.b.buildScalar(texpr, inScope, nil, nil, nil)
+		}
+
+		var val opt.ScalarExpr
+		if when.Action == tree.MergeActionDoNothing {
+			val = f.ConstructNull(types.Int)
+		} else {
+			val = f.ConstructConstVal(tree.NewDInt(tree.DInt(i+1)), types.Int)
+		}
+
+		if when.Matched {
+			matched = append(matched, f.ConstructWhen(cond, val))
+		} else {
+			notMatched = append(notMatched, f.ConstructWhen(cond, val))
+		}
+	}
+
+	buildCase := func(whens memo.ScalarListExpr) opt.ScalarExpr {
+		if len(whens) == 0 {
+			return f.ConstructNull(types.Int)
+		}
+		return f.ConstructCase(memo.TrueSingleton, whens, f.ConstructNull(types.Int))
+	}
+	clause := f.ConstructCase(
+		memo.TrueSingleton,
+		memo.ScalarListExpr{
+			f.ConstructWhen(
+				f.ConstructIsNot(f.ConstructVariable(mb.canaryColID), memo.NullSingleton),
+				buildCase(matched),
+			),
+		},
+		buildCase(notMatched),
+	)
+
+	projectionsScope := mb.outScope.replace()
+	projectionsScope.appendColumnsFromScope(mb.outScope)
+	clauseCol := mb.b.synthesizeColumn(
+		projectionsScope, scopeColName("").WithMetadataName("merge_clause"), types.Int, nil, clause,
+	)
+	mb.b.constructProjectForScope(mb.outScope, projectionsScope)
+	mb.outScope = projectionsScope
+
+	// Remove the rows that are not modified.
+	mb.outScope.expr = f.ConstructSelect(
+		mb.outScope.expr,
+		memo.FiltersExpr{f.ConstructFiltersItem(
+			f.ConstructIsNot(f.ConstructVariable(clauseCol.id), memo.NullSingleton),
+		)},
+	)
+
+	// Ensure that each target row is modified at most once. Source rows that
+	// did not match have NULL primary key values, and are treated as distinct.
+	var pkCols opt.ColSet
+	primaryIndex := mb.tab.Index(cat.PrimaryIndex)
+	for i, n := 0, primaryIndex.KeyColumnCount(); i < n; i++ {
+		pkCols.Add(mb.fetchColIDs[primaryIndex.Column(i).Ordinal()])
+	}
+	mb.outScope = mb.b.buildDistinctOn(
+		pkCols, mb.outScope, true /* nullsAreDistinct */, duplicateMergeErrText,
+	)
+	return clauseCol.id
+}
+
+// projectMergeDeleteCol projects a boolean column that is true for the rows
+// that match a DELETE clause. It returns the ID of the new column, or 0 if
+// there are no DELETE clauses.
+func (mb *mutationBuilder) projectMergeDeleteCol(
+	whens tree.MergeWhens, clauseColID opt.ColumnID,
+) opt.ColumnID {
+	f := mb.b.factory
+	var isDelete opt.ScalarExpr
+	for i, when := range whens {
+		if when.Action != tree.MergeActionDelete {
+			continue
+		}
+		eq := f.ConstructEq(
+			f.ConstructVariable(clauseColID),
+			f.ConstructConstVal(tree.NewDInt(tree.DInt(i+1)), types.Int),
+		)
+		if isDelete == nil {
+			isDelete = eq
+		} else {
+			isDelete = f.ConstructOr(isDelete, eq)
+		}
+	}
+	if isDelete == nil {
+		return 0
+	}
+
+	projectionsScope := mb.outScope.replace()
+	projectionsScope.appendColumnsFromScope(mb.outScope)
+	deleteCol := mb.b.synthesizeColumn(
+		projectionsScope, scopeColName("").WithMetadataName("merge_delete"), types.Bool, nil, isDelete,
+	)
+	mb.b.constructProjectForScope(mb.outScope, projectionsScope)
+	mb.outScope = projectionsScope
+	return deleteCol.id
+}
+
+// addMergeCols projects the insert and update columns of a MERGE statement,
+// along with any default and computed columns. Each column is a CASE
+// expression on the clause column that selects the value of the applicable
+// INSERT or UPDATE clause, and the fetched value otherwise.
+//
+// The Upsert operator requires insert values for every row, including the
+// rows that will be updated. Every non-computed column is therefore given an
+// insert value; for the matched rows, it is the fetched value, which
+// satisfies the NOT NULL constraints of the table. Columns that are not
+// targeted by an INSERT clause get their default value.
+func (mb *mutationBuilder) addMergeCols(
+	whens tree.MergeWhens, sourceScope *scope, clauseColID opt.ColumnID,
+) {
+	f := mb.b.factory
+	n := mb.tab.ColumnCount()
+	insertWhens := make([]memo.ScalarListExpr, n)
+	updateWhens := make([]memo.ScalarListExpr, n)
+
+	// Build the values of each clause before projecting any columns, so that
+	// column references in the clauses are not ambiguous.
+	for i, when := range whens {
+		clause := f.ConstructConstVal(tree.NewDInt(tree.DInt(i+1)), types.Int)
+		mb.targetColList = make(opt.ColList, 0, n)
+		mb.targetColSet = opt.ColSet{}
+
+		switch when.Action {
+		case tree.MergeActionInsert:
+			if len(when.Columns) != 0 {
+				mb.addTargetColsByName(when.Columns)
+			} else if when.Values != nil {
+				mb.addTargetTableColsForInsert(len(when.Values))
+			}
+			if when.Values != nil {
+				mb.checkNumCols(len(mb.targetColList), len(when.Values))
+			}
+
+			values := make(tree.Exprs, n)
+			for j, colID := range mb.targetColList {
+				values[mb.tabID.ColumnOrdinal(colID)] = when.Values[j]
+			}
+			for ord := 0; ord < n; ord++ {
+				if !isMergeInsertCol(mb.tab.Column(ord)) {
+					continue
+				}
+				expr, explicit := values[ord], true
+				if expr == nil {
+					expr, explicit = tree.DefaultVal{}, false
+				}
+				val := mb.buildMergeValue(expr, ord, sourceScope, explicit, false /* isUpdate */)
+				insertWhens[ord] = append(insertWhens[ord], f.ConstructWhen(clause, val))
+			}
+
+		case tree.MergeActionUpdate:
+			for _, set := range when.UpdateExprs {
+				mb.addTargetColsByName(set.Names)
+				exprs := tree.Exprs{set.Expr}
+				if set.Tuple {
+					t, ok := set.Expr.(*tree.Tuple)
+					if !ok {
+						panic(unimplemented.Newf("merge update subquery",
+							"source for a multiple-column MERGE UPDATE item must be a ROW() expression; "+
+								"not supported: %T", set.Expr))
+					}
+					if len(set.Names) != len(t.Exprs) {
+						panic(pgerror.Newf(pgcode.Syntax,
+							"number of columns (%d) does not match number of values (%d)",
+							len(set.Names), len(t.Exprs)))
+					}
+					exprs = t.Exprs
+				}
+				targetCols := mb.targetColList[len(mb.targetColList)-len(exprs):]
+				for j, expr := range exprs {
+					ord := mb.tabID.ColumnOrdinal(targetCols[j])
+					val := mb.buildMergeValue(expr, ord, mb.outScope, true /* explicit */, true /* isUpdate */)
+					updateWhens[ord] = append(updateWhens[ord], f.ConstructWhen(clause, val))
+				}
+			}
+		}
+	}
+	mb.targetColList = make(opt.ColList, 0, n)
+	mb.targetColSet = opt.ColSet{}
+
+	// project adds a column for each table column with the given values, and
+	// records its ID in colIDs.
+	project := func(colWhens []memo.ScalarListExpr, colIDs opt.OptionalColList, suffix string) {
+		projectionsScope := mb.outScope.replace()
+		projectionsScope.appendColumnsFromScope(mb.outScope)
+		for ord := 0; ord < n; ord++ {
+			if colWhens[ord] == nil {
+				continue
+			}
+			col := mb.tab.Column(ord)
+			var scalar opt.ScalarExpr = f.ConstructVariable(mb.fetchColIDs[ord])
+			if len(colWhens[ord]) > 0 {
+				scalar = f.ConstructCase(f.ConstructVariable(clauseColID), colWhens[ord], scalar)
+			}
+			colName := scopeColName(col.ColName()).WithMetadataName(string(col.ColName()) + suffix)
+			scopeCol := mb.b.synthesizeColumn(projectionsScope, colName, col.DatumType(), nil, scalar)
+			colIDs[ord] = scopeCol.id
+			colID := mb.tabID.ColumnID(ord)
+			mb.targetColList = append(mb.targetColList, colID)
+			mb.targetColSet.Add(colID)
+		}
+		mb.b.constructProjectForScope(mb.outScope, projectionsScope)
+		mb.outScope = projectionsScope
+	}
+
+	// Every non-computed column has an insert value.
+	for ord := 0; ord < n; ord++ {
+		if isMergeInsertCol(mb.tab.Column(ord)) && insertWhens[ord] == nil {
+			insertWhens[ord] = memo.ScalarListExpr{}
+		}
+	}
+	project(insertWhens, mb.insertColIDs, "_ins")
+
+	// Add the default and computed columns of inserted rows. The computed
+	// columns must be derived from the insert values rather than the fetched
+	// values, so the fetch columns are hidden while they are synthesized (see
+	// computedColumnScope).
+	fetchColIDs := make(opt.OptionalColList, n)
+	copy(fetchColIDs, mb.fetchColIDs)
+	for i := range mb.fetchColIDs {
+		mb.fetchColIDs[i] = 0
+	}
+	mb.addSynthesizedColsForInsert()
+	copy(mb.fetchColIDs, fetchColIDs)
+
+	// Set insertExpr. This expression is used when building uniqueness checks.
+	// See mutationBuilder.buildCheckInputScan.
+	mb.insertExpr = mb.outScope.expr
+
+	project(updateWhens, mb.updateColIDs, "_new")
+
+	// Add additional columns for computed expressions that may depend on any
+	// updated columns, as well as mutation columns with default values.
+	mb.addSynthesizedColsForUpdate()
+}
+
+// isMergeInsertCol returns true if the given column is given an explicit insert
+// value by MERGE, rather than a synthesized default or computed value.
+func isMergeInsertCol(col *cat.Column) bool {
+	return col.Kind() == cat.Ordinary && !col.IsComputed()
+}
+
+// buildMergeValue builds the value assigned to the table column with the given
+// ordinal by an INSERT or UPDATE clause of a MERGE statement, adding an
+// assignment cast if necessary. explicit is false if the value was not
+// specified by the user.
+func (mb *mutationBuilder) buildMergeValue(
+	expr tree.Expr, ord int, inScope *scope, explicit, isUpdate bool,
+) opt.ScalarExpr {
+	col := mb.tab.Column(ord)
+	if _, ok := expr.(tree.DefaultVal); ok {
+		expr = mb.parseDefaultExpr(mb.tabID.ColumnID(ord))
+	} else if explicit && col.IsGeneratedAlwaysAsIdentity() {
+		// GENERATED ALWAYS AS IDENTITY columns are not allowed to be explicitly
+		// written to.
+		if isUpdate {
+			panic(sqlerrors.NewGeneratedAlwaysAsIdentityColumnUpdateError(string(col.ColName())))
+		}
+		panic(sqlerrors.NewGeneratedAlwaysAsIdentityColumnOverrideError(string(col.ColName())))
+	}
+
+	targetType := col.DatumType()
+	texpr := inScope.resolveType(expr, targetType)
+	scalar := mb.b.buildScalar(texpr, inScope, nil, nil, nil)
+
+	// Check if an assignment cast is available from the value type to the
+	// column type.
+	if srcType := texpr.ResolvedType(); !srcType.Identical(targetType) {
+		if !cast.ValidCast(srcType, targetType, cast.ContextAssignment) {
+			panic(sqlerrors.NewInvalidAssignmentCastError(srcType, targetType, string(col.ColName())))
+		}
+		scalar = mb.b.factory.ConstructAssignmentCast(scalar, targetType)
+	}
+	return scalar
+}
+
+// mergeDeleteBuilder is a memo.CascadeBuilder implementation that deletes the
+// target rows matched by the DELETE clauses of a MERGE statement, equivalent
+// to a query like:
+//
+//	DELETE FROM t WHERE pk IN (SELECT pk FROM merge_input WHERE merge_delete)
+//
+// The input to the mutation is a semi-join of the table with the buffered
+// input of the MERGE statement. The oldValues passed to Build are the primary
+// key columns of the target table, followed by the merge_delete column.
+type mergeDeleteBuilder struct {
+	mutatedTable cat.Table
+}
+
+var _ memo.CascadeBuilder = &mergeDeleteBuilder{}
+
+// Build is part of the memo.CascadeBuilder interface.
+func (db *mergeDeleteBuilder) Build(
+	ctx context.Context,
+	semaCtx *tree.SemaContext,
+	evalCtx *eval.Context,
+	catalog cat.Catalog,
+	factoryI interface{},
+	binding opt.WithID,
+	bindingProps *props.Relational,
+	oldValues, newValues opt.ColList,
+) (_ memo.RelExpr, err error) {
+	return buildCascadeHelper(ctx, semaCtx, evalCtx, catalog, factoryI, func(b *Builder) memo.RelExpr {
+		opt.MaybeInjectOptimizerTestingPanic(ctx, evalCtx)
+
+		var mb mutationBuilder
+		mb.init(b, "delete", db.mutatedTable, tree.MakeUnqualifiedTableName(db.mutatedTable.Name()))
+
+		primaryIndex := db.mutatedTable.Index(cat.PrimaryIndex)
+		numKeyCols := primaryIndex.KeyColumnCount()
+		if len(oldValues) != numKeyCols+1 {
+			panic(errors.AssertionFailedf(
+				"expected %d oldValues columns, got %d", numKeyCols+1, len(oldValues),
+			))
+		}
+
+		// Scan the buffered input, keeping only the rows to delete.
+		md := b.factory.Metadata()
+		outCols := make(opt.ColList, len(oldValues))
+		for i := range outCols {
+			c := md.ColumnMeta(oldValues[i])
+			outCols[i] = md.AddColumn(c.Alias, c.Type)
+		}
+		md.AddWithBinding(binding, b.factory.ConstructFakeRel(&memo.FakeRelPrivate{
+			Props: bindingProps,
+		}))
+		input := b.factory.ConstructSelect(
+			b.factory.ConstructWithScan(&memo.WithScanPrivate{
+				With:    binding,
+				InCols:  oldValues,
+				OutCols: outCols,
+				ID:      md.NextUniqueID(),
+			}),
+			memo.FiltersExpr{b.factory.ConstructFiltersItem(
+				b.factory.ConstructVariable(outCols[numKeyCols]),
+			)},
+		)
+
+		// Build a semi join of the table with the rows to delete.
+		//
+		// The scope has one column for each public table column, making it
+		// appropriate to set it as mb.fetchScope.
+		mb.fetchScope = b.buildScan(
+			b.addTable(db.mutatedTable, &mb.alias),
+			tableOrdinals(db.mutatedTable, columnKinds{
+				includeMutations: false,
+				includeSystem:    false,
+				includeInverted:  false,
+			}),
+			nil, /* indexFlags */
+			noRowLocking,
+			b.allocScope(),
+			true, /* disableNotVisibleIndex */
+		)
+		on := make(memo.FiltersExpr, numKeyCols)
+		for i := range on {
+			col := mb.fetchScope.getColumnForTableOrdinal(primaryIndex.Column(i).Ordinal())
+			on[i] = b.factory.ConstructFiltersItem(b.factory.ConstructEq(
+				b.factory.ConstructVariable(col.id),
+				b.factory.ConstructVariable(outCols[i]),
+			))
+		}
+		mb.fetchScope.expr = b.factory.ConstructSemiJoin(
+			mb.fetchScope.expr, input, on, memo.EmptyJoinPrivate,
+		)
+		mb.outScope = mb.fetchScope
+
+		// Set list of columns that will be fetched by the input expression.
+		mb.setFetchColIDs(mb.outScope.cols)
+		mb.buildDelete(nil /* returning */)
+		return mb.outScope.expr
+	})
+}
+
+// String implements the fmt.Stringer interface.
+func (db *mergeDeleteBuilder) String() string {
+	return fmt.Sprintf("merge delete on %s", db.mutatedTable.Name())
+}
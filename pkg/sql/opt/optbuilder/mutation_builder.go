@@ -76,6 +76,11 @@ type mutationBuilder struct {
 	// targetColSet contains the same column IDs as targetColList, but as a set.
 	targetColSet opt.ColSet
 
+	// domainCheckedCols contains the IDs of columns which have already been
+	// checked against the constraints of their target domain types by
+	// addAssignmentCasts.
+	domainCheckedCols opt.ColSet
+
 	// insertColIDs lists the input column IDs providing values to insert. Its
 	// length is always equal to the number of columns in the target table,
 	// including mutation columns. Table columns which will not have values
@@ -1412,21 +1417,30 @@ func (mb *mutationBuilder) addAssignmentCasts(srcCols opt.OptionalColList) {
 		targetCol := mb.tab.Column(ord)
 		targetType := mb.tab.Column(ord).DatumType()
 
+		variable := mb.b.factory.ConstructVariable(colID)
+		scalar := opt.ScalarExpr(variable)
+
 		// An assignment cast is not necessary if the source and target types
 		// are identical.
-		if srcType.Identical(targetType) {
-			continue
-		}
+		if !srcType.Identical(targetType) {
+			// Check if an assignment cast is available from the inScope column
+			// type to the out type.
+			if !cast.ValidCast(srcType, targetType, cast.ContextAssignment) {
+				panic(sqlerrors.NewInvalidAssignmentCastError(srcType, targetType, string(targetCol.ColName())))
+			}
 
-		// Check if an assignment cast is available from the inScope column
-		// type to the out type.
-		if !cast.ValidCast(srcType, targetType, cast.ContextAssignment) {
-			panic(sqlerrors.NewInvalidAssignmentCastError(srcType, targetType, string(targetCol.ColName())))
+			// Create the cast expression.
+			scalar = mb.b.factory.ConstructAssignmentCast(variable, targetType)
 		}
 
-		// Create the cast expression.
-		variable := mb.b.factory.ConstructVariable(colID)
-		cast := mb.b.factory.ConstructAssignmentCast(variable, targetType)
+		// Enforce the constraints of a domain type, unless they have already
+		// been checked for this column.
+		if targetType.IsDomain() && !mb.domainCheckedCols.Contains(colID) {
+			scalar = mb.b.buildDomainConstraints(scalar, targetType)
+		}
+		if scalar == variable {
+			continue
+		}
 
 		// Lazily create the new scope.
 		if projectionScope == nil {
@@ -1441,7 +1455,10 @@ func (mb *mutationBuilder) addAssignmentCasts(srcCols opt.OptionalColList) {
 		// column, we perform a lookup with the ID and the name. See #61520.
 		scopeCol := projectionScope.getColumnWithIDAndReferenceName(colID, targetCol.ColName())
 		scopeCol.name = scopeCol.name.WithMetadataName(fmt.Sprintf("%s_cast", targetCol.ColName()))
-		mb.b.populateSynthesizedColumn(scopeCol, cast)
+		mb.b.populateSynthesizedColumn(scopeCol, scalar)
+		if targetType.IsDomain() {
+			mb.domainCheckedCols.Add(scopeCol.id)
+		}
 
 		// Replace old source column with the new one.
 		srcCols[ord] = scopeCol.id
@@ -1573,3 +1590,19 @@ func (mb *mutationBuilder) buildCheckInputScan(
 
 	return outScope, notNullOutCols
 }
+
+// constraintIsDeferred returns true if the check for the given deferrable
+// constraint has been deferred until the transaction commits, either because
+// it was declared INITIALLY DEFERRED or because of SET CONSTRAINTS. Deferred
+// checks are not planned with the mutation; they are built on their own when
+// the transaction commits instead (see BuildDeferredFKCheck).
+func (mb *mutationBuilder) constraintIsDeferred(
+	name string, deferrability tree.ConstraintDeferrability,
+) bool {
+	if deferrability == tree.ConstraintNotDeferrable {
+		return false
+	}
+	return mb.b.factory.Memo().IsConstraintDeferred(
+		name, deferrability == tree.ConstraintInitiallyDeferred,
+	)
+}
@@ -0,0 +1,148 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/errors"
+)
+
+// buildExclusionChecks builds check queries for the exclusion constraints of
+// the mutated table. Exclusion constraints are enforced like UNIQUE WITHOUT
+// INDEX constraints: by a query that runs after the mutation and returns the
+// conflicting rows, if any. The index backing the constraint, an inverted index
+// on its first range column prefixed by its columns compared with =, allows the
+// optimizer to plan the query as an inverted join rather than a full scan.
+//
+// If isUpdate is true, checks are only built for constraints with columns that
+// are updated.
+func (mb *mutationBuilder) buildExclusionChecks(isUpdate bool) {
+	if mb.tab.ExclusionCount() == 0 {
+		return
+	}
+
+	built := false
+	for i, n := 0, mb.tab.ExclusionCount(); i < n; i++ {
+		if isUpdate && !mb.exclusionColsUpdated(i) {
+			continue
+		}
+		// Under weaker isolation levels, the check query does not see rows
+		// written by concurrent transactions which have not committed yet, so it
+		// must lock the checked spans to prevent them from inserting conflicting
+		// rows. Predicate locks can only be taken by lookup joins on equality
+		// conditions, which cannot cover the && and -|- operators, so we reject
+		// the mutation instead.
+		if mb.b.evalCtx.TxnIsoLevel != isolation.Serializable {
+			panic(unimplemented.NewWithIssuef(46657,
+				"exclusion constraint %q cannot be checked under read committed or repeatable read isolation",
+				mb.tab.Exclusion(i).Name(),
+			))
+		}
+		mb.uniqueChecks = append(mb.uniqueChecks, mb.buildExclusionCheck(i))
+		built = true
+	}
+	if built {
+		// The insert fast path can only perform checks that are KV lookups.
+		mb.fastPathUniqueChecks = nil
+	}
+}
+
+// exclusionColsUpdated returns true if any of the columns of the given
+// exclusion constraint are being updated (according to updateColIDs).
+func (mb *mutationBuilder) exclusionColsUpdated(exclusionOrdinal int) bool {
+	ec := mb.tab.Exclusion(exclusionOrdinal)
+	for i, n := 0, ec.ColumnCount(); i < n; i++ {
+		if mb.updateColIDs[ec.ColumnOrdinal(i)] != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// buildExclusionCheck builds the check query for a single exclusion
+// constraint. The query joins the new values of the mutated rows with the rows
+// of the table, and returns every pair of distinct rows for which all of the
+// constraint's operators return true. For example, for the constraint
+// EXCLUDE USING gist (room WITH =, during WITH &&), the join filters are:
+//
+//	(new_room = existing_room) AND (new_during && existing_during) AND
+//	((new_pk1 != existing_pk1) OR (new_pk2 != existing_pk2) OR ...)
+//
+// The table is scanned after the mutation has been applied, so conflicts
+// between two of the new rows are also found.
+func (mb *mutationBuilder) buildExclusionCheck(exclusionOrdinal int) memo.UniqueChecksItem {
+	f := mb.b.factory
+	ec := mb.tab.Exclusion(exclusionOrdinal)
+
+	scanScope, scanOrdinals := mb.buildCheckTableScan()
+	newScope, _ := mb.buildCheckInputScan(checkInputScanNewVals, scanOrdinals, false /* isFK */)
+
+	joinFilters := make(memo.FiltersExpr, 0, ec.ColumnCount()+1)
+	newKeyCols := make(opt.ColList, 0, ec.ColumnCount()*2)
+	existingKeyCols := make(opt.ColList, 0, ec.ColumnCount())
+	for i, n := 0, ec.ColumnCount(); i < n; i++ {
+		ord := ec.ColumnOrdinal(i)
+		newCol := f.ConstructVariable(newScope.cols[ord].id)
+		existingCol := f.ConstructVariable(scanScope.cols[ord].id)
+		var cmp opt.ScalarExpr
+		switch ec.Operator(i) {
+		case treecmp.EQ:
+			cmp = f.ConstructEq(newCol, existingCol)
+		case treecmp.Overlaps:
+			cmp = f.ConstructOverlaps(newCol, existingCol)
+		case treecmp.Adjacent:
+			cmp = f.ConstructAdjacent(newCol, existingCol)
+		default:
+			panic(errors.AssertionFailedf(
+				"unsupported exclusion constraint operator %s", ec.Operator(i),
+			))
+		}
+		joinFilters = append(joinFilters, f.ConstructFiltersItem(cmp))
+		newKeyCols = append(newKeyCols, newScope.cols[ord].id)
+		existingKeyCols = append(existingKeyCols, scanScope.cols[ord].id)
+	}
+
+	// Prevent rows from conflicting with themselves:
+	//    (new_pk1 != existing_pk1) OR (new_pk2 != existing_pk2) OR ...
+	var pkFilter opt.ScalarExpr
+	primaryOrds := getIndexLaxKeyOrdinals(mb.tab.Index(cat.PrimaryIndex))
+	for i, ok := primaryOrds.Next(0); ok; i, ok = primaryOrds.Next(i + 1) {
+		pkFilterLocal := f.ConstructNe(
+			f.ConstructVariable(newScope.cols[i].id),
+			f.ConstructVariable(scanScope.cols[i].id),
+		)
+		if pkFilter == nil {
+			pkFilter = pkFilterLocal
+		} else {
+			pkFilter = f.ConstructOr(pkFilter, pkFilterLocal)
+		}
+	}
+	joinFilters = append(joinFilters, f.ConstructFiltersItem(pkFilter))
+
+	join := f.ConstructInnerJoin(newScope.expr, scanScope.expr, joinFilters, memo.EmptyJoinPrivate)
+
+	// The values of both rows are shown in the error message if there is a
+	// conflict.
+	keyCols := append(newKeyCols, existingKeyCols...)
+	project := f.ConstructProject(join, nil /* projections */, keyCols.ToSet())
+
+	return f.ConstructUniqueChecksItem(project, &memo.UniqueChecksItemPrivate{
+		Table:        mb.tabID,
+		CheckOrdinal: exclusionOrdinal,
+		KeyCols:      keyCols,
+		Exclusion:    true,
+	})
+}
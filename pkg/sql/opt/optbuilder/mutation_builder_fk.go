@@ -79,7 +79,7 @@ func (mb *mutationBuilder) buildFKChecksForInsert() {
 
 	h := &mb.fkCheckHelper
 	for i, n := 0, mb.tab.OutboundForeignKeyCount(); i < n; i++ {
-		if h.initWithOutboundFK(mb, i) {
+		if h.initWithOutboundFK(mb, i) && !h.isDeferred() {
 			mb.fkChecks = append(mb.fkChecks, h.buildInsertionCheck())
 		}
 	}
@@ -176,6 +176,9 @@ func (mb *mutationBuilder) buildFKChecksAndCascadesForDelete() {
 			})
 			continue
 		}
+		if h.fk.DeleteReferenceAction() == tree.NoAction && h.isDeferred() {
+			continue
+		}
 
 		withScanScope, _ := mb.buildCheckInputScan(checkInputScanFetchedVals, h.tabOrdinals, true /* isFK */)
 		mb.fkChecks = append(mb.fkChecks, h.buildDeletionCheck(withScanScope.expr, withScanScope.colList()))
@@ -279,7 +282,7 @@ func (mb *mutationBuilder) buildFKChecksForUpdate() {
 	for i, n := 0, mb.tab.OutboundForeignKeyCount(); i < n; i++ {
 		// Verify that at least one FK column is actually updated.
 		if mb.outboundFKColsUpdated(i) {
-			if h.initWithOutboundFK(mb, i) {
+			if h.initWithOutboundFK(mb, i) && !h.isDeferred() {
 				mb.fkChecks = append(mb.fkChecks, h.buildInsertionCheck())
 			}
 		}
@@ -323,6 +326,9 @@ func (mb *mutationBuilder) buildFKChecksForUpdate() {
 			})
 			continue
 		}
+		if h.fk.UpdateReferenceAction() == tree.NoAction && h.isDeferred() {
+			continue
+		}
 
 		// Construct an Except expression for the set difference between "old"
 		// FK values and "new" FK values.
@@ -399,7 +405,7 @@ func (mb *mutationBuilder) buildFKChecksForUpsert() {
 
 	h := &mb.fkCheckHelper
 	for i := 0; i < numOutbound; i++ {
-		if h.initWithOutboundFK(mb, i) {
+		if h.initWithOutboundFK(mb, i) && !h.isDeferred() {
 			mb.fkChecks = append(mb.fkChecks, h.buildInsertionCheck())
 		}
 	}
@@ -444,6 +450,9 @@ func (mb *mutationBuilder) buildFKChecksForUpsert() {
 			})
 			continue
 		}
+		if h.fk.UpdateReferenceAction() == tree.NoAction && h.isDeferred() {
+			continue
+		}
 
 		// Construct an Except expression for the set difference between "old" FK
 		// values and "new" FK values. See buildFKChecksForUpdate for more details.
@@ -617,6 +626,13 @@ func (h *fkCheckHelper) initWithInboundFK(mb *mutationBuilder, fkOrdinal int) (o
 	return true
 }
 
+// isDeferred returns true if the check for the FK constraint has been deferred
+// until the transaction commits. Only NO ACTION checks can be deferred; the
+// RESTRICT action is always checked immediately.
+func (h *fkCheckHelper) isDeferred() bool {
+	return h.mb.constraintIsDeferred(h.fk.Name(), h.fk.Deferrability())
+}
+
 // resolveTable resolves a table StableID. Returns nil if the table is in the
 // process of being added, in which case it is safe to ignore any FK
 // relation with the table.
@@ -58,6 +58,11 @@ func (mb *mutationBuilder) buildUniqueChecksForInsert() {
 		if mb.uniqueConstraintIsArbiter(i) {
 			continue
 		}
+		// If the check has been deferred until the transaction commits, we
+		// don't need to plan it.
+		if mb.uniqueConstraintIsDeferred(i) {
+			continue
+		}
 		if h.init(mb, i) {
 			uniqueChecksItem, fastPathUniqueChecksItem := h.buildInsertionCheck(buildFastPathCheck)
 			if fastPathUniqueChecksItem == nil {
@@ -97,6 +102,11 @@ func (mb *mutationBuilder) buildUniqueChecksForUpdate() {
 		if !mb.uniqueColsUpdated(i) {
 			continue
 		}
+		// If the check has been deferred until the transaction commits, we
+		// don't need to plan it.
+		if mb.uniqueConstraintIsDeferred(i) {
+			continue
+		}
 		if h.init(mb, i) {
 			// The insertion check works for updates too since it simply checks that
 			// the unique columns in the newly inserted or updated rows do not match
@@ -137,6 +147,11 @@ func (mb *mutationBuilder) buildUniqueChecksForUpsert() {
 		if mb.uniqueConstraintIsArbiter(i) && !mb.uniqueColsUpdated(i) {
 			continue
 		}
+		// If the check has been deferred until the transaction commits, we
+		// don't need to plan it.
+		if mb.uniqueConstraintIsDeferred(i) {
+			continue
+		}
 		if h.init(mb, i) {
 			// The insertion check works for upserts too since it simply checks that
 			// the unique columns in the newly inserted or updated rows do not match
@@ -196,6 +211,13 @@ func (mb *mutationBuilder) uniqueConstraintIsArbiter(uniqueOrdinal int) bool {
 	return mb.arbiters.ContainsUniqueConstraint(uniqueOrdinal)
 }
 
+// uniqueConstraintIsDeferred returns true if the check for the given unique
+// constraint has been deferred until the transaction commits.
+func (mb *mutationBuilder) uniqueConstraintIsDeferred(uniqueOrdinal int) bool {
+	uc := mb.tab.Unique(uniqueOrdinal)
+	return mb.constraintIsDeferred(uc.Name(), uc.Deferrability())
+}
+
 // uniqueCheckHelper is a type associated with a single unique constraint and
 // is used to build the "leaves" of a unique check expression, namely the
 // WithScan of the mutation input and the Scan of the table.
@@ -282,7 +304,7 @@ func (h *uniqueCheckHelper) init(mb *mutationBuilder, uniqueOrdinal int) bool {
 	// Build the scan that will serve as the right side of the semi join in the
 	// uniqueness check. We need to build the scan now so that we can use its
 	// FDs below.
-	h.scanScope, h.scanOrdinals = h.buildTableScan()
+	h.scanScope, h.scanOrdinals = h.mb.buildCheckTableScan()
 
 	// Check that the columns in the unique constraint aren't already known to
 	// form a lax key. This can happen if there is a unique index on a superset of
@@ -561,7 +583,7 @@ func (h *uniqueCheckHelper) buildInsertionCheck(
 	// an index which applies all filters. If no such scans are found, insert
 	// fast path cannot be applied.
 	if foundScan && len(scanFilters) != 0 {
-		newScanScope, _ := h.buildTableScan()
+		newScanScope, _ := h.mb.buildCheckTableScan()
 		newPossibleScan := newScanScope.expr
 		// Hash-sharded REGIONAL BY ROW tables may include a projection which can
 		// be skipped over to find the applicable Scan.
@@ -585,10 +607,11 @@ func (h *uniqueCheckHelper) buildInsertionCheck(
 	return uniqueChecks, &fastPathChecks
 }
 
-// buildTableScan builds a Scan of the table. The ordinals of the columns
-// scanned are also returned.
-func (h *uniqueCheckHelper) buildTableScan() (outScope *scope, ordinals []int) {
-	tabMeta := h.mb.b.addTable(h.mb.tab, tree.NewUnqualifiedTableName(h.mb.tab.Name()))
+// buildCheckTableScan builds a Scan of the mutated table that serves as the
+// right side of the join in a uniqueness or exclusion check. The ordinals of
+// the columns scanned are also returned.
+func (mb *mutationBuilder) buildCheckTableScan() (outScope *scope, ordinals []int) {
+	tabMeta := mb.b.addTable(mb.tab, tree.NewUnqualifiedTableName(mb.tab.Name()))
 	ordinals = tableOrdinals(tabMeta.Table, columnKinds{
 		includeMutations: false,
 		includeSystem:    false,
@@ -598,13 +621,13 @@ func (h *uniqueCheckHelper) buildTableScan() (outScope *scope, ordinals []int) {
 	// If we're using a weaker isolation level, we lock the checked predicate(s)
 	// to prevent concurrent inserts from other transactions from violating the
 	// unique constraint.
-	if h.mb.b.evalCtx.TxnIsoLevel != isolation.Serializable {
+	if mb.b.evalCtx.TxnIsoLevel != isolation.Serializable {
 		locking = lockingSpec{
 			&lockingItem{
 				item: &tree.LockingItem{
 					// TODO(michae2): Change this to ForKeyShare when it is supported.
 					Strength:   tree.ForShare,
-					Targets:    []tree.TableName{tree.MakeUnqualifiedTableName(h.mb.tab.Name())},
+					Targets:    []tree.TableName{tree.MakeUnqualifiedTableName(mb.tab.Name())},
 					WaitPolicy: tree.LockWaitBlock,
 					// Unique checks must ensure the non-existence of certain rows, so we
 					// use predicate locks instead of record locks to prevent insertion of
@@ -614,14 +637,14 @@ func (h *uniqueCheckHelper) buildTableScan() (outScope *scope, ordinals []int) {
 			},
 		}
 	}
-	return h.mb.b.buildScan(
+	return mb.b.buildScan(
 		tabMeta,
 		ordinals,
 		// After the update we can't guarantee that the constraints are unique
 		// (which is why we need the uniqueness checks in the first place).
 		&tree.IndexFlags{IgnoreUniqueWithoutIndexKeys: true},
 		locking,
-		h.mb.b.allocScope(),
+		mb.b.allocScope(),
 		true, /* disableNotVisibleIndex */
 	), ordinals
 }
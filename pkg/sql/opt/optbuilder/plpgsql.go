@@ -24,7 +24,10 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinsregistry"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/cast"
 	ast "github.com/cockroachdb/cockroach/pkg/sql/sem/plpgsqltree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/plpgsqltree/utils"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treebin"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
@@ -174,6 +177,15 @@ type plpgsqlBuilder struct {
 	// building their body statements.
 	outScope *scope
 
+	// params is the list of parameters for the routine. It is used to resolve
+	// aliases for parameters that are referenced with $n notation.
+	params []routineParam
+
+	// resultBuffer is shared between the routines that make up a set-returning
+	// function. It is used to add rows to the result set with RETURN NEXT and
+	// RETURN QUERY statements. It is nil if the routine is not set-returning.
+	resultBuffer *tree.RoutineResultBuffer
+
 	routineName  string
 	isProcedure  bool
 	identCounter int
@@ -192,7 +204,7 @@ func newPLpgSQLBuilder(
 	colRefs *opt.ColSet,
 	routineParams []routineParam,
 	returnType *types.T,
-	isProcedure bool,
+	isProcedure, isSetReturning bool,
 	outScope *scope,
 ) *plpgsqlBuilder {
 	const initialBlocksCap = 2
@@ -204,6 +216,10 @@ func newPLpgSQLBuilder(
 		routineName: routineName,
 		isProcedure: isProcedure,
 		outScope:    outScope,
+		params:      routineParams,
+	}
+	if isSetReturning {
+		b.resultBuffer = &tree.RoutineResultBuffer{}
 	}
 	// Build the initial block for the routine parameters, which are considered
 	// PL/pgSQL variables.
@@ -273,10 +289,10 @@ func (b *plpgsqlBuilder) buildRootBlock(
 	s = s.push()
 	b.ensureScopeHasExpr(s)
 
-	// Initialize OUT parameters to NULL. Note that the initial block for
-	// parameters was already created in newPLpgSQLBuilder().
+	// Initialize OUT parameters and TABLE columns to NULL. Note that the
+	// initial block for parameters was already created in newPLpgSQLBuilder().
 	for _, param := range routineParams {
-		if param.class != tree.RoutineParamOut || param.name == "" {
+		if !tree.IsOutOnlyParamClass(param.class) || param.name == "" {
 			continue
 		}
 		s = b.addPLpgSQLAssign(s, param.name, &tree.CastExpr{Expr: tree.DNull, Type: param.typ})
@@ -372,6 +388,11 @@ func (b *plpgsqlBuilder) buildBlock(astBlock *ast.Block, s *scope) *scope {
 			b.addVariable(dec.Name, types.RefCursor)
 			s = b.addPLpgSQLAssign(s, dec.Name, &tree.CastExpr{Expr: tree.DNull, Type: types.RefCursor})
 			block.cursors[dec.Name] = *dec
+		case *ast.AliasDeclaration:
+			// An alias is handled by replacing references to the alias with
+			// references to the aliased variable in the remainder of the block.
+			target := b.resolveAliasTarget(dec)
+			astBlock = b.replaceAlias(astBlock, i, dec.Var, target)
 		}
 	}
 	if b.returnType.Identical(types.AnyTuple) {
@@ -445,11 +466,19 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 			return b.buildBlock(t, s)
 
 		case *ast.Return:
-			// If the routine has OUT-parameters or a VOID return type, the RETURN
-			// statement must have no expression. Otherwise, the RETURN statement must
-			// have a non-empty expression.
+			// If the routine is set-returning, has OUT-parameters, or has a VOID
+			// return type, the RETURN statement must have no expression. Otherwise,
+			// the RETURN statement must have a non-empty expression.
 			expr := t.Expr
-			if b.hasOutParam() {
+			if b.resultBuffer != nil {
+				// RETURN in a set-returning routine only ends execution. The result
+				// set is made up of the rows added by RETURN NEXT and RETURN QUERY
+				// statements, so the returned value is ignored.
+				if expr != nil {
+					panic(returnWithSetofErr)
+				}
+				expr = &tree.CastExpr{Expr: tree.DNull, Type: b.returnType}
+			} else if b.hasOutParam() {
 				if expr != nil {
 					panic(returnWithOUTParameterErr)
 				}
@@ -477,6 +506,50 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 			b.ob.constructProjectForScope(s, returnScope)
 			return returnScope
 
+		case *ast.ReturnNext:
+			// RETURN NEXT adds a row to the result set of a set-returning routine,
+			// and then continues execution. This is handled by building the row into
+			// the first body statement of a continuation routine that has access to
+			// the result buffer of the set-returning routine. The remaining PL/pgSQL
+			// statements become the last body statement, as with RAISE.
+			if b.resultBuffer == nil {
+				panic(returnNextNonSetofErr)
+			}
+			expr := t.Expr
+			if b.hasOutParam() {
+				if expr != nil {
+					panic(returnNextWithOUTParameterErr)
+				}
+				expr = b.makeReturnForOutParams()
+			} else if expr == nil {
+				panic(emptyReturnNextErr)
+			}
+			con := b.makeContinuation("_stmt_return_next")
+			con.def.Volatility = volatility.Volatile
+			con.def.ResultBuffer = b.resultBuffer
+			nextScalar := b.buildPLpgSQLExpr(expr, b.returnType, con.s)
+			nextColName := scopeColName("").WithMetadataName(b.makeIdentifier("stmt_return_next"))
+			nextScope := con.s.push()
+			b.ob.synthesizeColumn(nextScope, nextColName, b.returnType, nil /* expr */, nextScalar)
+			b.ob.constructProjectForScope(con.s, nextScope)
+			b.appendBodyStmt(&con, nextScope)
+			b.appendPlpgSQLStmts(&con, stmts[i+1:])
+			return b.callContinuation(&con, s)
+
+		case *ast.ReturnQuery:
+			// RETURN QUERY adds the rows of a query to the result set of a
+			// set-returning routine. It is handled the same way as RETURN NEXT,
+			// except that the first body statement can produce any number of rows.
+			if b.resultBuffer == nil {
+				panic(returnQueryNonSetofErr)
+			}
+			con := b.makeContinuation("_stmt_return_query")
+			con.def.Volatility = volatility.Volatile
+			con.def.ResultBuffer = b.resultBuffer
+			b.appendBodyStmt(&con, b.buildReturnQuery(con.s, t.Query))
+			b.appendPlpgSQLStmts(&con, stmts[i+1:])
+			return b.callContinuation(&con, s)
+
 		case *ast.Assignment:
 			// Assignment (:=) is handled by projecting a new column with the same
 			// name as the variable being assigned.
@@ -605,6 +678,24 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 			}
 			return b.buildPLpgSQLStatements(b.prependStmt(loop, stmts[i+1:]), s)
 
+		case *ast.ForInt:
+			// An integer FOR loop is rewritten into a LOOP within a new block that
+			// tracks the loop bounds. See rewriteForInt for details.
+			block := b.rewriteForInt(t)
+			return b.buildPLpgSQLStatements(b.prependStmt(block, stmts[i+1:]), s)
+
+		case *ast.ForEachArray:
+			// A FOREACH loop is rewritten into a LOOP within a new block that tracks
+			// the array and the current index. See rewriteForEachArray for details.
+			block := b.rewriteForEachArray(s, t)
+			return b.buildPLpgSQLStatements(b.prependStmt(block, stmts[i+1:]), s)
+
+		case *ast.ForSelect:
+			// A FOR loop over the rows of a query is rewritten into a LOOP that
+			// fetches from a cursor. See rewriteForSelect for details.
+			block := b.rewriteForSelect(t)
+			return b.buildPLpgSQLStatements(b.prependStmt(block, stmts[i+1:]), s)
+
 		case *ast.Exit:
 			if t.Condition != nil {
 				// EXIT with a condition is syntactic sugar for EXIT inside an IF stmt.
@@ -775,6 +866,18 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 			if t.Scroll == tree.Scroll {
 				panic(scrollableCursorErr)
 			}
+			if t.Query == nil {
+				if decl, ok := b.lookupBoundCursor(t.CurVar); ok && (len(decl.Args) > 0 || len(t.Args) > 0) {
+					// The cursor arguments are handled by declaring them as variables
+					// in a new block that surrounds the OPEN statement. See
+					// rewriteOpenWithArgs for details.
+					block := b.rewriteOpenWithArgs(t, decl)
+					return b.buildPLpgSQLStatements(b.prependStmt(block, stmts[i+1:]), s)
+				}
+			}
+			if len(t.Args) > 0 {
+				panic(pgerror.Newf(pgcode.Syntax, "cursor \"%s\" has no arguments", t.CurVar))
+			}
 			openCon := b.makeContinuation("_stmt_open")
 			openCon.def.Volatility = volatility.Volatile
 			_, source, _, err := openCon.s.FindSourceProvidingColumn(b.ob.ctx, t.CurVar)
@@ -908,6 +1011,12 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 			// PL/pgSQL NULL statements are a no-op.
 			continue
 
+		case *ast.Perform:
+			// PERFORM executes a SELECT statement and discards the result, so it is
+			// equivalent to executing the query without an INTO target.
+			execStmt := &ast.Execute{SqlStmt: t.Query}
+			return b.buildPLpgSQLStatements(b.prependStmt(execStmt, stmts[i+1:]), s)
+
 		case *ast.TransactionControl:
 			// Transaction control statements are handled by a TxnControlExpr, which
 			// wraps a continuation for the remaining statements in the routine.
@@ -1033,21 +1142,15 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 // resolveOpenQuery finds and validates the query that is bound to cursor for
 // the given OPEN statement.
 func (b *plpgsqlBuilder) resolveOpenQuery(open *ast.Open) tree.Statement {
-	// Search the blocks in reverse order to ensure that more recent declarations
-	// are encountered first.
 	var boundStmt tree.Statement
-	for i := len(b.blocks) - 1; i >= 0; i-- {
-		block := &b.blocks[i]
-		for name := range block.cursors {
-			if open.CurVar == name {
-				boundStmt = block.cursors[name].Query
-				break
-			}
-		}
+	if decl, ok := b.lookupBoundCursor(open.CurVar); ok {
+		boundStmt = decl.Query
 	}
 	stmt := open.Query
-	if stmt != nil && boundStmt != nil {
-		// A bound cursor cannot be opened with "OPEN FOR" syntax.
+	if stmt != nil && boundStmt != nil && stmt != boundStmt {
+		// A bound cursor cannot be opened with "OPEN FOR" syntax. Note that the
+		// query is identical to the bound query if the OPEN statement was
+		// rewritten to supply cursor arguments (see rewriteOpenWithArgs).
 		panic(errors.WithHintf(
 			pgerror.New(pgcode.Syntax, "syntax error at or near \"FOR\""),
 			"cannot specify a query during OPEN for bound cursor \"%s\"", open.CurVar,
@@ -1073,6 +1176,420 @@ func (b *plpgsqlBuilder) resolveOpenQuery(open *ast.Open) tree.Statement {
 	return stmt
 }
 
+// lookupBoundCursor returns the declaration of the bound cursor with the given
+// name, if one is in scope.
+func (b *plpgsqlBuilder) lookupBoundCursor(name ast.Variable) (ast.CursorDeclaration, bool) {
+	// Search the blocks in reverse order to ensure that more recent declarations
+	// are encountered first.
+	for i := len(b.blocks) - 1; i >= 0; i-- {
+		if decl, ok := b.blocks[i].cursors[name]; ok {
+			return decl, true
+		}
+	}
+	return ast.CursorDeclaration{}, false
+}
+
+// rewriteOpenWithArgs handles an OPEN statement for a bound cursor that takes
+// arguments. The arguments are visible to the cursor query as variables, so
+// the OPEN statement is rewritten into a block that declares the arguments:
+//
+//	DECLARE
+//	  c CURSOR (x INT, y INT) FOR SELECT x + y;
+//	BEGIN
+//	  OPEN c (1, 2);
+//	  =>
+//	  DECLARE
+//	    x INT := 1;
+//	    y INT := 2;
+//	  BEGIN
+//	    OPEN c FOR SELECT x + y;
+//	  END;
+//	END
+func (b *plpgsqlBuilder) rewriteOpenWithArgs(open *ast.Open, decl ast.CursorDeclaration) *ast.Block {
+	switch {
+	case len(decl.Args) == 0:
+		panic(pgerror.Newf(pgcode.Syntax, "cursor \"%s\" has no arguments", open.CurVar))
+	case len(open.Args) == 0:
+		panic(pgerror.Newf(pgcode.Syntax, "cursor \"%s\" has arguments", open.CurVar))
+	case len(open.Args) < len(decl.Args):
+		panic(pgerror.Newf(pgcode.Syntax, "not enough arguments for cursor \"%s\"", open.CurVar))
+	case len(open.Args) > len(decl.Args):
+		panic(pgerror.Newf(pgcode.Syntax, "too many arguments for cursor \"%s\"", open.CurVar))
+	}
+	decls := make([]ast.Statement, len(decl.Args))
+	for i := range decl.Args {
+		decls[i] = &ast.Declaration{
+			Var:  decl.Args[i].Name,
+			Typ:  decl.Args[i].Typ,
+			Expr: open.Args[i],
+		}
+	}
+	return &ast.Block{
+		Decls: decls,
+		Body: []ast.Statement{&ast.Open{
+			CurVar: open.CurVar,
+			Scroll: open.Scroll,
+			Query:  decl.Query,
+		}},
+	}
+}
+
+// rewriteForInt rewrites an integer FOR loop into a LOOP within a new block.
+// The block declares variables that track the next value of the loop variable,
+// the upper bound, and the step, all of which are evaluated once before the
+// loop begins:
+//
+//	FOR i IN [REVERSE] lower..upper BY step LOOP
+//	  [body];
+//	END LOOP;
+//	=>
+//	DECLARE
+//	  i INT;
+//	  next INT := lower;
+//	  bound INT := upper;
+//	  step INT := step;
+//	BEGIN
+//	  IF next IS NULL THEN RAISE ...; END IF;
+//	  IF bound IS NULL THEN RAISE ...; END IF;
+//	  IF step IS NULL THEN RAISE ...; END IF;
+//	  IF step <= 0 THEN RAISE ...; END IF;
+//	  LOOP
+//	    IF next > bound THEN EXIT; END IF; -- next < bound for REVERSE
+//	    i := next;
+//	    next := next + step;               -- next - step for REVERSE
+//	    [body];
+//	  END LOOP;
+//	END;
+//
+// Postgres implicitly declares the loop variable within the scope of the loop,
+// shadowing any existing variable with the same name. Since variable shadowing
+// is not yet supported (#117508), an existing variable with the same name is
+// used as the loop variable instead.
+func (b *plpgsqlBuilder) rewriteForInt(loop *ast.ForInt) *ast.Block {
+	nextVar := ast.Variable(b.makeIdentifier("_for_next"))
+	boundVar := ast.Variable(b.makeIdentifier("_for_bound"))
+	stepVar := ast.Variable(b.makeIdentifier("_for_step"))
+	var step ast.Expr = tree.NewDInt(1)
+	if loop.Step != nil {
+		step = loop.Step
+	}
+	var decls []ast.Statement
+	if _, ok := b.lookupVariableType(loop.Target); !ok {
+		decls = append(decls, &ast.Declaration{Var: loop.Target, Typ: types.Int})
+	}
+	decls = append(decls,
+		&ast.Declaration{Var: nextVar, Typ: types.Int, Expr: loop.Lower},
+		&ast.Declaration{Var: boundVar, Typ: types.Int, Expr: loop.Upper},
+		&ast.Declaration{Var: stepVar, Typ: types.Int, Expr: step},
+	)
+	body := []ast.Statement{
+		makeRaiseIf(&tree.IsNullExpr{Expr: makeVarRef(nextVar)},
+			pgcode.NullValueNotAllowed, "lower bound of FOR loop cannot be null",
+		),
+		makeRaiseIf(&tree.IsNullExpr{Expr: makeVarRef(boundVar)},
+			pgcode.NullValueNotAllowed, "upper bound of FOR loop cannot be null",
+		),
+	}
+	if loop.Step != nil {
+		body = append(body,
+			makeRaiseIf(&tree.IsNullExpr{Expr: makeVarRef(stepVar)},
+				pgcode.NullValueNotAllowed, "BY value of FOR loop cannot be null",
+			),
+			makeRaiseIf(
+				&tree.ComparisonExpr{
+					Operator: treecmp.MakeComparisonOperator(treecmp.LE),
+					Left:     makeVarRef(stepVar),
+					Right:    tree.NewDInt(0),
+				},
+				pgcode.InvalidParameterValue, "BY value of FOR loop must be greater than zero",
+			),
+		)
+	}
+	exitCmp, stepOp := treecmp.GT, treebin.Plus
+	if loop.Reverse {
+		exitCmp, stepOp = treecmp.LT, treebin.Minus
+	}
+	loopBody := []ast.Statement{
+		&ast.If{
+			Condition: &tree.ComparisonExpr{
+				Operator: treecmp.MakeComparisonOperator(exitCmp),
+				Left:     makeVarRef(nextVar),
+				Right:    makeVarRef(boundVar),
+			},
+			ThenBody: []ast.Statement{&ast.Exit{}},
+		},
+		&ast.Assignment{Var: loop.Target, Value: makeVarRef(nextVar)},
+		&ast.Assignment{Var: nextVar, Value: &tree.BinaryExpr{
+			Operator: treebin.MakeBinaryOperator(stepOp),
+			Left:     makeVarRef(nextVar),
+			Right:    makeVarRef(stepVar),
+		}},
+	}
+	loopBody = append(loopBody, loop.Body...)
+	body = append(body, &ast.Loop{Label: loop.Label, Body: loopBody})
+	return &ast.Block{Decls: decls, Body: body}
+}
+
+// rewriteForEachArray rewrites a FOREACH loop into a LOOP within a new block.
+// The block declares variables that track the array and the index of the
+// current element:
+//
+//	FOREACH x IN ARRAY arr LOOP
+//	  [body];
+//	END LOOP;
+//	=>
+//	DECLARE
+//	  a <arr type> := arr;
+//	  idx INT := 0;
+//	BEGIN
+//	  IF a IS NULL THEN RAISE ...; END IF;
+//	  LOOP
+//	    idx := idx + 1;
+//	    IF idx > cardinality(a) THEN EXIT; END IF;
+//	    x := a[idx];
+//	    [body];
+//	  END LOOP;
+//	END;
+//
+// If there are multiple target variables, each element must be a tuple, and the
+// tuple elements are assigned to the target variables in order.
+func (b *plpgsqlBuilder) rewriteForEachArray(s *scope, loop *ast.ForEachArray) *ast.Block {
+	if loop.Slice != 0 {
+		panic(forEachSliceErr)
+	}
+	b.checkDuplicateTargets(loop.Target, "FOREACH")
+	expr, _ := tree.WalkExpr(s, loop.Expr)
+	typedExpr, err := expr.TypeCheck(b.ob.ctx, b.ob.semaCtx, types.AnyArray)
+	if err != nil {
+		panic(err)
+	}
+	arrTyp := typedExpr.ResolvedType()
+	if arrTyp.Family() != types.ArrayFamily {
+		panic(pgerror.Newf(pgcode.DatatypeMismatch,
+			"FOREACH expression must yield an array, not type %s", arrTyp.SQLStringForError(),
+		))
+	}
+	arrVar := ast.Variable(b.makeIdentifier("_foreach_arr"))
+	idxVar := ast.Variable(b.makeIdentifier("_foreach_idx"))
+	decls := []ast.Statement{
+		&ast.Declaration{Var: arrVar, Typ: arrTyp, Expr: loop.Expr},
+		&ast.Declaration{Var: idxVar, Typ: types.Int, Expr: tree.NewDInt(0)},
+	}
+	elem := &tree.IndirectionExpr{
+		Expr:        makeVarRef(arrVar),
+		Indirection: tree.ArraySubscripts{{Begin: makeVarRef(idxVar)}},
+	}
+	loopBody := []ast.Statement{
+		&ast.Assignment{Var: idxVar, Value: &tree.BinaryExpr{
+			Operator: treebin.MakeBinaryOperator(treebin.Plus),
+			Left:     makeVarRef(idxVar),
+			Right:    tree.NewDInt(1),
+		}},
+		&ast.If{
+			Condition: &tree.ComparisonExpr{
+				Operator: treecmp.MakeComparisonOperator(treecmp.GT),
+				Left:     makeVarRef(idxVar),
+				Right: &tree.FuncExpr{
+					Func:  tree.WrapFunction("cardinality"),
+					Exprs: tree.Exprs{makeVarRef(arrVar)},
+				},
+			},
+			ThenBody: []ast.Statement{&ast.Exit{}},
+		},
+	}
+	if len(loop.Target) == 1 {
+		loopBody = append(loopBody, &ast.Assignment{Var: loop.Target[0], Value: elem})
+	} else {
+		elemTyp := arrTyp.ArrayContents()
+		if elemTyp.Family() != types.TupleFamily {
+			panic(pgerror.New(pgcode.DatatypeMismatch,
+				"cannot assign non-composite value to a row variable",
+			))
+		}
+		for j := range loop.Target {
+			// If there are fewer tuple elements than target variables, NULL is
+			// assigned to the remaining targets.
+			var val ast.Expr = tree.DNull
+			if j < len(elemTyp.TupleContents()) {
+				val = &tree.ColumnAccessExpr{Expr: &tree.ParenExpr{Expr: elem}, ByIndex: true, ColIndex: j}
+			}
+			loopBody = append(loopBody, &ast.Assignment{Var: loop.Target[j], Value: val})
+		}
+	}
+	loopBody = append(loopBody, loop.Body...)
+	body := []ast.Statement{
+		makeRaiseIf(&tree.IsNullExpr{Expr: makeVarRef(arrVar)},
+			pgcode.NullValueNotAllowed, "FOREACH expression must not be null",
+		),
+		&ast.Loop{Label: loop.Label, Body: loopBody},
+	}
+	return &ast.Block{Decls: decls, Body: body}
+}
+
+// rewriteForSelect rewrites a FOR loop over the rows of a query into a LOOP
+// that fetches from a cursor, within a new block that declares the cursor:
+//
+//	FOR x, y IN SELECT a, b FROM xy LOOP
+//	  [body];
+//	END LOOP;
+//	=>
+//	DECLARE
+//	  cur REFCURSOR;
+//	  found BOOL;
+//	  elem1 <x type>;
+//	  elem2 <y type>;
+//	BEGIN
+//	  OPEN cur FOR SELECT true, * FROM (SELECT a, b FROM xy) WITH ORDINALITY AS q
+//	    ORDER BY ordinality;
+//	  LOOP
+//	    FETCH cur INTO found, elem1, elem2;
+//	    IF found IS NULL THEN EXIT; END IF;
+//	    x := elem1;
+//	    y := elem2;
+//	    [body];
+//	  END LOOP;
+//	  CLOSE cur;
+//	END;
+//
+// The leading TRUE column distinguishes a fetched row from the NULLs that
+// FETCH assigns once the cursor is exhausted, and WITH ORDINALITY preserves the
+// ordering of the query. Note that the cursor is not closed if control leaves
+// the loop through a RETURN statement or an EXIT to an enclosing label; in that
+// case, it is closed when the transaction ends.
+func (b *plpgsqlBuilder) rewriteForSelect(loop *ast.ForSelect) *ast.Block {
+	sel, ok := loop.Query.(*tree.Select)
+	if !ok {
+		panic(unimplemented.Newf("for loop query",
+			"FOR loop over a %s statement is not yet supported", loop.Query.StatementTag(),
+		))
+	}
+	b.checkDuplicateTargets(loop.Target, "FOR")
+	curVar := ast.Variable(b.makeIdentifier("_for_cursor"))
+	foundVar := ast.Variable(b.makeIdentifier("_for_found"))
+	decls := []ast.Statement{
+		&ast.Declaration{Var: curVar, Typ: types.RefCursor},
+		&ast.Declaration{Var: foundVar, Typ: types.Bool},
+	}
+	// Each row is fetched into hidden variables, which are only assigned to the
+	// target once it is known that a row was found. This ensures that the target
+	// retains the values of the last row after the loop.
+	fetchTarget := []ast.Variable{foundVar}
+	declareElem := func(typ *types.T) ast.Expr {
+		elemVar := ast.Variable(b.makeIdentifier("_for_elem"))
+		decls = append(decls, &ast.Declaration{Var: elemVar, Typ: typ})
+		fetchTarget = append(fetchTarget, elemVar)
+		return makeVarRef(elemVar)
+	}
+	var assignments []ast.Statement
+	if b.targetIsRecordVar(loop.Target) {
+		// For a single composite-type variable, the query columns are assigned as
+		// elements of the variable.
+		typ := b.resolveVariableForAssign(loop.Target[0])
+		elems := make(tree.Exprs, len(typ.TupleContents()))
+		for j, elemTyp := range typ.TupleContents() {
+			elems[j] = declareElem(elemTyp)
+		}
+		assignments = append(assignments, &ast.Assignment{
+			Var:   loop.Target[0],
+			Value: &tree.CastExpr{Expr: &tree.Tuple{Exprs: elems}, Type: typ},
+		})
+	} else {
+		for _, target := range loop.Target {
+			elem := declareElem(b.resolveVariableForAssign(target))
+			assignments = append(assignments, &ast.Assignment{Var: target, Value: elem})
+		}
+	}
+	query := &tree.Select{
+		Select: &tree.SelectClause{
+			Exprs: tree.SelectExprs{{Expr: tree.DBoolTrue}, tree.StarSelectExpr()},
+			From: tree.From{Tables: tree.TableExprs{&tree.AliasedTableExpr{
+				Expr:       &tree.Subquery{Select: &tree.ParenSelect{Select: sel}},
+				Ordinality: true,
+				As:         tree.AliasClause{Alias: tree.Name(b.makeIdentifier("_for_query"))},
+			}}},
+		},
+		OrderBy: tree.OrderBy{{Expr: tree.NewUnresolvedName("ordinality")}},
+	}
+	loopBody := []ast.Statement{
+		&ast.Fetch{
+			Cursor: tree.CursorStmt{Name: tree.Name(curVar), Count: 1},
+			Target: fetchTarget,
+		},
+		&ast.If{
+			Condition: &tree.IsNullExpr{Expr: makeVarRef(foundVar)},
+			ThenBody:  []ast.Statement{&ast.Exit{}},
+		},
+	}
+	loopBody = append(loopBody, assignments...)
+	loopBody = append(loopBody, loop.Body...)
+	body := []ast.Statement{
+		&ast.Open{CurVar: curVar, Query: query},
+		&ast.Loop{Label: loop.Label, Body: loopBody},
+		&ast.Close{CurVar: curVar},
+	}
+	return &ast.Block{Decls: decls, Body: body}
+}
+
+// resolveAliasTarget returns the name of the variable or parameter that is
+// aliased by the given ALIAS declaration.
+func (b *plpgsqlBuilder) resolveAliasTarget(alias *ast.AliasDeclaration) ast.Variable {
+	if alias.TargetParam != 0 {
+		if alias.TargetParam > len(b.params) {
+			panic(pgerror.Newf(pgcode.UndefinedObject,
+				"variable \"$%d\" does not exist", alias.TargetParam,
+			))
+		}
+		name := b.params[alias.TargetParam-1].name
+		if name == "" {
+			// TODO(119502): unnamed parameters can only be accessed via $i
+			// notation.
+			panic(unimplemented.NewWithIssue(119502, "alias for an unnamed parameter"))
+		}
+		return name
+	}
+	if _, ok := b.lookupVariableType(alias.Target); !ok {
+		panic(pgerror.Newf(pgcode.UndefinedObject, "variable \"%s\" does not exist", alias.Target))
+	}
+	return alias.Target
+}
+
+// replaceAlias returns a copy of the given block in which references to the
+// alias are replaced with references to the target variable. Only the
+// declarations following the alias declaration (at index declIdx) are
+// affected, as well as the body and exception handlers of the block.
+func (b *plpgsqlBuilder) replaceAlias(
+	block *ast.Block, declIdx int, alias, target ast.Variable,
+) *ast.Block {
+	rest := &ast.Block{
+		Decls:      block.Decls[declIdx+1:],
+		Body:       block.Body,
+		Exceptions: block.Exceptions,
+	}
+	v := aliasVisitor{alias: alias, target: target}
+	newRest := ast.Walk(&v, rest)
+	exprVisitor := utils.SQLStmtVisitor{Fn: v.replaceInExpr}
+	newRest = ast.Walk(&exprVisitor, newRest)
+	if exprVisitor.Err != nil {
+		panic(exprVisitor.Err)
+	}
+	newBlock := block.CopyNode()
+	newBlock.Decls = append(newBlock.Decls[:declIdx+1], newRest.(*ast.Block).Decls...)
+	newBlock.Body = newRest.(*ast.Block).Body
+	newBlock.Exceptions = newRest.(*ast.Block).Exceptions
+	return newBlock
+}
+
+// lookupVariableType returns the type of the variable with the given name, if
+// the variable is in scope.
+func (b *plpgsqlBuilder) lookupVariableType(name ast.Variable) (*types.T, bool) {
+	for i := len(b.blocks) - 1; i >= 0; i-- {
+		if typ, ok := b.blocks[i].varTypes[name]; ok {
+			return typ, true
+		}
+	}
+	return nil, false
+}
+
 // buildCursorNameGen builds a statement that generates a unique name for the
 // cursor if the variable containing the name is unset. The unique name
 // generation is implemented by the crdb_internal.plpgsql_gen_cursor_name
@@ -1175,6 +1692,43 @@ func (b *plpgsqlBuilder) buildInto(stmtScope *scope, target []ast.Variable) *sco
 	return intoScope
 }
 
+// buildReturnQuery builds the query of a RETURN QUERY statement, and projects
+// its columns as a single column with the return type of the routine.
+func (b *plpgsqlBuilder) buildReturnQuery(s *scope, query tree.Statement) *scope {
+	queryScope := b.ob.buildStmtAtRootWithScope(query, nil /* desiredTypes */, s)
+	elems := make(memo.ScalarListExpr, 0, len(queryScope.cols))
+	for i := range queryScope.cols {
+		if queryScope.cols[i].visibility == visible {
+			elems = append(elems, b.ob.factory.ConstructVariable(queryScope.cols[i].id))
+		}
+	}
+	var scalar opt.ScalarExpr
+	if b.returnType.Family() == types.TupleFamily {
+		// Each query column becomes an element of the returned tuple.
+		contents := b.returnType.TupleContents()
+		if len(elems) != len(contents) {
+			panic(returnQueryStructureErr)
+		}
+		for j := range elems {
+			elems[j] = b.coerceType(elems[j], contents[j])
+		}
+		scalar = b.ob.factory.ConstructTuple(elems, b.returnType)
+	} else {
+		if len(elems) != 1 {
+			panic(returnQueryStructureErr)
+		}
+		scalar = b.coerceType(elems[0], b.returnType)
+	}
+	returnColName := scopeColName("").WithMetadataName(b.makeIdentifier("stmt_return_query"))
+	returnScope := queryScope.push()
+	b.ob.synthesizeColumn(returnScope, returnColName, b.returnType, nil /* expr */, scalar)
+	// Preserve the ordering of the query, so that rows are added to the result
+	// set in order.
+	returnScope.copyOrdering(queryScope)
+	b.ob.constructProjectForScope(queryScope, returnScope)
+	return returnScope
+}
+
 // buildPLpgSQLRaise builds a Project expression which implements the
 // notice-sending behavior of RAISE statements.
 func (b *plpgsqlBuilder) buildPLpgSQLRaise(inScope *scope, args memo.ScalarListExpr) *scope {
@@ -1486,11 +2040,12 @@ func (b *plpgsqlBuilder) buildExceptions(block *ast.Block) *memo.ExceptionBlock
 // handleEndOfFunction handles the case when control flow reaches the end of a
 // PL/pgSQL routine without reaching a RETURN statement.
 func (b *plpgsqlBuilder) handleEndOfFunction(inScope *scope) *scope {
-	if b.hasOutParam() || b.returnType.Family() == types.VoidFamily {
-		// Routines with OUT-parameters and VOID return types need not explicitly
-		// specify a RETURN statement.
+	if b.resultBuffer != nil || b.hasOutParam() || b.returnType.Family() == types.VoidFamily {
+		// Set-returning routines, and routines with OUT-parameters and VOID return
+		// types need not explicitly specify a RETURN statement. The implicit
+		// return value of a set-returning routine is ignored.
 		var returnExpr tree.Expr = tree.DNull
-		if b.hasOutParam() {
+		if b.resultBuffer == nil && b.hasOutParam() {
 			returnExpr = b.makeReturnForOutParams()
 		}
 		returnScope := inScope.push()
@@ -1977,6 +2532,24 @@ func (b *plpgsqlBuilder) prependStmt(stmt ast.Statement, stmts []ast.Statement)
 	return append(newStmts, stmts...)
 }
 
+// makeRaiseIf returns an IF statement that raises an error with the given code
+// and message if the condition is true.
+func makeRaiseIf(cond ast.Expr, code pgcode.Code, message string) ast.Statement {
+	return &ast.If{
+		Condition: cond,
+		ThenBody: []ast.Statement{&ast.Raise{
+			LogLevel: "EXCEPTION",
+			Code:     code.String(),
+			Message:  message,
+		}},
+	}
+}
+
+// makeVarRef returns an expression that references the given variable.
+func makeVarRef(name ast.Variable) ast.Expr {
+	return tree.NewUnresolvedName(string(name))
+}
+
 func (b *plpgsqlBuilder) ensureScopeHasExpr(s *scope) {
 	if s.expr == nil {
 		s.expr = b.ob.factory.ConstructNoColsRow()
@@ -2196,35 +2769,145 @@ func (r *recordTypeVisitor) Visit(stmt ast.Statement) (newStmt ast.Statement, re
 			return t, false
 		}
 	case *ast.Return:
-		desired := types.Any
-		if r.typ != nil && r.typ.Family() != types.UnknownFamily {
-			desired = r.typ
+		r.visitReturnExpr(t.Expr)
+	case *ast.ReturnNext:
+		r.visitReturnExpr(t.Expr)
+	}
+	return stmt, true
+}
+
+// visitReturnExpr checks the type of an expression returned by a RETURN or
+// RETURN NEXT statement against the types of previously visited expressions.
+func (r *recordTypeVisitor) visitReturnExpr(returnExpr ast.Expr) {
+	if returnExpr == nil {
+		// An empty RETURN is valid in a set-returning routine; any other case is
+		// handled when the statement is built.
+		return
+	}
+	desired := types.Any
+	if r.typ != nil && r.typ.Family() != types.UnknownFamily {
+		desired = r.typ
+	}
+	expr, _ := tree.WalkExpr(r.s, returnExpr)
+	typedExpr, err := expr.TypeCheck(r.ctx, r.semaCtx, desired)
+	if err != nil {
+		panic(err)
+	}
+	typ := typedExpr.ResolvedType()
+	switch typ.Family() {
+	case types.UnknownFamily, types.TupleFamily:
+	default:
+		panic(nonCompositeErr)
+	}
+	if r.typ == nil || r.typ.Family() == types.UnknownFamily {
+		r.typ = typ
+		return
+	}
+	if typ.Family() == types.UnknownFamily {
+		return
+	}
+	if !typ.Identical(r.typ) {
+		panic(recordReturnErr)
+	}
+}
+
+// aliasVisitor replaces an alias with the aliased variable wherever a
+// PL/pgSQL statement names a variable directly, e.g. the target of an
+// assignment. References within SQL expressions and statements are replaced by
+// replaceInExpr.
+type aliasVisitor struct {
+	alias, target ast.Variable
+}
+
+var _ ast.StatementVisitor = &aliasVisitor{}
+
+func (v *aliasVisitor) Visit(stmt ast.Statement) (newStmt ast.Statement, recurse bool) {
+	switch t := stmt.(type) {
+	case *ast.Assignment:
+		if t.Var == v.alias {
+			cpy := t.CopyNode()
+			cpy.Var = v.target
+			return cpy, true
 		}
-		expr, _ := tree.WalkExpr(r.s, t.Expr)
-		typedExpr, err := expr.TypeCheck(r.ctx, r.semaCtx, desired)
-		if err != nil {
-			panic(err)
+	case *ast.Execute:
+		if v.hasAlias(t.Target) {
+			cpy := t.CopyNode()
+			v.replaceTarget(cpy.Target)
+			return cpy, true
 		}
-		typ := typedExpr.ResolvedType()
-		switch typ.Family() {
-		case types.UnknownFamily, types.TupleFamily:
-		default:
-			panic(nonCompositeErr)
+	case *ast.Fetch:
+		if t.Cursor.Name == v.alias || v.hasAlias(t.Target) {
+			cpy := t.CopyNode()
+			if cpy.Cursor.Name == v.alias {
+				cpy.Cursor.Name = v.target
+			}
+			v.replaceTarget(cpy.Target)
+			return cpy, true
+		}
+	case *ast.Open:
+		if t.CurVar == v.alias {
+			cpy := t.CopyNode()
+			cpy.CurVar = v.target
+			return cpy, true
+		}
+	case *ast.Close:
+		if t.CurVar == v.alias {
+			cpy := t.CopyNode()
+			cpy.CurVar = v.target
+			return cpy, true
 		}
-		if r.typ == nil || r.typ.Family() == types.UnknownFamily {
-			r.typ = typ
-			return stmt, false
+	case *ast.ForInt:
+		if t.Target == v.alias {
+			cpy := t.CopyNode()
+			cpy.Target = v.target
+			return cpy, true
 		}
-		if typ.Family() == types.UnknownFamily {
-			return stmt, false
+	case *ast.ForSelect:
+		if v.hasAlias(t.Target) {
+			cpy := t.CopyNode()
+			v.replaceTarget(cpy.Target)
+			return cpy, true
 		}
-		if !typ.Identical(r.typ) {
-			panic(recordReturnErr)
+	case *ast.ForEachArray:
+		if v.hasAlias(t.Target) {
+			cpy := t.CopyNode()
+			v.replaceTarget(cpy.Target)
+			return cpy, true
 		}
 	}
 	return stmt, true
 }
 
+func (v *aliasVisitor) hasAlias(target []ast.Variable) bool {
+	for _, name := range target {
+		if name == v.alias {
+			return true
+		}
+	}
+	return false
+}
+
+func (v *aliasVisitor) replaceTarget(target []ast.Variable) {
+	for i := range target {
+		if target[i] == v.alias {
+			target[i] = v.target
+		}
+	}
+}
+
+// replaceInExpr replaces references to the alias within a SQL expression. Only
+// the first part of a name can refer to a variable, e.g. "x" in "x.a".
+func (v *aliasVisitor) replaceInExpr(expr tree.Expr) (recurse bool, newExpr tree.Expr, err error) {
+	if name, ok := expr.(*tree.UnresolvedName); ok && name.NumParts > 0 {
+		if firstPart := name.NumParts - 1; name.Parts[firstPart] == string(v.alias) {
+			newName := *name
+			newName.Parts[firstPart] = string(v.target)
+			return false, &newName, nil
+		}
+	}
+	return true, expr, nil
+}
+
 // transactionControlVisitor is used to check for COMMIT or ROLLBACK statements
 // for a PL/pgSQL stored procedure, so that stable folding can be disabled.
 type transactionControlVisitor struct {
@@ -2302,6 +2985,29 @@ var (
 	emptyReturnErr = pgerror.New(pgcode.Syntax,
 		"missing expression at or near \"RETURN;\"",
 	)
+	returnWithSetofErr = errors.WithHint(
+		pgerror.New(pgcode.Syntax, "RETURN cannot have a parameter in function returning set"),
+		"Use RETURN NEXT or RETURN QUERY.",
+	)
+	returnNextNonSetofErr = pgerror.New(pgcode.DatatypeMismatch,
+		"cannot use RETURN NEXT in a non-SETOF function",
+	)
+	returnQueryNonSetofErr = pgerror.New(pgcode.DatatypeMismatch,
+		"cannot use RETURN QUERY in a non-SETOF function",
+	)
+	returnNextWithOUTParameterErr = pgerror.New(pgcode.DatatypeMismatch,
+		"RETURN NEXT cannot have a parameter in function with OUT parameters",
+	)
+	emptyReturnNextErr = pgerror.New(pgcode.Syntax,
+		"RETURN NEXT must have a parameter",
+	)
+	returnQueryStructureErr = errors.WithDetail(
+		pgerror.New(pgcode.DatatypeMismatch, "structure of query does not match function result type"),
+		"Number of returned columns does not match expected column count.",
+	)
+	forEachSliceErr = unimplemented.New("FOREACH SLICE",
+		"FOREACH with SLICE is not yet supported",
+	)
 	txnControlWithExceptionErr = errors.WithDetail(
 		pgerror.Newf(pgcode.InvalidTransactionTermination, "invalid transaction termination"),
 		"PL/pgSQL COMMIT/ROLLBACK is not allowed inside a block with exception handlers",
@@ -23,9 +23,10 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	plpgsql "github.com/cockroachdb/cockroach/pkg/sql/plpgsql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/cast"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/plpgsqltree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
-	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/errors"
 )
 
@@ -51,6 +52,11 @@ func (b *Builder) buildUDF(
 			"To call a procedure, use CALL.",
 		))
 	}
+	if f.ResolvedType().Family() == types.TriggerFamily {
+		panic(pgerror.New(pgcode.FeatureNotSupported,
+			"trigger functions can only be called as triggers",
+		))
+	}
 
 	// Check for execution privileges for user-defined overloads. Built-in
 	// overloads do not need to be checked.
@@ -238,7 +244,7 @@ func (b *Builder) buildRoutine(
 		args = make(memo.ScalarListExpr, 0, len(f.Exprs))
 		argTypes = make([]*types.T, 0, len(f.Exprs))
 		for i, pexpr := range f.Exprs {
-			if isProc && o.RoutineParams[i].Class == tree.RoutineParamOut {
+			if isProc && i < len(o.RoutineParams) && o.RoutineParams[i].Class == tree.RoutineParamOut {
 				// For procedures, OUT parameters need to be specified in the
 				// CALL statement, but they are not evaluated and shouldn't be
 				// passed down to the UDF Call (since the body can only
@@ -256,6 +262,12 @@ func (b *Builder) buildRoutine(
 			argTypes = append(argTypes, pexpr.(tree.TypedExpr).ResolvedType())
 		}
 	}
+	if o.Variadic && !f.Variadic {
+		// Collect the trailing arguments into an array for the VARIADIC
+		// parameter. If the last argument is marked VARIADIC, it is already an
+		// array and is passed as is.
+		args, argTypes = b.buildVariadicArg(o, args, argTypes)
+	}
 	// Create a new scope for building the statements in the function body. We
 	// start with an empty scope because a statement in the function body cannot
 	// refer to anything from the outer expression. If there are function
@@ -274,8 +286,7 @@ func (b *Builder) buildRoutine(
 		// Add all input parameters to the scope.
 		paramTypes, ok := o.Types.(tree.ParamTypes)
 		if !ok {
-			panic(unimplemented.NewWithIssue(88947,
-				"variadiac user-defined functions are not yet supported"))
+			panic(errors.AssertionFailedf("expected ParamTypes, found %T", o.Types))
 		}
 		if len(paramTypes) != len(args) {
 			panic(errors.AssertionFailedf(
@@ -352,10 +363,21 @@ func (b *Builder) buildRoutine(
 	b.insideSQLRoutine = o.Language == tree.RoutineLangSQL
 	isSetReturning := o.Class == tree.GeneratorClass
 
+	// A SECURITY DEFINER routine is built as its owner, and a routine with a
+	// SET search_path clause resolves names in its body using that search path.
+	settings := tree.MakeRoutineSessionSettings(o)
+	if settings != nil {
+		// Privileges in the body are checked for the owner rather than for the
+		// current user, so a cached memo cannot be reused without rebuilding.
+		b.DisableMemoReuse = true
+		defer b.pushRoutineSessionSettings(settings)()
+	}
+
 	// Build an expression for each statement in the function body.
 	var body []memo.RelExpr
 	var bodyProps []*physical.Required
 	var bodyStmts []string
+	var resultBuffer *tree.RoutineResultBuffer
 	switch o.Language {
 	case tree.RoutineLangSQL:
 		// Parse the function body.
@@ -407,6 +429,14 @@ func (b *Builder) buildRoutine(
 		if err != nil {
 			panic(err)
 		}
+		if isProc && settings != nil {
+			var tc transactionControlVisitor
+			plpgsqltree.Walk(&tc, stmt.AST)
+			if tc.foundTxnControlStatement {
+				panic(pgerror.New(pgcode.InvalidTransactionTermination,
+					"invalid transaction termination"))
+			}
+		}
 		routineParams := make([]routineParam, 0, len(o.RoutineParams))
 		for _, param := range o.RoutineParams {
 			// TODO(yuzefovich): can we avoid type resolution here?
@@ -423,7 +453,8 @@ func (b *Builder) buildRoutine(
 		var expr memo.RelExpr
 		var physProps *physical.Required
 		plBuilder := newPLpgSQLBuilder(
-			b, def.Name, stmt.AST.Label, colRefs, routineParams, f.ResolvedType(), isProc, outScope,
+			b, def.Name, stmt.AST.Label, colRefs, routineParams, f.ResolvedType(),
+			isProc, isSetReturning, outScope,
 		)
 		stmtScope := plBuilder.buildRootBlock(stmt.AST, bodyScope, routineParams)
 		expr, physProps = b.finishBuildLastStmt(
@@ -431,6 +462,7 @@ func (b *Builder) buildRoutine(
 		)
 		body = []memo.RelExpr{expr}
 		bodyProps = []*physical.Required{physProps}
+		resultBuffer = plBuilder.resultBuffer
 		if b.verboseTracing {
 			bodyStmts = []string{stmt.String()}
 		}
@@ -455,12 +487,50 @@ func (b *Builder) buildRoutine(
 				BodyProps:          bodyProps,
 				BodyStmts:          bodyStmts,
 				Params:             params,
+				ResultBuffer:       resultBuffer,
+				SessionSettings:    settings,
 			},
 		},
 	)
 	return routine
 }
 
+// pushRoutineSessionSettings pushes a copy of the session data onto the stack
+// with the current user and search path that are used to build the body of a
+// routine with the given settings. The returned function must be called to
+// restore the previous session data once the body is built.
+func (b *Builder) pushRoutineSessionSettings(settings *tree.RoutineSessionSettings) func() {
+	sds := b.evalCtx.SessionDataStack
+	if sds == nil {
+		return func() {}
+	}
+	sds.PushTopClone()
+	sd := sds.Top()
+	if !settings.Definer.Undefined() {
+		if sd.SessionUserProto == "" {
+			sd.SessionUserProto = sd.UserProto
+		}
+		sd.UserProto = settings.Definer.EncodeProto()
+		sd.SearchPath = sd.SearchPath.WithUserSchemaName(settings.Definer.Normalized())
+	}
+	for _, setting := range settings.Config {
+		name, value, _ := strings.Cut(setting, "=")
+		if name != "search_path" {
+			continue
+		}
+		paths, err := sessiondata.ParseSearchPath(value)
+		if err != nil {
+			panic(err)
+		}
+		sd.SearchPath = sd.SearchPath.UpdatePaths(paths)
+	}
+	return func() {
+		if err := sds.Pop(); err != nil {
+			panic(err)
+		}
+	}
+}
+
 // finishBuildLastStmt manages the columns returned by the last statement of a
 // routine. Depending on the context and return type of the routine, this may
 // mean expanding a tuple into multiple columns, or combining multiple columns
@@ -729,6 +799,33 @@ func (b *Builder) addDefaultArgs(
 	return args, argTypes
 }
 
+// buildVariadicArg replaces the arguments supplied for the VARIADIC parameter
+// of a routine, which are all arguments after the fixed parameters, with a
+// single array argument of the parameter's type.
+func (b *Builder) buildVariadicArg(
+	o *tree.Overload, args memo.ScalarListExpr, argTypes []*types.T,
+) (memo.ScalarListExpr, []*types.T) {
+	numFixed := o.Types.Length() - 1
+	if len(args) < numFixed {
+		panic(errors.AssertionFailedf(
+			"expected at least %d arguments for variadic routine, found %d", numFixed, len(args),
+		))
+	}
+	arrayTyp := o.Types.GetAt(numFixed)
+	elemTyp := arrayTyp.ArrayContents()
+	elems := make(memo.ScalarListExpr, 0, len(args)-numFixed)
+	for i := numFixed; i < len(args); i++ {
+		elem := args[i]
+		if !argTypes[i].Identical(elemTyp) {
+			elem = b.factory.ConstructCast(elem, elemTyp)
+		}
+		elems = append(elems, elem)
+	}
+	args = append(args[:numFixed:numFixed], b.factory.ConstructArray(elems, arrayTyp))
+	argTypes = append(argTypes[:numFixed:numFixed], arrayTyp)
+	return args, argTypes
+}
+
 // maybeResolvePolymorphicReturnType checks whether the return type of the
 // routine is polymorphic and if so, uses the resolved polymorphic argument type
 // to determine the concrete return type.
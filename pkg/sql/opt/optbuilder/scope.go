@@ -31,6 +31,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treewindow"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/redact"
 )
@@ -1042,6 +1043,13 @@ func (s *scope) VisitPre(expr tree.Expr) (recurse bool, newExpr tree.Expr) {
 	case *tree.ColumnItem:
 		colI, resolveErr := colinfo.ResolveColumnItem(s.builder.ctx, s, t)
 		if resolveErr != nil {
+			// It may be a reference to a field of a tuple-typed column, e.g. NEW.a
+			// in a trigger function.
+			if sqlerrors.IsUndefinedRelationError(resolveErr) {
+				if fieldAccess := s.maybeResolveFieldAccess(t); fieldAccess != nil {
+					return false, fieldAccess
+				}
+			}
 			// It may be a reference to a table, e.g. SELECT tbl FROM tbl.
 			// Attempt to resolve as a TupleStar.
 			if sqlerrors.IsUndefinedColumnError(resolveErr) {
@@ -1103,6 +1111,9 @@ func (s *scope) VisitPre(expr tree.Expr) (recurse bool, newExpr tree.Expr) {
 			break
 		}
 
+	case *tree.GroupingExpr:
+		expr = s.replaceGrouping(t)
+
 	case *tree.ArrayFlatten:
 		if sub, ok := t.Subquery.(*tree.Subquery); ok {
 			// Copy the ArrayFlatten expression so that the tree isn't mutated.
@@ -1301,6 +1312,14 @@ func (s *scope) replaceAggregate(f *tree.FuncExpr, def *tree.ResolvedFunctionDef
 	}
 
 	f = typedFunc.(*tree.FuncExpr)
+	if f.ResolvedOverload().UDFAggregate != nil {
+		if f.OrderBy != nil {
+			panic(unimplemented.NewWithIssuef(74775,
+				"ORDER BY in a call to user-defined aggregate %s is not yet supported", def.Name,
+			))
+		}
+		s.castUserDefinedAggArgs(f)
+	}
 
 	private := memo.FunctionPrivate{
 		Name:       def.Name,
@@ -1373,6 +1392,11 @@ func (s *scope) replaceWindowFn(f *tree.FuncExpr, def *tree.ResolvedFunctionDefi
 	}
 
 	f = typedFunc.(*tree.FuncExpr)
+	if f.ResolvedOverload().UDFAggregate != nil {
+		panic(unimplemented.NewWithIssuef(74775,
+			"user-defined aggregate %s cannot yet be used as a window function", def.Name,
+		))
+	}
 
 	// We will be performing type checking on expressions from PARTITION BY and
 	// ORDER BY clauses below, and we need the semantic context to know that we
@@ -1437,6 +1461,33 @@ func (s *scope) replaceWindowFn(f *tree.FuncExpr, def *tree.ResolvedFunctionDefi
 	return &info
 }
 
+// replaceGrouping replaces a GROUPING operation with a groupingInfo struct
+// containing its type checked arguments. The arguments are matched with the
+// GROUP BY expressions when the groupingInfo is built; see
+// Builder.buildGroupingExpr.
+func (s *scope) replaceGrouping(g *tree.GroupingExpr) tree.Expr {
+	switch s.context {
+	case exprKindSelect, exprKindHaving, exprKindOrderBy, exprKindDistinctOn:
+	default:
+		panic(pgerror.Newf(pgcode.Grouping,
+			"grouping operations are not allowed in %s", s.context))
+	}
+
+	info := groupingInfo{
+		GroupingExpr: &tree.GroupingExpr{Exprs: make(tree.Exprs, len(g.Exprs))},
+		args:         make(tree.TypedExprs, len(g.Exprs)),
+	}
+	for i, e := range g.Exprs {
+		typedExpr, err := tree.TypeCheck(s.builder.ctx, e.Walk(s), s.builder.semaCtx, types.Any)
+		if err != nil {
+			panic(err)
+		}
+		info.args[i] = typedExpr
+		info.GroupingExpr.Exprs[i] = typedExpr
+	}
+	return &info
+}
+
 // replaceSQLFn replaces a tree.SQLClass function with a sqlFnInfo struct. See
 // comments above tree.SQLClass and sqlFnInfo for details.
 func (s *scope) replaceSQLFn(f *tree.FuncExpr, def *tree.ResolvedFunctionDefinition) tree.Expr {
@@ -1714,6 +1765,25 @@ func columnNameAsTupleStar(colName string) *tree.TupleStar {
 	}
 }
 
+// maybeResolveFieldAccess attempts to resolve a column item of the form a.b
+// as an access of the field b of the tuple-typed column a. It returns nil if
+// there is no such column.
+func (s *scope) maybeResolveFieldAccess(c *tree.ColumnItem) tree.Expr {
+	if c.TableName == nil || c.TableName.NumParts != 1 {
+		return nil
+	}
+	tupleCol := &tree.ColumnItem{ColumnName: tree.Name(c.TableName.Parts[0])}
+	colI, err := colinfo.ResolveColumnItem(s.builder.ctx, s, tupleCol)
+	if err != nil {
+		return nil
+	}
+	col := colI.(*scopeColumn)
+	if col.typ.Family() != types.TupleFamily {
+		return nil
+	}
+	return &tree.ColumnAccessExpr{Expr: col, ColName: c.ColumnName}
+}
+
 // wrapColTupleStarPanic checks for panics and if the pgcode is
 // UndefinedTable panics with the originalError.
 // Otherwise, it will panic with the recovered error.
@@ -0,0 +1,745 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/props/physical"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	plpgsql "github.com/cockroachdb/cockroach/pkg/sql/plpgsql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
+)
+
+// This file contains methods that fire the triggers defined on the target
+// table of a mutation.
+//
+// -- BEFORE triggers --
+//
+// Row-level BEFORE triggers are built as a projection of the trigger function
+// call on top of the mutation input, before computed columns, checks, and
+// foreign keys are built. The function is passed the new and/or old values of
+// the row, and returns the row that should be written in place of the new
+// values. Rows for which the function returns NULL are filtered out, so that
+// they are not modified. For example, for an INSERT:
+//
+//	insert t
+//	 └── project
+//	      ├── columns: a_trg:6 b_trg:7
+//	      ├── select
+//	      │    ├── barrier
+//	      │    │    └── project
+//	      │    │         ├── columns: trigger_trg:5
+//	      │    │         └── projections
+//	      │    │              └── udf: trigger_fn [as=trigger_trg:5]
+//	      │    │                   └── args
+//	      │    │                        ├── tuple (a:3, b:4)
+//	      │    │                        └── ...
+//	      │    └── filters
+//	      │         └── trigger_trg:5 IS DISTINCT FROM NULL
+//	      └── projections
+//	           ├── (trigger_trg:5).a [as=a_trg:6]
+//	           └── (trigger_trg:5).b [as=b_trg:7]
+//
+// Statement-level BEFORE triggers are built as uncorrelated subqueries that
+// call the trigger function once. The subqueries are projected on top of the
+// mutation input, below a barrier which prevents them from being pruned. Since
+// uncorrelated subqueries are evaluated before the main query, the triggers
+// fire before any row is read or modified, even if the statement modifies no
+// rows. For example, for an INSERT:
+//
+//	insert t
+//	 └── barrier
+//	      └── project
+//	           ├── columns: trigger_trg:5 a:3 b:4
+//	           ├── values
+//	           │    └── ...
+//	           └── projections
+//	                └── subquery [as=trigger_trg:5]
+//	                     └── project
+//	                          ├── columns: trigger_trg:6
+//	                          ├── values
+//	                          │    └── ()
+//	                          └── projections
+//	                               └── udf: trigger_fn [as=trigger_trg:6]
+//	                                    └── args
+//	                                         ├── NULL
+//	                                         ├── NULL
+//	                                         └── ...
+//
+// -- AFTER triggers --
+//
+// AFTER triggers are built as post-queries that run after the mutation (and
+// any of its FK cascades) have completed. They use the same mechanism as FK
+// cascades (see mutation_builder_fk.go): a memo.FKCascade with a nil
+// FKConstraint and an afterTriggerBuilder which builds the query on demand.
+// Row-level triggers read the modified rows from the buffered mutation input.
+
+// triggerRowOrdinals returns the ordinals of the table columns that make up the
+// NEW and OLD rows passed to trigger functions.
+func triggerRowOrdinals(tab cat.Table) []int {
+	ords := make([]int, 0, tab.ColumnCount())
+	for i, n := 0, tab.ColumnCount(); i < n; i++ {
+		col := tab.Column(i)
+		if col.Kind() == cat.Ordinary && col.Visibility() == cat.Visible {
+			ords = append(ords, i)
+		}
+	}
+	return ords
+}
+
+// triggerRowType returns the type of the NEW and OLD rows passed to trigger
+// functions on the given table.
+func triggerRowType(tab cat.Table) *types.T {
+	ords := triggerRowOrdinals(tab)
+	contents := make([]*types.T, len(ords))
+	labels := make([]string, len(ords))
+	for i, ord := range ords {
+		col := tab.Column(ord)
+		contents[i] = col.DatumType()
+		labels[i] = string(col.ColName())
+	}
+	return types.MakeLabeledTuple(contents, labels)
+}
+
+// findTriggers returns the enabled triggers on the given table that fire at the
+// given time and level in response to the given event. UPDATE triggers that
+// specify a list of columns only fire if one of them is in targetCols, which
+// contains the metadata IDs of the targeted table columns. Triggers are returned in the order in which
+// they fire, which is alphabetical order by name.
+func findTriggers(
+	tab cat.Table,
+	actionTime tree.TriggerActionTime,
+	eventType tree.TriggerEventType,
+	forEachRow bool,
+	targetCols opt.ColSet,
+	tabID opt.TableID,
+) []cat.Trigger {
+	var triggers []cat.Trigger
+	for i, n := 0, tab.TriggerCount(); i < n; i++ {
+		trigger := tab.Trigger(i)
+		if !trigger.Enabled() || trigger.ActionTime() != actionTime ||
+			trigger.ForEachRow() != forEachRow {
+			continue
+		}
+		if triggerFiresOnEvent(tab, trigger, eventType, targetCols, tabID) {
+			triggers = append(triggers, trigger)
+		}
+	}
+	sort.Slice(triggers, func(i, j int) bool {
+		return triggers[i].Name() < triggers[j].Name()
+	})
+	return triggers
+}
+
+// triggerFiresOnEvent returns true if the given trigger fires in response to
+// the given event. See findTriggers.
+func triggerFiresOnEvent(
+	tab cat.Table,
+	trigger cat.Trigger,
+	eventType tree.TriggerEventType,
+	targetCols opt.ColSet,
+	tabID opt.TableID,
+) bool {
+	for i, n := 0, trigger.EventCount(); i < n; i++ {
+		event := trigger.Event(i)
+		if event.EventType != eventType {
+			continue
+		}
+		if len(event.Columns) == 0 {
+			return true
+		}
+		for _, name := range event.Columns {
+			ord := findPublicTableColumnByName(tab, name)
+			if ord != -1 && targetCols.Contains(tabID.ColumnID(ord)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// buildTriggerRow builds a tuple with the values of the given columns, which
+// are indexed by table column ordinal, for use as the NEW or OLD row of a
+// trigger function. If cols is nil, it builds a NULL row.
+func (b *Builder) buildTriggerRow(tab cat.Table, cols opt.OptionalColList) opt.ScalarExpr {
+	typ := triggerRowType(tab)
+	if cols == nil {
+		return b.factory.ConstructNull(typ)
+	}
+	ords := triggerRowOrdinals(tab)
+	elems := make(memo.ScalarListExpr, len(ords))
+	for i, ord := range ords {
+		elem := b.factory.ConstructVariable(cols[ord])
+		if colTyp := b.factory.Metadata().ColumnMeta(cols[ord]).Type; !colTyp.Identical(typ.TupleContents()[i]) {
+			elem = b.factory.ConstructAssignmentCast(elem, typ.TupleContents()[i])
+		}
+		elems[i] = elem
+	}
+	return b.factory.ConstructTuple(elems, typ)
+}
+
+// buildTriggerWhen builds the WHEN condition of the given trigger. References to
+// columns of the NEW and OLD rows resolve to the given columns, which are
+// indexed by table column ordinal.
+func (b *Builder) buildTriggerWhen(
+	tab cat.Table, trigger cat.Trigger, newCols, oldCols opt.OptionalColList,
+) opt.ScalarExpr {
+	expr, err := parser.ParseExpr(trigger.WhenExpr())
+	if err != nil {
+		panic(err)
+	}
+	s := b.allocScope()
+	addCols := func(cols opt.OptionalColList, rowName tree.Name) {
+		for ord, col := range cols {
+			if col == 0 {
+				continue
+			}
+			s.cols = append(s.cols, scopeColumn{
+				name:  scopeColName(tab.Column(ord).ColName()),
+				table: tree.MakeUnqualifiedTableName(rowName),
+				typ:   b.factory.Metadata().ColumnMeta(col).Type,
+				id:    col,
+			})
+		}
+	}
+	addCols(newCols, "new")
+	addCols(oldCols, "old")
+	texpr := s.resolveAndRequireType(expr, types.Bool)
+	return b.buildScalar(texpr, s, nil /* outScope */, nil /* outCol */, nil /* colRefs */)
+}
+
+// buildTriggerFunctionCall builds a call to the function of the given trigger,
+// which fires in response to the given event. newRow and oldRow are passed to
+// the function as the NEW and OLD variables. The call returns a row of the type
+// returned by triggerRowType.
+//
+// The body of a trigger function is not built when the function is created,
+// because the types of NEW and OLD depend on the table of the trigger. Instead,
+// it is built here, with the special variables that are available to trigger
+// functions passed as parameters.
+func (b *Builder) buildTriggerFunctionCall(
+	tab cat.Table,
+	trigger cat.Trigger,
+	eventType tree.TriggerEventType,
+	newRow, oldRow opt.ScalarExpr,
+) opt.ScalarExpr {
+	funcName, o, err := b.catalog.ResolveFunctionByOID(
+		b.ctx, catid.FuncIDToOID(catid.DescID(trigger.FuncID())),
+	)
+	if err != nil {
+		panic(err)
+	}
+	if o.Language != tree.RoutineLangPLpgSQL {
+		panic(errors.AssertionFailedf("unexpected trigger function language: %v", o.Language))
+	}
+	tabName, err := b.catalog.FullyQualifiedName(b.ctx, tab)
+	if err != nil {
+		panic(err)
+	}
+	rowTyp := triggerRowType(tab)
+
+	// Build the arguments for the special variables.
+	whenStr := "AFTER"
+	if trigger.ActionTime() == tree.TriggerActionTimeBefore {
+		whenStr = "BEFORE"
+	}
+	levelStr := "STATEMENT"
+	if trigger.ForEachRow() {
+		levelStr = "ROW"
+	}
+	funcArgs := trigger.FuncArgs()
+	argv := tree.NewDArray(types.String)
+	for _, arg := range funcArgs {
+		if err := argv.Append(tree.NewDString(arg)); err != nil {
+			panic(err)
+		}
+	}
+	constString := func(s string) opt.ScalarExpr {
+		return b.factory.ConstructConstVal(tree.NewDString(s), types.String)
+	}
+	triggerParams := []struct {
+		name tree.Name
+		typ  *types.T
+		arg  opt.ScalarExpr
+	}{
+		{name: "new", typ: rowTyp, arg: newRow},
+		{name: "old", typ: rowTyp, arg: oldRow},
+		{name: "tg_name", typ: types.String, arg: constString(string(trigger.Name()))},
+		{name: "tg_when", typ: types.String, arg: constString(whenStr)},
+		{name: "tg_level", typ: types.String, arg: constString(levelStr)},
+		{name: "tg_op", typ: types.String, arg: constString(eventType.String())},
+		{
+			name: "tg_relid",
+			typ:  types.Oid,
+			arg:  b.factory.ConstructConstVal(tree.NewDOid(oid.Oid(tab.ID())), types.Oid),
+		},
+		{name: "tg_table_name", typ: types.String, arg: constString(tabName.Table())},
+		{name: "tg_table_schema", typ: types.String, arg: constString(tabName.Schema())},
+		{
+			name: "tg_nargs",
+			typ:  types.Int,
+			arg:  b.factory.ConstructConstVal(tree.NewDInt(tree.DInt(len(funcArgs))), types.Int),
+		},
+		{
+			name: "tg_argv",
+			typ:  types.StringArray,
+			arg:  b.factory.ConstructConstVal(argv, types.StringArray),
+		},
+	}
+
+	// Add the special variables as parameters of the routine.
+	bodyScope := b.allocScope()
+	args := make(memo.ScalarListExpr, len(triggerParams))
+	params := make(opt.ColList, len(triggerParams))
+	paramTypes := make([]*types.T, len(triggerParams))
+	routineParams := make([]routineParam, len(triggerParams))
+	for i, param := range triggerParams {
+		col := b.synthesizeColumn(
+			bodyScope, funcParamColName(param.name, i), param.typ, nil /* expr */, nil, /* scalar */
+		)
+		col.setParamOrd(i)
+		args[i] = param.arg
+		params[i] = col.id
+		paramTypes[i] = param.typ
+		routineParams[i] = routineParam{name: param.name, typ: param.typ, class: tree.RoutineParamIn}
+	}
+	b.factory.Metadata().AddUserDefinedFunction(o, paramTypes, nil /* name */)
+
+	// Build the body of the trigger function in the same way as the body of a
+	// PL/pgSQL function that returns the row type of the table.
+	defer func(trackSchemaDeps, insideUDF, insideDataSource, insideSQLRoutine bool) {
+		b.trackSchemaDeps = trackSchemaDeps
+		b.insideUDF = insideUDF
+		b.insideDataSource = insideDataSource
+		b.insideSQLRoutine = insideSQLRoutine
+	}(b.trackSchemaDeps, b.insideUDF, b.insideDataSource, b.insideSQLRoutine)
+	b.trackSchemaDeps = false
+	b.insideUDF = true
+	b.insideDataSource = false
+	b.insideSQLRoutine = false
+
+	stmt, err := plpgsql.Parse(o.Body)
+	if err != nil {
+		panic(err)
+	}
+	plBuilder := newPLpgSQLBuilder(
+		b, funcName.Object(), stmt.AST.Label, nil /* colRefs */, routineParams, rowTyp,
+		false /* isProcedure */, false /* isSetReturning */, nil, /* outScope */
+	)
+	stmtScope := plBuilder.buildRootBlock(stmt.AST, bodyScope, routineParams)
+
+	// Add a LIMIT 1 to the last statement, and cast the result to the row type
+	// if necessary. See finishBuildLastStmt.
+	b.buildLimit(&tree.Limit{Count: tree.NewDInt(1)}, b.allocScope(), stmtScope)
+	expr, physProps := stmtScope.expr, stmtScope.makePhysicalProps()
+	physProps.Ordering = props.OrderingChoice{}
+	expr, physProps = b.maybeAddRoutineAssignmentCasts(
+		physProps.Presentation, bodyScope, rowTyp, expr, physProps, false, /* insideDataSource */
+	)
+	var bodyStmts []string
+	if b.verboseTracing {
+		bodyStmts = []string{stmt.String()}
+	}
+
+	return b.factory.ConstructUDFCall(
+		args,
+		&memo.UDFCallPrivate{
+			Def: &memo.UDFDefinition{
+				Name:       funcName.Object(),
+				Typ:        rowTyp,
+				Volatility: o.Volatility,
+				// The trigger function must be called even if NEW or OLD is NULL.
+				CalledOnNullInput: true,
+				RoutineType:       tree.UDFRoutine,
+				RoutineLang:       o.Language,
+				Body:              []memo.RelExpr{expr},
+				BodyProps:         []*physical.Required{physProps},
+				BodyStmts:         bodyStmts,
+				Params:            params,
+			},
+		},
+	)
+}
+
+// buildRowLevelBeforeTriggers builds the row-level BEFORE triggers on the target
+// table that fire in response to the given event. See the comment at the top of
+// the file for more details.
+//
+// For INSERT and UPDATE triggers, the row returned by each trigger function
+// replaces the values in insertColIDs or updateColIDs, respectively. It must be
+// called before computed columns are added to the input, so that they are
+// computed from the values returned by the triggers.
+func (mb *mutationBuilder) buildRowLevelBeforeTriggers(eventType tree.TriggerEventType) {
+	triggers := findTriggers(
+		mb.tab, tree.TriggerActionTimeBefore, eventType, true /* forEachRow */, mb.targetColSet, mb.tabID,
+	)
+	if len(triggers) == 0 {
+		return
+	}
+	f := mb.b.factory
+	rowTyp := triggerRowType(mb.tab)
+	ords := triggerRowOrdinals(mb.tab)
+	for _, trigger := range triggers {
+		var newCols, oldCols opt.OptionalColList
+		switch eventType {
+		case tree.TriggerEventInsert:
+			newCols = mb.insertColIDs
+		case tree.TriggerEventUpdate:
+			newCols = mb.triggerNewColsForUpdate()
+			oldCols = mb.fetchColIDs
+		case tree.TriggerEventDelete:
+			oldCols = mb.fetchColIDs
+		default:
+			panic(errors.AssertionFailedf("unexpected trigger event %s", eventType))
+		}
+		newRow := mb.b.buildTriggerRow(mb.tab, newCols)
+		oldRow := mb.b.buildTriggerRow(mb.tab, oldCols)
+		call := mb.b.buildTriggerFunctionCall(mb.tab, trigger, eventType, newRow, oldRow)
+
+		// If the trigger does not fire for a row, the row is left unchanged.
+		passthrough := newRow
+		if eventType == tree.TriggerEventDelete {
+			passthrough = oldRow
+		}
+		if trigger.WhenExpr() != "" {
+			when := mb.b.buildTriggerWhen(mb.tab, trigger, newCols, oldCols)
+			call = f.ConstructCase(
+				memo.TrueSingleton,
+				memo.ScalarListExpr{f.ConstructWhen(when, call)},
+				passthrough,
+			)
+		}
+		if eventType == tree.TriggerEventUpdate && mb.canaryColID != 0 {
+			// UPDATE triggers only fire for the rows of an UPSERT that conflict
+			// with an existing row.
+			call = f.ConstructCase(
+				memo.TrueSingleton,
+				memo.ScalarListExpr{
+					f.ConstructWhen(
+						f.ConstructIs(f.ConstructVariable(mb.canaryColID), memo.NullSingleton),
+						passthrough,
+					),
+				},
+				call,
+			)
+		}
+
+		// Project the result of the trigger function. The barrier prevents the
+		// call from being inlined into (and evaluated by) each of its references
+		// below.
+		projectionsScope := mb.outScope.replace()
+		projectionsScope.appendColumnsFromScope(mb.outScope)
+		name := scopeColName("").WithMetadataName(fmt.Sprintf("trigger_%s", trigger.Name()))
+		resultCol := mb.b.synthesizeColumn(projectionsScope, name, rowTyp, nil /* expr */, call)
+		mb.b.constructProjectForScope(mb.outScope, projectionsScope)
+		mb.outScope = projectionsScope
+		mb.outScope.expr = f.ConstructBarrier(mb.outScope.expr)
+
+		// Skip the rows for which the trigger function returned NULL.
+		mb.outScope.expr = f.ConstructSelect(
+			mb.outScope.expr,
+			memo.FiltersExpr{f.ConstructFiltersItem(
+				f.ConstructIsNot(f.ConstructVariable(resultCol.id), memo.NullSingleton),
+			)},
+		)
+		if eventType == tree.TriggerEventDelete {
+			continue
+		}
+
+		// Replace the new values with the values of the returned row. Computed
+		// columns are recomputed afterward, so they are not replaced.
+		projectionsScope = mb.outScope.replace()
+		projectionsScope.appendColumnsFromScope(mb.outScope)
+		for i, ord := range ords {
+			tabCol := mb.tab.Column(ord)
+			if tabCol.IsComputed() {
+				continue
+			}
+			colName := scopeColName(tabCol.ColName()).WithMetadataName(
+				fmt.Sprintf("%s_%s", tabCol.ColName(), trigger.Name()),
+			)
+			scalar := f.ConstructColumnAccess(f.ConstructVariable(resultCol.id), memo.TupleOrdinal(i))
+			newCol := mb.b.synthesizeColumn(projectionsScope, colName, tabCol.DatumType(), nil /* expr */, scalar)
+			if eventType == tree.TriggerEventInsert {
+				mb.insertColIDs[ord] = newCol.id
+				continue
+			}
+			if mb.updateColIDs[ord] == 0 {
+				// The trigger function may modify columns that are not targeted
+				// by the UPDATE.
+				tabColID := mb.tabID.ColumnID(ord)
+				mb.targetColList = append(mb.targetColList, tabColID)
+				mb.targetColSet.Add(tabColID)
+			}
+			mb.updateColIDs[ord] = newCol.id
+		}
+		mb.b.constructProjectForScope(mb.outScope, projectionsScope)
+		mb.outScope = projectionsScope
+	}
+}
+
+// buildStatementLevelBeforeTriggers builds the statement-level BEFORE triggers
+// on the target table that fire in response to the given event. See the comment
+// at the top of the file for more details.
+func (mb *mutationBuilder) buildStatementLevelBeforeTriggers(eventType tree.TriggerEventType) {
+	triggers := findTriggers(
+		mb.tab, tree.TriggerActionTimeBefore, eventType, false /* forEachRow */, mb.targetColSet, mb.tabID,
+	)
+	f := mb.b.factory
+	for _, trigger := range triggers {
+		call := mb.b.buildStatementLevelTrigger(mb.tab, trigger, eventType)
+		projectionsScope := mb.outScope.replace()
+		projectionsScope.appendColumnsFromScope(mb.outScope)
+		name := scopeColName("").WithMetadataName(fmt.Sprintf("trigger_%s", trigger.Name()))
+		mb.b.synthesizeColumn(
+			projectionsScope, name, triggerRowType(mb.tab), nil, /* expr */
+			f.ConstructSubquery(call, &memo.SubqueryPrivate{}),
+		)
+		mb.b.constructProjectForScope(mb.outScope, projectionsScope)
+		mb.outScope = projectionsScope
+		mb.outScope.expr = f.ConstructBarrier(mb.outScope.expr)
+	}
+}
+
+// buildStatementLevelTrigger builds a query that calls the function of the
+// given statement-level trigger once, without NEW and OLD rows, if its WHEN
+// condition holds.
+func (b *Builder) buildStatementLevelTrigger(
+	tab cat.Table, trigger cat.Trigger, eventType tree.TriggerEventType,
+) memo.RelExpr {
+	return b.buildTriggerCallQuery(
+		tab, trigger, eventType, b.factory.ConstructNoColsRow(), nil /* filters */, nil /* newCols */, nil, /* oldCols */
+	)
+}
+
+// buildTriggerCallQuery builds a query that calls the function of the given
+// trigger once for each row of the input for which the filters and the WHEN
+// condition of the trigger hold. newCols and oldCols hold the NEW and OLD rows,
+// indexed by table column ordinal, and are nil for statement-level triggers.
+func (b *Builder) buildTriggerCallQuery(
+	tab cat.Table,
+	trigger cat.Trigger,
+	eventType tree.TriggerEventType,
+	input memo.RelExpr,
+	filters memo.FiltersExpr,
+	newCols, oldCols opt.OptionalColList,
+) memo.RelExpr {
+	f := b.factory
+	if trigger.WhenExpr() != "" {
+		filters = append(filters, f.ConstructFiltersItem(
+			b.buildTriggerWhen(tab, trigger, newCols, oldCols),
+		))
+	}
+	if len(filters) > 0 {
+		input = f.ConstructSelect(input, filters)
+	}
+	call := b.buildTriggerFunctionCall(
+		tab, trigger, eventType, b.buildTriggerRow(tab, newCols), b.buildTriggerRow(tab, oldCols),
+	)
+	resultCol := f.Metadata().AddColumn(fmt.Sprintf("trigger_%s", trigger.Name()), call.DataType())
+	projections := memo.ProjectionsExpr{f.ConstructProjectionsItem(call, resultCol)}
+	return f.ConstructProject(input, projections, opt.ColSet{})
+}
+
+// triggerNewColsForUpdate returns the columns that hold the new values of
+// updated rows, indexed by table column ordinal.
+func (mb *mutationBuilder) triggerNewColsForUpdate() opt.OptionalColList {
+	newCols := make(opt.OptionalColList, len(mb.updateColIDs))
+	for ord, col := range mb.updateColIDs {
+		if col != 0 {
+			newCols[ord] = col
+		} else {
+			newCols[ord] = mb.fetchColIDs[ord]
+		}
+	}
+	return newCols
+}
+
+// buildAfterTriggers adds a post-query to mb.cascades for each AFTER trigger on
+// the target table that fires in response to the given event. It must be
+// called after the input of the mutation has been fully built.
+func (mb *mutationBuilder) buildAfterTriggers(eventType tree.TriggerEventType) {
+	for _, forEachRow := range []bool{true, false} {
+		triggers := findTriggers(
+			mb.tab, tree.TriggerActionTimeAfter, eventType, forEachRow, mb.targetColSet, mb.tabID,
+		)
+		for _, trigger := range triggers {
+			builder := &afterTriggerBuilder{
+				mutatedTable: mb.tab,
+				trigger:      trigger,
+				eventType:    eventType,
+			}
+			if !forEachRow {
+				mb.cascades = append(mb.cascades, memo.FKCascade{
+					Trigger: trigger,
+					Builder: builder,
+				})
+				continue
+			}
+
+			ords := triggerRowOrdinals(mb.tab)
+			var newValues, oldValues opt.ColList
+			switch eventType {
+			case tree.TriggerEventInsert:
+				newValues = make(opt.ColList, len(ords))
+				for i, ord := range ords {
+					newValues[i] = mb.insertColIDs[ord]
+				}
+			case tree.TriggerEventUpdate:
+				newCols := mb.triggerNewColsForUpdate()
+				newValues = make(opt.ColList, len(ords))
+				oldValues = make(opt.ColList, len(ords))
+				for i, ord := range ords {
+					newValues[i] = newCols[ord]
+					oldValues[i] = mb.fetchColIDs[ord]
+				}
+			case tree.TriggerEventDelete:
+				oldValues = make(opt.ColList, len(ords))
+				for i, ord := range ords {
+					oldValues[i] = mb.fetchColIDs[ord]
+				}
+			default:
+				panic(errors.AssertionFailedf("unexpected trigger event %s", eventType))
+			}
+			if mb.canaryColID != 0 {
+				// The rows of an UPSERT are split between INSERT and UPDATE
+				// triggers using the canary column.
+				builder.canary = true
+				oldValues = append(oldValues, mb.canaryColID)
+			}
+			mb.ensureWithID()
+			mb.cascades = append(mb.cascades, memo.FKCascade{
+				Trigger:   trigger,
+				Builder:   builder,
+				WithID:    mb.withID,
+				OldValues: oldValues,
+				NewValues: newValues,
+			})
+		}
+	}
+}
+
+// afterTriggerBuilder is a memo.CascadeBuilder implementation for AFTER
+// triggers. It builds a query that calls the trigger function once for each
+// modified row, or once for the statement.
+//
+// For example, for an AFTER UPDATE ... FOR EACH ROW trigger:
+//
+//	barrier
+//	 └── project
+//	      ├── columns: trigger_trg:9
+//	      ├── with-scan &1
+//	      │    ├── columns: a:5 b:6 a_new:7 b_new:8
+//	      │    └── mapping:
+//	      │         ├──  t.a:1 => a:5
+//	      │         ├──  ...
+//	      └── projections
+//	           └── udf: trigger_fn [as=trigger_trg:9]
+//	                └── args
+//	                     ├── tuple (a_new:7, b_new:8)
+//	                     ├── tuple (a:5, b:6)
+//	                     └── ...
+//
+// The barrier ensures that the call is not pruned, since its result is not
+// used.
+type afterTriggerBuilder struct {
+	mutatedTable cat.Table
+	trigger      cat.Trigger
+	eventType    tree.TriggerEventType
+
+	// canary is true if the mutation is an UPSERT. In that case the last of the
+	// oldValues columns is the canary column, which is NULL for inserted rows.
+	canary bool
+}
+
+var _ memo.CascadeBuilder = &afterTriggerBuilder{}
+
+// Build is part of the memo.CascadeBuilder interface.
+func (tb *afterTriggerBuilder) Build(
+	ctx context.Context,
+	semaCtx *tree.SemaContext,
+	evalCtx *eval.Context,
+	catalog cat.Catalog,
+	factoryI interface{},
+	binding opt.WithID,
+	bindingProps *props.Relational,
+	oldValues, newValues opt.ColList,
+) (_ memo.RelExpr, err error) {
+	return buildCascadeHelper(ctx, semaCtx, evalCtx, catalog, factoryI, func(b *Builder) memo.RelExpr {
+		opt.MaybeInjectOptimizerTestingPanic(ctx, evalCtx)
+
+		f := b.factory
+		md := f.Metadata()
+		tab := tb.mutatedTable
+		if binding == 0 {
+			// Statement-level triggers are called once, without NEW and OLD rows.
+			return f.ConstructBarrier(b.buildStatementLevelTrigger(tab, tb.trigger, tb.eventType))
+		}
+
+		// Scan the buffered mutation input.
+		md.AddWithBinding(binding, f.ConstructFakeRel(&memo.FakeRelPrivate{
+			Props: bindingProps,
+		}))
+		inCols := make(opt.ColList, 0, len(oldValues)+len(newValues))
+		inCols = append(inCols, oldValues...)
+		inCols = append(inCols, newValues...)
+		outCols := make(opt.ColList, len(inCols))
+		for i := range outCols {
+			c := md.ColumnMeta(inCols[i])
+			outCols[i] = md.AddColumn(c.Alias, c.Type)
+		}
+		input := f.ConstructWithScan(&memo.WithScanPrivate{
+			With:    binding,
+			InCols:  inCols,
+			OutCols: outCols,
+			ID:      md.NextUniqueID(),
+		})
+		outOld, outNew := outCols[:len(oldValues)], outCols[len(oldValues):]
+
+		var filters memo.FiltersExpr
+		if tb.canary {
+			canaryCol := outOld[len(outOld)-1]
+			outOld = outOld[:len(outOld)-1]
+			var cond opt.ScalarExpr = f.ConstructIs(f.ConstructVariable(canaryCol), memo.NullSingleton)
+			if tb.eventType == tree.TriggerEventUpdate {
+				cond = f.ConstructIsNot(f.ConstructVariable(canaryCol), memo.NullSingleton)
+			}
+			filters = append(filters, f.ConstructFiltersItem(cond))
+		}
+		ords := triggerRowOrdinals(tab)
+		toRowCols := func(cols opt.ColList) opt.OptionalColList {
+			if len(cols) == 0 {
+				return nil
+			}
+			res := make(opt.OptionalColList, tab.ColumnCount())
+			for i, ord := range ords {
+				res[ord] = cols[i]
+			}
+			return res
+		}
+		newCols, oldCols := toRowCols(outNew), toRowCols(outOld)
+		return f.ConstructBarrier(b.buildTriggerCallQuery(
+			tab, tb.trigger, tb.eventType, input, filters, newCols, oldCols,
+		))
+	})
+}
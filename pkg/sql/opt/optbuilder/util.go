@@ -622,6 +622,11 @@ func (b *Builder) resolveTableForMutation(
 		panic(pgerror.Newf(pgcode.WrongObjectType, "cannot mutate materialized view %q", tab.Name()))
 	}
 
+	// Foreign tables are read from external storage, and cannot be mutated.
+	if tab.IsForeignTable() {
+		panic(pgerror.Newf(pgcode.WrongObjectType, "cannot mutate foreign table %q", tab.Name()))
+	}
+
 	return tab, depName, alias, columns
 }
 
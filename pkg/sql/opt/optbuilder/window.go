@@ -22,6 +22,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treewindow"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/errors"
 )
 
@@ -288,6 +289,11 @@ func (b *Builder) buildAggregationAsWindow(
 	// so that we can group functions over the same partition and ordering.
 	frames := make([]memo.WindowExpr, 0, len(g.aggs))
 	for i, agg := range g.aggs {
+		if agg.def.Overload.UDFAggregate != nil {
+			panic(unimplemented.NewWithIssuef(74775,
+				"user-defined aggregate %s cannot yet be used with ordered aggregates", agg.def.Name,
+			))
+		}
 		fn := b.constructAggregate(agg.def.Name, argLists[i])
 		if filterCols[i] != 0 {
 			fn = b.factory.ConstructAggFilter(
@@ -592,7 +598,7 @@ func (b *Builder) replaceDefaultReturn(
 // to be applied is also returned.
 func (b *Builder) overrideDefaultNullValue(agg aggregateInfo) (opt.ScalarExpr, bool) {
 	switch agg.def.Name {
-	case "count", "count_rows":
+	case "count", "count_rows", "regr_count":
 		return b.factory.ConstructConst(tree.NewDInt(0), types.Int), true
 	default:
 		return nil, false
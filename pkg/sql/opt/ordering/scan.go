@@ -49,6 +49,12 @@ func ScanIsReverse(scan *memo.ScanExpr, required *props.OrderingChoice) bool {
 func ScanPrivateCanProvide(
 	md *opt.Metadata, s *memo.ScanPrivate, required *props.OrderingChoice,
 ) (ok bool, reverse bool) {
+	if md.Table(s.Table).IsForeignTable() {
+		// The rows of a foreign table are read from its files in no particular
+		// order.
+		return required.Any(), false
+	}
+
 	// Scan naturally orders according to scanned index's key columns. A scan can
 	// be executed either as a forward or as a reverse scan (unless it has a row
 	// limit, in which case the direction is fixed).
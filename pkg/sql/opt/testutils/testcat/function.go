@@ -100,7 +100,7 @@ func (tc *Catalog) CreateRoutine(c *tree.CreateRoutine) {
 				Typ:  typ,
 			})
 		}
-		if param.Class == tree.RoutineParamOut {
+		if tree.IsOutOnlyParamClass(param.Class) {
 			outParamOrdinals = append(outParamOrdinals, int32(i))
 			outParams = append(outParams, tree.ParamType{Typ: typ})
 		}
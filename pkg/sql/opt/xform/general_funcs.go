@@ -52,8 +52,12 @@ func (c *CustomFuncs) Init(e *explorer) {
 
 // IsCanonicalScan returns true if the given ScanPrivate is an original
 // unaltered primary index Scan operator (i.e. unconstrained and not limited).
+//
+// Scans of foreign tables are never considered canonical, so that no
+// alternative scans or lookup joins are explored for them: foreign tables have
+// no indexes, and their rows can only be read in full from their files.
 func (c *CustomFuncs) IsCanonicalScan(scan *memo.ScanPrivate) bool {
-	return scan.IsCanonical()
+	return scan.IsCanonical() && !c.e.mem.Metadata().Table(scan.Table).IsForeignTable()
 }
 
 // HasInvertedIndexes returns true if at least one inverted index is defined on
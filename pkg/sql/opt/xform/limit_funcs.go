@@ -13,11 +13,14 @@ package xform
 import (
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/constraint"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/ordering"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/props/physical"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinconstants"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/vector"
 	"github.com/cockroachdb/errors"
 )
 
@@ -176,6 +179,161 @@ func (c *CustomFuncs) GenerateLimitedScans(
 	})
 }
 
+// GenerateVectorSearchScans generates a search of each vector index on the Scan
+// operator's table for the nearest neighbors of a constant vector, if the Limit
+// operator orders its input by the cosine distance between the indexed column
+// and the constant vector. Only the lists of the index which are closest to
+// the constant vector are scanned, so unlike the other rules in this file, the
+// result may differ from that of the original expression. The lists are
+// determined by locality-sensitive hashing of the direction of the vectors
+// (see vector.LSHList), so vector indexes cannot be used to order by Euclidean
+// distance or inner product, which also depend on their magnitude.
+//
+// A vector index is a secondary index whose first key column is the virtual
+// computed column crdb_internal.vector_lsh_list(col, lists). Its lists are
+// scanned with a constrained Scan, an IndexJoin is added if the index does not
+// cover the needed columns, and the original Project and Limit are rebuilt on
+// top of it.
+func (c *CustomFuncs) GenerateVectorSearchScans(
+	grp memo.RelExpr,
+	required *physical.Required,
+	limitExpr *memo.LimitExpr,
+	scanPrivate *memo.ScanPrivate,
+	projections memo.ProjectionsExpr,
+	passthrough opt.ColSet,
+) {
+	probes := c.e.evalCtx.SessionData().VectorSearchProbes
+	if probes <= 0 {
+		return
+	}
+	ord := &limitExpr.Ordering
+	if len(ord.Columns) == 0 || ord.Columns[0].Descending {
+		return
+	}
+	// Find the vector column and the constant vector whose cosine distance the
+	// rows are ordered by.
+	var vecCol opt.ColumnID
+	var query vector.T
+	for i := range projections {
+		if !ord.Columns[0].Group.Contains(projections[i].Col) {
+			continue
+		}
+		t, ok := projections[i].Element.(*memo.VectorCosDistanceExpr)
+		if !ok {
+			continue
+		}
+		left, right := t.Left, t.Right
+		if _, ok := left.(*memo.ConstExpr); ok {
+			left, right = right, left
+		}
+		v, ok := left.(*memo.VariableExpr)
+		if !ok || !scanPrivate.Cols.Contains(v.Col) {
+			continue
+		}
+		k, ok := right.(*memo.ConstExpr)
+		if !ok {
+			continue
+		}
+		d, ok := k.Value.(*tree.DPGVector)
+		if !ok {
+			continue
+		}
+		vecCol, query = v.Col, d.T
+		break
+	}
+	if vecCol == 0 {
+		return
+	}
+
+	md := c.e.mem.Metadata()
+	tabMeta := md.TableMeta(scanPrivate.Table)
+	var pkCols opt.ColSet
+	var sb indexScanBuilder
+	sb.Init(c, scanPrivate.Table)
+
+	var iter scanIndexIter
+	iter.Init(c.e.evalCtx, c.e, c.e.mem, &c.im, scanPrivate, nil /* filters */, rejectPrimaryIndex|rejectInvertedIndexes|rejectPartialIndexes)
+	iter.ForEach(func(index cat.Index, filters memo.FiltersExpr, indexCols opt.ColSet, isCovering bool, constProj memo.ProjectionsExpr) {
+		listCol := scanPrivate.Table.IndexColumnID(index, 0)
+		lists, ok := c.vectorIndexLists(tabMeta.ComputedCols[listCol], vecCol)
+		if !ok {
+			return
+		}
+		probeLists, err := vector.LSHProbes(query, lists, int(probes))
+		if err != nil {
+			// The constant vector cannot be searched for, e.g. because it has
+			// the wrong number of dimensions. Leave the error to execution.
+			return
+		}
+
+		var columns constraint.Columns
+		columns.InitSingle(opt.MakeOrderingColumn(listCol, index.Column(0).Descending))
+		keyCtx := constraint.MakeKeyContext(c.e.ctx, &columns, c.e.evalCtx)
+		var spans constraint.Spans
+		spans.Alloc(len(probeLists))
+		for _, list := range probeLists {
+			key := constraint.MakeKey(tree.NewDInt(tree.DInt(list)))
+			var span constraint.Span
+			span.Init(key, constraint.IncludeBoundary, key, constraint.IncludeBoundary)
+			spans.Append(&span)
+		}
+		spans.SortAndMerge(&keyCtx)
+		var cons constraint.Constraint
+		cons.Init(&keyCtx, &spans)
+
+		newScanPrivate := *scanPrivate
+		newScanPrivate.Distribution.Regions = nil
+		newScanPrivate.Index = index.Ordinal()
+		newScanPrivate.SetConstraint(c.e.ctx, c.e.evalCtx, &cons)
+
+		if isCovering {
+			sb.SetScan(&newScanPrivate)
+		} else {
+			if scanPrivate.Flags.NoIndexJoin {
+				return
+			}
+			// Calculate the PK columns once.
+			if pkCols.Empty() {
+				pkCols = c.PrimaryKeyCols(scanPrivate.Table)
+			}
+			newScanPrivate.Cols = indexCols.Intersection(scanPrivate.Cols)
+			newScanPrivate.Cols.UnionWith(pkCols)
+			sb.SetScan(&newScanPrivate)
+			sb.AddIndexJoin(scanPrivate.Cols)
+		}
+		input := c.e.f.ConstructProject(sb.BuildNewExpr(), projections, passthrough)
+		grp.Memo().AddLimitToGroup(&memo.LimitExpr{
+			Input:    input,
+			Limit:    limitExpr.Limit,
+			Ordering: limitExpr.Ordering,
+		}, grp)
+	})
+}
+
+// vectorIndexLists returns the number of lists of a vector index on the given
+// column, if computed is the expression of the first key column of a vector
+// index.
+func (c *CustomFuncs) vectorIndexLists(
+	computed opt.ScalarExpr, vecCol opt.ColumnID,
+) (lists int64, ok bool) {
+	fn, ok := computed.(*memo.FunctionExpr)
+	if !ok || fn.Name != builtinconstants.VectorLSHListBuiltinName || len(fn.Args) != 2 {
+		return 0, false
+	}
+	if v, ok := fn.Args[0].(*memo.VariableExpr); !ok || v.Col != vecCol {
+		return 0, false
+	}
+	k, ok := fn.Args[1].(*memo.ConstExpr)
+	if !ok {
+		return 0, false
+	}
+	d, ok := k.Value.(*tree.DInt)
+	if !ok {
+		return 0, false
+	}
+	return int64(*d), true
+}
+
 // ScanIsLimited returns true if the scan operator with the given ScanPrivate is
 // limited.
 func (c *CustomFuncs) ScanIsLimited(sp *memo.ScanPrivate) bool {
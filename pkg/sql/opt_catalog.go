@@ -38,6 +38,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catconstants"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/semenumpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
@@ -768,6 +769,13 @@ type optTable struct {
 	// constraints for user defined types.
 	checkConstraints []optCheckConstraint
 
+	// triggers is the set of triggers defined on this table.
+	triggers []optTrigger
+
+	// exclusionConstraints is the set of exclusion constraints defined on this
+	// table.
+	exclusionConstraints []optExclusionConstraint
+
 	// colMap is a mapping from unique ColumnID to column ordinal within the
 	// table. This is a common lookup that needs to be fast.
 	colMap catalog.TableColMap
@@ -902,12 +910,13 @@ func newOptTable(
 	ot.uniqueConstraints = make([]optUniqueConstraint, len(ot.desc.EnforcedUniqueConstraintsWithoutIndex()))
 	for i, u := range ot.desc.EnforcedUniqueConstraintsWithoutIndex() {
 		ot.uniqueConstraints[i] = optUniqueConstraint{
-			name:         u.GetName(),
-			table:        ot.ID(),
-			columns:      u.CollectKeyColumnIDs().Ordered(),
-			predicate:    u.GetPredicate(),
-			withoutIndex: true,
-			validity:     u.GetConstraintValidity(),
+			name:          u.GetName(),
+			table:         ot.ID(),
+			columns:       u.CollectKeyColumnIDs().Ordered(),
+			predicate:     u.GetPredicate(),
+			withoutIndex:  true,
+			validity:      u.GetConstraintValidity(),
+			deferrability: tree.ConstraintDeferrabilityType[u.Deferrability()],
 		}
 	}
 
@@ -1012,6 +1021,7 @@ func newOptTable(
 			match:             tree.CompositeKeyMatchMethodType[fk.Match()],
 			deleteAction:      tree.ForeignKeyReferenceActionType[fk.OnDelete()],
 			updateAction:      tree.ForeignKeyReferenceActionType[fk.OnUpdate()],
+			deferrability:     tree.ConstraintDeferrabilityType[fk.Deferrability()],
 		})
 	}
 	for _, fk := range ot.desc.InboundForeignKeys() {
@@ -1025,6 +1035,7 @@ func newOptTable(
 			match:             tree.CompositeKeyMatchMethodType[fk.Match()],
 			deleteAction:      tree.ForeignKeyReferenceActionType[fk.OnDelete()],
 			updateAction:      tree.ForeignKeyReferenceActionType[fk.OnUpdate()],
+			deferrability:     tree.ConstraintDeferrabilityType[fk.Deferrability()],
 		})
 	}
 
@@ -1079,6 +1090,40 @@ func newOptTable(
 	}
 	ot.checkConstraints = append(ot.checkConstraints, synthesizedChecks...)
 
+	triggers := desc.GetTriggers()
+	ot.triggers = make([]optTrigger, len(triggers))
+	for i := range triggers {
+		ot.triggers[i] = optTrigger{desc: &triggers[i]}
+	}
+
+	exclusionConstraints := desc.GetExclusionConstraints()
+	ot.exclusionConstraints = make([]optExclusionConstraint, len(exclusionConstraints))
+	for i := range exclusionConstraints {
+		ec := &exclusionConstraints[i]
+		oe := &ot.exclusionConstraints[i]
+		oe.name = ec.Name
+		oe.columnOrdinals = make([]int, len(ec.ColumnIDs))
+		oe.operators = make([]treecmp.ComparisonOperatorSymbol, len(ec.ColumnIDs))
+		for j, colID := range ec.ColumnIDs {
+			ord, err := ot.lookupColumnOrdinal(colID)
+			if err != nil {
+				return nil, err
+			}
+			oe.columnOrdinals[j] = ord
+			switch ec.Operators[j] {
+			case treecmp.EQ.String():
+				oe.operators[j] = treecmp.EQ
+			case treecmp.Overlaps.String():
+				oe.operators[j] = treecmp.Overlaps
+			case treecmp.Adjacent.String():
+				oe.operators[j] = treecmp.Adjacent
+			default:
+				return nil, errors.AssertionFailedf(
+					"unknown operator %q in exclusion constraint %q", ec.Operators[j], ec.Name)
+			}
+		}
+	}
+
 	// Add stats last, now that other metadata is initialized.
 	if stats != nil {
 		ot.stats = make([]optTableStat, len(stats))
@@ -1200,6 +1245,11 @@ func (ot *optTable) IsMaterializedView() bool {
 	return ot.desc.MaterializedView()
 }
 
+// IsForeignTable implements the cat.Table interface.
+func (ot *optTable) IsForeignTable() bool {
+	return ot.desc.IsForeignTable()
+}
+
 // ColumnCount is part of the cat.Table interface.
 func (ot *optTable) ColumnCount() int {
 	return len(ot.columns)
@@ -1389,6 +1439,26 @@ func (ot *optTable) IsHypothetical() bool {
 	return false
 }
 
+// TriggerCount is part of the cat.Table interface.
+func (ot *optTable) TriggerCount() int {
+	return len(ot.triggers)
+}
+
+// Trigger is part of the cat.Table interface.
+func (ot *optTable) Trigger(i int) cat.Trigger {
+	return &ot.triggers[i]
+}
+
+// ExclusionCount is part of the cat.Table interface.
+func (ot *optTable) ExclusionCount() int {
+	return len(ot.exclusionConstraints)
+}
+
+// Exclusion is part of the cat.Table interface.
+func (ot *optTable) Exclusion(i int) cat.ExclusionConstraint {
+	return &ot.exclusionConstraints[i]
+}
+
 // lookupColumnOrdinal returns the ordinal of the column with the given ID. A
 // cache makes the lookup O(1).
 func (ot *optTable) lookupColumnOrdinal(colID descpb.ColumnID) (int, error) {
@@ -1778,6 +1848,115 @@ func (oc *optCheckConstraint) ColumnOrdinal(i int) int {
 	return ord
 }
 
+// optExclusionConstraint implements cat.ExclusionConstraint. See that
+// interface for more information on the fields.
+type optExclusionConstraint struct {
+	name           string
+	columnOrdinals []int
+	operators      []treecmp.ComparisonOperatorSymbol
+}
+
+var _ cat.ExclusionConstraint = &optExclusionConstraint{}
+
+// Name is part of the cat.ExclusionConstraint interface.
+func (oe *optExclusionConstraint) Name() string {
+	return oe.name
+}
+
+// ColumnCount is part of the cat.ExclusionConstraint interface.
+func (oe *optExclusionConstraint) ColumnCount() int {
+	return len(oe.columnOrdinals)
+}
+
+// ColumnOrdinal is part of the cat.ExclusionConstraint interface.
+func (oe *optExclusionConstraint) ColumnOrdinal(i int) int {
+	return oe.columnOrdinals[i]
+}
+
+// Operator is part of the cat.ExclusionConstraint interface.
+func (oe *optExclusionConstraint) Operator(i int) treecmp.ComparisonOperatorSymbol {
+	return oe.operators[i]
+}
+
+// optTrigger implements cat.Trigger. See that interface for more information
+// on the fields.
+type optTrigger struct {
+	desc *descpb.TriggerDescriptor
+}
+
+var _ cat.Trigger = &optTrigger{}
+
+// Name is part of the cat.Trigger interface.
+func (ot *optTrigger) Name() tree.Name {
+	return tree.Name(ot.desc.Name)
+}
+
+// ActionTime is part of the cat.Trigger interface.
+func (ot *optTrigger) ActionTime() tree.TriggerActionTime {
+	switch ot.desc.ActionTime {
+	case semenumpb.TriggerActionTime_BEFORE:
+		return tree.TriggerActionTimeBefore
+	case semenumpb.TriggerActionTime_AFTER:
+		return tree.TriggerActionTimeAfter
+	case semenumpb.TriggerActionTime_INSTEAD_OF:
+		return tree.TriggerActionTimeInsteadOf
+	}
+	return tree.TriggerActionTimeUnknown
+}
+
+// EventCount is part of the cat.Trigger interface.
+func (ot *optTrigger) EventCount() int {
+	return len(ot.desc.Events)
+}
+
+// Event is part of the cat.Trigger interface.
+func (ot *optTrigger) Event(i int) cat.TriggerEvent {
+	event := &ot.desc.Events[i]
+	res := cat.TriggerEvent{EventType: tree.TriggerEventTypeUnknown}
+	switch event.Type {
+	case semenumpb.TriggerEventType_INSERT:
+		res.EventType = tree.TriggerEventInsert
+	case semenumpb.TriggerEventType_UPDATE:
+		res.EventType = tree.TriggerEventUpdate
+	case semenumpb.TriggerEventType_DELETE:
+		res.EventType = tree.TriggerEventDelete
+	case semenumpb.TriggerEventType_TRUNCATE:
+		res.EventType = tree.TriggerEventTruncate
+	}
+	if len(event.ColumnNames) > 0 {
+		res.Columns = make([]tree.Name, len(event.ColumnNames))
+		for j := range event.ColumnNames {
+			res.Columns[j] = tree.Name(event.ColumnNames[j])
+		}
+	}
+	return res
+}
+
+// ForEachRow is part of the cat.Trigger interface.
+func (ot *optTrigger) ForEachRow() bool {
+	return ot.desc.ForEachRow
+}
+
+// WhenExpr is part of the cat.Trigger interface.
+func (ot *optTrigger) WhenExpr() string {
+	return ot.desc.WhenExpr
+}
+
+// FuncID is part of the cat.Trigger interface.
+func (ot *optTrigger) FuncID() cat.StableID {
+	return cat.StableID(ot.desc.FuncID)
+}
+
+// FuncArgs is part of the cat.Trigger interface.
+func (ot *optTrigger) FuncArgs() []string {
+	return ot.desc.FuncArgs
+}
+
+// Enabled is part of the cat.Trigger interface.
+func (ot *optTrigger) Enabled() bool {
+	return ot.desc.Enabled
+}
+
 type optTableStat struct {
 	stat           *stats.TableStatistic
 	columnOrdinals []int
@@ -1955,8 +2134,9 @@ type optUniqueConstraint struct {
 	columns   []descpb.ColumnID
 	predicate string
 
-	withoutIndex bool
-	validity     descpb.ConstraintValidity
+	withoutIndex  bool
+	validity      descpb.ConstraintValidity
+	deferrability tree.ConstraintDeferrability
 
 	uniquenessGuaranteedByAnotherIndex bool
 }
@@ -2001,9 +2181,13 @@ func (u *optUniqueConstraint) WithoutIndex() bool {
 	return u.withoutIndex
 }
 
-// Validated is part of the cat.UniqueConstraint interface.
+// Validated is part of the cat.UniqueConstraint interface. Deferrable
+// constraints are never reported as validated, since their checks may be
+// postponed until the transaction commits and the data may violate them in
+// the meantime.
 func (u *optUniqueConstraint) Validated() bool {
-	return u.validity == descpb.ConstraintValidity_Validated
+	return u.validity == descpb.ConstraintValidity_Validated &&
+		u.deferrability == tree.ConstraintNotDeferrable
 }
 
 // UniquenessGuaranteedByAnotherIndex is part of the cat.UniqueConstraint
@@ -2014,6 +2198,11 @@ func (u *optUniqueConstraint) UniquenessGuaranteedByAnotherIndex() bool {
 	return u.uniquenessGuaranteedByAnotherIndex
 }
 
+// Deferrability is part of the cat.UniqueConstraint interface.
+func (u *optUniqueConstraint) Deferrability() tree.ConstraintDeferrability {
+	return u.deferrability
+}
+
 // optForeignKeyConstraint implements cat.ForeignKeyConstraint and represents a
 // foreign key relationship. Both the origin and the referenced table store the
 // same optForeignKeyConstraint (as an outbound and inbound reference,
@@ -2027,10 +2216,11 @@ type optForeignKeyConstraint struct {
 	referencedTable   cat.StableID
 	referencedColumns []descpb.ColumnID
 
-	validity     descpb.ConstraintValidity
-	match        tree.CompositeKeyMatchMethod
-	deleteAction tree.ReferenceAction
-	updateAction tree.ReferenceAction
+	validity      descpb.ConstraintValidity
+	match         tree.CompositeKeyMatchMethod
+	deleteAction  tree.ReferenceAction
+	updateAction  tree.ReferenceAction
+	deferrability tree.ConstraintDeferrability
 }
 
 var _ cat.ForeignKeyConstraint = &optForeignKeyConstraint{}
@@ -2082,9 +2272,12 @@ func (fk *optForeignKeyConstraint) ReferencedColumnOrdinal(referencedTable cat.T
 	return ord
 }
 
-// Validated is part of the cat.ForeignKeyConstraint interface.
+// Validated is part of the cat.ForeignKeyConstraint interface. See
+// optUniqueConstraint.Validated for why deferrable foreign keys are never
+// reported as validated.
 func (fk *optForeignKeyConstraint) Validated() bool {
-	return fk.validity == descpb.ConstraintValidity_Validated
+	return fk.validity == descpb.ConstraintValidity_Validated &&
+		fk.deferrability == tree.ConstraintNotDeferrable
 }
 
 // MatchMethod is part of the cat.ForeignKeyConstraint interface.
@@ -2102,6 +2295,11 @@ func (fk *optForeignKeyConstraint) UpdateReferenceAction() tree.ReferenceAction
 	return fk.updateAction
 }
 
+// Deferrability is part of the cat.ForeignKeyConstraint interface.
+func (fk *optForeignKeyConstraint) Deferrability() tree.ConstraintDeferrability {
+	return fk.deferrability
+}
+
 // optVirtualTable is similar to optTable but is used with virtual tables.
 type optVirtualTable struct {
 	desc catalog.TableDescriptor
@@ -2295,6 +2493,11 @@ func (ot *optVirtualTable) IsMaterializedView() bool {
 	return false
 }
 
+// IsForeignTable implements the cat.Table interface.
+func (ot *optVirtualTable) IsForeignTable() bool {
+	return false
+}
+
 // ColumnCount is part of the cat.Table interface.
 func (ot *optVirtualTable) ColumnCount() int {
 	return len(ot.columns)
@@ -2449,6 +2652,26 @@ func (ot *optVirtualTable) IsHypothetical() bool {
 	return false
 }
 
+// TriggerCount is part of the cat.Table interface.
+func (ot *optVirtualTable) TriggerCount() int {
+	return 0
+}
+
+// Trigger is part of the cat.Table interface.
+func (ot *optVirtualTable) Trigger(i int) cat.Trigger {
+	panic(errors.AssertionFailedf("no triggers"))
+}
+
+// ExclusionCount is part of the cat.Table interface.
+func (ot *optVirtualTable) ExclusionCount() int {
+	return 0
+}
+
+// Exclusion is part of the cat.Table interface.
+func (ot *optVirtualTable) Exclusion(i int) cat.ExclusionConstraint {
+	panic(errors.AssertionFailedf("no exclusion constraints"))
+}
+
 // CollectTypes is part of the cat.DataSource interface.
 func (ot *optVirtualTable) CollectTypes(ord int) (descpb.IDs, error) {
 	col := ot.desc.AllColumns()[ord]
@@ -33,6 +33,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/constraint"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec/explain"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/row"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowcontainer"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins"
@@ -135,6 +137,9 @@ func (ef *execFactory) ConstructScan(
 	if table.IsVirtualTable() {
 		return ef.constructVirtualScan(table, index, params, reqOrdering)
 	}
+	if table.IsForeignTable() {
+		return ef.constructForeignScan(table, params, reqOrdering)
+	}
 
 	tabDesc := table.(*optTable).desc
 	idx := index.(*optIndex).idx
@@ -223,6 +228,12 @@ func asDataSource(n exec.Node) planDataSource {
 func (ef *execFactory) ConstructFilter(
 	n exec.Node, filter tree.TypedExpr, reqOrdering exec.OutputOrdering,
 ) (exec.Node, error) {
+	// Filters on foreign tables are applied while the files are read.
+	if scan, ok := n.(*foreignScanNode); ok && scan.filter == nil && scan.hardLimit == 0 {
+		scan.filter = filter
+		return scan, nil
+	}
+
 	// Create a filterNode.
 	src := asDataSource(n)
 	f := &filterNode{
@@ -544,6 +555,7 @@ func (ef *execFactory) addAggregations(n *groupNode, aggregations []exec.AggInfo
 			agg.DistsqlBlocklist,
 		)
 		f.filterRenderIdx = int(agg.Filter)
+		f.userDefined = agg.UserDefined
 
 		n.funcs = append(n.funcs, f)
 	}
@@ -715,6 +727,10 @@ func (ef *execFactory) ConstructLookupJoin(
 	if table.IsVirtualTable() {
 		return ef.constructVirtualTableLookupJoin(joinType, input, table, index, eqCols, lookupCols, onCond)
 	}
+	if table.IsForeignTable() {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"lookup joins into foreign table %q are not supported", table.Name())
+	}
 	tabDesc := table.(*optTable).desc
 	idx := index.(*optIndex).idx
 	colCfg := makeScanColumnsConfig(table, lookupCols)
@@ -105,6 +105,9 @@ func TestContextualHelp(t *testing.T) {
 		{`ALTER TYPE t RENAME ??`, `ALTER TYPE`},
 		{`ALTER TYPE t DROP VALUE ??`, `ALTER TYPE`},
 
+		{`ALTER DOMAIN ??`, `ALTER DOMAIN`},
+		{`ALTER DOMAIN d ??`, `ALTER DOMAIN`},
+
 		{`ALTER INDEX foo@bar RENAME ??`, `ALTER INDEX`},
 		{`ALTER INDEX foo@bar RENAME TO blih ??`, `ALTER INDEX`},
 		{`ALTER INDEX foo@bar SPLIT ??`, `ALTER INDEX`},
@@ -179,6 +182,13 @@ func TestContextualHelp(t *testing.T) {
 
 		{`CREATE EXTERNAL CONNECTION ??`, `CREATE EXTERNAL CONNECTION`},
 
+		{`CREATE SERVER ??`, `CREATE SERVER`},
+		{`CREATE SERVER s FOREIGN ??`, `CREATE SERVER`},
+		{`DROP SERVER ??`, `DROP SERVER`},
+
+		{`CREATE FOREIGN TABLE ??`, `CREATE FOREIGN TABLE`},
+		{`CREATE FOREIGN TABLE t (a INT) ??`, `CREATE FOREIGN TABLE`},
+
 		{`CREATE VIRTUAL CLUSTER ??`, `CREATE VIRTUAL CLUSTER`},
 		{`CREATE TENANT ??`, `CREATE VIRTUAL CLUSTER`},
 
@@ -209,6 +219,11 @@ func TestContextualHelp(t *testing.T) {
 
 		{`CREATE TYPE blah AS ENUM ??`, `CREATE TYPE`},
 		{`DROP TYPE ??`, `DROP TYPE`},
+		{`CREATE DOMAIN ??`, `CREATE DOMAIN`},
+		{`CREATE AGGREGATE ??`, `CREATE AGGREGATE`},
+		{`CREATE OR REPLACE AGGREGATE ??`, `CREATE AGGREGATE`},
+		{`DROP DOMAIN ??`, `DROP DOMAIN`},
+		{`DROP AGGREGATE ??`, `DROP AGGREGATE`},
 
 		{`CREATE SCHEMA IF ??`, `CREATE SCHEMA`},
 		{`CREATE SCHEMA IF NOT ??`, `CREATE SCHEMA`},
@@ -222,6 +237,9 @@ func TestContextualHelp(t *testing.T) {
 		{`DISCARD ALL ??`, `DISCARD`},
 		{`DISCARD ??`, `DISCARD`},
 
+		{`DO ??`, `DO`},
+		{`DO LANGUAGE ??`, `DO`},
+
 		{`DROP ??`, `DROP`},
 
 		{`DROP DATABASE IF ??`, `DROP DATABASE`},
@@ -247,6 +265,7 @@ func TestContextualHelp(t *testing.T) {
 		{`DROP TABLE blah ??`, `DROP TABLE`},
 		{`DROP TABLE IF ??`, `DROP TABLE`},
 		{`DROP TABLE IF EXISTS blih, bloh ??`, `DROP TABLE`},
+		{`DROP FOREIGN TABLE blah ??`, `DROP TABLE`},
 
 		{`DROP VIEW blah ??`, `DROP VIEW`},
 		{`DROP VIEW IF ??`, `DROP VIEW`},
@@ -306,6 +325,14 @@ func TestContextualHelp(t *testing.T) {
 		{`INSERT INTO blah VALUES (1) ??`, `VALUES`},
 		{`INSERT INTO blah TABLE foo ??`, `TABLE`},
 
+		{`MERGE ??`, `MERGE`},
+		{`MERGE INTO blah USING foo ON true ??`, `MERGE`},
+
+		{`LISTEN ??`, `LISTEN`},
+
+		{`NOTIFY ??`, `NOTIFY`},
+		{`NOTIFY foo, ??`, `NOTIFY`},
+
 		{`UPSERT INTO ??`, `UPSERT`},
 		{`UPSERT INTO blah (??`, `<SELECTCLAUSE>`},
 		{`UPSERT INTO blah VALUES (1) RETURNING ??`, `UPSERT`},
@@ -521,6 +548,7 @@ func TestContextualHelp(t *testing.T) {
 
 		{`SET SESSION TRANSACTION ??`, `SET TRANSACTION`},
 		{`SET SESSION TRANSACTION ISOLATION LEVEL SNAPSHOT ??`, `SET TRANSACTION`},
+		{`SET CONSTRAINTS ??`, `SET CONSTRAINTS`},
 		{`SET SESSION TIME ??`, `SET SESSION`},
 		{`SET SESSION TIME ZONE 'UTC' ??`, `SET SESSION`},
 		{`SET SESSION blah TO ??`, `SET SESSION`},
@@ -593,6 +621,16 @@ func TestContextualHelp(t *testing.T) {
 		{`CREATE PROCEDURE ??`, `CREATE PROCEDURE`},
 		{`ALTER PROCEDURE ??`, `ALTER PROCEDURE`},
 		{`DROP PROCEDURE ??`, `DROP PROCEDURE`},
+
+		{`CREATE TRIGGER ??`, `CREATE TRIGGER`},
+		{`CREATE TRIGGER foo AFTER ??`, `CREATE TRIGGER`},
+		{`DROP TRIGGER ??`, `DROP TRIGGER`},
+
+		{`CREATE PUBLICATION ??`, `CREATE PUBLICATION`},
+		{`CREATE PUBLICATION p FOR ??`, `CREATE PUBLICATION`},
+		{`ALTER PUBLICATION ??`, `ALTER PUBLICATION`},
+		{`ALTER PUBLICATION p ADD TABLE ??`, `ALTER PUBLICATION`},
+		{`DROP PUBLICATION ??`, `DROP PUBLICATION`},
 	}
 
 	// The following checks that the test definition above exercises all
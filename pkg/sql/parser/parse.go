@@ -462,10 +462,15 @@ func newDecimal(prec, scale int32) (*types.T, error) {
 }
 
 // arrayOf creates a type alias for an array of the given element type and fixed
-// bounds. The bounds are currently ignored.
+// bounds. Each bound adds a dimension to the array, but the bounds themselves
+// are ignored, as in Postgres. A nil bounds is treated as a single dimension.
 func arrayOf(
 	ref tree.ResolvableTypeReference, bounds []int32,
 ) (tree.ResolvableTypeReference, error) {
+	dims := len(bounds)
+	if dims == 0 {
+		dims = 1
+	}
 	// If the reference is a statically known type, then return an array type,
 	// rather than an array type reference.
 	if typ, ok := tree.GetStaticallyKnownType(ref); ok {
@@ -479,7 +484,13 @@ func arrayOf(
 		if err := types.CheckArrayElementType(typ); err != nil {
 			return nil, err
 		}
-		return types.MakeArray(typ), nil
+		for i := 0; i < dims; i++ {
+			typ = types.MakeArray(typ)
+		}
+		return typ, nil
+	}
+	for i := 0; i < dims; i++ {
+		ref = &tree.ArrayTypeReference{ElementType: ref}
 	}
-	return &tree.ArrayTypeReference{ElementType: ref}, nil
+	return ref, nil
 }
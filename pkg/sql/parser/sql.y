@@ -307,6 +307,12 @@ func (u *sqlSymUnion) enumValueList() tree.EnumValueList {
 func (u *sqlSymUnion) compositeTypeList() []tree.CompositeTypeElem {
     return u.val.([]tree.CompositeTypeElem)
 }
+func (u *sqlSymUnion) domainConstraint() tree.DomainConstraint {
+    return u.val.(tree.DomainConstraint)
+}
+func (u *sqlSymUnion) domainConstraints() []tree.DomainConstraint {
+    return u.val.([]tree.DomainConstraint)
+}
 func (u *sqlSymUnion) unresolvedName() *tree.UnresolvedName {
     return u.val.(*tree.UnresolvedName)
 }
@@ -526,6 +532,12 @@ func (u *sqlSymUnion) updateExpr() *tree.UpdateExpr {
 func (u *sqlSymUnion) updateExprs() tree.UpdateExprs {
     return u.val.(tree.UpdateExprs)
 }
+func (u *sqlSymUnion) mergeWhen() *tree.MergeWhen {
+    return u.val.(*tree.MergeWhen)
+}
+func (u *sqlSymUnion) mergeWhens() tree.MergeWhens {
+    return u.val.(tree.MergeWhens)
+}
 func (u *sqlSymUnion) limit() *tree.Limit {
     return u.val.(*tree.Limit)
 }
@@ -640,6 +652,12 @@ func (u *sqlSymUnion) idxElem() tree.IndexElem {
 func (u *sqlSymUnion) idxElems() tree.IndexElemList {
     return u.val.(tree.IndexElemList)
 }
+func (u *sqlSymUnion) exclusionElem() tree.ExclusionElem {
+    return u.val.(tree.ExclusionElem)
+}
+func (u *sqlSymUnion) exclusionElems() tree.ExclusionElemList {
+    return u.val.(tree.ExclusionElemList)
+}
 func (u *sqlSymUnion) indexInvisibility() tree.IndexInvisibility {
     return u.val.(tree.IndexInvisibility)
 }
@@ -715,6 +733,9 @@ func (u *sqlSymUnion) backupOptions() *tree.BackupOptions {
 func (u *sqlSymUnion) copyOptions() *tree.CopyOptions {
   return u.val.(*tree.CopyOptions)
 }
+func (u *sqlSymUnion) copyForceColumns() *tree.CopyForceColumns {
+  return u.val.(*tree.CopyForceColumns)
+}
 func (u *sqlSymUnion) showJobOptions() *tree.ShowJobOptions {
   return u.val.(*tree.ShowJobOptions)
 }
@@ -733,6 +754,9 @@ func (u *sqlSymUnion) transactionModes() tree.TransactionModes {
 func (u *sqlSymUnion) compositeKeyMatchMethod() tree.CompositeKeyMatchMethod {
   return u.val.(tree.CompositeKeyMatchMethod)
 }
+func (u *sqlSymUnion) constraintDeferrability() tree.ConstraintDeferrability {
+  return u.val.(tree.ConstraintDeferrability)
+}
 func (u *sqlSymUnion) referenceAction() tree.ReferenceAction {
     return u.val.(tree.ReferenceAction)
 }
@@ -842,6 +866,12 @@ func (u *sqlSymUnion) routineOptions() tree.RoutineOptions {
 func (u *sqlSymUnion) functionOption() tree.RoutineOption {
     return u.val.(tree.RoutineOption)
 }
+func (u *sqlSymUnion) aggregateOptions() tree.AggregateOptions {
+    return u.val.(tree.AggregateOptions)
+}
+func (u *sqlSymUnion) aggregateOption() tree.AggregateOption {
+    return u.val.(tree.AggregateOption)
+}
 func (u *sqlSymUnion) routineParams() tree.RoutineParams {
     return u.val.(tree.RoutineParams)
 }
@@ -863,6 +893,30 @@ func (u *sqlSymUnion) functionObj() tree.RoutineObj {
 func (u *sqlSymUnion) routineObjs() tree.RoutineObjs {
     return u.val.(tree.RoutineObjs)
 }
+func (u *sqlSymUnion) triggerActionTime() tree.TriggerActionTime {
+    return u.val.(tree.TriggerActionTime)
+}
+func (u *sqlSymUnion) triggerEvent() *tree.TriggerEvent {
+    return u.val.(*tree.TriggerEvent)
+}
+func (u *sqlSymUnion) triggerEvents() []*tree.TriggerEvent {
+    return u.val.([]*tree.TriggerEvent)
+}
+func (u *sqlSymUnion) triggerTransition() *tree.TriggerTransition {
+    return u.val.(*tree.TriggerTransition)
+}
+func (u *sqlSymUnion) triggerTransitions() []*tree.TriggerTransition {
+    return u.val.([]*tree.TriggerTransition)
+}
+func (u *sqlSymUnion) triggerForEach() tree.TriggerForEach {
+    return u.val.(tree.TriggerForEach)
+}
+func (u *sqlSymUnion) publicationTargets() *tree.PublicationTargets {
+    return u.val.(*tree.PublicationTargets)
+}
+func (u *sqlSymUnion) alterPublicationCommand() tree.AlterPublicationCommand {
+    return u.val.(tree.AlterPublicationCommand)
+}
 func (u *sqlSymUnion) tenantReplicationOptions() *tree.TenantReplicationOptions {
   return u.val.(*tree.TenantReplicationOptions)
 }
@@ -915,7 +969,7 @@ func (u *sqlSymUnion) logicalReplicationOptions() *tree.LogicalReplicationOption
 // below; search this file for "Keyword category lists".
 
 // Ordinary key words in alphabetical order.
-%token <str> ABORT ABSOLUTE ACCESS ACTION ADD ADMIN AFTER AGGREGATE
+%token <str> ABORT ABSOLUTE ACCESS ACTION ADD ADJACENT ADMIN AFTER AGGREGATE
 %token <str> ALL ALTER ALWAYS ANALYSE ANALYZE AND AND_AND ANY ANNOTATE_TYPE ARRAY AS ASC AS_JSON AT_AT
 %token <str> ASENSITIVE ASYMMETRIC AT ATOMIC ATTRIBUTE AUTHORIZATION AUTOMATIC AVAILABILITY
 
@@ -925,7 +979,7 @@ func (u *sqlSymUnion) logicalReplicationOptions() *tree.LogicalReplicationOption
 
 %token <str> CACHE CALL CALLED CANCEL CANCELQUERY CAPABILITIES CAPABILITY CASCADE CASE CAST CBRT CHANGEFEED CHAR
 %token <str> CHARACTER CHARACTERISTICS CHECK CHECK_FILES CLOSE
-%token <str> CLUSTER CLUSTERS COALESCE COLLATE COLLATION COLUMN COLUMNS COMMENT COMMENTS COMMIT
+%token <str> CLUSTER CLUSTERS COALESCE COLLATE COLLATION COLUMN COLUMNS COMBINEFUNC COMMENT COMMENTS COMMIT
 %token <str> COMMITTED COMPACT COMPLETE COMPLETIONS CONCAT CONCURRENTLY CONFIGURATION CONFIGURATIONS CONFIGURE
 %token <str> CONFLICT CONNECTION CONNECTIONS CONSTRAINT CONSTRAINTS CONTAINS CONTROLCHANGEFEED CONTROLJOB
 %token <str> CONVERSION CONVERT COPY COS_DISTANCE COST COVERING CREATE CREATEDB CREATELOGIN CREATEROLE
@@ -937,14 +991,14 @@ func (u *sqlSymUnion) logicalReplicationOptions() *tree.LogicalReplicationOption
 %token <str> DEALLOCATE DECLARE DEFERRABLE DEFERRED DELETE DELIMITER DEPENDS DESC DESTINATION DETACHED DETAILS
 %token <str> DISCARD DISTANCE DISTINCT DO DOMAIN DOUBLE DROP
 
-%token <str> ELSE ENCODING ENCRYPTED ENCRYPTION_INFO_DIR ENCRYPTION_PASSPHRASE END ENUM ENUMS ESCAPE EXCEPT EXCLUDE EXCLUDING
+%token <str> EACH ELSE ENCODING ENCRYPTED ENCRYPTION_INFO_DIR ENCRYPTION_PASSPHRASE END ENUM ENUMS ESCAPE EXCEPT EXCLUDE EXCLUDING
 %token <str> EXISTS EXECUTE EXECUTION EXPERIMENTAL
 %token <str> EXPERIMENTAL_FINGERPRINTS EXPERIMENTAL_REPLICA
 %token <str> EXPERIMENTAL_AUDIT EXPERIMENTAL_RELOCATE
 %token <str> EXPIRATION EXPLAIN EXPORT EXTENSION EXTERNAL EXTRACT EXTRACT_DURATION EXTREMES
 
 %token <str> FAILURE FALSE FAMILY FETCH FETCHVAL FETCHTEXT FETCHVAL_PATH FETCHTEXT_PATH
-%token <str> FILES FILTER
+%token <str> FILES FILTER FINALFUNC
 %token <str> FIRST FLOAT FLOAT4 FLOAT8 FLOORDIV FOLLOWING FOR FORCE FORCE_INDEX FORCE_INVERTED_INDEX
 %token <str> FORCE_NOT_NULL FORCE_NULL FORCE_QUOTE FORCE_ZIGZAG
 %token <str> FOREIGN FORMAT FORWARD FREEZE FROM FULL FUNCTION FUNCTIONS
@@ -959,9 +1013,9 @@ func (u *sqlSymUnion) logicalReplicationOptions() *tree.LogicalReplicationOption
 %token <str> IF IFERROR IFNULL IGNORE_FOREIGN_KEYS ILIKE IMMEDIATE IMMEDIATELY IMMUTABLE IMPORT IN INCLUDE
 %token <str> INCLUDING INCLUDE_ALL_SECONDARY_TENANTS INCLUDE_ALL_VIRTUAL_CLUSTERS INCREMENT INCREMENTAL INCREMENTAL_LOCATION
 %token <str> INET INET_CONTAINED_BY_OR_EQUALS
-%token <str> INET_CONTAINS_OR_EQUALS INDEX INDEXES INHERITS INJECT INITIALLY
+%token <str> INET_CONTAINS_OR_EQUALS INDEX INDEXES INHERITS INITCOND INJECT INITIALLY
 %token <str> INDEX_BEFORE_PAREN INDEX_BEFORE_NAME_THEN_PAREN INDEX_AFTER_ORDER_BY_BEFORE_AT
-%token <str> INNER INOUT INPUT INSENSITIVE INSERT INT INTEGER
+%token <str> INNER INOUT INPUT INSENSITIVE INSERT INSTEAD INT INTEGER
 %token <str> INTERSECT INTERVAL INTO INTO_DB INVERTED INVOKER IS ISERROR ISNULL ISOLATION
 
 %token <str> JOB JOBS JOIN JSON JSONB JSON_SOME_EXISTS JSON_ALL_EXISTS
@@ -971,21 +1025,21 @@ func (u *sqlSymUnion) logicalReplicationOptions() *tree.LogicalReplicationOption
 %token <str> LABEL LANGUAGE LAST LATERAL LATEST LC_CTYPE LC_COLLATE
 %token <str> LEADING LEASE LEAST LEAKPROOF LEFT LESS LEVEL LIKE LIMIT
 %token <str> LINESTRING LINESTRINGM LINESTRINGZ LINESTRINGZM
-%token <str> LIST LOCAL LOCALITY LOCALTIME LOCALTIMESTAMP LOCKED LOGICAL LOGIN LOOKUP LOW LSHIFT
+%token <str> LIST LISTEN LOCAL LOCALITY LOCALTIME LOCALTIMESTAMP LOCKED LOGICAL LOGIN LOOKUP LOW LSHIFT
 
-%token <str> MATCH MATERIALIZED MERGE MINVALUE MAXVALUE METHOD MINUTE MODIFYCLUSTERSETTING MODIFYSQLCLUSTERSETTING MODE MONTH MOVE
+%token <str> MATCH MATCHED MATERIALIZED MERGE MINVALUE MAXVALUE METHOD MINUTE MODIFYCLUSTERSETTING MODIFYSQLCLUSTERSETTING MODE MONTH MOVE
 %token <str> MULTILINESTRING MULTILINESTRINGM MULTILINESTRINGZ MULTILINESTRINGZM
 %token <str> MULTIPOINT MULTIPOINTM MULTIPOINTZ MULTIPOINTZM
 %token <str> MULTIPOLYGON MULTIPOLYGONM MULTIPOLYGONZ MULTIPOLYGONZM
 
-%token <str> NAN NAME NAMES NATURAL NEG_INNER_PRODUCT NEVER NEW_DB_NAME NEW_KMS NEXT NO NOCANCELQUERY NOCONTROLCHANGEFEED
+%token <str> NAN NAME NAMES NATURAL NEG_INNER_PRODUCT NEVER NEW NEW_DB_NAME NEW_KMS NEXT NO NOCANCELQUERY NOCONTROLCHANGEFEED
 %token <str> NOCONTROLJOB NOCREATEDB NOCREATELOGIN NOCREATEROLE NODE NOLOGIN NOMODIFYCLUSTERSETTING NOREPLICATION
 %token <str> NOSQLLOGIN NO_INDEX_JOIN NO_ZIGZAG_JOIN NO_FULL_SCAN NONE NONVOTERS NORMAL NOT
-%token <str> NOTHING NOTHING_AFTER_RETURNING
+%token <str> NOTHING NOTHING_AFTER_RETURNING NOTIFY
 %token <str> NOTNULL
 %token <str> NOVIEWACTIVITY NOVIEWACTIVITYREDACTED NOVIEWCLUSTERSETTING NOWAIT NULL NULLIF NULLS NUMERIC
 
-%token <str> OF OFF OFFSET OID OIDS OIDVECTOR OLD_KMS ON ONLY OPT OPTION OPTIONS OR
+%token <str> OF OFF OFFSET OID OIDS OIDVECTOR OLD OLD_KMS ON ONLY OPT OPTION OPTIONS OR
 %token <str> ORDER ORDINALITY OTHERS OUT OUTER OVER OVERLAPS OVERLAY OWNED OWNER OPERATOR
 
 %token <str> PARALLEL PARENT PARTIAL PARTITION PARTITIONS PASSWORD PAUSE PAUSED PER PHYSICAL PLACEMENT PLACING
@@ -995,7 +1049,7 @@ func (u *sqlSymUnion) logicalReplicationOptions() *tree.LogicalReplicationOption
 
 %token <str> QUERIES QUERY QUOTE
 
-%token <str> RANGE RANGES READ REAL REASON REASSIGN RECURSIVE RECURRING REDACT REF REFERENCES REFRESH
+%token <str> RANGE RANGES READ REAL REASON REASSIGN RECURSIVE RECURRING REDACT REF REFERENCES REFERENCING REFRESH
 %token <str> REGCLASS REGION REGIONAL REGIONS REGNAMESPACE REGPROC REGPROCEDURE REGROLE REGTYPE REINDEX
 %token <str> RELATIVE RELOCATE REMOVE_PATH REMOVE_REGIONS RENAME REPEATABLE REPLACE REPLICATION
 %token <str> RELEASE RESET RESTART RESTORE RESTRICT RESTRICTED RESUME RETENTION RETURNING RETURN RETURNS RETRY REVISION_HISTORY
@@ -1003,11 +1057,11 @@ func (u *sqlSymUnion) logicalReplicationOptions() *tree.LogicalReplicationOption
 
 %token <str> SAVEPOINT SCANS SCATTER SCHEDULE SCHEDULES SCROLL SCHEMA SCHEMA_ONLY SCHEMAS SCRUB
 %token <str> SEARCH SECOND SECONDARY SECURITY SELECT SEQUENCE SEQUENCES
-%token <str> SERIALIZABLE SERVER SERVICE SESSION SESSIONS SESSION_USER SET SETOF SETS SETTING SETTINGS
+%token <str> SERIALIZABLE SERVER SERVICE SESSION SESSIONS SESSION_USER SET SETOF SETS SETTING SETTINGS SFUNC
 %token <str> SHARE SHARED SHOW SIMILAR SIMPLE SIZE SKIP SKIP_LOCALITIES_CHECK SKIP_MISSING_FOREIGN_KEYS
 %token <str> SKIP_MISSING_SEQUENCES SKIP_MISSING_SEQUENCE_OWNERS SKIP_MISSING_VIEWS SKIP_MISSING_UDFS SMALLINT SMALLSERIAL
 %token <str> SNAPSHOT SOME SPLIT SQL SQLLOGIN
-%token <str> STABLE START STATE STATISTICS STATUS STDIN STDOUT STOP STRAIGHT STREAM STRICT STRING STORAGE STORE STORED STORING SUBJECT SUBSTRING SUPER
+%token <str> STABLE START STATE STATEMENT STATISTICS STATUS STDIN STDOUT STOP STRAIGHT STREAM STRICT STRING STORAGE STORE STORED STORING STYPE SUBJECT SUBSTRING SUPER
 %token <str> SUPPORT SURVIVE SURVIVAL SYMMETRIC SYNTAX SYSTEM SQRT SUBSCRIPTION STATEMENTS
 
 %token <str> TABLE TABLES TABLESPACE TEMP TEMPLATE TEMPORARY TENANT TENANT_NAME TENANTS TESTING_RELOCATE TEXT THEN
@@ -1023,7 +1077,7 @@ func (u *sqlSymUnion) logicalReplicationOptions() *tree.LogicalReplicationOption
 %token <str> VIEWCLUSTERMETADATA VIEWCLUSTERSETTING VIRTUAL VISIBLE INVISIBLE VISIBILITY VOLATILE VOTERS
 %token <str> VIRTUAL_CLUSTER_NAME VIRTUAL_CLUSTER
 
-%token <str> WHEN WHERE WINDOW WITH WITHIN WITHOUT WORK WRITE
+%token <str> WHEN WHERE WINDOW WITH WITHIN WITHOUT WORK WRAPPER WRITE
 
 %token <str> YEAR
 
@@ -1074,10 +1128,13 @@ func (u *sqlSymUnion) logicalReplicationOptions() *tree.LogicalReplicationOption
 %type <tree.Statement> alter_range_stmt
 %type <tree.Statement> alter_partition_stmt
 %type <tree.Statement> alter_role_stmt
-%type <*tree.SetVar> set_or_reset_clause
+%type <*tree.SetVar> set_or_reset_clause routine_set_or_reset_clause
 %type <tree.Statement> alter_type_stmt
+%type <tree.Statement> alter_domain_stmt
 %type <tree.Statement> alter_schema_stmt
 %type <tree.Statement> alter_unsupported_stmt
+%type <tree.Statement> alter_publication_stmt
+%type <tree.AlterPublicationCommand> alter_publication_cmd
 %type <tree.Statement> alter_func_stmt
 %type <tree.Statement> alter_proc_stmt
 
@@ -1176,6 +1233,7 @@ func (u *sqlSymUnion) logicalReplicationOptions() *tree.LogicalReplicationOption
 %type <tree.Statement> begin_stmt
 
 %type <tree.Statement> call_stmt
+%type <tree.Statement> do_stmt
 
 %type <tree.Statement> cancel_stmt
 %type <tree.Statement> cancel_jobs_stmt
@@ -1215,6 +1273,20 @@ func (u *sqlSymUnion) logicalReplicationOptions() *tree.LogicalReplicationOption
 %type <tree.Statement> create_sequence_stmt
 %type <tree.Statement> create_func_stmt
 %type <tree.Statement> create_proc_stmt
+%type <tree.Statement> create_trigger_stmt
+%type <tree.Statement> create_publication_stmt
+%type <*tree.PublicationTargets> opt_publication_targets
+
+%type <tree.TriggerActionTime> trigger_action_time
+%type <*tree.TriggerEvent> trigger_event
+%type <[]*tree.TriggerEvent> trigger_event_list
+%type <*tree.TriggerTransition> trigger_transition
+%type <[]*tree.TriggerTransition> trigger_transition_list opt_trigger_transition_list
+%type <bool> transition_is_new transition_is_row
+%type <tree.TriggerForEach> trigger_for_each trigger_for_type
+%type <tree.Expr> trigger_when
+%type <str> trigger_func_arg
+%type <[]string> trigger_func_args
 
 %type <*tree.LikeTenantSpec> opt_like_virtual_cluster
 %type <tree.LogicalReplicationResources> logical_replication_resources, logical_replication_resources_list
@@ -1226,6 +1298,10 @@ func (u *sqlSymUnion) logicalReplicationOptions() *tree.LogicalReplicationOption
 %type <*tree.CreateStatsOptions> create_stats_option
 
 %type <tree.Statement> create_type_stmt
+%type <tree.Statement> create_domain_stmt
+%type <tree.Statement> create_foreign_table_stmt
+%type <tree.Statement> create_server_stmt
+%type <tree.Statement> create_aggregate_stmt
 %type <tree.Statement> delete_stmt
 %type <tree.Statement> discard_stmt
 
@@ -1238,10 +1314,15 @@ func (u *sqlSymUnion) logicalReplicationOptions() *tree.LogicalReplicationOption
 %type <tree.Statement> drop_schema_stmt
 %type <tree.Statement> drop_table_stmt
 %type <tree.Statement> drop_type_stmt
+%type <tree.Statement> drop_domain_stmt
+%type <tree.Statement> drop_server_stmt
+%type <tree.Statement> drop_aggregate_stmt
 %type <tree.Statement> drop_view_stmt
 %type <tree.Statement> drop_sequence_stmt
 %type <tree.Statement> drop_func_stmt
 %type <tree.Statement> drop_proc_stmt
+%type <tree.Statement> drop_trigger_stmt
+%type <tree.Statement> drop_publication_stmt
 %type <tree.Statement> drop_virtual_cluster_stmt
 %type <bool>           opt_immediate
 
@@ -1281,6 +1362,7 @@ func (u *sqlSymUnion) logicalReplicationOptions() *tree.LogicalReplicationOption
 %type <tree.Statement> set_session_stmt
 %type <tree.Statement> set_csetting_stmt set_or_reset_csetting_stmt
 %type <tree.Statement> set_transaction_stmt
+%type <tree.Statement> set_constraints_stmt
 %type <tree.Statement> set_exprs_internal
 %type <tree.Statement> generic_set
 %type <tree.Statement> set_rest_more
@@ -1345,9 +1427,15 @@ func (u *sqlSymUnion) logicalReplicationOptions() *tree.LogicalReplicationOption
 
 %type <tree.Statement> transaction_stmt legacy_transaction_stmt legacy_begin_stmt legacy_end_stmt
 %type <tree.Statement> truncate_stmt
+%type <tree.Statement> listen_stmt
+%type <tree.Statement> notify_stmt
 %type <tree.Statement> unlisten_stmt
 %type <tree.Statement> update_stmt
 %type <tree.Statement> upsert_stmt
+%type <tree.Statement> merge_stmt
+%type <tree.MergeWhens> merge_when_list
+%type <*tree.MergeWhen> merge_when_clause merge_when_matched_action merge_when_not_matched_action
+%type <tree.Expr> opt_merge_when_cond
 %type <tree.Statement> use_stmt
 
 %type <tree.Statement> close_cursor_stmt
@@ -1364,8 +1452,9 @@ func (u *sqlSymUnion) logicalReplicationOptions() *tree.LogicalReplicationOption
 %type <tree.Statement> reindex_stmt
 
 %type <[]string> opt_incremental
-%type <tree.KVOption> kv_option
+%type <tree.KVOption> kv_option foreign_option
 %type <[]tree.KVOption> kv_option_list opt_with_options var_set_list opt_with_schedule_options
+%type <[]tree.KVOption> foreign_option_list opt_foreign_options
 %type <*tree.BackupOptions> opt_with_backup_options backup_options backup_options_list
 %type <*tree.RestoreOptions> opt_with_restore_options restore_options restore_options_list
 %type <*tree.TenantReplicationOptions> opt_with_replication_options replication_options replication_options_list
@@ -1373,6 +1462,7 @@ func (u *sqlSymUnion) logicalReplicationOptions() *tree.LogicalReplicationOption
 %type <*tree.ShowJobOptions> show_job_options show_job_options_list
 %type <*tree.ShowBackupOptions> opt_with_show_backup_options show_backup_options show_backup_options_list show_backup_connection_options opt_with_show_backup_connection_options_list show_backup_connection_options_list
 %type <*tree.CopyOptions> opt_with_copy_options copy_options copy_options_list copy_generic_options copy_generic_options_list
+%type <*tree.CopyForceColumns> copy_force_columns copy_generic_force_columns
 %type <str> import_format
 %type <str> storage_parameter_key
 %type <[]string> storage_parameter_key_list
@@ -1479,6 +1569,7 @@ func (u *sqlSymUnion) logicalReplicationOptions() *tree.LogicalReplicationOption
 %type <tree.OrderBy> sort_clause sort_clause_no_index single_sort_clause opt_sort_clause opt_sort_clause_no_index
 %type <[]*tree.Order> sortby_list sortby_no_index_list
 %type <tree.IndexElemList> index_params create_as_params
+%type <tree.ExclusionElemList> exclude_elem_list
 %type <tree.IndexInvisibility> opt_index_visible alter_index_visible
 %type <tree.NameList> name_list privilege_list
 %type <[]int32> opt_array_bounds
@@ -1531,7 +1622,7 @@ func (u *sqlSymUnion) logicalReplicationOptions() *tree.LogicalReplicationOption
 %type <*tree.TenantSpec> virtual_cluster_spec virtual_cluster_spec_opt_all
 
 %type <bool> opt_unique opt_concurrently opt_cluster opt_without_index
-%type <bool> opt_index_access_method
+%type <str> opt_index_access_method
 
 %type <*tree.Limit> limit_clause offset_clause opt_limit_clause
 %type <tree.Expr> select_fetch_first_value
@@ -1575,6 +1666,7 @@ func (u *sqlSymUnion) logicalReplicationOptions() *tree.LogicalReplicationOption
 %type <bool> opt_ordinality opt_compact
 %type <*tree.Order> sortby sortby_index
 %type <tree.IndexElem> index_elem index_elem_options create_as_param
+%type <tree.ExclusionElem> exclude_elem
 %type <tree.TableExpr> table_ref numeric_table_ref func_table
 %type <tree.Exprs> rowsfrom_list
 %type <tree.Expr> rowsfrom_item
@@ -1591,6 +1683,8 @@ func (u *sqlSymUnion) logicalReplicationOptions() *tree.LogicalReplicationOption
 %type <str> explain_option_name
 %type <[]string> explain_option_list opt_enum_val_list enum_val_list
 %type <[]tree.CompositeTypeElem> composite_type_list opt_composite_type_list
+%type <[]tree.DomainConstraint> domain_constraint_list opt_domain_constraint_list
+%type <tree.DomainConstraint> domain_constraint domain_constraint_elem
 
 %type <tree.ResolvableTypeReference> typename simple_typename cast_target
 %type <*types.T> const_typename
@@ -1630,7 +1724,7 @@ func (u *sqlSymUnion) logicalReplicationOptions() *tree.LogicalReplicationOption
 %type <str> bare_label_keywords bare_col_label
 %type <str> col_name_keyword reserved_keyword cockroachdb_extra_reserved_keyword extra_var_value
 
-%type <tree.ResolvableTypeReference> complex_type_name
+%type <tree.ResolvableTypeReference> complex_type_name range_type_subtype
 %type <str> general_type_name
 
 %type <tree.ConstraintTableDef> table_constraint constraint_elem create_as_constraint_def create_as_constraint_elem
@@ -1640,6 +1734,7 @@ func (u *sqlSymUnion) logicalReplicationOptions() *tree.LogicalReplicationOption
 %type <tree.NamedColumnQualification> col_qualification create_as_col_qualification
 %type <tree.ColumnQualification> col_qualification_elem create_as_col_qualification_elem
 %type <tree.CompositeKeyMatchMethod> key_match
+%type <tree.ConstraintDeferrability> opt_deferrable
 %type <tree.ReferenceActions> reference_actions
 %type <tree.ReferenceAction> reference_action reference_on_delete reference_on_update
 
@@ -1702,14 +1797,19 @@ func (u *sqlSymUnion) logicalReplicationOptions() *tree.LogicalReplicationOption
 %type <privilege.TargetObjectType> target_object_type
 
 // User defined function relevant components.
-%type <bool> opt_or_replace opt_return_table opt_return_set opt_no
+%type <bool> opt_or_replace opt_return_set opt_no
 %type <str> param_name routine_as
 %type <tree.RoutineParams> opt_routine_param_with_default_list routine_param_with_default_list func_params func_params_list
-%type <tree.RoutineParam> routine_param_with_default routine_param
+%type <tree.RoutineParams> routine_table_column_list
+%type <tree.RoutineParam> routine_param_with_default routine_param routine_table_column
 %type <tree.ResolvableTypeReference> routine_return_type routine_param_type
 %type <tree.RoutineOptions> opt_create_routine_opt_list create_routine_opt_list alter_func_opt_list
 %type <tree.RoutineOption> create_routine_opt_item common_routine_opt_item
+%type <tree.RoutineOptions> do_stmt_opt_list
+%type <tree.RoutineOption> do_stmt_opt_item
 %type <tree.RoutineParamClass> routine_param_class
+%type <tree.AggregateOptions> aggregate_option_list
+%type <tree.AggregateOption> aggregate_option
 %type <*tree.UnresolvedObjectName> routine_create_name
 %type <tree.Statement> routine_return_stmt routine_body_stmt
 %type <tree.Statements> routine_body_stmt_list
@@ -1766,7 +1866,7 @@ func (u *sqlSymUnion) logicalReplicationOptions() *tree.LogicalReplicationOption
 %left      '|'
 %left      '#'
 %left      '&'
-%left      LSHIFT RSHIFT INET_CONTAINS_OR_EQUALS INET_CONTAINED_BY_OR_EQUALS AND_AND SQRT CBRT
+%left      LSHIFT RSHIFT INET_CONTAINS_OR_EQUALS INET_CONTAINED_BY_OR_EQUALS AND_AND ADJACENT SQRT CBRT
 %left      OPERATOR // if changing the last token before OPERATOR, change all instances of %prec <last token>
 %left      '+' '-'
 %left      '*' '/' FLOORDIV '%'
@@ -1816,6 +1916,7 @@ stmt_without_legacy_transaction:
 | execute_stmt               // EXTEND WITH HELP: EXECUTE
 | deallocate_stmt            // EXTEND WITH HELP: DEALLOCATE
 | discard_stmt               // EXTEND WITH HELP: DISCARD
+| do_stmt                    // EXTEND WITH HELP: DO
 | grant_stmt                 // EXTEND WITH HELP: GRANT
 | prepare_stmt               // EXTEND WITH HELP: PREPARE
 | revoke_stmt                // EXTEND WITH HELP: REVOKE
@@ -1831,6 +1932,8 @@ stmt_without_legacy_transaction:
 | fetch_cursor_stmt          // EXTEND WITH HELP: FETCH
 | move_cursor_stmt           // EXTEND WITH HELP: MOVE
 | reindex_stmt
+| listen_stmt                // EXTEND WITH HELP: LISTEN
+| notify_stmt                // EXTEND WITH HELP: NOTIFY
 | unlisten_stmt
 | show_commit_timestamp_stmt // EXTEND WITH HELP: SHOW COMMIT TIMESTAMP
 
@@ -1854,12 +1957,14 @@ alter_ddl_stmt:
 | alter_partition_stmt          // EXTEND WITH HELP: ALTER PARTITION
 | alter_schema_stmt             // EXTEND WITH HELP: ALTER SCHEMA
 | alter_type_stmt               // EXTEND WITH HELP: ALTER TYPE
+| alter_domain_stmt             // EXTEND WITH HELP: ALTER DOMAIN
 | alter_default_privileges_stmt // EXTEND WITH HELP: ALTER DEFAULT PRIVILEGES
 | alter_changefeed_stmt         // EXTEND WITH HELP: ALTER CHANGEFEED
 | alter_backup_stmt             // EXTEND WITH HELP: ALTER BACKUP
 | alter_func_stmt               // EXTEND WITH HELP: ALTER FUNCTION
 | alter_proc_stmt               // EXTEND WITH HELP: ALTER PROCEDURE
 | alter_backup_schedule  // EXTEND WITH HELP: ALTER BACKUP SCHEDULE
+| alter_publication_stmt        // EXTEND WITH HELP: ALTER PUBLICATION
 
 // %Help: ALTER TABLE - change the definition of a table
 // %Category: DDL
@@ -3184,6 +3289,104 @@ alter_type_stmt:
   }
 | ALTER TYPE error // SHOW HELP: ALTER TYPE
 
+// %Help: ALTER DOMAIN - change the definition of a domain
+// %Category: DDL
+// %Text:
+// ALTER DOMAIN <type_name> SET NOT NULL
+// ALTER DOMAIN <type_name> DROP NOT NULL
+// ALTER DOMAIN <type_name> ADD [CONSTRAINT <name>] CHECK (<expr>)
+// ALTER DOMAIN <type_name> DROP CONSTRAINT [IF EXISTS] <name> [CASCADE | RESTRICT]
+// ALTER DOMAIN <type_name> RENAME TO <name>
+// ALTER DOMAIN <type_name> SET SCHEMA <schema_name>
+// ALTER DOMAIN <type_name> OWNER TO {<newowner> | CURRENT_USER | SESSION_USER }
+// %SeeAlso: CREATE DOMAIN, DROP DOMAIN
+alter_domain_stmt:
+  ALTER DOMAIN type_name SET NOT NULL
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainSetNotNull{},
+    }
+  }
+| ALTER DOMAIN type_name DROP NOT NULL
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainDropNotNull{},
+    }
+  }
+| ALTER DOMAIN type_name ADD CONSTRAINT name CHECK '(' a_expr ')'
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainAddConstraint{
+        Constraint: tree.DomainConstraint{Name: tree.Name($6), Check: $9.expr()},
+      },
+    }
+  }
+| ALTER DOMAIN type_name ADD CHECK '(' a_expr ')'
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainAddConstraint{
+        Constraint: tree.DomainConstraint{Check: $7.expr()},
+      },
+    }
+  }
+| ALTER DOMAIN type_name DROP CONSTRAINT name opt_drop_behavior
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainDropConstraint{
+        Constraint: tree.Name($6),
+        DropBehavior: $7.dropBehavior(),
+      },
+    }
+  }
+| ALTER DOMAIN type_name DROP CONSTRAINT IF EXISTS name opt_drop_behavior
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterDomainDropConstraint{
+        Constraint: tree.Name($8),
+        IfExists: true,
+        DropBehavior: $9.dropBehavior(),
+      },
+    }
+  }
+| ALTER DOMAIN type_name RENAME TO name
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterTypeRename{
+        NewName: tree.Name($6),
+      },
+    }
+  }
+| ALTER DOMAIN type_name SET SCHEMA schema_name
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterTypeSetSchema{
+        Schema: tree.Name($6),
+      },
+    }
+  }
+| ALTER DOMAIN type_name OWNER TO role_spec
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: &tree.AlterTypeOwner{
+        Owner: $6.roleSpec(),
+      },
+    }
+  }
+| ALTER DOMAIN type_name SET DEFAULT error
+  {
+    return unimplementedWithIssueDetail(sqllex, 27796, "alter domain set default")
+  }
+| ALTER DOMAIN error // SHOW HELP: ALTER DOMAIN
+
 opt_add_val_placement:
   BEFORE SCONST
   {
@@ -3753,6 +3956,76 @@ drop_external_connection_stmt:
 	}
 	| DROP EXTERNAL CONNECTION error // SHOW HELP: DROP EXTERNAL CONNECTION
 
+// %Help: CREATE SERVER - define a new foreign server
+// %Category: DDL
+// %Text:
+// CREATE SERVER [IF NOT EXISTS] <name> FOREIGN DATA WRAPPER <wrapper>
+//   OPTIONS (uri '<uri>')
+//
+// The only foreign-data wrapper is cloud_storage, whose servers are stored as
+// External Connections to the given ExternalStorage URI.
+// %SeeAlso: CREATE FOREIGN TABLE, DROP SERVER, CREATE EXTERNAL CONNECTION
+create_server_stmt:
+  CREATE SERVER name FOREIGN DATA WRAPPER name opt_foreign_options
+  {
+    $$.val = &tree.CreateServer{
+      Name: tree.Name($3),
+      Wrapper: tree.Name($7),
+      Options: $8.kvOptions(),
+    }
+  }
+| CREATE SERVER IF NOT EXISTS name FOREIGN DATA WRAPPER name opt_foreign_options
+  {
+    $$.val = &tree.CreateServer{
+      Name: tree.Name($6),
+      IfNotExists: true,
+      Wrapper: tree.Name($10),
+      Options: $11.kvOptions(),
+    }
+  }
+| CREATE SERVER error // SHOW HELP: CREATE SERVER
+
+// %Help: DROP SERVER - remove a foreign server
+// %Category: DDL
+// %Text: DROP SERVER [IF EXISTS] <name> [, ...]
+// %SeeAlso: CREATE SERVER
+drop_server_stmt:
+  DROP SERVER name_list
+  {
+    $$.val = &tree.DropServer{Names: $3.nameList()}
+  }
+| DROP SERVER IF EXISTS name_list
+  {
+    $$.val = &tree.DropServer{Names: $5.nameList(), IfExists: true}
+  }
+| DROP SERVER error // SHOW HELP: DROP SERVER
+
+opt_foreign_options:
+  OPTIONS '(' foreign_option_list ')'
+  {
+    $$.val = $3.kvOptions()
+  }
+| /* EMPTY */
+  {
+    $$.val = []tree.KVOption(nil)
+  }
+
+foreign_option_list:
+  foreign_option
+  {
+    $$.val = []tree.KVOption{$1.kvOption()}
+  }
+| foreign_option_list ',' foreign_option
+  {
+    $$.val = append($1.kvOptions(), $3.kvOption())
+  }
+
+foreign_option:
+  unrestricted_name SCONST
+  {
+    $$.val = tree.KVOption{Key: tree.Name($1), Value: tree.NewStrVal($2)}
+  }
+
 // %Help: RESTORE - restore data from external storage
 // %Category: CCL
 // %Text:
@@ -3991,11 +4264,7 @@ import_format:
   }
 
 alter_unsupported_stmt:
-  ALTER DOMAIN error
-  {
-    return unimplemented(sqllex, "alter domain")
-  }
-| ALTER AGGREGATE error
+  ALTER AGGREGATE error
   {
     return unimplementedWithIssueDetail(sqllex, 74775, "alter aggregate")
   }
@@ -4160,6 +4429,42 @@ call_stmt:
     $$.val = &tree.Call{Proc: p}
   }
 
+// %Help: DO - execute an anonymous code block
+// %Category: Misc
+// %Text: DO [ LANGUAGE <lang_name> ] <code>
+// %SeeAlso: CREATE FUNCTION, CREATE PROCEDURE
+do_stmt:
+  DO do_stmt_opt_list
+  {
+    doBlock, err := tree.MakeDoBlock($2.routineOptions())
+    if err != nil {
+      return setErr(sqllex, err)
+    }
+    $$.val = doBlock
+  }
+| DO error // SHOW HELP: DO
+
+do_stmt_opt_list:
+  do_stmt_opt_item { $$.val = tree.RoutineOptions{$1.functionOption()} }
+| do_stmt_opt_list do_stmt_opt_item
+  {
+    $$.val = append($1.routineOptions(), $2.functionOption())
+  }
+
+do_stmt_opt_item:
+  SCONST
+  {
+    $$.val = tree.RoutineBodyStr($1)
+  }
+| LANGUAGE non_reserved_word_or_sconst
+  {
+    lang, err := tree.AsRoutineLanguage($2)
+    if err != nil {
+      return setErr(sqllex, err)
+    }
+    $$.val = lang
+  }
+
 // The COPY grammar in postgres has 3 different versions, all of which are supported by postgres:
 // 1) The "really old" syntax from v7.2 and prior
 // 2) Pre 9.0 using hard-wired, space-separated options
@@ -4171,14 +4476,24 @@ copy_stmt:
   {
     /* FORCE DOC */
     name := $2.unresolvedObjectName().ToTableName()
-    if $7.expr() != nil {
-      return unimplementedWithIssue(sqllex, 54580)
-    }
     $$.val = &tree.CopyFrom{
        Table: name,
        Columns: $3.nameList(),
        Stdin: true,
        Options: *$6.copyOptions(),
+       Where: tree.NewWhere(tree.AstWhere, $7.expr()),
+    }
+  }
+| COPY table_name opt_column_list FROM SCONST opt_with_copy_options opt_where_clause
+  {
+    /* FORCE DOC */
+    name := $2.unresolvedObjectName().ToTableName()
+    $$.val = &tree.CopyFrom{
+       Table: name,
+       Columns: $3.nameList(),
+       File: tree.NewStrVal($5),
+       Options: *$6.copyOptions(),
+       Where: tree.NewWhere(tree.AstWhere, $7.expr()),
     }
   }
 | COPY table_name opt_column_list FROM error
@@ -4195,9 +4510,16 @@ copy_stmt:
        Options: *$6.copyOptions(),
     }
   }
-| COPY table_name opt_column_list TO error
+| COPY table_name opt_column_list TO SCONST opt_with_copy_options
   {
-    return unimplementedWithIssue(sqllex, 97181)
+    /* FORCE DOC */
+    name := $2.unresolvedObjectName().ToTableName()
+    $$.val = &tree.CopyTo{
+       Table: name,
+       Columns: $3.nameList(),
+       File: tree.NewStrVal($5),
+       Options: *$6.copyOptions(),
+    }
   }
 | COPY '(' copy_to_stmt ')' TO STDOUT opt_with_copy_options
    {
@@ -4207,9 +4529,14 @@ copy_stmt:
         Options: *$7.copyOptions(),
      }
    }
-| COPY '(' copy_to_stmt ')' TO error
+| COPY '(' copy_to_stmt ')' TO SCONST opt_with_copy_options
    {
-     return unimplementedWithIssue(sqllex, 96590)
+     /* FORCE DOC */
+     $$.val = &tree.CopyTo{
+        Statement: $3.stmt(),
+        File: tree.NewStrVal($6),
+        Options: *$7.copyOptions(),
+     }
    }
 
 opt_with_copy_options:
@@ -4291,17 +4618,17 @@ copy_options:
   {
     $$.val = &tree.CopyOptions{Escape: tree.NewStrVal($2)}
   }
-| FORCE QUOTE error
+| FORCE QUOTE copy_force_columns
   {
-    return unimplementedWithIssueDetail(sqllex, 41608, "force_quote")
+    $$.val = &tree.CopyOptions{ForceQuote: $3.copyForceColumns()}
   }
-| FORCE NOT NULL error
+| FORCE NOT NULL copy_force_columns
   {
-    return unimplementedWithIssueDetail(sqllex, 41608, "force_not_null")
+    $$.val = &tree.CopyOptions{ForceNotNull: $4.copyForceColumns()}
   }
-| FORCE NULL error
+| FORCE NULL copy_force_columns
   {
-    return unimplementedWithIssueDetail(sqllex, 41608, "force_null")
+    $$.val = &tree.CopyOptions{ForceNull: $3.copyForceColumns()}
   }
 | ENCODING SCONST
   {
@@ -4376,23 +4703,43 @@ copy_generic_options:
   {
     $$.val = &tree.CopyOptions{Escape: tree.NewStrVal($2)}
   }
-| FORCE_QUOTE error
+| FORCE_QUOTE copy_generic_force_columns
   {
-    return unimplementedWithIssueDetail(sqllex, 41608, "force_quote")
+    $$.val = &tree.CopyOptions{ForceQuote: $2.copyForceColumns()}
   }
-| FORCE_NOT_NULL error
+| FORCE_NOT_NULL copy_generic_force_columns
   {
-    return unimplementedWithIssueDetail(sqllex, 41608, "force_not_null")
+    $$.val = &tree.CopyOptions{ForceNotNull: $2.copyForceColumns()}
   }
-| FORCE_NULL error
+| FORCE_NULL copy_generic_force_columns
   {
-    return unimplementedWithIssueDetail(sqllex, 41608, "force_null")
+    $$.val = &tree.CopyOptions{ForceNull: $2.copyForceColumns()}
   }
 | ENCODING SCONST
   {
     $$.val = &tree.CopyOptions{Encoding: tree.NewStrVal($2)}
   }
 
+copy_force_columns:
+  '*'
+  {
+    $$.val = &tree.CopyForceColumns{All: true}
+  }
+| name_list
+  {
+    $$.val = &tree.CopyForceColumns{Columns: $1.nameList()}
+  }
+
+copy_generic_force_columns:
+  '*'
+  {
+    $$.val = &tree.CopyForceColumns{All: true}
+  }
+| '(' name_list ')'
+  {
+    $$.val = &tree.CopyForceColumns{Columns: $2.nameList()}
+  }
+
 // %Help: CANCEL
 // %Category: Group
 // %Text: CANCEL JOBS, CANCEL QUERIES, CANCEL SESSIONS
@@ -4584,6 +4931,7 @@ create_stmt:
 | create_changefeed_stmt // EXTEND WITH HELP: CREATE CHANGEFEED
 | create_extension_stmt  // EXTEND WITH HELP: CREATE EXTENSION
 | create_external_connection_stmt // EXTEND WITH HELP: CREATE EXTERNAL CONNECTION
+| create_server_stmt     // EXTEND WITH HELP: CREATE SERVER
 | create_virtual_cluster_stmt     // EXTEND WITH HELP: CREATE VIRTUAL CLUSTER
 | create_logical_replication_stream_stmt     // EXTEND WITH HELP: CREATE LOGICAL REPLICATION STREAM
 | create_schedule_stmt   // help texts in sub-rule
@@ -4852,7 +5200,7 @@ create_extension_stmt:
 // %SeeAlso: WEBDOCS/create-function.html
 create_func_stmt:
   CREATE opt_or_replace FUNCTION routine_create_name '(' opt_routine_param_with_default_list ')'
-  RETURNS opt_return_table opt_return_set routine_return_type
+  RETURNS opt_return_set routine_return_type
   opt_create_routine_opt_list opt_routine_body
   {
     name := $4.unresolvedObjectName().ToRoutineName()
@@ -4862,11 +5210,36 @@ create_func_stmt:
       Name: name,
       Params: $6.routineParams(),
       ReturnType: &tree.RoutineReturnType{
-        Type: $11.typeReference(),
-        SetOf: $10.bool(),
+        Type: $10.typeReference(),
+        SetOf: $9.bool(),
       },
-      Options: $12.routineOptions(),
-      RoutineBody: $13.routineBody(),
+      Options: $11.routineOptions(),
+      RoutineBody: $12.routineBody(),
+    }
+  }
+| CREATE opt_or_replace FUNCTION routine_create_name '(' opt_routine_param_with_default_list ')'
+  RETURNS TABLE '(' routine_table_column_list ')'
+  opt_create_routine_opt_list opt_routine_body
+  {
+    name := $4.unresolvedObjectName().ToRoutineName()
+    // The columns of the table are added as parameters after the other
+    // parameters, and the function returns a set of them.
+    tableCols := $11.routineParams()
+    var retType tree.ResolvableTypeReference = types.AnyTuple
+    if len(tableCols) == 1 {
+      retType = tableCols[0].Type
+    }
+    $$.val = &tree.CreateRoutine{
+      IsProcedure: false,
+      Replace: $2.bool(),
+      Name: name,
+      Params: append($6.routineParams(), tableCols...),
+      ReturnType: &tree.RoutineReturnType{
+        Type: retType,
+        SetOf: true,
+      },
+      Options: $13.routineOptions(),
+      RoutineBody: $14.routineBody(),
     }
   }
 | CREATE opt_or_replace FUNCTION routine_create_name '(' opt_routine_param_with_default_list ')'
@@ -4911,14 +5284,66 @@ create_proc_stmt:
   }
 | CREATE opt_or_replace PROCEDURE error // SHOW HELP: CREATE PROCEDURE
 
+// %Help: CREATE AGGREGATE - define a new aggregate function
+// %Category: DDL
+// %Text:
+// CREATE [ OR REPLACE ] AGGREGATE
+//    name ( [ [ argmode ] [ argname ] argtype [, ...] ] ) (
+//    SFUNC = state_function,
+//    STYPE = state_type
+//    [ , FINALFUNC = final_function ]
+//    [ , COMBINEFUNC = combine_function ]
+//    [ , INITCOND = initial_condition ]
+// )
+// %SeeAlso: DROP AGGREGATE, CREATE FUNCTION
+create_aggregate_stmt:
+  CREATE opt_or_replace AGGREGATE routine_create_name func_params '(' aggregate_option_list ')'
+  {
+    $$.val = &tree.CreateAggregate{
+      Replace: $2.bool(),
+      Name: $4.unresolvedObjectName().ToRoutineName(),
+      Params: $5.routineParams(),
+      Options: $7.aggregateOptions(),
+    }
+  }
+| CREATE opt_or_replace AGGREGATE error // SHOW HELP: CREATE AGGREGATE
+
+aggregate_option_list:
+  aggregate_option
+  {
+    $$.val = tree.AggregateOptions{$1.aggregateOption()}
+  }
+| aggregate_option_list ',' aggregate_option
+  {
+    $$.val = append($1.aggregateOptions(), $3.aggregateOption())
+  }
+
+aggregate_option:
+  SFUNC '=' db_object_name
+  {
+    $$.val = &tree.AggregateStateFunc{Name: $3.unresolvedObjectName().ToRoutineName()}
+  }
+| STYPE '=' typename
+  {
+    $$.val = &tree.AggregateStateType{Type: $3.typeReference()}
+  }
+| FINALFUNC '=' db_object_name
+  {
+    $$.val = &tree.AggregateFinalFunc{Name: $3.unresolvedObjectName().ToRoutineName()}
+  }
+| COMBINEFUNC '=' db_object_name
+  {
+    $$.val = &tree.AggregateCombineFunc{Name: $3.unresolvedObjectName().ToRoutineName()}
+  }
+| INITCOND '=' SCONST
+  {
+    $$.val = tree.AggregateInitCond($3)
+  }
+
 opt_or_replace:
   OR REPLACE { $$.val = true }
 | /* EMPTY */ { $$.val = false }
 
-opt_return_table:
-  TABLE { return unimplementedWithIssueDetail(sqllex, 100226, "UDF returning TABLE") }
-| /* EMPTY */ { $$.val = false }
-
 opt_return_set:
   SETOF { $$.val = true}
 | /* EMPTY */ { $$.val = false }
@@ -4997,11 +5422,28 @@ routine_param_class:
 | OUT { $$.val = tree.RoutineParamOut }
 | INOUT { $$.val = tree.RoutineParamInOut }
 | IN OUT { $$.val = tree.RoutineParamInOut }
-| VARIADIC { return unimplementedWithIssueDetail(sqllex, 88947, "variadic user-defined functions") }
+| VARIADIC { $$.val = tree.RoutineParamVariadic }
 
 routine_param_type:
   typename
 
+routine_table_column_list:
+  routine_table_column { $$.val = tree.RoutineParams{$1.routineParam()} }
+| routine_table_column_list ',' routine_table_column
+  {
+    $$.val = append($1.routineParams(), $3.routineParam())
+  }
+
+routine_table_column:
+  param_name routine_param_type
+  {
+    $$.val = tree.RoutineParam{
+      Name: tree.Name($1),
+      Type: $2.typeReference(),
+      Class: tree.RoutineParamTable,
+    }
+  }
+
 routine_return_type:
   routine_param_type
 
@@ -5063,19 +5505,19 @@ common_routine_opt_item:
   }
 | EXTERNAL SECURITY DEFINER
   {
-    return unimplemented(sqllex, "create function...security")
+    $$.val = tree.RoutineSecurityDefiner
   }
 | EXTERNAL SECURITY INVOKER
   {
-    return unimplemented(sqllex, "create function...security")
+    $$.val = tree.RoutineSecurityInvoker
   }
 | SECURITY DEFINER
   {
-    return unimplemented(sqllex, "create function...security")
+    $$.val = tree.RoutineSecurityDefiner
   }
 | SECURITY INVOKER
   {
-    return unimplemented(sqllex, "create function...security")
+    $$.val = tree.RoutineSecurityInvoker
   }
 | LEAKPROOF
   {
@@ -5097,13 +5539,34 @@ common_routine_opt_item:
   {
     return unimplemented(sqllex, "create function/procedure ... support")
   }
-
-// In theory we should parse the a whole set/reset statement here. But it's fine
-// to just return fast on SET/RESET keyword for now since it's not supported
-// yet.
-| SET { return unimplemented(sqllex, "create function/procedure ... set") }
+| routine_set_or_reset_clause
+  {
+    $$.val = $1.setVar()
+  }
 | PARALLEL { return unimplemented(sqllex, "create function/procedure ... parallel") }
 
+// This rule is a subset of set_or_reset_clause. SET SCHEMA is excluded, since
+// it would be ambiguous with ALTER FUNCTION ... SET SCHEMA.
+routine_set_or_reset_clause:
+  SET generic_set
+  {
+    $$.val = $2.setVar()
+  }
+| SET TIME ZONE zone_value
+  {
+    /* SKIP DOC */
+    $$.val = &tree.SetVar{Name: "timezone", Values: tree.Exprs{$4.expr()}}
+  }
+| SET var_name FROM CURRENT { return unimplemented(sqllex, "create function/procedure ... set from current") }
+| RESET_ALL ALL
+  {
+    $$.val = &tree.SetVar{ResetAll: true}
+  }
+| RESET session_var
+  {
+    $$.val = &tree.SetVar{Name: $2, Values: tree.Exprs{tree.DefaultVal{}}, Reset: true}
+  }
+
 routine_as:
   SCONST
 
@@ -5136,74 +5599,393 @@ opt_routine_body:
       Stmts: tree.Statements{$1.stmt()},
     }
   }
-| BEGIN ATOMIC routine_body_stmt_list END
+| BEGIN ATOMIC routine_body_stmt_list END
+  {
+    $$.val = &tree.RoutineBody{
+        Stmts: $3.stmts(),
+    }
+  }
+| /* Empty */
+  {
+    $$.val = (*tree.RoutineBody)(nil)
+  }
+
+opt_link_sym:
+  ',' SCONST
+  {
+  }
+| /* Empty */
+  {
+  }
+
+// %Help: DROP FUNCTION - remove a function
+// %Category: DDL
+// %Text:
+// DROP FUNCTION [ IF EXISTS ] name [ ( [ [ argmode ] [ argname ] argtype [, ...] ] ) ] [, ...]
+//    [ CASCADE | RESTRICT ]
+// %SeeAlso: WEBDOCS/drop-function.html
+drop_func_stmt:
+  DROP FUNCTION function_with_paramtypes_list opt_drop_behavior
+  {
+    $$.val = &tree.DropRoutine{
+      Routines: $3.routineObjs(),
+      DropBehavior: $4.dropBehavior(),
+    }
+  }
+| DROP FUNCTION IF EXISTS function_with_paramtypes_list opt_drop_behavior
+  {
+    $$.val = &tree.DropRoutine{
+      IfExists: true,
+      Routines: $5.routineObjs(),
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP FUNCTION error // SHOW HELP: DROP FUNCTION
+
+// %Help: DROP PROCEDURE - remove a procedure
+// %Category: DDL
+// %Text:
+// DROP PROCEDURE [ IF EXISTS ] name [ ( [ [ argmode ] [ argname ] argtype [, ...] ] ) ] [, ...]
+//    [ CASCADE | RESTRICT ]
+// %SeeAlso: WEBDOCS/drop-procedure.html
+drop_proc_stmt:
+  DROP PROCEDURE function_with_paramtypes_list opt_drop_behavior
+  {
+    $$.val = &tree.DropRoutine{
+      Procedure: true,
+      Routines: $3.routineObjs(),
+      DropBehavior: $4.dropBehavior(),
+    }
+  }
+| DROP PROCEDURE IF EXISTS function_with_paramtypes_list opt_drop_behavior
+  {
+    $$.val = &tree.DropRoutine{
+      IfExists: true,
+      Procedure: true,
+      Routines: $5.routineObjs(),
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP PROCEDURE error // SHOW HELP: DROP PROCEDURE
+
+// %Help: DROP AGGREGATE - remove an aggregate function
+// %Category: DDL
+// %Text:
+// DROP AGGREGATE [ IF EXISTS ] name [ ( [ [ argmode ] [ argname ] argtype [, ...] ] ) ] [, ...]
+//    [ CASCADE | RESTRICT ]
+// %SeeAlso: CREATE AGGREGATE
+drop_aggregate_stmt:
+  DROP AGGREGATE function_with_paramtypes_list opt_drop_behavior
+  {
+    $$.val = &tree.DropRoutine{
+      Aggregate: true,
+      Routines: $3.routineObjs(),
+      DropBehavior: $4.dropBehavior(),
+    }
+  }
+| DROP AGGREGATE IF EXISTS function_with_paramtypes_list opt_drop_behavior
+  {
+    $$.val = &tree.DropRoutine{
+      IfExists: true,
+      Aggregate: true,
+      Routines: $5.routineObjs(),
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP AGGREGATE error // SHOW HELP: DROP AGGREGATE
+
+// %Help: CREATE PUBLICATION - define a new publication for logical replication
+// %Category: DDL
+// %Text:
+// CREATE PUBLICATION <name> [ FOR ALL TABLES | FOR TABLE <tablename> [, ...] ]
+// %SeeAlso: ALTER PUBLICATION, DROP PUBLICATION
+create_publication_stmt:
+  CREATE PUBLICATION name opt_publication_targets
+  {
+    $$.val = &tree.CreatePublication{Name: tree.Name($3), Targets: *$4.publicationTargets()}
+  }
+| CREATE PUBLICATION error // SHOW HELP: CREATE PUBLICATION
+
+opt_publication_targets:
+  FOR ALL TABLES
+  {
+    $$.val = &tree.PublicationTargets{AllTables: true}
+  }
+| FOR TABLE table_name_list
+  {
+    $$.val = &tree.PublicationTargets{Tables: $3.tableNames()}
+  }
+| /* EMPTY */
+  {
+    $$.val = &tree.PublicationTargets{}
+  }
+
+// %Help: ALTER PUBLICATION - change the tables of a publication
+// %Category: DDL
+// %Text:
+// ALTER PUBLICATION <name> ADD TABLE <tablename> [, ...]
+// ALTER PUBLICATION <name> SET TABLE <tablename> [, ...]
+// ALTER PUBLICATION <name> DROP TABLE <tablename> [, ...]
+// %SeeAlso: CREATE PUBLICATION, DROP PUBLICATION
+alter_publication_stmt:
+  ALTER PUBLICATION name alter_publication_cmd TABLE table_name_list
+  {
+    $$.val = &tree.AlterPublication{Name: tree.Name($3), Command: $4.alterPublicationCommand(), Tables: $6.tableNames()}
+  }
+| ALTER PUBLICATION error // SHOW HELP: ALTER PUBLICATION
+
+alter_publication_cmd:
+  ADD
+  {
+    $$.val = tree.AlterPublicationAddTable
+  }
+| SET
+  {
+    $$.val = tree.AlterPublicationSetTable
+  }
+| DROP
+  {
+    $$.val = tree.AlterPublicationDropTable
+  }
+
+// %Help: DROP PUBLICATION - remove a publication
+// %Category: DDL
+// %Text: DROP PUBLICATION [IF EXISTS] <name> [, ...] [CASCADE | RESTRICT]
+// %SeeAlso: CREATE PUBLICATION
+drop_publication_stmt:
+  DROP PUBLICATION name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropPublication{Names: $3.nameList(), DropBehavior: $4.dropBehavior()}
+  }
+| DROP PUBLICATION IF EXISTS name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropPublication{Names: $5.nameList(), IfExists: true, DropBehavior: $6.dropBehavior()}
+  }
+| DROP PUBLICATION error // SHOW HELP: DROP PUBLICATION
+
+// %Help: CREATE TRIGGER - define a new trigger
+// %Category: DDL
+// %Text:
+// CREATE [ OR REPLACE ] TRIGGER <name> { BEFORE | AFTER | INSTEAD OF } <event> [ OR ... ]
+//    ON <tablename>
+//    [ REFERENCING { { OLD | NEW } { ROW | TABLE } [ AS ] <transition_name> } [ ... ] ]
+//    [ FOR [ EACH ] { ROW | STATEMENT } ]
+//    [ WHEN ( <condition> ) ]
+//    EXECUTE { FUNCTION | PROCEDURE } <func_name> ( [ <argument> [, ...] ] )
+//
+// Events:
+//    INSERT
+//    UPDATE [ OF <colname> [, ...] ]
+//    DELETE
+//    TRUNCATE
+// %SeeAlso: DROP TRIGGER, WEBDOCS/create-trigger.html
+create_trigger_stmt:
+  CREATE opt_or_replace TRIGGER name trigger_action_time trigger_event_list
+  ON table_name opt_trigger_transition_list trigger_for_each trigger_when
+  EXECUTE function_or_procedure func_name '(' trigger_func_args ')'
+  {
+    $$.val = &tree.CreateTrigger{
+      Replace: $2.bool(),
+      Name: tree.Name($4),
+      ActionTime: $5.triggerActionTime(),
+      Events: $6.triggerEvents(),
+      TableName: $8.unresolvedObjectName(),
+      Transitions: $9.triggerTransitions(),
+      ForEach: $10.triggerForEach(),
+      When: $11.expr(),
+      FuncName: $14.unresolvedName(),
+      FuncArgs: $16.strs(),
+    }
+  }
+| CREATE opt_or_replace TRIGGER error // SHOW HELP: CREATE TRIGGER
+
+trigger_action_time:
+  BEFORE
+  {
+    $$.val = tree.TriggerActionTimeBefore
+  }
+| AFTER
+  {
+    $$.val = tree.TriggerActionTimeAfter
+  }
+| INSTEAD OF
+  {
+    $$.val = tree.TriggerActionTimeInsteadOf
+  }
+
+trigger_event_list:
+  trigger_event
+  {
+    $$.val = []*tree.TriggerEvent{$1.triggerEvent()}
+  }
+| trigger_event_list OR trigger_event
+  {
+    $$.val = append($1.triggerEvents(), $3.triggerEvent())
+  }
+
+trigger_event:
+  INSERT
+  {
+    $$.val = &tree.TriggerEvent{EventType: tree.TriggerEventInsert}
+  }
+| UPDATE
+  {
+    $$.val = &tree.TriggerEvent{EventType: tree.TriggerEventUpdate}
+  }
+| UPDATE OF name_list
+  {
+    $$.val = &tree.TriggerEvent{EventType: tree.TriggerEventUpdate, Columns: $3.nameList()}
+  }
+| DELETE
+  {
+    $$.val = &tree.TriggerEvent{EventType: tree.TriggerEventDelete}
+  }
+| TRUNCATE
+  {
+    $$.val = &tree.TriggerEvent{EventType: tree.TriggerEventTruncate}
+  }
+
+opt_trigger_transition_list:
+  REFERENCING trigger_transition_list
+  {
+    $$.val = $2.triggerTransitions()
+  }
+| /* EMPTY */
+  {
+    $$.val = []*tree.TriggerTransition(nil)
+  }
+
+trigger_transition_list:
+  trigger_transition
+  {
+    $$.val = []*tree.TriggerTransition{$1.triggerTransition()}
+  }
+| trigger_transition_list trigger_transition
+  {
+    $$.val = append($1.triggerTransitions(), $2.triggerTransition())
+  }
+
+trigger_transition:
+  transition_is_new transition_is_row opt_as name
+  {
+    $$.val = &tree.TriggerTransition{
+      Name: tree.Name($4),
+      IsNew: $1.bool(),
+      IsRow: $2.bool(),
+    }
+  }
+
+transition_is_new:
+  NEW
+  {
+    $$.val = true
+  }
+| OLD
+  {
+    $$.val = false
+  }
+
+transition_is_row:
+  ROW
+  {
+    $$.val = true
+  }
+| TABLE
+  {
+    $$.val = false
+  }
+
+opt_as:
+  AS {}
+| /* EMPTY */ {}
+
+trigger_for_each:
+  FOR opt_each trigger_for_type
+  {
+    $$.val = $3.triggerForEach()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.TriggerForEachStatement
+  }
+
+opt_each:
+  EACH {}
+| /* EMPTY */ {}
+
+trigger_for_type:
+  ROW
+  {
+    $$.val = tree.TriggerForEachRow
+  }
+| STATEMENT
+  {
+    $$.val = tree.TriggerForEachStatement
+  }
+
+trigger_when:
+  WHEN '(' a_expr ')'
   {
-    $$.val = &tree.RoutineBody{
-        Stmts: $3.stmts(),
-    }
+    $$.val = $3.expr()
   }
-| /* Empty */
+| /* EMPTY */
   {
-    $$.val = (*tree.RoutineBody)(nil)
+    $$.val = tree.Expr(nil)
   }
 
-opt_link_sym:
-  ',' SCONST
+function_or_procedure:
+  FUNCTION {}
+| PROCEDURE {}
+
+trigger_func_args:
+  trigger_func_arg
   {
+    $$.val = []string{$1}
   }
-| /* Empty */
+| trigger_func_args ',' trigger_func_arg
   {
+    $$.val = append($1.strs(), $3)
+  }
+| /* EMPTY */
+  {
+    $$.val = []string(nil)
   }
 
-// %Help: DROP FUNCTION - remove a function
-// %Category: DDL
-// %Text:
-// DROP FUNCTION [ IF EXISTS ] name [ ( [ [ argmode ] [ argname ] argtype [, ...] ] ) ] [, ...]
-//    [ CASCADE | RESTRICT ]
-// %SeeAlso: WEBDOCS/drop-function.html
-drop_func_stmt:
-  DROP FUNCTION function_with_paramtypes_list opt_drop_behavior
+trigger_func_arg:
+  ICONST
   {
-    $$.val = &tree.DropRoutine{
-      Routines: $3.routineObjs(),
-      DropBehavior: $4.dropBehavior(),
-    }
+    $$ = $1.numVal().String()
   }
-| DROP FUNCTION IF EXISTS function_with_paramtypes_list opt_drop_behavior
+| FCONST
   {
-    $$.val = &tree.DropRoutine{
-      IfExists: true,
-      Routines: $5.routineObjs(),
-      DropBehavior: $6.dropBehavior(),
-    }
+    $$ = $1.numVal().String()
   }
-| DROP FUNCTION error // SHOW HELP: DROP FUNCTION
+| SCONST
+| unrestricted_name
 
-// %Help: DROP PROCEDURE - remove a procedure
+// %Help: DROP TRIGGER - remove a trigger
 // %Category: DDL
-// %Text:
-// DROP PROCEDURE [ IF EXISTS ] name [ ( [ [ argmode ] [ argname ] argtype [, ...] ] ) ] [, ...]
-//    [ CASCADE | RESTRICT ]
-// %SeeAlso: WEBDOCS/drop-procedure.html
-drop_proc_stmt:
-  DROP PROCEDURE function_with_paramtypes_list opt_drop_behavior
-  {
-    $$.val = &tree.DropRoutine{
-      Procedure: true,
-      Routines: $3.routineObjs(),
-      DropBehavior: $4.dropBehavior(),
+// %Text: DROP TRIGGER [IF EXISTS] <trigger_name> ON <tablename> [CASCADE | RESTRICT]
+// %SeeAlso: CREATE TRIGGER, WEBDOCS/drop-trigger.html
+drop_trigger_stmt:
+  DROP TRIGGER name ON table_name opt_drop_behavior
+  {
+    $$.val = &tree.DropTrigger{
+      Trigger: tree.Name($3),
+      Table: $5.unresolvedObjectName(),
+      DropBehavior: $6.dropBehavior(),
     }
   }
-| DROP PROCEDURE IF EXISTS function_with_paramtypes_list opt_drop_behavior
+| DROP TRIGGER IF EXISTS name ON table_name opt_drop_behavior
   {
-    $$.val = &tree.DropRoutine{
+    $$.val = &tree.DropTrigger{
       IfExists: true,
-      Procedure: true,
-      Routines: $5.routineObjs(),
-      DropBehavior: $6.dropBehavior(),
+      Trigger: tree.Name($5),
+      Table: $7.unresolvedObjectName(),
+      DropBehavior: $8.dropBehavior(),
     }
   }
-| DROP PROCEDURE error // SHOW HELP: DROP PROCEDURE
+| DROP TRIGGER error // SHOW HELP: DROP TRIGGER
 
 function_with_paramtypes_list:
   function_with_paramtypes
@@ -5352,22 +6134,17 @@ opt_no:
 
 create_unsupported:
   CREATE ACCESS METHOD error { return unimplemented(sqllex, "create access method") }
-| CREATE AGGREGATE error { return unimplementedWithIssueDetail(sqllex, 74775, "create aggregate") }
 | CREATE CAST error { return unimplemented(sqllex, "create cast") }
 | CREATE CONSTRAINT TRIGGER error { return unimplementedWithIssueDetail(sqllex, 28296, "create constraint") }
 | CREATE CONVERSION error { return unimplemented(sqllex, "create conversion") }
 | CREATE DEFAULT CONVERSION error { return unimplemented(sqllex, "create def conv") }
-| CREATE FOREIGN TABLE error { return unimplemented(sqllex, "create foreign table") }
 | CREATE FOREIGN DATA error { return unimplemented(sqllex, "create fdw") }
 | CREATE opt_or_replace opt_trusted opt_procedural LANGUAGE name error { return unimplementedWithIssueDetail(sqllex, 17511, "create language " + $6) }
 | CREATE OPERATOR error { return unimplementedWithIssue(sqllex, 65017) }
-| CREATE PUBLICATION error { return unimplemented(sqllex, "create publication") }
 | CREATE opt_or_replace RULE error { return unimplemented(sqllex, "create rule") }
-| CREATE SERVER error { return unimplemented(sqllex, "create server") }
 | CREATE SUBSCRIPTION error { return unimplemented(sqllex, "create subscription") }
 | CREATE TABLESPACE error { return unimplementedWithIssueDetail(sqllex, 54113, "create tablespace") }
 | CREATE TEXT error { return unimplementedWithIssueDetail(sqllex, 7821, "create text") }
-| CREATE TRIGGER error { return unimplementedWithIssueDetail(sqllex, 28296, "create trigger") }
 
 opt_trusted:
   TRUSTED {}
@@ -5379,23 +6156,17 @@ opt_procedural:
 
 drop_unsupported:
   DROP ACCESS METHOD error { return unimplemented(sqllex, "drop access method") }
-| DROP AGGREGATE error { return unimplementedWithIssueDetail(sqllex, 74775, "drop aggregate") }
 | DROP CAST error { return unimplemented(sqllex, "drop cast") }
 | DROP COLLATION error { return unimplemented(sqllex, "drop collation") }
 | DROP CONVERSION error { return unimplemented(sqllex, "drop conversion") }
-| DROP DOMAIN error { return unimplementedWithIssueDetail(sqllex, 27796, "drop") }
 | DROP EXTENSION IF EXISTS name error { return unimplementedWithIssueDetail(sqllex, 74777, "drop extension if exists") }
 | DROP EXTENSION name error { return unimplementedWithIssueDetail(sqllex, 74777, "drop extension") }
-| DROP FOREIGN TABLE error { return unimplemented(sqllex, "drop foreign table") }
 | DROP FOREIGN DATA error { return unimplemented(sqllex, "drop fdw") }
 | DROP opt_procedural LANGUAGE name error { return unimplementedWithIssueDetail(sqllex, 17511, "drop language " + $4) }
 | DROP OPERATOR error { return unimplemented(sqllex, "drop operator") }
-| DROP PUBLICATION error { return unimplemented(sqllex, "drop publication") }
 | DROP RULE error { return unimplemented(sqllex, "drop rule") }
-| DROP SERVER error { return unimplemented(sqllex, "drop server") }
 | DROP SUBSCRIPTION error { return unimplemented(sqllex, "drop subscription") }
 | DROP TEXT error { return unimplementedWithIssueDetail(sqllex, 7821, "drop text") }
-| DROP TRIGGER error { return unimplementedWithIssueDetail(sqllex, 28296, "drop") }
 
 create_ddl_stmt:
   create_database_stmt // EXTEND WITH HELP: CREATE DATABASE
@@ -5406,10 +6177,15 @@ create_ddl_stmt:
 // Error case for both CREATE TABLE and CREATE TABLE ... AS in one
 | CREATE opt_persistence_temp_table TABLE error   // SHOW HELP: CREATE TABLE
 | create_type_stmt     // EXTEND WITH HELP: CREATE TYPE
+| create_domain_stmt   // EXTEND WITH HELP: CREATE DOMAIN
+| create_foreign_table_stmt // EXTEND WITH HELP: CREATE FOREIGN TABLE
 | create_view_stmt     // EXTEND WITH HELP: CREATE VIEW
 | create_sequence_stmt // EXTEND WITH HELP: CREATE SEQUENCE
 | create_func_stmt     // EXTEND WITH HELP: CREATE FUNCTION
 | create_proc_stmt     // EXTEND WITH HELP: CREATE PROCEDURE
+| create_aggregate_stmt // EXTEND WITH HELP: CREATE AGGREGATE
+| create_trigger_stmt  // EXTEND WITH HELP: CREATE TRIGGER
+| create_publication_stmt // EXTEND WITH HELP: CREATE PUBLICATION
 
 // %Help: CREATE STATISTICS - create a new table statistic
 // %Category: Misc
@@ -5782,6 +6558,7 @@ drop_stmt:
 | drop_role_stmt                // EXTEND WITH HELP: DROP ROLE
 | drop_schedule_stmt            // EXTEND WITH HELP: DROP SCHEDULES
 | drop_external_connection_stmt // EXTEND WITH HELP: DROP EXTERNAL CONNECTION
+| drop_server_stmt              // EXTEND WITH HELP: DROP SERVER
 | drop_virtual_cluster_stmt     // EXTEND WITH HELP: DROP VIRTUAL CLUSTER
 | drop_unsupported   {}
 | DROP error                    // SHOW HELP: DROP
@@ -5794,8 +6571,12 @@ drop_ddl_stmt:
 | drop_sequence_stmt // EXTEND WITH HELP: DROP SEQUENCE
 | drop_schema_stmt   // EXTEND WITH HELP: DROP SCHEMA
 | drop_type_stmt     // EXTEND WITH HELP: DROP TYPE
+| drop_domain_stmt   // EXTEND WITH HELP: DROP DOMAIN
 | drop_func_stmt     // EXTEND WITH HELP: DROP FUNCTION
 | drop_proc_stmt     // EXTEND WITH HELP: DROP FUNCTION
+| drop_aggregate_stmt // EXTEND WITH HELP: DROP AGGREGATE
+| drop_trigger_stmt  // EXTEND WITH HELP: DROP TRIGGER
+| drop_publication_stmt // EXTEND WITH HELP: DROP PUBLICATION
 
 // %Help: DROP VIEW - remove a view
 // %Category: DDL
@@ -5847,7 +6628,7 @@ drop_sequence_stmt:
 
 // %Help: DROP TABLE - remove a table
 // %Category: DDL
-// %Text: DROP TABLE [IF EXISTS] <tablename> [, ...] [CASCADE | RESTRICT]
+// %Text: DROP [FOREIGN] TABLE [IF EXISTS] <tablename> [, ...] [CASCADE | RESTRICT]
 // %SeeAlso: WEBDOCS/drop-table.html
 drop_table_stmt:
   DROP TABLE table_name_list opt_drop_behavior
@@ -5858,7 +6639,16 @@ drop_table_stmt:
   {
     $$.val = &tree.DropTable{Names: $5.tableNames(), IfExists: true, DropBehavior: $6.dropBehavior()}
   }
+| DROP FOREIGN TABLE table_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropTable{Names: $4.tableNames(), IfExists: false, DropBehavior: $5.dropBehavior()}
+  }
+| DROP FOREIGN TABLE IF EXISTS table_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropTable{Names: $6.tableNames(), IfExists: true, DropBehavior: $7.dropBehavior()}
+  }
 | DROP TABLE error // SHOW HELP: DROP TABLE
+| DROP FOREIGN TABLE error // SHOW HELP: DROP TABLE
 
 // %Help: DROP INDEX - remove an index
 // %Category: DDL
@@ -5930,6 +6720,29 @@ drop_type_stmt:
   }
 | DROP TYPE error // SHOW HELP: DROP TYPE
 
+// %Help: DROP DOMAIN - remove a domain
+// %Category: DDL
+// %Text: DROP DOMAIN [IF EXISTS] <type_name> [, ...] [CASCADE | RESTRICT]
+// %SeeAlso: CREATE DOMAIN, ALTER DOMAIN
+drop_domain_stmt:
+  DROP DOMAIN type_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropDomain{
+      Names: $3.unresolvedObjectNames(),
+      IfExists: false,
+      DropBehavior: $4.dropBehavior(),
+    }
+  }
+| DROP DOMAIN IF EXISTS type_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropDomain{
+      Names: $5.unresolvedObjectNames(),
+      IfExists: true,
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP DOMAIN error // SHOW HELP: DROP DOMAIN
+
 // %Help: DROP VIRTUAL CLUSTER - remove a virtual cluster
 // %Category: Experimental
 // %Text: DROP VIRTUAL CLUSTER [IF EXISTS] <virtual_cluster_spec> [IMMEDIATE]
@@ -6151,6 +6964,7 @@ preparable_stmt:
 | explain_stmt   // EXTEND WITH HELP: EXPLAIN
 | import_stmt    // EXTEND WITH HELP: IMPORT
 | insert_stmt    // EXTEND WITH HELP: INSERT
+| merge_stmt     // EXTEND WITH HELP: MERGE
 | pause_stmt     // help texts in sub-rule
 | reset_stmt     // help texts in sub-rule
 | restore_stmt   // EXTEND WITH HELP: RESTORE
@@ -6877,7 +7691,7 @@ use_stmt:
 nonpreparable_set_stmt:
   set_transaction_stmt // EXTEND WITH HELP: SET TRANSACTION
 | set_exprs_internal   { /* SKIP DOC */ }
-| SET CONSTRAINTS error { return unimplemented(sqllex, "set constraints") }
+| set_constraints_stmt // EXTEND WITH HELP: SET CONSTRAINTS
 
 // SET SESSION / SET LOCAL / SET CLUSTER SETTING
 preparable_set_stmt:
@@ -7357,6 +8171,33 @@ set_transaction_stmt:
   }
 | SET SESSION TRANSACTION error // SHOW HELP: SET TRANSACTION
 
+// %Help: SET CONSTRAINTS - set constraint check timing for the current transaction
+// %Category: Txn
+// %Text:
+// SET CONSTRAINTS { ALL | <name> [, ...] } { DEFERRED | IMMEDIATE }
+//
+// Only constraints declared DEFERRABLE are affected.
+//
+// %SeeAlso: SET TRANSACTION, COMMIT
+set_constraints_stmt:
+  SET CONSTRAINTS ALL DEFERRED
+  {
+    $$.val = &tree.SetConstraints{Deferred: true}
+  }
+| SET CONSTRAINTS ALL IMMEDIATE
+  {
+    $$.val = &tree.SetConstraints{}
+  }
+| SET CONSTRAINTS name_list DEFERRED
+  {
+    $$.val = &tree.SetConstraints{Names: $3.nameList(), Deferred: true}
+  }
+| SET CONSTRAINTS name_list IMMEDIATE
+  {
+    $$.val = &tree.SetConstraints{Names: $3.nameList()}
+  }
+| SET CONSTRAINTS error // SHOW HELP: SET CONSTRAINTS
+
 generic_set:
   var_name to_or_eq var_list
   {
@@ -9896,6 +10737,7 @@ alter_schema_stmt:
 //    FOREIGN KEY ( <colnames...> ) REFERENCES <tablename> [( <colnames...> )] [ON DELETE {NO ACTION | RESTRICT}] [ON UPDATE {NO ACTION | RESTRICT}]
 //    UNIQUE ( <colnames...> ) [{STORING | INCLUDE | COVERING} ( <colnames...> )]
 //    CHECK ( <expr> )
+//    EXCLUDE USING gist ( <colname> WITH <operator> [, ...] )
 //
 // Column qualifiers:
 //   [CONSTRAINT <constraintname>] {NULL | NOT NULL | NOT VISIBLE | UNIQUE | PRIMARY KEY | CHECK (<expr>) | DEFAULT <expr> | ON UPDATE <expr> | GENERATED { ALWAYS | BY DEFAULT } AS IDENTITY [( <opt_sequence_option_list> )]}
@@ -9942,6 +10784,44 @@ create_table_stmt:
     }
   }
 
+// %Help: CREATE FOREIGN TABLE - create a table over files in external storage
+// %Category: DDL
+// %Text:
+// CREATE FOREIGN TABLE [IF NOT EXISTS] <tablename> ( <colname> <type> [, ...] )
+//   SERVER <server_name> OPTIONS (<option> '<value>' [, ...])
+//
+// Options:
+//    location            path of the files, relative to the server URI; may contain globs
+//    format              csv, avro or parquet
+//    compression         none, gzip, bzip, snappy or auto
+//    delimiter           CSV field delimiter
+//    header              whether CSV files have a header row
+//    null                CSV string that represents NULL
+//    comment             CSV comment character
+//
+// %SeeAlso: CREATE SERVER, DROP TABLE
+create_foreign_table_stmt:
+  CREATE FOREIGN TABLE table_name '(' opt_table_elem_list ')' SERVER name opt_foreign_options
+  {
+    $$.val = &tree.CreateForeignTable{
+      Table: $4.unresolvedObjectName().ToTableName(),
+      Defs: $6.tblDefs(),
+      Server: tree.Name($9),
+      Options: $10.kvOptions(),
+    }
+  }
+| CREATE FOREIGN TABLE IF NOT EXISTS table_name '(' opt_table_elem_list ')' SERVER name opt_foreign_options
+  {
+    $$.val = &tree.CreateForeignTable{
+      IfNotExists: true,
+      Table: $7.unresolvedObjectName().ToTableName(),
+      Defs: $9.tblDefs(),
+      Server: tree.Name($12),
+      Options: $13.kvOptions(),
+    }
+  }
+| CREATE FOREIGN TABLE error // SHOW HELP: CREATE FOREIGN TABLE
+
 opt_locality:
   locality
   {
@@ -10406,7 +11286,7 @@ col_qualification_elem:
   {
     $$.val = &tree.ColumnOnUpdate{Expr: $3.expr()}
   }
-| REFERENCES table_name opt_name_parens key_match reference_actions
+| REFERENCES table_name opt_name_parens key_match reference_actions opt_deferrable
   {
     name := $2.unresolvedObjectName().ToTableName()
     $$.val = &tree.ColumnFKConstraint{
@@ -10414,6 +11294,7 @@ col_qualification_elem:
       Col: tree.Name($3),
       Actions: $5.referenceActions(),
       Match: $4.compositeKeyMatchMethod(),
+      Deferrable: $6.constraintDeferrability(),
     }
   }
 | generated_as '(' a_expr ')' STORED
@@ -10570,6 +11451,9 @@ table_constraint:
 constraint_elem:
   CHECK '(' a_expr ')' opt_deferrable
   {
+    if $5.constraintDeferrability() != tree.ConstraintNotDeferrable {
+      return setErr(sqllex, pgerror.New(pgcode.FeatureNotSupported, "CHECK constraints cannot be marked DEFERRABLE"))
+    }
     $$.val = &tree.CheckConstraintTableDef{
       Expr: $3.expr(),
     }
@@ -10585,6 +11469,7 @@ constraint_elem:
         PartitionByIndex: $7.partitionByIndex(),
         Predicate: $9.expr(),
       },
+      Deferrable: $8.constraintDeferrability(),
     }
   }
 | PRIMARY KEY '(' index_params ')' opt_hash_sharded opt_with_storage_parameter_list
@@ -10608,11 +11493,39 @@ constraint_elem:
       ToCols: $8.nameList(),
       Match: $9.compositeKeyMatchMethod(),
       Actions: $10.referenceActions(),
+      Deferrable: $11.constraintDeferrability(),
+    }
+  }
+| EXCLUDE USING name '(' exclude_elem_list ')' opt_where_clause
+  {
+    if $7.expr() != nil {
+      return unimplementedWithIssueDetail(sqllex, 46657, "exclusion constraint with WHERE clause")
     }
+    $$.val = &tree.ExclusionConstraintTableDef{
+      Method: tree.Name($3),
+      Elems: $5.exclusionElems(),
+    }
+  }
+
+exclude_elem_list:
+  exclude_elem
+  {
+    $$.val = tree.ExclusionElemList{$1.exclusionElem()}
   }
-| EXCLUDE USING error
+| exclude_elem_list ',' exclude_elem
   {
-    return unimplementedWithIssueDetail(sqllex, 46657, "add constraint exclude using")
+    $$.val = append($1.exclusionElems(), $3.exclusionElem())
+  }
+
+exclude_elem:
+  name WITH all_op
+  {
+    op, ok := $3.op().(treecmp.ComparisonOperator)
+    if !ok {
+      return setErr(sqllex, pgerror.Newf(pgcode.WrongObjectType,
+        "operator %s is not supported by exclusion constraints", $3.op()))
+    }
+    $$.val = tree.ExclusionElem{Column: tree.Name($1), Operator: op}
   }
 
 
@@ -10721,12 +11634,30 @@ create_as_col_qualification_elem:
   }
 
 opt_deferrable:
-  /* EMPTY */ { /* no error */ }
-| DEFERRABLE { return unimplementedWithIssueDetail(sqllex, 31632, "deferrable") }
-| DEFERRABLE INITIALLY DEFERRED { return unimplementedWithIssueDetail(sqllex, 31632, "def initially deferred") }
-| DEFERRABLE INITIALLY IMMEDIATE { return unimplementedWithIssueDetail(sqllex, 31632, "def initially immediate") }
-| INITIALLY DEFERRED { return unimplementedWithIssueDetail(sqllex, 31632, "initially deferred") }
-| INITIALLY IMMEDIATE { return unimplementedWithIssueDetail(sqllex, 31632, "initially immediate") }
+  /* EMPTY */
+  {
+    $$.val = tree.ConstraintNotDeferrable
+  }
+| DEFERRABLE
+  {
+    $$.val = tree.ConstraintInitiallyImmediate
+  }
+| DEFERRABLE INITIALLY DEFERRED
+  {
+    $$.val = tree.ConstraintInitiallyDeferred
+  }
+| DEFERRABLE INITIALLY IMMEDIATE
+  {
+    $$.val = tree.ConstraintInitiallyImmediate
+  }
+| INITIALLY DEFERRED
+  {
+    $$.val = tree.ConstraintInitiallyDeferred
+  }
+| INITIALLY IMMEDIATE
+  {
+    $$.val = tree.ConstraintNotDeferrable
+  }
 
 storing:
   COVERING
@@ -11341,7 +12272,10 @@ opt_view_recursive:
 
 // %Help: CREATE TYPE - create a type
 // %Category: DDL
-// %Text: CREATE TYPE [IF NOT EXISTS] <type_name> AS ENUM (...)
+// %Text:
+// CREATE TYPE [IF NOT EXISTS] <type_name> AS ENUM (...)
+// CREATE TYPE [IF NOT EXISTS] <type_name> AS (<label> <type>, ...)
+// CREATE TYPE [IF NOT EXISTS] <type_name> AS RANGE (SUBTYPE = <type>)
 create_type_stmt:
   // Enum types.
   CREATE TYPE type_name AS ENUM '(' opt_enum_val_list ')'
@@ -11381,13 +12315,115 @@ create_type_stmt:
     }
   }
   // Range types.
+| CREATE TYPE type_name AS RANGE '(' range_type_subtype ')'
+  {
+    $$.val = &tree.CreateType{
+      TypeName: $3.unresolvedObjectName(),
+      Variety: tree.Range,
+      RangeSubtype: $7.typeReference(),
+    }
+  }
+| CREATE TYPE IF NOT EXISTS type_name AS RANGE '(' range_type_subtype ')'
+  {
+    $$.val = &tree.CreateType{
+      TypeName: $6.unresolvedObjectName(),
+      Variety: tree.Range,
+      IfNotExists: true,
+      RangeSubtype: $10.typeReference(),
+    }
+  }
 | CREATE TYPE type_name AS RANGE error    { return unimplementedWithIssue(sqllex, 27791) }
   // Base (primitive) types.
 | CREATE TYPE type_name '(' error         { return unimplementedWithIssueDetail(sqllex, 27793, "base") }
   // Shell types, gateway to define base types using the previous syntax.
 | CREATE TYPE type_name                   { return unimplementedWithIssueDetail(sqllex, 27793, "shell") }
-  // Domain types.
-| CREATE DOMAIN type_name error           { return unimplementedWithIssueDetail(sqllex, 27796, "create") }
+
+// range_type_subtype is the SUBTYPE option of a range type definition, which
+// is the only option supported.
+range_type_subtype:
+  name '=' typename
+  {
+    if $1 != "subtype" {
+      return unimplementedWithIssueDetail(sqllex, 27791, "range type option " + $1)
+    }
+    $$.val = $3.typeReference()
+  }
+
+// %Help: CREATE DOMAIN - create a domain
+// %Category: DDL
+// %Text:
+// CREATE DOMAIN <type_name> [AS] <data_type> [<domain_constraint> ...]
+//
+// Domain constraints:
+//   [CONSTRAINT <name>] NOT NULL
+//   [CONSTRAINT <name>] NULL
+//   [CONSTRAINT <name>] CHECK (<expr>)
+//
+// %SeeAlso: ALTER DOMAIN, DROP DOMAIN
+create_domain_stmt:
+  CREATE DOMAIN type_name opt_as typename opt_domain_constraint_list
+  {
+    $$.val = &tree.CreateDomain{
+      TypeName: $3.unresolvedObjectName(),
+      Type: $5.typeReference(),
+      Constraints: $6.domainConstraints(),
+    }
+  }
+| CREATE DOMAIN error // SHOW HELP: CREATE DOMAIN
+
+opt_domain_constraint_list:
+  domain_constraint_list
+  {
+    $$.val = $1.domainConstraints()
+  }
+| /* EMPTY */
+  {
+    $$.val = []tree.DomainConstraint(nil)
+  }
+
+domain_constraint_list:
+  domain_constraint
+  {
+    $$.val = []tree.DomainConstraint{$1.domainConstraint()}
+  }
+| domain_constraint_list domain_constraint
+  {
+    $$.val = append($1.domainConstraints(), $2.domainConstraint())
+  }
+
+domain_constraint:
+  CONSTRAINT name domain_constraint_elem
+  {
+    c := $3.domainConstraint()
+    c.Name = tree.Name($2)
+    $$.val = c
+  }
+| domain_constraint_elem
+  {
+    $$.val = $1.domainConstraint()
+  }
+
+domain_constraint_elem:
+  NOT NULL
+  {
+    $$.val = tree.DomainConstraint{NotNull: true}
+  }
+| NULL
+  {
+    $$.val = tree.DomainConstraint{Null: true}
+  }
+| CHECK '(' a_expr ')'
+  {
+    $$.val = tree.DomainConstraint{Check: $3.expr()}
+  }
+| DEFAULT error
+  {
+    return unimplementedWithIssueDetail(sqllex, 27796, "default")
+  }
+| COLLATE error
+  {
+    return unimplementedWithIssueDetail(sqllex, 27796, "collate")
+  }
 
 opt_enum_val_list:
   enum_val_list
@@ -11443,7 +12479,7 @@ composite_type_list:
 // %Category: DDL
 // %Text:
 // CREATE [UNIQUE | INVERTED] INDEX [CONCURRENTLY] [IF NOT EXISTS] [<idxname>]
-//        ON <tablename> ( <colname> [ASC | DESC] [, ...] )
+//        ON <tablename> [USING lsh] ( <colname> [ASC | DESC] [, ...] )
 //        [USING HASH] [STORING ( <colnames...> )]
 //        [PARTITION BY <partition params>]
 //        [WITH <storage_parameter_list] [WHERE <where_conds...>]
@@ -11464,7 +12500,8 @@ create_index_stmt:
       PartitionByIndex: $14.partitionByIndex(),
       StorageParams:    $15.storageParams(),
       Predicate:        $16.expr(),
-      Inverted:         $8.bool(),
+      Inverted:         $8 == "inverted",
+      Vector:           $8 == "vector",
       Concurrently:     $4.bool(),
       Invisibility:     $17.indexInvisibility(),
     }
@@ -11481,7 +12518,8 @@ create_index_stmt:
       Sharded:          $15.shardedIndexDef(),
       Storing:          $16.nameList(),
       PartitionByIndex: $17.partitionByIndex(),
-      Inverted:         $11.bool(),
+      Inverted:         $11 == "inverted",
+      Vector:           $11 == "vector",
       StorageParams:    $18.storageParams(),
       Predicate:        $19.expr(),
       Concurrently:     $4.bool(),
@@ -11531,10 +12569,12 @@ opt_index_access_method:
     /* FORCE DOC */
     switch $2 {
       case "gin", "gist":
-        $$.val = true
+        $$ = "inverted"
       case "btree":
-        $$.val = false
-      case "hash", "spgist", "brin":
+        $$ = ""
+      case "lsh":
+        $$ = "vector"
+      case "hash", "spgist", "brin", "ivfflat", "hnsw":
         return unimplemented(sqllex, "index using " + $2)
       default:
         sqllex.Error("unrecognized access method: " + $2)
@@ -11543,7 +12583,7 @@ opt_index_access_method:
   }
 | /* EMPTY */
   {
-    $$.val = false
+    $$ = ""
   }
 
 opt_concurrently:
@@ -12658,6 +13698,92 @@ insert_stmt:
   }
 | opt_with_clause INSERT error // SHOW HELP: INSERT
 
+// %Help: MERGE - conditionally insert, update, or delete rows of a table
+// %Category: DML
+// %Text:
+// MERGE INTO <tablename> [[AS] <name>]
+//        USING <source> ON <expr>
+//        WHEN MATCHED [AND <expr>] THEN { UPDATE SET ... | DELETE | DO NOTHING }
+//        WHEN NOT MATCHED [AND <expr>] THEN
+//          { INSERT [( <colnames...> )] { VALUES ( <exprs...> ) | DEFAULT VALUES } | DO NOTHING }
+//        [...]
+// %SeeAlso: INSERT, UPDATE, DELETE, UPSERT
+merge_stmt:
+  opt_with_clause MERGE INTO table_expr_opt_alias_idx USING table_ref ON a_expr merge_when_list
+  {
+    $$.val = &tree.Merge{
+      With: $1.with(),
+      Table: $4.tblExpr(),
+      Source: $6.tblExpr(),
+      On: $8.expr(),
+      Whens: $9.mergeWhens(),
+    }
+  }
+| opt_with_clause MERGE error // SHOW HELP: MERGE
+
+merge_when_list:
+  merge_when_clause
+  {
+    $$.val = tree.MergeWhens{$1.mergeWhen()}
+  }
+| merge_when_list merge_when_clause
+  {
+    $$.val = append($1.mergeWhens(), $2.mergeWhen())
+  }
+
+merge_when_clause:
+  WHEN MATCHED opt_merge_when_cond THEN merge_when_matched_action
+  {
+    when := $5.mergeWhen()
+    when.Matched = true
+    when.Cond = $3.expr()
+    $$.val = when
+  }
+| WHEN NOT MATCHED opt_merge_when_cond THEN merge_when_not_matched_action
+  {
+    when := $6.mergeWhen()
+    when.Cond = $4.expr()
+    $$.val = when
+  }
+
+opt_merge_when_cond:
+  AND a_expr
+  {
+    $$.val = $2.expr()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.Expr(nil)
+  }
+
+merge_when_matched_action:
+  UPDATE SET set_clause_list
+  {
+    $$.val = &tree.MergeWhen{Action: tree.MergeActionUpdate, UpdateExprs: $3.updateExprs()}
+  }
+| DELETE
+  {
+    $$.val = &tree.MergeWhen{Action: tree.MergeActionDelete}
+  }
+| DO NOTHING
+  {
+    $$.val = &tree.MergeWhen{Action: tree.MergeActionDoNothing}
+  }
+
+merge_when_not_matched_action:
+  INSERT opt_column_list VALUES '(' expr_list ')'
+  {
+    $$.val = &tree.MergeWhen{Action: tree.MergeActionInsert, Columns: $2.nameList(), Values: $5.exprs()}
+  }
+| INSERT opt_column_list DEFAULT VALUES
+  {
+    $$.val = &tree.MergeWhen{Action: tree.MergeActionInsert, Columns: $2.nameList()}
+  }
+| DO NOTHING
+  {
+    $$.val = &tree.MergeWhen{Action: tree.MergeActionDoNothing}
+  }
+
 // %Help: UPSERT - create or replace rows in a table
 // %Category: DML
 // %Text:
@@ -13545,9 +14671,18 @@ group_by_list:
 // rather than reducing the conflicting unreserved_keyword rule.
 group_by_item:
   a_expr { $$.val = $1.expr() }
-| ROLLUP '(' error { return unimplementedWithIssueDetail(sqllex, 46280, "rollup") }
-| CUBE '(' error { return unimplementedWithIssueDetail(sqllex, 46280, "cube") }
-| GROUPING SETS error { return unimplementedWithIssueDetail(sqllex, 46280, "grouping sets") }
+| ROLLUP '(' expr_list ')'
+  {
+    $$.val = &tree.GroupingSet{Type: tree.RollupGroupingSet, Exprs: $3.exprs()}
+  }
+| CUBE '(' expr_list ')'
+  {
+    $$.val = &tree.GroupingSet{Type: tree.CubeGroupingSet, Exprs: $3.exprs()}
+  }
+| GROUPING SETS '(' group_by_list ')'
+  {
+    $$.val = &tree.GroupingSet{Type: tree.ExplicitGroupingSet, Exprs: $4.exprs()}
+  }
 
 having_clause:
   HAVING a_expr
@@ -14126,6 +15261,32 @@ relation_expr_list:
     $$.val = append($1.tableNames(), name)
   }
 
+// %Help: LISTEN - listen for notifications on a channel
+// %Category: Misc
+// %Text: LISTEN <channel>
+// %SeeAlso: NOTIFY
+listen_stmt:
+  LISTEN name
+  {
+    $$.val = &tree.Listen{ChannelName: tree.Name($2)}
+  }
+| LISTEN error // SHOW HELP: LISTEN
+
+// %Help: NOTIFY - send a notification on a channel
+// %Category: Misc
+// %Text: NOTIFY <channel> [, <payload>]
+// %SeeAlso: LISTEN
+notify_stmt:
+  NOTIFY name
+  {
+    $$.val = &tree.Notify{ChannelName: tree.Name($2)}
+  }
+| NOTIFY name ',' SCONST
+  {
+    $$.val = &tree.Notify{ChannelName: tree.Name($2), Payload: $4}
+  }
+| NOTIFY error // SHOW HELP: NOTIFY
+
 // UNLISTEN
 unlisten_stmt:
    UNLISTEN type_name
@@ -14259,20 +15420,16 @@ cast_target:
   }
 
 opt_array_bounds:
-  // TODO(justin): reintroduce multiple array bounds
-  // opt_array_bounds '[' ']' { $$.val = append($1.int32s(), -1) }
-  '[' ']' { $$.val = []int32{-1} }
-| '[' ']' '[' error { return unimplementedWithIssue(sqllex, 32552) }
-| '[' ICONST ']'
+  opt_array_bounds '[' ']' { $$.val = append($1.int32s(), -1) }
+| opt_array_bounds '[' ICONST ']'
   {
     /* SKIP DOC */
-    bound, err := $2.numVal().AsInt32()
+    bound, err := $3.numVal().AsInt32()
     if err != nil {
       return setErr(sqllex, err)
     }
-    $$.val = []int32{bound}
+    $$.val = append($1.int32s(), bound)
   }
-| '[' ICONST ']' '[' error { return unimplementedWithIssue(sqllex, 32552) }
 | /* EMPTY */ { $$.val = []int32(nil) }
 
 // general_type_name is a variant of type_or_function_name but does not
@@ -15035,6 +16192,10 @@ a_expr:
   {
     $$.val = &tree.ComparisonExpr{Operator: treecmp.MakeComparisonOperator(treecmp.TSMatches), Left: $1.expr(), Right: $3.expr()}
   }
+| a_expr ADJACENT a_expr
+  {
+    $$.val = &tree.ComparisonExpr{Operator: treecmp.MakeComparisonOperator(treecmp.Adjacent), Left: $1.expr(), Right: $3.expr()}
+  }
 | a_expr DISTANCE a_expr
   {
     $$.val = &tree.BinaryExpr{Operator: treebin.MakeBinaryOperator(treebin.Distance), Left: $1.expr(), Right: $3.expr()}
@@ -15601,7 +16762,10 @@ d_expr:
   {
     $$.val = $2.expr()
   }
-| GROUPING '(' expr_list ')' { return unimplemented(sqllex, "d_expr grouping") }
+| GROUPING '(' expr_list ')'
+  {
+    $$.val = &tree.GroupingExpr{Exprs: $3.exprs()}
+  }
 
 func_application:
   func_application_name '(' ')'
@@ -15612,8 +16776,14 @@ func_application:
   {
     $$.val = &tree.FuncExpr{Func: $1.resolvableFuncRef(), Exprs: $3.exprs(), OrderBy: $4.orderBy(), AggType: tree.GeneralAgg}
   }
-| func_application_name '(' VARIADIC a_expr opt_sort_clause_no_index ')' { return unimplemented(sqllex, "variadic") }
-| func_application_name '(' expr_list ',' VARIADIC a_expr opt_sort_clause_no_index ')' { return unimplemented(sqllex, "variadic") }
+| func_application_name '(' VARIADIC a_expr opt_sort_clause_no_index ')'
+  {
+    $$.val = &tree.FuncExpr{Func: $1.resolvableFuncRef(), Exprs: tree.Exprs{$4.expr()}, OrderBy: $5.orderBy(), AggType: tree.GeneralAgg, Variadic: true}
+  }
+| func_application_name '(' expr_list ',' VARIADIC a_expr opt_sort_clause_no_index ')'
+  {
+    $$.val = &tree.FuncExpr{Func: $1.resolvableFuncRef(), Exprs: append($3.exprs(), $6.expr()), OrderBy: $7.orderBy(), AggType: tree.GeneralAgg, Variadic: true}
+  }
 | func_application_name '(' ALL expr_list opt_sort_clause_no_index ')'
   {
     $$.val = &tree.FuncExpr{Func: $1.resolvableFuncRef(), Type: tree.AllFuncType, Exprs: $4.exprs(), OrderBy: $5.orderBy(), AggType: tree.GeneralAgg}
@@ -16259,6 +17429,7 @@ all_op:
 | NOT_REGIMATCH { $$.val = treecmp.MakeComparisonOperator(treecmp.NotRegIMatch) }
 | AND_AND { $$.val = treecmp.MakeComparisonOperator(treecmp.Overlaps) }
 | AT_AT { $$.val = treecmp.MakeComparisonOperator(treecmp.TSMatches) }
+| ADJACENT { $$.val = treecmp.MakeComparisonOperator(treecmp.Adjacent) }
 | DISTANCE { $$.val = treebin.MakeBinaryOperator(treebin.Distance) }
 | COS_DISTANCE { $$.val = treebin.MakeBinaryOperator(treebin.CosDistance) }
 | NEG_INNER_PRODUCT { $$.val = treebin.MakeBinaryOperator(treebin.NegInnerProduct) }
@@ -17238,6 +18409,7 @@ unreserved_keyword:
 | CLUSTER
 | CLUSTERS
 | COLUMNS
+| COMBINEFUNC
 | COMMENT
 | COMMENTS
 | COMMIT
@@ -17288,6 +18460,7 @@ unreserved_keyword:
 | DOMAIN
 | DOUBLE
 | DROP
+| EACH
 | ENCODING
 | ENCRYPTED
 | ENCRYPTION_PASSPHRASE
@@ -17313,6 +18486,7 @@ unreserved_keyword:
 | FAILURE
 | FILES
 | FILTER
+| FINALFUNC
 | FIRST
 | FOLLOWING
 | FORMAT
@@ -17361,9 +18535,11 @@ unreserved_keyword:
 | INDEX
 | INDEXES
 | INHERITS
+| INITCOND
 | INJECT
 | INPUT
 | INSERT
+| INSTEAD
 | INTO_DB
 | INVERTED
 | INVISIBLE
@@ -17391,6 +18567,7 @@ unreserved_keyword:
 | LINESTRINGZ
 | LINESTRINGZM
 | LIST
+| LISTEN
 | LOCAL
 | LOCKED
 | LOGICAL
@@ -17399,6 +18576,7 @@ unreserved_keyword:
 | LOOKUP
 | LOW
 | MATCH
+| MATCHED
 | MATERIALIZED
 | MAXVALUE
 | MERGE
@@ -17425,12 +18603,14 @@ unreserved_keyword:
 | NAMES
 | NAN
 | NEVER
+| NEW
 | NEW_DB_NAME
 | NEW_KMS
 | NEXT
 | NO
 | NORMAL
 | NOTHING
+| NOTIFY
 | NO_INDEX_JOIN
 | NO_ZIGZAG_JOIN
 | NO_FULL_SCAN
@@ -17456,6 +18636,7 @@ unreserved_keyword:
 | OF
 | OFF
 | OIDS
+| OLD
 | OLD_KMS
 | OPERATOR
 | OPT
@@ -17507,6 +18688,7 @@ unreserved_keyword:
 | RECURSIVE
 | REDACT
 | REF
+| REFERENCING
 | REFRESH
 | REGION
 | REGIONAL
@@ -17546,6 +18728,7 @@ unreserved_keyword:
 | SCROLL
 | SETTING
 | SETTINGS
+| STATEMENT
 | STATUS
 | SAVEPOINT
 | SCANS
@@ -17566,6 +18749,7 @@ unreserved_keyword:
 | SESSIONS
 | SET
 | SETS
+| SFUNC
 | SHARE
 | SHARED
 | SHOW
@@ -17598,6 +18782,7 @@ unreserved_keyword:
 | STREAM
 | STRICT
 | SUBSCRIPTION
+| STYPE
 | SUBJECT
 | SUPER
 | SUPPORT
@@ -17662,6 +18847,7 @@ unreserved_keyword:
 | VOTERS
 | WITHIN
 | WITHOUT
+| WRAPPER
 | WRITE
 | YEAR
 | ZONE
@@ -17734,6 +18920,7 @@ bare_label_keywords:
 | COLLATION
 | COLUMN
 | COLUMNS
+| COMBINEFUNC
 | COMMENT
 | COMMENTS
 | COMMIT
@@ -17800,6 +18987,7 @@ bare_label_keywords:
 | DOMAIN
 | DOUBLE
 | DROP
+| EACH
 | ELSE
 | ENCODING
 | ENCRYPTED
@@ -17831,6 +19019,7 @@ bare_label_keywords:
 | FALSE
 | FAMILY
 | FILES
+| FINALFUNC
 | FIRST
 | FLOAT
 | FOLLOWING
@@ -17895,12 +19084,14 @@ bare_label_keywords:
 | INDEX_BEFORE_PAREN
 | INHERITS
 | INITIALLY
+| INITCOND
 | INJECT
 | INNER
 | INOUT
 | INPUT
 | INSENSITIVE
 | INSERT
+| INSTEAD
 | INT
 | INTEGER
 | INTERVAL
@@ -17939,6 +19130,7 @@ bare_label_keywords:
 | LINESTRINGZ
 | LINESTRINGZM
 | LIST
+| LISTEN
 | LOCAL
 | LOCALITY
 | LOCALTIME
@@ -17949,6 +19141,7 @@ bare_label_keywords:
 | LOOKUP
 | LOW
 | MATCH
+| MATCHED
 | MATERIALIZED
 | MAXVALUE
 | MERGE
@@ -17974,6 +19167,7 @@ bare_label_keywords:
 | NAN
 | NATURAL
 | NEVER
+| NEW
 | NEW_DB_NAME
 | NEW_KMS
 | NEXT
@@ -17995,6 +19189,7 @@ bare_label_keywords:
 | NOT
 | NOTHING
 | NOTHING_AFTER_RETURNING
+| NOTIFY
 | NOVIEWACTIVITY
 | NOVIEWACTIVITYREDACTED
 | NOVIEWCLUSTERSETTING
@@ -18009,6 +19204,7 @@ bare_label_keywords:
 | OF
 | OFF
 | OIDS
+| OLD
 | OLD_KMS
 | ONLY
 | OPERATOR
@@ -18071,6 +19267,7 @@ bare_label_keywords:
 | REDACT
 | REF
 | REFERENCES
+| REFERENCING
 | REFRESH
 | REGION
 | REGIONAL
@@ -18133,6 +19330,7 @@ bare_label_keywords:
 | SETS
 | SETTING
 | SETTINGS
+| SFUNC
 | SHARE
 | SHARED
 | SHOW
@@ -18155,6 +19353,7 @@ bare_label_keywords:
 | STABLE
 | START
 | STATE
+| STATEMENT
 | STATEMENTS
 | STATISTICS
 | STATUS
@@ -18171,6 +19370,7 @@ bare_label_keywords:
 | STRING
 | SUBSCRIPTION
 | SUBSTRING
+| STYPE
 | SUBJECT
 | SUPER
 | SUPPORT
@@ -18254,6 +19454,7 @@ bare_label_keywords:
 | VOTERS
 | WHEN
 | WORK
+| WRAPPER
 | WRITE
 | ZONE
 
@@ -0,0 +1,152 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/scheduledjobs"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/cockroachdb/errors"
+	pbtypes "github.com/gogo/protobuf/types"
+)
+
+// BuildPartitionMaintenanceScheduleLabel returns the label of the partition
+// maintenance schedule of the given table.
+func BuildPartitionMaintenanceScheduleLabel(tableID catid.DescID) string {
+	return fmt.Sprintf("partition-maintenance-%d", tableID)
+}
+
+// checkPartitionMaintenanceEnabled returns an error if automatic partitioning
+// is not available. The partition maintenance job is implemented in CCL code,
+// and repartitions tables with PARTITION BY, which requires an enterprise
+// license.
+func checkPartitionMaintenanceEnabled(st *cluster.Settings) error {
+	return base.CheckEnterpriseEnabled(st, "automatic partitioning")
+}
+
+// newPartitionMaintenanceScheduledJob returns a *jobs.ScheduledJob for the
+// automatic partitioning of the given table. The first run of the schedule is
+// due immediately, so that the partitions of the table are created as soon as
+// possible.
+func newPartitionMaintenanceScheduledJob(
+	env scheduledjobs.JobSchedulerEnv,
+	owner username.SQLUsername,
+	tblDesc *tabledesc.Mutable,
+	clusterID uuid.UUID,
+	clusterVersion clusterversion.ClusterVersion,
+) (*jobs.ScheduledJob, error) {
+	sj := jobs.NewScheduledJob(env)
+	sj.SetScheduleLabel(BuildPartitionMaintenanceScheduleLabel(tblDesc.GetID()))
+	sj.SetOwner(owner)
+	sj.SetScheduleDetails(jobspb.ScheduleDetails{
+		Wait: jobspb.ScheduleDetails_WAIT,
+		// If a job fails, try again at the allocated cron time.
+		OnError:                jobspb.ScheduleDetails_RETRY_SCHED,
+		ClusterID:              clusterID,
+		CreationClusterVersion: clusterVersion,
+	})
+	if err := sj.SetSchedule(catpb.PartitionMaintenanceCron); err != nil {
+		return nil, err
+	}
+	sj.SetNextRun(env.Now())
+	args := &catpb.ScheduledPartitionMaintenanceArgs{
+		TableID: tblDesc.GetID(),
+	}
+	any, err := pbtypes.MarshalAny(args)
+	if err != nil {
+		return nil, err
+	}
+	sj.SetExecutionDetails(
+		tree.ScheduledPartitionMaintenanceExecutor.InternalName(),
+		jobspb.ExecutionArguments{Args: any},
+	)
+	return sj, nil
+}
+
+// CreatePartitionMaintenanceScheduledJob creates a new partition maintenance
+// schedule.
+func CreatePartitionMaintenanceScheduledJob(
+	ctx context.Context,
+	knobs *jobs.TestingKnobs,
+	s jobs.ScheduledJobStorage,
+	owner username.SQLUsername,
+	tblDesc *tabledesc.Mutable,
+	clusterID uuid.UUID,
+	version clusterversion.ClusterVersion,
+) (*jobs.ScheduledJob, error) {
+	if !tblDesc.HasPartitionMaintenance() {
+		return nil, errors.AssertionFailedf(
+			"CreatePartitionMaintenanceScheduledJob called with no .PartitionMaintenance: %#v", tblDesc,
+		)
+	}
+	env := JobSchedulerEnv(knobs)
+	j, err := newPartitionMaintenanceScheduledJob(env, owner, tblDesc, clusterID, version)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Create(ctx, j); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+// handlePartitionMaintenanceStorageParamChange validates the automatic
+// partitioning of the table after its storage parameters were changed, and
+// creates or deletes its schedule as needed. before is the automatic
+// partitioning config of the table before the change.
+func handlePartitionMaintenanceStorageParamChange(
+	params runParams, tableDesc *tabledesc.Mutable, before *catpb.PartitionMaintenance,
+) error {
+	after := tableDesc.GetPartitionMaintenance()
+	if after == nil {
+		if before != nil && before.ScheduleID != 0 {
+			return DeleteSchedule(
+				params.ctx, params.ExecCfg(), params.p.InternalSQLTxn(), before.ScheduleID,
+			)
+		}
+		return nil
+	}
+	if err := checkPartitionMaintenanceEnabled(params.ExecCfg().Settings); err != nil {
+		return err
+	}
+	if _, err := tabledesc.ValidatePartitionMaintenanceTable(tableDesc); err != nil {
+		return err
+	}
+	if after.ScheduleID != 0 {
+		return nil
+	}
+	j, err := CreatePartitionMaintenanceScheduledJob(
+		params.ctx,
+		params.ExecCfg().JobsKnobs(),
+		jobs.ScheduledJobTxn(params.p.InternalSQLTxn()),
+		params.p.User(),
+		tableDesc,
+		params.p.extendedEvalCtx.ClusterID,
+		params.ExecCfg().Settings.Version.ActiveVersion(params.ctx),
+	)
+	if err != nil {
+		return err
+	}
+	after.ScheduleID = j.ScheduleID()
+	return nil
+}
@@ -21,6 +21,7 @@ import (
 	"time"
 	"unicode"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
@@ -966,6 +967,7 @@ func populateTableConstraints(
 		consrc := tree.DNull
 		conbin := tree.DNull
 		condef := tree.DNull
+		deferrability := semenumpb.Deferrability_NOT_DEFERRABLE
 
 		// Determine constraint kind-specific fields.
 		var err error
@@ -1037,6 +1039,7 @@ func populateTableConstraints(
 			if r, ok := fkMatchMap[fk.Match()]; ok {
 				confmatchtype = r
 			}
+			deferrability = fk.Deferrability()
 			if conkey, err = colIDArrayToDatum(fk.ForeignKeyDesc().OriginColumnIDs); err != nil {
 				return err
 			}
@@ -1066,6 +1069,11 @@ func populateTableConstraints(
 			}
 			f.WriteString(strings.Join(colNames, ", "))
 			f.WriteByte(')')
+			deferrability = uwoi.Deferrability()
+			if deferrability != semenumpb.Deferrability_NOT_DEFERRABLE {
+				f.WriteByte(' ')
+				f.WriteString(tree.ConstraintDeferrabilityType[deferrability].String())
+			}
 			if !uwoi.IsConstraintValidated() {
 				f.WriteString(" NOT VALID")
 			}
@@ -1096,13 +1104,16 @@ func populateTableConstraints(
 			condef = tree.NewDString(fmt.Sprintf("CHECK ((%s))%s", displayExpr, validity))
 		}
 
+		condeferrable := tree.MakeDBool(tree.DBool(deferrability != semenumpb.Deferrability_NOT_DEFERRABLE))
+		condeferred := tree.MakeDBool(tree.DBool(deferrability == semenumpb.Deferrability_INITIALLY_DEFERRED))
+
 		if err := addRow(
 			conoid,                   // oid
 			dNameOrNull(c.GetName()), // conname
 			namespaceOid,             // connamespace
 			contype,                  // contype
-			tree.DBoolFalse,          // condeferrable
-			tree.DBoolFalse,          // condeferred
+			condeferrable,            // condeferrable
+			condeferred,              // condeferred
 			tree.MakeDBool(tree.DBool(!c.IsConstraintUnvalidated())), // convalidated
 			tblOid,         // conrelid
 			oidZero,        // contypid
@@ -2625,6 +2636,7 @@ var (
 	proArgModeOut      = tree.NewDString("o")
 	proArgModeInOut    = tree.NewDString("b")
 	proArgModeVariadic = tree.NewDString("v")
+	proArgModeTable    = tree.NewDString("t")
 )
 
 func addPgProcUDFRow(
@@ -2651,8 +2663,13 @@ func addPgProcUDFRow(
 	var foundAnyArgNames bool
 	var nArgs, nArgDefaults int
 	var argDefaultsBuilder strings.Builder
+	variadic := oidZero
 	for _, param := range fnDesc.GetParams() {
 		class := funcdesc.ToTreeRoutineParamClass(param.Class)
+		if class == tree.RoutineParamVariadic {
+			// provariadic is the element type of the VARIADIC parameter.
+			variadic = tree.NewDOid(param.Type.ArrayContents().Oid())
+		}
 		if tree.IsInParamClass(class) {
 			// nArgs tracks only the number of input arguments.
 			nArgs++
@@ -2689,6 +2706,8 @@ func addPgProcUDFRow(
 			argMode = proArgModeInOut
 		case tree.RoutineParamVariadic:
 			argMode = proArgModeVariadic
+		case tree.RoutineParamTable:
+			argMode = proArgModeTable
 		default:
 			return errors.AssertionFailedf("unknown parameter class %d", class)
 		}
@@ -2716,18 +2735,29 @@ func addPgProcUDFRow(
 	if nArgDefaults > 0 {
 		argDefaults = tree.NewDString("(" + argDefaultsBuilder.String() + ")")
 	}
+	config := tree.DNull
+	if len(fnDesc.GetConfig()) > 0 {
+		// If the function has no SET clauses, then proconfig is NULL.
+		configArray := tree.NewDArray(types.String)
+		for _, setting := range fnDesc.GetConfig() {
+			if err := configArray.Append(tree.NewDString(setting)); err != nil {
+				return err
+			}
+		}
+		config = configArray
+	}
 	return addRow(
 		tree.NewDOid(catid.FuncIDToOID(fnDesc.GetID())), // oid
 		tree.NewDName(fnDesc.GetName()),                 // proname
 		schemaOid(scDesc.GetID()),                       // pronamespace
 		h.UserOid(fnDesc.GetPrivileges().Owner()),       // proowner
-		lang,            // prolang
-		tree.DNull,      // procost
-		tree.DNull,      // prorows
-		oidZero,         // provariadic // TODO(88947): this might need an adjustment.
-		tree.DNull,      // prosupport
-		kind,            // prokind
-		tree.DBoolFalse, // prosecdef
+		lang,       // prolang
+		tree.DNull, // procost
+		tree.DNull, // prorows
+		variadic,   // provariadic
+		tree.DNull, // prosupport
+		kind,       // prokind
+		tree.MakeDBool(fnDesc.GetSecurity() == catpb.Function_DEFINER),                       // prosecdef
 		tree.MakeDBool(tree.DBool(fnDesc.GetLeakProof())),                                    // proleakproof
 		tree.MakeDBool(fnDesc.GetNullInputBehavior() != catpb.Function_CALLED_ON_NULL_INPUT), // proisstrict
 		tree.MakeDBool(tree.DBool(fnDesc.GetReturnType().ReturnSet)),                         // proretset
@@ -2742,11 +2772,11 @@ func addPgProcUDFRow(
 		argNames,                                        // proargnames
 		argDefaults,                                     // proargdefaults
 		tree.DNull,                                      // protrftypes
-		tree.NewDString(fnDesc.GetFunctionBody()),       // prosrc
-		tree.DNull,                                      // probin
-		tree.DNull,                                      // prosqlbody
-		tree.DNull,                                      // proconfig
-		tree.DNull,                                      // proacl
+		tree.NewDString(fnDesc.GetFunctionBody()), // prosrc
+		tree.DNull, // probin
+		tree.DNull, // prosqlbody
+		config,     // proconfig
+		tree.DNull, // proacl
 	)
 }
 
@@ -3263,7 +3293,6 @@ var (
 	typTypeRange     = tree.NewDString("r")
 
 	// Avoid unused warning for constants.
-	_ = typTypeDomain
 	_ = typTypePseudo
 	_ = typTypeRange
 
@@ -3393,14 +3422,27 @@ func addPGTypeRow(
 		if isUDT {
 			typrelid = tree.NewDOid(typ.Oid())
 		}
-	case types.VoidFamily:
-		// void does not have an array type.
+	case types.RangeFamily:
+		builtinPrefix = "range_"
+		typType = typTypeRange
+		typArray = tree.NewDOid(types.CalcArrayOid(typ))
+	case types.VoidFamily, types.TriggerFamily:
+		// void and trigger do not have an array type.
 	default:
 		typArray = tree.NewDOid(types.CalcArrayOid(typ))
 	}
 	if cat == typCategoryPseudo {
 		typType = typTypePseudo
 	}
+	typNotNull := tree.DBoolFalse
+	typBaseType := oidZero
+	if typ.IsDomain() {
+		typType = typTypeDomain
+		typBaseType = tree.NewDOid(typ.DomainBaseType().Oid())
+		if d := typ.TypeMeta.DomainData; d != nil && d.NotNull {
+			typNotNull = tree.DBoolTrue
+		}
+	}
 	typname := typ.PGName()
 	typDelim := tree.NewDString(typ.Delimiter())
 	return addRow(
@@ -3430,8 +3472,8 @@ func addPGTypeRow(
 
 		tree.DNull,      // typalign
 		tree.DNull,      // typstorage
-		tree.DBoolFalse, // typnotnull
-		oidZero,         // typbasetype
+		typNotNull,      // typnotnull
+		typBaseType,     // typbasetype
 		negOneVal,       // typtypmod
 		zeroVal,         // typndims
 		typColl(typ, h), // typcollation
@@ -4224,12 +4266,95 @@ var pgCatalogStatUserFunctionsTable = virtualSchemaTable{
 }
 
 var pgCatalogPublicationTable = virtualSchemaTable{
-	comment: "pg_publication was created for compatibility and is currently unimplemented",
-	schema:  vtable.PgCatalogPublication,
-	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return nil
+	comment: `publications created by CREATE PUBLICATION
+https://www.postgresql.org/docs/current/catalog-pg-publication.html`,
+	schema: vtable.PgCatalogPublication,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		h := makeOidHasher()
+		return forEachPublication(ctx, p, dbContext, func(pub *publication) error {
+			return addRow(
+				h.PublicationOid(pub.databaseID, pub.name), // oid
+				tree.NewDName(pub.name),                    // pubname
+				h.UserOid(pub.owner),                       // pubowner
+				tree.MakeDBool(tree.DBool(pub.allTables)),  // puballtables
+				tree.DBoolTrue,                             // pubinsert
+				tree.DBoolTrue,                             // pubupdate
+				tree.DBoolTrue,                             // pubdelete
+				tree.DBoolFalse,                            // pubtruncate
+				tree.DBoolFalse,                            // pubviaroot
+			)
+		})
 	},
-	unimplemented: true,
+}
+
+// forEachPublication calls fn on each publication of the given database, or
+// of all the databases if dbContext is nil.
+func forEachPublication(
+	ctx context.Context,
+	p *planner,
+	dbContext catalog.DatabaseDescriptor,
+	fn func(pub *publication) error,
+) error {
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V24_2_LogicalReplicationTables) {
+		return nil
+	}
+	var dbID descpb.ID
+	if dbContext != nil {
+		dbID = dbContext.GetID()
+	}
+	pubs, err := listPublications(ctx, p.InternalSQLTxn(), dbID)
+	if err != nil {
+		return err
+	}
+	for _, pub := range pubs {
+		if err := fn(pub); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// forEachPublishedTable calls fn on each table published by each publication
+// of the given database, or of all the databases if dbContext is nil.
+func forEachPublishedTable(
+	ctx context.Context,
+	p *planner,
+	dbContext catalog.DatabaseDescriptor,
+	fn func(pub *publication, sc catalog.SchemaDescriptor, table catalog.TableDescriptor) error,
+) error {
+	type schemaAndTable struct {
+		sc    catalog.SchemaDescriptor
+		table catalog.TableDescriptor
+	}
+	var pubs []*publication
+	if err := forEachPublication(ctx, p, dbContext, func(pub *publication) error {
+		pubs = append(pubs, pub)
+		return nil
+	}); err != nil || len(pubs) == 0 {
+		return err
+	}
+	tablesByDB := make(map[descpb.ID][]schemaAndTable)
+	if err := forEachTableDesc(ctx, p, dbContext, hideVirtual,
+		func(ctx context.Context, db catalog.DatabaseDescriptor, sc catalog.SchemaDescriptor, table catalog.TableDescriptor) error {
+			if !table.IsTable() || table.IsTemporary() {
+				return nil
+			}
+			tablesByDB[db.GetID()] = append(tablesByDB[db.GetID()], schemaAndTable{sc: sc, table: table})
+			return nil
+		}); err != nil {
+		return err
+	}
+	for _, pub := range pubs {
+		for _, t := range tablesByDB[pub.databaseID] {
+			if !pub.allTables && !pub.hasTable(t.table.GetID()) {
+				continue
+			}
+			if err := fn(pub, t.sc, t.table); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
 }
 
 var pgCatalogAmprocTable = virtualSchemaTable{
@@ -4384,12 +4509,19 @@ var pgCatalogStatXactUserTablesTable = virtualSchemaTable{
 }
 
 var pgCatalogPublicationTablesTable = virtualSchemaTable{
-	comment: "pg_publication_tables was created for compatibility and is currently unimplemented",
-	schema:  vtable.PgCatalogPublicationTables,
-	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return nil
+	comment: `tables published by publications
+https://www.postgresql.org/docs/current/view-pg-publication-tables.html`,
+	schema: vtable.PgCatalogPublicationTables,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		return forEachPublishedTable(ctx, p, dbContext,
+			func(pub *publication, sc catalog.SchemaDescriptor, table catalog.TableDescriptor) error {
+				return addRow(
+					tree.NewDName(pub.name),        // pubname
+					tree.NewDName(sc.GetName()),    // schemaname
+					tree.NewDName(table.GetName()), // tablename
+				)
+			})
 	},
-	unimplemented: true,
 }
 
 var pgCatalogStatProgressClusterTable = virtualSchemaTable{
@@ -4420,12 +4552,54 @@ var pgCatalogLargeobjectMetadataTable = virtualSchemaTable{
 }
 
 var pgCatalogReplicationSlotsTable = virtualSchemaTable{
-	comment: "pg_replication_slots was created for compatibility and is currently unimplemented",
-	schema:  vtable.PgCatalogReplicationSlots,
+	comment: `replication slots created by CREATE_REPLICATION_SLOT
+https://www.postgresql.org/docs/current/view-pg-replication-slots.html`,
+	schema: vtable.PgCatalogReplicationSlots,
 	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V24_2_LogicalReplicationTables) {
+			return nil
+		}
+		slots, err := listReplicationSlots(ctx, p.InternalSQLTxn())
+		if err != nil {
+			return err
+		}
+		if len(slots) == 0 {
+			return nil
+		}
+		dbs, err := p.Descriptors().GetAllDatabaseDescriptors(ctx, p.txn)
+		if err != nil {
+			return err
+		}
+		dbNames := make(map[descpb.ID]string, len(dbs))
+		for _, db := range dbs {
+			dbNames[db.GetID()] = db.GetName()
+		}
+		for _, slot := range slots {
+			dbName := tree.DNull
+			if name, ok := dbNames[slot.databaseID]; ok {
+				dbName = tree.NewDName(name)
+			}
+			if err := addRow(
+				tree.NewDName(slot.name),   // slot_name
+				tree.NewDName(slot.plugin), // plugin
+				tree.NewDString("logical"), // slot_type
+				dbOid(slot.databaseID),     // datoid
+				dbName,                     // database
+				tree.DBoolFalse,            // temporary
+				tree.DBoolFalse,            // active
+				tree.DNull,                 // active_pid
+				tree.DNull,                 // xmin
+				tree.DNull,                 // catalog_xmin
+				tree.NewDString(slot.confirmedFlushLSN.String()), // restart_lsn
+				tree.NewDString(slot.confirmedFlushLSN.String()), // confirmed_flush_lsn
+				tree.NewDString("reserved"),                      // wal_status
+				tree.DNull,                                       // safe_wal_size
+			); err != nil {
+				return err
+			}
+		}
 		return nil
 	},
-	unimplemented: true,
 }
 
 var pgCatalogSubscriptionRelTable = virtualSchemaTable{
@@ -4696,12 +4870,24 @@ var pgCatalogStatReplicationTable = virtualSchemaTable{
 }
 
 var pgCatalogPublicationRelTable = virtualSchemaTable{
-	comment: "pg_publication_rel was created for compatibility and is currently unimplemented",
-	schema:  vtable.PgCatalogPublicationRel,
-	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return nil
+	comment: `tables explicitly listed by publications
+https://www.postgresql.org/docs/current/catalog-pg-publication-rel.html`,
+	schema: vtable.PgCatalogPublicationRel,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		h := makeOidHasher()
+		return forEachPublishedTable(ctx, p, dbContext,
+			func(pub *publication, _ catalog.SchemaDescriptor, table catalog.TableDescriptor) error {
+				if pub.allTables {
+					return nil
+				}
+				pubOid := h.PublicationOid(pub.databaseID, pub.name)
+				return addRow(
+					h.PublicationRelOid(pubOid, table.GetID()), // oid
+					pubOid,                  // prpubid
+					tableOid(table.GetID()), // prrelid
+				)
+			})
 	},
-	unimplemented: true,
 }
 
 var pgCatalogAvailableExtensionVersionsTable = virtualSchemaTable{
@@ -4776,11 +4962,13 @@ var datumToTypeCategory = map[types.Family]*tree.DString{
 	types.OidFamily:         typCategoryNumeric,
 	types.PGLSNFamily:       typCategoryUserDefined,
 	types.PGVectorFamily:    typCategoryUserDefined,
+	types.RangeFamily:       typCategoryRange,
 	types.RefCursorFamily:   typCategoryUserDefined,
 	types.UuidFamily:        typCategoryUserDefined,
 	types.INetFamily:        typCategoryNetworkAddr,
 	types.UnknownFamily:     typCategoryUnknown,
 	types.VoidFamily:        typCategoryPseudo,
+	types.TriggerFamily:     typCategoryPseudo,
 }
 
 func typCategory(typ *types.T) tree.Datum {
@@ -4994,6 +5182,8 @@ const (
 	rewriteTypeTag
 	dbSchemaRoleTypeTag
 	castTypeTag
+	publicationTypeTag
+	publicationRelTypeTag
 )
 
 func (h oidHasher) writeTypeTag(tag oidTypeTag) {
@@ -5193,6 +5383,20 @@ func (h oidHasher) CastOid(srcID oid.Oid, tgtID oid.Oid) *tree.DOid {
 	return h.getOid()
 }
 
+func (h oidHasher) PublicationOid(dbID descpb.ID, name string) *tree.DOid {
+	h.writeTypeTag(publicationTypeTag)
+	h.writeDB(dbID)
+	h.writeStr(name)
+	return h.getOid()
+}
+
+func (h oidHasher) PublicationRelOid(pubOid *tree.DOid, tableID descpb.ID) *tree.DOid {
+	h.writeTypeTag(publicationRelTypeTag)
+	h.writeOID(pubOid)
+	h.writeTable(tableID)
+	return h.getOid()
+}
+
 func funcVolatility(v catpb.Function_Volatility) string {
 	switch v {
 	case catpb.Function_IMMUTABLE:
@@ -0,0 +1,532 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package pgnotify implements the asynchronous notification mechanism behind
+// the LISTEN and NOTIFY statements.
+//
+// Notifications are written to the system.notifications table as part of the
+// notifying transaction, so they become visible only if and when that
+// transaction commits. Every node that has at least one listening session
+// runs a rangefeed over the table and forwards the notifications it observes
+// to the sessions listening on the corresponding channel. Old notifications
+// are periodically deleted from the table.
+package pgnotify
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/rangefeed"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/multitenant"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/systemschema"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/valueside"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+)
+
+// MaxPayloadBytes is the limit on the size of a notification payload. It
+// matches the limit imposed by Postgres.
+const MaxPayloadBytes = 8000
+
+// maxChannelNameBytes is the limit on the length of a channel name. It
+// matches the limit on identifiers imposed by Postgres.
+const maxChannelNameBytes = 63
+
+// maxPendingNotifications is the maximum number of notifications queued for a
+// single session before new notifications are dropped.
+const maxPendingNotifications = 1 << 16
+
+// deleteBatchSize is the maximum number of expired notifications deleted by a
+// single statement.
+const deleteBatchSize = 1024
+
+// Retention is the amount of time notifications are kept in the
+// system.notifications table before they are deleted.
+var Retention = settings.RegisterDurationSetting(
+	settings.ApplicationLevel,
+	"sql.notifications.retention",
+	"the amount of time a notification sent with NOTIFY is retained before it is deleted",
+	time.Minute,
+	settings.PositiveDuration,
+)
+
+// cleanupInterval is the interval at which expired notifications are deleted.
+var cleanupInterval = settings.RegisterDurationSetting(
+	settings.ApplicationLevel,
+	"sql.notifications.cleanup.interval",
+	"the interval at which expired notifications are deleted",
+	time.Minute,
+	settings.PositiveDuration,
+)
+
+// Notification is an asynchronous notification sent with NOTIFY.
+type Notification struct {
+	// Channel is the name of the channel the notification was sent on.
+	Channel string
+	// Payload is the (possibly empty) payload of the notification.
+	Payload string
+	// SenderPID is the backend PID of the session that sent the notification.
+	SenderPID int32
+}
+
+// Validate checks that the channel name and payload of the notification are
+// acceptable.
+func (n Notification) Validate() error {
+	if err := ValidateChannel(n.Channel); err != nil {
+		return err
+	}
+	if len(n.Payload) >= MaxPayloadBytes {
+		return pgerror.New(pgcode.InvalidParameterValue, "payload string too long")
+	}
+	return nil
+}
+
+// ValidateChannel checks that the given channel name is acceptable.
+func ValidateChannel(channel string) error {
+	if channel == "" {
+		return pgerror.New(pgcode.InvalidParameterValue, "channel name cannot be empty")
+	}
+	if len(channel) > maxChannelNameBytes {
+		return pgerror.New(pgcode.InvalidParameterValue, "channel name too long")
+	}
+	return nil
+}
+
+// Notify sends the notification as part of the given transaction. The
+// notification is delivered to the listening sessions only once the
+// transaction commits.
+func Notify(ctx context.Context, txn isql.Txn, n Notification) error {
+	if err := n.Validate(); err != nil {
+		return err
+	}
+	_, err := txn.ExecEx(
+		ctx, "notify", txn.KV(), sessiondata.NodeUserSessionDataOverride,
+		`INSERT INTO system.notifications (channel, payload, sender_pid) VALUES ($1, $2, $3)`,
+		n.Channel, n.Payload, n.SenderPID,
+	)
+	return err
+}
+
+// Registry keeps track of the sessions on this node which are listening for
+// notifications and delivers notifications to them.
+type Registry struct {
+	ambientCtx       log.AmbientContext
+	st               *cluster.Settings
+	codec            keys.SQLCodec
+	clock            *hlc.Clock
+	db               isql.DB
+	stopper          *stop.Stopper
+	rangeFeedFactory *rangefeed.Factory
+	tableIDResolver  catalog.SystemTableIDResolver
+
+	decoder valueside.Decoder
+
+	mu struct {
+		syncutil.Mutex
+		// listeners maps each channel to the set of listeners which are
+		// listening on it.
+		listeners map[string]map[*Listener]struct{}
+		// feed is the rangefeed over system.notifications. It is started when
+		// the first session on this node starts listening.
+		feed *rangefeed.RangeFeed
+		// frontier is the timestamp up to which the feed has delivered every
+		// notification.
+		frontier hlc.Timestamp
+		// delivered contains the notifications above the frontier which have
+		// already been dispatched. The rangefeed may replay them, for example
+		// when it restarts after an error, and they must not be delivered
+		// twice.
+		delivered map[deliveredKey]struct{}
+	}
+}
+
+// deliveredKey identifies a notification observed by the rangefeed.
+type deliveredKey struct {
+	key string
+	ts  hlc.Timestamp
+}
+
+// NewRegistry creates a new Registry.
+func NewRegistry(
+	ambientCtx log.AmbientContext,
+	st *cluster.Settings,
+	codec keys.SQLCodec,
+	clock *hlc.Clock,
+	db isql.DB,
+	stopper *stop.Stopper,
+	rangeFeedFactory *rangefeed.Factory,
+	tableIDResolver catalog.SystemTableIDResolver,
+) *Registry {
+	r := &Registry{
+		ambientCtx:       ambientCtx,
+		st:               st,
+		codec:            codec,
+		clock:            clock,
+		db:               db,
+		stopper:          stopper,
+		rangeFeedFactory: rangeFeedFactory,
+		tableIDResolver:  tableIDResolver,
+		decoder:          valueside.MakeDecoder(systemschema.NotificationsTable.PublicColumns()),
+	}
+	r.mu.listeners = make(map[string]map[*Listener]struct{})
+	r.mu.delivered = make(map[deliveredKey]struct{})
+	return r
+}
+
+// Start starts the background task which deletes expired notifications.
+func (r *Registry) Start(ctx context.Context) error {
+	ctx = r.ambientCtx.AnnotateCtx(ctx)
+	return r.stopper.RunAsyncTask(ctx, "notifications-cleanup", func(ctx context.Context) {
+		ctx, cancel := r.stopper.WithCancelOnQuiesce(ctx)
+		defer cancel()
+		ctx = multitenant.WithTenantCostControlExemption(ctx)
+
+		var timer timeutil.Timer
+		defer timer.Stop()
+		for {
+			timer.Reset(cleanupInterval.Get(&r.st.SV))
+			select {
+			case <-timer.C:
+				timer.Read = true
+			case <-ctx.Done():
+				return
+			}
+			if !r.st.Version.IsActive(ctx, clusterversion.V24_2_NotificationsTable) {
+				continue
+			}
+			if err := r.deleteExpired(ctx); err != nil {
+				log.Warningf(ctx, "failed to delete expired notifications: %v", err)
+			}
+		}
+	})
+}
+
+// deleteExpired deletes the notifications which are older than the retention
+// period.
+func (r *Registry) deleteExpired(ctx context.Context) error {
+	cutoff := r.clock.PhysicalTime().Add(-Retention.Get(&r.st.SV))
+	for {
+		n, err := r.db.Executor().ExecEx(
+			ctx, "delete-expired-notifications", nil, /* txn */
+			sessiondata.NodeUserSessionDataOverride,
+			`DELETE FROM system.notifications WHERE created < $1 LIMIT $2`,
+			cutoff, deleteBatchSize,
+		)
+		if err != nil {
+			return err
+		}
+		if n < deleteBatchSize {
+			return nil
+		}
+	}
+}
+
+// NewListener creates a new Listener for a session. The wake function is
+// called whenever notifications are queued for a listener which had no
+// pending notifications; it must not block.
+func (r *Registry) NewListener(wake func()) *Listener {
+	l := &Listener{registry: r, wake: wake}
+	l.mu.channels = make(map[string]struct{})
+	return l
+}
+
+// ensureFeed starts the rangefeed over system.notifications if it is not
+// running yet.
+func (r *Registry) ensureFeed(ctx context.Context) error {
+	r.mu.Lock()
+	running := r.mu.feed != nil
+	r.mu.Unlock()
+	if running {
+		return nil
+	}
+
+	tableID, err := r.tableIDResolver.LookupSystemTableID(ctx, string(catconstants.NotificationsTableName))
+	if err != nil {
+		return err
+	}
+	// Only the primary index is watched: the entries of the secondary indexes
+	// do not hold the notifications.
+	prefix := r.codec.IndexPrefix(
+		uint32(tableID), uint32(systemschema.NotificationsTable.GetPrimaryIndexID()),
+	)
+	span := roachpb.Span{Key: prefix, EndKey: prefix.PrefixEnd()}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.mu.feed != nil {
+		return nil
+	}
+	// The feed outlives the session which started it, so it must not use the
+	// session's context.
+	feedCtx := multitenant.WithTenantCostControlExemption(
+		r.ambientCtx.AnnotateCtx(context.Background()),
+	)
+	// Only notifications which are committed after the feed starts are of
+	// interest.
+	startTS := r.clock.Now()
+	r.mu.frontier = startTS
+	feed, err := r.rangeFeedFactory.RangeFeed(feedCtx,
+		"notifications",
+		[]roachpb.Span{span},
+		startTS,
+		r.onValue,
+		rangefeed.WithOnFrontierAdvance(r.onFrontierAdvance),
+		rangefeed.WithSystemTablePriority(),
+	)
+	if err != nil {
+		return err
+	}
+	r.mu.feed = feed
+	return nil
+}
+
+// onValue is called by the rangefeed for every write to system.notifications.
+func (r *Registry) onValue(ctx context.Context, kv *kvpb.RangeFeedValue) {
+	if !kv.Value.IsPresent() {
+		// Deletions of expired notifications are of no interest.
+		return
+	}
+	if !r.markDelivered(kv.Key, kv.Value.Timestamp) {
+		return
+	}
+	n, err := r.decodeRow(kv.Value)
+	if err != nil {
+		log.Warningf(ctx, "failed to decode notification: %v", err)
+		return
+	}
+	r.dispatch(ctx, n)
+}
+
+// markDelivered records that the notification written at the given key and
+// timestamp is being dispatched. It returns false if the notification was
+// already dispatched, in which case it must be ignored.
+func (r *Registry) markDelivered(key roachpb.Key, ts hlc.Timestamp) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if ts.LessEq(r.mu.frontier) {
+		return false
+	}
+	k := deliveredKey{key: string(key), ts: ts}
+	if _, ok := r.mu.delivered[k]; ok {
+		return false
+	}
+	r.mu.delivered[k] = struct{}{}
+	return true
+}
+
+// onFrontierAdvance is called by the rangefeed when its frontier advances.
+// The notifications at or below the frontier are never replayed, so they no
+// longer need to be remembered.
+func (r *Registry) onFrontierAdvance(_ context.Context, frontier hlc.Timestamp) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.mu.frontier.Less(frontier) {
+		return
+	}
+	r.mu.frontier = frontier
+	for k := range r.mu.delivered {
+		if k.ts.LessEq(frontier) {
+			delete(r.mu.delivered, k)
+		}
+	}
+}
+
+// dispatch queues the notification for the listeners of its channel.
+func (r *Registry) dispatch(ctx context.Context, n Notification) {
+	r.mu.Lock()
+	listeners := make([]*Listener, 0, len(r.mu.listeners[n.Channel]))
+	for l := range r.mu.listeners[n.Channel] {
+		listeners = append(listeners, l)
+	}
+	r.mu.Unlock()
+
+	for _, l := range listeners {
+		l.enqueue(ctx, n)
+	}
+}
+
+// decodeRow decodes the value of a row of system.notifications.
+func (r *Registry) decodeRow(value roachpb.Value) (Notification, error) {
+	bytes, err := value.GetTuple()
+	if err != nil {
+		return Notification{}, err
+	}
+	datums, err := r.decoder.Decode(&tree.DatumAlloc{}, bytes)
+	if err != nil {
+		return Notification{}, err
+	}
+	var n Notification
+	if d := datums[1]; d != tree.DNull {
+		n.Channel = string(tree.MustBeDString(d))
+	}
+	if d := datums[2]; d != tree.DNull {
+		n.Payload = string(tree.MustBeDString(d))
+	}
+	if d := datums[3]; d != tree.DNull {
+		n.SenderPID = int32(tree.MustBeDInt(d))
+	}
+	if n.Channel == "" {
+		return Notification{}, errors.AssertionFailedf("notification without channel")
+	}
+	return n, nil
+}
+
+func (r *Registry) addListener(channel string, l *Listener) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	listeners, ok := r.mu.listeners[channel]
+	if !ok {
+		listeners = make(map[*Listener]struct{})
+		r.mu.listeners[channel] = listeners
+	}
+	listeners[l] = struct{}{}
+}
+
+func (r *Registry) removeListener(channel string, l *Listener) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	listeners := r.mu.listeners[channel]
+	delete(listeners, l)
+	if len(listeners) == 0 {
+		delete(r.mu.listeners, channel)
+	}
+}
+
+// Listener receives the notifications sent on the channels a single session
+// is listening on.
+type Listener struct {
+	registry *Registry
+	wake     func()
+
+	mu struct {
+		syncutil.Mutex
+		// channels is the set of channels the session is listening on.
+		channels map[string]struct{}
+		// pending are the notifications received but not yet delivered to
+		// the client.
+		pending []Notification
+	}
+}
+
+// Listen registers the session as a listener on the given channel when the
+// given transaction commits, like Postgres does. The rangefeed is started
+// right away so that failures are reported to the caller. Listening on a
+// channel twice is a no-op.
+func (l *Listener) Listen(ctx context.Context, txn *kv.Txn, channel string) error {
+	if err := ValidateChannel(channel); err != nil {
+		return err
+	}
+	if err := l.registry.ensureFeed(ctx); err != nil {
+		return err
+	}
+	txn.AddCommitTrigger(func(context.Context) { l.listen(channel) })
+	return nil
+}
+
+// Unlisten unregisters the session as a listener on the given channel when
+// the given transaction commits.
+func (l *Listener) Unlisten(txn *kv.Txn, channel string) {
+	txn.AddCommitTrigger(func(context.Context) { l.unlisten(channel) })
+}
+
+// UnlistenAll unregisters the session as a listener on all channels when the
+// given transaction commits.
+func (l *Listener) UnlistenAll(txn *kv.Txn) {
+	txn.AddCommitTrigger(func(context.Context) { l.unlistenAll() })
+}
+
+func (l *Listener) listen(channel string) {
+	l.mu.Lock()
+	_, ok := l.mu.channels[channel]
+	l.mu.channels[channel] = struct{}{}
+	l.mu.Unlock()
+	if !ok {
+		l.registry.addListener(channel, l)
+	}
+}
+
+// unlisten unregisters the session as a listener on the given channel.
+// Unlistening from a channel the session is not listening on is a no-op.
+func (l *Listener) unlisten(channel string) {
+	l.mu.Lock()
+	_, ok := l.mu.channels[channel]
+	delete(l.mu.channels, channel)
+	l.mu.Unlock()
+	if ok {
+		l.registry.removeListener(channel, l)
+	}
+}
+
+// unlistenAll unregisters the session as a listener on all channels.
+func (l *Listener) unlistenAll() {
+	l.mu.Lock()
+	channels := l.mu.channels
+	l.mu.channels = make(map[string]struct{})
+	l.mu.Unlock()
+	for channel := range channels {
+		l.registry.removeListener(channel, l)
+	}
+}
+
+// Close unregisters the listener from all channels and discards the pending
+// notifications.
+func (l *Listener) Close() {
+	l.unlistenAll()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.mu.pending = nil
+}
+
+// Drain returns and clears the pending notifications.
+func (l *Listener) Drain() []Notification {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	pending := l.mu.pending
+	l.mu.pending = nil
+	return pending
+}
+
+func (l *Listener) enqueue(ctx context.Context, n Notification) {
+	l.mu.Lock()
+	if _, ok := l.mu.channels[n.Channel]; !ok {
+		// The session stopped listening after the notification was dispatched.
+		l.mu.Unlock()
+		return
+	}
+	if len(l.mu.pending) >= maxPendingNotifications {
+		l.mu.Unlock()
+		log.Warningf(ctx, "dropping notification on channel %q: too many pending notifications", n.Channel)
+		return
+	}
+	wake := len(l.mu.pending) == 0
+	l.mu.pending = append(l.mu.pending, n)
+	l.mu.Unlock()
+	if wake && l.wake != nil {
+		l.wake()
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgnotify
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/rangefeed"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationValidate(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	for _, tc := range []struct {
+		n   Notification
+		err string
+	}{
+		{n: Notification{Channel: "ch"}},
+		{n: Notification{Channel: "ch", Payload: strings.Repeat("x", MaxPayloadBytes-1)}},
+		{n: Notification{Channel: ""}, err: "channel name cannot be empty"},
+		{n: Notification{Channel: strings.Repeat("c", 64)}, err: "channel name too long"},
+		{n: Notification{Channel: "ch", Payload: strings.Repeat("x", MaxPayloadBytes)}, err: "payload string too long"},
+	} {
+		err := tc.n.Validate()
+		if tc.err == "" {
+			require.NoError(t, err)
+		} else {
+			require.ErrorContains(t, err, tc.err)
+		}
+	}
+}
+
+func TestListenerDelivery(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	r := newTestRegistry()
+
+	var wakes int
+	l := r.NewListener(func() { wakes++ })
+	l.listen("a")
+	l.listen("b")
+	r.dispatch(ctx, Notification{Channel: "a", Payload: "1"})
+	r.dispatch(ctx, Notification{Channel: "b", Payload: "2"})
+	r.dispatch(ctx, Notification{Channel: "c", Payload: "3"})
+	// The listener is only woken up when its queue becomes non-empty.
+	require.Equal(t, 1, wakes)
+	require.Equal(t, []Notification{
+		{Channel: "a", Payload: "1"},
+		{Channel: "b", Payload: "2"},
+	}, l.Drain())
+	require.Empty(t, l.Drain())
+
+	l.unlisten("a")
+	r.dispatch(ctx, Notification{Channel: "a", Payload: "4"})
+	require.Empty(t, l.Drain())
+	require.Equal(t, 1, wakes)
+
+	l.Close()
+	r.dispatch(ctx, Notification{Channel: "b", Payload: "5"})
+	require.Empty(t, l.Drain())
+	require.Empty(t, r.mu.listeners)
+}
+
+// newTestRegistry returns a Registry which does not run a rangefeed.
+func newTestRegistry() *Registry {
+	r := &Registry{}
+	r.mu.listeners = make(map[string]map[*Listener]struct{})
+	r.mu.delivered = make(map[deliveredKey]struct{})
+	// Pretend that the rangefeed is already running.
+	r.mu.feed = &rangefeed.RangeFeed{}
+	return r
+}
+
+func TestListenAtCommit(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	stopper := stop.NewStopper()
+	defer stopper.Stop(ctx)
+	clock := hlc.NewClockForTesting(nil)
+	factory := kv.MakeMockTxnSenderFactory(
+		func(_ context.Context, _ *roachpb.Transaction, ba *kvpb.BatchRequest,
+		) (*kvpb.BatchResponse, *kvpb.Error) {
+			return ba.CreateReply(), nil
+		})
+	db := kv.NewDB(log.MakeTestingAmbientCtxWithNewTracer(), factory, clock, stopper)
+
+	r := newTestRegistry()
+	l := r.NewListener(nil /* wake */)
+	notify := func(channel string) []Notification {
+		r.dispatch(ctx, Notification{Channel: channel})
+		return l.Drain()
+	}
+
+	// LISTEN takes effect only when the transaction commits.
+	txn := kv.NewTxn(ctx, db, 0 /* gatewayNodeID */)
+	require.NoError(t, l.Listen(ctx, txn, "a"))
+	require.Empty(t, notify("a"))
+	require.NoError(t, txn.Commit(ctx))
+	require.Len(t, notify("a"), 1)
+
+	// LISTEN in a transaction which is rolled back has no effect.
+	txn = kv.NewTxn(ctx, db, 0 /* gatewayNodeID */)
+	require.NoError(t, l.Listen(ctx, txn, "b"))
+	require.NoError(t, txn.Rollback(ctx))
+	require.Empty(t, notify("b"))
+
+	// Neither does UNLISTEN.
+	txn = kv.NewTxn(ctx, db, 0 /* gatewayNodeID */)
+	l.Unlisten(txn, "a")
+	require.Len(t, notify("a"), 1)
+	require.NoError(t, txn.Rollback(ctx))
+	require.Len(t, notify("a"), 1)
+
+	txn = kv.NewTxn(ctx, db, 0 /* gatewayNodeID */)
+	l.UnlistenAll(txn)
+	require.Len(t, notify("a"), 1)
+	require.NoError(t, txn.Commit(ctx))
+	require.Empty(t, notify("a"))
+}
+
+func TestDeliveredOnce(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	r := newTestRegistry()
+	r.mu.frontier = hlc.Timestamp{WallTime: 10}
+	ts := func(wallTime int64) hlc.Timestamp { return hlc.Timestamp{WallTime: wallTime} }
+	k1, k2 := roachpb.Key("a"), roachpb.Key("b")
+
+	// Notifications at or below the initial frontier are never delivered.
+	require.False(t, r.markDelivered(k1, ts(10)))
+	require.True(t, r.markDelivered(k1, ts(11)))
+	require.True(t, r.markDelivered(k2, ts(12)))
+	// Replays are ignored.
+	require.False(t, r.markDelivered(k1, ts(11)))
+	require.False(t, r.markDelivered(k2, ts(12)))
+
+	// Advancing the frontier forgets the notifications below it.
+	r.onFrontierAdvance(context.Background(), ts(11))
+	require.Len(t, r.mu.delivered, 1)
+	require.False(t, r.markDelivered(k1, ts(11)))
+	require.False(t, r.markDelivered(k2, ts(12)))
+	require.True(t, r.markDelivered(k1, ts(13)))
+
+	// The frontier never regresses.
+	r.onFrontierAdvance(context.Background(), ts(5))
+	require.Equal(t, ts(11), r.mu.frontier)
+}
@@ -11,7 +11,7 @@
 package lsnutil
 
 import (
-	"time"
+	"math"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
@@ -19,7 +19,18 @@ import (
 
 // HLCToLSN converts a HLC to a LSN.
 // It is in a separate package to prevent the `lsn` package importing `log`.
+//
+// The LSN is the wall time of the timestamp in nanoseconds, which preserves
+// the ordering of timestamps with different wall times. The logical component
+// is dropped, so timestamps that only differ by their logical component map to
+// the same LSN.
 func HLCToLSN(h hlc.Timestamp) lsn.LSN {
-	// TODO(#105130): correctly populate this field.
-	return lsn.LSN(h.WallTime/int64(time.Millisecond)) << 32
+	return lsn.LSN(h.WallTime)
+}
+
+// LSNToHLC converts a LSN produced by HLCToLSN back to a HLC. The returned
+// timestamp is the latest timestamp that maps to the given LSN, so that
+// everything up to and including the LSN is at or below the timestamp.
+func LSNToHLC(l lsn.LSN) hlc.Timestamp {
+	return hlc.Timestamp{WallTime: int64(l), Logical: math.MaxInt32}
 }
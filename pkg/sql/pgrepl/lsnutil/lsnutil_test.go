@@ -0,0 +1,37 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package lsnutil
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHLCToLSN(t *testing.T) {
+	a := hlc.Timestamp{WallTime: 1700000000123456789}
+	b := hlc.Timestamp{WallTime: 1700000000123456789, Logical: 3}
+	c := hlc.Timestamp{WallTime: 1700000000123456790}
+
+	// The ordering of wall times is preserved, and the logical component is
+	// dropped.
+	require.Equal(t, HLCToLSN(a), HLCToLSN(b))
+	require.Less(t, HLCToLSN(b), HLCToLSN(c))
+
+	// LSNToHLC returns a timestamp at or above every timestamp with the same
+	// LSN, and below every timestamp with a larger LSN.
+	ts := LSNToHLC(HLCToLSN(a))
+	require.True(t, a.LessEq(ts))
+	require.True(t, b.LessEq(ts))
+	require.True(t, ts.Less(c))
+	require.Equal(t, HLCToLSN(a), HLCToLSN(ts))
+}
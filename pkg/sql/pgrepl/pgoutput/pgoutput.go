@@ -0,0 +1,324 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package pgoutput implements the encoding of the messages produced by
+// pgoutput, the standard logical decoding output plugin of PostgreSQL, and of
+// the streaming replication protocol messages that carry them. See
+// https://www.postgresql.org/docs/current/protocol-logicalrep-message-formats.html
+// and https://www.postgresql.org/docs/current/protocol-replication.html.
+//
+// Only version 1 of the pgoutput protocol is implemented: transactions are
+// always sent in full once they have committed, and column values are always
+// sent in text format.
+package pgoutput
+
+import (
+	"encoding/binary"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
+)
+
+// PluginName is the name of the output plugin, as used in
+// CREATE_REPLICATION_SLOT.
+const PluginName = "pgoutput"
+
+// ProtoVersion is the version of the pgoutput protocol implemented by this
+// package.
+const ProtoVersion = 1
+
+// Logical replication message types.
+const (
+	msgBegin    byte = 'B'
+	msgCommit   byte = 'C'
+	msgRelation byte = 'R'
+	msgInsert   byte = 'I'
+	msgUpdate   byte = 'U'
+	msgDelete   byte = 'D'
+)
+
+// Streaming replication message types, which are sent inside CopyData
+// messages.
+const (
+	msgXLogData            byte = 'w'
+	msgPrimaryKeepalive    byte = 'k'
+	msgStandbyStatusUpdate byte = 'r'
+	msgHotStandbyFeedback  byte = 'h'
+)
+
+// Tuple submessage markers.
+const (
+	tupleNew byte = 'N'
+	tupleKey byte = 'K'
+	tupleOld byte = 'O'
+
+	valueNull byte = 'n'
+	valueText byte = 't'
+)
+
+// replicaIdentityDefault is the replica identity of the published relations.
+// Relations are always identified by their primary key.
+const replicaIdentityDefault byte = 'd'
+
+// columnFlagKey is the flag of a Relation column that is part of the key.
+const columnFlagKey byte = 1
+
+// pgEpoch is the epoch of the timestamps of the replication protocol.
+var pgEpoch = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Column describes a column of a published relation.
+type Column struct {
+	Name    string
+	TypeOID oid.Oid
+	TypeMod int32
+	// IsKey is true if the column is part of the key of the relation.
+	IsKey bool
+}
+
+// Relation describes a published relation. A Relation message is sent before
+// the first change of a relation, and again whenever its schema changes.
+type Relation struct {
+	ID        oid.Oid
+	Namespace string
+	Name      string
+	Columns   []Column
+}
+
+// Value is a column value of a Tuple.
+type Value struct {
+	// Null is true if the value is NULL.
+	Null bool
+	// Text is the text representation of the value.
+	Text string
+}
+
+// Tuple holds the values of the columns of a row, in the order of the columns
+// of its Relation. Columns that are not part of the key are NULL in the key
+// tuple of a Delete message.
+type Tuple []Value
+
+// AppendBegin appends a Begin message to buf.
+func AppendBegin(buf []byte, finalLSN lsn.LSN, commitTime time.Time, xid uint32) []byte {
+	buf = append(buf, msgBegin)
+	buf = binary.BigEndian.AppendUint64(buf, uint64(finalLSN))
+	buf = appendTime(buf, commitTime)
+	return binary.BigEndian.AppendUint32(buf, xid)
+}
+
+// AppendCommit appends a Commit message to buf.
+func AppendCommit(buf []byte, commitLSN, endLSN lsn.LSN, commitTime time.Time) []byte {
+	buf = append(buf, msgCommit)
+	buf = append(buf, 0 /* flags */)
+	buf = binary.BigEndian.AppendUint64(buf, uint64(commitLSN))
+	buf = binary.BigEndian.AppendUint64(buf, uint64(endLSN))
+	return appendTime(buf, commitTime)
+}
+
+// AppendRelation appends a Relation message to buf.
+func AppendRelation(buf []byte, r *Relation) []byte {
+	buf = append(buf, msgRelation)
+	buf = binary.BigEndian.AppendUint32(buf, uint32(r.ID))
+	buf = appendString(buf, r.Namespace)
+	buf = appendString(buf, r.Name)
+	buf = append(buf, replicaIdentityDefault)
+	buf = binary.BigEndian.AppendUint16(buf, uint16(len(r.Columns)))
+	for _, c := range r.Columns {
+		var flags byte
+		if c.IsKey {
+			flags |= columnFlagKey
+		}
+		buf = append(buf, flags)
+		buf = appendString(buf, c.Name)
+		buf = binary.BigEndian.AppendUint32(buf, uint32(c.TypeOID))
+		buf = binary.BigEndian.AppendUint32(buf, uint32(c.TypeMod))
+	}
+	return buf
+}
+
+// AppendInsert appends an Insert message to buf.
+func AppendInsert(buf []byte, relID oid.Oid, newTuple Tuple) []byte {
+	buf = append(buf, msgInsert)
+	buf = binary.BigEndian.AppendUint32(buf, uint32(relID))
+	buf = append(buf, tupleNew)
+	return appendTuple(buf, newTuple)
+}
+
+// AppendUpdate appends an Update message to buf. oldTuple is optional, and
+// holds the previous values of all the columns of the row.
+func AppendUpdate(buf []byte, relID oid.Oid, oldTuple, newTuple Tuple) []byte {
+	buf = append(buf, msgUpdate)
+	buf = binary.BigEndian.AppendUint32(buf, uint32(relID))
+	if oldTuple != nil {
+		buf = append(buf, tupleOld)
+		buf = appendTuple(buf, oldTuple)
+	}
+	buf = append(buf, tupleNew)
+	return appendTuple(buf, newTuple)
+}
+
+// AppendDelete appends a Delete message to buf. If isOld is true, oldTuple
+// holds the previous values of all the columns of the row; otherwise it only
+// holds the values of the key columns.
+func AppendDelete(buf []byte, relID oid.Oid, oldTuple Tuple, isOld bool) []byte {
+	buf = append(buf, msgDelete)
+	buf = binary.BigEndian.AppendUint32(buf, uint32(relID))
+	if isOld {
+		buf = append(buf, tupleOld)
+	} else {
+		buf = append(buf, tupleKey)
+	}
+	return appendTuple(buf, oldTuple)
+}
+
+// AppendXLogData appends an XLogData message carrying the given logical
+// replication message to buf.
+func AppendXLogData(buf []byte, start, end lsn.LSN, sendTime time.Time, data []byte) []byte {
+	buf = append(buf, msgXLogData)
+	buf = binary.BigEndian.AppendUint64(buf, uint64(start))
+	buf = binary.BigEndian.AppendUint64(buf, uint64(end))
+	buf = appendTime(buf, sendTime)
+	return append(buf, data...)
+}
+
+// AppendPrimaryKeepalive appends a primary keepalive message to buf.
+func AppendPrimaryKeepalive(
+	buf []byte, end lsn.LSN, sendTime time.Time, replyRequested bool,
+) []byte {
+	buf = append(buf, msgPrimaryKeepalive)
+	buf = binary.BigEndian.AppendUint64(buf, uint64(end))
+	buf = appendTime(buf, sendTime)
+	if replyRequested {
+		return append(buf, 1)
+	}
+	return append(buf, 0)
+}
+
+// StandbyStatusUpdate is a status update sent by the client of a replication
+// stream.
+type StandbyStatusUpdate struct {
+	// WrittenLSN, FlushedLSN and AppliedLSN are the positions up to which the
+	// client has received, durably stored and applied the changes.
+	WrittenLSN lsn.LSN
+	FlushedLSN lsn.LSN
+	AppliedLSN lsn.LSN
+	// ClientTime is the time at which the client sent the update.
+	ClientTime time.Time
+	// ReplyRequested is true if the client requests a keepalive in response.
+	ReplyRequested bool
+}
+
+// ParseStandbyMessage parses the contents of a CopyData message sent by the
+// client of a replication stream. ok is false if the message is a valid
+// message that carries no status update, such as a hot standby feedback
+// message.
+func ParseStandbyMessage(data []byte) (_ StandbyStatusUpdate, ok bool, _ error) {
+	if len(data) == 0 {
+		return StandbyStatusUpdate{}, false, errors.New("empty replication message")
+	}
+	switch data[0] {
+	case msgStandbyStatusUpdate:
+		const size = 1 + 8 + 8 + 8 + 8 + 1
+		if len(data) < size {
+			return StandbyStatusUpdate{}, false, errors.Newf(
+				"invalid standby status update message of length %d", len(data),
+			)
+		}
+		u := StandbyStatusUpdate{
+			WrittenLSN:     lsn.LSN(binary.BigEndian.Uint64(data[1:])),
+			FlushedLSN:     lsn.LSN(binary.BigEndian.Uint64(data[9:])),
+			AppliedLSN:     lsn.LSN(binary.BigEndian.Uint64(data[17:])),
+			ClientTime:     pgEpoch.Add(time.Duration(int64(binary.BigEndian.Uint64(data[25:]))) * time.Microsecond),
+			ReplyRequested: data[33] != 0,
+		}
+		return u, true, nil
+	case msgHotStandbyFeedback:
+		return StandbyStatusUpdate{}, false, nil
+	default:
+		return StandbyStatusUpdate{}, false, errors.Newf(
+			"unexpected replication message type %q", data[0],
+		)
+	}
+}
+
+// ParsePublicationNames parses the value of the publication_names option of
+// START_REPLICATION, which is a comma-separated list of identifiers. Unquoted
+// identifiers are folded to lower case.
+func ParsePublicationNames(s string) ([]string, error) {
+	var names []string
+	for {
+		s = strings.TrimLeft(s, " \t\n")
+		var name string
+		if strings.HasPrefix(s, `"`) {
+			var b strings.Builder
+			i := 1
+			for ; i < len(s); i++ {
+				if s[i] == '"' {
+					if i+1 < len(s) && s[i+1] == '"' {
+						b.WriteByte('"')
+						i++
+						continue
+					}
+					break
+				}
+				b.WriteByte(s[i])
+			}
+			if i >= len(s) {
+				return nil, errors.Newf("unterminated quoted identifier in publication_names")
+			}
+			name = b.String()
+			s = s[i+1:]
+		} else {
+			i := strings.IndexByte(s, ',')
+			if i < 0 {
+				i = len(s)
+			}
+			name = strings.ToLower(strings.TrimRight(s[:i], " \t\n"))
+			s = s[i:]
+		}
+		if name == "" {
+			return nil, errors.Newf("invalid publication_names syntax")
+		}
+		names = append(names, name)
+		s = strings.TrimLeft(s, " \t\n")
+		if s == "" {
+			return names, nil
+		}
+		if s[0] != ',' {
+			return nil, errors.Newf("invalid publication_names syntax")
+		}
+		s = s[1:]
+	}
+}
+
+func appendTuple(buf []byte, t Tuple) []byte {
+	buf = binary.BigEndian.AppendUint16(buf, uint16(len(t)))
+	for _, v := range t {
+		if v.Null {
+			buf = append(buf, valueNull)
+			continue
+		}
+		buf = append(buf, valueText)
+		buf = binary.BigEndian.AppendUint32(buf, uint32(len(v.Text)))
+		buf = append(buf, v.Text...)
+	}
+	return buf
+}
+
+func appendString(buf []byte, s string) []byte {
+	buf = append(buf, s...)
+	return append(buf, 0)
+}
+
+func appendTime(buf []byte, t time.Time) []byte {
+	return binary.BigEndian.AppendUint64(buf, uint64(t.Sub(pgEpoch).Microseconds()))
+}
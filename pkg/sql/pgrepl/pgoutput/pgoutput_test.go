@@ -0,0 +1,149 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgoutput
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/lib/pq/oid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessages(t *testing.T) {
+	ts := pgEpoch.Add(time.Second)
+	rel := &Relation{
+		ID:        104,
+		Namespace: "public",
+		Name:      "t",
+		Columns: []Column{
+			{Name: "k", TypeOID: oid.T_int8, TypeMod: -1, IsKey: true},
+			{Name: "v", TypeOID: oid.T_text, TypeMod: -1},
+		},
+	}
+	row := Tuple{{Text: "1"}, {Null: true}}
+	key := Tuple{{Text: "1"}, {Null: true}}
+
+	for _, tc := range []struct {
+		name     string
+		msg      []byte
+		expected string
+	}{
+		{
+			name:     "begin",
+			msg:      AppendBegin(nil, 0x10, ts, 7),
+			expected: "42" + "0000000000000010" + "00000000000f4240" + "00000007",
+		},
+		{
+			name:     "commit",
+			msg:      AppendCommit(nil, 0x10, 0x11, ts),
+			expected: "43" + "00" + "0000000000000010" + "0000000000000011" + "00000000000f4240",
+		},
+		{
+			name: "relation",
+			msg:  AppendRelation(nil, rel),
+			expected: "52" + "00000068" + "7075626c696300" + "7400" + "64" + "0002" +
+				"01" + "6b00" + "00000014" + "ffffffff" +
+				"00" + "7600" + "00000019" + "ffffffff",
+		},
+		{
+			name:     "insert",
+			msg:      AppendInsert(nil, 104, row),
+			expected: "49" + "00000068" + "4e" + "0002" + "74" + "00000001" + "31" + "6e",
+		},
+		{
+			name:     "update",
+			msg:      AppendUpdate(nil, 104, nil, row),
+			expected: "55" + "00000068" + "4e" + "0002" + "74" + "00000001" + "31" + "6e",
+		},
+		{
+			name: "update with old tuple",
+			msg:  AppendUpdate(nil, 104, row, row),
+			expected: "55" + "00000068" + "4f" + "0002" + "74" + "00000001" + "31" + "6e" +
+				"4e" + "0002" + "74" + "00000001" + "31" + "6e",
+		},
+		{
+			name:     "delete",
+			msg:      AppendDelete(nil, 104, key, false /* isOld */),
+			expected: "44" + "00000068" + "4b" + "0002" + "74" + "00000001" + "31" + "6e",
+		},
+		{
+			name:     "xlogdata",
+			msg:      AppendXLogData(nil, 0x10, 0x20, ts, []byte{0xaa}),
+			expected: "77" + "0000000000000010" + "0000000000000020" + "00000000000f4240" + "aa",
+		},
+		{
+			name:     "keepalive",
+			msg:      AppendPrimaryKeepalive(nil, 0x20, ts, true /* replyRequested */),
+			expected: "6b" + "0000000000000020" + "00000000000f4240" + "01",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, hex.EncodeToString(tc.msg))
+		})
+	}
+}
+
+func TestParseStandbyMessage(t *testing.T) {
+	msg, err := hex.DecodeString(
+		"72" + "0000000000000010" + "0000000000000020" + "0000000000000030" + "00000000000f4240" + "01",
+	)
+	require.NoError(t, err)
+	u, ok, err := ParseStandbyMessage(msg)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, StandbyStatusUpdate{
+		WrittenLSN:     lsn.LSN(0x10),
+		FlushedLSN:     lsn.LSN(0x20),
+		AppliedLSN:     lsn.LSN(0x30),
+		ClientTime:     pgEpoch.Add(time.Second),
+		ReplyRequested: true,
+	}, u)
+
+	_, ok, err = ParseStandbyMessage([]byte{'h'})
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	_, _, err = ParseStandbyMessage(msg[:10])
+	require.Error(t, err)
+
+	_, _, err = ParseStandbyMessage([]byte{'x'})
+	require.Error(t, err)
+}
+
+func TestParsePublicationNames(t *testing.T) {
+	for _, tc := range []struct {
+		input    string
+		expected []string
+		err      bool
+	}{
+		{input: "pub", expected: []string{"pub"}},
+		{input: "Pub1, pub2", expected: []string{"pub1", "pub2"}},
+		{input: `"My Pub",other`, expected: []string{"My Pub", "other"}},
+		{input: `"a""b"`, expected: []string{`a"b`}},
+		{input: "", err: true},
+		{input: "a,,b", err: true},
+		{input: `"a`, err: true},
+		{input: `"a" b`, err: true},
+	} {
+		t.Run(tc.input, func(t *testing.T) {
+			names, err := ParsePublicationNames(tc.input)
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, names)
+		})
+	}
+}
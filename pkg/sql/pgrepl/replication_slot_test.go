@@ -0,0 +1,67 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgrepl_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+)
+
+// TestReplicationSlotProtectedTimestamp checks that a replication slot
+// protects the data of its database from garbage collection for as long as
+// the slot exists.
+func TestReplicationSlotProtectedTimestamp(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	srv, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer srv.Stopper().Stop(ctx)
+	s := srv.ApplicationLayer()
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, `CREATE TABLE t (k INT PRIMARY KEY)`)
+
+	pgURL, cleanup := s.PGUrl(t, serverutils.CertsDirPrefix("pgrepl_slot_test"), serverutils.User(username.RootUser))
+	defer cleanup()
+	q := pgURL.Query()
+	q.Set("replication", "database")
+	pgURL.RawQuery = q.Encode()
+	conn, err := pgconn.Connect(ctx, pgURL.String())
+	require.NoError(t, err)
+	defer func() { _ = conn.Close(ctx) }()
+
+	const countRecords = `
+SELECT count(*) FROM system.replication_slots AS s
+JOIN system.protected_ts_records AS r ON r.id = s.pts_record_id
+WHERE s.slot_name = 'test_slot' AND r.meta_type = 'replication_slots'
+AND r.meta = 'test_slot'::BYTES AND floor(r.ts)::INT8 = s.confirmed_flush_lsn`
+
+	_, err = conn.Exec(ctx, `CREATE_REPLICATION_SLOT test_slot LOGICAL pgoutput`).ReadAll()
+	require.NoError(t, err)
+	sqlDB.CheckQueryResults(t, countRecords, [][]string{{"1"}})
+
+	_, err = conn.Exec(ctx, `DROP_REPLICATION_SLOT test_slot`).ReadAll()
+	require.NoError(t, err)
+	sqlDB.CheckQueryResults(t,
+		`SELECT count(*) FROM system.protected_ts_records WHERE meta_type = 'replication_slots'`,
+		[][]string{{"0"}},
+	)
+}
@@ -0,0 +1,65 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package slotprotectedts
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/protectedts/ptpb"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/protectedts/ptreconcile"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/cockroachdb/errors"
+)
+
+// SlotMetaType is the meta type for protected timestamp records associated
+// with logical replication slots.
+const SlotMetaType = "replication_slots"
+
+// MakeRecord makes a protected timestamp record to protect the data of the
+// given database after a timestamp on behalf of a replication slot, so that
+// the changes which the consumer of the slot has not acknowledged yet can
+// still be streamed.
+func MakeRecord(
+	recordID uuid.UUID, slotName string, databaseID descpb.ID, tsToProtect hlc.Timestamp,
+) *ptpb.Record {
+	return &ptpb.Record{
+		ID:        recordID.GetBytesMut(),
+		Timestamp: tsToProtect,
+		Mode:      ptpb.PROTECT_AFTER,
+		MetaType:  SlotMetaType,
+		Meta:      []byte(slotName),
+		Target:    ptpb.MakeSchemaObjectsTarget(descpb.IDs{databaseID}),
+	}
+}
+
+// MakeStatusFunc returns a function which determines whether the replication
+// slot implied with this value of meta was dropped, in which case its record
+// should be removed by the reconciler.
+func MakeStatusFunc() ptreconcile.StatusFunc {
+	return func(ctx context.Context, txn isql.Txn, meta []byte) (shouldRemove bool, _ error) {
+		row, err := txn.QueryRowEx(ctx, "check-for-dropped-replication-slot", txn.KV(),
+			sessiondata.NodeUserSessionDataOverride,
+			`SELECT EXISTS (SELECT 1 FROM system.replication_slots WHERE slot_name = $1)`, string(meta))
+		if err != nil {
+			return false, err
+		}
+		if row == nil {
+			return false, errors.AssertionFailedf("no row returned when checking for a dropped replication slot")
+		}
+		slotIsDropped := bool(!tree.MustBeDBool(row[0]))
+		return slotIsDropped, nil
+	}
+}
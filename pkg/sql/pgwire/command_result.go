@@ -413,6 +413,26 @@ func (r *commandResult) SendCopyDone(ctx context.Context) error {
 	return r.conn.bufferCopyDone()
 }
 
+// AppendCopyBinaryRow is part of the sql.CopyOutResult interface.
+func (r *commandResult) AppendCopyBinaryRow(
+	ctx context.Context, buf []byte, row tree.Datums, cols colinfo.ResultColumns, loc *time.Location,
+) ([]byte, error) {
+	r.assertNotReleased()
+	// The message builder is only used between initMsg and finishMsg, so it is
+	// free to be borrowed for encoding the row.
+	b := &r.conn.msgBuilder
+	b.reset()
+	defer b.reset()
+	b.putInt16(int16(len(row)))
+	for i, d := range row {
+		b.writeBinaryDatum(ctx, d, loc, cols[i].Typ)
+	}
+	if b.err != nil {
+		return nil, b.err
+	}
+	return append(buf, b.wrapped.Bytes()...), nil
+}
+
 // SetRowsAffected is part of the sql.RestrictedCommandResult interface.
 func (r *commandResult) SetRowsAffected(ctx context.Context, n int) {
 	r.assertNotReleased()
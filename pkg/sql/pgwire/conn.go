@@ -30,6 +30,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/clusterunique"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser/statements"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgnotify"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgreplparser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgrepltree"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
@@ -360,8 +361,24 @@ func (c *conn) handleSimpleQuery(
 			log.SqlExec.Infof(ctx, "could not parse simple query in replication protocol: %s", query)
 			return c.stmtBuf.Push(ctx, sql.SendError{Err: err})
 		}
-		switch stmt.AST.(type) {
-		case *pgrepltree.IdentifySystem:
+		switch ast := stmt.AST.(type) {
+		case *pgrepltree.IdentifySystem, *pgrepltree.CreateReplicationSlot, *pgrepltree.DropReplicationSlot:
+		case *pgrepltree.StartReplication:
+			// START_REPLICATION takes control of the connection, like COPY. We
+			// block this network routine until control is passed back.
+			var wg sync.WaitGroup
+			wg.Add(1)
+			if err := c.stmtBuf.Push(ctx, sql.StartReplication{
+				ParsedStmt:      stmt,
+				Stmt:            ast,
+				Conn:            c,
+				ReplicationDone: &wg,
+				TimeReceived:    timeReceived,
+			}); err != nil {
+				return err
+			}
+			wg.Wait()
+			return nil
 		default:
 			log.SqlExec.Infof(ctx, "unhandled replication protocol query: %s", query)
 			return c.stmtBuf.Push(ctx, sql.SendError{
@@ -811,6 +828,29 @@ func (c *conn) BeginCopyIn(
 	return c.msgBuilder.finishMsg(c.conn)
 }
 
+// BeginCopyBoth is part of the pgwirebase.Conn interface.
+func (c *conn) BeginCopyBoth(ctx context.Context) error {
+	c.msgBuilder.initMsg(pgwirebase.ServerMsgCopyBothResponse)
+	c.msgBuilder.writeByte(byte(pgwirebase.FormatText))
+	c.msgBuilder.putInt16(0 /* number of columns */)
+	return c.msgBuilder.finishMsg(c.conn)
+}
+
+// SendCopyData is part of the pgwirebase.Conn interface.
+func (c *conn) SendCopyData(ctx context.Context, data []byte) error {
+	c.msgBuilder.initMsg(pgwirebase.ServerMsgCopyDataCommand)
+	if _, err := c.msgBuilder.Write(data); err != nil {
+		return err
+	}
+	return c.msgBuilder.finishMsg(c.conn)
+}
+
+// SendCopyDone is part of the pgwirebase.Conn interface.
+func (c *conn) SendCopyDone(ctx context.Context) error {
+	c.msgBuilder.initMsg(pgwirebase.ServerMsgCopyDoneCommand)
+	return c.msgBuilder.finishMsg(c.conn)
+}
+
 // Rd is part of the pgwirebase.Conn interface.
 func (c *conn) Rd() pgwirebase.BufferedReader {
 	return &pgwireReader{conn: c}
@@ -868,7 +908,7 @@ func cookTag(
 			tag = strconv.AppendUint(tag, uint64(rowsAffected), 10)
 		}
 
-	case tree.Ack, tree.DDL:
+	case tree.Ack, tree.DDL, tree.Replication:
 		if tagStr == "SELECT" {
 			tag = append(tag, ' ')
 			tag = strconv.AppendInt(tag, int64(rowsAffected), 10)
@@ -1372,6 +1412,15 @@ func (c *conn) CreateDrainResult(pos sql.CmdPos) sql.DrainResult {
 	return c.newMiscResult(pos, noCompletionMsg)
 }
 
+// BufferNotification is part of the sql.ClientComm interface.
+func (c *conn) BufferNotification(n pgnotify.Notification) error {
+	c.msgBuilder.initMsg(pgwirebase.ServerMsgNotificationResponse)
+	c.msgBuilder.putInt32(n.SenderPID)
+	c.msgBuilder.writeTerminatedString(n.Channel)
+	c.msgBuilder.writeTerminatedString(n.Payload)
+	return c.msgBuilder.finishMsg(&c.writerState.buf)
+}
+
 // CreateBindResult is part of the sql.ClientComm interface.
 func (c *conn) CreateBindResult(pos sql.CmdPos) sql.BindResult {
 	return c.newMiscResult(pos, bindComplete)
@@ -1420,6 +1469,16 @@ func (c *conn) CreateCopyOutResult(cmd sql.CopyOut, pos sql.CmdPos) sql.CopyOutR
 	return res
 }
 
+// CreateStartReplicationResult is part of the sql.ClientComm interface.
+func (c *conn) CreateStartReplicationResult(
+	cmd sql.StartReplication, pos sql.CmdPos,
+) sql.StartReplicationResult {
+	res := c.newMiscResult(pos, commandComplete)
+	res.stmtType = cmd.Stmt.StatementReturnType()
+	res.cmdCompleteTag = cmd.Stmt.StatementTag()
+	return res
+}
+
 // pgwireReader is an io.Reader that wraps a conn, maintaining its metrics as
 // it is consumed.
 type pgwireReader struct {
@@ -17,7 +17,8 @@ import (
 )
 
 // Conn exposes some functionality of a pgwire network connection to be
-// used by the Copy subprotocol implemented in the sql package.
+// used by the Copy subprotocol and by the streaming replication protocol
+// implemented in the sql package.
 type Conn interface {
 	// Rd returns a reader to be used to consume bytes from the connection.
 	// This reader can be used with a pgwirebase.ReadBuffer for reading messages.
@@ -30,4 +31,17 @@ type Conn interface {
 	// subprotocol (COPY ... FROM STDIN). This message informs the client about
 	// the columns that are expected for the rows to be inserted.
 	BeginCopyIn(ctx context.Context, columns []colinfo.ResultColumn, format FormatCode) error
+
+	// BeginCopyBoth sends the server message initiating the Copy-both
+	// subprotocol, which carries the messages of a replication stream (see
+	// START_REPLICATION).
+	BeginCopyBoth(ctx context.Context) error
+
+	// SendCopyData sends a CopyData message with the given contents to the
+	// client, without buffering it.
+	SendCopyData(ctx context.Context, data []byte) error
+
+	// SendCopyDone sends a CopyDone message to the client, without buffering
+	// it.
+	SendCopyDone(ctx context.Context) error
 }
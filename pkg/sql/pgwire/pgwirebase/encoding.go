@@ -35,7 +35,6 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/bitarray"
 	"github.com/cockroachdb/cockroach/pkg/util/duration"
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
-	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/ipaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
@@ -296,21 +295,28 @@ func NewInvalidBinaryRepresentationErrorf(format string, args ...interface{}) er
 	return pgerror.Newf(pgcode.InvalidBinaryRepresentation, format, args...)
 }
 
-// validateArrayDimensions takes the number of dimensions and elements and
+// validateArrayDimensions takes the number of dimensions and the length of
+// each of them, along with the number of bytes remaining to be read, and
 // returns an error if we don't support that combination.
-func validateArrayDimensions(nDimensions int, nElements int) error {
-	switch nDimensions {
-	case 1:
-		break
-	case 0:
-		// 0-dimensional array means 0-length array: validate that.
-		if nElements == 0 {
-			break
+func validateArrayDimensions(nDimensions int, dimSizes []int32, remaining int) error {
+	if nDimensions < 0 {
+		return NewInvalidBinaryRepresentationErrorf("invalid number of dimensions: %d", nDimensions)
+	}
+	if nDimensions > tree.MaxArrayDimensions {
+		return pgerror.Newf(pgcode.ProgramLimitExceeded,
+			"number of array dimensions (%d) exceeds the maximum allowed (%d)",
+			nDimensions, tree.MaxArrayDimensions)
+	}
+	nElements := 1
+	for _, size := range dimSizes {
+		if size < 0 {
+			return NewInvalidBinaryRepresentationErrorf("invalid array dimension: %d", size)
+		}
+		nElements *= int(size)
+		// Each element takes at least 4 bytes for its length.
+		if nElements > remaining/4 {
+			return NewInvalidBinaryRepresentationErrorf("insufficient data left in message")
 		}
-		fallthrough
-	default:
-		return unimplemented.NewWithIssuef(32552,
-			"%d-dimension arrays not supported; only 1-dimension", nDimensions)
 	}
 	return nil
 }
@@ -486,6 +492,15 @@ func DecodeDatum(
 			return &tree.DPGVector{T: ret}, nil
 		}
 		switch typ.Family() {
+		case types.RangeFamily:
+			if err := validateStringBytes(b); err != nil {
+				return nil, err
+			}
+			d, _, err := tree.ParseDRangeFromString(evalCtx, string(b), typ)
+			if err != nil {
+				return nil, err
+			}
+			return d, nil
 		case types.ArrayFamily, types.TupleFamily:
 			// Arrays and tuples come in in their string form, so we parse them
 			// as such and later convert them to their actual datum form.
@@ -816,6 +831,9 @@ func DecodeDatum(
 			if typ.Family() == types.TupleFamily {
 				return decodeBinaryTuple(ctx, evalCtx, b, da)
 			}
+			if typ.Family() == types.RangeFamily {
+				return decodeBinaryRange(ctx, evalCtx, typ, b, da)
+			}
 			if typ.Family() == types.OidFamily {
 				if len(b) < 4 {
 					return nil, pgerror.Newf(pgcode.ProtocolViolation, "oid requires 4 bytes for binary format")
@@ -985,52 +1003,63 @@ func decodeBinaryArray(
 		_       int32
 		ElemOid int32
 	}
-	var dim struct {
-		// The next two fields should be arrays of size Ndims. However, since
-		// we only support 1-dimensional arrays for now, for convenience we can
-		// leave them in this struct as such for `binary.Read` to parse for us.
-		DimSize int32
-		// Dim lower bound
-		_ int32
-	}
 	r := bytes.NewBuffer(b)
 	if err := binary.Read(r, binary.BigEndian, &hdr); err != nil {
 		return nil, err
 	}
-	if t.Oid() != oid.Oid(hdr.ElemOid) {
+	// The elements of multi-dimensional arrays are given in row-major order,
+	// and have the type of the innermost dimension.
+	elemTyp := tree.ArrayScalarType(t)
+	if elemTyp.Oid() != oid.Oid(hdr.ElemOid) {
 		return nil, pgerror.Newf(pgcode.ProtocolViolation, "wrong element type")
 	}
-	arr := tree.NewDArray(t)
 	if hdr.Ndims == 0 {
-		return arr, nil
+		return tree.NewDArray(t), nil
 	}
-	if err := binary.Read(r, binary.BigEndian, &dim); err != nil {
-		return nil, err
+	if hdr.Ndims < 0 || hdr.Ndims > tree.MaxArrayDimensions {
+		return nil, validateArrayDimensions(int(hdr.Ndims), nil /* dimSizes */, r.Len())
+	}
+	var dim struct {
+		DimSize int32
+		// Dim lower bound
+		LowerBound int32
 	}
-	if err := validateArrayDimensions(int(hdr.Ndims), int(dim.DimSize)); err != nil {
+	dimSizes := make([]int32, hdr.Ndims)
+	lowerBounds := make([]int, hdr.Ndims)
+	for i := range dimSizes {
+		if err := binary.Read(r, binary.BigEndian, &dim); err != nil {
+			return nil, err
+		}
+		dimSizes[i] = dim.DimSize
+		lowerBounds[i] = int(dim.LowerBound)
+	}
+	if err := validateArrayDimensions(int(hdr.Ndims), dimSizes, r.Len()); err != nil {
 		return nil, err
 	}
+	nElements := 1
+	sizes := make([]int, hdr.Ndims)
+	for i := range sizes {
+		sizes[i] = int(dimSizes[i])
+		nElements *= sizes[i]
+	}
+	elems := make(tree.Datums, 0, nElements)
 	var vlen int32
-	for i := int32(0); i < dim.DimSize; i++ {
+	for i := 0; i < nElements; i++ {
 		if err := binary.Read(r, binary.BigEndian, &vlen); err != nil {
 			return nil, err
 		}
 		if vlen < 0 {
-			if err := arr.Append(tree.DNull); err != nil {
-				return nil, err
-			}
+			elems = append(elems, tree.DNull)
 			continue
 		}
 		buf := r.Next(int(vlen))
-		elem, err := DecodeDatum(ctx, evalCtx, t, code, buf, da)
+		elem, err := DecodeDatum(ctx, evalCtx, elemTyp, code, buf, da)
 		if err != nil {
 			return nil, err
 		}
-		if err := arr.Append(elem); err != nil {
-			return nil, err
-		}
+		elems = append(elems, elem)
 	}
-	return arr, nil
+	return tree.NewMultiDimDArray(t, sizes, lowerBounds, elems)
 }
 
 const tupleHeaderSize, oidSize, elementSize = 4, 4, 4
@@ -1124,6 +1153,82 @@ func decodeBinaryTuple(
 
 }
 
+// The flags of the binary format of a range, which match those used by
+// Postgres.
+const (
+	RangeEmpty    byte = 0x01
+	RangeLowerInc byte = 0x02
+	RangeUpperInc byte = 0x04
+	RangeLowerInf byte = 0x08
+	RangeUpperInf byte = 0x10
+)
+
+// MakeRangeFlags returns the flags of the binary format of the given range.
+func MakeRangeFlags(r *tree.DRange) byte {
+	if r.Empty {
+		return RangeEmpty
+	}
+	var flags byte
+	if r.Lower == nil {
+		flags |= RangeLowerInf
+	} else if r.LowerInc {
+		flags |= RangeLowerInc
+	}
+	if r.Upper == nil {
+		flags |= RangeUpperInf
+	} else if r.UpperInc {
+		flags |= RangeUpperInc
+	}
+	return flags
+}
+
+// decodeBinaryRange decodes the binary format of a range, which is a flags
+// byte followed by the length-prefixed binary format of each finite bound.
+func decodeBinaryRange(
+	ctx context.Context, evalCtx *eval.Context, typ *types.T, b []byte, da *tree.DatumAlloc,
+) (tree.Datum, error) {
+	if len(b) < 1 {
+		return nil, pgerror.New(pgcode.Syntax, "range requires a flags byte for binary format")
+	}
+	flags := b[0]
+	b = b[1:]
+	if flags&RangeEmpty != 0 {
+		return tree.NewDEmptyRange(typ), nil
+	}
+	readBound := func(inf byte) (tree.Datum, error) {
+		if flags&inf != 0 {
+			return tree.DNull, nil
+		}
+		if len(b) < elementSize {
+			return nil, pgerror.New(pgcode.Syntax, "insufficient bytes reading range bound size for binary format")
+		}
+		n := int32(binary.BigEndian.Uint32(b))
+		b = b[elementSize:]
+		if n < 0 || int(n) > len(b) {
+			return nil, pgerror.Newf(pgcode.Syntax, "invalid range bound size %d for binary format", n)
+		}
+		d, err := DecodeDatum(ctx, evalCtx, typ.RangeContents(), FormatBinary, b[:n], da)
+		b = b[n:]
+		return d, err
+	}
+	lower, err := readBound(RangeLowerInf)
+	if err != nil {
+		return nil, err
+	}
+	upper, err := readBound(RangeUpperInf)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != 0 {
+		return nil, pgerror.New(pgcode.Syntax, "trailing bytes in range for binary format")
+	}
+	r, err := tree.NewDRange(typ, lower, upper, flags&RangeLowerInc != 0, flags&RangeUpperInc != 0)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
 var invalidUTF8Error = pgerror.Newf(pgcode.CharacterNotInRepertoire, "invalid UTF-8 sequence")
 
 var (
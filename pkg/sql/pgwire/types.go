@@ -30,7 +30,6 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/duration"
-	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/ipaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
@@ -58,6 +57,10 @@ type pgType struct {
 }
 
 func pgTypeForParserType(t *types.T) pgType {
+	// Like Postgres, describe values of domain types with their base type.
+	if base := t.DomainBaseType(); base != nil {
+		t = base
+	}
 	size := tree.PGWireTypeSize(t)
 	tOid := t.Oid()
 	if tOid == oid.T_text && t.Width() > 0 {
@@ -154,6 +157,9 @@ func writeTextDatumNotNull(
 	sessionLoc *time.Location,
 	t *types.T,
 ) {
+	if t != nil && t.IsDomain() {
+		t = t.DomainBaseType()
+	}
 
 	oldDCC := b.textFormatter.SetDataConversionConfig(conv)
 	oldLoc := b.textFormatter.SetLocation(sessionLoc)
@@ -258,6 +264,10 @@ func writeTextDatumNotNull(
 		b.textFormatter.FormatNode(v)
 		b.writeFromFmtCtx(b.textFormatter)
 
+	case *tree.DRange:
+		b.textFormatter.FormatNode(v)
+		b.writeFromFmtCtx(b.textFormatter)
+
 	case *tree.DPGVector:
 		b.textFormatter.FormatNode(v)
 		b.writeFromFmtCtx(b.textFormatter)
@@ -312,6 +322,9 @@ func (b *writeBuffer) writeTextColumnarElement(
 		b.textFormatter.SetLocation(oldLoc)
 	}()
 	typ := vecs.Vecs[vecIdx].Type()
+	if base := typ.DomainBaseType(); base != nil {
+		typ = base
+	}
 	if log.V(2) {
 		log.Infof(ctx, "pgwire writing TEXT columnar element of type: %s", typ)
 	}
@@ -593,6 +606,9 @@ func (b *writeBuffer) writeBinaryDatum(
 func writeBinaryDatumNotNull(
 	ctx context.Context, b *writeBuffer, d tree.Datum, sessionLoc *time.Location, t *types.T,
 ) {
+	if t != nil && t.IsDomain() {
+		t = t.DomainBaseType()
+	}
 	switch v := tree.UnwrapDOidWrapper(d).(type) {
 	case *tree.DBitArray:
 		words, lastBitsUsed := v.EncodingParts()
@@ -731,6 +747,26 @@ func writeBinaryDatumNotNull(
 		lengthToWrite := b.Len() - (initialLen + 4)
 		b.putInt32AtIndex(initialLen /* index to write at */, int32(lengthToWrite))
 
+	case *tree.DRange:
+		initialLen := b.Len()
+
+		// Reserve bytes for writing length later.
+		b.putInt32(int32(0))
+
+		// Put the flags, followed by the finite bounds.
+		flags := pgwirebase.MakeRangeFlags(v)
+		b.writeByte(flags)
+		elemTyp := v.ResolvedType().RangeContents()
+		if flags&(pgwirebase.RangeEmpty|pgwirebase.RangeLowerInf) == 0 {
+			b.writeBinaryDatum(ctx, v.Lower, sessionLoc, elemTyp)
+		}
+		if flags&(pgwirebase.RangeEmpty|pgwirebase.RangeUpperInf) == 0 {
+			b.writeBinaryDatum(ctx, v.Upper, sessionLoc, elemTyp)
+		}
+
+		lengthToWrite := b.Len() - (initialLen + 4)
+		b.putInt32AtIndex(initialLen /* index to write at */, int32(lengthToWrite))
+
 	case *tree.DVoid:
 		b.putInt32(0)
 
@@ -786,37 +822,33 @@ func writeBinaryDatumNotNull(
 		}
 
 	case *tree.DArray:
-		if v.ParamTyp.Family() == types.ArrayFamily {
-			b.setError(unimplemented.NewWithIssueDetail(32552,
-				"binenc", "unsupported binary serialization of multidimensional arrays"))
-			return
-		}
-
 		initialLen := b.Len()
 
 		// Reserve bytes for writing length later.
 		b.putInt32(int32(0))
 
-		// Put the number of dimensions. We currently support 1d arrays only.
-		var ndims int32 = 1
-		if v.Len() == 0 {
-			ndims = 0
-		}
-		b.putInt32(ndims)
+		// Multi-dimensional arrays are written with the length and lower bound
+		// of each dimension, followed by their scalar elements in row-major
+		// order.
+		dims, lowerBounds := v.Dimensions(), v.LowerBounds()
+		elems := v.ScalarElements()
+		b.putInt32(int32(len(dims)))
 		hasNulls := 0
-		if v.HasNulls {
-			hasNulls = 1
+		for _, elem := range elems {
+			if elem == tree.DNull {
+				hasNulls = 1
+				break
+			}
 		}
-		oid := v.ParamTyp.Oid()
+		elemTyp := v.ScalarType()
 		b.putInt32(int32(hasNulls))
-		b.putInt32(int32(oid))
-		if v.Len() > 0 {
-			b.putInt32(int32(v.Len()))
-			// Lower bound, we only support a lower bound of 1.
-			b.putInt32(1)
-			for _, elem := range v.Array {
-				b.writeBinaryDatum(ctx, elem, sessionLoc, v.ParamTyp)
-			}
+		b.putInt32(int32(elemTyp.Oid()))
+		for i := range dims {
+			b.putInt32(int32(dims[i]))
+			b.putInt32(int32(lowerBounds[i]))
+		}
+		for _, elem := range elems {
+			b.writeBinaryDatum(ctx, elem, sessionLoc, elemTyp)
 		}
 
 		lengthToWrite := b.Len() - (initialLen + 4)
@@ -840,6 +872,9 @@ func (b *writeBuffer) writeBinaryColumnarElement(
 	ctx context.Context, vecs *coldata.TypedVecs, vecIdx int, rowIdx int, sessionLoc *time.Location,
 ) {
 	typ := vecs.Vecs[vecIdx].Type()
+	if base := typ.DomainBaseType(); base != nil {
+		typ = base
+	}
 	if log.V(2) {
 		log.Infof(ctx, "pgwire writing BINARY columnar element of type: %s", typ)
 	}
@@ -58,6 +58,8 @@ func getPlanColumns(plan planNode, mut bool) colinfo.ResultColumns {
 		return n.columns
 	case *scanNode:
 		return n.resultColumns
+	case *foreignScanNode:
+		return n.resultColumns
 	case *unionNode:
 		return n.columns
 	case *valuesNode:
@@ -178,6 +180,8 @@ func getPlanColumns(plan planNode, mut bool) colinfo.ResultColumns {
 
 	case *identifySystemNode:
 		return n.getColumns(mut, colinfo.IdentifySystemColumns)
+	case *createReplicationSlotNode:
+		return n.getColumns(mut, colinfo.CreateReplicationSlotColumns)
 	}
 
 	// Every other node has no columns in their results.
@@ -25,6 +25,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
 	"github.com/cockroachdb/cockroach/pkg/spanconfig"
+	"github.com/cockroachdb/cockroach/pkg/sql/advisorylock"
 	"github.com/cockroachdb/cockroach/pkg/sql/auditlogging"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catsessiondata"
@@ -39,6 +40,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/exprutil"
 	"github.com/cockroachdb/cockroach/pkg/sql/idxusage"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgnotify"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
@@ -104,6 +106,9 @@ type extendedEvalContext struct {
 	// jobs refers to jobs in extraTxnState.
 	jobs *txnJobsCollection
 
+	// deferredConstraints refers to deferredConstraints in extraTxnState.
+	deferredConstraints *deferredConstraints
+
 	statsProvider *persistedsqlstats.PersistedSQLStats
 
 	indexUsageStats *idxusage.LocalIndexUsageStats
@@ -237,6 +242,14 @@ type planner struct {
 
 	sqlCursors sqlCursors
 
+	// notificationListener returns the listener used by LISTEN and UNLISTEN.
+	// It is nil, or returns nil, if the session cannot receive notifications.
+	notificationListener func() *pgnotify.Listener
+
+	// advisoryLocks returns the advisory locks of the session. It is nil if the
+	// planner does not belong to a session.
+	advisoryLocks func() *advisorylock.SessionLocks
+
 	storedProcTxnState storedProcTxnStateAccessor
 
 	createdSequences createdSequences
@@ -13,6 +13,7 @@ package parser
 import (
 	"strings"
 
+	"github.com/cockroachdb/cockroach/pkg/build"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
@@ -374,6 +375,124 @@ func (l *lexer) MakeFetchOrMoveStmt(isMove bool) (plpgsqltree.Statement, error)
 	}, nil
 }
 
+// MakeForLoopControl reads the control clause of a FOR loop, which follows the
+// IN keyword and ends just before the LOOP keyword. The result is a ForInt for
+// an integer FOR loop, or a ForSelect for a loop over the rows of a query. If
+// the clause describes a kind of FOR loop that is not supported, the name of
+// the unimplemented feature is returned instead.
+func (l *lexer) MakeForLoopControl(
+	target []plpgsqltree.Variable,
+) (stmt plpgsqltree.Statement, unimplementedFeature string, err error) {
+	if l.parser.Lookahead() != -1 {
+		// Push back the lookahead token so that it can be included.
+		l.PushBack(1)
+	}
+	var reverse bool
+	if l.Peek().id == REVERSE {
+		reverse = true
+		l.lastPos++
+	}
+	if l.Peek().id == EXECUTE {
+		l.lastPos++
+		return nil, "for loop over dynamic query", nil
+	}
+	startPos, endPos, terminator, err := l.readSQLConstruct(
+		true /* isExpr */, false /* allowEmpty */, DOT_DOT, LOOP,
+	)
+	if err != nil {
+		return nil, "", err
+	}
+	if terminator == DOT_DOT {
+		// This is an integer FOR loop.
+		if len(target) != 1 {
+			return nil, "", errors.New("integer FOR loop must have only one target variable")
+		}
+		lower, err := l.ParseExpr(l.getStr(startPos, endPos))
+		if err != nil {
+			return nil, "", err
+		}
+		// Move past the "..".
+		l.lastPos++
+		upperStr, terminator, err := l.ReadSqlExpr(BY, LOOP)
+		if err != nil {
+			return nil, "", err
+		}
+		upper, err := l.ParseExpr(upperStr)
+		if err != nil {
+			return nil, "", err
+		}
+		var step plpgsqltree.Expr
+		if terminator == BY {
+			// Move past the BY.
+			l.lastPos++
+			stepStr, _, err := l.ReadSqlExpr(LOOP)
+			if err != nil {
+				return nil, "", err
+			}
+			if step, err = l.ParseExpr(stepStr); err != nil {
+				return nil, "", err
+			}
+		}
+		return &plpgsqltree.ForInt{
+			Target:  target[0],
+			Lower:   lower,
+			Upper:   upper,
+			Step:    step,
+			Reverse: reverse,
+		}, "", nil
+	}
+	if reverse {
+		return nil, "", errors.New("cannot specify REVERSE in query FOR loop")
+	}
+	if l.tokens[startPos].id == IDENT && (endPos == startPos+1 || l.tokens[startPos+1].id == '(') {
+		// A query cannot begin with an identifier, so this must be a loop over a
+		// bound cursor.
+		return nil, "for loop over cursor", nil
+	}
+	stmts, err := parser.Parse(l.getStr(startPos, endPos))
+	if err != nil {
+		return nil, "", err
+	}
+	if len(stmts) != 1 {
+		return nil, "", errors.New("expected exactly one SQL statement for FOR loop")
+	}
+	return &plpgsqltree.ForSelect{
+		ForQuery: plpgsqltree.ForQuery{Target: target},
+		Query:    stmts[0].AST,
+	}, "", nil
+}
+
+// ReadType reads and parses a data type, stopping at any of the given
+// terminator tokens.
+func (l *lexer) ReadType(
+	terminator1 int, terminators ...int,
+) (tree.ResolvableTypeReference, error) {
+	sqlStr, _, err := l.ReadSqlExpr(terminator1, terminators...)
+	if err != nil {
+		return nil, err
+	}
+	// This is an inlined version of GetTypeFromValidSQLSyntax which doesn't
+	// return an assertion failure.
+	castExpr, err := l.ParseExpr("1::" + sqlStr)
+	if err != nil {
+		return nil, errors.New("unable to parse type of variable declaration")
+	}
+	switch t := castExpr.(type) {
+	case *tree.CollateExpr:
+		return types.MakeCollatedString(types.String, t.Locale), nil
+	case *tree.CastExpr:
+		return t.Type, nil
+	default:
+		err := errors.New("unable to parse type of variable declaration")
+		if strings.Contains(sqlStr, "%") {
+			err = errors.WithIssueLink(errors.WithHint(err,
+				"you may have attempted to use %TYPE or %ROWTYPE syntax, which is unsupported.",
+			), errors.IssueLink{IssueURL: build.MakeIssueURL(114676)})
+		}
+		return nil, err
+	}
+}
+
 func (l *lexer) ReadSqlExpr(
 	terminator1 int, terminators ...int,
 ) (sqlStr string, terminatorMet int, err error) {
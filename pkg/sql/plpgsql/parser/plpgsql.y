@@ -2,14 +2,10 @@
 package parser
 
 import (
-  "strings"
-
-  "github.com/cockroachdb/cockroach/pkg/build"
   "github.com/cockroachdb/cockroach/pkg/sql/parser"
   "github.com/cockroachdb/cockroach/pkg/sql/scanner"
   "github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
   "github.com/cockroachdb/cockroach/pkg/sql/sem/plpgsqltree"
-  "github.com/cockroachdb/cockroach/pkg/sql/types"
   "github.com/cockroachdb/errors"
   "github.com/cockroachdb/redact"
 )
@@ -171,6 +167,18 @@ func (u *plpgsqlSymUnion) sqlStatement() tree.Statement {
     return u.val.(tree.Statement)
 }
 
+func (u *plpgsqlSymUnion) variables() []plpgsqltree.Variable {
+    return u.val.([]plpgsqltree.Variable)
+}
+
+func (u *plpgsqlSymUnion) cursorArg() plpgsqltree.CursorArg {
+    return u.val.(plpgsqltree.CursorArg)
+}
+
+func (u *plpgsqlSymUnion) cursorArgs() []plpgsqltree.CursorArg {
+    return u.val.([]plpgsqltree.CursorArg)
+}
+
 %}
 /*
  * Basic non-keyword token types.  These are hard-wired into the core lexer.
@@ -318,18 +326,21 @@ func (u *plpgsqlSymUnion) sqlStatement() tree.Statement {
 %type <str> decl_varname decl_defkey
 %type <bool>	decl_const decl_notnull
 %type <plpgsqltree.Expr>	decl_defval decl_cursor_query
-%type <tree.ResolvableTypeReference>	decl_datatype
+%type <tree.ResolvableTypeReference>	decl_datatype decl_cursor_argtype
+%type <str>	decl_aliasitem
+%type <[]plpgsqltree.CursorArg>	decl_cursor_args decl_cursor_arglist
+%type <plpgsqltree.CursorArg>	decl_cursor_arg
 %type <str>		decl_collate
 
 %type <str>	expr_until_semi expr_until_paren stmt_until_semi return_expr
 %type <str>	expr_until_then expr_until_loop opt_expr_until_when
 %type <plpgsqltree.Expr>	opt_exitcond
 
-%type <forvariable>	for_variable
-%type <*tree.NumVal>	foreach_slice
+%type <[]plpgsqltree.Variable>	for_variable
+%type <int32>	foreach_slice
 %type <plpgsqltree.Statement>	for_control
 
-%type <str> any_identifier opt_block_label opt_loop_label opt_label query_options
+%type <str> any_identifier opt_block_label opt_loop_label opt_label
 %type <str> opt_error_level option_type
 
 %type <[]plpgsqltree.Statement> proc_sect
@@ -462,13 +473,29 @@ decl_statement: decl_varname decl_const decl_datatype decl_collate decl_notnull
   }
 | decl_varname ALIAS FOR decl_aliasitem ';'
   {
-    return unimplemented(plpgsqllex, "alias for")
+    $$.val = &plpgsqltree.AliasDeclaration{
+      Var: plpgsqltree.Variable($1),
+      Target: plpgsqltree.Variable($4),
+    }
+  }
+| decl_varname ALIAS FOR '$' ICONST ';'
+  {
+    // The alias refers to a routine parameter by position.
+    param, err := $5.numVal().AsInt32()
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = &plpgsqltree.AliasDeclaration{
+      Var: plpgsqltree.Variable($1),
+      TargetParam: int(param),
+    }
   }
 | decl_varname opt_scrollable CURSOR decl_cursor_args decl_is_for decl_cursor_query
   {
     $$.val = &plpgsqltree.CursorDeclaration{
       Name: plpgsqltree.Variable($1),
       Scroll: $2.cursorScrollOption(),
+      Args: $4.cursorArgs(),
       Query: $6.sqlStatement(),
     }
   }
@@ -501,25 +528,43 @@ decl_cursor_query: stmt_until_semi ';'
   }
 ;
 
-decl_cursor_args: '('
+decl_cursor_args: '(' decl_cursor_arglist ')'
   {
-    return unimplemented(plpgsqllex, "cursor arguments")
+    $$.val = $2.cursorArgs()
   }
 | /* EMPTY */
   {
+    $$.val = []plpgsqltree.CursorArg(nil)
   }
 ;
 
 decl_cursor_arglist: decl_cursor_arg
   {
+    $$.val = []plpgsqltree.CursorArg{$1.cursorArg()}
   }
 | decl_cursor_arglist ',' decl_cursor_arg
   {
+    $$.val = append($1.cursorArgs(), $3.cursorArg())
+  }
+;
+
+decl_cursor_arg: decl_varname decl_cursor_argtype
+  {
+    $$.val = plpgsqltree.CursorArg{
+      Name: plpgsqltree.Variable($1),
+      Typ: $2.typ(),
+    }
   }
 ;
 
-decl_cursor_arg: decl_varname decl_datatype
+decl_cursor_argtype:
   {
+    // Read until reaching the end of the cursor argument.
+    typ, err := plpgsqllex.(*lexer).ReadType(',', ')')
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = typ
   }
 ;
 
@@ -528,11 +573,7 @@ decl_is_for:
 | FOR  /* SQL standard */
 
 decl_aliasitem: IDENT
-  {
-  }
 | unreserved_keyword
-  {
-  }
 ;
 
 decl_varname: IDENT
@@ -553,32 +594,13 @@ decl_datatype:
   {
     // Read until reaching one of the tokens that can follow a declaration
     // data type.
-    sqlStr, _, err := plpgsqllex.(*lexer).ReadSqlExpr(
+    typ, err := plpgsqllex.(*lexer).ReadType(
       ';', COLLATE, NOT, '=', COLON_EQUALS, DECLARE,
     )
     if err != nil {
       return setErr(plpgsqllex, err)
     }
-    // This is an inlined version of GetTypeFromValidSQLSyntax which doesn't
-    // return an assertion failure.
-    castExpr, err := plpgsqllex.(*lexer).ParseExpr("1::" + sqlStr)
-    if err != nil {
-      return setErr(plpgsqllex, errors.New("unable to parse type of variable declaration"))
-    }
-    switch t := castExpr.(type) {
-    case *tree.CollateExpr:
-      $$.val = types.MakeCollatedString(types.String, t.Locale)
-    case *tree.CastExpr:
-      $$.val = t.Type
-    default:
-      err := errors.New("unable to parse type of variable declaration")
-      if strings.Contains(sqlStr, "%") {
-        err = errors.WithIssueLink(errors.WithHint(err,
-          "you may have attempted to use %TYPE or %ROWTYPE syntax, which is unsupported.",
-        ), errors.IssueLink{IssueURL: build.MakeIssueURL(114676)})
-      }
-      return setErr(plpgsqllex, err)
-    }
+    $$.val = typ
   }
 ;
 
@@ -759,7 +781,17 @@ proc_stmt:pl_block ';'
 
 stmt_perform: PERFORM stmt_until_semi ';'
   {
-    return unimplemented(plpgsqllex, "perform")
+    // PERFORM executes a query as if it were a SELECT, and discards the
+    // result.
+    stmt, err := parser.ParseOne("SELECT " + $2)
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    sel, ok := stmt.AST.(*tree.Select)
+    if !ok {
+      return setErr(plpgsqllex, errors.New("expected a query for PERFORM"))
+    }
+    $$.val = &plpgsqltree.Perform{Query: sel}
   }
 ;
 
@@ -781,9 +813,18 @@ stmt_call: CALL expr_until_semi ';'
   }
 ;
 
-stmt_do: DO expr_until_semi ';'
+stmt_do: DO stmt_until_semi ';'
   {
-    return unimplemented(plpgsqllex, "do")
+    // A nested DO block is executed as a separate SQL statement, so it cannot
+    // reference the variables of the enclosing block.
+    stmt, err := parser.ParseOne("DO " + $2)
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    if _, ok := stmt.AST.(*tree.DoBlock); !ok {
+      return setErr(plpgsqllex, errors.New("expected a code block for DO"))
+    }
+    $$.val = &plpgsqltree.Execute{SqlStmt: stmt.AST}
   }
 ;
 
@@ -1020,54 +1061,84 @@ stmt_while: opt_loop_label WHILE expr_until_loop LOOP loop_body opt_label ';'
   }
 ;
 
-stmt_for: opt_loop_label FOR for_control loop_body
+stmt_for: opt_loop_label FOR for_control LOOP loop_body opt_label ';'
   {
-    return unimplemented(plpgsqllex, "for loop")
+    loopLabel, loopEndLabel := $1, $6
+    if err := checkLoopLabels(loopLabel, loopEndLabel); err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    switch t := $3.statement().(type) {
+    case *plpgsqltree.ForInt:
+      t.Label = $1
+      t.Body = $5.statements()
+    case *plpgsqltree.ForSelect:
+      t.Label = $1
+      t.Body = $5.statements()
+    }
+    $$.val = $3.statement()
   }
 ;
 
 for_control: for_variable IN
-  // TODO(drewk) need to parse the sql expression here.
   {
-    return unimplemented(plpgsqllex, "for loop")
+    stmt, unimplementedFeature, err := plpgsqllex.(*lexer).MakeForLoopControl($1.variables())
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    if unimplementedFeature != "" {
+      return unimplemented(plpgsqllex, unimplementedFeature)
+    }
+    $$.val = stmt
   }
 ;
 
 /*
  * Processing the for_variable is tricky because we don't yet know if the
- * FOR is an integer FOR loop or a loop over query results.  In the former
- * case, the variable is just a name that we must instantiate as a loop
- * local variable, regardless of any other definition it might have.
- * Therefore, we always save the actual identifier into $$.name where it
- * can be used for that case.  We also save the outer-variable definition,
- * if any, because that's what we need for the loop-over-query case.  Note
- * that we must NOT apply check_assignable() or any other semantic check
- * until we know what's what.
- *
- * However, if we see a comma-separated list of names, we know that it
- * can't be an integer FOR loop and so it's OK to check the variables
- * immediately.  In particular, for T_WORD followed by comma, we should
- * complain that the name is not known rather than say it's a syntax error.
- * Note that the non-error result of this case sets *both* $$.scalar and
- * $$.row; see the for_control production.
+ * FOR is an integer FOR loop or a loop over query results. A list of names
+ * is only valid for the latter; this is checked when the loop control is
+ * read.
  */
 for_variable: any_identifier
   {
-    return unimplemented(plpgsqllex, "for loop")
+    $$.val = []plpgsqltree.Variable{plpgsqltree.Variable($1)}
+  }
+| for_variable ',' any_identifier
+  {
+    $$.val = append($1.variables(), plpgsqltree.Variable($3))
   }
 ;
 
-stmt_foreach_a: opt_loop_label FOREACH for_variable foreach_slice IN ARRAY expr_until_loop loop_body
+stmt_foreach_a: opt_loop_label FOREACH for_variable foreach_slice IN ARRAY expr_until_loop LOOP loop_body opt_label ';'
   {
-    return unimplemented(plpgsqllex, "for each loop")
+    loopLabel, loopEndLabel := $1, $10
+    if err := checkLoopLabels(loopLabel, loopEndLabel); err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    expr, err := plpgsqllex.(*lexer).ParseExpr($7)
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = &plpgsqltree.ForEachArray{
+      Label: $1,
+      Target: $3.variables(),
+      Slice: int($4.int32()),
+      Expr: expr,
+      Body: $9.statements(),
+    }
   }
 ;
 
 foreach_slice:
   {
+    $$.val = int32(0)
   }
 | SLICE ICONST
   {
+    slice, err := $2.numVal().AsInt32()
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = slice
   }
 ;
 
@@ -1101,39 +1172,42 @@ stmt_return: RETURN return_expr ';'
     }
     $$.val = &plpgsqltree.Return{Expr: expr}
   }
-| RETURN_NEXT NEXT
+| RETURN_NEXT NEXT return_expr ';'
   {
-    return unimplemented(plpgsqllex, "return next")
+    var expr plpgsqltree.Expr
+    if $3 != "" {
+      var err error
+      expr, err = plpgsqllex.(*lexer).ParseExpr($3)
+      if err != nil {
+        return setErr(plpgsqllex, err)
+      }
+    }
+    $$.val = &plpgsqltree.ReturnNext{Expr: expr}
   }
-| RETURN_QUERY QUERY
- {
-   return unimplemented (plpgsqllex, "return query")
- }
-;
-
-return_expr:
+| RETURN_QUERY QUERY EXECUTE
   {
-    sqlStr, err := plpgsqllex.(*lexer).ReadReturnExpr()
+    return unimplemented(plpgsqllex, "return dynamic sql query")
+  }
+| RETURN_QUERY QUERY stmt_until_semi ';'
+  {
+    stmts, err := parser.Parse($3)
     if err != nil {
       return setErr(plpgsqllex, err)
     }
-    $$ = sqlStr
+    if len(stmts) != 1 {
+      return setErr(plpgsqllex, errors.New("expected exactly one SQL statement for RETURN QUERY"))
+    }
+    $$.val = &plpgsqltree.ReturnQuery{Query: stmts[0].AST}
   }
 ;
 
-query_options:
+return_expr:
   {
-    _, terminator, err := plpgsqllex.(*lexer).ReadSqlExpr(EXECUTE, ';')
-    if err != nil {
-      return setErr(plpgsqllex, err)
-    }
-    if terminator == EXECUTE {
-      return unimplemented (plpgsqllex, "return dynamic sql query")
-    }
-    _, _, err = plpgsqllex.(*lexer).ReadSqlExpr(';')
+    sqlStr, err := plpgsqllex.(*lexer).ReadReturnExpr()
     if err != nil {
       return setErr(plpgsqllex, err)
     }
+    $$ = sqlStr
   }
 ;
 
@@ -1340,6 +1414,14 @@ stmt_open: OPEN IDENT ';'
   {
     $$.val = &plpgsqltree.Open{CurVar: plpgsqltree.Variable($2)}
   }
+| OPEN IDENT '(' expr_until_paren ')' ';'
+  {
+    args, err := parser.ParseExprs([]string{$4})
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = &plpgsqltree.Open{CurVar: plpgsqltree.Variable($2), Args: args}
+  }
 | OPEN IDENT opt_scrollable FOR EXECUTE 
   {
     return unimplemented(plpgsqllex, "cursor for execute")
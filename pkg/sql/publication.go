@@ -0,0 +1,404 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// publication is a publication stored in the system.publications table.
+type publication struct {
+	databaseID descpb.ID
+	name       string
+	owner      username.SQLUsername
+	// allTables is true for FOR ALL TABLES publications, in which case
+	// tableIDs is empty.
+	allTables bool
+	tableIDs  []descpb.ID
+}
+
+// hasTable returns true if the publication explicitly lists the given table.
+func (pub *publication) hasTable(id descpb.ID) bool {
+	for _, tableID := range pub.tableIDs {
+		if tableID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// getPublication reads the publication with the given name from the given
+// database. It returns nil if the publication does not exist.
+func getPublication(
+	ctx context.Context, txn isql.Txn, dbID descpb.ID, name string,
+) (*publication, error) {
+	row, err := txn.QueryRowEx(
+		ctx, "get-publication", txn.KV(), sessiondata.NodeUserSessionDataOverride,
+		`SELECT database_id, name, owner, all_tables, table_ids FROM system.publications
+WHERE database_id = $1 AND name = $2`,
+		dbID, name,
+	)
+	if err != nil || row == nil {
+		return nil, err
+	}
+	return publicationFromRow(row), nil
+}
+
+// listPublications reads the publications of the given database, or of all
+// the databases if dbID is zero.
+func listPublications(
+	ctx context.Context, txn isql.Txn, dbID descpb.ID,
+) ([]*publication, error) {
+	rows, err := txn.QueryBufferedEx(
+		ctx, "list-publications", txn.KV(), sessiondata.NodeUserSessionDataOverride,
+		`SELECT database_id, name, owner, all_tables, table_ids FROM system.publications
+WHERE $1 = 0 OR database_id = $1 ORDER BY database_id, name`,
+		dbID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	pubs := make([]*publication, len(rows))
+	for i, row := range rows {
+		pubs[i] = publicationFromRow(row)
+	}
+	return pubs, nil
+}
+
+func publicationFromRow(row tree.Datums) *publication {
+	pub := &publication{
+		databaseID: descpb.ID(tree.MustBeDInt(row[0])),
+		name:       string(tree.MustBeDString(row[1])),
+		owner:      username.MakeSQLUsernameFromPreNormalizedString(string(tree.MustBeDString(row[2]))),
+		allTables:  bool(tree.MustBeDBool(row[3])),
+	}
+	for _, d := range tree.MustBeDArray(row[4]).Array {
+		pub.tableIDs = append(pub.tableIDs, descpb.ID(tree.MustBeDInt(d)))
+	}
+	return pub
+}
+
+// writePublication inserts or overwrites the given publication.
+func writePublication(ctx context.Context, txn isql.Txn, pub *publication) error {
+	tableIDs := tree.NewDArray(types.Int)
+	for _, id := range pub.tableIDs {
+		if err := tableIDs.Append(tree.NewDInt(tree.DInt(id))); err != nil {
+			return err
+		}
+	}
+	_, err := txn.ExecEx(
+		ctx, "write-publication", txn.KV(), sessiondata.NodeUserSessionDataOverride,
+		`UPSERT INTO system.publications (database_id, name, owner, all_tables, table_ids)
+VALUES ($1, $2, $3, $4, $5)`,
+		pub.databaseID, pub.name, pub.owner, pub.allTables, tableIDs,
+	)
+	return err
+}
+
+// deletePublication deletes the publication with the given name.
+func deletePublication(ctx context.Context, txn isql.Txn, dbID descpb.ID, name string) error {
+	_, err := txn.ExecEx(
+		ctx, "delete-publication", txn.KV(), sessiondata.NodeUserSessionDataOverride,
+		`DELETE FROM system.publications WHERE database_id = $1 AND name = $2`,
+		dbID, name,
+	)
+	return err
+}
+
+// checkLogicalReplicationSupported returns an error if the cluster has not
+// been upgraded to a version which supports publications and replication
+// slots.
+func checkLogicalReplicationSupported(ctx context.Context, p *planner, stmt string) error {
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V24_2_LogicalReplicationTables) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"%s is only supported after the v24.2 upgrade is finalized", stmt)
+	}
+	return nil
+}
+
+// currentDatabaseForPublication returns the current database, to which the
+// publications manipulated by the session belong.
+func (p *planner) currentDatabaseForPublication(
+	ctx context.Context,
+) (catalog.DatabaseDescriptor, error) {
+	if p.CurrentDatabase() == "" {
+		return nil, pgerror.New(pgcode.InvalidCatalogName,
+			"cannot use publications without a current database")
+	}
+	return p.Descriptors().ByNameWithLeased(p.txn).Get().Database(ctx, p.CurrentDatabase())
+}
+
+// resolvePublicationTables resolves the given tables, which must be regular
+// tables of the given database owned by the current user.
+func (p *planner) resolvePublicationTables(
+	ctx context.Context, db catalog.DatabaseDescriptor, names tree.TableNames,
+) ([]catalog.TableDescriptor, error) {
+	tables := make([]catalog.TableDescriptor, 0, len(names))
+	for i := range names {
+		tn := &names[i]
+		desc, err := p.ResolveExistingObjectEx(
+			ctx, tn.ToUnresolvedObjectName(), true /* required */, tree.ResolveRequireTableDesc,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if desc.IsVirtualTable() || desc.IsTemporary() {
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+				"cannot add relation %q to publication", desc.GetName())
+		}
+		if desc.GetParentID() != db.GetID() {
+			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"cannot add relation %q from another database to publication", desc.GetName())
+		}
+		if len(desc.GetFamilies()) > 1 {
+			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"cannot add relation %q with multiple column families to publication", desc.GetName())
+		}
+		if isOwner, err := p.HasOwnership(ctx, desc); err != nil {
+			return nil, err
+		} else if !isOwner {
+			return nil, pgerror.Newf(pgcode.InsufficientPrivilege,
+				"must be owner of table %s", desc.GetName())
+		}
+		tables = append(tables, desc)
+	}
+	return tables, nil
+}
+
+// checkPublicationOwnership returns an error if the current user does not own
+// the publication, and is not an admin.
+func (p *planner) checkPublicationOwnership(ctx context.Context, pub *publication) error {
+	isOwner, err := p.checkRolePredicate(ctx, p.User(), func(role username.SQLUsername) (bool, error) {
+		return role == pub.owner, nil
+	})
+	if err != nil || isOwner {
+		return err
+	}
+	if isAdmin, err := p.HasAdminRole(ctx); err != nil || isAdmin {
+		return err
+	}
+	return pgerror.Newf(pgcode.InsufficientPrivilege,
+		"must be owner of publication %s", pub.name)
+}
+
+// CreatePublication implements the CREATE PUBLICATION statement.
+// See https://www.postgresql.org/docs/current/sql-createpublication.html for
+// details.
+func (p *planner) CreatePublication(
+	ctx context.Context, n *tree.CreatePublication,
+) (planNode, error) {
+	if err := checkLogicalReplicationSupported(ctx, p, "CREATE PUBLICATION"); err != nil {
+		return nil, err
+	}
+	db, err := p.currentDatabaseForPublication(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.CheckPrivilege(ctx, db, privilege.CREATE); err != nil {
+		return nil, err
+	}
+	pub := &publication{
+		databaseID: db.GetID(),
+		name:       string(n.Name),
+		owner:      p.User(),
+		allTables:  n.Targets.AllTables,
+	}
+	if pub.allTables {
+		if isAdmin, err := p.HasAdminRole(ctx); err != nil {
+			return nil, err
+		} else if !isAdmin {
+			return nil, pgerror.New(pgcode.InsufficientPrivilege,
+				"must be admin to create FOR ALL TABLES publication")
+		}
+	}
+	tables, err := p.resolvePublicationTables(ctx, db, n.Targets.Tables)
+	if err != nil {
+		return nil, err
+	}
+	for _, desc := range tables {
+		if !pub.hasTable(desc.GetID()) {
+			pub.tableIDs = append(pub.tableIDs, desc.GetID())
+		}
+	}
+	return &createPublicationNode{pub: pub}, nil
+}
+
+type createPublicationNode struct {
+	pub *publication
+}
+
+func (n *createPublicationNode) startExec(params runParams) error {
+	txn := params.p.InternalSQLTxn()
+	existing, err := getPublication(params.ctx, txn, n.pub.databaseID, n.pub.name)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return pgerror.Newf(pgcode.DuplicateObject,
+			"publication %q already exists", n.pub.name)
+	}
+	return writePublication(params.ctx, txn, n.pub)
+}
+
+func (n *createPublicationNode) Next(_ runParams) (bool, error) { return false, nil }
+func (n *createPublicationNode) Values() tree.Datums            { return nil }
+func (n *createPublicationNode) Close(_ context.Context)        {}
+
+// AlterPublication implements the ALTER PUBLICATION statement.
+// See https://www.postgresql.org/docs/current/sql-alterpublication.html for
+// details.
+func (p *planner) AlterPublication(
+	ctx context.Context, n *tree.AlterPublication,
+) (planNode, error) {
+	if err := checkLogicalReplicationSupported(ctx, p, "ALTER PUBLICATION"); err != nil {
+		return nil, err
+	}
+	db, err := p.currentDatabaseForPublication(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tables, err := p.resolvePublicationTables(ctx, db, n.Tables)
+	if err != nil {
+		return nil, err
+	}
+	return &alterPublicationNode{n: n, databaseID: db.GetID(), tables: tables}, nil
+}
+
+type alterPublicationNode struct {
+	n          *tree.AlterPublication
+	databaseID descpb.ID
+	tables     []catalog.TableDescriptor
+}
+
+func (n *alterPublicationNode) startExec(params runParams) error {
+	txn := params.p.InternalSQLTxn()
+	name := string(n.n.Name)
+	pub, err := getPublication(params.ctx, txn, n.databaseID, name)
+	if err != nil {
+		return err
+	}
+	if pub == nil {
+		return pgerror.Newf(pgcode.UndefinedObject, "publication %q does not exist", name)
+	}
+	if err := params.p.checkPublicationOwnership(params.ctx, pub); err != nil {
+		return err
+	}
+	if pub.allTables {
+		return errors.WithDetail(
+			pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+				"publication %q is defined as FOR ALL TABLES", name),
+			"Tables cannot be added to or dropped from FOR ALL TABLES publications.",
+		)
+	}
+	switch n.n.Command {
+	case tree.AlterPublicationAddTable:
+		for _, desc := range n.tables {
+			if pub.hasTable(desc.GetID()) {
+				return pgerror.Newf(pgcode.DuplicateObject,
+					"relation %q is already member of publication %q", desc.GetName(), name)
+			}
+			pub.tableIDs = append(pub.tableIDs, desc.GetID())
+		}
+	case tree.AlterPublicationSetTable:
+		pub.tableIDs = pub.tableIDs[:0]
+		for _, desc := range n.tables {
+			if !pub.hasTable(desc.GetID()) {
+				pub.tableIDs = append(pub.tableIDs, desc.GetID())
+			}
+		}
+	case tree.AlterPublicationDropTable:
+		for _, desc := range n.tables {
+			if !pub.hasTable(desc.GetID()) {
+				return pgerror.Newf(pgcode.UndefinedObject,
+					"relation %q is not part of the publication", desc.GetName())
+			}
+			tableIDs := pub.tableIDs[:0]
+			for _, id := range pub.tableIDs {
+				if id != desc.GetID() {
+					tableIDs = append(tableIDs, id)
+				}
+			}
+			pub.tableIDs = tableIDs
+		}
+	default:
+		return errors.AssertionFailedf("unknown ALTER PUBLICATION command %d", n.n.Command)
+	}
+	return writePublication(params.ctx, txn, pub)
+}
+
+func (n *alterPublicationNode) Next(_ runParams) (bool, error) { return false, nil }
+func (n *alterPublicationNode) Values() tree.Datums            { return nil }
+func (n *alterPublicationNode) Close(_ context.Context)        {}
+
+// DropPublication implements the DROP PUBLICATION statement.
+// See https://www.postgresql.org/docs/current/sql-droppublication.html for
+// details.
+func (p *planner) DropPublication(ctx context.Context, n *tree.DropPublication) (planNode, error) {
+	if err := checkLogicalReplicationSupported(ctx, p, "DROP PUBLICATION"); err != nil {
+		return nil, err
+	}
+	db, err := p.currentDatabaseForPublication(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &dropPublicationNode{n: n, databaseID: db.GetID()}, nil
+}
+
+type dropPublicationNode struct {
+	n          *tree.DropPublication
+	databaseID descpb.ID
+}
+
+func (n *dropPublicationNode) startExec(params runParams) error {
+	txn := params.p.InternalSQLTxn()
+	for _, name := range n.n.Names {
+		pub, err := getPublication(params.ctx, txn, n.databaseID, string(name))
+		if err != nil {
+			return err
+		}
+		if pub == nil {
+			if n.n.IfExists {
+				params.p.BufferClientNotice(
+					params.ctx,
+					pgnotice.Newf("publication %q does not exist, skipping", string(name)),
+				)
+				continue
+			}
+			return pgerror.Newf(pgcode.UndefinedObject,
+				"publication %q does not exist", string(name))
+		}
+		if err := params.p.checkPublicationOwnership(params.ctx, pub); err != nil {
+			return err
+		}
+		if err := deletePublication(params.ctx, txn, n.databaseID, pub.name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *dropPublicationNode) Next(_ runParams) (bool, error) { return false, nil }
+func (n *dropPublicationNode) Values() tree.Datums            { return nil }
+func (n *dropPublicationNode) Close(_ context.Context)        {}
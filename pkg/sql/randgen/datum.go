@@ -245,6 +245,22 @@ func RandDatumWithNullChance(
 		// populated.
 		tuple.ResolvedType()
 		return &tuple
+	case types.RangeFamily:
+		if rng.Intn(10) == 0 {
+			return tree.NewDEmptyRange(typ)
+		}
+		lower := RandDatumWithNullChance(rng, typ.RangeContents(), 5, favorCommonData, false)
+		upper := RandDatumWithNullChance(rng, typ.RangeContents(), 5, favorCommonData, false)
+		lowerInc, upperInc := rng.Intn(2) == 0, rng.Intn(2) == 0
+		r, err := tree.NewDRange(typ, lower, upper, lowerInc, upperInc)
+		if err != nil {
+			// The bounds are out of order, or canonicalizing a discrete bound
+			// overflowed.
+			if r, err = tree.NewDRange(typ, upper, lower, lowerInc, upperInc); err != nil {
+				return tree.NewDEmptyRange(typ)
+			}
+		}
+		return r
 	case types.BitFamily:
 		width := typ.Width()
 		if width == 0 {
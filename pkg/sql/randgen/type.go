@@ -88,6 +88,12 @@ func IsAllowedForArray(typ *types.T) bool {
 		return false
 	}
 
+	// Don't include array types, since randomly generated arrays wouldn't have
+	// the matching dimensions required of multi-dimensional arrays.
+	if typ.Family() == types.ArrayFamily {
+		return false
+	}
+
 	// Don't include reg types, since parser currently doesn't allow them to
 	// be declared as array element types.
 	if typ.Family() == types.OidFamily && typ.Oid() != oid.T_oid {
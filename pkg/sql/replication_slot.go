@@ -0,0 +1,279 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsnutil"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgoutput"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgrepltree"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/slotprotectedts"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+)
+
+// maxReplicationSlotNameLength is the limit on the length of a replication
+// slot name. It matches the limit on identifiers imposed by Postgres.
+const maxReplicationSlotNameLength = 63
+
+// replicationSlot is a logical replication slot stored in the
+// system.replication_slots table.
+type replicationSlot struct {
+	name       string
+	plugin     string
+	databaseID descpb.ID
+	// confirmedFlushLSN is the position up to which the consumer of the slot
+	// has acknowledged the changes it received. Streaming from the slot
+	// resumes after this position.
+	confirmedFlushLSN lsn.LSN
+	// ptsRecordID is the ID of the protected timestamp record which keeps the
+	// data of the database after confirmedFlushLSN from being garbage
+	// collected. The record is advanced along with confirmedFlushLSN.
+	ptsRecordID uuid.UUID
+}
+
+// getReplicationSlot reads the replication slot with the given name. It
+// returns nil if the slot does not exist.
+func getReplicationSlot(ctx context.Context, txn isql.Txn, name string) (*replicationSlot, error) {
+	row, err := txn.QueryRowEx(
+		ctx, "get-replication-slot", txn.KV(), sessiondata.NodeUserSessionDataOverride,
+		`SELECT slot_name, plugin, database_id, confirmed_flush_lsn, pts_record_id
+FROM system.replication_slots WHERE slot_name = $1`,
+		name,
+	)
+	if err != nil || row == nil {
+		return nil, err
+	}
+	return replicationSlotFromRow(row), nil
+}
+
+// listReplicationSlots reads all the replication slots.
+func listReplicationSlots(ctx context.Context, txn isql.Txn) ([]*replicationSlot, error) {
+	rows, err := txn.QueryBufferedEx(
+		ctx, "list-replication-slots", txn.KV(), sessiondata.NodeUserSessionDataOverride,
+		`SELECT slot_name, plugin, database_id, confirmed_flush_lsn, pts_record_id
+FROM system.replication_slots ORDER BY slot_name`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	slots := make([]*replicationSlot, len(rows))
+	for i, row := range rows {
+		slots[i] = replicationSlotFromRow(row)
+	}
+	return slots, nil
+}
+
+func replicationSlotFromRow(row tree.Datums) *replicationSlot {
+	return &replicationSlot{
+		name:              string(tree.MustBeDString(row[0])),
+		plugin:            string(tree.MustBeDString(row[1])),
+		databaseID:        descpb.ID(tree.MustBeDInt(row[2])),
+		confirmedFlushLSN: lsn.LSN(tree.MustBeDInt(row[3])),
+		ptsRecordID:       tree.MustBeDUuid(row[4]).UUID,
+	}
+}
+
+// advanceReplicationSlot moves the confirmed flush position of the given slot
+// forward to the given position, along with the protected timestamp of the
+// slot, so that the changes the consumer acknowledged can be garbage
+// collected. The position never moves backwards.
+func advanceReplicationSlot(
+	ctx context.Context, execCfg *ExecutorConfig, name string, to lsn.LSN,
+) error {
+	return execCfg.InternalDB.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		row, err := txn.QueryRowEx(
+			ctx, "advance-replication-slot", txn.KV(), sessiondata.NodeUserSessionDataOverride,
+			`UPDATE system.replication_slots SET confirmed_flush_lsn = $2
+WHERE slot_name = $1 AND confirmed_flush_lsn < $2 RETURNING pts_record_id`,
+			name, int64(to),
+		)
+		if err != nil || row == nil {
+			return err
+		}
+		pts := execCfg.ProtectedTimestampProvider.WithTxn(txn)
+		return pts.UpdateTimestamp(ctx, tree.MustBeDUuid(row[0]).UUID, lsnutil.LSNToHLC(to))
+	})
+}
+
+// validateReplicationSlotName checks that the given slot name only contains
+// the characters allowed by Postgres.
+func validateReplicationSlotName(name string) error {
+	if name == "" {
+		return pgerror.Newf(pgcode.InvalidName, "replication slot name %q is too short", name)
+	}
+	if len(name) > maxReplicationSlotNameLength {
+		return pgerror.Newf(pgcode.NameTooLong, "replication slot name %q is too long", name)
+	}
+	for _, c := range name {
+		if !(c >= 'a' && c <= 'z') && !(c >= '0' && c <= '9') && c != '_' {
+			return pgerror.WithDetail(
+				pgerror.Newf(pgcode.InvalidName, "replication slot name %q contains invalid character", name),
+				"Replication slot names may only contain lower case letters, numbers, and the underscore character.",
+			)
+		}
+	}
+	return nil
+}
+
+// checkLogicalReplicationConnection returns an error if the session is not a
+// replication connection to a database, which is required by logical
+// decoding.
+func (p *planner) checkLogicalReplicationConnection() error {
+	if p.SessionData().ReplicationMode != sessiondatapb.ReplicationMode_REPLICATION_MODE_DATABASE ||
+		p.CurrentDatabase() == "" {
+		return pgerror.New(pgcode.ObjectNotInPrerequisiteState,
+			"logical decoding requires a database connection")
+	}
+	return nil
+}
+
+// CreateReplicationSlot implements the CREATE_REPLICATION_SLOT replication
+// command. Only permanent logical slots using the pgoutput plugin are
+// supported. Snapshots are never exported, so the SNAPSHOT option is ignored.
+func (p *planner) CreateReplicationSlot(
+	ctx context.Context, n *pgrepltree.CreateReplicationSlot,
+) (planNode, error) {
+	if err := checkLogicalReplicationSupported(ctx, p, "CREATE_REPLICATION_SLOT"); err != nil {
+		return nil, err
+	}
+	if n.Kind != pgrepltree.LogicalReplication {
+		return nil, unimplemented.New("physical replication slot",
+			"physical replication slots are not supported")
+	}
+	if n.Temporary {
+		return nil, unimplemented.New("temporary replication slot",
+			"temporary replication slots are not supported")
+	}
+	if string(n.Plugin) != pgoutput.PluginName {
+		return nil, pgerror.Newf(pgcode.UndefinedFile,
+			"could not access file %q: No such file or directory", string(n.Plugin))
+	}
+	if err := p.checkLogicalReplicationConnection(); err != nil {
+		return nil, err
+	}
+	if err := validateReplicationSlotName(string(n.Slot)); err != nil {
+		return nil, err
+	}
+	db, err := p.Descriptors().ByNameWithLeased(p.txn).Get().Database(ctx, p.CurrentDatabase())
+	if err != nil {
+		return nil, err
+	}
+	return &createReplicationSlotNode{
+		slot: replicationSlot{
+			name:              string(n.Slot),
+			plugin:            string(n.Plugin),
+			databaseID:        db.GetID(),
+			confirmedFlushLSN: lsnutil.HLCToLSN(p.Txn().ReadTimestamp()),
+			ptsRecordID:       uuid.MakeV4(),
+		},
+	}, nil
+}
+
+type createReplicationSlotNode struct {
+	optColumnsSlot
+	slot  replicationSlot
+	shown bool
+}
+
+func (n *createReplicationSlotNode) startExec(params runParams) error {
+	txn := params.p.InternalSQLTxn()
+	existing, err := getReplicationSlot(params.ctx, txn, n.slot.name)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return pgerror.Newf(pgcode.DuplicateObject,
+			"replication slot %q already exists", n.slot.name)
+	}
+	if _, err := txn.ExecEx(
+		params.ctx, "create-replication-slot", txn.KV(), sessiondata.NodeUserSessionDataOverride,
+		`INSERT INTO system.replication_slots
+(slot_name, plugin, database_id, confirmed_flush_lsn, pts_record_id)
+VALUES ($1, $2, $3, $4, $5)`,
+		n.slot.name, n.slot.plugin, n.slot.databaseID, int64(n.slot.confirmedFlushLSN),
+		tree.NewDUuid(tree.DUuid{UUID: n.slot.ptsRecordID}),
+	); err != nil {
+		return err
+	}
+	// Protect the data of the database from garbage collection from the start
+	// position of the slot, in the same transaction as the slot is created.
+	pts := params.ExecCfg().ProtectedTimestampProvider.WithTxn(txn)
+	return pts.Protect(params.ctx, slotprotectedts.MakeRecord(
+		n.slot.ptsRecordID, n.slot.name, n.slot.databaseID,
+		lsnutil.LSNToHLC(n.slot.confirmedFlushLSN),
+	))
+}
+
+func (n *createReplicationSlotNode) Next(_ runParams) (bool, error) {
+	if n.shown {
+		return false, nil
+	}
+	n.shown = true
+	return true, nil
+}
+
+func (n *createReplicationSlotNode) Values() tree.Datums {
+	return tree.Datums{
+		tree.NewDString(n.slot.name),
+		tree.NewDString(n.slot.confirmedFlushLSN.String()),
+		tree.DNull, // snapshot_name
+		tree.NewDString(n.slot.plugin),
+	}
+}
+
+func (n *createReplicationSlotNode) Close(_ context.Context) {}
+
+// DropReplicationSlot implements the DROP_REPLICATION_SLOT replication
+// command.
+func (p *planner) DropReplicationSlot(
+	ctx context.Context, n *pgrepltree.DropReplicationSlot,
+) (planNode, error) {
+	if err := checkLogicalReplicationSupported(ctx, p, "DROP_REPLICATION_SLOT"); err != nil {
+		return nil, err
+	}
+	return &dropReplicationSlotNode{name: string(n.Slot)}, nil
+}
+
+type dropReplicationSlotNode struct {
+	name string
+}
+
+func (n *dropReplicationSlotNode) startExec(params runParams) error {
+	txn := params.p.InternalSQLTxn()
+	row, err := txn.QueryRowEx(
+		params.ctx, "drop-replication-slot", txn.KV(), sessiondata.NodeUserSessionDataOverride,
+		`DELETE FROM system.replication_slots WHERE slot_name = $1 RETURNING pts_record_id`,
+		n.name,
+	)
+	if err != nil {
+		return err
+	}
+	if row == nil {
+		return pgerror.Newf(pgcode.UndefinedObject,
+			"replication slot %q does not exist", n.name)
+	}
+	pts := params.ExecCfg().ProtectedTimestampProvider.WithTxn(txn)
+	return pts.Release(params.ctx, tree.MustBeDUuid(row[0]).UUID)
+}
+
+func (n *dropReplicationSlotNode) Next(_ runParams) (bool, error) { return false, nil }
+func (n *dropReplicationSlotNode) Values() tree.Datums            { return nil }
+func (n *dropReplicationSlotNode) Close(_ context.Context)        {}
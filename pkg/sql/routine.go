@@ -13,6 +13,7 @@ package sql
 import (
 	"context"
 	"strconv"
+	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
@@ -228,6 +229,22 @@ func (g *routineGenerator) ResolvedType() *types.T {
 
 // Start is part of the eval.ValueGenerator interface.
 func (g *routineGenerator) Start(ctx context.Context, txn *kv.Txn) (err error) {
+	if g.expr.SessionSettings != nil {
+		pop, pushErr := g.p.pushRoutineSessionSettings(ctx, g.expr.SessionSettings)
+		if pushErr != nil {
+			return pushErr
+		}
+		defer func() { err = errors.CombineErrors(err, pop()) }()
+	}
+	if g.expr.ResultBuffer != nil && g.expr.Generator {
+		return g.startWithResultBuffer(ctx, txn)
+	}
+	return g.startWithTCO(ctx, txn)
+}
+
+// startWithTCO executes the routine, as well as any nested routines in
+// tail-call position that defer their execution to this routine.
+func (g *routineGenerator) startWithTCO(ctx context.Context, txn *kv.Txn) (err error) {
 	for {
 		err = g.startInternal(ctx, txn)
 		if err != nil || g.deferredRoutine.expr == nil {
@@ -241,22 +258,62 @@ func (g *routineGenerator) Start(ctx context.Context, txn *kv.Txn) (err error) {
 	}
 }
 
+// startWithResultBuffer executes the root routine of a set-returning PL/pgSQL
+// function. The rows of the result set are added by RETURN NEXT and RETURN
+// QUERY statements, which are executed by nested routines that write to the
+// shared result buffer. The result of the routine body itself is discarded.
+func (g *routineGenerator) startWithResultBuffer(ctx context.Context, txn *kv.Txn) (err error) {
+	buffer := g.expr.ResultBuffer
+	retTypes, err := g.returnTypes()
+	if err != nil {
+		return err
+	}
+	var rch rowContainerHelper
+	rch.Init(ctx, retTypes, g.p.ExtendedEvalContext(), "routine_result_buffer" /* opName */)
+	// Restore the previous writer once this routine finishes, since a
+	// recursive call to the same function may share the buffer.
+	prevWriter := buffer.Writer
+	buffer.Writer = &resultBufferWriter{
+		w:              NewRowResultWriter(&rch),
+		multiColOutput: g.expr.MultiColOutput,
+		numCols:        len(retTypes),
+	}
+	defer func() { buffer.Writer = prevWriter }()
+	if err = g.startWithTCO(ctx, txn); err != nil {
+		rch.Close(ctx)
+		return err
+	}
+	if g.rci != nil {
+		g.rci.Close()
+	}
+	g.rch.Close(ctx)
+	g.rch = rch
+	g.rci = newRowContainerIterator(ctx, g.rch)
+	return nil
+}
+
+// returnTypes returns the types of the columns produced by the routine.
+func (g *routineGenerator) returnTypes() ([]*types.T, error) {
+	rt := g.expr.ResolvedType()
+	if g.expr.MultiColOutput {
+		// A routine with multiple output column should have its types in a tuple.
+		if rt.Family() != types.TupleFamily {
+			return nil, errors.AssertionFailedf("routine expected to return multiple columns")
+		}
+		return rt.TupleContents(), nil
+	}
+	return []*types.T{rt}, nil
+}
+
 // startInternal implements logic for a single execution of a routine.
 // TODO(mgartner): We can cache results for future invocations of the routine by
 // creating a new iterator over an existing row container helper if the routine
 // is cache-able (i.e., there are no arguments to the routine and stepping is
 // disabled).
 func (g *routineGenerator) startInternal(ctx context.Context, txn *kv.Txn) (err error) {
-	rt := g.expr.ResolvedType()
-	var retTypes []*types.T
-	if g.expr.MultiColOutput {
-		// A routine with multiple output column should have its types in a tuple.
-		if rt.Family() != types.TupleFamily {
-			return errors.AssertionFailedf("routine expected to return multiple columns")
-		}
-		retTypes = rt.TupleContents()
-	} else {
-		retTypes = []*types.T{g.expr.ResolvedType()}
+	retTypes, err := g.returnTypes()
+	if err != nil {
+		return err
 	}
 	g.rch.Init(ctx, retTypes, g.p.ExtendedEvalContext(), "routine" /* opName */)
 
@@ -296,9 +353,17 @@ func (g *routineGenerator) startInternal(ctx context.Context, txn *kv.Txn) (err
 
 		var w rowResultWriter
 		openCursor := stmtIdx == 1 && g.expr.CursorDeclaration != nil
+		addToResult := stmtIdx == 1 && g.expr.ResultBuffer != nil && !g.expr.Generator
 		if isFinalPlan {
 			// The result of this statement is the routine's output.
 			w = rrw
+		} else if addToResult {
+			// The result of the first statement is added to the result set of the
+			// enclosing set-returning function.
+			if g.expr.ResultBuffer.Writer == nil {
+				return errors.AssertionFailedf("expected result buffer to be initialized")
+			}
+			w = g.expr.ResultBuffer.Writer.(rowResultWriter)
 		} else if openCursor {
 			// The result of the first statement will be used to open a SQL cursor.
 			cursorHelper, err = g.newCursorHelper(plan.(*planComponents))
@@ -495,6 +560,48 @@ func (g *routineGenerator) Close(ctx context.Context) {
 	*g = routineGenerator{}
 }
 
+// pushRoutineSessionSettings pushes a copy of the session data onto the
+// stack, and applies the given settings of a routine to it. A SECURITY
+// DEFINER routine runs as its owner, and the variables set by the routine's
+// SET clauses are in effect until it returns. The returned function must be
+// called to restore the previous session data.
+func (p *planner) pushRoutineSessionSettings(
+	ctx context.Context, settings *tree.RoutineSessionSettings,
+) (pop func() error, err error) {
+	sds := p.EvalContext().SessionDataStack
+	sds.PushTopClone()
+	pop = sds.Pop
+	defer func() {
+		if err != nil {
+			err = errors.CombineErrors(err, pop())
+		}
+	}()
+	m := p.sessionDataMutatorIterator.mutator(false /* applyCallbacks */, sds.Top())
+	if !settings.Definer.Undefined() {
+		// Only update session_user when the current_user is the session_user,
+		// as is done by SET ROLE.
+		if m.data.SessionUserProto == "" {
+			m.data.SessionUserProto = m.data.UserProto
+		}
+		m.data.UserProto = settings.Definer.EncodeProto()
+		m.data.SearchPath = m.data.SearchPath.WithUserSchemaName(settings.Definer.Normalized())
+	}
+	for _, setting := range settings.Config {
+		name, value, _ := strings.Cut(setting, "=")
+		_, v, err := getSessionVar(name, false /* missingOk */)
+		if err != nil {
+			return nil, err
+		}
+		if v.Set == nil {
+			return nil, newCannotChangeParameterError(name)
+		}
+		if err := v.Set(ctx, m, value); err != nil {
+			return nil, err
+		}
+	}
+	return pop, nil
+}
+
 var tailCallOptimizationEnabled = metamorphic.ConstantWithTestBool(
 	"tail-call-optimization-enabled",
 	true,
@@ -508,6 +615,11 @@ func (g *routineGenerator) CanOptimizeTailCall(nestedRoutine *tree.RoutineExpr)
 	// always more than one body statement if a cursor is opened. This is enforced
 	// during exec-building. For this reason, we only have to check for an
 	// exception handler.
+	if nestedRoutine.SessionSettings != nil {
+		// The session settings of a routine are applied when it starts, so
+		// the nested routine must be executed in its own generator.
+		return false
+	}
 	if g.expr.BlockState != nil {
 		// If the current routine has an exception handler (which is the case when
 		// BlockState is non-nil), the nested routine must either be part of the
@@ -551,6 +663,52 @@ func (d *droppingResultWriter) Err() error {
 	return d.err
 }
 
+// resultBufferWriter adds the rows produced by RETURN NEXT and RETURN QUERY
+// statements to the result set of a set-returning PL/pgSQL function. Each row
+// has a single column with the return type of the function, which is expanded
+// into multiple columns if the function is used as a data source.
+type resultBufferWriter struct {
+	w              rowResultWriter
+	multiColOutput bool
+	numCols        int
+}
+
+var _ rowResultWriter = &resultBufferWriter{}
+
+// AddRow is part of the rowResultWriter interface.
+func (r *resultBufferWriter) AddRow(ctx context.Context, row tree.Datums) error {
+	if !r.multiColOutput || len(row) != 1 {
+		return r.w.AddRow(ctx, row)
+	}
+	expanded := make(tree.Datums, r.numCols)
+	if row[0] == tree.DNull {
+		// A NULL composite value is expanded into NULL columns.
+		for i := range expanded {
+			expanded[i] = tree.DNull
+		}
+		return r.w.AddRow(ctx, expanded)
+	}
+	tuple, ok := tree.AsDTuple(row[0])
+	if !ok || len(tuple.D) != r.numCols {
+		return errors.AssertionFailedf("expected a tuple with %d elements, got %v", r.numCols, row[0])
+	}
+	copy(expanded, tuple.D)
+	return r.w.AddRow(ctx, expanded)
+}
+
+// SetRowsAffected is part of the rowResultWriter interface.
+func (r *resultBufferWriter) SetRowsAffected(ctx context.Context, n int) {}
+
+// SetError is part of the rowResultWriter interface.
+func (r *resultBufferWriter) SetError(err error) {
+	r.w.SetError(err)
+}
+
+// Err is part of the rowResultWriter interface.
+func (r *resultBufferWriter) Err() error {
+	return r.w.Err()
+}
+
 func (g *routineGenerator) newCursorHelper(plan *planComponents) (*plpgsqlCursorHelper, error) {
 	open := g.expr.CursorDeclaration
 	if open.NameArgIdx < 0 || open.NameArgIdx >= len(g.args) {
@@ -172,12 +172,16 @@ func GenerateInsertRow(
 		}
 	}
 
-	// Ensure that the values honor the specified column widths.
+	// Ensure that the values honor the specified column widths and can be
+	// stored at the active cluster version.
 	for i := 0; i < len(insertCols); i++ {
 		outVal, err := tree.AdjustValueToType(insertCols[i].GetType(), rowVals[i])
 		if err != nil {
 			return nil, err
 		}
+		if err := colinfo.ValidateArrayValue(ctx, evalCtx.Settings, outVal); err != nil {
+			return nil, err
+		}
 		rowVals[i] = outVal
 	}
 
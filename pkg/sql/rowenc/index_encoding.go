@@ -698,6 +698,8 @@ func EncodeInvertedIndexTableKeys(
 		return encodeTrigramInvertedIndexTableKeys(string(*datum.(*tree.DString)), inKey, version, true /* pad */)
 	case types.TSVectorFamily:
 		return tsearch.EncodeInvertedIndexKeys(inKey, val.(*tree.DTSVector).TSVector)
+	case types.RangeFamily:
+		return encodeRangeInvertedIndexTableKeys(tree.MustBeDRange(datum), inKey)
 	}
 	return nil, errors.AssertionFailedf("trying to apply inverted index to unsupported type %s", datum.ResolvedType().SQLStringForError())
 }
@@ -820,15 +822,15 @@ func EncodeExistsInvertedIndexSpans(
 
 // EncodeOverlapsInvertedIndexSpans returns the spans that must be scanned in
 // the inverted index to evaluate an overlaps (&&) predicate with the given
-// datum, which should be an Array. These spans should be used to find the
-// objects in the index that could overlap with the given array. In other
-// words, if we have a predicate x && y, this function should use the value of
-// y to find the spans to scan in an inverted index on x.
+// datum, which should be an Array or a range. These spans should be used to
+// find the objects in the index that could overlap with the given array or
+// range. In other words, if we have a predicate x && y, this function should
+// use the value of y to find the spans to scan in an inverted index on x.
 //
 // The spans are returned in an inverted.SpanExpression, which represents the
 // set operations that must be applied on the spans read during execution. The
-// span expression returned will be tight. See comments in the
-// SpanExpression definition for details.
+// span expression returned will be tight for arrays, but not for ranges. See
+// comments in the SpanExpression definition for details.
 func EncodeOverlapsInvertedIndexSpans(
 	ctx context.Context, evalCtx *eval.Context, val tree.Datum,
 ) (invertedExpr inverted.Expression, err error) {
@@ -839,6 +841,8 @@ func EncodeOverlapsInvertedIndexSpans(
 	switch val.ResolvedType().Family() {
 	case types.ArrayFamily:
 		return encodeOverlapsArrayInvertedIndexSpans(val.(*tree.DArray), nil /* inKey */)
+	case types.RangeFamily:
+		return encodeOverlapsRangeInvertedIndexSpans(tree.MustBeDRange(datum))
 	default:
 		return nil, errors.AssertionFailedf(
 			"trying to apply inverted index to unsupported type %s", datum.ResolvedType().SQLStringForError(),
@@ -855,18 +859,20 @@ func EncodeOverlapsInvertedIndexSpans(
 // It also does not return keys for NULL array elements if excludeNulls is
 // true. This option is used by encodeContainedArrayInvertedIndexSpans, which
 // builds index spans to evaluate <@ (contained by) expressions.
+//
+// The entries of a multi-dimensional array are its scalar elements.
 func encodeArrayInvertedIndexTableKeys(
 	val *tree.DArray, inKey []byte, version descpb.IndexDescriptorVersion, excludeNulls bool,
 ) (key [][]byte, err error) {
-	if val.Array.Len() == 0 {
+	elems := val.ScalarElements()
+	if len(elems) == 0 {
 		if version >= descpb.EmptyArraysInInvertedIndexesVersion {
 			return [][]byte{encoding.EncodeEmptyArray(inKey)}, nil
 		}
 	}
 
-	outKeys := make([][]byte, 0, len(val.Array))
-	for i := range val.Array {
-		d := val.Array[i]
+	outKeys := make([][]byte, 0, len(elems))
+	for _, d := range elems {
 		if d == tree.DNull && (version < descpb.EmptyArraysInInvertedIndexesVersion || excludeNulls) {
 			// Older versions did not include null elements, but we must include them
 			// going forward since `SELECT ARRAY[NULL] @> ARRAY[]` returns true.
@@ -891,7 +897,8 @@ func encodeArrayInvertedIndexTableKeys(
 func encodeContainingArrayInvertedIndexSpans(
 	val *tree.DArray, inKey []byte,
 ) (invertedExpr inverted.Expression, err error) {
-	if val.Array.Len() == 0 {
+	hasNulls, hasNonNulls := arrayInvertedIndexNulls(val)
+	if !hasNulls && !hasNonNulls {
 		// All arrays contain the empty array. Return a SpanExpression that
 		// requires a full scan of the inverted index.
 		invertedExpr = inverted.ExprForSpan(
@@ -900,7 +907,7 @@ func encodeContainingArrayInvertedIndexSpans(
 		return invertedExpr, nil
 	}
 
-	if val.HasNulls {
+	if hasNulls {
 		// If there are any nulls, return empty spans. This is needed to ensure
 		// that `SELECT ARRAY[NULL, 2] @> ARRAY[NULL, 2]` is false.
 		return &inverted.SpanExpression{Tight: true, Unique: true}, nil
@@ -939,7 +946,7 @@ func encodeContainedArrayInvertedIndexSpans(
 	emptyArrSpanExpr.Unique = true
 
 	// If the given array is empty, we return the SpanExpression.
-	if val.Array.Len() == 0 {
+	if hasNulls, hasNonNulls := arrayInvertedIndexNulls(val); !hasNulls && !hasNonNulls {
 		return emptyArrSpanExpr, nil
 	}
 
@@ -981,7 +988,7 @@ func encodeOverlapsArrayInvertedIndexSpans(
 	// we cannot generate an inverted expression.
 
 	// TODO: This should be a contradiction which is treated as a no-op.
-	if val.Array.Len() == 0 || !val.HasNonNulls {
+	if _, hasNonNulls := arrayInvertedIndexNulls(val); !hasNonNulls {
 		return inverted.NonInvertedColExpression{}, nil
 	}
 
@@ -1006,6 +1013,24 @@ func encodeOverlapsArrayInvertedIndexSpans(
 	return invertedExpr, nil
 }
 
+// arrayInvertedIndexNulls returns whether the entries of the given array in an
+// inverted index include NULLs and non-NULL values, respectively. These are the
+// array's scalar elements, so for a one-dimensional array they are given by
+// its HasNulls and HasNonNulls fields.
+func arrayInvertedIndexNulls(val *tree.DArray) (hasNulls, hasNonNulls bool) {
+	if val.ParamTyp.Family() != types.ArrayFamily {
+		return val.HasNulls, val.HasNonNulls
+	}
+	for _, d := range val.ScalarElements() {
+		if d == tree.DNull {
+			hasNulls = true
+		} else {
+			hasNonNulls = true
+		}
+	}
+	return hasNulls, hasNonNulls
+}
+
 // EncodeTrigramSpans returns the spans that must be scanned to look up trigrams
 // present in the input string. If allMustMatch is true, the resultant inverted
 // expression must match every trigram in the input. Otherwise, it will match
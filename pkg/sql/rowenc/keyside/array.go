@@ -14,6 +14,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/errors"
 )
 
@@ -26,7 +27,18 @@ import (
 // differently, because the standard NULL encoding conflicts with the
 // terminator byte. This NULL value is chosen to be larger than the
 // terminator but less than all existing encoded values.
+//
+// The elements of a multi-dimensional array are arrays, which are encoded the
+// same way. The lower bounds of an array are not part of its key encoding, so
+// arrays with lower bounds other than the default can't be encoded: they
+// can't be added to this encoding without breaking either its ordering or
+// the encoding of existing keys. Such arrays can still be stored in columns
+// that aren't indexed, but they can't be indexed or used as grouping keys.
 func encodeArrayKey(b []byte, array *tree.DArray, dir encoding.Direction) ([]byte, error) {
+	if !array.HasDefaultLowerBounds() {
+		return nil, unimplemented.NewWithIssue(32552,
+			"arrays with lower bounds other than 1 cannot be indexed or grouped")
+	}
 	var err error
 	b = encoding.EncodeArrayKeyMarker(b, dir)
 	for _, elem := range array.Array {
@@ -75,6 +75,8 @@ func Decode(
 			rkey, i, err = encoding.DecodeVarintDescending(key)
 		}
 		return a.NewDInt(tree.DInt(i)), rkey, err
+	case types.RangeFamily:
+		return decodeRangeKey(a, valType, key, dir)
 	case types.PGLSNFamily:
 		var i uint64
 		if dir == encoding.Ascending {
@@ -0,0 +1,138 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package keyside
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/errors"
+)
+
+// Markers used in the key encoding of a range. They are chosen so that the
+// encoding sorts in the same order as tree.DRange.Compare: the empty range
+// first, then by lower bound (infinite first; for equal values, inclusive
+// before exclusive), then by upper bound (for equal values, exclusive before
+// inclusive; infinite last).
+const (
+	rangeKeyEmpty    = 0
+	rangeKeyNonEmpty = 1
+
+	rangeKeyLowerInf    = 0
+	rangeKeyBoundFinite = 1
+	rangeKeyUpperInf    = 2
+
+	rangeKeyLowerInc = 0
+	rangeKeyLowerExc = 1
+	rangeKeyUpperExc = 0
+	rangeKeyUpperInc = 1
+)
+
+// encodeRangeKey generates an ordered key encoding of a range. The bounds are
+// encoded in ascending order, along with markers for the emptiness of the
+// range and for each bound whether it is infinite and inclusive, and the
+// result is encoded as a single byte string in the given direction so that
+// the encoded range can be skipped as a single key value.
+func encodeRangeKey(b []byte, r *tree.DRange, dir encoding.Direction) ([]byte, error) {
+	var inner []byte
+	if r.Empty {
+		inner = encoding.EncodeVarintAscending(inner, rangeKeyEmpty)
+	} else {
+		var err error
+		inner = encoding.EncodeVarintAscending(inner, rangeKeyNonEmpty)
+		if r.Lower == nil {
+			inner = encoding.EncodeVarintAscending(inner, rangeKeyLowerInf)
+		} else {
+			inner = encoding.EncodeVarintAscending(inner, rangeKeyBoundFinite)
+			if inner, err = Encode(inner, r.Lower, encoding.Ascending); err != nil {
+				return nil, err
+			}
+			flag := int64(rangeKeyLowerExc)
+			if r.LowerInc {
+				flag = rangeKeyLowerInc
+			}
+			inner = encoding.EncodeVarintAscending(inner, flag)
+		}
+		if r.Upper == nil {
+			inner = encoding.EncodeVarintAscending(inner, rangeKeyUpperInf)
+		} else {
+			inner = encoding.EncodeVarintAscending(inner, rangeKeyBoundFinite)
+			if inner, err = Encode(inner, r.Upper, encoding.Ascending); err != nil {
+				return nil, err
+			}
+			flag := int64(rangeKeyUpperExc)
+			if r.UpperInc {
+				flag = rangeKeyUpperInc
+			}
+			inner = encoding.EncodeVarintAscending(inner, flag)
+		}
+	}
+	if dir == encoding.Ascending {
+		return encoding.EncodeBytesAscending(b, inner), nil
+	}
+	return encoding.EncodeBytesDescending(b, inner), nil
+}
+
+// decodeRangeKey decodes a range key generated by encodeRangeKey.
+func decodeRangeKey(
+	a *tree.DatumAlloc, t *types.T, key []byte, dir encoding.Direction,
+) (tree.Datum, []byte, error) {
+	var inner []byte
+	var err error
+	if dir == encoding.Ascending {
+		key, inner, err = encoding.DecodeBytesAscending(key, nil)
+	} else {
+		key, inner, err = encoding.DecodeBytesDescending(key, nil)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	inner, marker, err := encoding.DecodeVarintAscending(inner)
+	if err != nil {
+		return nil, nil, err
+	}
+	if marker == rangeKeyEmpty {
+		return tree.NewDEmptyRange(t), key, nil
+	}
+	var lower, upper tree.Datum
+	var lowerInc, upperInc bool
+	if inner, marker, err = encoding.DecodeVarintAscending(inner); err != nil {
+		return nil, nil, err
+	}
+	if marker == rangeKeyBoundFinite {
+		if lower, inner, err = Decode(a, t.RangeContents(), inner, encoding.Ascending); err != nil {
+			return nil, nil, err
+		}
+		var flag int64
+		if inner, flag, err = encoding.DecodeVarintAscending(inner); err != nil {
+			return nil, nil, err
+		}
+		lowerInc = flag == rangeKeyLowerInc
+	}
+	if inner, marker, err = encoding.DecodeVarintAscending(inner); err != nil {
+		return nil, nil, err
+	}
+	if marker == rangeKeyBoundFinite {
+		if upper, inner, err = Decode(a, t.RangeContents(), inner, encoding.Ascending); err != nil {
+			return nil, nil, err
+		}
+		var flag int64
+		if inner, flag, err = encoding.DecodeVarintAscending(inner); err != nil {
+			return nil, nil, err
+		}
+		upperInc = flag == rangeKeyUpperInc
+	}
+	if len(inner) != 0 {
+		return nil, nil, errors.AssertionFailedf("invalid range encoding (%d trailing bytes)", len(inner))
+	}
+	r, err := tree.NewDRange(t, lower, upper, lowerInc, upperInc)
+	return r, key, err
+}
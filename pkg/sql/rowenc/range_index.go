@@ -0,0 +1,303 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package rowenc
+
+import (
+	"math"
+	"math/bits"
+	"sort"
+
+	"github.com/cockroachdb/apd/v3"
+	"github.com/cockroachdb/cockroach/pkg/keysbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/inverted"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/errors"
+)
+
+// Inverted indexes on range columns index the cells of a one-dimensional
+// hierarchy that cover each range, much like geospatial indexes index the S2
+// cells that cover a shape.
+//
+// The bounds of a range are first mapped to points in [0, 2^64) such that the
+// order of the points is consistent with the order of the bound values:
+// a < b implies point(a) <= point(b). The hierarchy consists of 64 levels of
+// cells: the cell at level 0 contains every point, and each cell at level l is
+// split into two cells at level l+1 which each contain half of its points.
+// Like an S2 cell ID, the ID of a cell at level l is made of the l bits shared
+// by all of its points, followed by a 1 bit and 63-l 0 bits, so that the IDs
+// of the descendants of a cell are contiguous and surround its own ID.
+//
+// A range is indexed under at most rangeIndexMaxCells cells which cover the
+// points of its bounds, and an empty range under a key of its own. Two ranges
+// can only overlap or be adjacent if a cell covering one of them is equal to,
+// an ancestor of, or a descendant of a cell covering the other, so the spans
+// to scan to find the ranges that overlap a given range are the descendants
+// and ancestors of the cells that cover it. The index is not tight: the
+// ranges it returns must be rechecked with the original operator.
+
+const (
+	// rangeIndexMaxCells is the maximum number of cells used to cover a range.
+	rangeIndexMaxCells = 4
+	// rangeIndexMaxLevel is the level of the smallest cells.
+	rangeIndexMaxLevel = 63
+)
+
+// encodeRangeInvertedIndexTableKeys returns the inverted index keys of the
+// given range. The input inKey is prefixed to all returned keys.
+func encodeRangeInvertedIndexTableKeys(val *tree.DRange, inKey []byte) ([][]byte, error) {
+	if val.Empty {
+		return [][]byte{encoding.EncodeNullAscending(inKey)}, nil
+	}
+	lo, hi, err := rangeIndexInterval(val)
+	if err != nil {
+		return nil, err
+	}
+	cells := rangeIndexCovering(lo, hi)
+	keys := make([][]byte, len(cells))
+	for i, c := range cells {
+		// Make sure that each key has its own backing array.
+		key := make([]byte, len(inKey), len(inKey)+encoding.MaxVarintLen)
+		copy(key, inKey)
+		keys[i] = encoding.EncodeUvarintAscending(key, c)
+	}
+	return keys, nil
+}
+
+// encodeOverlapsRangeInvertedIndexSpans returns the spans that must be scanned
+// in an inverted index on a range column to find the ranges that overlap (&&)
+// the given range. The returned expression is nil if the given range is empty,
+// since no range overlaps it.
+func encodeOverlapsRangeInvertedIndexSpans(val *tree.DRange) (inverted.Expression, error) {
+	if val.Empty {
+		return nil, nil
+	}
+	lo, hi, err := rangeIndexInterval(val)
+	if err != nil {
+		return nil, err
+	}
+	return rangeIndexIntersectingSpans(rangeIndexCovering(lo, hi)), nil
+}
+
+// EncodeAdjacentInvertedIndexSpans returns the spans that must be scanned in
+// an inverted index on a range column to find the ranges that are adjacent
+// (-|-) to the given range. A range is adjacent to another if its upper bound
+// is the lower bound of the other, or vice versa, so the spans are those of
+// the ranges that overlap the finite bounds of the given range.
+//
+// The returned expression is nil if no range can be adjacent to the given one.
+func EncodeAdjacentInvertedIndexSpans(val tree.Datum) (inverted.Expression, error) {
+	if val == tree.DNull {
+		return nil, nil
+	}
+	r, ok := tree.AsDRange(val)
+	if !ok {
+		return nil, errors.AssertionFailedf(
+			"trying to apply inverted index to unsupported type %s", val.ResolvedType().SQLStringForError(),
+		)
+	}
+	if r.Empty {
+		return nil, nil
+	}
+	var cells []uint64
+	for _, bound := range []tree.Datum{r.Lower, r.Upper} {
+		if bound == nil {
+			// Nothing is adjacent to an infinite bound.
+			continue
+		}
+		p, err := rangeIndexPoint(bound)
+		if err != nil {
+			return nil, err
+		}
+		cells = append(cells, rangeIndexCell(p, rangeIndexMaxLevel))
+	}
+	if len(cells) == 0 {
+		return nil, nil
+	}
+	return rangeIndexIntersectingSpans(cells), nil
+}
+
+// rangeIndexInterval returns the points of the bounds of the given non-empty
+// range. The inclusivity of the bounds is ignored, which can only widen the
+// interval.
+func rangeIndexInterval(r *tree.DRange) (lo, hi uint64, err error) {
+	lo, hi = 0, math.MaxUint64
+	if r.Lower != nil {
+		if lo, err = rangeIndexPoint(r.Lower); err != nil {
+			return 0, 0, err
+		}
+	}
+	if r.Upper != nil {
+		if hi, err = rangeIndexPoint(r.Upper); err != nil {
+			return 0, 0, err
+		}
+	}
+	return lo, hi, nil
+}
+
+// rangeIndexPoint maps a bound of a range to a point of the index hierarchy.
+// The mapping preserves the order of the bounds, but it may map distinct
+// values to the same point.
+func rangeIndexPoint(d tree.Datum) (uint64, error) {
+	const signBit = 1 << 63
+	switch t := d.(type) {
+	case *tree.DInt:
+		return uint64(*t) ^ signBit, nil
+	case *tree.DFloat:
+		return rangeIndexFloatPoint(float64(*t)), nil
+	case *tree.DDecimal:
+		if t.Form == apd.NaN {
+			// NaN sorts before every other value.
+			return 0, nil
+		}
+		f, err := t.Float64()
+		if err != nil {
+			return 0, err
+		}
+		return rangeIndexFloatPoint(f), nil
+	case *tree.DDate:
+		return uint64(int64(t.PGEpochDays())) ^ signBit, nil
+	case *tree.DTimestamp:
+		return uint64(rangeIndexMicros(t.Unix(), t.Nanosecond())) ^ signBit, nil
+	case *tree.DTimestampTZ:
+		return uint64(rangeIndexMicros(t.Unix(), t.Nanosecond())) ^ signBit, nil
+	}
+	return 0, errors.AssertionFailedf("unexpected range element %T", d)
+}
+
+// rangeIndexFloatPoint maps a float to a point such that the order of the
+// points is the SQL order of the floats, in which NaN sorts first.
+func rangeIndexFloatPoint(f float64) uint64 {
+	if math.IsNaN(f) {
+		return 0
+	}
+	if f == 0 {
+		// Map -0 to the same point as 0, since they are equal.
+		f = 0
+	}
+	b := math.Float64bits(f)
+	if b&(1<<63) != 0 {
+		return ^b
+	}
+	return b | 1<<63
+}
+
+// rangeIndexMicros returns the number of microseconds of a timestamp since the
+// Unix epoch, saturating at the bounds of an int64 since the infinite
+// timestamps don't fit in one.
+func rangeIndexMicros(sec int64, nsec int) int64 {
+	const microsPerSec = 1000000
+	const maxSec = math.MaxInt64/microsPerSec - 1
+	const minSec = math.MinInt64/microsPerSec + 1
+	if sec > maxSec {
+		return math.MaxInt64
+	}
+	if sec < minSec {
+		return math.MinInt64
+	}
+	return sec*microsPerSec + int64(nsec/1000)
+}
+
+// rangeIndexCell returns the ID of the cell at the given level that contains
+// the given point.
+func rangeIndexCell(p uint64, level int) uint64 {
+	lsb := uint64(1) << (63 - level)
+	return (p &^ (lsb<<1 - 1)) | lsb
+}
+
+// rangeIndexCellLevel returns the level of the given cell.
+func rangeIndexCellLevel(c uint64) int {
+	return 63 - bits.TrailingZeros64(c)
+}
+
+// rangeIndexCellBounds returns the first and last points of the given cell.
+func rangeIndexCellBounds(c uint64) (first, last uint64) {
+	lsb := c & -c
+	return c - lsb, c + (lsb - 1)
+}
+
+// rangeIndexCovering returns the cells that cover the points in [lo, hi], in
+// ascending order. Starting from the smallest single cell that contains both
+// points, it repeatedly replaces the largest cell that isn't contained in the
+// interval by its children that intersect it, as long as that doesn't use more
+// than rangeIndexMaxCells cells.
+func rangeIndexCovering(lo, hi uint64) []uint64 {
+	level := bits.LeadingZeros64(lo ^ hi)
+	if level > rangeIndexMaxLevel {
+		level = rangeIndexMaxLevel
+	}
+	cells := make([]uint64, 1, rangeIndexMaxCells)
+	cells[0] = rangeIndexCell(lo, level)
+	for {
+		split := -1
+		for i, c := range cells {
+			first, last := rangeIndexCellBounds(c)
+			if rangeIndexCellLevel(c) == rangeIndexMaxLevel || (first >= lo && last <= hi) {
+				continue
+			}
+			if split == -1 || rangeIndexCellLevel(c) < rangeIndexCellLevel(cells[split]) {
+				split = i
+			}
+		}
+		if split == -1 {
+			return cells
+		}
+		c := cells[split]
+		half := (c & -c) >> 1
+		var children []uint64
+		for _, child := range [2]uint64{c - half, c + half} {
+			if first, last := rangeIndexCellBounds(child); first <= hi && last >= lo {
+				children = append(children, child)
+			}
+		}
+		if len(cells)-1+len(children) > rangeIndexMaxCells {
+			return cells
+		}
+		cells = append(cells[:split], append(children, cells[split+1:]...)...)
+	}
+}
+
+// rangeIndexIntersectingSpans returns a span expression that finds the indexed
+// ranges with a cell that intersects one of the given cells: the given cells
+// and their descendants, and their ancestors.
+func rangeIndexIntersectingSpans(cells []uint64) inverted.Expression {
+	encode := func(c uint64) inverted.EncVal {
+		return encoding.EncodeUvarintAscending(nil, c)
+	}
+	var ancestors []uint64
+	var invertedExpr inverted.Expression
+	union := func(spanExpr *inverted.SpanExpression) {
+		if invertedExpr == nil {
+			invertedExpr = spanExpr
+		} else {
+			invertedExpr = inverted.Or(invertedExpr, spanExpr)
+		}
+	}
+	for _, c := range cells {
+		lsb := c & -c
+		span := inverted.Span{
+			Start: encode(c - lsb + 1),
+			End:   keysbase.PrefixEnd(encode(c + lsb - 1)),
+		}
+		union(inverted.ExprForSpan(span, false /* tight */))
+		for level := rangeIndexCellLevel(c) - 1; level >= 0; level-- {
+			ancestors = append(ancestors, rangeIndexCell(c, level))
+		}
+	}
+	sort.Slice(ancestors, func(i, j int) bool { return ancestors[i] < ancestors[j] })
+	for i, c := range ancestors {
+		if i > 0 && c == ancestors[i-1] {
+			continue
+		}
+		union(inverted.ExprForSpan(inverted.MakeSingleValSpan(encode(c)), false /* tight */))
+	}
+	return invertedExpr
+}
@@ -0,0 +1,115 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package rowenc_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/inverted"
+	"github.com/cockroachdb/cockroach/pkg/sql/randgen"
+	. "github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/randutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeRangeInvertedIndexSpans(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	evalCtx := eval.MakeTestingEvalContext(cluster.MakeTestingClusterSettings())
+
+	// matches returns whether the spans that the index is scanned with to find
+	// the ranges that overlap, or that are adjacent to, value include one of
+	// the keys of indexedValue.
+	matches := func(indexedValue, value tree.Datum, adjacent bool) bool {
+		keys, err := EncodeInvertedIndexTableKeys(indexedValue, nil, descpb.LatestIndexDescriptorVersion)
+		require.NoError(t, err)
+		var invertedExpr inverted.Expression
+		if adjacent {
+			invertedExpr, err = EncodeAdjacentInvertedIndexSpans(value)
+		} else {
+			invertedExpr, err = EncodeOverlapsInvertedIndexSpans(ctx, &evalCtx, value)
+		}
+		require.NoError(t, err)
+		if invertedExpr == nil {
+			return false
+		}
+		spanExpr, ok := invertedExpr.(*inverted.SpanExpression)
+		require.True(t, ok)
+		require.False(t, spanExpr.Tight)
+		found, err := spanExpr.ContainsKeys(keys)
+		require.NoError(t, err)
+		return found
+	}
+
+	testCases := []struct {
+		indexedValue string
+		value        string
+		overlaps     bool
+		adjacent     bool
+	}{
+		{`[1,5)`, `[3,7)`, true, false},
+		{`[1,5)`, `[5,7)`, false, true},
+		{`[5,7)`, `[1,5)`, false, true},
+		{`[1,5)`, `[1,5)`, true, false},
+		{`[1,2)`, `[1,1000000)`, true, false},
+		{`[1,1000000)`, `[999999,1000001)`, true, false},
+		{`(,5)`, `[4,)`, true, false},
+		{`(,)`, `[-100,-99)`, true, false},
+		{`[-3,-1)`, `[-1,0)`, false, true},
+		{`[1,5)`, `[1000,2000)`, false, false},
+		{`[1000,2000)`, `[1,5)`, false, false},
+		{`empty`, `[1,5)`, false, false},
+		{`[1,5)`, `empty`, false, false},
+	}
+	for _, tc := range testCases {
+		indexedValue, _, err := tree.ParseDRangeFromString(&evalCtx, tc.indexedValue, types.Int8Range)
+		require.NoError(t, err)
+		value, _, err := tree.ParseDRangeFromString(&evalCtx, tc.value, types.Int8Range)
+		require.NoError(t, err)
+		require.Equal(t, tc.overlaps, indexedValue.Overlaps(value))
+		require.Equal(t, tc.adjacent, indexedValue.Adjacent(value))
+		// The index is not tight, so it may return ranges which don't overlap
+		// or aren't adjacent, but not those which are far apart.
+		far := !tc.overlaps && !tc.adjacent
+		if tc.overlaps || far {
+			require.Equal(t, tc.overlaps, matches(indexedValue, value, false /* adjacent */),
+				"%s && %s", tc.indexedValue, tc.value)
+		}
+		if tc.adjacent || far {
+			require.Equal(t, tc.adjacent, matches(indexedValue, value, true /* adjacent */),
+				"%s -|- %s", tc.indexedValue, tc.value)
+		}
+	}
+
+	// The index must return all of the ranges which overlap or are adjacent.
+	rng, _ := randutil.NewTestRand()
+	for i := 0; i < 1000; i++ {
+		typ := types.RangeTypes[rng.Intn(len(types.RangeTypes))]
+		left := tree.MustBeDRange(randgen.RandDatum(rng, typ, false /* nullOk */))
+		right := tree.MustBeDRange(randgen.RandDatum(rng, typ, false /* nullOk */))
+		if left.Overlaps(right) {
+			require.True(t, matches(left, right, false /* adjacent */), "%s && %s", left, right)
+		}
+		if left.Adjacent(right) {
+			require.True(t, matches(left, right, true /* adjacent */), "%s -|- %s", left, right)
+		}
+	}
+}
@@ -21,39 +21,55 @@ import (
 	"github.com/cockroachdb/errors"
 )
 
-// encodeArray produces the value encoding for an array.
+// encodeArray produces the value encoding for an array. The elements of a
+// multi-dimensional array are encoded in row-major order, after the length of
+// each of its dimensions.
 func encodeArray(d *tree.DArray, scratch []byte) ([]byte, error) {
 	if err := d.Validate(); err != nil {
 		return scratch, err
 	}
 	scratch = scratch[0:0]
-	elementType, err := DatumTypeToArrayElementEncodingType(d.ParamTyp)
+	elementType, err := DatumTypeToArrayElementEncodingType(d.ScalarType())
 
 	if err != nil {
 		return nil, err
 	}
+	elems := d.ScalarElements()
 	header := arrayHeader{
-		hasNulls: d.HasNulls,
-		// TODO(justin): support multiple dimensions.
+		hasNulls:      d.HasNulls,
 		numDimensions: 1,
 		elementType:   elementType,
-		length:        uint64(d.Len()),
+		length:        uint64(len(elems)),
 		// We don't encode the NULL bitmap in this function because we do it in lockstep with the
 		// main data.
 	}
+	if dims := d.Dimensions(); len(dims) > 1 {
+		header.numDimensions = len(dims)
+		header.dimensions = dims
+		header.hasNulls = false
+		for _, e := range elems {
+			if e == tree.DNull {
+				header.hasNulls = true
+				break
+			}
+		}
+	}
+	if !d.HasDefaultLowerBounds() {
+		header.lowerBounds = d.LowerBounds()
+	}
 	scratch, err = encodeArrayHeader(header, scratch)
 	if err != nil {
 		return nil, err
 	}
 	nullBitmapStart := len(scratch)
-	if d.HasNulls {
-		for i := 0; i < numBytesInBitArray(d.Len()); i++ {
+	if header.hasNulls {
+		for i := 0; i < numBytesInBitArray(len(elems)); i++ {
 			scratch = append(scratch, 0)
 		}
 	}
-	for i, e := range d.Array {
+	for i, e := range elems {
 		var err error
-		if d.HasNulls && e == tree.DNull {
+		if header.hasNulls && e == tree.DNull {
 			setBit(scratch[nullBitmapStart:], i)
 		} else {
 			scratch, err = encodeArrayElement(scratch, e)
@@ -76,8 +92,10 @@ func decodeArray(a *tree.DatumAlloc, arrayType *types.T, b []byte) (tree.Datum,
 		Array:    make(tree.Datums, header.length),
 		ParamTyp: elementType,
 	}
-	if err = result.MaybeSetCustomOid(arrayType); err != nil {
-		return nil, b, err
+	if header.numDimensions > 1 {
+		// The elements of multi-dimensional arrays have the type of the
+		// innermost dimension.
+		elementType = tree.ArrayScalarType(elementType)
 	}
 	var val tree.Datum
 	for i := uint64(0); i < header.length; i++ {
@@ -93,6 +111,17 @@ func decodeArray(a *tree.DatumAlloc, arrayType *types.T, b []byte) (tree.Datum,
 			result.Array[i] = val
 		}
 	}
+	if header.numDimensions > 1 || header.lowerBounds != nil {
+		dims := header.dimensions
+		if dims == nil {
+			dims = []int{int(header.length)}
+		}
+		arr, err := tree.NewMultiDimDArray(result.ParamTyp, dims, header.lowerBounds, result.Array)
+		return arr, b, err
+	}
+	if err = result.MaybeSetCustomOid(arrayType); err != nil {
+		return nil, b, err
+	}
 	return &result, b, nil
 }
 
@@ -106,9 +135,16 @@ type arrayHeader struct {
 	hasNulls bool
 	// numDimensions is the number of dimensions in the array.
 	numDimensions int
+	// dimensions is the length of each dimension of the array, which is only
+	// set if it has more than one dimension.
+	dimensions []int
+	// lowerBounds is the lower bound of each dimension of the array, which is
+	// only set if any of them isn't the default.
+	lowerBounds []int
 	// elementType is the encoding type of the array elements.
 	elementType encoding.Type
-	// length is the total number of elements encoded.
+	// length is the total number of elements encoded, which is the product of
+	// the dimensions.
 	length uint64
 	// nullBitmap is a compact representation of which array indexes
 	// have NULL values.
@@ -144,20 +180,34 @@ func makeBitVec(src []byte, length int) (b, bitVec []byte) {
 
 const hasNullFlag = 1 << 4
 
+const hasLowerBoundsFlag = 1 << 5
+
 // encodeArrayHeader is used by encodeArray to encode the header
 // at the beginning of the value encoding.
 func encodeArrayHeader(h arrayHeader, buf []byte) ([]byte, error) {
 	// The header byte we append here is formatted as follows:
 	// * The low 4 bits encode the number of dimensions in the array.
 	// * The high 4 bits are flags, with the lowest representing whether the array
-	//   contains NULLs, and the rest reserved.
+	//   contains NULLs, the next one whether the array has lower bounds other
+	//   than the default, and the rest reserved.
 	headerByte := h.numDimensions
 	if h.hasNulls {
 		headerByte = headerByte | hasNullFlag
 	}
+	if h.lowerBounds != nil {
+		headerByte = headerByte | hasLowerBoundsFlag
+	}
 	buf = append(buf, byte(headerByte))
 	buf = encoding.EncodeValueTag(buf, encoding.NoColumnID, h.elementType)
 	buf = encoding.EncodeNonsortingUvarint(buf, h.length)
+	if h.numDimensions > 1 {
+		for _, dim := range h.dimensions {
+			buf = encoding.EncodeNonsortingUvarint(buf, uint64(dim))
+		}
+	}
+	for _, lowerBound := range h.lowerBounds {
+		buf = encoding.EncodeNonsortingStdlibVarint(buf, int64(lowerBound))
+	}
 	return buf, nil
 }
 
@@ -168,6 +218,13 @@ func decodeArrayHeader(b []byte) (arrayHeader, []byte, error) {
 		return arrayHeader{}, b, errors.Errorf("buffer too small")
 	}
 	hasNulls := b[0]&hasNullFlag != 0
+	hasLowerBounds := b[0]&hasLowerBoundsFlag != 0
+	// Arrays encoded before multi-dimensional arrays were supported always
+	// have one dimension.
+	numDimensions := int(b[0] & 0x0f)
+	if numDimensions == 0 {
+		numDimensions = 1
+	}
 	b = b[1:]
 	_, dataOffset, _, encType, err := encoding.DecodeValueTag(b)
 	if err != nil {
@@ -178,14 +235,39 @@ func decodeArrayHeader(b []byte) (arrayHeader, []byte, error) {
 	if err != nil {
 		return arrayHeader{}, b, err
 	}
+	var dimensions []int
+	if numDimensions > 1 {
+		dimensions = make([]int, numDimensions)
+		for i := range dimensions {
+			var dim uint64
+			b, _, dim, err = encoding.DecodeNonsortingUvarint(b)
+			if err != nil {
+				return arrayHeader{}, b, err
+			}
+			dimensions[i] = int(dim)
+		}
+	}
+	var lowerBounds []int
+	if hasLowerBounds {
+		lowerBounds = make([]int, numDimensions)
+		for i := range lowerBounds {
+			var lowerBound int64
+			b, _, lowerBound, err = encoding.DecodeNonsortingStdlibVarint(b)
+			if err != nil {
+				return arrayHeader{}, b, err
+			}
+			lowerBounds[i] = int(lowerBound)
+		}
+	}
 	nullBitmap := []byte(nil)
 	if hasNulls {
 		b, nullBitmap = makeBitVec(b, int(length))
 	}
 	return arrayHeader{
-		hasNulls: hasNulls,
-		// TODO(justin): support multiple dimensions.
-		numDimensions: 1,
+		hasNulls:      hasNulls,
+		numDimensions: numDimensions,
+		dimensions:    dimensions,
+		lowerBounds:   lowerBounds,
 		elementType:   encType,
 		length:        length,
 		nullBitmap:    nullBitmap,
@@ -239,8 +321,15 @@ func DatumTypeToArrayElementEncodingType(t *types.T) (encoding.Type, error) {
 		return encoding.JSON, nil
 	case types.TupleFamily:
 		return encoding.Tuple, nil
+	case types.RangeFamily:
+		return encoding.Bytes, nil
 	case types.ArrayFamily:
-		return 0, unimplemented.NewWithIssueDetail(32552, "", "nested arrays are not fully supported")
+		// The elements of multi-dimensional arrays are encoded with the encoding
+		// type of their scalar elements.
+		if scalarTyp := tree.ArrayScalarType(t); scalarTyp != t {
+			return DatumTypeToArrayElementEncodingType(scalarTyp)
+		}
+		return 0, unimplemented.NewWithIssueDetail(32552, "", "arrays of vectors are not supported")
 	default:
 		return 0, errors.AssertionFailedf("no known encoding type for %s", t.Family().Name())
 	}
@@ -321,6 +410,12 @@ func encodeArrayElement(b []byte, d tree.Datum) ([]byte, error) {
 		return encoding.EncodeUntaggedBytesValue(b, encoded), nil
 	case *tree.DTuple:
 		return encodeUntaggedTuple(t, b, encoding.NoColumnID, nil)
+	case *tree.DRange:
+		encoded, err := encodeRange(t, nil /* scratch */)
+		if err != nil {
+			return nil, err
+		}
+		return encoding.EncodeUntaggedBytesValue(b, encoded), nil
 	case *tree.DTSQuery:
 		encoded := tsearch.EncodeTSQueryPGBinary(nil, t.TSQuery)
 		return encoding.EncodeUntaggedBytesValue(b, encoded), nil
@@ -13,6 +13,7 @@ package valueside
 import (
 	"bytes"
 	"context"
+	"reflect"
 	"testing"
 
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
@@ -143,6 +144,96 @@ func TestArrayEncoding(t *testing.T) {
 	}
 }
 
+func TestMultiDimArrayEncoding(t *testing.T) {
+	ints := func(vals ...int) tree.Datums {
+		res := make(tree.Datums, len(vals))
+		for i, v := range vals {
+			res[i] = tree.NewDInt(tree.DInt(v))
+		}
+		return res
+	}
+	tests := []struct {
+		name        string
+		paramTyp    *types.T
+		dims        []int
+		lowerBounds []int
+		elems       tree.Datums
+		encoding    []byte
+	}{
+		{
+			"two-dimensional array",
+			types.IntArray,
+			[]int{2, 2},
+			nil, /* lowerBounds */
+			ints(1, 2, 3, 4),
+			[]byte{2, 3, 4, 2, 2, 2, 4, 6, 8},
+		},
+		{
+			"two-dimensional array with NULLs",
+			types.IntArray,
+			[]int{1, 3},
+			nil, /* lowerBounds */
+			tree.Datums{tree.NewDInt(1), tree.DNull, tree.NewDInt(3)},
+			[]byte{18, 3, 3, 1, 3, 2, 2, 6},
+		},
+		{
+			"one-dimensional array with lower bounds",
+			types.Int,
+			[]int{2},
+			[]int{0},
+			ints(1, 2),
+			[]byte{33, 3, 2, 0, 2, 4},
+		},
+		{
+			"two-dimensional array with lower bounds",
+			types.IntArray,
+			[]int{2, 2},
+			[]int{0, -1},
+			ints(1, 2, 3, 4),
+			[]byte{34, 3, 4, 2, 2, 0, 1, 2, 4, 6, 8},
+		},
+		{
+			"three-dimensional array",
+			types.MakeArray(types.IntArray),
+			[]int{2, 1, 2},
+			nil, /* lowerBounds */
+			ints(1, 2, 3, 4),
+			[]byte{3, 3, 4, 2, 1, 2, 2, 4, 6, 8},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			arr, err := tree.NewMultiDimDArray(test.paramTyp, test.dims, test.lowerBounds, test.elems)
+			if err != nil {
+				t.Fatal(err)
+			}
+			enc, err := encodeArray(arr, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(enc, test.encoding) {
+				t.Fatalf("expected %s to encode to %v, got %v", arr, test.encoding, enc)
+			}
+			d, _, err := decodeArray(&tree.DatumAlloc{}, types.MakeArray(test.paramTyp), enc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			evalContext := eval.NewTestingEvalContext(cluster.MakeTestingClusterSettings())
+			if cmp, err := d.Compare(context.Background(), evalContext, arr); err != nil {
+				t.Fatal(err)
+			} else if cmp != 0 {
+				t.Fatalf("expected %v to decode to %s, got %s", enc, arr, d)
+			}
+			decoded := tree.MustBeDArray(d)
+			if !reflect.DeepEqual(decoded.Dimensions(), arr.Dimensions()) ||
+				!reflect.DeepEqual(decoded.LowerBounds(), arr.LowerBounds()) {
+				t.Fatalf("expected %v to decode to %s, got %s", enc, arr, d)
+			}
+		})
+	}
+}
+
 func BenchmarkArrayEncoding(b *testing.B) {
 	ary := tree.DArray{ParamTyp: types.Int, Array: tree.Datums{}}
 	for i := 0; i < 10000; i++ {
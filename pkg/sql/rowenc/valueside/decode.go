@@ -251,6 +251,13 @@ func DecodeUntaggedDatum(
 		return decodeArray(a, t, b)
 	case types.TupleFamily:
 		return decodeTuple(a, t, buf)
+	case types.RangeFamily:
+		b, data, err := encoding.DecodeUntaggedBytesValue(buf)
+		if err != nil {
+			return nil, b, err
+		}
+		d, err := decodeRange(a, t, data)
+		return d, b, err
 	case types.EnumFamily:
 		b, data, err := encoding.DecodeUntaggedBytesValue(buf)
 		if err != nil {
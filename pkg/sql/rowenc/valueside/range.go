@@ -0,0 +1,90 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package valueside
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// The flags of an encoded range, stored in its first byte.
+const (
+	rangeEmpty byte = 1 << iota
+	rangeLowerInc
+	rangeUpperInc
+	rangeLowerInf
+	rangeUpperInf
+)
+
+// encodeRange produces the payload of the value encoding of a range, which is
+// stored as a byte string. It consists of a flags byte followed by the value
+// encodings of the finite bounds of the range.
+func encodeRange(t *tree.DRange, scratch []byte) ([]byte, error) {
+	var flags byte
+	switch {
+	case t.Empty:
+		return []byte{rangeEmpty}, nil
+	case t.Lower == nil:
+		flags |= rangeLowerInf
+	case t.LowerInc:
+		flags |= rangeLowerInc
+	}
+	switch {
+	case t.Upper == nil:
+		flags |= rangeUpperInf
+	case t.UpperInc:
+		flags |= rangeUpperInc
+	}
+	b := []byte{flags}
+	var err error
+	for _, bound := range []tree.Datum{t.Lower, t.Upper} {
+		if bound == nil {
+			continue
+		}
+		if b, err = Encode(b, NoColumnID, bound, scratch); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// decodeRange decodes a range from the payload produced by encodeRange.
+func decodeRange(a *tree.DatumAlloc, typ *types.T, b []byte) (tree.Datum, error) {
+	if len(b) == 0 {
+		return nil, errors.AssertionFailedf("missing flags of encoded range")
+	}
+	flags := b[0]
+	b = b[1:]
+	if flags&rangeEmpty != 0 {
+		return tree.NewDEmptyRange(typ), nil
+	}
+	var lower, upper tree.Datum = tree.DNull, tree.DNull
+	var err error
+	if flags&rangeLowerInf == 0 {
+		if lower, b, err = Decode(a, typ.RangeContents(), b); err != nil {
+			return nil, err
+		}
+	}
+	if flags&rangeUpperInf == 0 {
+		if upper, b, err = Decode(a, typ.RangeContents(), b); err != nil {
+			return nil, err
+		}
+	}
+	if len(b) != 0 {
+		return nil, errors.AssertionFailedf("%d trailing bytes in encoded range", len(b))
+	}
+	r, err := tree.NewDRange(typ, lower, upper, flags&rangeLowerInc != 0, flags&rangeUpperInc != 0)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
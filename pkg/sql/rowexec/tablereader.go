@@ -87,6 +87,10 @@ func newTableReader(
 	if nodeID, ok := flowCtx.NodeID.OptionalNodeID(); ok && nodeID == 0 {
 		return nil, errors.Errorf("attempting to create a tableReader with uninitialized NodeID")
 	}
+	if spec.FetchSpec.Pushdown != nil {
+		// The pushdown is only planned when the vectorized engine is used.
+		return nil, errors.AssertionFailedf("filter and aggregation pushdown is not supported by the tableReader")
+	}
 
 	if spec.LimitHint > 0 || spec.BatchBytesLimit > 0 {
 		// Parallelize shouldn't be set when there's a limit hint, but double-check
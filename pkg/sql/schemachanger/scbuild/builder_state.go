@@ -489,6 +489,33 @@ func (b *builderState) NextTableConstraintID(tableID catid.DescID) (ret catid.Co
 	return ret
 }
 
+// NextTableTriggerID implements the scbuildstmt.TableHelpers interface.
+func (b *builderState) NextTableTriggerID(tableID catid.DescID) (ret catid.TriggerID) {
+	{
+		b.ensureDescriptor(tableID)
+		desc := b.descCache[tableID].desc
+		tbl, ok := desc.(catalog.TableDescriptor)
+		if !ok {
+			panic(errors.AssertionFailedf("Expected table descriptor for ID %d, instead got %s",
+				desc.GetID(), desc.DescriptorType()))
+		}
+		ret = tbl.GetNextTriggerID()
+		if ret == 0 {
+			ret = 1
+		}
+	}
+	// Consult all present element in case they have a TriggerID field and it's larger.
+	b.QueryByID(tableID).ForEach(func(
+		_ scpb.Status, _ scpb.TargetStatus, e scpb.Element,
+	) {
+		v, _ := screl.Schema.GetAttribute(screl.TriggerID, e)
+		if id, ok := v.(catid.TriggerID); ok && id >= ret {
+			ret = id + 1
+		}
+	})
+	return ret
+}
+
 // NextTableTentativeIndexID implements the scbuildstmt.TableHelpers interface.
 func (b *builderState) NextTableTentativeIndexID(tableID catid.DescID) (ret catid.IndexID) {
 	ret = catid.IndexID(scbuildstmt.TableTentativeIdsStart)
@@ -1010,6 +1037,10 @@ func (b *builderState) ResolveUserDefinedTypeType(
 	case descpb.TypeDescriptor_COMPOSITE:
 		b.ensureDescriptor(typ.GetID())
 		b.mustOwn(typ.GetID())
+	case descpb.TypeDescriptor_DOMAIN:
+		panic(scerrors.NotImplementedErrorf(nil, "domain type %q", typ.GetName()))
+	case descpb.TypeDescriptor_RANGE:
+		panic(scerrors.NotImplementedErrorf(nil, "range type %q", typ.GetName()))
 	case descpb.TypeDescriptor_TABLE_IMPLICIT_RECORD_TYPE:
 		// Implicit record types are not directly modifiable.
 		panic(pgerror.Newf(pgcode.DependentObjectsStillExist,
@@ -1364,7 +1395,13 @@ func (b *builderState) ResolveRoutine(
 	}
 
 	fnID := funcdesc.UserDefinedFunctionOIDToID(ol.Oid)
-	b.mustOwn(fnID)
+	if p.RequiredPrivilege != 0 && !p.RequireOwnership {
+		if err := b.checkPrivilege(fnID, p.RequiredPrivilege); err != nil {
+			panic(err)
+		}
+	} else {
+		b.mustOwn(fnID)
+	}
 	b.ensureDescriptor(fnID)
 	return b.QueryByID(fnID)
 }
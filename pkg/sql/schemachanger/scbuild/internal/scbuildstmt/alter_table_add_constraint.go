@@ -42,6 +42,9 @@ func alterTableAddConstraint(
 ) {
 	switch d := t.ConstraintDef.(type) {
 	case *tree.UniqueConstraintTableDef:
+		if d.Deferrable != tree.ConstraintNotDeferrable {
+			panic(scerrors.NotImplementedErrorf(t, "deferrable unique constraint"))
+		}
 		if d.PrimaryKey {
 			alterTableAddPrimaryKey(b, tn, tbl, t)
 		} else if d.WithoutIndex {
@@ -62,7 +65,12 @@ func alterTableAddConstraint(
 	case *tree.CheckConstraintTableDef:
 		alterTableAddCheck(b, tn, tbl, t)
 	case *tree.ForeignKeyConstraintTableDef:
+		if d.Deferrable != tree.ConstraintNotDeferrable {
+			panic(scerrors.NotImplementedErrorf(t, "deferrable foreign key constraint"))
+		}
 		alterTableAddForeignKey(b, tn, tbl, t)
+	case *tree.ExclusionConstraintTableDef:
+		panic(scerrors.NotImplementedErrorf(t, "exclusion constraint"))
 	}
 }
 
@@ -33,6 +33,18 @@ func CreateFunction(b BuildCtx, n *tree.CreateRoutine) {
 	if n.Replace {
 		panic(scerrors.NotImplementedError(n))
 	}
+	// SECURITY DEFINER and SET clauses are not yet supported by the declarative
+	// schema changer.
+	for _, option := range n.Options {
+		switch t := option.(type) {
+		case tree.RoutineSecurity:
+			if t == tree.RoutineSecurityDefiner {
+				panic(scerrors.NotImplementedError(n))
+			}
+		case *tree.SetVar:
+			panic(scerrors.NotImplementedError(n))
+		}
+	}
 	b.IncrementSchemaChangeCreateCounter("function")
 
 	dbElts, scElts := b.ResolveTargetObject(n.Name.ToUnresolvedObjectName(), privilege.CREATE)
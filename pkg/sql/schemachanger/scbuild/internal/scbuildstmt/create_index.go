@@ -172,6 +172,18 @@ func CreateIndex(b BuildCtx, n *tree.CreateIndex) {
 			panic(pgerror.Newf(pgcode.DuplicateRelation, "index with name %q already exists", n.Name))
 		}
 	}
+	// Vector indexes are built as expression indexes on the list of each
+	// vector.
+	if n.Vector {
+		b.IncrementSchemaChangeIndexCounter("vector")
+		tableID := idxSpec.secondary.TableID
+		if err := tabledesc.RewriteVectorIndex(b, b.SemaCtx(), b.EvalCtx(), n, func(name tree.Name) (*types.T, error) {
+			colID := getColumnIDFromColumnName(b, tableID, name, true /* required */)
+			return mustRetrieveColumnTypeElem(b, tableID, colID).Type, nil
+		}); err != nil {
+			panic(err)
+		}
+	}
 	// Assign the ID here, since we may have added columns
 	// and made a new primary key above.
 	idxSpec.secondary.SourceIndexID = sourceIndex.IndexID
@@ -365,6 +377,12 @@ func processColNodeType(
 			}
 			invertedKind = catpb.InvertedIndexColumnKind_TRIGRAM
 			b.IncrementSchemaChangeIndexCounter("trigram_inverted")
+		case types.RangeFamily:
+			switch columnNode.OpClass {
+			case "range_ops", "":
+			default:
+				panic(newUndefinedOpclassError(columnNode.OpClass))
+			}
 
 		}
 		relationElts := b.QueryByID(indexSpec.secondary.TableID)
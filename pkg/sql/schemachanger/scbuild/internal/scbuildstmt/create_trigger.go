@@ -0,0 +1,339 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package scbuildstmt
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/semenumpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
+)
+
+// CreateTrigger implements CREATE TRIGGER.
+func CreateTrigger(b BuildCtx, n *tree.CreateTrigger) {
+	// Triggers are stored in the table and function descriptors, which older
+	// binaries can't read, so they can only be created once every node knows
+	// about them.
+	if !b.ClusterSettings().Version.IsActive(b, clusterversion.V24_2_Triggers) {
+		panic(pgerror.Newf(pgcode.FeatureNotSupported,
+			"triggers not supported until version 24.2"))
+	}
+	if n.Replace {
+		panic(scerrors.NotImplementedErrorf(n, "CREATE OR REPLACE TRIGGER"))
+	}
+	b.IncrementSchemaChangeCreateCounter("trigger")
+
+	tableElts := b.ResolveTable(n.TableName, ResolveParams{
+		RequiredPrivilege: privilege.CREATE,
+	})
+	_, _, tbl := scpb.FindTable(tableElts)
+	_, _, ns := scpb.FindNamespace(tableElts)
+	panicIfSchemaIsLocked(tableElts)
+	if tbl.IsTemporary {
+		panic(scerrors.NotImplementedErrorf(n, "triggers on temporary tables"))
+	}
+	validateTriggerDefinition(n, ns.Name)
+
+	// Triggers are identified by name within their table.
+	scpb.ForEachTriggerName(tableElts, func(
+		_ scpb.Status, target scpb.TargetStatus, e *scpb.TriggerName,
+	) {
+		if target == scpb.ToPublic && e.Name == string(n.Name) {
+			panic(pgerror.Newf(pgcode.DuplicateObject,
+				"trigger %q for relation %q already exists", n.Name, ns.Name))
+		}
+	})
+
+	triggerID := b.NextTableTriggerID(tbl.TableID)
+	trigger := &scpb.Trigger{
+		TableID:   tbl.TableID,
+		TriggerID: triggerID,
+	}
+	b.Add(trigger)
+	b.Add(&scpb.TriggerName{
+		TableID:   tbl.TableID,
+		TriggerID: triggerID,
+		Name:      string(n.Name),
+	})
+	b.Add(&scpb.TriggerEnabled{
+		TableID:   tbl.TableID,
+		TriggerID: triggerID,
+		Enabled:   true,
+	})
+	b.Add(&scpb.TriggerTiming{
+		TableID:    tbl.TableID,
+		TriggerID:  triggerID,
+		ActionTime: triggerActionTimeToProto(n.ActionTime),
+		ForEachRow: n.ForEach == tree.TriggerForEachRow,
+	})
+	events := make([]scpb.TriggerEvent, len(n.Events))
+	for i, ev := range n.Events {
+		events[i].Type = triggerEventTypeToProto(ev.EventType)
+		for _, colName := range ev.Columns {
+			// Verify that the column exists.
+			getColumnIDFromColumnName(b, tbl.TableID, colName, true /* required */)
+			events[i].ColumnNames = append(events[i].ColumnNames, string(colName))
+		}
+	}
+	b.Add(&scpb.TriggerEvents{
+		TableID:   tbl.TableID,
+		TriggerID: triggerID,
+		Events:    events,
+	})
+
+	var deps scpb.TriggerDeps
+	deps.TableID, deps.TriggerID = tbl.TableID, triggerID
+	if n.When != nil {
+		whenExpr := buildTriggerWhenExpr(b, n, tbl.TableID, ns.DatabaseID)
+		b.Add(&scpb.TriggerWhen{
+			TableID:   tbl.TableID,
+			TriggerID: triggerID,
+			WhenExpr:  string(whenExpr.Expr),
+		})
+		deps.UsesTypeIDs = whenExpr.UsesTypeIDs
+		deps.UsesRoutineIDs = whenExpr.UsesFunctionIDs
+	}
+
+	fnID := resolveTriggerFunction(b, n, ns.DatabaseID)
+	b.Add(&scpb.TriggerFunctionCall{
+		TableID:   tbl.TableID,
+		TriggerID: triggerID,
+		FuncID:    fnID,
+		FuncArgs:  n.FuncArgs,
+	})
+	b.Add(&deps)
+	b.LogEventForExistingTarget(trigger)
+}
+
+// validateTriggerDefinition checks that the combination of options specified
+// for the trigger is valid and supported.
+func validateTriggerDefinition(n *tree.CreateTrigger, tableName string) {
+	if n.ActionTime == tree.TriggerActionTimeInsteadOf {
+		panic(errors.WithDetail(
+			pgerror.Newf(pgcode.WrongObjectType, "%q is a table", tableName),
+			"Tables cannot have INSTEAD OF triggers.",
+		))
+	}
+	for _, ev := range n.Events {
+		if ev.EventType == tree.TriggerEventTruncate {
+			panic(unimplemented.NewWithIssue(28296, "TRUNCATE triggers are not supported"))
+		}
+	}
+	if len(n.Transitions) > 0 {
+		panic(unimplemented.NewWithIssue(28296, "REFERENCING clause is not supported"))
+	}
+	if n.When != nil && n.ForEach == tree.TriggerForEachStatement {
+		var refsRow bool
+		forEachTriggerRowReference(n.When, func(*tree.UnresolvedName) { refsRow = true })
+		if refsRow {
+			panic(pgerror.New(pgcode.InvalidObjectDefinition,
+				"statement trigger's WHEN condition cannot reference column values"))
+		}
+	}
+}
+
+// resolveTriggerFunction resolves the function executed by the trigger, and
+// verifies that it is a trigger function.
+func resolveTriggerFunction(b BuildCtx, n *tree.CreateTrigger, parentDBID catid.DescID) catid.DescID {
+	fnName, err := n.FuncName.ToRoutineName()
+	if err != nil {
+		panic(err)
+	}
+	fnElts := b.ResolveRoutine(
+		&tree.RoutineObj{FuncName: fnName, Params: tree.RoutineParams{}},
+		ResolveParams{RequiredPrivilege: privilege.EXECUTE},
+		tree.UDFRoutine,
+	)
+	_, _, fn := scpb.FindFunction(fnElts)
+	if fn.ReturnType.Type.Family() != types.TriggerFamily {
+		panic(pgerror.Newf(pgcode.InvalidObjectDefinition,
+			"function %s must return type trigger", fnName.Object()))
+	}
+	maybeFailOnCrossDBFunctionReference(b, fn.FunctionID, parentDBID)
+	return fn.FunctionID
+}
+
+// forEachTriggerRowReference calls fn for each reference to a column of the
+// NEW or OLD row in the given expression.
+func forEachTriggerRowReference(expr tree.Expr, fn func(*tree.UnresolvedName)) {
+	_, _ = tree.SimpleVisit(expr, func(e tree.Expr) (recurse bool, newExpr tree.Expr, err error) {
+		if name, ok := e.(*tree.UnresolvedName); ok && isTriggerRowReference(name) {
+			fn(name)
+		}
+		return true, e, nil
+	})
+}
+
+// isTriggerRowReference returns true if the name refers to a column of the
+// NEW or OLD row, e.g. NEW.a.
+func isTriggerRowReference(name *tree.UnresolvedName) bool {
+	if name.NumParts != 2 || name.Star {
+		return false
+	}
+	prefix := tree.Name(name.Parts[1])
+	return prefix == "new" || prefix == "old"
+}
+
+// triggerWhenVars is the IndexedVarContainer used to type-check the WHEN
+// clause of a trigger. Each reference to a column of the NEW or OLD row is
+// replaced by an IndexedVar of the column's type.
+type triggerWhenVars struct {
+	refs  []*tree.UnresolvedName
+	types []*types.T
+}
+
+var _ tree.IndexedVarContainer = &triggerWhenVars{}
+
+// IndexedVarResolvedType implements the tree.IndexedVarContainer interface.
+func (v *triggerWhenVars) IndexedVarResolvedType(idx int) *types.T {
+	return v.types[idx]
+}
+
+// buildTriggerWhenExpr type-checks the WHEN clause of the trigger, and returns
+// it in its serialized form along with the types and routines it references.
+func buildTriggerWhenExpr(
+	b BuildCtx, n *tree.CreateTrigger, tableID catid.DescID, parentDBID catid.DescID,
+) *scpb.Expression {
+	var vars triggerWhenVars
+	replaced, err := tree.SimpleVisit(n.When, func(e tree.Expr) (recurse bool, newExpr tree.Expr, err error) {
+		name, ok := e.(*tree.UnresolvedName)
+		if !ok {
+			return true, e, nil
+		}
+		if !isTriggerRowReference(name) {
+			return false, nil, pgerror.Newf(pgcode.UndefinedColumn,
+				"column %q does not exist", tree.ErrString(name))
+		}
+		isNew := tree.Name(name.Parts[1]) == "new"
+		for _, ev := range n.Events {
+			if isNew && ev.EventType == tree.TriggerEventDelete {
+				return false, nil, pgerror.New(pgcode.InvalidObjectDefinition,
+					"DELETE trigger's WHEN condition cannot reference NEW values")
+			}
+			if !isNew && ev.EventType == tree.TriggerEventInsert {
+				return false, nil, pgerror.New(pgcode.InvalidObjectDefinition,
+					"INSERT trigger's WHEN condition cannot reference OLD values")
+			}
+		}
+		colID := getColumnIDFromColumnName(b, tableID, tree.Name(name.Parts[0]), true /* required */)
+		colType := mustRetrieveColumnTypeElem(b, tableID, colID)
+		if n.ActionTime == tree.TriggerActionTimeBefore && isNew && colType.ComputeExpr != nil {
+			return false, nil, pgerror.New(pgcode.InvalidObjectDefinition,
+				"BEFORE trigger's WHEN condition cannot reference NEW generated columns")
+		}
+		vars.refs = append(vars.refs, name)
+		vars.types = append(vars.types, colType.Type)
+		return false, tree.NewOrdinalReference(len(vars.refs) - 1), nil
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	semaCtx := *b.SemaCtx()
+	semaCtx.IVarContainer = &vars
+	typedExpr, err := tree.TypeCheckAndRequire(b, replaced, &semaCtx, types.Bool, "WHEN")
+	if err != nil {
+		panic(err)
+	}
+	typedExpr, err = schemaexpr.MaybeReplaceUDFNameWithOIDReferenceInTypedExpr(typedExpr)
+	if err != nil {
+		panic(err)
+	}
+	// Serialize the expression, restoring the original references to the
+	// columns of the NEW and OLD rows.
+	exprStr := tree.AsStringWithFlags(typedExpr, tree.FmtSerializable,
+		tree.FmtIndexedVarFormat(func(ctx *tree.FmtCtx, idx int) {
+			ctx.FormatNode(vars.refs[idx])
+		}),
+	)
+	parsed, err := parser.ParseExpr(exprStr)
+	if err != nil {
+		panic(err)
+	}
+
+	var typeIDs catalog.DescriptorIDSet
+	visitor := &tree.TypeCollectorVisitor{OIDs: make(map[oid.Oid]struct{})}
+	tree.WalkExpr(visitor, parsed)
+	for typOID := range visitor.OIDs {
+		if !types.IsOIDUserDefinedType(typOID) {
+			continue
+		}
+		typ := b.ResolveTypeRef(&tree.OIDTypeReference{OID: typOID})
+		for _, id := range typ.ClosedTypeIDs {
+			maybeFailOnCrossDBTypeReference(b, id, parentDBID)
+			typeIDs.Add(id)
+		}
+	}
+	fnIDs, err := schemaexpr.GetUDFIDs(parsed)
+	if err != nil {
+		panic(err)
+	}
+	fnIDs.ForEach(func(id catid.DescID) {
+		maybeFailOnCrossDBFunctionReference(b, id, parentDBID)
+	})
+	return &scpb.Expression{
+		Expr:            catpb.Expression(exprStr),
+		UsesTypeIDs:     typeIDs.Ordered(),
+		UsesFunctionIDs: fnIDs.Ordered(),
+	}
+}
+
+// maybeFailOnCrossDBFunctionReference panics if the given function is not in
+// the database of the trigger's table.
+func maybeFailOnCrossDBFunctionReference(b BuildCtx, fnID, parentDBID catid.DescID) {
+	fnElts := b.QueryByID(fnID)
+	fnName := fnElts.FilterFunctionName().MustGetOneElement()
+	schemaParent := fnElts.FilterSchemaChild().MustGetOneElement()
+	schemaNamespace := b.QueryByID(schemaParent.SchemaID).FilterNamespace().MustGetOneElement()
+	if schemaNamespace.DatabaseID != parentDBID {
+		panic(pgerror.Newf(pgcode.FeatureNotSupported,
+			"dependent function %s cannot be from another database", fnName.Name))
+	}
+}
+
+func triggerActionTimeToProto(t tree.TriggerActionTime) semenumpb.TriggerActionTime {
+	switch t {
+	case tree.TriggerActionTimeBefore:
+		return semenumpb.TriggerActionTime_BEFORE
+	case tree.TriggerActionTimeAfter:
+		return semenumpb.TriggerActionTime_AFTER
+	case tree.TriggerActionTimeInsteadOf:
+		return semenumpb.TriggerActionTime_INSTEAD_OF
+	}
+	panic(errors.AssertionFailedf("unknown trigger action time %v", t))
+}
+
+func triggerEventTypeToProto(t tree.TriggerEventType) semenumpb.TriggerEventType {
+	switch t {
+	case tree.TriggerEventInsert:
+		return semenumpb.TriggerEventType_INSERT
+	case tree.TriggerEventUpdate:
+		return semenumpb.TriggerEventType_UPDATE
+	case tree.TriggerEventDelete:
+		return semenumpb.TriggerEventType_DELETE
+	case tree.TriggerEventTruncate:
+		return semenumpb.TriggerEventType_TRUNCATE
+	}
+	panic(errors.AssertionFailedf("unknown trigger event type %v", t))
+}
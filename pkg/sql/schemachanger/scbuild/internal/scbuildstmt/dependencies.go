@@ -280,6 +280,10 @@ type TableHelpers interface {
 	// added to this table.
 	NextTableConstraintID(tableID catid.DescID) catid.ConstraintID
 
+	// NextTableTriggerID returns the ID that should be used for any new trigger
+	// added to this table.
+	NextTableTriggerID(tableID catid.DescID) catid.TriggerID
+
 	// NextTableTentativeIndexID returns the tentative ID, starting from
 	// scbuild.TABLE_TENTATIVE_IDS_START, that should be used for any new index added to
 	// this table.
@@ -412,7 +416,8 @@ type NameResolver interface {
 	ResolveIndex(relationID catid.DescID, indexName tree.Name, p ResolveParams) ElementResultSet
 
 	// ResolveRoutine retrieves a user defined function or a stored procedure
-	// and returns its elements.
+	// and returns its elements. The current user must own the routine, unless
+	// RequiredPrivilege is set and RequireOwnership is not.
 	ResolveRoutine(routineObj *tree.RoutineObj, p ResolveParams, routineType tree.RoutineType) ElementResultSet
 
 	// ResolveIndexByName retrieves a table which contains the target
@@ -22,6 +22,9 @@ import (
 )
 
 func DropFunction(b BuildCtx, n *tree.DropRoutine) {
+	if n.Aggregate {
+		panic(scerrors.NotImplementedErrorf(n, "DROP AGGREGATE"))
+	}
 	if n.DropBehavior == tree.DropCascade {
 		// TODO(chengxiong): remove this when we allow UDF usage.
 		panic(scerrors.NotImplementedErrorf(n, "cascade dropping functions"))
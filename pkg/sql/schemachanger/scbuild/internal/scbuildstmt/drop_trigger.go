@@ -0,0 +1,71 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package scbuildstmt
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// DropTrigger implements DROP TRIGGER.
+func DropTrigger(b BuildCtx, n *tree.DropTrigger) {
+	if n.DropBehavior == tree.DropCascade {
+		// Nothing can depend on a trigger, so CASCADE is a no-op. Reject it
+		// anyway until that changes.
+		panic(scerrors.NotImplementedErrorf(n, "cascade dropping triggers"))
+	}
+	b.IncrementSchemaChangeDropCounter("trigger")
+
+	tableElts := b.ResolveTable(n.Table, ResolveParams{
+		IsExistenceOptional: n.IfExists,
+		RequiredPrivilege:   privilege.CREATE,
+	})
+	_, _, tbl := scpb.FindTable(tableElts)
+	if tbl == nil {
+		b.EvalCtx().ClientNoticeSender.BufferClientNotice(b, pgnotice.Newf(
+			"relation %q does not exist, skipping", n.Table.String()))
+		return
+	}
+	_, _, ns := scpb.FindNamespace(tableElts)
+	panicIfSchemaIsLocked(tableElts)
+
+	var triggerID catid.TriggerID
+	scpb.ForEachTriggerName(tableElts, func(
+		_ scpb.Status, target scpb.TargetStatus, e *scpb.TriggerName,
+	) {
+		if target == scpb.ToPublic && e.Name == string(n.Trigger) {
+			triggerID = e.TriggerID
+		}
+	})
+	if triggerID == 0 {
+		if n.IfExists {
+			b.EvalCtx().ClientNoticeSender.BufferClientNotice(b, pgnotice.Newf(
+				"trigger %q for relation %q does not exist, skipping", n.Trigger, ns.Name))
+			return
+		}
+		panic(pgerror.Newf(pgcode.UndefinedObject,
+			"trigger %q for table %q does not exist", n.Trigger, ns.Name))
+	}
+	elts := triggerElements(b, tbl.TableID, triggerID)
+	elts.ForEach(func(_ scpb.Status, target scpb.TargetStatus, e scpb.Element) {
+		if target == scpb.ToPublic {
+			b.Drop(e)
+		}
+	})
+	_, _, trigger := scpb.FindTrigger(elts)
+	b.LogEventForExistingTarget(trigger)
+}
@@ -289,6 +289,17 @@ func constraintElements(
 	})
 }
 
+func triggerElements(
+	b BuildCtx, relationID catid.DescID, triggerID catid.TriggerID,
+) ElementResultSet {
+	return b.QueryByID(relationID).Filter(func(
+		current scpb.Status, target scpb.TargetStatus, e scpb.Element,
+	) bool {
+		idI, _ := screl.Schema.GetAttribute(screl.TriggerID, e)
+		return idI != nil && idI.(catid.TriggerID) == triggerID
+	})
+}
+
 // getSortedColumnIDsInIndex return an all column IDs in an index, sorted.
 func getSortedColumnIDsInIndex(
 	b BuildCtx, tableID catid.DescID, indexID catid.IndexID,
@@ -22,6 +22,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catconstants"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
@@ -219,6 +220,12 @@ func (w *walkCtx) walkType(typ catalog.TypeDescriptor) {
 				Name:            comp.GetElementLabel(i),
 			})
 		}
+	} else if typ.AsDomainTypeDescriptor() != nil {
+		panic(scerrors.NotImplementedErrorf(nil, /* n */
+			"domain type %q", typ.GetName()))
+	} else if typ.AsRangeTypeDescriptor() != nil {
+		panic(scerrors.NotImplementedErrorf(nil, /* n */
+			"range type %q", typ.GetName()))
 	} else {
 		panic(errors.AssertionFailedf("unsupported type kind %q", typ.GetKind()))
 	}
@@ -287,6 +294,14 @@ func GetSequenceOptions(
 }
 
 func (w *walkCtx) walkRelation(tbl catalog.TableDescriptor) {
+	if tbl.IsForeignTable() {
+		panic(scerrors.NotImplementedErrorf(nil, /* n */
+			"foreign table %q", tbl.GetName()))
+	}
+	if len(tbl.GetExclusionConstraints()) > 0 {
+		panic(scerrors.NotImplementedErrorf(nil, /* n */
+			"table %q has exclusion constraints", tbl.GetName()))
+	}
 	switch {
 	case tbl.IsSequence():
 		w.ev(descriptorStatus(tbl), &scpb.Sequence{
@@ -406,6 +421,9 @@ func (w *walkCtx) walkRelation(tbl catalog.TableDescriptor) {
 	for _, c := range tbl.OutboundForeignKeys() {
 		w.walkForeignKeyConstraint(tbl, c)
 	}
+	for i := range tbl.GetTriggers() {
+		w.walkTrigger(tbl, &tbl.GetTriggers()[i])
+	}
 
 	_ = tbl.ForeachDependedOnBy(func(dep *descpb.TableDescriptor_Reference) error {
 		w.backRefs.Add(dep.ID)
@@ -772,6 +790,60 @@ func (w *walkCtx) walkCheckConstraint(tbl catalog.TableDescriptor, c catalog.Che
 	}
 }
 
+func (w *walkCtx) walkTrigger(tbl catalog.TableDescriptor, t *descpb.TriggerDescriptor) {
+	w.ev(scpb.Status_PUBLIC, &scpb.Trigger{
+		TableID:   tbl.GetID(),
+		TriggerID: t.ID,
+	})
+	w.ev(scpb.Status_PUBLIC, &scpb.TriggerName{
+		TableID:   tbl.GetID(),
+		TriggerID: t.ID,
+		Name:      t.Name,
+	})
+	w.ev(scpb.Status_PUBLIC, &scpb.TriggerEnabled{
+		TableID:   tbl.GetID(),
+		TriggerID: t.ID,
+		Enabled:   t.Enabled,
+	})
+	w.ev(scpb.Status_PUBLIC, &scpb.TriggerTiming{
+		TableID:    tbl.GetID(),
+		TriggerID:  t.ID,
+		ActionTime: t.ActionTime,
+		ForEachRow: t.ForEachRow,
+	})
+	events := make([]scpb.TriggerEvent, len(t.Events))
+	for i := range t.Events {
+		events[i] = scpb.TriggerEvent{
+			Type:        t.Events[i].Type,
+			ColumnNames: t.Events[i].ColumnNames,
+		}
+	}
+	w.ev(scpb.Status_PUBLIC, &scpb.TriggerEvents{
+		TableID:   tbl.GetID(),
+		TriggerID: t.ID,
+		Events:    events,
+	})
+	if t.WhenExpr != "" {
+		w.ev(scpb.Status_PUBLIC, &scpb.TriggerWhen{
+			TableID:   tbl.GetID(),
+			TriggerID: t.ID,
+			WhenExpr:  t.WhenExpr,
+		})
+	}
+	w.ev(scpb.Status_PUBLIC, &scpb.TriggerFunctionCall{
+		TableID:   tbl.GetID(),
+		TriggerID: t.ID,
+		FuncID:    t.FuncID,
+		FuncArgs:  t.FuncArgs,
+	})
+	w.ev(scpb.Status_PUBLIC, &scpb.TriggerDeps{
+		TableID:        tbl.GetID(),
+		TriggerID:      t.ID,
+		UsesTypeIDs:    t.DependsOnTypes,
+		UsesRoutineIDs: t.DependsOnRoutines,
+	})
+}
+
 func (w *walkCtx) walkForeignKeyConstraint(
 	tbl catalog.TableDescriptor, c catalog.ForeignKeyConstraint,
 ) {
@@ -813,6 +885,10 @@ func (w *walkCtx) walkForeignKeyConstraint(
 }
 
 func (w *walkCtx) walkFunction(fnDesc catalog.FunctionDescriptor) {
+	if fnDesc.IsAggregate() {
+		panic(scerrors.NotImplementedErrorf(nil, /* n */
+			"aggregate function %q", fnDesc.GetName()))
+	}
 	typeT := newTypeT(fnDesc.GetReturnType().Type)
 	fn := &scpb.Function{
 		FunctionID: fnDesc.GetID(),
@@ -0,0 +1,172 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package scmutationexec
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scop"
+	"github.com/cockroachdb/errors"
+)
+
+func (i *immediateVisitor) AddTrigger(ctx context.Context, op scop.AddTrigger) error {
+	tbl, err := i.checkOutTable(ctx, op.Trigger.TableID)
+	if err != nil {
+		return err
+	}
+	if op.Trigger.TriggerID >= tbl.NextTriggerID {
+		tbl.NextTriggerID = op.Trigger.TriggerID + 1
+	}
+	tbl.Triggers = append(tbl.Triggers, descpb.TriggerDescriptor{
+		ID: op.Trigger.TriggerID,
+	})
+	return nil
+}
+
+func (i *immediateVisitor) SetTriggerName(ctx context.Context, op scop.SetTriggerName) error {
+	trigger, err := i.checkOutTrigger(ctx, op.Name.TableID, op.Name.TriggerID)
+	if err != nil {
+		return err
+	}
+	trigger.Name = op.Name.Name
+	return nil
+}
+
+func (i *immediateVisitor) SetTriggerEnabled(
+	ctx context.Context, op scop.SetTriggerEnabled,
+) error {
+	trigger, err := i.checkOutTrigger(ctx, op.Enabled.TableID, op.Enabled.TriggerID)
+	if err != nil {
+		return err
+	}
+	trigger.Enabled = op.Enabled.Enabled
+	return nil
+}
+
+func (i *immediateVisitor) SetTriggerTiming(ctx context.Context, op scop.SetTriggerTiming) error {
+	trigger, err := i.checkOutTrigger(ctx, op.Timing.TableID, op.Timing.TriggerID)
+	if err != nil {
+		return err
+	}
+	trigger.ActionTime = op.Timing.ActionTime
+	trigger.ForEachRow = op.Timing.ForEachRow
+	return nil
+}
+
+func (i *immediateVisitor) SetTriggerEvents(ctx context.Context, op scop.SetTriggerEvents) error {
+	trigger, err := i.checkOutTrigger(ctx, op.Events.TableID, op.Events.TriggerID)
+	if err != nil {
+		return err
+	}
+	trigger.Events = make([]descpb.TriggerDescriptor_Event, len(op.Events.Events))
+	for j, ev := range op.Events.Events {
+		trigger.Events[j] = descpb.TriggerDescriptor_Event{
+			Type:        ev.Type,
+			ColumnNames: ev.ColumnNames,
+		}
+	}
+	return nil
+}
+
+func (i *immediateVisitor) SetTriggerWhen(ctx context.Context, op scop.SetTriggerWhen) error {
+	trigger, err := i.checkOutTrigger(ctx, op.When.TableID, op.When.TriggerID)
+	if err != nil {
+		return err
+	}
+	trigger.WhenExpr = op.When.WhenExpr
+	return nil
+}
+
+func (i *immediateVisitor) SetTriggerFunctionCall(
+	ctx context.Context, op scop.SetTriggerFunctionCall,
+) error {
+	trigger, err := i.checkOutTrigger(ctx, op.FunctionCall.TableID, op.FunctionCall.TriggerID)
+	if err != nil {
+		return err
+	}
+	trigger.FuncID = op.FunctionCall.FuncID
+	trigger.FuncArgs = op.FunctionCall.FuncArgs
+	return nil
+}
+
+func (i *immediateVisitor) SetTriggerForwardReferences(
+	ctx context.Context, op scop.SetTriggerForwardReferences,
+) error {
+	trigger, err := i.checkOutTrigger(ctx, op.Deps.TableID, op.Deps.TriggerID)
+	if err != nil {
+		return err
+	}
+	trigger.DependsOnTypes = op.Deps.UsesTypeIDs
+	trigger.DependsOnRoutines = op.Deps.UsesRoutineIDs
+	return nil
+}
+
+func (i *immediateVisitor) RemoveTrigger(ctx context.Context, op scop.RemoveTrigger) error {
+	tbl, err := i.checkOutTable(ctx, op.Trigger.TableID)
+	if err != nil {
+		return err
+	}
+	for j := range tbl.Triggers {
+		if tbl.Triggers[j].ID == op.Trigger.TriggerID {
+			tbl.Triggers = append(tbl.Triggers[:j], tbl.Triggers[j+1:]...)
+			return nil
+		}
+	}
+	return errors.AssertionFailedf("failed to find trigger %d in table %q (%d)",
+		op.Trigger.TriggerID, tbl.GetName(), tbl.GetID())
+}
+
+func (i *immediateVisitor) AddTriggerBackReferencesInRoutines(
+	ctx context.Context, op scop.AddTriggerBackReferencesInRoutines,
+) error {
+	for _, id := range op.RoutineIDs {
+		fnDesc, err := i.checkOutFunction(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := fnDesc.AddTriggerReference(op.BackReferencedTableID, op.BackReferencedTriggerID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (i *immediateVisitor) RemoveTriggerBackReferencesInRoutines(
+	ctx context.Context, op scop.RemoveTriggerBackReferencesInRoutines,
+) error {
+	for _, id := range op.RoutineIDs {
+		fnDesc, err := i.checkOutFunction(ctx, id)
+		if err != nil {
+			return err
+		}
+		fnDesc.RemoveTriggerReference(op.BackReferencedTableID, op.BackReferencedTriggerID)
+	}
+	return nil
+}
+
+// checkOutTrigger returns a pointer to the descriptor of the given trigger in
+// the checked-out table.
+func (i *immediateVisitor) checkOutTrigger(
+	ctx context.Context, tableID descpb.ID, triggerID descpb.TriggerID,
+) (*descpb.TriggerDescriptor, error) {
+	tbl, err := i.checkOutTable(ctx, tableID)
+	if err != nil {
+		return nil, err
+	}
+	trigger := catalog.FindTriggerByID(tbl, triggerID)
+	if trigger == nil {
+		return nil, errors.AssertionFailedf("failed to find trigger %d in table %q (%d)",
+			triggerID, tbl.GetName(), tbl.GetID())
+	}
+	return trigger, nil
+}
@@ -841,6 +841,80 @@ type UpdateFunctionRelationReferences struct {
 	FunctionReferences []descpb.ID
 }
 
+// AddTrigger adds a trigger to a table.
+type AddTrigger struct {
+	immediateMutationOp
+	Trigger scpb.Trigger
+}
+
+// SetTriggerName sets the name of a trigger.
+type SetTriggerName struct {
+	immediateMutationOp
+	Name scpb.TriggerName
+}
+
+// SetTriggerEnabled sets whether a trigger is enabled.
+type SetTriggerEnabled struct {
+	immediateMutationOp
+	Enabled scpb.TriggerEnabled
+}
+
+// SetTriggerTiming sets the action time and granularity of a trigger.
+type SetTriggerTiming struct {
+	immediateMutationOp
+	Timing scpb.TriggerTiming
+}
+
+// SetTriggerEvents sets the events which fire a trigger.
+type SetTriggerEvents struct {
+	immediateMutationOp
+	Events scpb.TriggerEvents
+}
+
+// SetTriggerWhen sets the WHEN condition of a trigger.
+type SetTriggerWhen struct {
+	immediateMutationOp
+	When scpb.TriggerWhen
+}
+
+// SetTriggerFunctionCall sets the function executed by a trigger, along with
+// its arguments.
+type SetTriggerFunctionCall struct {
+	immediateMutationOp
+	FunctionCall scpb.TriggerFunctionCall
+}
+
+// SetTriggerForwardReferences sets the types and routines referenced by the
+// WHEN condition of a trigger.
+type SetTriggerForwardReferences struct {
+	immediateMutationOp
+	Deps scpb.TriggerDeps
+}
+
+// RemoveTrigger removes a trigger from a table.
+type RemoveTrigger struct {
+	immediateMutationOp
+	Trigger scpb.Trigger
+}
+
+// AddTriggerBackReferencesInRoutines adds back-references to a trigger from
+// the routines it references.
+type AddTriggerBackReferencesInRoutines struct {
+	immediateMutationOp
+	BackReferencedTableID   descpb.ID
+	BackReferencedTriggerID descpb.TriggerID
+	RoutineIDs              []descpb.ID
+}
+
+// RemoveTriggerBackReferencesInRoutines removes back-references to a trigger
+// from the routines it references.
+type RemoveTriggerBackReferencesInRoutines struct {
+	immediateMutationOp
+	BackReferencedTableID   descpb.ID
+	BackReferencedTriggerID descpb.TriggerID
+	RoutineIDs              []descpb.ID
+}
+
 type SetObjectParentID struct {
 	immediateMutationOp
 	ObjParent scpb.SchemaChild
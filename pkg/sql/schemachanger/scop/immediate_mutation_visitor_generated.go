@@ -126,6 +126,17 @@ type ImmediateMutationVisitor interface {
 	SetFunctionBody(context.Context, SetFunctionBody) error
 	UpdateFunctionTypeReferences(context.Context, UpdateFunctionTypeReferences) error
 	UpdateFunctionRelationReferences(context.Context, UpdateFunctionRelationReferences) error
+	AddTrigger(context.Context, AddTrigger) error
+	SetTriggerName(context.Context, SetTriggerName) error
+	SetTriggerEnabled(context.Context, SetTriggerEnabled) error
+	SetTriggerTiming(context.Context, SetTriggerTiming) error
+	SetTriggerEvents(context.Context, SetTriggerEvents) error
+	SetTriggerWhen(context.Context, SetTriggerWhen) error
+	SetTriggerFunctionCall(context.Context, SetTriggerFunctionCall) error
+	SetTriggerForwardReferences(context.Context, SetTriggerForwardReferences) error
+	RemoveTrigger(context.Context, RemoveTrigger) error
+	AddTriggerBackReferencesInRoutines(context.Context, AddTriggerBackReferencesInRoutines) error
+	RemoveTriggerBackReferencesInRoutines(context.Context, RemoveTriggerBackReferencesInRoutines) error
 	SetObjectParentID(context.Context, SetObjectParentID) error
 	UpdateUserPrivileges(context.Context, UpdateUserPrivileges) error
 	UpdateOwner(context.Context, UpdateOwner) error
@@ -658,6 +669,61 @@ func (op UpdateFunctionRelationReferences) Visit(ctx context.Context, v Immediat
 	return v.UpdateFunctionRelationReferences(ctx, op)
 }
 
+// Visit is part of the ImmediateMutationOp interface.
+func (op AddTrigger) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.AddTrigger(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op SetTriggerName) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.SetTriggerName(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op SetTriggerEnabled) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.SetTriggerEnabled(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op SetTriggerTiming) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.SetTriggerTiming(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op SetTriggerEvents) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.SetTriggerEvents(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op SetTriggerWhen) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.SetTriggerWhen(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op SetTriggerFunctionCall) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.SetTriggerFunctionCall(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op SetTriggerForwardReferences) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.SetTriggerForwardReferences(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op RemoveTrigger) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.RemoveTrigger(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op AddTriggerBackReferencesInRoutines) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.AddTriggerBackReferencesInRoutines(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op RemoveTriggerBackReferencesInRoutines) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.RemoveTriggerBackReferencesInRoutines(ctx, op)
+}
+
 // Visit is part of the ImmediateMutationOp interface.
 func (op SetObjectParentID) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
 	return v.SetObjectParentID(ctx, op)
@@ -310,6 +310,15 @@ func isData(e scpb.Element) bool {
 	return false
 }
 
+func isTriggerDependent(e scpb.Element) bool {
+	switch e.(type) {
+	case *scpb.TriggerName, *scpb.TriggerEnabled, *scpb.TriggerTiming, *scpb.TriggerEvents,
+		*scpb.TriggerWhen, *scpb.TriggerFunctionCall, *scpb.TriggerDeps:
+		return true
+	}
+	return false
+}
+
 func isDescriptorParentReference(e scpb.Element) bool {
 	switch e.(type) {
 	case *scpb.SchemaChild, *scpb.SchemaParent:
@@ -79,6 +79,8 @@ const (
 	// zone config element. Those two DatabaseZoneConfig nodes in the graph will
 	// have different SeqNum attribute.
 	SeqNum
+	// TriggerID is the ID of a trigger.
+	TriggerID
 
 	// TargetStatus is the target status of an element.
 	TargetStatus
@@ -429,6 +431,42 @@ var elementSchemaOptions = []rel.SchemaOption{
 	rel.EntityMapping(t((*scpb.FunctionBody)(nil)),
 		rel.EntityAttr(DescID, "FunctionID"),
 	),
+	rel.EntityMapping(t((*scpb.Trigger)(nil)),
+		rel.EntityAttr(DescID, "TableID"),
+		rel.EntityAttr(TriggerID, "TriggerID"),
+	),
+	rel.EntityMapping(t((*scpb.TriggerName)(nil)),
+		rel.EntityAttr(DescID, "TableID"),
+		rel.EntityAttr(TriggerID, "TriggerID"),
+		rel.EntityAttr(Name, "Name"),
+	),
+	rel.EntityMapping(t((*scpb.TriggerEnabled)(nil)),
+		rel.EntityAttr(DescID, "TableID"),
+		rel.EntityAttr(TriggerID, "TriggerID"),
+	),
+	rel.EntityMapping(t((*scpb.TriggerTiming)(nil)),
+		rel.EntityAttr(DescID, "TableID"),
+		rel.EntityAttr(TriggerID, "TriggerID"),
+	),
+	rel.EntityMapping(t((*scpb.TriggerEvents)(nil)),
+		rel.EntityAttr(DescID, "TableID"),
+		rel.EntityAttr(TriggerID, "TriggerID"),
+	),
+	rel.EntityMapping(t((*scpb.TriggerWhen)(nil)),
+		rel.EntityAttr(DescID, "TableID"),
+		rel.EntityAttr(TriggerID, "TriggerID"),
+	),
+	rel.EntityMapping(t((*scpb.TriggerFunctionCall)(nil)),
+		rel.EntityAttr(DescID, "TableID"),
+		rel.EntityAttr(TriggerID, "TriggerID"),
+		rel.EntityAttr(ReferencedDescID, "FuncID"),
+	),
+	rel.EntityMapping(t((*scpb.TriggerDeps)(nil)),
+		rel.EntityAttr(DescID, "TableID"),
+		rel.EntityAttr(TriggerID, "TriggerID"),
+		rel.EntityAttr(ReferencedTypeIDs, "UsesTypeIDs"),
+		rel.EntityAttr(ReferencedFunctionIDs, "UsesRoutineIDs"),
+	),
 }
 
 // Schema is the schema exported by this package covering the elements of scpb.
@@ -127,6 +127,9 @@ func VersionSupportsElementUse(el scpb.Element, version clusterversion.ClusterVe
 		return true
 	case *scpb.TypeComment, *scpb.DatabaseZoneConfig:
 		return version.IsActive(clusterversion.V24_2)
+	case *scpb.Trigger, *scpb.TriggerName, *scpb.TriggerEnabled, *scpb.TriggerTiming,
+		*scpb.TriggerEvents, *scpb.TriggerWhen, *scpb.TriggerFunctionCall, *scpb.TriggerDeps:
+		return version.IsActive(clusterversion.V24_2_Triggers)
 	default:
 		panic(errors.AssertionFailedf("unknown element %T", el))
 	}
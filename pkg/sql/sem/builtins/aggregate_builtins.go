@@ -1371,6 +1371,7 @@ const sizeOfDecimalVarPopAggregate = int64(unsafe.Sizeof(decimalVarPopAggregate{
 const sizeOfFloatStdDevAggregate = int64(unsafe.Sizeof(floatStdDevAggregate{}))
 const sizeOfDecimalStdDevAggregate = int64(unsafe.Sizeof(decimalStdDevAggregate{}))
 const sizeOfAnyNotNullAggregate = int64(unsafe.Sizeof(anyNotNullAggregate{}))
+const sizeOfUserDefinedAggregate = int64(unsafe.Sizeof(userDefinedAggregate{}))
 const sizeOfConcatAggregate = int64(unsafe.Sizeof(concatAggregate{}))
 const sizeOfBoolAndAggregate = int64(unsafe.Sizeof(boolAndAggregate{}))
 const sizeOfBoolOrAggregate = int64(unsafe.Sizeof(boolOrAggregate{}))
@@ -1583,6 +1584,128 @@ func (a *anyNotNullAggregate) Size() int64 {
 	return sizeOfAnyNotNullAggregate
 }
 
+// UserDefinedAggregateFunc is a support function of a user-defined aggregate.
+type UserDefinedAggregateFunc interface {
+	// Eval invokes the support function with the given arguments.
+	Eval(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error)
+}
+
+// userDefinedAggregate implements an aggregate created with CREATE AGGREGATE.
+// The state of the aggregate is updated by invoking the state transition
+// function of the aggregate for each input row, and the result is computed by
+// invoking the final function, if any, on the final state.
+type userDefinedAggregate struct {
+	singleDatumAggregateBase
+
+	evalCtx   *eval.Context
+	stateFunc UserDefinedAggregateFunc
+	finalFunc UserDefinedAggregateFunc
+	// strict is true if the state transition function is not called on NULL
+	// input.
+	strict   bool
+	initCond tree.Datum
+
+	state tree.Datum
+	// noState is true if the aggregate has no initial condition and no input
+	// rows have been added yet.
+	noState bool
+	// args is reused to pass the state and the arguments of each input row to
+	// the state transition function.
+	args tree.Datums
+}
+
+var _ eval.AggregateFunc = &userDefinedAggregate{}
+
+// NewUserDefinedAggregate returns an aggregate function for a user-defined
+// aggregate with the given support functions and initial state. finalFunc is
+// nil if the aggregate has no final function, and initCond is NULL if the
+// aggregate has no initial condition. strict indicates whether the state
+// transition function is not called on NULL input.
+func NewUserDefinedAggregate(
+	evalCtx *eval.Context,
+	stateFunc, finalFunc UserDefinedAggregateFunc,
+	strict bool,
+	initCond tree.Datum,
+) eval.AggregateFunc {
+	return &userDefinedAggregate{
+		singleDatumAggregateBase: makeSingleDatumAggregateBase(evalCtx),
+		evalCtx:                  evalCtx,
+		stateFunc:                stateFunc,
+		finalFunc:                finalFunc,
+		strict:                   strict,
+		initCond:                 initCond,
+		state:                    initCond,
+		noState:                  initCond == tree.DNull,
+	}
+}
+
+// Add invokes the state transition function with the current state followed
+// by the given arguments, and stores the result as the new state.
+func (a *userDefinedAggregate) Add(
+	ctx context.Context, firstArg tree.Datum, otherArgs ...tree.Datum,
+) error {
+	if a.strict {
+		// A strict state transition function is not invoked for rows with NULL
+		// arguments. Without an initial condition, the state is initialized from
+		// the first row with non-NULL arguments instead. Once the state becomes
+		// NULL, it stays NULL.
+		if firstArg == tree.DNull {
+			return nil
+		}
+		for _, arg := range otherArgs {
+			if arg == tree.DNull {
+				return nil
+			}
+		}
+		if a.noState {
+			a.noState = false
+			return a.setState(ctx, firstArg)
+		}
+		if a.state == tree.DNull {
+			return nil
+		}
+	}
+	a.args = append(a.args[:0], a.state, firstArg)
+	a.args = append(a.args, otherArgs...)
+	state, err := a.stateFunc.Eval(ctx, a.evalCtx, a.args)
+	if err != nil {
+		return err
+	}
+	return a.setState(ctx, state)
+}
+
+func (a *userDefinedAggregate) setState(ctx context.Context, state tree.Datum) error {
+	a.state = state
+	return a.updateMemoryUsage(ctx, int64(state.Size()))
+}
+
+// Result invokes the final function, if any, on the final state.
+func (a *userDefinedAggregate) Result() (tree.Datum, error) {
+	if a.finalFunc == nil {
+		return a.state, nil
+	}
+	// Result is not passed a context, so the final function is evaluated with
+	// an empty one.
+	return a.finalFunc.Eval(context.Background(), a.evalCtx, tree.Datums{a.state})
+}
+
+// Reset implements eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Reset(ctx context.Context) {
+	a.state = a.initCond
+	a.noState = a.initCond == tree.DNull
+	a.reset(ctx)
+}
+
+// Close is part of the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Close(ctx context.Context) {
+	a.close(ctx)
+}
+
+// Size is part of the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Size() int64 {
+	return sizeOfUserDefinedAggregate
+}
+
 type arrayAggregate struct {
 	arr *tree.DArray
 	// Note that we do not embed singleDatumAggregateBase struct to help with
@@ -52,6 +52,7 @@ const (
 	CategoryMultiRegion         = "Multi-region"
 	CategoryMultiTenancy        = "Multi-tenancy"
 	CategoryPGVector            = "PGVector"
+	CategoryRange               = "Range"
 	CategorySequences           = "Sequence"
 	CategorySpatial             = "Spatial"
 	CategoryString              = "String and byte"
@@ -75,6 +76,10 @@ const (
 	// CreateSchemaTelemetryJobBuiltinName is the name for the builtin that
 	// creates a job that logs SQL schema telemetry.
 	CreateSchemaTelemetryJobBuiltinName = "crdb_internal.create_sql_schema_telemetry_job"
+	// VectorLSHListBuiltinName is the name for the builtin that returns the
+	// list of a vector index to which a vector is assigned by locality-sensitive
+	// hashing.
+	VectorLSHListBuiltinName = "crdb_internal.vector_lsh_list"
 )
 
 // A unique int generated by GenerateUniqueInt is a 64-bit integer with
@@ -298,14 +298,16 @@ var regularBuiltins = map[string]builtinDefinition{
 	// TODO(pmattis): What string functions should also support types.Bytes?
 
 	"lower": makeBuiltin(tree.FunctionProperties{Category: builtinconstants.CategoryString},
-		stringOverload1(
-			func(_ context.Context, _ *eval.Context, s string) (tree.Datum, error) {
-				return tree.NewDString(strings.ToLower(s)), nil
-			},
-			types.String,
-			"Converts all characters in `val` to their lower-case equivalents.",
-			volatility.Immutable,
-		),
+		append([]tree.Overload{
+			stringOverload1(
+				func(_ context.Context, _ *eval.Context, s string) (tree.Datum, error) {
+					return tree.NewDString(strings.ToLower(s)), nil
+				},
+				types.String,
+				"Converts all characters in `val` to their lower-case equivalents.",
+				volatility.Immutable,
+			),
+		}, makeRangeBoundOverloads(true /* lower */)...)...,
 	),
 
 	"unaccent": makeBuiltin(tree.FunctionProperties{Category: builtinconstants.CategoryString},
@@ -329,14 +331,16 @@ var regularBuiltins = map[string]builtinDefinition{
 	),
 
 	"upper": makeBuiltin(tree.FunctionProperties{Category: builtinconstants.CategoryString},
-		stringOverload1(
-			func(_ context.Context, _ *eval.Context, s string) (tree.Datum, error) {
-				return tree.NewDString(strings.ToUpper(s)), nil
-			},
-			types.String,
-			"Converts all characters in `val` to their to their upper-case equivalents.",
-			volatility.Immutable,
-		),
+		append([]tree.Overload{
+			stringOverload1(
+				func(_ context.Context, _ *eval.Context, s string) (tree.Datum, error) {
+					return tree.NewDString(strings.ToUpper(s)), nil
+				},
+				types.String,
+				"Converts all characters in `val` to their to their upper-case equivalents.",
+				volatility.Immutable,
+			),
+		}, makeRangeBoundOverloads(false /* lower */)...)...,
 	),
 
 	"prettify_statement": makeBuiltin(tree.FunctionProperties{Category: builtinconstants.CategoryString},
@@ -3673,9 +3677,34 @@ value if you rely on the HLC for accuracy.`,
 				dimen := int64(tree.MustBeDInt(args[1]))
 				return arrayLength(arr, dimen), nil
 			},
-			Info: "Calculates the length of `input` on the provided `array_dimension`. However, " +
-				"because CockroachDB doesn't yet support multi-dimensional arrays, the only supported" +
-				" `array_dimension` is **1**.",
+			Info:       "Calculates the length of `input` on the provided `array_dimension`.",
+			Volatility: volatility.Immutable,
+		},
+	),
+
+	"array_ndims": makeBuiltin(arrayProps(),
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "input", Typ: types.AnyArray}},
+			ReturnType: tree.FixedReturnType(types.Int),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				arr := tree.MustBeDArray(args[0])
+				return arrayNDims(arr), nil
+			},
+			Info:       "Returns the number of dimensions of `input`, or NULL if it is empty.",
+			Volatility: volatility.Immutable,
+		},
+	),
+
+	"array_dims": makeBuiltin(arrayProps(),
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "input", Typ: types.AnyArray}},
+			ReturnType: tree.FixedReturnType(types.String),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				arr := tree.MustBeDArray(args[0])
+				return arrayDims(arr), nil
+			},
+			Info: "Returns a text representation of the dimensions of `input`, such as " +
+				"`[1:2][1:3]`, or NULL if it is empty.",
 			Volatility: volatility.Immutable,
 		},
 	),
@@ -3702,9 +3731,7 @@ value if you rely on the HLC for accuracy.`,
 				dimen := int64(tree.MustBeDInt(args[1]))
 				return arrayLower(arr, dimen), nil
 			},
-			Info: "Calculates the minimum value of `input` on the provided `array_dimension`. " +
-				"However, because CockroachDB doesn't yet support multi-dimensional arrays, the only " +
-				"supported `array_dimension` is **1**.",
+			Info:       "Calculates the lower bound of `input` on the provided `array_dimension`.",
 			Volatility: volatility.Immutable,
 		},
 	),
@@ -3716,11 +3743,9 @@ value if you rely on the HLC for accuracy.`,
 			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
 				arr := tree.MustBeDArray(args[0])
 				dimen := int64(tree.MustBeDInt(args[1]))
-				return arrayLength(arr, dimen), nil
+				return arrayUpper(arr, dimen), nil
 			},
-			Info: "Calculates the maximum value of `input` on the provided `array_dimension`. " +
-				"However, because CockroachDB doesn't yet support multi-dimensional arrays, the only " +
-				"supported `array_dimension` is **1**.",
+			Info:       "Calculates the upper bound of `input` on the provided `array_dimension`.",
 			Volatility: volatility.Immutable,
 		},
 	),
@@ -5582,6 +5607,43 @@ SELECT
 		},
 	),
 
+	"crdb_internal.assert_domain_constraint": makeBuiltin(
+		tree.FunctionProperties{
+			Category:     builtinconstants.CategorySystemInfo,
+			Undocumented: true,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "value", Typ: types.AnyElement},
+				{Name: "satisfied", Typ: types.Bool},
+				{Name: "errorCode", Typ: types.String},
+				{Name: "msg", Typ: types.String},
+			},
+			ReturnType: tree.IdentityReturnType(0),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				// As with CHECK constraints on tables, a NULL result satisfies the
+				// constraint.
+				if args[1] != tree.DBoolFalse {
+					return args[0], nil
+				}
+				code, ok := tree.AsDString(args[2])
+				if !ok {
+					return nil, errors.Newf("expected string value, got %T", args[2])
+				}
+				msg, ok := tree.AsDString(args[3])
+				if !ok {
+					return nil, errors.Newf("expected string value, got %T", args[3])
+				}
+				return nil, pgerror.Newf(pgcode.MakeCode(string(code)), "%s", string(msg))
+			},
+			Info: "Returns the given value if satisfied is not false, and returns an " +
+				"error with the given code and message otherwise. This function is used " +
+				"internally to enforce the constraints of domain types.",
+			Volatility:        volatility.Immutable,
+			CalledOnNullInput: true,
+		},
+	),
+
 	"crdb_internal.notice": makeBuiltin(
 		tree.FunctionProperties{
 			Category: builtinconstants.CategorySystemInfo,
@@ -9903,9 +9965,8 @@ func arrayBuiltin(impl func(*types.T) tree.Overload, supportsArrayInput bool) bu
 			if supportsArrayInput {
 				arrayTyp := types.MakeArray(typ)
 				overload := impl(arrayTyp)
-				// We currently don't have value encoding for nested arrays, so
-				// we have to disable distributed evaluation for such overloads.
-				overload.DistsqlBlocklist = true
+				// Like above, arrays of untyped tuples can't be used in DistSQL.
+				overload.DistsqlBlocklist = typ.Family() == types.TupleFamily
 				overloads = append(overloads, overload)
 			}
 		}
@@ -10524,44 +10585,51 @@ func GenerateUniqueID(instanceID int32, timestamp uint64) tree.DInt {
 }
 
 func cardinality(arr *tree.DArray) tree.Datum {
-	if arr.ParamTyp.Family() != types.ArrayFamily {
-		return tree.NewDInt(tree.DInt(arr.Len()))
-	}
-	card := 0
-	for _, a := range arr.Array {
-		card += int(tree.MustBeDInt(cardinality(tree.MustBeDArray(a))))
-	}
-	return tree.NewDInt(tree.DInt(card))
+	return tree.NewDInt(tree.DInt(len(arr.ScalarElements())))
 }
 
 func arrayLength(arr *tree.DArray, dim int64) tree.Datum {
-	if arr.Len() == 0 || dim < 1 {
+	dims := arr.Dimensions()
+	if dim < 1 || dim > int64(len(dims)) {
 		return tree.DNull
 	}
-	if dim == 1 {
-		return tree.NewDInt(tree.DInt(arr.Len()))
-	}
-	a, ok := tree.AsDArray(arr.Array[0])
-	if !ok {
+	return tree.NewDInt(tree.DInt(dims[dim-1]))
+}
+
+func arrayLower(arr *tree.DArray, dim int64) tree.Datum {
+	lowerBounds := arr.LowerBounds()
+	if dim < 1 || dim > int64(len(lowerBounds)) {
 		return tree.DNull
 	}
-	return arrayLength(a, dim-1)
+	return tree.NewDInt(tree.DInt(lowerBounds[dim-1]))
 }
 
-var intOne = tree.NewDInt(tree.DInt(1))
-
-func arrayLower(arr *tree.DArray, dim int64) tree.Datum {
-	if arr.Len() == 0 || dim < 1 {
+func arrayUpper(arr *tree.DArray, dim int64) tree.Datum {
+	dims, lowerBounds := arr.Dimensions(), arr.LowerBounds()
+	if dim < 1 || dim > int64(len(dims)) {
 		return tree.DNull
 	}
-	if dim == 1 {
-		return intOne
+	return tree.NewDInt(tree.DInt(lowerBounds[dim-1] + dims[dim-1] - 1))
+}
+
+func arrayNDims(arr *tree.DArray) tree.Datum {
+	dims := arr.Dimensions()
+	if len(dims) == 0 {
+		return tree.DNull
 	}
-	a, ok := tree.AsDArray(arr.Array[0])
-	if !ok {
+	return tree.NewDInt(tree.DInt(len(dims)))
+}
+
+func arrayDims(arr *tree.DArray) tree.Datum {
+	dims, lowerBounds := arr.Dimensions(), arr.LowerBounds()
+	if len(dims) == 0 {
 		return tree.DNull
 	}
-	return arrayLower(a, dim-1)
+	var buf strings.Builder
+	for i := range dims {
+		fmt.Fprintf(&buf, "[%d:%d]", lowerBounds[i], lowerBounds[i]+dims[i]-1)
+	}
+	return tree.NewDString(buf.String())
 }
 
 func extractBuiltin() builtinDefinition {
@@ -1400,7 +1400,7 @@ var builtinOidsArray = []string{
 	1424: `obj_description(object_oid: oid, catalog_name: string) -> string`,
 	1425: `oid(int: int) -> oid`,
 	1426: `shobj_description(object_oid: oid, catalog_name: string) -> string`,
-	1427: `pg_try_advisory_lock(int: int) -> bool`,
+	1427: `pg_try_advisory_lock(key: int) -> bool`,
 	1428: `pg_advisory_unlock(key: int) -> bool`,
 	1429: `pg_client_encoding() -> string`,
 	1430: `pg_function_is_visible(oid: oid) -> bool`,
@@ -2601,6 +2601,110 @@ var builtinOidsArray = []string{
 	2633: `inner_product(v1: vector, v2: vector) -> float`,
 	2634: `vector_dims(vector: vector) -> int`,
 	2635: `vector_norm(vector: vector) -> float`,
+	2636: `pg_notify(channel: string, payload: string) -> void`,
+	2637: `crdb_internal.assert_domain_constraint(value: anyelement, satisfied: bool, errorCode: string, msg: string) -> anyelement`,
+	2638: `crdb_internal.vector_lsh_list(vector: vector, lists: int) -> int`,
+	2639: `pg_try_advisory_lock(key1: int4, key2: int4) -> bool`,
+	2640: `pg_advisory_lock(key: int) -> void`,
+	2641: `pg_advisory_lock(key1: int4, key2: int4) -> void`,
+	2642: `pg_advisory_lock_shared(key: int) -> void`,
+	2643: `pg_advisory_lock_shared(key1: int4, key2: int4) -> void`,
+	2644: `pg_try_advisory_lock_shared(key: int) -> bool`,
+	2645: `pg_try_advisory_lock_shared(key1: int4, key2: int4) -> bool`,
+	2646: `pg_advisory_xact_lock(key: int) -> void`,
+	2647: `pg_advisory_xact_lock(key1: int4, key2: int4) -> void`,
+	2648: `pg_advisory_xact_lock_shared(key: int) -> void`,
+	2649: `pg_advisory_xact_lock_shared(key1: int4, key2: int4) -> void`,
+	2650: `pg_try_advisory_xact_lock(key: int) -> bool`,
+	2651: `pg_try_advisory_xact_lock(key1: int4, key2: int4) -> bool`,
+	2652: `pg_try_advisory_xact_lock_shared(key: int) -> bool`,
+	2653: `pg_try_advisory_xact_lock_shared(key1: int4, key2: int4) -> bool`,
+	2654: `int4range(lower: int4, upper: int4) -> int4range`,
+	2655: `int4range(lower: int4, upper: int4, bounds: string) -> int4range`,
+	2656: `int8range(lower: int, upper: int) -> int8range`,
+	2657: `int8range(lower: int, upper: int, bounds: string) -> int8range`,
+	2658: `numrange(lower: decimal, upper: decimal) -> numrange`,
+	2659: `numrange(lower: decimal, upper: decimal, bounds: string) -> numrange`,
+	2660: `tsrange(lower: timestamp, upper: timestamp) -> tsrange`,
+	2661: `tsrange(lower: timestamp, upper: timestamp, bounds: string) -> tsrange`,
+	2662: `tstzrange(lower: timestamptz, upper: timestamptz) -> tstzrange`,
+	2663: `tstzrange(lower: timestamptz, upper: timestamptz, bounds: string) -> tstzrange`,
+	2664: `daterange(lower: date, upper: date) -> daterange`,
+	2665: `daterange(lower: date, upper: date, bounds: string) -> daterange`,
+	2666: `isempty(range: int4range) -> bool`,
+	2667: `isempty(range: int8range) -> bool`,
+	2668: `isempty(range: numrange) -> bool`,
+	2669: `isempty(range: tsrange) -> bool`,
+	2670: `isempty(range: tstzrange) -> bool`,
+	2671: `isempty(range: daterange) -> bool`,
+	2672: `lower_inc(range: int4range) -> bool`,
+	2673: `lower_inc(range: int8range) -> bool`,
+	2674: `lower_inc(range: numrange) -> bool`,
+	2675: `lower_inc(range: tsrange) -> bool`,
+	2676: `lower_inc(range: tstzrange) -> bool`,
+	2677: `lower_inc(range: daterange) -> bool`,
+	2678: `upper_inc(range: int4range) -> bool`,
+	2679: `upper_inc(range: int8range) -> bool`,
+	2680: `upper_inc(range: numrange) -> bool`,
+	2681: `upper_inc(range: tsrange) -> bool`,
+	2682: `upper_inc(range: tstzrange) -> bool`,
+	2683: `upper_inc(range: daterange) -> bool`,
+	2684: `lower_inf(range: int4range) -> bool`,
+	2685: `lower_inf(range: int8range) -> bool`,
+	2686: `lower_inf(range: numrange) -> bool`,
+	2687: `lower_inf(range: tsrange) -> bool`,
+	2688: `lower_inf(range: tstzrange) -> bool`,
+	2689: `lower_inf(range: daterange) -> bool`,
+	2690: `upper_inf(range: int4range) -> bool`,
+	2691: `upper_inf(range: int8range) -> bool`,
+	2692: `upper_inf(range: numrange) -> bool`,
+	2693: `upper_inf(range: tsrange) -> bool`,
+	2694: `upper_inf(range: tstzrange) -> bool`,
+	2695: `upper_inf(range: daterange) -> bool`,
+	2696: `lower(range: int4range) -> int4`,
+	2697: `lower(range: int8range) -> int`,
+	2698: `lower(range: numrange) -> decimal`,
+	2699: `lower(range: tsrange) -> timestamp`,
+	2700: `lower(range: tstzrange) -> timestamptz`,
+	2701: `lower(range: daterange) -> date`,
+	2702: `upper(range: int4range) -> int4`,
+	2703: `upper(range: int8range) -> int`,
+	2704: `upper(range: numrange) -> decimal`,
+	2705: `upper(range: tsrange) -> timestamp`,
+	2706: `upper(range: tstzrange) -> timestamptz`,
+	2707: `upper(range: daterange) -> date`,
+	2708: `range_merge(range1: int4range, range2: int4range) -> int4range`,
+	2709: `range_merge(range1: int8range, range2: int8range) -> int8range`,
+	2710: `range_merge(range1: numrange, range2: numrange) -> numrange`,
+	2711: `range_merge(range1: tsrange, range2: tsrange) -> tsrange`,
+	2712: `range_merge(range1: tstzrange, range2: tstzrange) -> tstzrange`,
+	2713: `range_merge(range1: daterange, range2: daterange) -> daterange`,
+	2714: `int4rangesend(int4range: int4range) -> bytes`,
+	2715: `int4rangerecv(input: anyelement) -> int4range`,
+	2716: `int4rangeout(int4range: int4range) -> bytes`,
+	2717: `int4rangein(input: anyelement) -> int4range`,
+	2718: `int8rangesend(int8range: int8range) -> bytes`,
+	2719: `int8rangerecv(input: anyelement) -> int8range`,
+	2720: `int8rangeout(int8range: int8range) -> bytes`,
+	2721: `int8rangein(input: anyelement) -> int8range`,
+	2722: `numrangesend(numrange: numrange) -> bytes`,
+	2723: `numrangerecv(input: anyelement) -> numrange`,
+	2724: `numrangeout(numrange: numrange) -> bytes`,
+	2725: `numrangein(input: anyelement) -> numrange`,
+	2726: `tsrangesend(tsrange: tsrange) -> bytes`,
+	2727: `tsrangerecv(input: anyelement) -> tsrange`,
+	2728: `tsrangeout(tsrange: tsrange) -> bytes`,
+	2729: `tsrangein(input: anyelement) -> tsrange`,
+	2730: `tstzrangesend(tstzrange: tstzrange) -> bytes`,
+	2731: `tstzrangerecv(input: anyelement) -> tstzrange`,
+	2732: `tstzrangeout(tstzrange: tstzrange) -> bytes`,
+	2733: `tstzrangein(input: anyelement) -> tstzrange`,
+	2734: `daterangesend(daterange: daterange) -> bytes`,
+	2735: `daterangerecv(input: anyelement) -> daterange`,
+	2736: `daterangeout(daterange: daterange) -> bytes`,
+	2737: `daterangein(input: anyelement) -> daterange`,
+	2738: `array_ndims(input: anyelement[]) -> int`,
+	2739: `array_dims(input: anyelement[]) -> string`,
 }
 
 var builtinOidsBySignature map[string]oid.Oid
@@ -18,6 +18,7 @@ import (
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/advisorylock"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catalogkeys"
 	"github.com/cockroachdb/cockroach/pkg/sql/oidext"
@@ -1171,68 +1172,31 @@ var pgBuiltins = map[string]builtinDefinition{
 		},
 	),
 
-	"pg_try_advisory_lock": makeBuiltin(defProps(),
-		tree.Overload{
-			Types:      tree.ParamTypes{{Name: "int", Typ: types.Int}},
-			ReturnType: tree.FixedReturnType(types.Bool),
-			Fn: func(_ context.Context, _ *eval.Context, _ tree.Datums) (tree.Datum, error) {
-				return tree.DBoolTrue, nil
-			},
-			Info:       notUsableInfo,
-			Volatility: volatility.Volatile,
-		},
-	),
-
-	"pg_advisory_unlock": makeBuiltin(defProps(),
-		tree.Overload{
-			Types:      tree.ParamTypes{{Name: "key", Typ: types.Int}},
-			ReturnType: tree.FixedReturnType(types.Bool),
-			Fn: func(_ context.Context, _ *eval.Context, _ tree.Datums) (tree.Datum, error) {
-				return tree.DBoolTrue, nil
-			},
-			Info:       notUsableInfo,
-			Volatility: volatility.Volatile,
-		},
-		tree.Overload{
-			Types:      tree.ParamTypes{{Name: "key1", Typ: types.Int4}, {Name: "key2", Typ: types.Int4}},
-			ReturnType: tree.FixedReturnType(types.Bool),
-			Fn: func(_ context.Context, _ *eval.Context, _ tree.Datums) (tree.Datum, error) {
-				return tree.DBoolTrue, nil
-			},
-			Info:       notUsableInfo,
-			Volatility: volatility.Volatile,
-		},
-	),
-
-	"pg_advisory_unlock_shared": makeBuiltin(defProps(),
-		tree.Overload{
-			Types:      tree.ParamTypes{{Name: "key", Typ: types.Int}},
-			ReturnType: tree.FixedReturnType(types.Bool),
-			Fn: func(_ context.Context, _ *eval.Context, _ tree.Datums) (tree.Datum, error) {
-				return tree.DBoolTrue, nil
-			},
-			Info:       notUsableInfo,
-			Volatility: volatility.Volatile,
-		},
-		tree.Overload{
-			Types:      tree.ParamTypes{{Name: "key1", Typ: types.Int4}, {Name: "key2", Typ: types.Int4}},
-			ReturnType: tree.FixedReturnType(types.Bool),
-			Fn: func(_ context.Context, _ *eval.Context, _ tree.Datums) (tree.Datum, error) {
-				return tree.DBoolTrue, nil
-			},
-			Info:       notUsableInfo,
-			Volatility: volatility.Volatile,
-		},
-	),
+	// Advisory locks.
+	// https://www.postgresql.org/docs/current/functions-admin.html#FUNCTIONS-ADVISORY-LOCKS
+	"pg_advisory_lock":                 makeAdvisoryLockBuiltin(false /* shared */, false /* xact */, true /* wait */),
+	"pg_advisory_lock_shared":          makeAdvisoryLockBuiltin(true /* shared */, false /* xact */, true /* wait */),
+	"pg_advisory_xact_lock":            makeAdvisoryLockBuiltin(false /* shared */, true /* xact */, true /* wait */),
+	"pg_advisory_xact_lock_shared":     makeAdvisoryLockBuiltin(true /* shared */, true /* xact */, true /* wait */),
+	"pg_try_advisory_lock":             makeAdvisoryLockBuiltin(false /* shared */, false /* xact */, false /* wait */),
+	"pg_try_advisory_lock_shared":      makeAdvisoryLockBuiltin(true /* shared */, false /* xact */, false /* wait */),
+	"pg_try_advisory_xact_lock":        makeAdvisoryLockBuiltin(false /* shared */, true /* xact */, false /* wait */),
+	"pg_try_advisory_xact_lock_shared": makeAdvisoryLockBuiltin(true /* shared */, true /* xact */, false /* wait */),
+	"pg_advisory_unlock":               makeAdvisoryUnlockBuiltin(false /* shared */),
+	"pg_advisory_unlock_shared":        makeAdvisoryUnlockBuiltin(true /* shared */),
 
-	"pg_advisory_unlock_all": makeBuiltin(defProps(),
+	"pg_advisory_unlock_all": makeBuiltin(
+		tree.FunctionProperties{DistsqlBlocklist: true},
 		tree.Overload{
 			Types:      tree.ParamTypes{},
 			ReturnType: tree.FixedReturnType(types.Void),
-			Fn: func(_ context.Context, _ *eval.Context, _ tree.Datums) (tree.Datum, error) {
+			Fn: func(ctx context.Context, evalCtx *eval.Context, _ tree.Datums) (tree.Datum, error) {
+				if err := evalCtx.Planner.ReleaseAllAdvisoryLocks(ctx); err != nil {
+					return nil, err
+				}
 				return tree.DVoidDatum, nil
 			},
-			Info:       notUsableInfo,
+			Info:       "Releases all the session-level advisory locks held by the current session.",
 			Volatility: volatility.Volatile,
 		},
 	),
@@ -1370,6 +1334,34 @@ var pgBuiltins = map[string]builtinDefinition{
 		},
 	),
 
+	"pg_notify": makeBuiltin(
+		tree.FunctionProperties{DistsqlBlocklist: true},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "channel", Typ: types.String}, {Name: "payload", Typ: types.String}},
+			ReturnType: tree.FixedReturnType(types.Void),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				// A NULL channel is rejected as an empty channel name, and a NULL
+				// payload is treated as an empty payload, like in Postgres.
+				var channel, payload string
+				if args[0] != tree.DNull {
+					channel = string(tree.MustBeDString(args[0]))
+				}
+				if args[1] != tree.DNull {
+					payload = string(tree.MustBeDString(args[1]))
+				}
+				if err := evalCtx.Planner.SendNotification(ctx, channel, payload); err != nil {
+					return nil, err
+				}
+				return tree.DVoidDatum, nil
+			},
+			Info: "Sends a notification event with the given payload to the sessions " +
+				"listening on the given channel. The notification is delivered only if " +
+				"the current transaction commits.",
+			Volatility:        volatility.Volatile,
+			CalledOnNullInput: true,
+		},
+	),
+
 	// pg_is_in_recovery returns true if the Postgres database is currently in
 	// recovery.  This is not applicable so this can always return false.
 	// https://www.postgresql.org/docs/current/static/functions-admin.html#FUNCTIONS-RECOVERY-INFO-TABLE
@@ -2601,3 +2593,94 @@ func isAdminOfRole(
 	}
 	return eval.HasNoPrivilege, nil
 }
+
+// advisoryLockKeyOverloads returns the overloads of an advisory lock builtin,
+// which identify the lock by a bigint or by a pair of int4s.
+func advisoryLockKeyOverloads(
+	returnType *types.T,
+	fn func(ctx context.Context, evalCtx *eval.Context, key advisorylock.Key) (tree.Datum, error),
+	info string,
+) []tree.Overload {
+	return []tree.Overload{
+		{
+			Types:      tree.ParamTypes{{Name: "key", Typ: types.Int}},
+			ReturnType: tree.FixedReturnType(returnType),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return fn(ctx, evalCtx, advisorylock.Key{ID: int64(tree.MustBeDInt(args[0]))})
+			},
+			Info:       info,
+			Volatility: volatility.Volatile,
+		},
+		{
+			Types:      tree.ParamTypes{{Name: "key1", Typ: types.Int4}, {Name: "key2", Typ: types.Int4}},
+			ReturnType: tree.FixedReturnType(returnType),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return fn(ctx, evalCtx, advisorylock.MakePairKey(
+					int32(tree.MustBeDInt(args[0])), int32(tree.MustBeDInt(args[1])),
+				))
+			},
+			Info:       info,
+			Volatility: volatility.Volatile,
+		},
+	}
+}
+
+// makeAdvisoryLockBuiltin returns the definition of a builtin which acquires
+// an advisory lock. If wait is false, the builtin returns whether the lock was
+// acquired instead of waiting for it.
+func makeAdvisoryLockBuiltin(shared, xact, wait bool) builtinDefinition {
+	mode := "an exclusive"
+	if shared {
+		mode = "a shared"
+	}
+	scope := "session-level"
+	if xact {
+		scope = "transaction-level"
+	}
+	info := fmt.Sprintf("Obtains %s %s advisory lock, waiting if necessary.", mode, scope)
+	returnType := types.Void
+	if !wait {
+		info = fmt.Sprintf("Obtains %s %s advisory lock if it is available, "+
+			"and returns whether it was obtained.", mode, scope)
+		returnType = types.Bool
+	}
+	return makeBuiltin(
+		tree.FunctionProperties{DistsqlBlocklist: true},
+		advisoryLockKeyOverloads(returnType,
+			func(ctx context.Context, evalCtx *eval.Context, key advisorylock.Key) (tree.Datum, error) {
+				acquired, err := evalCtx.Planner.AcquireAdvisoryLock(ctx, key, shared, xact, wait)
+				if err != nil {
+					return nil, err
+				}
+				if wait {
+					return tree.DVoidDatum, nil
+				}
+				return tree.MakeDBool(tree.DBool(acquired)), nil
+			},
+			info,
+		)...,
+	)
+}
+
+// makeAdvisoryUnlockBuiltin returns the definition of a builtin which releases
+// a session-level advisory lock.
+func makeAdvisoryUnlockBuiltin(shared bool) builtinDefinition {
+	mode := "an exclusive"
+	if shared {
+		mode = "a shared"
+	}
+	return makeBuiltin(
+		tree.FunctionProperties{DistsqlBlocklist: true},
+		advisoryLockKeyOverloads(types.Bool,
+			func(ctx context.Context, evalCtx *eval.Context, key advisorylock.Key) (tree.Datum, error) {
+				released, err := evalCtx.Planner.ReleaseAdvisoryLock(ctx, key, shared)
+				if err != nil {
+					return nil, err
+				}
+				return tree.MakeDBool(tree.DBool(released)), nil
+			},
+			fmt.Sprintf("Releases %s session-level advisory lock previously obtained, "+
+				"and returns whether it was held.", mode),
+		)...,
+	)
+}
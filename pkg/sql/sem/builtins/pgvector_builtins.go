@@ -51,6 +51,29 @@ var pgvectorBuiltins = map[string]builtinDefinition{
 			Volatility: volatility.Immutable,
 		},
 	),
+	builtinconstants.VectorLSHListBuiltinName: makeBuiltin(
+		tree.FunctionProperties{
+			Undocumented: true,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "vector", Typ: types.PGVector},
+				{Name: "lists", Typ: types.Int},
+			},
+			ReturnType: tree.FixedReturnType(types.Int),
+			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				v := tree.MustBeDPGVector(args[0])
+				lists := tree.MustBeDInt(args[1])
+				list, err := vector.LSHList(v.T, int64(lists))
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDInt(tree.DInt(list)), nil
+			},
+			Info:       "Returns the list of a vector index with the given number of lists to which the vector is assigned by random hyperplane locality-sensitive hashing.",
+			Volatility: volatility.Immutable,
+		},
+	),
 	"inner_product": makeBuiltin(defProps(),
 		tree.Overload{
 			Types: tree.ParamTypes{
@@ -0,0 +1,203 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package builtins
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+func init() {
+	for k, v := range rangeBuiltins {
+		v.props.Category = builtinconstants.CategoryRange
+		const enforceClass = true
+		registerBuiltin(k, v, tree.NormalClass, enforceClass)
+	}
+}
+
+var rangeBuiltins = map[string]builtinDefinition{
+	"int4range": makeRangeConstructor(types.Int4Range),
+	"int8range": makeRangeConstructor(types.Int8Range),
+	"numrange":  makeRangeConstructor(types.NumRange),
+	"tsrange":   makeRangeConstructor(types.TSRange),
+	"tstzrange": makeRangeConstructor(types.TSTZRange),
+	"daterange": makeRangeConstructor(types.DateRange),
+
+	"isempty": makeBuiltin(defProps(), makeRangeOverloads(types.Bool,
+		"Returns whether the range is empty.",
+		func(r *tree.DRange) tree.Datum {
+			return tree.MakeDBool(tree.DBool(r.Empty))
+		},
+	)...),
+	"lower_inc": makeBuiltin(defProps(), makeRangeOverloads(types.Bool,
+		"Returns whether the lower bound of the range is inclusive.",
+		func(r *tree.DRange) tree.Datum {
+			return tree.MakeDBool(tree.DBool(r.LowerInc))
+		},
+	)...),
+	"upper_inc": makeBuiltin(defProps(), makeRangeOverloads(types.Bool,
+		"Returns whether the upper bound of the range is inclusive.",
+		func(r *tree.DRange) tree.Datum {
+			return tree.MakeDBool(tree.DBool(r.UpperInc))
+		},
+	)...),
+	"lower_inf": makeBuiltin(defProps(), makeRangeOverloads(types.Bool,
+		"Returns whether the lower bound of the range is infinite.",
+		func(r *tree.DRange) tree.Datum {
+			return tree.MakeDBool(tree.DBool(!r.Empty && r.Lower == nil))
+		},
+	)...),
+	"upper_inf": makeBuiltin(defProps(), makeRangeOverloads(types.Bool,
+		"Returns whether the upper bound of the range is infinite.",
+		func(r *tree.DRange) tree.Datum {
+			return tree.MakeDBool(tree.DBool(!r.Empty && r.Upper == nil))
+		},
+	)...),
+	"range_merge": makeBuiltin(defProps(), makeRangeMergeOverloads()...),
+}
+
+// rangeBoundFlags maps the bounds argument of the range constructors to
+// whether the lower and upper bounds are inclusive.
+var rangeBoundFlags = map[string][2]bool{
+	"[]": {true, true},
+	"[)": {true, false},
+	"(]": {false, true},
+	"()": {false, false},
+}
+
+// makeRangeConstructor returns the definition of the constructor function of
+// the given range type, which has the same name as the type. A NULL bound is
+// infinite.
+func makeRangeConstructor(typ *types.T) builtinDefinition {
+	elem := typ.RangeContents()
+	construct := func(args tree.Datums, bounds string) (tree.Datum, error) {
+		flags, ok := rangeBoundFlags[bounds]
+		if !ok {
+			return nil, errors.WithHint(
+				pgerror.New(pgcode.Syntax, "invalid range bound flags"),
+				`Valid values are "[]", "[)", "(]", and "()".`,
+			)
+		}
+		return tree.NewDRange(typ, args[0], args[1], flags[0], flags[1])
+	}
+	return makeBuiltin(defProps(),
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "lower", Typ: elem},
+				{Name: "upper", Typ: elem},
+			},
+			ReturnType:        tree.FixedReturnType(typ),
+			CalledOnNullInput: true,
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return construct(args, "[)")
+			},
+			Info: fmt.Sprintf("Constructs a %s with an inclusive lower and an exclusive upper bound. "+
+				"A NULL bound is infinite.", typ.Name()),
+			Volatility: volatility.Immutable,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "lower", Typ: elem},
+				{Name: "upper", Typ: elem},
+				{Name: "bounds", Typ: types.String},
+			},
+			ReturnType:        tree.FixedReturnType(typ),
+			CalledOnNullInput: true,
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				if args[2] == tree.DNull {
+					return nil, pgerror.New(pgcode.DataException,
+						"range constructor flags argument must not be null")
+				}
+				return construct(args, string(tree.MustBeDString(args[2])))
+			},
+			Info: fmt.Sprintf("Constructs a %s with the given bounds, where `bounds` is one of "+
+				"'[]', '[)', '(]' and '()'. A NULL bound is infinite.", typ.Name()),
+			Volatility: volatility.Immutable,
+		},
+	)
+}
+
+// makeRangeOverloads returns the overload of a function of a single range,
+// which accepts any range type, including user-defined ones.
+func makeRangeOverloads(
+	returnType *types.T, info string, f func(r *tree.DRange) tree.Datum,
+) []tree.Overload {
+	return []tree.Overload{{
+		Types:      tree.ParamTypes{{Name: "range", Typ: types.AnyRange}},
+		ReturnType: tree.FixedReturnType(returnType),
+		Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+			return f(tree.MustBeDRange(args[0])), nil
+		},
+		Info:       info,
+		Volatility: volatility.Immutable,
+	}}
+}
+
+// makeRangeBoundOverloads returns the overload of the lower and upper
+// functions, which return a bound of a range of any range type.
+func makeRangeBoundOverloads(lower bool) []tree.Overload {
+	info := "Returns the upper bound of the range, or NULL if the range is empty or the bound is infinite."
+	if lower {
+		info = "Returns the lower bound of the range, or NULL if the range is empty or the bound is infinite."
+	}
+	return []tree.Overload{{
+		Types: tree.ParamTypes{{Name: "range", Typ: types.AnyRange}},
+		ReturnType: func(args []tree.TypedExpr) *types.T {
+			if len(args) == 0 || args[0].ResolvedType().Family() != types.RangeFamily {
+				return tree.UnknownReturnType
+			}
+			return args[0].ResolvedType().RangeContents()
+		},
+		Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+			r := tree.MustBeDRange(args[0])
+			bound := r.Upper
+			if lower {
+				bound = r.Lower
+			}
+			if bound == nil {
+				return tree.DNull, nil
+			}
+			return bound, nil
+		},
+		Info:       info,
+		Volatility: volatility.Immutable,
+	}}
+}
+
+func makeRangeMergeOverloads() []tree.Overload {
+	return []tree.Overload{{
+		Types: tree.ParamTypes{
+			{Name: "range1", Typ: types.AnyRange},
+			{Name: "range2", Typ: types.AnyRange},
+		},
+		ReturnType: tree.FirstNonNullReturnType(),
+		Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+			left, right := tree.MustBeDRange(args[0]), tree.MustBeDRange(args[1])
+			if !left.ResolvedType().Equivalent(right.ResolvedType()) {
+				return nil, pgerror.Newf(pgcode.DatatypeMismatch,
+					"range_merge arguments must be of the same range type, got %s and %s",
+					left.ResolvedType().SQLString(), right.ResolvedType().SQLString())
+			}
+			return left.Merge(right)
+		},
+		Info:       "Returns the smallest range that contains both ranges.",
+		Volatility: volatility.Immutable,
+	}}
+}
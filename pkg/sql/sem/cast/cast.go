@@ -201,6 +201,14 @@ func OIDInCastMap(src, tgt oid.Oid) bool {
 // LookupCast returns a cast that describes the cast from src to tgt if it
 // exists. If it does not exist, ok=false is returned.
 func LookupCast(src, tgt *types.T) (Cast, bool) {
+	// Casts to and from domain types behave like casts to and from their base
+	// types. The constraints of a domain are enforced separately.
+	if base := src.DomainBaseType(); base != nil {
+		src = base
+	}
+	if base := tgt.DomainBaseType(); base != nil {
+		tgt = base
+	}
 	srcFamily := src.Family()
 	tgtFamily := tgt.Family()
 
@@ -265,6 +273,22 @@ func LookupCast(src, tgt *types.T) (Cast, bool) {
 		}, true
 	}
 
+	// Casts between range types and string types are stable, since the
+	// formatting and parsing of the range bounds may depend on the session, and
+	// are allowed in assignment and explicit contexts respectively.
+	if srcFamily == types.RangeFamily && tgtFamily == types.StringFamily {
+		return Cast{
+			MaxContext: ContextAssignment,
+			Volatility: volatility.Stable,
+		}, true
+	}
+	if srcFamily == types.StringFamily && tgtFamily == types.RangeFamily {
+		return Cast{
+			MaxContext: ContextExplicit,
+			Volatility: volatility.Stable,
+		}, true
+	}
+
 	// Casts from array and tuple types to string types are immutable and
 	// allowed in assignment contexts.
 	// TODO(mgartner): Tuple to string casts should be stable. They are
@@ -106,6 +106,9 @@ const (
 	MVCCStatistics                         SystemTableName = "mvcc_statistics"
 	StmtExecInsightsTableName              SystemTableName = "statement_execution_insights"
 	TxnExecInsightsTableName               SystemTableName = "transaction_execution_insights"
+	NotificationsTableName                 SystemTableName = "notifications"
+	PublicationsTableName                  SystemTableName = "publications"
+	ReplicationSlotsTableName              SystemTableName = "replication_slots"
 )
 
 // Oid for virtual database and table.
@@ -1212,6 +1212,60 @@ func (e *evaluator) EvalOverlapsINetOp(
 	return tree.MakeDBool(tree.DBool(ipAddr.ContainsOrContainedBy(&other))), nil
 }
 
+func (e *evaluator) EvalOverlapsRangeOp(
+	ctx context.Context, _ *tree.OverlapsRangeOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	return tree.MakeDBool(tree.DBool(tree.MustBeDRange(left).Overlaps(tree.MustBeDRange(right)))), nil
+}
+
+func (e *evaluator) EvalAdjacentRangeOp(
+	ctx context.Context, _ *tree.AdjacentRangeOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	return tree.MakeDBool(tree.DBool(tree.MustBeDRange(left).Adjacent(tree.MustBeDRange(right)))), nil
+}
+
+func (e *evaluator) EvalContainsRangeOp(
+	ctx context.Context, _ *tree.ContainsRangeOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	return tree.MakeDBool(tree.DBool(tree.MustBeDRange(left).Contains(tree.MustBeDRange(right)))), nil
+}
+
+func (e *evaluator) EvalContainsRangeElemOp(
+	ctx context.Context, _ *tree.ContainsRangeElemOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	return tree.MakeDBool(tree.DBool(tree.MustBeDRange(left).ContainsElem(right))), nil
+}
+
+func (e *evaluator) EvalContainedByRangeOp(
+	ctx context.Context, _ *tree.ContainedByRangeOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	return tree.MakeDBool(tree.DBool(tree.MustBeDRange(right).Contains(tree.MustBeDRange(left)))), nil
+}
+
+func (e *evaluator) EvalContainedByElemRangeOp(
+	ctx context.Context, _ *tree.ContainedByElemRangeOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	return tree.MakeDBool(tree.DBool(tree.MustBeDRange(right).ContainsElem(left))), nil
+}
+
+func (e *evaluator) EvalPlusRangeOp(
+	ctx context.Context, _ *tree.PlusRangeOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	return tree.MustBeDRange(left).Union(tree.MustBeDRange(right))
+}
+
+func (e *evaluator) EvalMultRangeOp(
+	ctx context.Context, _ *tree.MultRangeOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	return tree.MustBeDRange(left).Intersect(tree.MustBeDRange(right))
+}
+
+func (e *evaluator) EvalMinusRangeOp(
+	ctx context.Context, _ *tree.MinusRangeOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	return tree.MustBeDRange(left).Difference(tree.MustBeDRange(right))
+}
+
 func (e *evaluator) EvalTSMatchesQueryVectorOp(
 	ctx context.Context, _ *tree.TSMatchesQueryVectorOp, left, right tree.Datum,
 ) (tree.Datum, error) {
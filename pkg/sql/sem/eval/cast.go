@@ -102,6 +102,11 @@ func PerformAssignmentCast(
 func performCast(
 	ctx context.Context, evalCtx *Context, d tree.Datum, t *types.T, truncateWidth bool,
 ) (tree.Datum, error) {
+	// Values of domain types are represented by datums of their base type. The
+	// constraints of the domain are checked by the optimizer.
+	if base := t.DomainBaseType(); base != nil {
+		t = base
+	}
 	d, err := performCastWithoutPrecisionTruncation(ctx, evalCtx, d, t, truncateWidth)
 	if err != nil {
 		return nil, err
@@ -461,7 +466,7 @@ func performCastWithoutPrecisionTruncation(
 				tree.FmtDataConversionConfig(evalCtx.SessionData().DataConversionConfig),
 				tree.FmtLocation(evalCtx.GetLocation()),
 			)
-		case *tree.DArray:
+		case *tree.DArray, *tree.DRange:
 			s = tree.AsStringWithFlags(
 				d,
 				tree.FmtPgwireText,
@@ -964,6 +969,11 @@ func performCastWithoutPrecisionTruncation(
 					return nil, err
 				}
 			}
+			if !v.HasDefaultLowerBounds() {
+				// The lower bounds of inner dimensions are preserved by the casts
+				// of the elements.
+				dcast.SetLowerBound(v.LowerBound())
+			}
 			return dcast, nil
 		case *tree.DPGVector:
 			dcast := tree.NewDArray(t.ArrayContents())
@@ -1010,6 +1020,19 @@ func performCastWithoutPrecisionTruncation(
 			res, _, err := tree.ParseDTupleFromString(evalCtx, string(*v), t)
 			return res, err
 		}
+	case types.RangeFamily:
+		switch v := d.(type) {
+		case *tree.DString:
+			res, _, err := tree.ParseDRangeFromString(evalCtx, string(*v), t)
+			return res, err
+		case *tree.DCollatedString:
+			res, _, err := tree.ParseDRangeFromString(evalCtx, v.Contents, t)
+			return res, err
+		case *tree.DRange:
+			if v.ResolvedType().Oid() == t.Oid() {
+				return v, nil
+			}
+		}
 	case types.VoidFamily:
 		switch d.(type) {
 		case *tree.DString:
@@ -0,0 +1,97 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package eval
+
+// ConstraintMode is the check mode of deferrable constraints set by SET
+// CONSTRAINTS.
+type ConstraintMode int8
+
+const (
+	// ConstraintModeDefault means that a constraint is checked according to
+	// its INITIALLY IMMEDIATE or INITIALLY DEFERRED declaration.
+	ConstraintModeDefault ConstraintMode = iota
+	// ConstraintModeImmediate means that a constraint is checked at the end of
+	// each statement.
+	ConstraintModeImmediate
+	// ConstraintModeDeferred means that a constraint is checked when the
+	// transaction commits.
+	ConstraintModeDeferred
+)
+
+// ConstraintModes holds the check modes of the deferrable constraints in the
+// current transaction.
+type ConstraintModes struct {
+	// ExplicitTxn is true if the current transaction is explicit. Constraint
+	// checks are never deferred in implicit transactions, since they commit at
+	// the end of the statement anyway.
+	ExplicitTxn bool
+
+	// All is the mode set by the last SET CONSTRAINTS ALL.
+	All ConstraintMode
+
+	// Named contains the modes set for individual constraints since the last
+	// SET CONSTRAINTS ALL, keyed by constraint name. The map is never modified
+	// in place, so ConstraintModes can be copied by value.
+	Named map[string]ConstraintMode
+}
+
+// IsDeferred returns true if the checks of a deferrable constraint with the
+// given name are deferred until the transaction commits.
+func (m *ConstraintModes) IsDeferred(name string, initiallyDeferred bool) bool {
+	if !m.ExplicitTxn {
+		return false
+	}
+	mode, ok := m.Named[name]
+	if !ok {
+		mode = m.All
+	}
+	switch mode {
+	case ConstraintModeImmediate:
+		return false
+	case ConstraintModeDeferred:
+		return true
+	default:
+		return initiallyDeferred
+	}
+}
+
+// Set returns the modes resulting from a SET CONSTRAINTS statement which sets
+// the given mode for the named constraints, or for all constraints if names is
+// empty.
+func (m ConstraintModes) Set(names []string, mode ConstraintMode) ConstraintModes {
+	if len(names) == 0 {
+		m.All = mode
+		m.Named = nil
+		return m
+	}
+	named := make(map[string]ConstraintMode, len(m.Named)+len(names))
+	for name, namedMode := range m.Named {
+		named[name] = namedMode
+	}
+	for _, name := range names {
+		named[name] = mode
+	}
+	m.Named = named
+	return m
+}
+
+// Equal returns true if the two sets of modes defer the same constraints.
+func (m *ConstraintModes) Equal(other *ConstraintModes) bool {
+	if m.ExplicitTxn != other.ExplicitTxn || m.All != other.All || len(m.Named) != len(other.Named) {
+		return false
+	}
+	for name, mode := range m.Named {
+		if otherMode, ok := other.Named[name]; !ok || mode != otherMode {
+			return false
+		}
+	}
+	return true
+}
@@ -85,6 +85,9 @@ type Context struct {
 	TxnIsSingleStmt bool
 	// TxnIsoLevel is the isolation level of the current transaction.
 	TxnIsoLevel isolation.Level
+	// ConstraintModes holds the SET CONSTRAINTS modes of the current
+	// transaction.
+	ConstraintModes ConstraintModes
 
 	Settings *cluster.Settings
 	// ClusterID is the logical cluster ID for this tenant.
@@ -18,6 +18,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/advisorylock"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
@@ -451,6 +452,27 @@ type Planner interface {
 	InsertTemporarySchema(
 		tempSchemaName string, databaseID descpb.ID, schemaID descpb.ID,
 	)
+
+	// SendNotification sends a notification on the given channel as part of
+	// the current transaction. It is used to implement the pg_notify builtin.
+	SendNotification(ctx context.Context, channel, payload string) error
+
+	// AcquireAdvisoryLock acquires the advisory lock with the given key in the
+	// current database, in shared or exclusive mode. The lock is held until the
+	// end of the current transaction if xact is true, and until it is released
+	// or the session ends otherwise. If wait is false, it returns false instead
+	// of waiting for a conflicting lock to be released.
+	AcquireAdvisoryLock(
+		ctx context.Context, key advisorylock.Key, shared, xact, wait bool,
+	) (bool, error)
+
+	// ReleaseAdvisoryLock releases a session-level advisory lock held by the
+	// session once. It returns false if the session does not hold the lock.
+	ReleaseAdvisoryLock(ctx context.Context, key advisorylock.Key, shared bool) (bool, error)
+
+	// ReleaseAllAdvisoryLocks releases all the session-level advisory locks
+	// held by the session.
+	ReleaseAllAdvisoryLocks(ctx context.Context) error
 }
 
 // InternalRows is an iterator interface that's exposed by the internal
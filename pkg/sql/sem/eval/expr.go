@@ -303,8 +303,10 @@ func (e *evaluator) EvalIndirectionExpr(
 
 	switch d.ResolvedType().Family() {
 	case types.ArrayFamily:
-		for i, t := range expr.Indirection {
-			if t.Slice || i > 0 {
+		// Each subscript indexes into one dimension of a, possibly
+		// multi-dimensional, array.
+		for _, t := range expr.Indirection {
+			if t.Slice {
 				return nil, errors.AssertionFailedf("unsupported feature should have been rejected during planning")
 			}
 
@@ -312,23 +314,21 @@ func (e *evaluator) EvalIndirectionExpr(
 			if err != nil {
 				return nil, err
 			}
-			if beginDatum == tree.DNull {
+			if beginDatum == tree.DNull || d == tree.DNull {
 				return tree.DNull, nil
 			}
 			subscriptIdx = int(tree.MustBeDInt(beginDatum))
-		}
-
-		// Index into the DArray, using 1-indexing.
-		arr := tree.MustBeDArray(d)
 
-		// VECTOR types use 0-indexing.
-		if arr.FirstIndex() == 0 {
-			subscriptIdx++
-		}
-		if subscriptIdx < 1 || subscriptIdx > arr.Len() {
-			return tree.DNull, nil
+			// Index into the DArray relative to its lower bound, which is 1 by
+			// default, and 0 for VECTOR types.
+			arr := tree.MustBeDArray(d)
+			idx := subscriptIdx - arr.LowerBound()
+			if idx < 0 || idx >= arr.Len() {
+				return tree.DNull, nil
+			}
+			d = arr.Array[idx]
 		}
-		return arr.Array[subscriptIdx-1], nil
+		return d, nil
 	case types.JsonFamily:
 		j := tree.MustBeDJSON(d)
 		curr := j.JSON
@@ -190,11 +190,20 @@ type CursorDeclaration struct {
 	StatementImpl
 	Name   Variable
 	Scroll tree.CursorScrollOption
+	Args   []CursorArg
 	Query  tree.Statement
 }
 
+// CursorArg is an argument of a bound cursor. The argument is visible to the
+// cursor query, and its value is supplied when the cursor is opened.
+type CursorArg struct {
+	Name Variable
+	Typ  tree.ResolvableTypeReference
+}
+
 func (s *CursorDeclaration) CopyNode() *CursorDeclaration {
 	copyNode := *s
+	copyNode.Args = append([]CursorArg(nil), copyNode.Args...)
 	return &copyNode
 }
 
@@ -206,7 +215,20 @@ func (s *CursorDeclaration) Format(ctx *tree.FmtCtx) {
 	case tree.NoScroll:
 		ctx.WriteString(" NO SCROLL")
 	}
-	ctx.WriteString(" CURSOR FOR ")
+	ctx.WriteString(" CURSOR")
+	if s.Args != nil {
+		ctx.WriteString(" (")
+		for i := range s.Args {
+			if i > 0 {
+				ctx.WriteString(", ")
+			}
+			ctx.FormatNode(&s.Args[i].Name)
+			ctx.WriteString(" ")
+			ctx.FormatTypeReference(s.Args[i].Typ)
+		}
+		ctx.WriteString(")")
+	}
+	ctx.WriteString(" FOR ")
 	ctx.FormatNode(s.Query)
 	ctx.WriteString(";\n")
 }
@@ -220,6 +242,44 @@ func (s *CursorDeclaration) WalkStmt(visitor StatementVisitor) Statement {
 	return newStmt
 }
 
+// decl_alias
+type AliasDeclaration struct {
+	StatementImpl
+	Var Variable
+	// Target is the name of the aliased variable or parameter. It is unset if
+	// the alias refers to a parameter by position.
+	Target Variable
+	// TargetParam is the 1-based ordinal of the aliased parameter, or zero if
+	// the alias refers to a name.
+	TargetParam int
+}
+
+func (s *AliasDeclaration) CopyNode() *AliasDeclaration {
+	copyNode := *s
+	return &copyNode
+}
+
+func (s *AliasDeclaration) Format(ctx *tree.FmtCtx) {
+	ctx.FormatNode(&s.Var)
+	ctx.WriteString(" ALIAS FOR ")
+	if s.TargetParam != 0 {
+		ctx.WriteByte('$')
+		ctx.WriteString(strconv.Itoa(s.TargetParam))
+	} else {
+		ctx.FormatNode(&s.Target)
+	}
+	ctx.WriteString(";\n")
+}
+
+func (s *AliasDeclaration) PlpgSQLStatementTag() string {
+	return "decl_alias"
+}
+
+func (s *AliasDeclaration) WalkStmt(visitor StatementVisitor) Statement {
+	newStmt, _ := visitor.Visit(s)
+	return newStmt
+}
+
 // stmt_assign
 type Assignment struct {
 	StatementImpl
@@ -606,15 +666,49 @@ func (s *While) WalkStmt(visitor StatementVisitor) Statement {
 type ForInt struct {
 	StatementImpl
 	Label   string
-	Var     Variable
+	Target  Variable
 	Lower   Expr
 	Upper   Expr
 	Step    Expr
-	Reverse int
+	Reverse bool
 	Body    []Statement
 }
 
+func (s *ForInt) CopyNode() *ForInt {
+	copyNode := *s
+	copyNode.Body = append([]Statement(nil), copyNode.Body...)
+	return &copyNode
+}
+
 func (s *ForInt) Format(ctx *tree.FmtCtx) {
+	if s.Label != "" {
+		ctx.WriteString("<<")
+		ctx.FormatNameP(&s.Label)
+		ctx.WriteString(">>\n")
+	}
+	ctx.WriteString("FOR ")
+	ctx.FormatNode(&s.Target)
+	ctx.WriteString(" IN ")
+	if s.Reverse {
+		ctx.WriteString("REVERSE ")
+	}
+	ctx.FormatNode(s.Lower)
+	ctx.WriteString("..")
+	ctx.FormatNode(s.Upper)
+	if s.Step != nil {
+		ctx.WriteString(" BY ")
+		ctx.FormatNode(s.Step)
+	}
+	ctx.WriteString(" LOOP\n")
+	for _, stmt := range s.Body {
+		ctx.FormatNode(stmt)
+	}
+	ctx.WriteString("END LOOP")
+	if s.Label != "" {
+		ctx.WriteString(" ")
+		ctx.FormatNameP(&s.Label)
+	}
+	ctx.WriteString(";\n")
 }
 
 func (s *ForInt) PlpgSQLStatementTag() string {
@@ -622,14 +716,27 @@ func (s *ForInt) PlpgSQLStatementTag() string {
 }
 
 func (s *ForInt) WalkStmt(visitor StatementVisitor) Statement {
-	panic(unimplemented.New("plpgsql visitor", "Unimplemented PLpgSQL visitor pattern"))
+	newStmt, recurse := visitor.Visit(s)
+
+	if recurse {
+		for i, bodyStmt := range s.Body {
+			newBodyStmt := bodyStmt.WalkStmt(visitor)
+			if newBodyStmt != bodyStmt {
+				if newStmt == s {
+					newStmt = s.CopyNode()
+				}
+				newStmt.(*ForInt).Body[i] = newBodyStmt
+			}
+		}
+	}
+	return newStmt
 }
 
 type ForQuery struct {
 	StatementImpl
-	Label string
-	Var   Variable
-	Body  []Statement
+	Label  string
+	Target []Variable
+	Body   []Statement
 }
 
 func (s *ForQuery) Format(ctx *tree.FmtCtx) {
@@ -645,10 +752,41 @@ func (s *ForQuery) WalkStmt(visitor StatementVisitor) Statement {
 
 type ForSelect struct {
 	ForQuery
-	Query Expr
+	Query tree.Statement
+}
+
+func (s *ForSelect) CopyNode() *ForSelect {
+	copyNode := *s
+	copyNode.Target = append([]Variable(nil), copyNode.Target...)
+	copyNode.Body = append([]Statement(nil), copyNode.Body...)
+	return &copyNode
 }
 
 func (s *ForSelect) Format(ctx *tree.FmtCtx) {
+	if s.Label != "" {
+		ctx.WriteString("<<")
+		ctx.FormatNameP(&s.Label)
+		ctx.WriteString(">>\n")
+	}
+	ctx.WriteString("FOR ")
+	for i := range s.Target {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(&s.Target[i])
+	}
+	ctx.WriteString(" IN ")
+	ctx.FormatNode(s.Query)
+	ctx.WriteString(" LOOP\n")
+	for _, stmt := range s.Body {
+		ctx.FormatNode(stmt)
+	}
+	ctx.WriteString("END LOOP")
+	if s.Label != "" {
+		ctx.WriteString(" ")
+		ctx.FormatNameP(&s.Label)
+	}
+	ctx.WriteString(";\n")
 }
 
 func (s *ForSelect) PlpgSQLStatementTag() string {
@@ -656,7 +794,20 @@ func (s *ForSelect) PlpgSQLStatementTag() string {
 }
 
 func (s *ForSelect) WalkStmt(visitor StatementVisitor) Statement {
-	panic(unimplemented.New("plpgsql visitor", "Unimplemented PLpgSQL visitor pattern"))
+	newStmt, recurse := visitor.Visit(s)
+
+	if recurse {
+		for i, bodyStmt := range s.Body {
+			newBodyStmt := bodyStmt.WalkStmt(visitor)
+			if newBodyStmt != bodyStmt {
+				if newStmt == s {
+					newStmt = s.CopyNode()
+				}
+				newStmt.(*ForSelect).Body[i] = newBodyStmt
+			}
+		}
+	}
+	return newStmt
 }
 
 type ForCursor struct {
@@ -696,14 +847,51 @@ func (s *ForDynamic) WalkStmt(visitor StatementVisitor) Statement {
 // stmt_foreach_a
 type ForEachArray struct {
 	StatementImpl
-	Label string
-	Var   *Variable
-	Slice int // TODO(drewk): not sure what this is
+	Label  string
+	Target []Variable
+	// Slice is the number of array dimensions that are assigned to the target
+	// on each iteration. It is zero if the loop iterates over single elements.
+	Slice int
 	Expr  Expr
 	Body  []Statement
 }
 
+func (s *ForEachArray) CopyNode() *ForEachArray {
+	copyNode := *s
+	copyNode.Target = append([]Variable(nil), copyNode.Target...)
+	copyNode.Body = append([]Statement(nil), copyNode.Body...)
+	return &copyNode
+}
+
 func (s *ForEachArray) Format(ctx *tree.FmtCtx) {
+	if s.Label != "" {
+		ctx.WriteString("<<")
+		ctx.FormatNameP(&s.Label)
+		ctx.WriteString(">>\n")
+	}
+	ctx.WriteString("FOREACH ")
+	for i := range s.Target {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(&s.Target[i])
+	}
+	if s.Slice != 0 {
+		ctx.WriteString(" SLICE ")
+		ctx.WriteString(strconv.Itoa(s.Slice))
+	}
+	ctx.WriteString(" IN ARRAY ")
+	ctx.FormatNode(s.Expr)
+	ctx.WriteString(" LOOP\n")
+	for _, stmt := range s.Body {
+		ctx.FormatNode(stmt)
+	}
+	ctx.WriteString("END LOOP")
+	if s.Label != "" {
+		ctx.WriteString(" ")
+		ctx.FormatNameP(&s.Label)
+	}
+	ctx.WriteString(";\n")
 }
 
 func (s *ForEachArray) PlpgSQLStatementTag() string {
@@ -711,7 +899,20 @@ func (s *ForEachArray) PlpgSQLStatementTag() string {
 }
 
 func (s *ForEachArray) WalkStmt(visitor StatementVisitor) Statement {
-	panic(unimplemented.New("plpgsql visitor", "Unimplemented PLpgSQL visitor pattern"))
+	newStmt, recurse := visitor.Visit(s)
+
+	if recurse {
+		for i, bodyStmt := range s.Body {
+			newBodyStmt := bodyStmt.WalkStmt(visitor)
+			if newBodyStmt != bodyStmt {
+				if newStmt == s {
+					newStmt = s.CopyNode()
+				}
+				newStmt.(*ForEachArray).Body[i] = newBodyStmt
+			}
+		}
+	}
+	return newStmt
 }
 
 // stmt_exit
@@ -812,13 +1013,24 @@ func (s *Return) WalkStmt(visitor StatementVisitor) Statement {
 	return newStmt
 }
 
+// stmt_return_next
 type ReturnNext struct {
 	StatementImpl
-	Expr   Expr
-	RetVar Variable
+	Expr Expr
+}
+
+func (s *ReturnNext) CopyNode() *ReturnNext {
+	copyNode := *s
+	return &copyNode
 }
 
 func (s *ReturnNext) Format(ctx *tree.FmtCtx) {
+	ctx.WriteString("RETURN NEXT")
+	if s.Expr != nil {
+		ctx.WriteByte(' ')
+		ctx.FormatNode(s.Expr)
+	}
+	ctx.WriteString(";\n")
 }
 
 func (s *ReturnNext) PlpgSQLStatementTag() string {
@@ -826,17 +1038,25 @@ func (s *ReturnNext) PlpgSQLStatementTag() string {
 }
 
 func (s *ReturnNext) WalkStmt(visitor StatementVisitor) Statement {
-	panic(unimplemented.New("plpgsql visitor", "Unimplemented PLpgSQL visitor pattern"))
+	newStmt, _ := visitor.Visit(s)
+	return newStmt
 }
 
+// stmt_return_query
 type ReturnQuery struct {
 	StatementImpl
-	Query        Expr
-	DynamicQuery Expr
-	Params       []Expr
+	Query tree.Statement
+}
+
+func (s *ReturnQuery) CopyNode() *ReturnQuery {
+	copyNode := *s
+	return &copyNode
 }
 
 func (s *ReturnQuery) Format(ctx *tree.FmtCtx) {
+	ctx.WriteString("RETURN QUERY ")
+	ctx.FormatNode(s.Query)
+	ctx.WriteString(";\n")
 }
 
 func (s *ReturnQuery) PlpgSQLStatementTag() string {
@@ -844,7 +1064,8 @@ func (s *ReturnQuery) PlpgSQLStatementTag() string {
 }
 
 func (s *ReturnQuery) WalkStmt(visitor StatementVisitor) Statement {
-	panic(unimplemented.New("plpgsql visitor", "Unimplemented PLpgSQL visitor pattern"))
+	newStmt, _ := visitor.Visit(s)
+	return newStmt
 }
 
 // stmt_raise
@@ -1027,10 +1248,25 @@ func (s *DynamicExecute) WalkStmt(visitor StatementVisitor) Statement {
 // stmt_perform
 type Perform struct {
 	StatementImpl
-	Expr Expr
+	// Query is the SELECT statement that is executed in place of the PERFORM.
+	// Its result is discarded.
+	Query *tree.Select
+}
+
+func (s *Perform) CopyNode() *Perform {
+	copyNode := *s
+	return &copyNode
 }
 
 func (s *Perform) Format(ctx *tree.FmtCtx) {
+	// The PERFORM keyword replaces the leading SELECT keyword of the query.
+	start := ctx.Len()
+	ctx.FormatNode(s.Query)
+	query := strings.TrimPrefix(ctx.String()[start:], "SELECT ")
+	ctx.Truncate(start)
+	ctx.WriteString("PERFORM ")
+	ctx.WriteString(query)
+	ctx.WriteString(";\n")
 }
 
 func (s *Perform) PlpgSQLStatementTag() string {
@@ -1038,7 +1274,8 @@ func (s *Perform) PlpgSQLStatementTag() string {
 }
 
 func (s *Perform) WalkStmt(visitor StatementVisitor) Statement {
-	panic(unimplemented.New("plpgsql visitor", "Unimplemented PLpgSQL visitor pattern"))
+	newStmt, _ := visitor.Visit(s)
+	return newStmt
 }
 
 // stmt_call
@@ -1116,17 +1353,30 @@ type Open struct {
 	StatementImpl
 	CurVar Variable
 	Scroll tree.CursorScrollOption
-	Query  tree.Statement
+	// Args are the values supplied for the arguments of a bound cursor.
+	Args  []Expr
+	Query tree.Statement
 }
 
 func (s *Open) CopyNode() *Open {
 	copyNode := *s
+	copyNode.Args = append([]Expr(nil), copyNode.Args...)
 	return &copyNode
 }
 
 func (s *Open) Format(ctx *tree.FmtCtx) {
 	ctx.WriteString("OPEN ")
 	ctx.FormatNode(&s.CurVar)
+	if s.Args != nil {
+		ctx.WriteString("(")
+		for i := range s.Args {
+			if i > 0 {
+				ctx.WriteString(", ")
+			}
+			ctx.FormatNode(s.Args[i])
+		}
+		ctx.WriteString(")")
+	}
 	switch s.Scroll {
 	case tree.Scroll:
 		ctx.WriteString(" SCROLL")
@@ -1158,6 +1408,12 @@ type Fetch struct {
 	IsMove bool
 }
 
+func (s *Fetch) CopyNode() *Fetch {
+	copyNode := *s
+	copyNode.Target = append([]Variable(nil), copyNode.Target...)
+	return &copyNode
+}
+
 func (s *Fetch) Format(ctx *tree.FmtCtx) {
 	if s.IsMove {
 		ctx.WriteString("MOVE ")
@@ -1204,6 +1460,11 @@ type Close struct {
 	CurVar Variable
 }
 
+func (s *Close) CopyNode() *Close {
+	copyNode := *s
+	return &copyNode
+}
+
 func (s *Close) Format(ctx *tree.FmtCtx) {
 	ctx.WriteString("CLOSE ")
 	ctx.FormatNode(&s.CurVar)
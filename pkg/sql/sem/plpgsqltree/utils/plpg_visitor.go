@@ -163,6 +163,18 @@ func (v *SQLStmtVisitor) Visit(
 			cpy.Query = s
 			newStmt = cpy
 		}
+		for i, arg := range t.Args {
+			e, v.Err = simpleVisit(arg, v.Fn)
+			if v.Err != nil {
+				return stmt, false
+			}
+			if arg != e {
+				if newStmt == stmt {
+					newStmt = t.CopyNode()
+				}
+				newStmt.(*plpgsqltree.Open).Args[i] = e
+			}
+		}
 	case *plpgsqltree.Declaration:
 		e, v.Err = simpleVisit(t.Expr, v.Fn)
 		if v.Err != nil {
@@ -316,9 +328,74 @@ func (v *SQLStmtVisitor) Visit(
 			newStmt = cpy
 		}
 
-	case *plpgsqltree.ForInt, *plpgsqltree.ForSelect, *plpgsqltree.ForCursor,
-		*plpgsqltree.ForDynamic, *plpgsqltree.ForEachArray, *plpgsqltree.ReturnNext,
-		*plpgsqltree.ReturnQuery, *plpgsqltree.Perform:
+	case *plpgsqltree.ForInt:
+		var lower, upper, step tree.Expr
+		if lower, v.Err = simpleVisit(t.Lower, v.Fn); v.Err != nil {
+			return stmt, false
+		}
+		if upper, v.Err = simpleVisit(t.Upper, v.Fn); v.Err != nil {
+			return stmt, false
+		}
+		if step, v.Err = simpleVisit(t.Step, v.Fn); v.Err != nil {
+			return stmt, false
+		}
+		if t.Lower != lower || t.Upper != upper || t.Step != step {
+			cpy := t.CopyNode()
+			cpy.Lower, cpy.Upper, cpy.Step = lower, upper, step
+			newStmt = cpy
+		}
+	case *plpgsqltree.ForSelect:
+		s, v.Err = simpleStmtVisit(t.Query, v.Fn)
+		if v.Err != nil {
+			return stmt, false
+		}
+		if t.Query != s {
+			cpy := t.CopyNode()
+			cpy.Query = s
+			newStmt = cpy
+		}
+	case *plpgsqltree.ForEachArray:
+		e, v.Err = simpleVisit(t.Expr, v.Fn)
+		if v.Err != nil {
+			return stmt, false
+		}
+		if t.Expr != e {
+			cpy := t.CopyNode()
+			cpy.Expr = e
+			newStmt = cpy
+		}
+	case *plpgsqltree.ReturnNext:
+		e, v.Err = simpleVisit(t.Expr, v.Fn)
+		if v.Err != nil {
+			return stmt, false
+		}
+		if t.Expr != e {
+			cpy := t.CopyNode()
+			cpy.Expr = e
+			newStmt = cpy
+		}
+	case *plpgsqltree.ReturnQuery:
+		s, v.Err = simpleStmtVisit(t.Query, v.Fn)
+		if v.Err != nil {
+			return stmt, false
+		}
+		if t.Query != s {
+			cpy := t.CopyNode()
+			cpy.Query = s
+			newStmt = cpy
+		}
+	case *plpgsqltree.Perform:
+		s, v.Err = simpleStmtVisit(t.Query, v.Fn)
+		if v.Err != nil {
+			return stmt, false
+		}
+		if t.Query != s {
+			cpy := t.CopyNode()
+			cpy.Query = s.(*tree.Select)
+			newStmt = cpy
+		}
+
+	case *plpgsqltree.ForCursor, *plpgsqltree.ForDynamic:
 		panic(unimp.New("plpgsql visitor", "Unimplemented PLpgSQL visitor"))
 	}
 	if v.Err != nil {
@@ -329,7 +406,8 @@ func (v *SQLStmtVisitor) Visit(
 
 // TypeRefVisitor calls the given replace function on each type reference
 // contained in the visited PLpgSQL statements. Note that this currently only
-// includes `Declaration`. SQL statements and expressions are not visited.
+// includes `Declaration` and the arguments of `CursorDeclaration`. SQL
+// statements and expressions are not visited.
 type TypeRefVisitor struct {
 	Fn  func(typ tree.ResolvableTypeReference) (newTyp tree.ResolvableTypeReference, err error)
 	Err error
@@ -357,5 +435,20 @@ func (v *TypeRefVisitor) Visit(
 			}
 		}
 	}
+	if t, ok := stmt.(*plpgsqltree.CursorDeclaration); ok {
+		for i := range t.Args {
+			var newTyp tree.ResolvableTypeReference
+			newTyp, v.Err = v.Fn(t.Args[i].Typ)
+			if v.Err != nil {
+				return stmt, false
+			}
+			if t.Args[i].Typ != newTyp {
+				if newStmt == stmt {
+					newStmt = t.CopyNode()
+				}
+				newStmt.(*plpgsqltree.CursorDeclaration).Args[i].Typ = newTyp
+			}
+		}
+	}
 	return newStmt, true
 }
@@ -178,12 +178,13 @@ func (node *AlterTable) HoistAddColumnConstraints(onHoistedFKConstraint func())
 					targetCol = append(targetCol, d.References.Col)
 				}
 				fk := &ForeignKeyConstraintTableDef{
-					Table:    *d.References.Table,
-					FromCols: NameList{d.Name},
-					ToCols:   targetCol,
-					Name:     d.References.ConstraintName,
-					Actions:  d.References.Actions,
-					Match:    d.References.Match,
+					Table:      *d.References.Table,
+					FromCols:   NameList{d.Name},
+					ToCols:     targetCol,
+					Name:       d.References.ConstraintName,
+					Actions:    d.References.Actions,
+					Match:      d.References.Match,
+					Deferrable: d.References.Deferrable,
 				}
 				constraint := &AlterTableAddConstraint{
 					ConstraintDef:      fk,
@@ -548,6 +548,13 @@ var (
 		types.PGVectorArray,
 		types.RefCursor,
 		types.RefCursorArray,
+		types.Int4Range,
+		types.Int8Range,
+		types.NumRange,
+		types.TSRange,
+		types.TSTZRange,
+		types.DateRange,
+		types.AnyRange,
 		types.TSQuery,
 		types.TSVector,
 		types.VarBit,
@@ -143,3 +143,68 @@ func (x CompositeKeyMatchMethod) String() string {
 		return strconv.Itoa(int(x))
 	}
 }
+
+// ConstraintDeferrability describes whether the checks of a constraint can be
+// deferred until the end of the transaction with SET CONSTRAINTS, and whether
+// they are deferred by default.
+type ConstraintDeferrability semenumpb.Deferrability
+
+// The values for ConstraintDeferrability. It has a one-to-one mapping to
+// semenumpb.Deferrability.
+const (
+	ConstraintNotDeferrable ConstraintDeferrability = iota
+	ConstraintInitiallyImmediate
+	ConstraintInitiallyDeferred
+)
+
+// ConstraintDeferrabilityType allows the conversion from a
+// semenumpb.Deferrability to a tree.ConstraintDeferrability.
+var ConstraintDeferrabilityType = [...]ConstraintDeferrability{
+	semenumpb.Deferrability_NOT_DEFERRABLE:      ConstraintNotDeferrable,
+	semenumpb.Deferrability_INITIALLY_IMMEDIATE: ConstraintInitiallyImmediate,
+	semenumpb.Deferrability_INITIALLY_DEFERRED:  ConstraintInitiallyDeferred,
+}
+
+// ConstraintDeferrabilityValue allows the conversion from a
+// tree.ConstraintDeferrability to a semenumpb.Deferrability.
+var ConstraintDeferrabilityValue = [...]semenumpb.Deferrability{
+	ConstraintNotDeferrable:      semenumpb.Deferrability_NOT_DEFERRABLE,
+	ConstraintInitiallyImmediate: semenumpb.Deferrability_INITIALLY_IMMEDIATE,
+	ConstraintInitiallyDeferred:  semenumpb.Deferrability_INITIALLY_DEFERRED,
+}
+
+// String implements the fmt.Stringer interface.
+func (x ConstraintDeferrability) String() string {
+	switch x {
+	case ConstraintNotDeferrable:
+		return "NOT DEFERRABLE"
+	case ConstraintInitiallyImmediate:
+		return "DEFERRABLE INITIALLY IMMEDIATE"
+	case ConstraintInitiallyDeferred:
+		return "DEFERRABLE INITIALLY DEFERRED"
+	default:
+		return strconv.Itoa(int(x))
+	}
+}
+
+// keyword returns the clause which is printed for x in a constraint
+// definition. It is empty for constraints which are not deferrable.
+func (x ConstraintDeferrability) keyword() string {
+	switch x {
+	case ConstraintInitiallyImmediate:
+		return "DEFERRABLE"
+	case ConstraintInitiallyDeferred:
+		return "DEFERRABLE INITIALLY DEFERRED"
+	default:
+		return ""
+	}
+}
+
+// Format implements the NodeFormatter interface. Nothing is printed for
+// constraints which are not deferrable.
+func (x ConstraintDeferrability) Format(ctx *FmtCtx) {
+	if kw := x.keyword(); kw != "" {
+		ctx.WriteByte(' ')
+		ctx.WriteString(kw)
+	}
+}
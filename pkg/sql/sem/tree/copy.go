@@ -20,7 +20,11 @@ type CopyFrom struct {
 	Table   TableName
 	Columns NameList
 	Stdin   bool
+	// File, if set, is the URI of the external storage file the data is read
+	// from instead of STDIN.
+	File    *StrVal
 	Options CopyOptions
+	Where   *Where
 }
 
 // CopyTo represents a COPY TO statement.
@@ -28,7 +32,10 @@ type CopyTo struct {
 	Table     TableName
 	Columns   NameList
 	Statement Statement
-	Options   CopyOptions
+	// File, if set, is the URI of the external storage file the data is written
+	// to instead of STDOUT.
+	File    *StrVal
+	Options CopyOptions
 }
 
 // Format implements the NodeFormatter interface.
@@ -46,7 +53,12 @@ func (node *CopyTo) Format(ctx *FmtCtx) {
 			ctx.WriteString(")")
 		}
 	}
-	ctx.WriteString(" TO STDOUT")
+	if node.File != nil {
+		ctx.WriteString(" TO ")
+		ctx.FormatNode(node.File)
+	} else {
+		ctx.WriteString(" TO STDOUT")
+	}
 	if !node.Options.IsDefault() {
 		ctx.WriteString(" WITH ")
 		ctx.FormatNode(&node.Options)
@@ -68,10 +80,53 @@ type CopyOptions struct {
 	// values were already set.
 	HasFormat bool
 	HasHeader bool
+
+	ForceQuote   *CopyForceColumns
+	ForceNotNull *CopyForceColumns
+	ForceNull    *CopyForceColumns
 }
 
 var _ NodeFormatter = &CopyOptions{}
 
+// CopyForceColumns is the list of columns that a FORCE_QUOTE, FORCE_NOT_NULL
+// or FORCE_NULL option applies to.
+type CopyForceColumns struct {
+	// All is set if the option was specified with *, in which case it applies
+	// to all columns.
+	All     bool
+	Columns NameList
+}
+
+var _ NodeFormatter = &CopyForceColumns{}
+
+// Format implements the NodeFormatter interface.
+func (node *CopyForceColumns) Format(ctx *FmtCtx) {
+	if node.All {
+		ctx.WriteString("*")
+		return
+	}
+	ctx.WriteString("(")
+	ctx.FormatNode(&node.Columns)
+	ctx.WriteString(")")
+}
+
+// Contains returns true if the option applies to the column with the given
+// name.
+func (node *CopyForceColumns) Contains(name Name) bool {
+	if node == nil {
+		return false
+	}
+	if node.All {
+		return true
+	}
+	for _, n := range node.Columns {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
 // Format implements the NodeFormatter interface.
 func (node *CopyFrom) Format(ctx *FmtCtx) {
 	ctx.WriteString("COPY ")
@@ -84,11 +139,17 @@ func (node *CopyFrom) Format(ctx *FmtCtx) {
 	ctx.WriteString(" FROM ")
 	if node.Stdin {
 		ctx.WriteString("STDIN")
+	} else if node.File != nil {
+		ctx.FormatNode(node.File)
 	}
 	if !node.Options.IsDefault() {
 		ctx.WriteString(" WITH ")
 		ctx.FormatNode(&node.Options)
 	}
+	if node.Where != nil {
+		ctx.WriteByte(' ')
+		ctx.FormatNode(node.Where)
+	}
 }
 
 // Format implements the NodeFormatter interface
@@ -158,6 +219,21 @@ func (o *CopyOptions) Format(ctx *FmtCtx) {
 		ctx.WriteString("QUOTE ")
 		ctx.FormatNode(o.Quote)
 	}
+	if o.ForceQuote != nil {
+		maybeAddSep()
+		ctx.WriteString("FORCE_QUOTE ")
+		ctx.FormatNode(o.ForceQuote)
+	}
+	if o.ForceNotNull != nil {
+		maybeAddSep()
+		ctx.WriteString("FORCE_NOT_NULL ")
+		ctx.FormatNode(o.ForceNotNull)
+	}
+	if o.ForceNull != nil {
+		maybeAddSep()
+		ctx.WriteString("FORCE_NULL ")
+		ctx.FormatNode(o.ForceNull)
+	}
 	ctx.WriteString(")")
 }
 
@@ -219,6 +295,24 @@ func (o *CopyOptions) CombineWith(other *CopyOptions) error {
 		}
 		o.Quote = other.Quote
 	}
+	if other.ForceQuote != nil {
+		if o.ForceQuote != nil {
+			return pgerror.Newf(pgcode.Syntax, "force_quote option specified multiple times")
+		}
+		o.ForceQuote = other.ForceQuote
+	}
+	if other.ForceNotNull != nil {
+		if o.ForceNotNull != nil {
+			return pgerror.Newf(pgcode.Syntax, "force_not_null option specified multiple times")
+		}
+		o.ForceNotNull = other.ForceNotNull
+	}
+	if other.ForceNull != nil {
+		if o.ForceNull != nil {
+			return pgerror.Newf(pgcode.Syntax, "force_null option specified multiple times")
+		}
+		o.ForceNull = other.ForceNull
+	}
 	return nil
 }
 
@@ -28,6 +28,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/collatedstring"
 	"github.com/cockroachdb/cockroach/pkg/util/pretty"
@@ -239,6 +240,7 @@ type CreateIndex struct {
 	Table       TableName
 	Unique      bool
 	Inverted    bool
+	Vector      bool
 	IfNotExists bool
 	Columns     IndexElemList
 	Sharded     *ShardedIndexDef
@@ -277,6 +279,9 @@ func (node *CreateIndex) Format(ctx *FmtCtx) {
 	}
 	ctx.WriteString("ON ")
 	ctx.FormatNode(&node.Table)
+	if node.Vector {
+		ctx.WriteString(" USING lsh")
+	}
 
 	ctx.WriteString(" (")
 	ctx.FormatNode(&node.Columns)
@@ -374,6 +379,9 @@ type CreateType struct {
 	// CompositeTypeList is set when this repesnets a CREATE TYPE ... AS ( )
 	// statement.
 	CompositeTypeList []CompositeTypeElem
+	// RangeSubtype is set when this represents a CREATE TYPE ... AS RANGE
+	// statement.
+	RangeSubtype ResolvableTypeReference
 	// IfNotExists is true if IF NOT EXISTS was requested.
 	IfNotExists bool
 }
@@ -405,6 +413,10 @@ func (node *CreateType) Format(ctx *FmtCtx) {
 			ctx.FormatTypeReference(elem.Type)
 		}
 		ctx.WriteString(")")
+	case Range:
+		ctx.WriteString("AS RANGE (SUBTYPE = ")
+		ctx.FormatTypeReference(node.RangeSubtype)
+		ctx.WriteString(")")
 	}
 }
 
@@ -412,6 +424,65 @@ func (node *CreateType) String() string {
 	return AsString(node)
 }
 
+// DomainConstraint is a single constraint in a CREATE DOMAIN statement. Exactly
+// one of NotNull, Null or Check is set.
+type DomainConstraint struct {
+	// Name is the optional name of the constraint.
+	Name Name
+	// NotNull is set for NOT NULL constraints.
+	NotNull bool
+	// Null is set for NULL constraints, which are accepted for compatibility
+	// but have no effect.
+	Null bool
+	// Check is set for CHECK constraints. It may reference the value being
+	// checked using the VALUE keyword.
+	Check Expr
+}
+
+// Format implements the NodeFormatter interface.
+func (node *DomainConstraint) Format(ctx *FmtCtx) {
+	if node.Name != "" {
+		ctx.WriteString("CONSTRAINT ")
+		ctx.FormatNode(&node.Name)
+		ctx.WriteByte(' ')
+	}
+	switch {
+	case node.NotNull:
+		ctx.WriteString("NOT NULL")
+	case node.Null:
+		ctx.WriteString("NULL")
+	default:
+		ctx.WriteString("CHECK (")
+		ctx.FormatNode(node.Check)
+		ctx.WriteByte(')')
+	}
+}
+
+// CreateDomain represents a CREATE DOMAIN statement.
+type CreateDomain struct {
+	TypeName    *UnresolvedObjectName
+	Type        ResolvableTypeReference
+	Constraints []DomainConstraint
+}
+
+var _ Statement = &CreateDomain{}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateDomain) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE DOMAIN ")
+	ctx.FormatNode(node.TypeName)
+	ctx.WriteString(" AS ")
+	ctx.FormatTypeReference(node.Type)
+	for i := range node.Constraints {
+		ctx.WriteByte(' ')
+		ctx.FormatNode(&node.Constraints[i])
+	}
+}
+
+func (node *CreateDomain) String() string {
+	return AsString(node)
+}
+
 // TableDef represents a column, index or constraint definition within a CREATE
 // TABLE statement.
 type TableDef interface {
@@ -426,6 +497,7 @@ func (*IndexTableDef) tableDef()                {}
 func (*FamilyTableDef) tableDef()               {}
 func (*ForeignKeyConstraintTableDef) tableDef() {}
 func (*CheckConstraintTableDef) tableDef()      {}
+func (*ExclusionConstraintTableDef) tableDef()  {}
 func (*LikeTableDef) tableDef()                 {}
 
 // TableDefs represents a list of table definitions.
@@ -518,6 +590,7 @@ type ColumnTableDef struct {
 		ConstraintName Name
 		Actions        ReferenceActions
 		Match          CompositeKeyMatchMethod
+		Deferrable     ConstraintDeferrability
 	}
 	Computed struct {
 		Computed bool
@@ -701,6 +774,7 @@ func NewColumnTableDef(
 			d.References.ConstraintName = c.Name
 			d.References.Actions = t.Actions
 			d.References.Match = t.Match
+			d.References.Deferrable = t.Deferrable
 		case *ColumnComputedDef:
 			if d.GeneratedIdentity.IsGeneratedAsIdentity {
 				return nil, pgerror.Newf(pgcode.Syntax,
@@ -871,6 +945,7 @@ func (node *ColumnTableDef) Format(ctx *FmtCtx) {
 			ctx.WriteString(node.References.Match.String())
 		}
 		ctx.FormatNode(&node.References.Actions)
+		ctx.FormatNode(node.References.Deferrable)
 	}
 	if node.IsComputed() {
 		ctx.WriteString(" AS (")
@@ -1009,10 +1084,11 @@ type ColumnCheckConstraint struct {
 
 // ColumnFKConstraint represents a FK-constaint on a column.
 type ColumnFKConstraint struct {
-	Table   TableName
-	Col     Name // empty-string means use PK
-	Actions ReferenceActions
-	Match   CompositeKeyMatchMethod
+	Table      TableName
+	Col        Name // empty-string means use PK
+	Actions    ReferenceActions
+	Match      CompositeKeyMatchMethod
+	Deferrable ConstraintDeferrability
 }
 
 // ColumnComputedDef represents the description of a computed column.
@@ -1102,6 +1178,7 @@ type ConstraintTableDef interface {
 func (*UniqueConstraintTableDef) constraintTableDef()     {}
 func (*ForeignKeyConstraintTableDef) constraintTableDef() {}
 func (*CheckConstraintTableDef) constraintTableDef()      {}
+func (*ExclusionConstraintTableDef) constraintTableDef()  {}
 
 // UniqueConstraintTableDef represents a unique constraint within a CREATE
 // TABLE statement.
@@ -1110,6 +1187,7 @@ type UniqueConstraintTableDef struct {
 	PrimaryKey   bool
 	WithoutIndex bool
 	IfNotExists  bool
+	Deferrable   ConstraintDeferrability
 }
 
 // SetName implements the TableDef interface.
@@ -1154,6 +1232,7 @@ func (node *UniqueConstraintTableDef) Format(ctx *FmtCtx) {
 	if node.PartitionByIndex != nil {
 		ctx.FormatNode(node.PartitionByIndex)
 	}
+	ctx.FormatNode(node.Deferrable)
 	if node.Predicate != nil {
 		ctx.WriteString(" WHERE ")
 		ctx.FormatNode(node.Predicate)
@@ -1180,6 +1259,7 @@ type ForeignKeyConstraintTableDef struct {
 	Actions     ReferenceActions
 	Match       CompositeKeyMatchMethod
 	IfNotExists bool
+	Deferrable  ConstraintDeferrability
 }
 
 // Format implements the NodeFormatter interface.
@@ -1210,6 +1290,7 @@ func (node *ForeignKeyConstraintTableDef) Format(ctx *FmtCtx) {
 	}
 
 	ctx.FormatNode(&node.Actions)
+	ctx.FormatNode(node.Deferrable)
 }
 
 // SetName implements the ConstraintTableDef interface.
@@ -1256,6 +1337,69 @@ func (node *CheckConstraintTableDef) Format(ctx *FmtCtx) {
 	ctx.WriteByte(')')
 }
 
+// ExclusionConstraintTableDef represents an EXCLUDE constraint within a CREATE
+// TABLE statement.
+type ExclusionConstraintTableDef struct {
+	Name        Name
+	Method      Name
+	Elems       ExclusionElemList
+	IfNotExists bool
+}
+
+// ExclusionElem is a column of an exclusion constraint and the operator used
+// to compare it between rows.
+type ExclusionElem struct {
+	Column   Name
+	Operator treecmp.ComparisonOperator
+}
+
+// ExclusionElemList is a list of ExclusionElems.
+type ExclusionElemList []ExclusionElem
+
+// SetName implements the ConstraintTableDef interface.
+func (node *ExclusionConstraintTableDef) SetName(name Name) {
+	node.Name = name
+}
+
+// SetIfNotExists implements the ConstraintTableDef interface.
+func (node *ExclusionConstraintTableDef) SetIfNotExists() {
+	node.IfNotExists = true
+}
+
+// Format implements the NodeFormatter interface.
+func (node *ExclusionConstraintTableDef) Format(ctx *FmtCtx) {
+	if node.Name != "" {
+		ctx.WriteString("CONSTRAINT ")
+		if node.IfNotExists {
+			ctx.WriteString("IF NOT EXISTS ")
+		}
+		ctx.FormatNode(&node.Name)
+		ctx.WriteByte(' ')
+	}
+	ctx.WriteString("EXCLUDE USING ")
+	ctx.WriteString(string(node.Method))
+	ctx.WriteString(" (")
+	ctx.FormatNode(&node.Elems)
+	ctx.WriteByte(')')
+}
+
+// Format implements the NodeFormatter interface.
+func (node *ExclusionElem) Format(ctx *FmtCtx) {
+	ctx.FormatNode(&node.Column)
+	ctx.WriteString(" WITH ")
+	ctx.WriteString(node.Operator.String())
+}
+
+// Format implements the NodeFormatter interface.
+func (l *ExclusionElemList) Format(ctx *FmtCtx) {
+	for i := range *l {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(&(*l)[i])
+	}
+}
+
 // FamilyTableDef represents a family definition within a CREATE TABLE
 // statement.
 type FamilyTableDef struct {
@@ -1620,12 +1764,13 @@ func (node *CreateTable) HoistConstraints() {
 					targetCol = append(targetCol, col.References.Col)
 				}
 				node.Defs = append(node.Defs, &ForeignKeyConstraintTableDef{
-					Table:    *col.References.Table,
-					FromCols: NameList{col.Name},
-					ToCols:   targetCol,
-					Name:     col.References.ConstraintName,
-					Actions:  col.References.Actions,
-					Match:    col.References.Match,
+					Table:      *col.References.Table,
+					FromCols:   NameList{col.Name},
+					ToCols:     targetCol,
+					Name:       col.References.ConstraintName,
+					Actions:    col.References.Actions,
+					Match:      col.References.Match,
+					Deferrable: col.References.Deferrable,
 				})
 				col.References.Table = nil
 			}
@@ -2180,6 +2325,77 @@ func (node *CreateExternalConnection) Format(ctx *FmtCtx) {
 	ctx.FormatNode(node.As)
 }
 
+// CreateServer represents a CREATE SERVER statement. A server names an
+// External Connection that foreign tables read their files through.
+type CreateServer struct {
+	Name        Name
+	IfNotExists bool
+	// Wrapper is the name of the foreign-data wrapper of the server.
+	Wrapper Name
+	Options KVOptions
+}
+
+var _ Statement = &CreateServer{}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateServer) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE SERVER ")
+	if node.IfNotExists {
+		ctx.WriteString("IF NOT EXISTS ")
+	}
+	ctx.FormatNode(&node.Name)
+	ctx.WriteString(" FOREIGN DATA WRAPPER ")
+	ctx.FormatNode(&node.Wrapper)
+	node.Options.formatAsForeignOptions(ctx)
+}
+
+// CreateForeignTable represents a CREATE FOREIGN TABLE statement.
+type CreateForeignTable struct {
+	IfNotExists bool
+	Table       TableName
+	Defs        TableDefs
+	Server      Name
+	Options     KVOptions
+}
+
+var _ Statement = &CreateForeignTable{}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateForeignTable) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE FOREIGN TABLE ")
+	if node.IfNotExists {
+		ctx.WriteString("IF NOT EXISTS ")
+	}
+	ctx.FormatNode(&node.Table)
+	ctx.WriteString(" (")
+	ctx.FormatNode(&node.Defs)
+	ctx.WriteString(") SERVER ")
+	ctx.FormatNode(&node.Server)
+	node.Options.formatAsForeignOptions(ctx)
+}
+
+// formatAsForeignOptions formats the options of a CREATE SERVER or CREATE
+// FOREIGN TABLE statement, which are written as key-value pairs without an
+// equals sign.
+func (o *KVOptions) formatAsForeignOptions(ctx *FmtCtx) {
+	if len(*o) == 0 {
+		return
+	}
+	ctx.WriteString(" OPTIONS (")
+	for i := range *o {
+		n := &(*o)[i]
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.WithFlags(ctx.flags&^FmtMarkRedactionNode, func() {
+			ctx.FormatNode(&n.Key)
+		})
+		ctx.WriteByte(' ')
+		ctx.FormatNode(n.Value)
+	}
+	ctx.WriteByte(')')
+}
+
 // CreateTenant represents a CREATE VIRTUAL CLUSTER statement.
 type CreateTenant struct {
 	IfNotExists bool
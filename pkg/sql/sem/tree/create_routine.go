@@ -13,6 +13,7 @@ package tree
 import (
 	"strings"
 
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/errors"
@@ -110,9 +111,14 @@ func (node *CreateRoutine) Format(ctx *FmtCtx) {
 	}
 	ctx.FormatNode(&node.Name)
 	ctx.WriteByte('(')
-	ctx.FormatNode(node.Params)
+	params, tableCols := node.Params.splitTableColumns()
+	ctx.FormatNode(params)
 	ctx.WriteString(")\n\t")
-	if !node.IsProcedure && node.ReturnType != nil {
+	if !node.IsProcedure && len(tableCols) > 0 {
+		ctx.WriteString("RETURNS TABLE (")
+		ctx.FormatNode(tableCols)
+		ctx.WriteString(")\n\t")
+	} else if !node.IsProcedure && node.ReturnType != nil {
 		ctx.WriteString("RETURNS ")
 		if node.ReturnType.SetOf {
 			ctx.WriteString("SETOF ")
@@ -203,6 +209,8 @@ func (RoutineVolatility) routineOption()        {}
 func (RoutineLeakproof) routineOption()         {}
 func (RoutineBodyStr) routineOption()           {}
 func (RoutineLanguage) routineOption()          {}
+func (RoutineSecurity) routineOption()          {}
+func (*SetVar) routineOption()                  {}
 
 // RoutineNullInputBehavior represent the UDF property on null parameters.
 type RoutineNullInputBehavior int
@@ -275,6 +283,31 @@ func (node RoutineLeakproof) Format(ctx *FmtCtx) {
 	ctx.WriteString("LEAKPROOF")
 }
 
+// RoutineSecurity indicates whether a routine executes with the privileges of
+// the invoking user or with those of the routine's owner.
+type RoutineSecurity int
+
+const (
+	// RoutineSecurityInvoker indicates that the routine executes with the
+	// privileges of the invoking user. This is the default.
+	RoutineSecurityInvoker RoutineSecurity = iota
+	// RoutineSecurityDefiner indicates that the routine executes with the
+	// privileges of its owner.
+	RoutineSecurityDefiner
+)
+
+// Format implements the NodeFormatter interface.
+func (node RoutineSecurity) Format(ctx *FmtCtx) {
+	switch node {
+	case RoutineSecurityInvoker:
+		ctx.WriteString("SECURITY INVOKER")
+	case RoutineSecurityDefiner:
+		ctx.WriteString("SECURITY DEFINER")
+	default:
+		panic(pgerror.New(pgcode.InvalidParameterValue, "unknown routine option"))
+	}
+}
+
 // RoutineLanguage indicates the language of the statements in the routine body.
 type RoutineLanguage string
 
@@ -346,6 +379,19 @@ func (node RoutineParams) Format(ctx *FmtCtx) {
 	}
 }
 
+// splitTableColumns separates the columns of a RETURNS TABLE clause from the
+// other parameters.
+func (node RoutineParams) splitTableColumns() (params, tableCols RoutineParams) {
+	for i := range node {
+		if node[i].Class == RoutineParamTable {
+			tableCols = append(tableCols, node[i])
+		} else {
+			params = append(params, node[i])
+		}
+	}
+	return params, tableCols
+}
+
 // RoutineParam represents a parameter in a UDF signature.
 type RoutineParam struct {
 	Name       Name
@@ -366,6 +412,8 @@ func (node *RoutineParam) Format(ctx *FmtCtx) {
 		ctx.WriteString("INOUT ")
 	case RoutineParamVariadic:
 		ctx.WriteString("VARIADIC ")
+	case RoutineParamTable:
+		// The columns of a RETURNS TABLE clause are formatted without a class.
 	default:
 		panic(pgerror.New(pgcode.InvalidParameterValue, "unknown routine option"))
 	}
@@ -395,13 +443,16 @@ const (
 	RoutineParamInOut
 	// RoutineParamVariadic args are variadic.
 	RoutineParamVariadic
+	// RoutineParamTable args are the output columns of a function declared
+	// with RETURNS TABLE. They behave like OUT parameters.
+	RoutineParamTable
 )
 
 // IsInParamClass returns true if the given parameter class specifies an input
-// parameter (i.e. either unspecified, IN or, INOUT).
+// parameter (i.e. either unspecified, IN, INOUT or VARIADIC).
 func IsInParamClass(class RoutineParamClass) bool {
 	switch class {
-	case RoutineParamDefault, RoutineParamIn, RoutineParamInOut:
+	case RoutineParamDefault, RoutineParamIn, RoutineParamInOut, RoutineParamVariadic:
 		return true
 	default:
 		return false
@@ -409,16 +460,23 @@ func IsInParamClass(class RoutineParamClass) bool {
 }
 
 // IsOutParamClass returns true if the given parameter class specifies an output
-// parameter (i.e. either OUT or INOUT).
+// parameter (i.e. either OUT, INOUT or a RETURNS TABLE column).
 func IsOutParamClass(class RoutineParamClass) bool {
 	switch class {
-	case RoutineParamOut, RoutineParamInOut:
+	case RoutineParamOut, RoutineParamInOut, RoutineParamTable:
 		return true
 	default:
 		return false
 	}
 }
 
+// IsOutOnlyParamClass returns true if the given parameter class specifies a
+// parameter that is only used as output (i.e. either OUT or a RETURNS TABLE
+// column).
+func IsOutOnlyParamClass(class RoutineParamClass) bool {
+	return IsOutParamClass(class) && !IsInParamClass(class)
+}
+
 // IsInParam returns true if the parameter is an input parameter (i.e. either IN
 // or INOUT).
 func (node *RoutineParam) IsInParam() bool {
@@ -437,10 +495,12 @@ type RoutineReturnType struct {
 	SetOf bool
 }
 
-// DropRoutine represents a DROP FUNCTION or DROP PROCEDURE statement.
+// DropRoutine represents a DROP FUNCTION, DROP PROCEDURE or DROP AGGREGATE
+// statement.
 type DropRoutine struct {
 	IfExists     bool
 	Procedure    bool
+	Aggregate    bool
 	Routines     RoutineObjs
 	DropBehavior DropBehavior
 }
@@ -449,6 +509,8 @@ type DropRoutine struct {
 func (node *DropRoutine) Format(ctx *FmtCtx) {
 	if node.Procedure {
 		ctx.WriteString("DROP PROCEDURE ")
+	} else if node.Aggregate {
+		ctx.WriteString("DROP AGGREGATE ")
 	} else {
 		ctx.WriteString("DROP FUNCTION ")
 	}
@@ -586,6 +648,117 @@ func (node *AlterFunctionDepExtension) Format(ctx *FmtCtx) {
 	ctx.WriteString(string(node.Extension))
 }
 
+// CreateAggregate represents a CREATE AGGREGATE statement.
+type CreateAggregate struct {
+	Replace bool
+	Name    RoutineName
+	Params  RoutineParams
+	Options AggregateOptions
+}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateAggregate) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE ")
+	if node.Replace {
+		ctx.WriteString("OR REPLACE ")
+	}
+	ctx.WriteString("AGGREGATE ")
+	ctx.FormatNode(&node.Name)
+	ctx.WriteByte('(')
+	ctx.FormatNode(node.Params)
+	ctx.WriteString(") (")
+	ctx.FormatNode(node.Options)
+	ctx.WriteByte(')')
+}
+
+// AggregateOptions represents a list of aggregate options.
+type AggregateOptions []AggregateOption
+
+// Format implements the NodeFormatter interface.
+func (node AggregateOptions) Format(ctx *FmtCtx) {
+	for i, option := range node {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(option)
+	}
+}
+
+// AggregateOption is an interface representing the properties of a
+// user-defined aggregate.
+type AggregateOption interface {
+	aggregateOption()
+	NodeFormatter
+}
+
+func (*AggregateStateFunc) aggregateOption()   {}
+func (*AggregateStateType) aggregateOption()   {}
+func (*AggregateFinalFunc) aggregateOption()   {}
+func (*AggregateCombineFunc) aggregateOption() {}
+func (AggregateInitCond) aggregateOption()     {}
+
+// AggregateStateFunc is the state transition function of an aggregate. It is
+// called for each input row with the current state and the row's arguments,
+// and returns the new state.
+type AggregateStateFunc struct {
+	Name RoutineName
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AggregateStateFunc) Format(ctx *FmtCtx) {
+	ctx.WriteString("SFUNC = ")
+	ctx.FormatNode(&node.Name)
+}
+
+// AggregateStateType is the data type of the state of an aggregate.
+type AggregateStateType struct {
+	Type ResolvableTypeReference
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AggregateStateType) Format(ctx *FmtCtx) {
+	ctx.WriteString("STYPE = ")
+	ctx.FormatTypeReference(node.Type)
+}
+
+// AggregateFinalFunc is the optional final function of an aggregate, which
+// computes the result of the aggregate from its final state.
+type AggregateFinalFunc struct {
+	Name RoutineName
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AggregateFinalFunc) Format(ctx *FmtCtx) {
+	ctx.WriteString("FINALFUNC = ")
+	ctx.FormatNode(&node.Name)
+}
+
+// AggregateCombineFunc is the optional combine function of an aggregate, which
+// merges two partial states into one.
+type AggregateCombineFunc struct {
+	Name RoutineName
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AggregateCombineFunc) Format(ctx *FmtCtx) {
+	ctx.WriteString("COMBINEFUNC = ")
+	ctx.FormatNode(&node.Name)
+}
+
+// AggregateInitCond is the optional initial value of the state of an
+// aggregate, in the string form of the state type.
+type AggregateInitCond string
+
+// Format implements the NodeFormatter interface.
+func (node AggregateInitCond) Format(ctx *FmtCtx) {
+	ctx.WriteString("INITCOND = ")
+	if ctx.flags.HasFlags(FmtAnonymize) || ctx.flags.HasFlags(FmtHideConstants) {
+		ctx.WriteString("'_'")
+	} else {
+		lexbase.EncodeSQLString(&ctx.Buffer, string(node))
+	}
+}
+
 // UDFDisallowanceVisitor is used to determine if a type checked expression
 // contains any UDF function sub-expression. It's needed only temporarily to
 // disallow any usage of UDF from relation objects.
@@ -640,7 +813,7 @@ func ComputedColumnExprContext(isVirtual bool) SchemaExprContext {
 // ValidateRoutineOptions checks whether there are conflicting or redundant
 // routine options in the given slice.
 func ValidateRoutineOptions(options RoutineOptions, isProc bool) error {
-	var hasLang, hasBody, hasLeakProof, hasVolatility, hasNullInputBehavior bool
+	var hasLang, hasBody, hasLeakProof, hasVolatility, hasNullInputBehavior, hasSecurity bool
 	conflictingErr := func(opt RoutineOption) error {
 		return errors.Wrapf(ErrConflictingRoutineOption, "%s", AsString(opt))
 	}
@@ -680,6 +853,14 @@ func ValidateRoutineOptions(options RoutineOptions, isProc bool) error {
 				return conflictingErr(option)
 			}
 			hasNullInputBehavior = true
+		case RoutineSecurity:
+			if hasSecurity {
+				return conflictingErr(option)
+			}
+			hasSecurity = true
+		case *SetVar:
+			// Any number of SET and RESET clauses may be specified. They are
+			// applied in order.
 		default:
 			return pgerror.Newf(pgcode.InvalidParameterValue, "unknown function option: ", AsString(option))
 		}
@@ -78,6 +78,23 @@ func TestConflictingFunctionOptions(t *testing.T) {
 			},
 			expectedErr: "AS $$others$$: conflicting or redundant options",
 		},
+		{
+			testName: "security conflict",
+			options: tree.RoutineOptions{
+				tree.RoutineSecurityDefiner, tree.RoutineSecurityInvoker,
+			},
+			expectedErr: "SECURITY INVOKER: conflicting or redundant options",
+		},
+		{
+			testName: "multiple set clauses",
+			options: tree.RoutineOptions{
+				tree.RoutineSecurityDefiner,
+				&tree.SetVar{Name: "search_path", Values: tree.Exprs{tree.NewStrVal("public")}},
+				&tree.SetVar{Name: "search_path", Values: tree.Exprs{tree.DefaultVal{}}},
+			},
+			isProc:      true,
+			expectedErr: "",
+		},
 		{
 			testName: "proc volatility",
 			options: tree.RoutineOptions{
@@ -0,0 +1,213 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+import "github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+
+// CreateTrigger represents a CREATE TRIGGER statement.
+type CreateTrigger struct {
+	Replace     bool
+	Name        Name
+	ActionTime  TriggerActionTime
+	Events      []*TriggerEvent
+	TableName   *UnresolvedObjectName
+	Transitions []*TriggerTransition
+	ForEach     TriggerForEach
+	When        Expr
+	FuncName    *UnresolvedName
+	FuncArgs    []string
+}
+
+var _ Statement = &CreateTrigger{}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateTrigger) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE ")
+	if node.Replace {
+		ctx.WriteString("OR REPLACE ")
+	}
+	ctx.WriteString("TRIGGER ")
+	ctx.FormatNode(&node.Name)
+	ctx.WriteString(" ")
+	ctx.WriteString(node.ActionTime.String())
+	for i := range node.Events {
+		if i == 0 {
+			ctx.WriteString(" ")
+		} else {
+			ctx.WriteString(" OR ")
+		}
+		ctx.FormatNode(node.Events[i])
+	}
+	ctx.WriteString(" ON ")
+	ctx.FormatNode(node.TableName)
+	if len(node.Transitions) > 0 {
+		ctx.WriteString(" REFERENCING ")
+		for i := range node.Transitions {
+			if i > 0 {
+				ctx.WriteString(" ")
+			}
+			ctx.FormatNode(node.Transitions[i])
+		}
+	}
+	ctx.WriteString(" FOR EACH ")
+	ctx.WriteString(node.ForEach.String())
+	if node.When != nil {
+		ctx.WriteString(" WHEN (")
+		ctx.FormatNode(node.When)
+		ctx.WriteString(")")
+	}
+	ctx.WriteString(" EXECUTE FUNCTION ")
+	ctx.FormatNode(node.FuncName)
+	ctx.WriteString("(")
+	for i := range node.FuncArgs {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		if ctx.flags.HasFlags(FmtHideConstants) {
+			ctx.WriteString("'_'")
+		} else {
+			lexbase.EncodeSQLStringWithFlags(&ctx.Buffer, node.FuncArgs[i], ctx.flags.EncodeFlags())
+		}
+	}
+	ctx.WriteString(")")
+}
+
+// TriggerActionTime represents the activation time of a trigger: BEFORE,
+// AFTER, or INSTEAD OF the triggering event.
+type TriggerActionTime uint8
+
+// TriggerActionTime values.
+const (
+	TriggerActionTimeUnknown TriggerActionTime = iota
+	TriggerActionTimeBefore
+	TriggerActionTimeAfter
+	TriggerActionTimeInsteadOf
+)
+
+var triggerActionTimeName = [...]string{
+	TriggerActionTimeUnknown:   "UNKNOWN",
+	TriggerActionTimeBefore:    "BEFORE",
+	TriggerActionTimeAfter:     "AFTER",
+	TriggerActionTimeInsteadOf: "INSTEAD OF",
+}
+
+func (t TriggerActionTime) String() string {
+	return triggerActionTimeName[t]
+}
+
+// TriggerEventType represents the type of event that can fire a trigger.
+type TriggerEventType uint8
+
+// TriggerEventType values.
+const (
+	TriggerEventTypeUnknown TriggerEventType = iota
+	TriggerEventInsert
+	TriggerEventUpdate
+	TriggerEventDelete
+	TriggerEventTruncate
+)
+
+var triggerEventTypeName = [...]string{
+	TriggerEventTypeUnknown: "UNKNOWN",
+	TriggerEventInsert:      "INSERT",
+	TriggerEventUpdate:      "UPDATE",
+	TriggerEventDelete:      "DELETE",
+	TriggerEventTruncate:    "TRUNCATE",
+}
+
+func (t TriggerEventType) String() string {
+	return triggerEventTypeName[t]
+}
+
+// TriggerEvent represents an event that can fire a trigger. UPDATE events
+// may specify a list of columns; the trigger only fires if one of those
+// columns is the target of an UPDATE.
+type TriggerEvent struct {
+	EventType TriggerEventType
+	Columns   NameList
+}
+
+// Format implements the NodeFormatter interface.
+func (node *TriggerEvent) Format(ctx *FmtCtx) {
+	ctx.WriteString(node.EventType.String())
+	if len(node.Columns) > 0 {
+		ctx.WriteString(" OF ")
+		ctx.FormatNode(&node.Columns)
+	}
+}
+
+// TriggerTransition represents a transition table alias declared in the
+// REFERENCING clause of a trigger.
+type TriggerTransition struct {
+	Name  Name
+	IsNew bool
+	IsRow bool
+}
+
+// Format implements the NodeFormatter interface.
+func (node *TriggerTransition) Format(ctx *FmtCtx) {
+	if node.IsNew {
+		ctx.WriteString("NEW")
+	} else {
+		ctx.WriteString("OLD")
+	}
+	if node.IsRow {
+		ctx.WriteString(" ROW")
+	} else {
+		ctx.WriteString(" TABLE")
+	}
+	ctx.WriteString(" AS ")
+	ctx.FormatNode(&node.Name)
+}
+
+// TriggerForEach represents the granularity at which a trigger fires: once
+// per modified row, or once per statement.
+type TriggerForEach uint8
+
+// TriggerForEach values.
+const (
+	TriggerForEachStatement TriggerForEach = iota
+	TriggerForEachRow
+)
+
+var triggerForEachName = [...]string{
+	TriggerForEachStatement: "STATEMENT",
+	TriggerForEachRow:       "ROW",
+}
+
+func (t TriggerForEach) String() string {
+	return triggerForEachName[t]
+}
+
+// DropTrigger represents a DROP TRIGGER statement.
+type DropTrigger struct {
+	IfExists     bool
+	Trigger      Name
+	Table        *UnresolvedObjectName
+	DropBehavior DropBehavior
+}
+
+var _ Statement = &DropTrigger{}
+
+// Format implements the NodeFormatter interface.
+func (node *DropTrigger) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP TRIGGER ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.FormatNode(&node.Trigger)
+	ctx.WriteString(" ON ")
+	ctx.FormatNode(node.Table)
+	if node.DropBehavior != DropDefault {
+		ctx.WriteString(" ")
+		ctx.WriteString(node.DropBehavior.String())
+	}
+}
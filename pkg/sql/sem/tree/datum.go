@@ -3633,6 +3633,513 @@ func (d *DPGLSN) Size() uintptr {
 	return unsafe.Sizeof(*d)
 }
 
+// DRange is the Datum representation of the range types. A range is either
+// empty, or consists of a lower and an upper bound, each of which is either
+// infinite or a value of the element type of the range that is included in
+// or excluded from the range.
+//
+// Ranges over discrete element types (int4range, int8range and daterange)
+// are kept in their canonical form, in which the lower bound is inclusive and
+// the upper bound is exclusive.
+type DRange struct {
+	typ *types.T
+	// Lower and Upper are the bounds of the range, or nil if the bound is
+	// infinite. Both are nil if the range is empty.
+	Lower, Upper Datum
+	// LowerInc and UpperInc are set if the corresponding bound is included in
+	// the range. They are never set for an infinite bound.
+	LowerInc, UpperInc bool
+	// Empty is set if the range contains no values.
+	Empty bool
+}
+
+var errRangeBoundsOutOfOrder = pgerror.New(pgcode.DataException,
+	"range lower bound must be less than or equal to range upper bound")
+
+// NewDEmptyRange returns the empty range of the given range type.
+func NewDEmptyRange(typ *types.T) *DRange {
+	return &DRange{typ: typ, Empty: true}
+}
+
+// NewDRange returns a new range of the given range type with the given bounds.
+// A nil or NULL bound is infinite. Ranges over discrete element types are
+// canonicalized, and a range containing no values is returned as the empty
+// range.
+func NewDRange(typ *types.T, lower, upper Datum, lowerInc, upperInc bool) (*DRange, error) {
+	if lower == DNull {
+		lower = nil
+	}
+	if upper == DNull {
+		upper = nil
+	}
+	lowerInc = lowerInc && lower != nil
+	upperInc = upperInc && upper != nil
+	if lower != nil && upper != nil {
+		c := compareRangeElements(lower, upper)
+		if c > 0 {
+			return nil, errRangeBoundsOutOfOrder
+		}
+		if c == 0 && !(lowerInc && upperInc) {
+			return NewDEmptyRange(typ), nil
+		}
+	}
+	if rangeIsDiscrete(typ) {
+		var err error
+		if lower != nil && !lowerInc {
+			if lower, err = nextRangeElement(typ, lower); err != nil {
+				return nil, err
+			}
+			lowerInc = true
+		}
+		if upper != nil && upperInc {
+			if upper, err = nextRangeElement(typ, upper); err != nil {
+				return nil, err
+			}
+			upperInc = false
+		}
+		if lower != nil && upper != nil && compareRangeElements(lower, upper) >= 0 {
+			return NewDEmptyRange(typ), nil
+		}
+	}
+	return &DRange{typ: typ, Lower: lower, Upper: upper, LowerInc: lowerInc, UpperInc: upperInc}, nil
+}
+
+// rangeIsDiscrete returns whether the element type of the given range type is
+// discrete, in which case ranges of the type are canonicalized. Like in
+// Postgres, user-defined range types have no canonical form.
+func rangeIsDiscrete(typ *types.T) bool {
+	if typ.UserDefined() {
+		return false
+	}
+	switch typ.RangeContents().Family() {
+	case types.IntFamily, types.DateFamily:
+		return true
+	}
+	return false
+}
+
+// nextRangeElement returns the value following the given element of a range
+// over a discrete element type.
+func nextRangeElement(typ *types.T, d Datum) (Datum, error) {
+	switch t := d.(type) {
+	case *DInt:
+		limit := DInt(math.MaxInt64)
+		if typ.RangeContents().Width() == 32 {
+			limit = math.MaxInt32
+		}
+		if *t == limit {
+			return nil, ErrIntOutOfRange
+		}
+		return NewDInt(*t + 1), nil
+	case *DDate:
+		if !t.IsFinite() {
+			return t, nil
+		}
+		next, err := t.AddDays(1)
+		if err != nil {
+			return nil, err
+		}
+		return NewDDate(next), nil
+	}
+	return nil, errors.AssertionFailedf("unexpected element %T of discrete range type %s", d, typ)
+}
+
+// compareRangeElements compares two non-NULL elements of a range. Unlike
+// Datum.Compare, it does not depend on the evaluation context, which none of
+// the element types of the range types require.
+func compareRangeElements(a, b Datum) int {
+	switch t := a.(type) {
+	case *DInt:
+		v := *b.(*DInt)
+		if *t < v {
+			return -1
+		} else if *t > v {
+			return 1
+		}
+		return 0
+	case *DFloat:
+		// Like DFloat.Compare, NaN sorts before non-NaN values.
+		v := *b.(*DFloat)
+		if *t < v {
+			return -1
+		} else if *t > v {
+			return 1
+		} else if *t == v {
+			return 0
+		}
+		if math.IsNaN(float64(*t)) {
+			if math.IsNaN(float64(v)) {
+				return 0
+			}
+			return -1
+		}
+		return 1
+	case *DDecimal:
+		return CompareDecimals(&t.Decimal, &b.(*DDecimal).Decimal)
+	case *DDate:
+		return t.Date.Compare(b.(*DDate).Date)
+	case *DTimestamp:
+		return t.Time.Compare(b.(*DTimestamp).Time)
+	case *DTimestampTZ:
+		return t.Time.Compare(b.(*DTimestampTZ).Time)
+	}
+	panic(errors.AssertionFailedf("unexpected range element %T", a))
+}
+
+// rangeBound is a bound of a non-empty range, used to compare the bounds of
+// ranges with each other.
+type rangeBound struct {
+	// val is nil if the bound is infinite.
+	val   Datum
+	inc   bool
+	lower bool
+}
+
+func (d *DRange) lowerBound() rangeBound {
+	return rangeBound{val: d.Lower, inc: d.LowerInc, lower: true}
+}
+
+func (d *DRange) upperBound() rangeBound {
+	return rangeBound{val: d.Upper, inc: d.UpperInc}
+}
+
+// compareRangeBounds compares two range bounds by the position in the element
+// domain they bound, taking into account whether they are lower or upper
+// bounds and whether they are inclusive. For example, the exclusive lower
+// bound 1 is greater than the inclusive upper bound 1.
+func compareRangeBounds(b1, b2 rangeBound) int {
+	if b1.val == nil && b2.val == nil {
+		if b1.lower == b2.lower {
+			return 0
+		} else if b1.lower {
+			return -1
+		}
+		return 1
+	} else if b1.val == nil {
+		if b1.lower {
+			return -1
+		}
+		return 1
+	} else if b2.val == nil {
+		if b2.lower {
+			return 1
+		}
+		return -1
+	}
+	if c := compareRangeElements(b1.val, b2.val); c != 0 {
+		return c
+	}
+	switch {
+	case !b1.inc && !b2.inc:
+		if b1.lower == b2.lower {
+			return 0
+		} else if b1.lower {
+			return 1
+		}
+		return -1
+	case !b1.inc:
+		if b1.lower {
+			return 1
+		}
+		return -1
+	case !b2.inc:
+		if b2.lower {
+			return -1
+		}
+		return 1
+	}
+	return 0
+}
+
+// newRangeFromBounds returns a new range with the given lower and upper
+// bounds.
+func newRangeFromBounds(typ *types.T, lower, upper rangeBound) (*DRange, error) {
+	return NewDRange(typ, lower.val, upper.val, lower.inc, upper.inc)
+}
+
+// AsDRange attempts to retrieve a *DRange from an Expr, returning a *DRange
+// and a flag signifying whether the assertion was successful.
+func AsDRange(e Expr) (*DRange, bool) {
+	switch t := e.(type) {
+	case *DRange:
+		return t, true
+	case *DOidWrapper:
+		return AsDRange(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDRange attempts to retrieve a *DRange from an Expr, panicking if the
+// assertion fails.
+func MustBeDRange(e Expr) *DRange {
+	r, ok := AsDRange(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DRange, found %T", e))
+	}
+	return r
+}
+
+// Overlaps returns whether the two ranges have any values in common.
+func (d *DRange) Overlaps(other *DRange) bool {
+	if d.Empty || other.Empty {
+		return false
+	}
+	return compareRangeBounds(d.lowerBound(), other.upperBound()) <= 0 &&
+		compareRangeBounds(other.lowerBound(), d.upperBound()) <= 0
+}
+
+// Contains returns whether all values of the other range are in the range.
+func (d *DRange) Contains(other *DRange) bool {
+	if other.Empty {
+		return true
+	} else if d.Empty {
+		return false
+	}
+	return compareRangeBounds(d.lowerBound(), other.lowerBound()) <= 0 &&
+		compareRangeBounds(d.upperBound(), other.upperBound()) >= 0
+}
+
+// ContainsElem returns whether the given element is in the range.
+func (d *DRange) ContainsElem(elem Datum) bool {
+	if d.Empty {
+		return false
+	}
+	if d.Lower != nil {
+		c := compareRangeElements(d.Lower, elem)
+		if c > 0 || (c == 0 && !d.LowerInc) {
+			return false
+		}
+	}
+	if d.Upper != nil {
+		c := compareRangeElements(d.Upper, elem)
+		if c < 0 || (c == 0 && !d.UpperInc) {
+			return false
+		}
+	}
+	return true
+}
+
+// Adjacent returns whether the two ranges do not overlap, but there are no
+// values between them.
+func (d *DRange) Adjacent(other *DRange) bool {
+	if d.Empty || other.Empty {
+		return false
+	}
+	return rangeBoundsAdjacent(d.upperBound(), other.lowerBound()) ||
+		rangeBoundsAdjacent(other.upperBound(), d.lowerBound())
+}
+
+// rangeBoundsAdjacent returns whether the given upper bound is immediately
+// followed by the given lower bound. Since discrete ranges are canonical, this
+// is only the case if both bounds have the same value and exactly one of them
+// is inclusive.
+func rangeBoundsAdjacent(upper, lower rangeBound) bool {
+	if upper.val == nil || lower.val == nil {
+		return false
+	}
+	return upper.inc != lower.inc && compareRangeElements(upper.val, lower.val) == 0
+}
+
+// Merge returns the smallest range that contains both ranges.
+func (d *DRange) Merge(other *DRange) (*DRange, error) {
+	if d.Empty {
+		return other, nil
+	} else if other.Empty {
+		return d, nil
+	}
+	lower, upper := d.lowerBound(), d.upperBound()
+	if compareRangeBounds(other.lowerBound(), lower) < 0 {
+		lower = other.lowerBound()
+	}
+	if compareRangeBounds(other.upperBound(), upper) > 0 {
+		upper = other.upperBound()
+	}
+	return newRangeFromBounds(d.typ, lower, upper)
+}
+
+// Union returns the range containing the values of both ranges. It is an
+// error if the ranges neither overlap nor are adjacent.
+func (d *DRange) Union(other *DRange) (*DRange, error) {
+	if !d.Empty && !other.Empty && !d.Overlaps(other) && !d.Adjacent(other) {
+		return nil, pgerror.New(pgcode.DataException, "result of range union would not be contiguous")
+	}
+	return d.Merge(other)
+}
+
+// Intersect returns the range containing the values in both ranges.
+func (d *DRange) Intersect(other *DRange) (*DRange, error) {
+	if !d.Overlaps(other) {
+		return NewDEmptyRange(d.typ), nil
+	}
+	lower, upper := d.lowerBound(), d.upperBound()
+	if compareRangeBounds(other.lowerBound(), lower) > 0 {
+		lower = other.lowerBound()
+	}
+	if compareRangeBounds(other.upperBound(), upper) < 0 {
+		upper = other.upperBound()
+	}
+	return newRangeFromBounds(d.typ, lower, upper)
+}
+
+// Difference returns the range containing the values of the range that are
+// not in the other range. It is an error if the result would consist of two
+// disjoint ranges.
+func (d *DRange) Difference(other *DRange) (*DRange, error) {
+	if d.Empty || other.Empty {
+		return d, nil
+	}
+	l1, u1 := d.lowerBound(), d.upperBound()
+	l2, u2 := other.lowerBound(), other.upperBound()
+	cmpL1L2 := compareRangeBounds(l1, l2)
+	cmpL1U2 := compareRangeBounds(l1, u2)
+	cmpU1L2 := compareRangeBounds(u1, l2)
+	cmpU1U2 := compareRangeBounds(u1, u2)
+	switch {
+	case cmpL1L2 < 0 && cmpU1U2 > 0:
+		return nil, pgerror.New(pgcode.DataException, "result of range difference would not be contiguous")
+	case cmpL1U2 > 0 || cmpU1L2 < 0:
+		return d, nil
+	case cmpL1L2 >= 0 && cmpU1U2 <= 0:
+		return NewDEmptyRange(d.typ), nil
+	case cmpL1L2 <= 0 && cmpU1L2 >= 0 && cmpU1U2 <= 0:
+		return newRangeFromBounds(d.typ, l1, rangeBound{val: l2.val, inc: !l2.inc})
+	case cmpL1L2 >= 0 && cmpU1U2 >= 0 && cmpL1U2 <= 0:
+		return newRangeFromBounds(d.typ, rangeBound{val: u2.val, inc: !u2.inc, lower: true}, u1)
+	}
+	return nil, errors.AssertionFailedf("unexpected case in range difference")
+}
+
+// IsComposite implements the CompositeDatum interface.
+func (d *DRange) IsComposite() bool {
+	for _, bound := range []Datum{d.Lower, d.Upper} {
+		if cdatum, ok := bound.(CompositeDatum); ok && cdatum.IsComposite() {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (d *DRange) ResolvedType() *types.T {
+	return d.typ
+}
+
+// compareRanges compares two ranges of the same type. The empty range sorts
+// before all other ranges, which are ordered by their lower and then by their
+// upper bounds.
+func compareRanges(a, b *DRange) int {
+	if a.Empty || b.Empty {
+		if a.Empty && b.Empty {
+			return 0
+		} else if a.Empty {
+			return -1
+		}
+		return 1
+	}
+	if c := compareRangeBounds(a.lowerBound(), b.lowerBound()); c != 0 {
+		return c
+	}
+	return compareRangeBounds(a.upperBound(), b.upperBound())
+}
+
+// Compare implements the Datum interface.
+func (d *DRange) Compare(ctx context.Context, cmpCtx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := cmpCtx.UnwrapDatum(ctx, other).(*DRange)
+	if !ok || !d.typ.Equivalent(v.typ) {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	return compareRanges(d, v), nil
+}
+
+// Prev implements the Datum interface.
+func (d *DRange) Prev(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DRange) Next(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMax implements the Datum interface.
+func (d *DRange) IsMax(ctx context.Context, cmpCtx CompareContext) bool {
+	return false
+}
+
+// IsMin implements the Datum interface.
+func (d *DRange) IsMin(ctx context.Context, cmpCtx CompareContext) bool {
+	return d.Empty
+}
+
+// Max implements the Datum interface.
+func (d *DRange) Max(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DRange) Min(ctx context.Context, cmpCtx CompareContext) (Datum, bool) {
+	return NewDEmptyRange(d.typ), true
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (*DRange) AmbiguousFormat() bool { return true }
+
+// Format implements the NodeFormatter interface.
+func (d *DRange) Format(ctx *FmtCtx) {
+	if ctx.HasFlags(FmtFlags(lexbase.EncBareStrings)) || ctx.HasFlags(fmtPgwireFormat) {
+		d.formatText(ctx, &ctx.Buffer)
+		return
+	}
+	var buf bytes.Buffer
+	d.formatText(ctx, &buf)
+	lexbase.EncodeSQLStringWithFlags(&ctx.Buffer, buf.String(), ctx.flags.EncodeFlags())
+}
+
+// formatText writes the text representation of the range, as accepted by
+// ParseDRange, to buf.
+func (d *DRange) formatText(ctx *FmtCtx, buf *bytes.Buffer) {
+	if d.Empty {
+		buf.WriteString("empty")
+		return
+	}
+	formatBound := func(v Datum) {
+		if v != nil {
+			s := AsStringWithFlags(v, FmtPgwireText,
+				FmtDataConversionConfig(ctx.dataConversionConfig), FmtLocation(ctx.location))
+			pgwireFormatStringInRange(buf, s)
+		}
+	}
+	if d.LowerInc {
+		buf.WriteByte('[')
+	} else {
+		buf.WriteByte('(')
+	}
+	formatBound(d.Lower)
+	buf.WriteByte(',')
+	formatBound(d.Upper)
+	if d.UpperInc {
+		buf.WriteByte(']')
+	} else {
+		buf.WriteByte(')')
+	}
+}
+
+// Size implements the Datum interface.
+func (d *DRange) Size() uintptr {
+	sz := unsafe.Sizeof(*d)
+	if d.Lower != nil {
+		sz += d.Lower.Size()
+	}
+	if d.Upper != nil {
+		sz += d.Upper.Size()
+	}
+	return sz
+}
+
 // DPGVector is the Datum representation of the PGVector type.
 type DPGVector struct {
 	vector.T
@@ -4851,6 +5358,13 @@ type DArray struct {
 
 	// customOid, if non-0, is the oid of this array datum.
 	customOid oid.Oid
+
+	// lowerBoundOffset is the difference between the lower bound of the
+	// array's outermost dimension and its default lower bound, FirstIndex. It
+	// is only non-zero for arrays whose bounds were given explicitly, as in
+	// '[0:1]={a,b}'. The lower bounds of the inner dimensions of a
+	// multi-dimensional array are those of its elements.
+	lowerBoundOffset int32
 }
 
 // NewDArray returns a DArray containing elements of the specified type.
@@ -4931,6 +5445,183 @@ func (d *DArray) FirstIndex() int {
 	return 1
 }
 
+// LowerBound returns the lower bound of the array's outermost dimension.
+func (d *DArray) LowerBound() int {
+	return d.FirstIndex() + int(d.lowerBoundOffset)
+}
+
+// SetLowerBound sets the lower bound of the array's outermost dimension.
+func (d *DArray) SetLowerBound(lowerBound int) {
+	d.lowerBoundOffset = int32(lowerBound - d.FirstIndex())
+}
+
+// innerArray returns the first element of a multi-dimensional array, whose
+// dimensions are those of all its elements, or nil if the array is empty or
+// one-dimensional.
+func (d *DArray) innerArray() *DArray {
+	if !isMultiDimArrayElemType(d.ParamTyp) || d.Len() == 0 || d.Array[0] == DNull {
+		return nil
+	}
+	return MustBeDArray(d.Array[0])
+}
+
+// isMultiDimArrayElemType returns whether an array with elements of type t is
+// multi-dimensional. The vector types are arrays, but they are treated as
+// scalar elements.
+func isMultiDimArrayElemType(t *types.T) bool {
+	if t.Family() != types.ArrayFamily {
+		return false
+	}
+	switch t.Oid() {
+	case oid.T_int2vector, oid.T_oidvector:
+		return false
+	}
+	return true
+}
+
+// Dimensions returns the length of each dimension of the array, from the
+// outermost to the innermost. An empty array has no dimensions.
+func (d *DArray) Dimensions() []int {
+	var dims []int
+	for a := d; a != nil && a.Len() > 0; a = a.innerArray() {
+		dims = append(dims, a.Len())
+	}
+	return dims
+}
+
+// LowerBounds returns the lower bound of each of the array's dimensions, from
+// the outermost to the innermost.
+func (d *DArray) LowerBounds() []int {
+	var lowerBounds []int
+	for a := d; a != nil && a.Len() > 0; a = a.innerArray() {
+		lowerBounds = append(lowerBounds, a.LowerBound())
+	}
+	return lowerBounds
+}
+
+// HasDefaultLowerBounds returns whether all of the array's dimensions have
+// their default lower bound.
+func (d *DArray) HasDefaultLowerBounds() bool {
+	for a := d; a != nil; a = a.innerArray() {
+		if a.lowerBoundOffset != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// ScalarType returns the type of the scalar elements of the array, which is
+// the element type of its innermost dimension.
+func (d *DArray) ScalarType() *types.T {
+	return ArrayScalarType(d.ParamTyp)
+}
+
+// ArrayScalarType returns the type of the scalar elements of an array whose
+// elements have type t. It is t itself unless the array is multi-dimensional.
+func ArrayScalarType(t *types.T) *types.T {
+	for isMultiDimArrayElemType(t) {
+		t = t.ArrayContents()
+	}
+	return t
+}
+
+// ScalarElements returns the scalar elements of the array in row-major order.
+// For a one-dimensional array, these are its elements.
+func (d *DArray) ScalarElements() Datums {
+	if !isMultiDimArrayElemType(d.ParamTyp) {
+		return d.Array
+	}
+	var elems Datums
+	for _, e := range d.Array {
+		if e == DNull {
+			continue
+		}
+		elems = append(elems, MustBeDArray(e).ScalarElements()...)
+	}
+	return elems
+}
+
+// NewMultiDimDArray returns a, possibly multi-dimensional, array containing
+// elements of the specified type. The elements are given in row-major order,
+// along with the length of each dimension. lowerBounds is either nil, for the
+// default lower bounds, or contains the lower bound of each dimension. The
+// number of dimensions must match the number of levels of nesting of the
+// array's type, unless the array is empty.
+func NewMultiDimDArray(
+	paramTyp *types.T, dims []int, lowerBounds []int, elems Datums,
+) (*DArray, error) {
+	if len(dims) > MaxArrayDimensions {
+		return nil, pgerror.Newf(pgcode.ProgramLimitExceeded,
+			"number of array dimensions (%d) exceeds the maximum allowed (%d)",
+			len(dims), MaxArrayDimensions)
+	}
+	numElems := 1
+	for _, dim := range dims {
+		numElems *= dim
+		if dim < 0 || numElems > maxArrayLength {
+			return nil, errors.WithStack(errArrayTooLongError)
+		}
+	}
+	if len(dims) == 0 || numElems == 0 {
+		if len(elems) != 0 {
+			return nil, errors.AssertionFailedf("expected no elements, found %d", len(elems))
+		}
+		return NewDArray(paramTyp), nil
+	}
+	if numElems != len(elems) {
+		return nil, errors.AssertionFailedf("expected %d elements, found %d", numElems, len(elems))
+	}
+	if lowerBounds != nil && len(lowerBounds) != len(dims) {
+		return nil, errors.AssertionFailedf(
+			"expected %d lower bounds, found %d", len(dims), len(lowerBounds),
+		)
+	}
+	typ := paramTyp
+	for i := 1; i < len(dims); i++ {
+		if !isMultiDimArrayElemType(typ) {
+			return nil, pgerror.Newf(pgcode.DatatypeMismatch,
+				"cannot use %d-dimensional array as type %s",
+				len(dims), types.MakeArray(paramTyp).SQLStringForError())
+		}
+		typ = typ.ArrayContents()
+	}
+	if isMultiDimArrayElemType(typ) {
+		return nil, pgerror.Newf(pgcode.DatatypeMismatch,
+			"cannot use %d-dimensional array as type %s",
+			len(dims), types.MakeArray(paramTyp).SQLStringForError())
+	}
+	var build func(paramTyp *types.T, level int, elems Datums) (*DArray, error)
+	build = func(paramTyp *types.T, level int, elems Datums) (*DArray, error) {
+		a := &DArray{ParamTyp: paramTyp, Array: make(Datums, 0, dims[level])}
+		if lowerBounds != nil {
+			if upper := int64(lowerBounds[level]) + int64(dims[level]) - 1; upper > math.MaxInt32 || lowerBounds[level] < math.MinInt32 {
+				return nil, pgerror.New(pgcode.ProgramLimitExceeded, "array upper bound is too large")
+			}
+			a.SetLowerBound(lowerBounds[level])
+		}
+		if level == len(dims)-1 {
+			for _, e := range elems {
+				if err := a.Append(e); err != nil {
+					return nil, err
+				}
+			}
+			return a, nil
+		}
+		size := len(elems) / dims[level]
+		for i := 0; i < dims[level]; i++ {
+			inner, err := build(paramTyp.ArrayContents(), level+1, elems[i*size:(i+1)*size])
+			if err != nil {
+				return nil, err
+			}
+			if err := a.Append(inner); err != nil {
+				return nil, err
+			}
+		}
+		return a, nil
+	}
+	return build(paramTyp, 0, elems)
+}
+
 // Compare implements the Datum interface.
 func (d *DArray) Compare(ctx context.Context, cmpCtx CompareContext, other Datum) (int, error) {
 	if other == DNull {
@@ -4960,6 +5651,13 @@ func (d *DArray) Compare(ctx context.Context, cmpCtx CompareContext, other Datum
 	if d.Len() > v.Len() {
 		return 1, nil
 	}
+	// Arrays with the same elements are ordered by their lower bounds, as in
+	// Postgres.
+	if lb, vlb := d.LowerBound(), v.LowerBound(); lb < vlb {
+		return -1, nil
+	} else if lb > vlb {
+		return 1, nil
+	}
 	return 0, nil
 }
 
@@ -5006,7 +5704,8 @@ func (d *DArray) AmbiguousFormat() bool {
 		// a valid type. So an array of unknown type is (paradoxically) unambiguous.
 		return false
 	}
-	return !d.HasNonNulls
+	// Arrays with explicit lower bounds are formatted as string literals.
+	return !d.HasNonNulls || !d.HasDefaultLowerBounds()
 }
 
 // Format implements the NodeFormatter interface.
@@ -5019,6 +5718,14 @@ func (d *DArray) Format(ctx *FmtCtx) {
 		return
 	}
 
+	// The lower bounds of an array can only be expressed by its string
+	// representation, e.g. '[0:1]={a,b}'.
+	if !d.HasDefaultLowerBounds() {
+		s := AsStringWithFlags(d, FmtPgwireText, FmtDataConversionConfig(ctx.dataConversionConfig), FmtLocation(ctx.location))
+		lexbase.EncodeSQLStringWithFlags(&ctx.Buffer, s, ctx.flags.EncodeFlags())
+		return
+	}
+
 	// If we want to export arrays, we need to ensure that
 	// the datums within the arrays are formatted with enclosing quotes etc.
 	if ctx.HasFlags(FmtExport) {
@@ -5039,6 +5746,10 @@ func (d *DArray) Format(ctx *FmtCtx) {
 
 const maxArrayLength = math.MaxInt32
 
+// MaxArrayDimensions is the maximum number of dimensions of an array, which
+// matches Postgres' MAXDIM.
+const MaxArrayDimensions = 6
+
 var errArrayTooLongError = errors.New("ARRAYs can be at most 2^31-1 elements long")
 
 // Validate checks that the given array is valid,
@@ -5090,8 +5801,7 @@ func (d *DArray) Append(v Datum) error {
 			if prevItem == DNull {
 				return errNonHomogeneousArray
 			}
-			expectedLen := MustBeDArray(prevItem).Len()
-			if MustBeDArray(v).Len() != expectedLen {
+			if !sameArrayShape(MustBeDArray(prevItem), MustBeDArray(v)) {
 				return errNonHomogeneousArray
 			}
 		}
@@ -5105,6 +5815,18 @@ func (d *DArray) Append(v Datum) error {
 	return d.Validate()
 }
 
+// sameArrayShape returns whether the arrays have the same dimensions and lower
+// bounds, as is required of the elements of a multi-dimensional array.
+func sameArrayShape(a, b *DArray) bool {
+	for a != nil && b != nil {
+		if a.Len() != b.Len() || a.lowerBoundOffset != b.lowerBoundOffset {
+			return false
+		}
+		a, b = a.innerArray(), b.innerArray()
+	}
+	return a == nil && b == nil
+}
+
 // DVoid represents a void type.
 type DVoid struct{}
 
@@ -6073,6 +6795,7 @@ var baseDatumTypeSizes = map[types.Family]struct {
 	types.PGLSNFamily:          {unsafe.Sizeof(DPGLSN{}), fixedSize},
 	types.PGVectorFamily:       {unsafe.Sizeof(DPGVector{}), variableSize},
 	types.RefCursorFamily:      {unsafe.Sizeof(DString("")), variableSize},
+	types.RangeFamily:          {unsafe.Sizeof(DRange{}), variableSize},
 	types.TimeFamily:           {unsafe.Sizeof(DTime(0)), fixedSize},
 	types.TimeTZFamily:         {unsafe.Sizeof(DTimeTZ{}), fixedSize},
 	types.TimestampFamily:      {unsafe.Sizeof(DTimestamp{}), fixedSize},
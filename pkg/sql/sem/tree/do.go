@@ -0,0 +1,73 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/errors"
+)
+
+// DoBlock represents a DO statement, which executes an anonymous code block
+// once in the current transaction.
+type DoBlock struct {
+	// Code is the unparsed body of the code block.
+	Code string
+	// Language is the procedural language of the code block. It defaults to
+	// PL/pgSQL.
+	Language RoutineLanguage
+}
+
+// MakeDoBlock constructs a DoBlock from the options given to a DO statement.
+// Exactly one code block must be specified, and at most one language.
+func MakeDoBlock(options RoutineOptions) (*DoBlock, error) {
+	var hasCode, hasLang bool
+	node := &DoBlock{Language: RoutineLangPLpgSQL}
+	for _, option := range options {
+		switch t := option.(type) {
+		case RoutineBodyStr:
+			if hasCode {
+				return nil, ErrConflictingRoutineOption
+			}
+			hasCode = true
+			node.Code = string(t)
+		case RoutineLanguage:
+			if hasLang {
+				return nil, ErrConflictingRoutineOption
+			}
+			hasLang = true
+			node.Language = t
+		default:
+			return nil, errors.AssertionFailedf("unexpected DO option: %T", t)
+		}
+	}
+	if !hasCode {
+		return nil, pgerror.New(pgcode.Syntax, "no inline code specified")
+	}
+	return node, nil
+}
+
+// Format implements the NodeFormatter interface.
+func (node *DoBlock) Format(ctx *FmtCtx) {
+	ctx.WriteString("DO ")
+	if node.Language != RoutineLangPLpgSQL {
+		ctx.FormatNode(node.Language)
+		ctx.WriteByte(' ')
+	}
+	if ctx.flags.HasFlags(FmtHideConstants) || ctx.flags.HasFlags(FmtAnonymize) {
+		ctx.WriteString("'_'")
+	} else {
+		lexbase.EncodeSQLStringWithFlags(&ctx.Buffer, node.Code, ctx.flags.EncodeFlags())
+	}
+}
+
+var _ Statement = &DoBlock{}
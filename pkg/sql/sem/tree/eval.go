@@ -359,20 +359,23 @@ func ConcatArrays(typ *types.T, left Datum, right Datum) (Datum, error) {
 	return result, nil
 }
 
-// ArrayContains return true if the haystack contains all needles.
+// ArrayContains return true if the haystack contains all needles. As in
+// Postgres, multi-dimensional arrays are compared by their scalar elements,
+// regardless of their dimensions.
 func ArrayContains(
 	ctx context.Context, cmpCtx CompareContext, haystack *DArray, needles *DArray,
 ) (*DBool, error) {
-	if !haystack.ParamTyp.Equivalent(needles.ParamTyp) {
+	if !haystack.ScalarType().Equivalent(needles.ScalarType()) {
 		return DBoolFalse, pgerror.New(pgcode.DatatypeMismatch, "cannot compare arrays with different element types")
 	}
-	for _, needle := range needles.Array {
+	hayElems := haystack.ScalarElements()
+	for _, needle := range needles.ScalarElements() {
 		// Nulls don't compare to each other in @> syntax.
 		if needle == DNull {
 			return DBoolFalse, nil
 		}
 		var found bool
-		for _, hay := range haystack.Array {
+		for _, hay := range hayElems {
 			if cmp, err := needle.Compare(ctx, cmpCtx, hay); err != nil {
 				return DBoolFalse, err
 			} else if cmp == 0 {
@@ -388,19 +391,21 @@ func ArrayContains(
 }
 
 // ArrayOverlaps return true if there is even one element
-// common between the left and right arrays.
+// common between the left and right arrays. Multi-dimensional arrays are
+// compared by their scalar elements.
 func ArrayOverlaps(
 	ctx context.Context, cmpCtx CompareContext, array, other *DArray,
 ) (*DBool, error) {
-	if !array.ParamTyp.Equivalent(other.ParamTyp) {
+	if !array.ScalarType().Equivalent(other.ScalarType()) {
 		return nil, pgerror.New(pgcode.DatatypeMismatch, "cannot compare arrays with different element types")
 	}
-	for _, needle := range array.Array {
+	otherElems := other.ScalarElements()
+	for _, needle := range array.ScalarElements() {
 		// Nulls don't compare to each other in && syntax.
 		if needle == DNull {
 			continue
 		}
-		for _, hay := range other.Array {
+		for _, hay := range otherElems {
 			if cmp, err := needle.Compare(ctx, cmpCtx, hay); err != nil {
 				return DBoolFalse, err
 			} else if cmp == 0 {
@@ -488,6 +493,10 @@ func init() {
 		_ = overload.ForEachBinOp(func(impl *BinOp) error {
 			impl.types = ParamTypes{{"left", impl.LeftType}, {"right", impl.RightType}}
 			impl.retType = FixedReturnType(impl.ReturnType)
+			if impl.ReturnType == types.AnyRange {
+				// Operations on ranges return a range of the type of their inputs.
+				impl.retType = FirstNonNullReturnType()
+			}
 			return nil
 		})
 	}
@@ -618,7 +627,7 @@ var BinOps = map[treebin.BinaryOperatorSymbol]*BinOpOverloads{
 		},
 	}},
 
-	treebin.Plus: {overloads: []*BinOp{
+	treebin.Plus: {overloads: append([]*BinOp{
 		{
 			LeftType:   types.Int,
 			RightType:  types.Int,
@@ -808,9 +817,9 @@ var BinOps = map[treebin.BinaryOperatorSymbol]*BinOpOverloads{
 			EvalOp:     &PlusPGVectorOp{},
 			Volatility: volatility.Immutable,
 		},
-	}},
+	}, makeRangeBinOps(&PlusRangeOp{})...)},
 
-	treebin.Minus: {overloads: []*BinOp{
+	treebin.Minus: {overloads: append([]*BinOp{
 		{
 			LeftType:   types.Int,
 			RightType:  types.Int,
@@ -1001,9 +1010,9 @@ var BinOps = map[treebin.BinaryOperatorSymbol]*BinOpOverloads{
 			EvalOp:     &MinusPGVectorOp{},
 			Volatility: volatility.Immutable,
 		},
-	}},
+	}, makeRangeBinOps(&MinusRangeOp{})...)},
 
-	treebin.Mult: {overloads: []*BinOp{
+	treebin.Mult: {overloads: append([]*BinOp{
 		{
 			LeftType:   types.Int,
 			RightType:  types.Int,
@@ -1091,7 +1100,7 @@ var BinOps = map[treebin.BinaryOperatorSymbol]*BinOpOverloads{
 			EvalOp:     &MultPGVectorOp{},
 			Volatility: volatility.Immutable,
 		},
-	}},
+	}, makeRangeBinOps(&MultRangeOp{})...)},
 
 	treebin.Div: {overloads: []*BinOp{
 		{
@@ -1602,7 +1611,7 @@ func makeIsFn(a, b *types.T, v volatility.V) *CmpOp {
 
 // CmpOps contains the comparison operations indexed by operation type.
 var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
-	treecmp.EQ: {overloads: []*CmpOp{
+	treecmp.EQ: {overloads: append([]*CmpOp{
 		// Single-type comparisons.
 		makeEqFn(types.AnyEnum, types.AnyEnum, volatility.Immutable),
 		makeEqFn(types.Bool, types.Bool, volatility.Leakproof),
@@ -1662,9 +1671,9 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 			},
 			Volatility: volatility.Immutable,
 		},
-	}},
+	}, makeRangeOrderingOps(makeEqFn)...)},
 
-	treecmp.LT: {overloads: []*CmpOp{
+	treecmp.LT: {overloads: append([]*CmpOp{
 		// Single-type comparisons.
 		makeLtFn(types.AnyEnum, types.AnyEnum, volatility.Immutable),
 		makeLtFn(types.Bool, types.Bool, volatility.Leakproof),
@@ -1722,9 +1731,9 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 			},
 			Volatility: volatility.Immutable,
 		},
-	}},
+	}, makeRangeOrderingOps(makeLtFn)...)},
 
-	treecmp.LE: {overloads: []*CmpOp{
+	treecmp.LE: {overloads: append([]*CmpOp{
 		// Single-type comparisons.
 		makeLeFn(types.AnyEnum, types.AnyEnum, volatility.Immutable),
 		makeLeFn(types.Bool, types.Bool, volatility.Leakproof),
@@ -1782,9 +1791,9 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 			},
 			Volatility: volatility.Immutable,
 		},
-	}},
+	}, makeRangeOrderingOps(makeLeFn)...)},
 
-	treecmp.IsNotDistinctFrom: {overloads: []*CmpOp{
+	treecmp.IsNotDistinctFrom: {overloads: append([]*CmpOp{
 		{
 			LeftType:  types.Unknown,
 			RightType: types.Unknown,
@@ -1874,9 +1883,9 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 			},
 			Volatility: volatility.Immutable,
 		},
-	}},
+	}, makeRangeOrderingOps(makeIsFn)...)},
 
-	treecmp.In: {overloads: []*CmpOp{
+	treecmp.In: {overloads: append([]*CmpOp{
 		makeEvalTupleIn(types.AnyEnum, volatility.Leakproof),
 		makeEvalTupleIn(types.Bool, volatility.Leakproof),
 		makeEvalTupleIn(types.Bytes, volatility.Leakproof),
@@ -1903,7 +1912,9 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 		makeEvalTupleIn(types.TimestampTZ, volatility.Leakproof),
 		makeEvalTupleIn(types.Uuid, volatility.Leakproof),
 		makeEvalTupleIn(types.VarBit, volatility.Leakproof),
-	}},
+	}, makeRangeOrderingOps(func(t, _ *types.T, v volatility.V) *CmpOp {
+		return makeEvalTupleIn(t, v)
+	})...)},
 
 	treecmp.Like: {overloads: []*CmpOp{
 		{
@@ -1983,53 +1994,67 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 		},
 	}},
 
-	treecmp.Contains: {overloads: []*CmpOp{
-		{
-			LeftType:   types.AnyArray,
-			RightType:  types.AnyArray,
-			EvalOp:     &ContainsArrayOp{},
-			Volatility: volatility.Immutable,
-		},
-		{
-			LeftType:   types.Jsonb,
-			RightType:  types.Jsonb,
-			EvalOp:     &ContainsJsonbOp{},
-			Volatility: volatility.Immutable,
+	treecmp.Contains: {overloads: concatCmpOps(
+		[]*CmpOp{
+			{
+				LeftType:   types.AnyArray,
+				RightType:  types.AnyArray,
+				EvalOp:     &ContainsArrayOp{},
+				Volatility: volatility.Immutable,
+			},
+			{
+				LeftType:   types.Jsonb,
+				RightType:  types.Jsonb,
+				EvalOp:     &ContainsJsonbOp{},
+				Volatility: volatility.Immutable,
+			},
 		},
-	}},
+		makeRangeCmpOps(&ContainsRangeOp{}, false /* leftElem */, false /* rightElem */),
+		makeRangeCmpOps(&ContainsRangeElemOp{}, false /* leftElem */, true /* rightElem */),
+	)},
 
-	treecmp.ContainedBy: {overloads: []*CmpOp{
-		{
-			LeftType:   types.AnyArray,
-			RightType:  types.AnyArray,
-			EvalOp:     &ContainedByArrayOp{},
-			Volatility: volatility.Immutable,
-		},
-		{
-			LeftType:   types.Jsonb,
-			RightType:  types.Jsonb,
-			EvalOp:     &ContainedByJsonbOp{},
-			Volatility: volatility.Immutable,
-		},
-	}},
-	treecmp.Overlaps: {overloads: append([]*CmpOp{
-		{
-			LeftType:   types.AnyArray,
-			RightType:  types.AnyArray,
-			EvalOp:     &OverlapsArrayOp{},
-			Volatility: volatility.Immutable,
-		},
-		{
-			LeftType:   types.INet,
-			RightType:  types.INet,
-			EvalOp:     &OverlapsINetOp{},
-			Volatility: volatility.Immutable,
+	treecmp.ContainedBy: {overloads: concatCmpOps(
+		[]*CmpOp{
+			{
+				LeftType:   types.AnyArray,
+				RightType:  types.AnyArray,
+				EvalOp:     &ContainedByArrayOp{},
+				Volatility: volatility.Immutable,
+			},
+			{
+				LeftType:   types.Jsonb,
+				RightType:  types.Jsonb,
+				EvalOp:     &ContainedByJsonbOp{},
+				Volatility: volatility.Immutable,
+			},
 		},
-	}, makeBox2DComparisonOperators(
-		func(lhs, rhs *geo.CartesianBoundingBox) bool {
-			return lhs.Intersects(rhs)
+		makeRangeCmpOps(&ContainedByRangeOp{}, false /* leftElem */, false /* rightElem */),
+		makeRangeCmpOps(&ContainedByElemRangeOp{}, true /* leftElem */, false /* rightElem */),
+	)},
+	treecmp.Overlaps: {overloads: concatCmpOps(
+		[]*CmpOp{
+			{
+				LeftType:   types.AnyArray,
+				RightType:  types.AnyArray,
+				EvalOp:     &OverlapsArrayOp{},
+				Volatility: volatility.Immutable,
+			},
+			{
+				LeftType:   types.INet,
+				RightType:  types.INet,
+				EvalOp:     &OverlapsINetOp{},
+				Volatility: volatility.Immutable,
+			},
 		},
-	)...),
+		makeBox2DComparisonOperators(
+			func(lhs, rhs *geo.CartesianBoundingBox) bool {
+				return lhs.Intersects(rhs)
+			},
+		),
+		makeRangeCmpOps(&OverlapsRangeOp{}, false /* leftElem */, false /* rightElem */),
+	)},
+	treecmp.Adjacent: {overloads: makeRangeCmpOps(
+		&AdjacentRangeOp{}, false /* leftElem */, false /* rightElem */),
 	},
 	treecmp.TSMatches: {overloads: []*CmpOp{
 		{
@@ -2047,6 +2072,68 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 	}},
 })
 
+// concatCmpOps returns the concatenation of the given lists of overloads.
+func concatCmpOps(ops ...[]*CmpOp) []*CmpOp {
+	var res []*CmpOp
+	for _, o := range ops {
+		res = append(res, o...)
+	}
+	return res
+}
+
+// makeRangeOrderingOps returns the overload built by fn comparing two ranges.
+// The overload accepts ranges of any type, including user-defined range types,
+// and type checking rejects comparisons between ranges of different types.
+func makeRangeOrderingOps(fn func(a, b *types.T, v volatility.V) *CmpOp) []*CmpOp {
+	return []*CmpOp{fn(types.AnyRange, types.AnyRange, volatility.Immutable)}
+}
+
+// makeRangeCmpOps returns the overloads of a comparison operator on ranges.
+// If neither leftElem nor rightElem is set, this is a single overload on two
+// ranges of the same type, like for makeRangeOrderingOps. Otherwise, there is
+// an overload for each of the built-in range types, in which the argument
+// selected by leftElem or rightElem is of the element type of the range type.
+func makeRangeCmpOps(op BinaryEvalOp, leftElem, rightElem bool) []*CmpOp {
+	if !leftElem && !rightElem {
+		return []*CmpOp{{
+			LeftType:   types.AnyRange,
+			RightType:  types.AnyRange,
+			EvalOp:     op,
+			Volatility: volatility.Immutable,
+		}}
+	}
+	ops := make([]*CmpOp, len(types.RangeTypes))
+	for i, t := range types.RangeTypes {
+		left, right := t, t
+		if leftElem {
+			left = t.RangeContents()
+		}
+		if rightElem {
+			right = t.RangeContents()
+		}
+		ops[i] = &CmpOp{
+			LeftType:   left,
+			RightType:  right,
+			EvalOp:     op,
+			Volatility: volatility.Immutable,
+		}
+	}
+	return ops
+}
+
+// makeRangeBinOps returns the overload of a binary operator on two ranges of
+// the same type, returning a range of that type. Type checking rejects
+// operations on ranges of different types.
+func makeRangeBinOps(op BinaryEvalOp) []*BinOp {
+	return []*BinOp{{
+		LeftType:   types.AnyRange,
+		RightType:  types.AnyRange,
+		ReturnType: types.AnyRange,
+		EvalOp:     op,
+		Volatility: volatility.Immutable,
+	}}
+}
+
 func makeBox2DComparisonOperators(op func(lhs, rhs *geo.CartesianBoundingBox) bool) []*CmpOp {
 	return []*CmpOp{
 		{
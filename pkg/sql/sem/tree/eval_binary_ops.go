@@ -75,6 +75,12 @@ type OverlapsArrayOp struct{}
 // OverlapsINetOp is a BinaryEvalOp.
 type OverlapsINetOp struct{}
 
+// OverlapsRangeOp is a BinaryEvalOp.
+type OverlapsRangeOp struct{}
+
+// AdjacentRangeOp is a BinaryEvalOp.
+type AdjacentRangeOp struct{}
+
 // TSMatchesVectorQueryOp is a BinaryEvalOp.
 type TSMatchesVectorQueryOp struct{}
 
@@ -191,6 +197,8 @@ type (
 	PlusPGLSNDecimalOp struct{}
 	// PlusPGVectorOp is a BinaryEvalOp.
 	PlusPGVectorOp struct{}
+	// PlusRangeOp is a BinaryEvalOp.
+	PlusRangeOp struct{}
 )
 
 type (
@@ -248,6 +256,8 @@ type (
 	MinusPGLSNOp struct{}
 	// MinusPGVectorOp is a BinaryEvalOp.
 	MinusPGVectorOp struct{}
+	// MinusRangeOp is a BinaryEvalOp.
+	MinusRangeOp struct{}
 )
 type (
 	// MultDecimalIntOp is a BinaryEvalOp.
@@ -274,6 +284,8 @@ type (
 	MultIntervalIntOp struct{}
 	// MultPGVectorOp is a BinaryEvalOp.
 	MultPGVectorOp struct{}
+	// MultRangeOp is a BinaryEvalOp.
+	MultRangeOp struct{}
 )
 
 type (
@@ -397,8 +409,20 @@ type ContainsArrayOp struct{}
 // ContainsJsonbOp is a BinaryEvalOp.
 type ContainsJsonbOp struct{}
 
+// ContainsRangeOp is a BinaryEvalOp.
+type ContainsRangeOp struct{}
+
+// ContainsRangeElemOp is a BinaryEvalOp.
+type ContainsRangeElemOp struct{}
+
 // ContainedByArrayOp is a BinaryEvalOp.
 type ContainedByArrayOp struct{}
 
 // ContainedByJsonbOp is a BinaryEvalOp.
 type ContainedByJsonbOp struct{}
+
+// ContainedByRangeOp is a BinaryEvalOp.
+type ContainedByRangeOp struct{}
+
+// ContainedByElemRangeOp is a BinaryEvalOp.
+type ContainedByElemRangeOp struct{}
@@ -915,6 +915,22 @@ func (node *ArrayFlatten) Format(ctx *FmtCtx) {
 	}
 }
 
+// GroupingExpr represents a GROUPING(...) operation, which returns a bit mask
+// indicating which of its arguments are not included in the grouping set of
+// the current row. It is only valid in the SELECT list, HAVING and ORDER BY
+// clauses of a query with a GROUP BY clause, where the optimizer replaces it
+// with a reference to the current grouping set.
+type GroupingExpr struct {
+	Exprs Exprs
+}
+
+// Format implements the NodeFormatter interface.
+func (node *GroupingExpr) Format(ctx *FmtCtx) {
+	ctx.WriteString("GROUPING(")
+	ctx.FormatNode(&node.Exprs)
+	ctx.WriteByte(')')
+}
+
 // Exprs represents a list of value expressions. It's not a valid expression
 // because it's not parenthesized.
 type Exprs []Expr
@@ -1277,6 +1293,11 @@ type FuncExpr struct {
 	// InCall is true when the FuncExpr is part of a CALL statement.
 	InCall bool
 
+	// Variadic is true when the last argument is marked VARIADIC, in which
+	// case it is an array which is passed as is to the VARIADIC parameter of a
+	// user-defined routine.
+	Variadic bool
+
 	typeAnnotation
 	fnProps *FunctionProperties
 	fn      *Overload
@@ -1388,7 +1409,17 @@ func (node *FuncExpr) Format(ctx *FmtCtx) {
 
 	ctx.WriteByte('(')
 	ctx.WriteString(typ)
-	ctx.FormatNode(&node.Exprs)
+	if n := len(node.Exprs); node.Variadic && n > 0 {
+		if n > 1 {
+			fixed := node.Exprs[:n-1]
+			ctx.FormatNode(&fixed)
+			ctx.WriteString(", ")
+		}
+		ctx.WriteString("VARIADIC ")
+		ctx.FormatNode(node.Exprs[n-1])
+	} else {
+		ctx.FormatNode(&node.Exprs)
+	}
 	if node.AggType == GeneralAgg && len(node.OrderBy) > 0 {
 		ctx.WriteByte(' ')
 		ctx.FormatNode(&node.OrderBy)
@@ -1739,6 +1770,7 @@ func (node *DDecimal) String() string         { return AsString(node) }
 func (node *DFloat) String() string           { return AsString(node) }
 func (node *DBox2D) String() string           { return AsString(node) }
 func (node *DPGLSN) String() string           { return AsString(node) }
+func (node *DRange) String() string           { return AsString(node) }
 func (node *DGeography) String() string       { return AsString(node) }
 func (node *DGeometry) String() string        { return AsString(node) }
 func (node *DInt) String() string             { return AsString(node) }
@@ -1758,6 +1790,8 @@ func (node *DVoid) String() string            { return AsString(node) }
 func (node *Exprs) String() string            { return AsString(node) }
 func (node *ArrayFlatten) String() string     { return AsString(node) }
 func (node *FuncExpr) String() string         { return AsString(node) }
+func (node *GroupingExpr) String() string     { return AsString(node) }
+func (node *GroupingSet) String() string      { return AsString(node) }
 func (node *IfExpr) String() string           { return AsString(node) }
 func (node *IfErrExpr) String() string        { return AsString(node) }
 func (node *IndexedVar) String() string       { return AsString(node) }
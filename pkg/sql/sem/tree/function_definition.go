@@ -319,13 +319,14 @@ func (fd *ResolvedFunctionDefinition) MatchOverload(
 		// Special handling of routines.
 		//
 		// First, apply regular postgres resolution approach of using only
-		// the input types.
-		if ol.params().MatchIdentical(paramTypes) {
+		// the input types. Note that a VARIADIC parameter is identified by its
+		// array type, so Types is used rather than params().
+		if ol.Types.MatchIdentical(paramTypes) {
 			return true
 		}
 		if tryDefaultExprs && len(ol.defaultExprs()) > 0 {
 			// Check whether any of the input arguments might have been omitted.
-			// TODO(88947): this logic might need to change to support VARIADIC.
+			// Note that a VARIADIC parameter cannot have a DEFAULT expression.
 			if inputTypes, ok := ol.Types.(ParamTypes); ok {
 				numOmittedExprs := len(inputTypes) - len(paramTypes)
 				if numOmittedExprs > 0 && numOmittedExprs <= len(inputTypes) {
@@ -0,0 +1,114 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+// Merge represents a MERGE statement.
+type Merge struct {
+	With   *With
+	Table  TableExpr
+	Source TableExpr
+	On     Expr
+	Whens  MergeWhens
+}
+
+// Format implements the NodeFormatter interface.
+func (node *Merge) Format(ctx *FmtCtx) {
+	ctx.FormatNode(node.With)
+	ctx.WriteString("MERGE INTO ")
+	ctx.FormatNode(node.Table)
+	ctx.WriteString(" USING ")
+	ctx.FormatNode(node.Source)
+	ctx.WriteString(" ON ")
+	ctx.FormatNode(node.On)
+	ctx.FormatNode(&node.Whens)
+}
+
+// MergeWhens represents the list of WHEN clauses of a MERGE statement.
+type MergeWhens []*MergeWhen
+
+// Format implements the NodeFormatter interface.
+func (node *MergeWhens) Format(ctx *FmtCtx) {
+	for _, n := range *node {
+		ctx.WriteByte(' ')
+		ctx.FormatNode(n)
+	}
+}
+
+// MergeActionType is the type of action performed by a WHEN clause of a MERGE
+// statement.
+type MergeActionType uint8
+
+const (
+	// MergeActionDoNothing skips the source row.
+	MergeActionDoNothing MergeActionType = iota
+	// MergeActionUpdate updates the matched target row.
+	MergeActionUpdate
+	// MergeActionDelete deletes the matched target row.
+	MergeActionDelete
+	// MergeActionInsert inserts a new row into the target table.
+	MergeActionInsert
+)
+
+// MergeWhen represents a single WHEN [NOT] MATCHED clause of a MERGE
+// statement.
+type MergeWhen struct {
+	// Matched is true for WHEN MATCHED clauses, and false for WHEN NOT MATCHED
+	// clauses.
+	Matched bool
+	// Cond is the optional AND condition of the clause.
+	Cond Expr
+	// Action is the action performed when the clause applies.
+	Action MergeActionType
+	// UpdateExprs are the SET expressions of an UPDATE action.
+	UpdateExprs UpdateExprs
+	// Columns are the (optional) target columns of an INSERT action.
+	Columns NameList
+	// Values are the values of an INSERT action. If it is nil, the INSERT
+	// action uses DEFAULT VALUES.
+	Values Exprs
+}
+
+// Format implements the NodeFormatter interface.
+func (node *MergeWhen) Format(ctx *FmtCtx) {
+	ctx.WriteString("WHEN ")
+	if !node.Matched {
+		ctx.WriteString("NOT ")
+	}
+	ctx.WriteString("MATCHED")
+	if node.Cond != nil {
+		ctx.WriteString(" AND ")
+		ctx.FormatNode(node.Cond)
+	}
+	ctx.WriteString(" THEN ")
+	switch node.Action {
+	case MergeActionDoNothing:
+		ctx.WriteString("DO NOTHING")
+	case MergeActionUpdate:
+		ctx.WriteString("UPDATE SET ")
+		ctx.FormatNode(&node.UpdateExprs)
+	case MergeActionDelete:
+		ctx.WriteString("DELETE")
+	case MergeActionInsert:
+		ctx.WriteString("INSERT")
+		if node.Columns != nil {
+			ctx.WriteString(" (")
+			ctx.FormatNode(&node.Columns)
+			ctx.WriteByte(')')
+		}
+		if node.Values == nil {
+			ctx.WriteString(" DEFAULT VALUES")
+		} else {
+			ctx.WriteString(" VALUES (")
+			ctx.FormatNode(&node.Values)
+			ctx.WriteByte(')')
+		}
+	}
+}
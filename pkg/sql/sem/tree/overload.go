@@ -18,6 +18,7 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
@@ -295,10 +296,62 @@ type Overload struct {
 	// UDFContainsOnlySignature is false, then DEFAULT expressions are included
 	// into RoutineParams.
 	DefaultExprs Exprs
+	// Variadic is set if the last input parameter of a user-defined routine is
+	// VARIADIC. In that case the last element of Types is an array type, and
+	// the overload matches any number of trailing arguments of the array's
+	// element type.
+	Variadic bool
+	// UDFAggregate is set if the overload represents a user-defined aggregate
+	// function. It is only set when UDFContainsOnlySignature is false.
+	UDFAggregate *UDFAggregate
+	// SecurityDefiner is true if the routine executes with the privileges of
+	// its owner rather than those of the invoking user. It is only set when
+	// UDFContainsOnlySignature is false.
+	SecurityDefiner bool
+	// Owner is the owner of the routine. It is only set if SecurityDefiner is
+	// true.
+	Owner username.SQLUsername
+	// Config contains the session variables that are set for the duration of
+	// each call to the routine, in the form "name=value". It is only set when
+	// UDFContainsOnlySignature is false.
+	Config []string
+}
+
+// UDFAggregate describes the functions which implement a user-defined
+// aggregate.
+type UDFAggregate struct {
+	// StateFunc is the OID of the state transition function.
+	StateFunc oid.Oid
+	// StateType is the type of the aggregate state.
+	StateType *types.T
+	// FinalFunc is the OID of the final function, or zero if the aggregate has
+	// no final function.
+	FinalFunc oid.Oid
+	// CombineFunc is the OID of the function which combines two partial states,
+	// or zero if the aggregate has no combine function.
+	CombineFunc oid.Oid
+	// InitCond is the string form of the initial state, or nil if the initial
+	// state is NULL.
+	InitCond *string
 }
 
 // params implements the overloadImpl interface.
-func (b Overload) params() TypeList { return b.Types }
+func (b Overload) params() TypeList {
+	if b.Variadic {
+		if p, ok := b.Types.(ParamTypes); ok && len(p) > 0 {
+			fixed := make([]*types.T, len(p)-1)
+			for i := range fixed {
+				fixed[i] = p[i].Typ
+			}
+			return VariadicType{
+				FixedTypes:     fixed,
+				VarType:        p[len(p)-1].Typ.ArrayContents(),
+				VarArgRequired: true,
+			}
+		}
+	}
+	return b.Types
+}
 
 // returnType implements the overloadImpl interface.
 func (b Overload) returnType() ReturnTyper { return b.ReturnType }
@@ -609,6 +662,11 @@ func (HomogeneousType) String() string {
 type VariadicType struct {
 	FixedTypes []*types.T
 	VarType    *types.T
+	// VarArgRequired is true if at least one variadic argument must be
+	// supplied. This is the case for the VARIADIC parameter of user-defined
+	// routines, which, as in Postgres, cannot be called with zero variadic
+	// arguments.
+	VarArgRequired bool
 }
 
 // Match is part of the TypeList interface.
@@ -622,7 +680,15 @@ func (v VariadicType) Match(types []*types.T) bool {
 }
 
 // MatchIdentical is part of the TypeList interface.
-func (VariadicType) MatchIdentical(types []*types.T) bool {
+func (v VariadicType) MatchIdentical(types []*types.T) bool {
+	if !v.MatchLen(len(types)) {
+		return false
+	}
+	for i := range types {
+		if !v.MatchAtIdentical(types[i], i) {
+			return false
+		}
+	}
 	return true
 }
 
@@ -635,13 +701,18 @@ func (v VariadicType) MatchAt(typ *types.T, i int) bool {
 }
 
 // MatchAtIdentical is part of the TypeList interface.
-// TODO(mgartner): This will be incorrect once we add support for variadic UDFs
-func (VariadicType) MatchAtIdentical(typ *types.T, i int) bool {
-	return true
+func (v VariadicType) MatchAtIdentical(typ *types.T, i int) bool {
+	if i < len(v.FixedTypes) {
+		return typ.Family() == types.UnknownFamily || v.FixedTypes[i].Identical(typ)
+	}
+	return typ.Family() == types.UnknownFamily || v.VarType.Identical(typ)
 }
 
 // MatchLen is part of the TypeList interface.
 func (v VariadicType) MatchLen(l int) bool {
+	if v.VarArgRequired {
+		return l > len(v.FixedTypes)
+	}
 	return l >= len(v.FixedTypes)
 }
 
@@ -960,8 +1031,9 @@ func (s *overloadTypeChecker) typeCheckOverloadedExprs(
 			return params.MatchLen(numInputExprs)
 		}
 		// Some "suffix" parameters have DEFAULT expressions, so values for them
-		// can be omitted from the input expressions.
-		// TODO(88947): this logic might need to change to support VARIADIC.
+		// can be omitted from the input expressions. Note that a VARIADIC
+		// parameter cannot have a DEFAULT expression, so params is never a
+		// VariadicType here.
 		paramsLen := params.Length()
 		return paramsLen-len(defaultExprs) <= numInputExprs && numInputExprs <= paramsLen
 	}
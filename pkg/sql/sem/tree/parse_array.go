@@ -12,6 +12,7 @@ package tree
 
 import (
 	"bytes"
+	"strconv"
 	"strings"
 	"unicode"
 	"unicode/utf8"
@@ -19,12 +20,13 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
-	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/errors"
 )
 
 var enclosingError = pgerror.Newf(pgcode.InvalidTextRepresentation, "array must be enclosed in { and }")
 var extraTextError = pgerror.Newf(pgcode.InvalidTextRepresentation, "extra text after closing right brace")
-var nestedArraysNotSupportedError = unimplemented.NewWithIssueDetail(32552, "strcast", "nested arrays not supported")
+var dimensionMismatchError = pgerror.Newf(pgcode.InvalidTextRepresentation, "multidimensional arrays must have sub-arrays with matching dimensions")
+var dimensionDecorationMismatchError = pgerror.Newf(pgcode.InvalidTextRepresentation, "specified array dimensions do not match array contents")
 var malformedError = pgerror.Newf(pgcode.InvalidTextRepresentation, "malformed array")
 
 func isQuoteChar(ch byte) bool {
@@ -121,8 +123,21 @@ type parseState struct {
 	s                string
 	ctx              ParseContext
 	dependsOnContext bool
-	result           *DArray
-	t                *types.T
+	// t is the type of the scalar elements of the array, and delimiter is the
+	// delimiter used between elements.
+	t         *types.T
+	delimiter string
+	// elems contains the scalar elements of the array in row-major order.
+	elems Datums
+	// dims contains the length of each dimension of the array, as determined
+	// by the first sub-array seen at each level of nesting. It is -1 for
+	// levels where no sub-array has been closed yet.
+	dims []int
+	// elemLevel is the level of nesting at which scalar elements appear, or -1
+	// if no scalar element has been seen.
+	elemLevel int
+	// maxLevel is the deepest level of nesting seen so far.
+	maxLevel int
 }
 
 func (p *parseState) advance() {
@@ -157,13 +172,20 @@ func (p *parseState) parseUnquotedString() (string, error) {
 	return trimSpaceInParseArray(out), nil
 }
 
-func (p *parseState) parseElement() error {
+// parseElement parses a scalar element found at the given level of nesting.
+func (p *parseState) parseElement(level int) error {
+	if p.maxLevel != level || (p.elemLevel != -1 && p.elemLevel != level) {
+		// Scalar elements and sub-arrays can't be mixed.
+		return malformedError
+	}
+	p.elemLevel = level
+	if len(p.elems) >= maxArrayLength {
+		return errors.WithStack(errArrayTooLongError)
+	}
 	var next string
 	var err error
 	r := p.peek()
 	switch r {
-	case '{':
-		return nestedArraysNotSupportedError
 	case '"':
 		p.advance()
 		next, err = p.parseQuotedString()
@@ -180,7 +202,8 @@ func (p *parseState) parseElement() error {
 			return err
 		}
 		if strings.EqualFold(next, "null") {
-			return p.result.Append(DNull)
+			p.elems = append(p.elems, DNull)
+			return nil
 		}
 	}
 
@@ -191,12 +214,143 @@ func (p *parseState) parseElement() error {
 	if dependsOnContext {
 		p.dependsOnContext = true
 	}
-	return p.result.Append(d)
+	p.elems = append(p.elems, d)
+	return nil
+}
+
+// parseArray parses a, possibly nested, array enclosed in braces, found at the
+// given level of nesting.
+func (p *parseState) parseArray(level int) error {
+	if level >= MaxArrayDimensions {
+		return pgerror.Newf(pgcode.ProgramLimitExceeded,
+			"number of array dimensions (%d) exceeds the maximum allowed (%d)",
+			level+1, MaxArrayDimensions)
+	}
+	if level > p.maxLevel {
+		if p.elemLevel != -1 && p.elemLevel < level {
+			// Scalar elements and sub-arrays can't be mixed.
+			return malformedError
+		}
+		p.maxLevel = level
+	}
+	if p.peek() != '{' {
+		return enclosingError
+	}
+	p.advance()
+	p.eatWhitespace()
+	count := 0
+	if p.peek() != '}' {
+		for {
+			var err error
+			if p.peek() == '{' {
+				err = p.parseArray(level + 1)
+			} else {
+				err = p.parseElement(level)
+			}
+			if err != nil {
+				return err
+			}
+			count++
+			p.eatWhitespace()
+			if string(p.peek()) != p.delimiter {
+				break
+			}
+			p.advance()
+			p.eatWhitespace()
+		}
+	}
+	p.eatWhitespace()
+	if p.eof() {
+		return enclosingError
+	}
+	if p.peek() != '}' {
+		return malformedError
+	}
+	p.advance()
+
+	for len(p.dims) <= level {
+		p.dims = append(p.dims, -1)
+	}
+	if p.dims[level] == -1 {
+		p.dims[level] = count
+	} else if p.dims[level] != count {
+		return dimensionMismatchError
+	}
+	return nil
+}
+
+// parseInt parses a, possibly signed, integer that is part of the dimension
+// decoration of an array.
+func (p *parseState) parseInt() (int, error) {
+	p.eatWhitespace()
+	i := 0
+	if i < len(p.s) && (p.s[i] == '-' || p.s[i] == '+') {
+		i++
+	}
+	for i < len(p.s) && p.s[i] >= '0' && p.s[i] <= '9' {
+		i++
+	}
+	n, err := strconv.ParseInt(p.s[:i], 10, 32)
+	if err != nil {
+		return 0, malformedError
+	}
+	p.s = p.s[i:]
+	p.eatWhitespace()
+	return int(n), nil
+}
+
+// parseDimensions parses the optional dimension decoration of an array, such
+// as `[0:1][1:3]=`, returning the lower bound and the length of each specified
+// dimension. A dimension which only specifies its upper bound has a lower bound
+// of 1.
+func (p *parseState) parseDimensions() (lowerBounds, dims []int, _ error) {
+	for p.peek() == '[' {
+		if len(dims) >= MaxArrayDimensions {
+			return nil, nil, pgerror.Newf(pgcode.ProgramLimitExceeded,
+				"number of array dimensions (%d) exceeds the maximum allowed (%d)",
+				len(dims)+1, MaxArrayDimensions)
+		}
+		p.advance()
+		lower, upper := 1, 0
+		n, err := p.parseInt()
+		if err != nil {
+			return nil, nil, err
+		}
+		if p.peek() == ':' {
+			p.advance()
+			lower = n
+			if upper, err = p.parseInt(); err != nil {
+				return nil, nil, err
+			}
+		} else {
+			upper = n
+		}
+		if p.peek() != ']' {
+			return nil, nil, malformedError
+		}
+		p.advance()
+		if upper < lower {
+			return nil, nil, pgerror.New(pgcode.InvalidTextRepresentation,
+				"upper bound cannot be less than lower bound")
+		}
+		lowerBounds = append(lowerBounds, lower)
+		dims = append(dims, upper-lower+1)
+		p.eatWhitespace()
+	}
+	if dims != nil {
+		if p.peek() != '=' {
+			return nil, nil, malformedError
+		}
+		p.advance()
+		p.eatWhitespace()
+	}
+	return lowerBounds, dims, nil
 }
 
 // ParseDArrayFromString parses the string-form of constructing arrays, handling
-// cases such as `'{1,2,3}'::INT[]`. The input type t is the type of the
-// parameter of the array to parse.
+// cases such as `'{1,2,3}'::INT[]`, `'{{1,2},{3,4}}'::INT[][]` and
+// `'[0:2]={1,2,3}'::INT[]`. The input type t is the type of the parameter of
+// the array to parse.
 //
 // The dependsOnContext return value indicates if we had to consult the
 // ParseContext (either for the time or the local timezone).
@@ -218,44 +372,50 @@ func ParseDArrayFromString(
 func doParseDArrayFromString(
 	ctx ParseContext, s string, t *types.T,
 ) (_ *DArray, dependsOnContext bool, _ error) {
+	elemTyp := ArrayScalarType(t)
 	parser := parseState{
-		s:      s,
-		ctx:    ctx,
-		result: NewDArray(t),
-		t:      t,
+		s:         s,
+		ctx:       ctx,
+		t:         elemTyp,
+		delimiter: elemTyp.Delimiter(),
+		elemLevel: -1,
 	}
 
 	parser.eatWhitespace()
-	if parser.peek() != '{' {
-		return nil, false, enclosingError
-	}
-	parser.advance()
-	parser.eatWhitespace()
-	if parser.peek() != '}' {
-		if err := parser.parseElement(); err != nil {
-			return nil, false, err
-		}
-		parser.eatWhitespace()
-		for string(parser.peek()) == t.Delimiter() {
-			parser.advance()
-			parser.eatWhitespace()
-			if err := parser.parseElement(); err != nil {
-				return nil, false, err
-			}
-		}
-	}
-	parser.eatWhitespace()
-	if parser.eof() {
-		return nil, false, enclosingError
+	lowerBounds, specifiedDims, err := parser.parseDimensions()
+	if err != nil {
+		return nil, false, err
 	}
-	if parser.peek() != '}' {
-		return nil, false, malformedError
+	if err := parser.parseArray(0 /* level */); err != nil {
+		return nil, false, err
 	}
-	parser.advance()
 	parser.eatWhitespace()
 	if !parser.eof() {
 		return nil, false, extraTextError
 	}
 
-	return parser.result, parser.dependsOnContext, nil
+	if parser.elemLevel == -1 {
+		// The array only contains empty sub-arrays, which results in an empty
+		// array, like in Postgres.
+		if specifiedDims != nil {
+			return nil, false, dimensionDecorationMismatchError
+		}
+		return NewDArray(t), parser.dependsOnContext, nil
+	}
+	dims := parser.dims[:parser.elemLevel+1]
+	if specifiedDims != nil {
+		if len(specifiedDims) != len(dims) {
+			return nil, false, dimensionDecorationMismatchError
+		}
+		for i := range dims {
+			if specifiedDims[i] != dims[i] {
+				return nil, false, dimensionDecorationMismatchError
+			}
+		}
+	}
+	ret, err := NewMultiDimDArray(t, dims, lowerBounds, parser.elems)
+	if err != nil {
+		return nil, false, err
+	}
+	return ret, parser.dependsOnContext, nil
 }
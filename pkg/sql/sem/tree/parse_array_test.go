@@ -14,6 +14,7 @@ import (
 	"bytes"
 	"context"
 	"math/rand"
+	"reflect"
 	"testing"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
@@ -143,6 +144,45 @@ lo}`, types.String, Datums{NewDString(`hel`), NewDString(`lo`)}},
 	}
 }
 
+func TestParseMultiDimArray(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	intArray := types.MakeArray(types.Int)
+	testData := []struct {
+		str         string
+		typ         *types.T
+		dims        []int
+		lowerBounds []int
+		expected    string
+	}{
+		{`{{1,2},{3,4}}`, intArray, []int{2, 2}, []int{1, 1}, `{{1,2},{3,4}}`},
+		{` { { 1 } , { NULL } } `, intArray, []int{2, 1}, []int{1, 1}, `{{1},{NULL}}`},
+		{`{{{1,2,3}},{{4,5,6}}}`, types.MakeArray(intArray), []int{2, 1, 3}, []int{1, 1, 1}, `{{{1,2,3}},{{4,5,6}}}`},
+		{`{{"a,b",c},{d,"e}"}}`, types.MakeArray(types.String), []int{2, 2}, []int{1, 1}, `{{"a,b",c},{d,"e}"}}`},
+		{`{{},{}}`, intArray, nil, nil, `{}`},
+		{`[0:2]={1,2,3}`, types.Int, []int{3}, []int{0}, `[0:2]={1,2,3}`},
+		{`[3]={1,2,3}`, types.Int, []int{3}, []int{1}, `{1,2,3}`},
+		{`[-1:0][2:4]={{1,2,3},{4,5,6}}`, intArray, []int{2, 3}, []int{-1, 2}, `[-1:0][2:4]={{1,2,3},{4,5,6}}`},
+	}
+	for _, td := range testData {
+		t.Run(td.str, func(t *testing.T) {
+			actual, _, err := ParseDArrayFromString(nil /* ParseContext */, td.str, td.typ)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if dims := actual.Dimensions(); !reflect.DeepEqual(dims, td.dims) {
+				t.Fatalf("expected dimensions %v, got %v", td.dims, dims)
+			}
+			if lowerBounds := actual.LowerBounds(); !reflect.DeepEqual(lowerBounds, td.lowerBounds) {
+				t.Fatalf("expected lower bounds %v, got %v", td.lowerBounds, lowerBounds)
+			}
+			if s := AsStringWithFlags(actual, FmtPgwireText); s != td.expected {
+				t.Fatalf("expected %s, got %s", td.expected, s)
+			}
+		})
+	}
+}
+
 type noopUnwrapCompareContext struct {
 	CompareContext
 }
@@ -207,6 +247,7 @@ func TestParseArrayRandomParseArray(t *testing.T) {
 func TestParseArrayError(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
+	intArray := types.MakeArray(types.Int)
 	testData := []struct {
 		str           string
 		typ           *types.T
@@ -221,8 +262,16 @@ func TestParseArrayError(t *testing.T) {
 		{`{,}`, types.Int, `could not parse "{,}" as type int[]: malformed array`},
 		{`{}{}`, types.Int, `could not parse "{}{}" as type int[]: extra text after closing right brace`},
 		{`{} {}`, types.Int, `could not parse "{} {}" as type int[]: extra text after closing right brace`},
-		{`{{}}`, types.Int, `could not parse "{{}}" as type int[]: unimplemented: nested arrays not supported`},
-		{`{1, {1}}`, types.Int, `could not parse "{1, {1}}" as type int[]: unimplemented: nested arrays not supported`},
+		{`{{1}}`, types.Int, `could not parse "{{1}}" as type int[]: cannot use 2-dimensional array as type INT8[]`},
+		{`{1, {1}}`, types.Int, `could not parse "{1, {1}}" as type int[]: malformed array`},
+		{`{{1}, 1}`, types.Int, `could not parse "{{1}, 1}" as type int[]: malformed array`},
+		{`{{1,2},{3}}`, intArray, `could not parse "{{1,2},{3}}" as type int[][]: multidimensional arrays must have sub-arrays with matching dimensions`},
+		{`{{{{{{{1}}}}}}}`, types.Int, `could not parse "{{{{{{{1}}}}}}}" as type int[]: number of array dimensions (7) exceeds the maximum allowed (6)`},
+		{`[1:2]={1}`, types.Int, `could not parse "[1:2]={1}" as type int[]: specified array dimensions do not match array contents`},
+		{`[1:2][1:2]={1,2}`, types.Int, `could not parse "[1:2][1:2]={1,2}" as type int[]: specified array dimensions do not match array contents`},
+		{`[2:1]={}`, types.Int, `could not parse "[2:1]={}" as type int[]: upper bound cannot be less than lower bound`},
+		{`[1:2]{1,2}`, types.Int, `could not parse "[1:2]{1,2}" as type int[]: malformed array`},
+		{`[a]={1}`, types.Int, `could not parse "[a]={1}" as type int[]: malformed array`},
 		{`{hello}`, types.Int, `could not parse "{hello}" as type int[]: could not parse "hello" as type int: strconv.ParseInt: parsing "hello": invalid syntax`},
 		{`{"hello}`, types.String, `could not parse "{\"hello}" as type string[]: malformed array`},
 		// It might be unnecessary to disallow this, but Postgres does.
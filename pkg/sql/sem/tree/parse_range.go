@@ -0,0 +1,130 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+import (
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+var malformedRangeError = pgerror.Newf(pgcode.InvalidTextRepresentation, "malformed range literal")
+
+// ParseDRangeFromString parses the string-form of constructing ranges, handling
+// cases such as `'[1,10)'::INT4RANGE`. The input type t is the type of the
+// range to parse.
+//
+// The dependsOnContext return value indicates if we had to consult the
+// ParseContext (either for the time or the local timezone).
+func ParseDRangeFromString(
+	ctx ParseContext, s string, t *types.T,
+) (_ *DRange, dependsOnContext bool, _ error) {
+	ret, dependsOnContext, err := doParseDRangeFromString(ctx, s, t)
+	if err != nil {
+		return ret, false, MakeParseError(s, t, err)
+	}
+	return ret, dependsOnContext, nil
+}
+
+// doParseDRangeFromString does most of the work of ParseDRangeFromString,
+// except the error it returns isn't prettified as a parsing error.
+//
+// The format of a range is the same as in Postgres: either the string
+// "empty", or a lower and an upper bound separated by a comma and enclosed in
+// brackets for inclusive or parentheses for exclusive bounds. An omitted bound
+// is infinite. Bounds may be double-quoted, and within a bound a backslash
+// escapes the following character.
+func doParseDRangeFromString(
+	ctx ParseContext, s string, t *types.T,
+) (_ *DRange, dependsOnContext bool, _ error) {
+	in := strings.TrimSpace(s)
+	if strings.EqualFold(in, "empty") {
+		return NewDEmptyRange(t), false, nil
+	}
+	if len(in) == 0 || (in[0] != '[' && in[0] != '(') {
+		return nil, false, errors.WithDetail(malformedRangeError, "Missing left parenthesis or bracket.")
+	}
+	lowerInc := in[0] == '['
+	lowerStr, lowerInf, pos, err := parseRangeBound(in, 1)
+	if err != nil {
+		return nil, false, err
+	}
+	if in[pos] != ',' {
+		return nil, false, errors.WithDetail(malformedRangeError, "Missing comma after lower bound.")
+	}
+	upperStr, upperInf, pos, err := parseRangeBound(in, pos+1)
+	if err != nil {
+		return nil, false, err
+	}
+	if in[pos] != ']' && in[pos] != ')' {
+		return nil, false, errors.WithDetail(malformedRangeError, "Missing right parenthesis or bracket.")
+	}
+	upperInc := in[pos] == ']'
+	if pos != len(in)-1 {
+		return nil, false, errors.WithDetail(malformedRangeError, "Junk after right parenthesis or bracket.")
+	}
+
+	var lower, upper Datum
+	if !lowerInf {
+		var dep bool
+		if lower, dep, err = ParseAndRequireString(t.RangeContents(), lowerStr, ctx); err != nil {
+			return nil, false, err
+		}
+		dependsOnContext = dependsOnContext || dep
+	}
+	if !upperInf {
+		var dep bool
+		if upper, dep, err = ParseAndRequireString(t.RangeContents(), upperStr, ctx); err != nil {
+			return nil, false, err
+		}
+		dependsOnContext = dependsOnContext || dep
+	}
+	r, err := NewDRange(t, lower, upper, lowerInc, upperInc)
+	return r, dependsOnContext, err
+}
+
+// parseRangeBound parses the bound of a range starting at position pos of in,
+// returning the unquoted bound, whether it is infinite, and the position of
+// the character following it.
+func parseRangeBound(in string, pos int) (_ string, inf bool, _ int, _ error) {
+	if pos < len(in) && (in[pos] == ',' || in[pos] == ')' || in[pos] == ']') {
+		return "", true, pos, nil
+	}
+	var b strings.Builder
+	inQuote := false
+	for ; pos < len(in); pos++ {
+		ch := in[pos]
+		if !inQuote && (ch == ',' || ch == ')' || ch == ']') {
+			return b.String(), false, pos, nil
+		}
+		switch {
+		case ch == '\\':
+			pos++
+			if pos < len(in) {
+				b.WriteByte(in[pos])
+			}
+		case ch == '"' && !inQuote:
+			inQuote = true
+		case ch == '"' && pos+1 < len(in) && in[pos+1] == '"':
+			// Within quotes, a doubled double quote is a literal double quote.
+			b.WriteByte('"')
+			pos++
+		case ch == '"':
+			inQuote = false
+		default:
+			b.WriteByte(ch)
+		}
+	}
+	return "", false, pos, errors.WithDetail(malformedRangeError, "Unexpected end of input.")
+}
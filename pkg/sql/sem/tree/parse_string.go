@@ -71,6 +71,8 @@ func ParseAndRequireString(
 		d, err = ParseDPGLSN(s)
 	case types.PGVectorFamily:
 		d, err = ParseDPGVector(s)
+	case types.RangeFamily:
+		d, dependsOnContext, err = ParseDRangeFromString(ctx, s, t)
 	case types.RefCursorFamily:
 		d = NewDRefCursor(s)
 	case types.Box2DFamily:
@@ -309,3 +311,62 @@ func ParseAndRequireStringHandler(
 	}
 	return err
 }
+
+// SetValueHandlerDatum passes the value of a datum of type t, such as one
+// decoded from the pgwire binary format, to a ValueHandler. Values of types
+// supported by the vector engine are passed directly, mirroring
+// ParseAndRequireStringHandler, and other types are passed as datums.
+func SetValueHandlerDatum(t *types.T, d Datum, vh ValueHandler) error {
+	if d == DNull {
+		vh.Null()
+		return nil
+	}
+	d = UnwrapDOidWrapper(d)
+	switch t.Family() {
+	case types.BoolFamily:
+		vh.Bool(bool(MustBeDBool(d)))
+	case types.BytesFamily:
+		vh.Bytes(encoding.UnsafeConvertStringToBytes(string(MustBeDBytes(d))))
+	case types.DateFamily:
+		vh.Date(MustBeDDate(d).Date)
+	case types.DecimalFamily:
+		dec := MustBeDDecimal(d)
+		vh.Decimal().Set(&dec.Decimal)
+	case types.FloatFamily:
+		vh.Float(float64(MustBeDFloat(d)))
+	case types.IntFamily:
+		i := MustBeDInt(d)
+		switch t.Width() {
+		case 16:
+			vh.Int16(int16(i))
+		case 32:
+			vh.Int32(int32(i))
+		default:
+			vh.Int(int64(i))
+		}
+	case types.JsonFamily:
+		vh.JSON(MustBeDJSON(d).JSON)
+	case types.StringFamily:
+		vh.String(string(MustBeDString(d)))
+	case types.TimestampTZFamily:
+		vh.TimestampTZ(MustBeDTimestampTZ(d).Time)
+	case types.TimestampFamily:
+		vh.TimestampTZ(MustBeDTimestamp(d).Time)
+	case types.IntervalFamily:
+		vh.Duration(MustBeDInterval(d).Duration)
+	case types.UuidFamily:
+		vh.Bytes(MustBeDUuid(d).GetBytes())
+	case types.EnumFamily:
+		e, ok := d.(*DEnum)
+		if !ok {
+			return errors.AssertionFailedf("expected *DEnum, found %T", d)
+		}
+		vh.Bytes(e.PhysicalRep)
+	default:
+		if typeconv.TypeFamilyToCanonicalTypeFamily(t.Family()) != typeconv.DatumVecCanonicalTypeFamily {
+			return errors.AssertionFailedf("unexpected type %v in datum case arm, does a new type need to be handled?", t)
+		}
+		vh.Datum(d)
+	}
+	return nil
+}
@@ -110,6 +110,25 @@ func pgwireFormatStringInTuple(buf *bytes.Buffer, in string) {
 	}
 }
 
+// pgwireFormatStringInRange writes a range bound, quoting and escaping it
+// the same way bounds of a range are quoted in Postgres.
+func pgwireFormatStringInRange(buf *bytes.Buffer, in string) {
+	quote := in == "" || rangeQuoteSet.in(in)
+	if quote {
+		buf.WriteByte('"')
+	}
+	for _, r := range in {
+		if r == '"' || r == '\\' {
+			// Quoted bounds double " and \.
+			buf.WriteByte(byte(r))
+		}
+		buf.WriteRune(r)
+	}
+	if quote {
+		buf.WriteByte('"')
+	}
+}
+
 func (d *DArray) pgwireFormat(ctx *FmtCtx) {
 	// When converting an array to text in "postgres mode" there is
 	// special behavior: values are printed in "postgres mode" then the
@@ -139,6 +158,25 @@ func (d *DArray) pgwireFormat(ctx *FmtCtx) {
 	if ctx.HasFlags(fmtPGCatalog) {
 		ctx.WriteByte('\'')
 	}
+	if !d.HasDefaultLowerBounds() {
+		// Like Postgres, only print the bounds of the dimensions if any of them
+		// aren't the default.
+		dims, lowerBounds := d.Dimensions(), d.LowerBounds()
+		for i := range dims {
+			ctx.Printf("[%d:%d]", lowerBounds[i], lowerBounds[i]+dims[i]-1)
+		}
+		ctx.WriteByte('=')
+	}
+	d.pgwireFormatElements(ctx)
+	if ctx.HasFlags(fmtPGCatalog) {
+		ctx.WriteByte('\'')
+	}
+}
+
+// pgwireFormatElements writes the elements of the array enclosed in braces.
+// The elements of a multi-dimensional array are themselves arrays, which are
+// written the same way.
+func (d *DArray) pgwireFormatElements(ctx *FmtCtx) {
 	ctx.WriteByte('{')
 	delimiter := ""
 	for _, v := range d.Array {
@@ -146,6 +184,14 @@ func (d *DArray) pgwireFormat(ctx *FmtCtx) {
 		switch dv := UnwrapDOidWrapper(v).(type) {
 		case dNull:
 			ctx.WriteString("NULL")
+		case *DArray:
+			if isMultiDimArrayElemType(d.ParamTyp) {
+				dv.pgwireFormatElements(ctx)
+			} else {
+				// Vectors are formatted like any other element.
+				s := AsStringWithFlags(v, ctx.flags, FmtDataConversionConfig(ctx.dataConversionConfig), FmtLocation(ctx.location))
+				pgwireFormatStringInArray(ctx, s)
+			}
 		case *DString:
 			pgwireFormatStringInArray(ctx, string(*dv))
 		case *DCollatedString:
@@ -172,12 +218,9 @@ func (d *DArray) pgwireFormat(ctx *FmtCtx) {
 		delimiter = d.ParamTyp.Delimiter()
 	}
 	ctx.WriteByte('}')
-	if ctx.HasFlags(fmtPGCatalog) {
-		ctx.WriteByte('\'')
-	}
 }
 
-var tupleQuoteSet, arrayQuoteSet asciiSet
+var tupleQuoteSet, arrayQuoteSet, rangeQuoteSet asciiSet
 
 func init() {
 	var ok bool
@@ -189,6 +232,10 @@ func init() {
 	if !ok {
 		panic("array asciiset")
 	}
+	rangeQuoteSet, ok = makeASCIISet(" \t\v\f\r\n()[],\"\\")
+	if !ok {
+		panic("range asciiset")
+	}
 }
 
 // PgwireFormatFloat returns a []byte representing a float according to
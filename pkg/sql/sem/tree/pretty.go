@@ -733,8 +733,15 @@ func (node *AliasedTableExpr) doc(p *PrettyCfg) pretty.Doc {
 func (node *FuncExpr) doc(p *PrettyCfg) pretty.Doc {
 	d := p.Doc(&node.Func)
 
-	if len(node.Exprs) > 0 {
+	if n := len(node.Exprs); n > 0 {
 		args := node.Exprs.doc(p)
+		if node.Variadic {
+			args = pretty.ConcatSpace(pretty.Keyword("VARIADIC"), p.Doc(node.Exprs[n-1]))
+			if n > 1 {
+				fixed := node.Exprs[:n-1]
+				args = p.commaSeparated(fixed.doc(p), args)
+			}
+		}
 		if node.Type != 0 {
 			args = pretty.ConcatLine(
 				pretty.Text(funcTypeName[node.Type]),
@@ -1827,6 +1834,9 @@ func (node *UniqueConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 	if node.PartitionByIndex != nil {
 		clauses = append(clauses, p.Doc(node.PartitionByIndex))
 	}
+	if node.Deferrable != ConstraintNotDeferrable {
+		clauses = append(clauses, pretty.Keyword(node.Deferrable.keyword()))
+	}
 	if node.Predicate != nil {
 		clauses = append(clauses, p.nestUnder(pretty.Keyword("WHERE"), p.Doc(node.Predicate)))
 	}
@@ -1890,6 +1900,10 @@ func (node *ForeignKeyConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 		clauses = append(clauses, actions)
 	}
 
+	if node.Deferrable != ConstraintNotDeferrable {
+		clauses = append(clauses, pretty.Keyword(node.Deferrable.keyword()))
+	}
+
 	return p.nestUnder(title, pretty.Group(pretty.Stack(clauses...)))
 }
 
@@ -2076,6 +2090,9 @@ func (node *ColumnTableDef) docRow(p *PrettyCfg) pretty.TableRow {
 		if ref := p.Doc(&node.References.Actions); ref != pretty.Nil {
 			fkDetails = append(fkDetails, ref)
 		}
+		if node.References.Deferrable != ConstraintNotDeferrable {
+			fkDetails = append(fkDetails, pretty.Keyword(node.References.Deferrable.keyword()))
+		}
 		fk := fkHead
 		if len(fkDetails) > 0 {
 			fk = p.nestUnder(fk, pretty.Group(pretty.Stack(fkDetails...)))
@@ -0,0 +1,113 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+// PublicationTargets represents the set of tables published by a
+// publication.
+type PublicationTargets struct {
+	// AllTables is true for FOR ALL TABLES publications, which publish every
+	// table in the database, including the ones created in the future.
+	AllTables bool
+	// Tables is the list of published tables when AllTables is false.
+	Tables TableNames
+}
+
+// Format implements the NodeFormatter interface.
+func (node *PublicationTargets) Format(ctx *FmtCtx) {
+	if node.AllTables {
+		ctx.WriteString(" FOR ALL TABLES")
+	} else if len(node.Tables) > 0 {
+		ctx.WriteString(" FOR TABLE ")
+		ctx.FormatNode(&node.Tables)
+	}
+}
+
+// CreatePublication represents a CREATE PUBLICATION statement.
+type CreatePublication struct {
+	Name    Name
+	Targets PublicationTargets
+}
+
+var _ Statement = &CreatePublication{}
+
+// Format implements the NodeFormatter interface.
+func (node *CreatePublication) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE PUBLICATION ")
+	ctx.FormatNode(&node.Name)
+	ctx.FormatNode(&node.Targets)
+}
+
+// AlterPublicationCommand is the type of change performed by an ALTER
+// PUBLICATION statement.
+type AlterPublicationCommand uint8
+
+const (
+	// AlterPublicationAddTable adds tables to the publication.
+	AlterPublicationAddTable AlterPublicationCommand = iota
+	// AlterPublicationSetTable replaces the tables of the publication.
+	AlterPublicationSetTable
+	// AlterPublicationDropTable removes tables from the publication.
+	AlterPublicationDropTable
+)
+
+// String implements the fmt.Stringer interface.
+func (c AlterPublicationCommand) String() string {
+	switch c {
+	case AlterPublicationAddTable:
+		return "ADD"
+	case AlterPublicationSetTable:
+		return "SET"
+	case AlterPublicationDropTable:
+		return "DROP"
+	}
+	return "unknown"
+}
+
+// AlterPublication represents an ALTER PUBLICATION statement.
+type AlterPublication struct {
+	Name    Name
+	Command AlterPublicationCommand
+	Tables  TableNames
+}
+
+var _ Statement = &AlterPublication{}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterPublication) Format(ctx *FmtCtx) {
+	ctx.WriteString("ALTER PUBLICATION ")
+	ctx.FormatNode(&node.Name)
+	ctx.WriteByte(' ')
+	ctx.WriteString(node.Command.String())
+	ctx.WriteString(" TABLE ")
+	ctx.FormatNode(&node.Tables)
+}
+
+// DropPublication represents a DROP PUBLICATION statement.
+type DropPublication struct {
+	Names        NameList
+	IfExists     bool
+	DropBehavior DropBehavior
+}
+
+var _ Statement = &DropPublication{}
+
+// Format implements the NodeFormatter interface.
+func (node *DropPublication) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP PUBLICATION ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.FormatNode(&node.Names)
+	if node.DropBehavior != DropDefault {
+		ctx.WriteByte(' ')
+		ctx.WriteString(node.DropBehavior.String())
+	}
+}
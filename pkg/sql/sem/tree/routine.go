@@ -14,6 +14,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/buildutil"
@@ -141,6 +142,44 @@ type RoutineExpr struct {
 	// CursorDeclaration contains the information needed to open a SQL cursor with
 	// the result of the *first* body statement. It may be unset.
 	CursorDeclaration *RoutineOpenCursor
+
+	// ResultBuffer is shared between the routines that make up a set-returning
+	// PL/pgSQL function. For the root routine (where Generator is true), the
+	// rows added to the buffer become the result of the routine. For a nested
+	// routine, the result of the *first* body statement is added to the buffer.
+	// It may be unset.
+	ResultBuffer *RoutineResultBuffer
+
+	// SessionSettings contains the changes to the session that are in effect
+	// while the routine executes. It is set only for the root routine of a
+	// SECURITY DEFINER routine, or of a routine with SET clauses.
+	SessionSettings *RoutineSessionSettings
+}
+
+// RoutineSessionSettings contains the changes to the session that are in
+// effect while a routine executes.
+type RoutineSessionSettings struct {
+	// Definer is the owner of a SECURITY DEFINER routine. The routine executes
+	// with the privileges of Definer rather than those of the invoking user. It
+	// is empty if the routine executes with the privileges of the invoker.
+	Definer username.SQLUsername
+
+	// Config contains the session variables that are set while the routine
+	// executes, in the form "name=value".
+	Config []string
+}
+
+// MakeRoutineSessionSettings returns the session settings for a call to the
+// given routine overload, or nil if the routine does not change the session.
+func MakeRoutineSessionSettings(o *Overload) *RoutineSessionSettings {
+	if !o.SecurityDefiner && len(o.Config) == 0 {
+		return nil
+	}
+	settings := &RoutineSessionSettings{Config: o.Config}
+	if o.SecurityDefiner {
+		settings.Definer = o.Owner
+	}
+	return settings
 }
 
 // NewTypedRoutineExpr returns a new RoutineExpr that is well-typed.
@@ -158,6 +197,7 @@ func NewTypedRoutineExpr(
 	blockStart bool,
 	blockState *BlockState,
 	cursorDeclaration *RoutineOpenCursor,
+	resultBuffer *RoutineResultBuffer,
 ) *RoutineExpr {
 	return &RoutineExpr{
 		Args:              args,
@@ -173,6 +213,7 @@ func NewTypedRoutineExpr(
 		BlockStart:        blockStart,
 		BlockState:        blockState,
 		CursorDeclaration: cursorDeclaration,
+		ResultBuffer:      resultBuffer,
 	}
 }
 
@@ -228,6 +269,18 @@ type RoutineOpenCursor struct {
 	CursorSQL string
 }
 
+// RoutineResultBuffer is shared state between all routines that make up a
+// set-returning PL/pgSQL function. It allows RETURN NEXT and RETURN QUERY
+// statements to add rows to the result set of the function before control
+// reaches the final RETURN statement.
+type RoutineResultBuffer struct {
+	// Writer accepts the rows that are added to the result set. It is only set
+	// while the root routine of the function is executing. It currently maps to
+	// the rowResultWriter interface in the sql package. We use the empty
+	// interface here to avoid import cycles.
+	Writer interface{}
+}
+
 // BlockState is shared state between all routines that make up a PLpgSQL block.
 // It allows for coordination between the routines for exception handling.
 type BlockState struct {
@@ -780,6 +780,52 @@ func (node *GroupBy) Format(ctx *FmtCtx) {
 	}
 }
 
+// GroupingSetType is the type of a GroupingSet.
+type GroupingSetType int
+
+// GroupingSetType values.
+const (
+	// RollupGroupingSet is ROLLUP (a, b, ...), which groups by every prefix of
+	// its list of expressions, including the empty prefix.
+	RollupGroupingSet GroupingSetType = iota
+	// CubeGroupingSet is CUBE (a, b, ...), which groups by every subset of its
+	// list of expressions, including the empty subset.
+	CubeGroupingSet
+	// ExplicitGroupingSet is GROUPING SETS (...), which groups by each of the
+	// listed grouping sets.
+	ExplicitGroupingSet
+)
+
+var groupingSetTypeName = [...]string{
+	RollupGroupingSet:   "ROLLUP",
+	CubeGroupingSet:     "CUBE",
+	ExplicitGroupingSet: "GROUPING SETS",
+}
+
+// String implements the fmt.Stringer interface.
+func (t GroupingSetType) String() string {
+	return groupingSetTypeName[t]
+}
+
+// GroupingSet represents a ROLLUP, CUBE or GROUPING SETS item in a GROUP BY
+// clause. Each of the Exprs is either a single expression, a Tuple listing
+// several expressions that are grouped together (the empty Tuple being the
+// empty grouping set) or, for GROUPING SETS only, a nested GroupingSet.
+type GroupingSet struct {
+	Type  GroupingSetType
+	Exprs Exprs
+}
+
+var _ Expr = &GroupingSet{}
+
+// Format implements the NodeFormatter interface.
+func (node *GroupingSet) Format(ctx *FmtCtx) {
+	ctx.WriteString(node.Type.String())
+	ctx.WriteString(" (")
+	ctx.FormatNode(&node.Exprs)
+	ctx.WriteByte(')')
+}
+
 // DistinctOn represents a DISTINCT ON clause.
 type DistinctOn []Expr
 
@@ -1312,15 +1312,20 @@ const (
 	// ScheduledChangefeedExecutor is an executor responsible for
 	// the execution of the scheduled changefeeds.
 	ScheduledChangefeedExecutor
+
+	// ScheduledPartitionMaintenanceExecutor is an executor responsible for
+	// the automatic creation and removal of time-based partitions.
+	ScheduledPartitionMaintenanceExecutor
 )
 
 var scheduleExecutorInternalNames = map[ScheduledJobExecutorType]string{
-	InvalidExecutor:                     "unknown-executor",
-	ScheduledBackupExecutor:             "scheduled-backup-executor",
-	ScheduledSQLStatsCompactionExecutor: "scheduled-sql-stats-compaction-executor",
-	ScheduledRowLevelTTLExecutor:        "scheduled-row-level-ttl-executor",
-	ScheduledSchemaTelemetryExecutor:    "scheduled-schema-telemetry-executor",
-	ScheduledChangefeedExecutor:         "scheduled-changefeed-executor",
+	InvalidExecutor:                       "unknown-executor",
+	ScheduledBackupExecutor:               "scheduled-backup-executor",
+	ScheduledSQLStatsCompactionExecutor:   "scheduled-sql-stats-compaction-executor",
+	ScheduledRowLevelTTLExecutor:          "scheduled-row-level-ttl-executor",
+	ScheduledSchemaTelemetryExecutor:      "scheduled-schema-telemetry-executor",
+	ScheduledChangefeedExecutor:           "scheduled-changefeed-executor",
+	ScheduledPartitionMaintenanceExecutor: "scheduled-partition-maintenance-executor",
 }
 
 // InternalName returns an internal executor name.
@@ -1342,6 +1347,8 @@ func (t ScheduledJobExecutorType) UserName() string {
 		return "SCHEMA TELEMETRY"
 	case ScheduledChangefeedExecutor:
 		return "CHANGEFEED"
+	case ScheduledPartitionMaintenanceExecutor:
+		return "PARTITION MAINTENANCE"
 	}
 	return "unsupported-executor"
 }
@@ -83,6 +83,9 @@ func SampleDatum(t *types.T) Datum {
 		return NewDOidWithType(1009, t)
 	case types.PGLSNFamily:
 		return NewDPGLSN(0x1000000100)
+	case types.RangeFamily:
+		r, _ := NewDRange(t, SampleDatum(t.RangeContents()), nil, true /* lowerInc */, false /* upperInc */)
+		return r
 	case types.RefCursorFamily:
 		return NewDRefCursor("Wheezer")
 	case types.Box2DFamily:
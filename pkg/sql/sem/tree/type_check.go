@@ -419,15 +419,19 @@ func (expr *BinaryExpr) TypeCheck(
 		}
 	}
 
+	// Operators on ranges are only defined for two ranges of the same type.
+	rangeMismatch := leftReturn.Family() == types.RangeFamily &&
+		rightReturn.Family() == types.RangeFamily && !leftReturn.Equivalent(rightReturn)
+
 	// Throw a typing error if overload resolution found either no compatible candidates
 	// or if it found an ambiguity.
-	if len(s.overloadIdxs) != 1 {
+	if len(s.overloadIdxs) != 1 || rangeMismatch {
 		var desStr string
 		if desired.Family() != types.AnyFamily {
 			desStr = fmt.Sprintf(" (returning <%s>)", desired)
 		}
 		sig := fmt.Sprintf("<%s> %s <%s>%s", leftReturn, expr.Operator, rightReturn, desStr)
-		if len(s.overloadIdxs) == 0 {
+		if len(s.overloadIdxs) == 0 || rangeMismatch {
 			return nil,
 				pgerror.Newf(pgcode.InvalidParameterValue, unsupportedBinaryOpErrFmt, sig)
 		}
@@ -701,7 +705,11 @@ func (expr *CastExpr) TypeCheck(
 func (expr *IndirectionExpr) TypeCheck(
 	ctx context.Context, semaCtx *SemaContext, desired *types.T,
 ) (TypedExpr, error) {
-	subExpr, err := expr.Expr.TypeCheck(ctx, semaCtx, types.MakeArray(desired))
+	desiredArray := desired
+	for range expr.Indirection {
+		desiredArray = types.MakeArray(desiredArray)
+	}
+	subExpr, err := expr.Expr.TypeCheck(ctx, semaCtx, desiredArray)
 	if err != nil {
 		return nil, err
 	}
@@ -710,14 +718,18 @@ func (expr *IndirectionExpr) TypeCheck(
 
 	switch typ.Family() {
 	case types.ArrayFamily:
-		expr.typ = typ.ArrayContents()
-		for i, t := range expr.Indirection {
+		// Each subscript indexes into one dimension of a, possibly
+		// multi-dimensional, array.
+		expr.typ = typ
+		for _, t := range expr.Indirection {
 			if t.Slice {
 				return nil, unimplemented.NewWithIssuef(32551, "ARRAY slicing in %s", expr)
 			}
-			if i > 0 {
-				return nil, unimplemented.NewWithIssueDetailf(32552, "ind", "multidimensional indexing: %s", expr)
+			if expr.typ.Family() != types.ArrayFamily {
+				return nil, pgerror.Newf(pgcode.DatatypeMismatch,
+					"cannot subscript type %s because it is not an array", expr.typ)
 			}
+			expr.typ = expr.typ.ArrayContents()
 
 			beginExpr, err := typeCheckAndRequire(ctx, semaCtx, t.Begin, types.Int, "ARRAY subscript")
 			if err != nil {
@@ -995,11 +1007,12 @@ func (expr *ComparisonExpr) TypeCheck(
 }
 
 var (
-	errStarNotAllowed      = pgerror.New(pgcode.Syntax, "cannot use \"*\" in this context")
-	errInvalidDefaultUsage = pgerror.New(pgcode.Syntax, "DEFAULT can only appear in a VALUES list within INSERT or on the right side of a SET")
-	errInvalidMaxUsage     = pgerror.New(pgcode.Syntax, "MAXVALUE can only appear within a range partition expression")
-	errInvalidMinUsage     = pgerror.New(pgcode.Syntax, "MINVALUE can only appear within a range partition expression")
-	errPrivateFunction     = pgerror.New(pgcode.ReservedName, "function reserved for internal use")
+	errStarNotAllowed       = pgerror.New(pgcode.Syntax, "cannot use \"*\" in this context")
+	errInvalidDefaultUsage  = pgerror.New(pgcode.Syntax, "DEFAULT can only appear in a VALUES list within INSERT or on the right side of a SET")
+	errInvalidMaxUsage      = pgerror.New(pgcode.Syntax, "MAXVALUE can only appear within a range partition expression")
+	errInvalidMinUsage      = pgerror.New(pgcode.Syntax, "MINVALUE can only appear within a range partition expression")
+	errInvalidGroupingUsage = pgerror.New(pgcode.Grouping, "GROUPING can only appear in the SELECT list, HAVING or ORDER BY clause of a query with GROUP BY")
+	errPrivateFunction      = pgerror.New(pgcode.ReservedName, "function reserved for internal use")
 )
 
 // NewAggInAggError creates an error for the case when an aggregate function is
@@ -1206,8 +1219,15 @@ func (expr *FuncExpr) TypeCheck(
 		return sb.String()
 	}
 
+	overloads := def.Overloads
+	if expr.Variadic {
+		// The last argument is passed as is to the VARIADIC parameter, so only
+		// the variadic overloads apply, and they are matched against their
+		// declared parameter types.
+		overloads = variadicCallOverloads(overloads)
+	}
 	s := getOverloadTypeChecker(
-		(*qualifiedOverloads)(&def.Overloads), expr.Exprs...,
+		(*qualifiedOverloads)(&overloads), expr.Exprs...,
 	)
 	defer s.release()
 
@@ -1223,7 +1243,7 @@ func (expr *FuncExpr) TypeCheck(
 			// resetting the UDF overloads to their original state.
 			var functionIdxs []int
 			var functionOverloads []QualifiedOverload
-			for idx, o := range def.Overloads {
+			for idx, o := range overloads {
 				if o.Type == UDFRoutine {
 					o.Type = ProcedureRoutine
 					functionIdxs = append(functionIdxs, idx)
@@ -1233,7 +1253,7 @@ func (expr *FuncExpr) TypeCheck(
 			if len(functionIdxs) > 0 {
 				defer func() {
 					for _, idx := range functionIdxs {
-						def.Overloads[idx].Type = UDFRoutine
+						overloads[idx].Type = UDFRoutine
 					}
 				}()
 				s2 := getOverloadTypeChecker((*qualifiedOverloads)(&functionOverloads), expr.Exprs...)
@@ -1259,13 +1279,13 @@ func (expr *FuncExpr) TypeCheck(
 	var hasUDFOverload bool
 	var calledOnNullInputFns, notCalledOnNullInputFns intsets.Fast
 	for _, idx := range s.overloadIdxs {
-		if def.Overloads[idx].CalledOnNullInput {
+		if overloads[idx].CalledOnNullInput {
 			calledOnNullInputFns.Add(int(idx))
 		} else {
 			notCalledOnNullInputFns.Add(int(idx))
 		}
 		// TODO(harding): Check if this is a record-returning UDF instead.
-		if def.Overloads[idx].Type == UDFRoutine {
+		if overloads[idx].Type == UDFRoutine {
 			hasUDFOverload = true
 		}
 	}
@@ -1285,7 +1305,7 @@ func (expr *FuncExpr) TypeCheck(
 			if s.typedExprs[i].ResolvedType().Family() == types.UnknownFamily {
 				var filtered intsets.Fast
 				for j, ok := notCalledOnNullInputFns.Next(0); ok; j, ok = notCalledOnNullInputFns.Next(j + 1) {
-					if def.Overloads[j].params().GetAt(i).Equivalent(types.String) {
+					if overloads[j].params().GetAt(i).Equivalent(types.String) {
 						filtered.Add(j)
 					}
 				}
@@ -1346,11 +1366,11 @@ func (expr *FuncExpr) TypeCheck(
 		// If the function is resolved by OID, we know that there is always only one
 		// overload qualified. As long as it passes the argument type checks above,
 		// there is no need to worry about the search path.
-		favoredOverload = def.Overloads[0]
+		favoredOverload = overloads[0]
 	} else {
 		// Get overloads from the most significant schema in search path.
 		favoredOverload, err = getMostSignificantOverload(
-			def.Overloads, s.overloads, s.overloadIdxs, searchPath, expr, s.typedExprs,
+			overloads, s.overloads, s.overloadIdxs, searchPath, expr, s.typedExprs,
 			func() string { return getFuncSig(expr, s.typedExprs, desired) },
 		)
 		if err != nil {
@@ -1814,6 +1834,20 @@ func (expr DefaultVal) TypeCheck(
 	return nil, errInvalidDefaultUsage
 }
 
+// TypeCheck implements the Expr interface.
+func (expr *GroupingExpr) TypeCheck(
+	_ context.Context, _ *SemaContext, desired *types.T,
+) (TypedExpr, error) {
+	return nil, errInvalidGroupingUsage
+}
+
+// TypeCheck implements the Expr interface.
+func (expr *GroupingSet) TypeCheck(
+	_ context.Context, _ *SemaContext, desired *types.T,
+) (TypedExpr, error) {
+	return nil, pgerror.Newf(pgcode.Syntax, "%s can only appear in a GROUP BY clause", expr.Type)
+}
+
 // TypeCheck implements the Expr interface.
 func (expr PartitionMinVal) TypeCheck(
 	_ context.Context, _ *SemaContext, desired *types.T,
@@ -2116,6 +2150,12 @@ func (d *DPGLSN) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (Typed
 	return d, nil
 }
 
+// TypeCheck implements the Expr interface. It is implemented as an idempotent
+// identity function for Datum.
+func (d *DRange) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
+	return d, nil
+}
+
 // TypeCheck implements the Expr interface. It is implemented as an idempotent
 // identity function for Datum.
 func (d *DPGVector) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
@@ -2708,8 +2748,10 @@ func typeCheckComparisonOp(
 		}
 	}
 
-	leftIsGeneric := leftFamily == types.CollatedStringFamily || leftFamily == types.ArrayFamily || leftFamily == types.EnumFamily
-	rightIsGeneric := rightFamily == types.CollatedStringFamily || rightFamily == types.ArrayFamily || rightFamily == types.EnumFamily
+	leftIsGeneric := leftFamily == types.CollatedStringFamily || leftFamily == types.ArrayFamily ||
+		leftFamily == types.EnumFamily || leftFamily == types.RangeFamily
+	rightIsGeneric := rightFamily == types.CollatedStringFamily || rightFamily == types.ArrayFamily ||
+		rightFamily == types.EnumFamily || rightFamily == types.RangeFamily
 	genericComparison := leftIsGeneric && rightIsGeneric
 
 	typeMismatch := false
@@ -3449,6 +3491,23 @@ func (v stripFuncsVisitor) VisitPre(expr Expr) (recurse bool, newExpr Expr) {
 
 func (stripFuncsVisitor) VisitPost(expr Expr) Expr { return expr }
 
+// variadicCallOverloads returns the overloads with a VARIADIC parameter,
+// adjusted so that their last parameter accepts an array rather than a list of
+// arguments. It is used to type-check calls in which the last argument is
+// marked VARIADIC.
+func variadicCallOverloads(overloads []QualifiedOverload) []QualifiedOverload {
+	var res []QualifiedOverload
+	for _, o := range overloads {
+		if !o.Variadic {
+			continue
+		}
+		ov := *o.Overload
+		ov.Variadic = false
+		res = append(res, QualifiedOverload{Schema: o.Schema, Overload: &ov})
+	}
+	return res
+}
+
 // getMostSignificantOverload returns the overload from the most significant
 // schema. If there are more than one overload available from the most
 // significant schema, ambiguity error will be thrown. If search path is not
@@ -3525,8 +3584,13 @@ func getMostSignificantOverload(
 				} else {
 					inputTypes = allArgTypes
 				}
-				// TODO(88947): this logic might need to change to support
-				// VARIADIC.
+				if _, ok := srcParams.(VariadicType); ok {
+					// A VARIADIC routine cannot have DEFAULT expressions, and
+					// OUT parameters are not allowed in a VARIADIC procedure,
+					// so the argument types have already been fully matched
+					// above.
+					continue
+				}
 				ovInputTypes, ok := srcParams.(ParamTypes)
 				if !ok {
 					return QualifiedOverload{}, errors.AssertionFailedf("overload params is %T and not ParamTypes", srcParams)
@@ -0,0 +1,56 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// SetConstraints sets the check mode of deferrable constraints for the rest
+// of the current transaction. As in Postgres, switching constraints to
+// IMMEDIATE runs the checks which were deferred so far right away.
+func (p *planner) SetConstraints(ctx context.Context, n *tree.SetConstraints) (planNode, error) {
+	dc := p.extendedEvalCtx.deferredConstraints
+	if p.extendedEvalCtx.TxnImplicit || dc == nil {
+		// This no-ops in postgres with a warning, so copy accordingly.
+		p.BufferClientNotice(
+			ctx,
+			pgnotice.NewWithSeverityf(
+				"WARNING",
+				"SET CONSTRAINTS can only be used in transaction blocks",
+			),
+		)
+		return newZeroNode(nil /* columns */), nil
+	}
+
+	mode := eval.ConstraintModeImmediate
+	if n.Deferred {
+		mode = eval.ConstraintModeDeferred
+	}
+	names := make([]string, len(n.Names))
+	for i := range n.Names {
+		names[i] = string(n.Names[i])
+	}
+	dc.modes = dc.modes.Set(names, mode)
+	modes := p.EvalContext().ConstraintModes.Set(names, mode)
+	p.EvalContext().ConstraintModes = modes
+
+	if !n.Deferred && dc.hasPending() {
+		if err := dc.validate(ctx, p, modes.IsDeferred); err != nil {
+			return nil, err
+		}
+	}
+	return newZeroNode(nil /* columns */), nil
+}
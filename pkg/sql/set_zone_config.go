@@ -618,6 +618,14 @@ func (n *setZoneConfigNode) startExec(params runParams) error {
 				return err
 			}
 
+			// The num_witnesses option is checked when it's evaluated, but it can
+			// also be set through YAML.
+			if finalZone.NumWitnesses != nil {
+				if err := zone.CheckNumWitnessesAllowed(params.ctx, params.ExecCfg().Settings); err != nil {
+					return err
+				}
+			}
+
 			currentZone := zonepb.NewZoneConfig()
 			if currentZoneConfigWithRaw, err := params.p.Descriptors().GetZoneConfig(
 				params.ctx, params.p.Txn(), targetID,
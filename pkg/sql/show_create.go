@@ -20,6 +20,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/util/tracing"
@@ -90,14 +91,24 @@ func ShowCreateTable(
 	if desc.IsTemporary() {
 		f.WriteString("TEMP ")
 	}
+	if desc.IsForeignTable() {
+		f.WriteString("FOREIGN ")
+	}
 	f.WriteString("TABLE ")
 	f.FormatNode(tn)
 	f.WriteString(" (")
-	// Inaccessible columns are not displayed in SHOW CREATE TABLE.
-	for i, col := range desc.AccessibleColumns() {
-		if i != 0 {
+	// Inaccessible columns are not displayed in SHOW CREATE TABLE. The hidden
+	// primary key column of a foreign table is not displayed either, since it
+	// is not part of its definition.
+	first := true
+	for _, col := range desc.AccessibleColumns() {
+		if desc.IsForeignTable() && col.IsHidden() {
+			continue
+		}
+		if !first {
 			f.WriteString(",")
 		}
+		first = false
 		f.WriteString("\n\t")
 		colstr, err := schemaexpr.FormatColumnForDisplay(
 			ctx, desc, col, p.EvalContext(), &p.semaCtx, p.SessionData(),
@@ -109,6 +120,29 @@ func ShowCreateTable(
 		f.WriteString(colstr)
 	}
 
+	if ft := desc.GetForeignTable(); ft != nil {
+		f.WriteString("\n) SERVER ")
+		f.FormatNameP(&ft.Server)
+		if len(ft.Options) > 0 {
+			f.WriteString(" OPTIONS (")
+			for i := range ft.Options {
+				if i > 0 {
+					f.WriteString(", ")
+				}
+				f.FormatNameP(&ft.Options[i].Key)
+				f.WriteByte(' ')
+				lexbase.EncodeSQLString(&f.Buffer, ft.Options[i].Value)
+			}
+			f.WriteString(")")
+		}
+		if !displayOptions.IgnoreComments {
+			if err := showComments(tn, desc, selectComment(ctx, p, desc.GetID()), &f.Buffer); err != nil {
+				return "", err
+			}
+		}
+		return f.CloseAndGetString(), nil
+	}
+
 	if desc.IsPhysicalTable() {
 		f.WriteString(",\n\tCONSTRAINT ")
 		formatQuoteNames(&f.Buffer, desc.GetPrimaryIndex().GetName())
@@ -147,6 +181,12 @@ func ShowCreateTable(
 	for _, idx := range desc.PublicNonPrimaryIndexes() {
 		// Showing the primary index is handled above.
 
+		// Indexes backing exclusion constraints are created by the EXCLUDE
+		// clause, which is shown below.
+		if exclusionConstraintBackedByIndex(desc, idx.GetID()) != nil {
+			continue
+		}
+
 		// Build the PARTITION BY clause.
 		var partitionBuf bytes.Buffer
 		if err := ShowCreatePartitioning(
@@ -593,6 +593,10 @@ func showForeignKeyConstraint(
 		buf.WriteString(" ON UPDATE ")
 		buf.WriteString(tree.ForeignKeyReferenceActionType[fk.OnUpdate].String())
 	}
+	if fk.Deferrability != semenumpb.Deferrability_NOT_DEFERRABLE {
+		buf.WriteByte(' ')
+		buf.WriteString(tree.ConstraintDeferrabilityType[fk.Deferrability].String())
+	}
 	if fk.Validity != descpb.ConstraintValidity_Validated {
 		buf.WriteString(" NOT VALID")
 	}
@@ -872,10 +876,33 @@ func showConstraintClause(
 			}
 			f.WriteString(pred)
 		}
+		if d := c.Deferrability(); d != semenumpb.Deferrability_NOT_DEFERRABLE {
+			f.WriteString(" ")
+			f.WriteString(tree.ConstraintDeferrabilityType[d].String())
+		}
 		if !c.IsConstraintValidated() {
 			f.WriteString(" NOT VALID")
 		}
 	}
+	for _, c := range desc.GetExclusionConstraints() {
+		f.WriteString(",\n\t")
+		f.WriteString("CONSTRAINT ")
+		formatQuoteNames(&f.Buffer, c.Name)
+		f.WriteString(" EXCLUDE USING gist (")
+		for i, colID := range c.ColumnIDs {
+			col, err := catalog.MustFindColumnByID(desc, colID)
+			if err != nil {
+				return err
+			}
+			if i > 0 {
+				f.WriteString(", ")
+			}
+			formatQuoteNames(&f.Buffer, col.GetName())
+			f.WriteString(" WITH ")
+			f.WriteString(c.Operators[i])
+		}
+		f.WriteString(")")
+	}
 	f.WriteString("\n)")
 	return nil
 }
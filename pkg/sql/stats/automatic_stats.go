@@ -327,6 +327,10 @@ func (r *Refresher) autoStatsEnabled(desc catalog.TableDescriptor) bool {
 		// If the descriptor could not be accessed, defer to the cluster setting.
 		return AutomaticStatisticsClusterMode.Get(&r.st.SV)
 	}
+	if desc.IsForeignTable() {
+		// Statistics cannot be collected on foreign tables.
+		return false
+	}
 	enabledForTable := desc.AutoStatsCollectionEnabled()
 	// The table-level setting of sql_stats_automatic_collection_enabled takes
 	// precedence over the cluster setting.
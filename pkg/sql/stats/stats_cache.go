@@ -309,6 +309,11 @@ func tableTypeCanHaveStats(table catalog.TableDescriptor) bool {
 		// Don't try to get statistics for views.
 		return false
 	}
+	if table.IsForeignTable() {
+		// Foreign tables store no rows, so statistics collected on them would be
+		// misleading.
+		return false
+	}
 	return true
 }
 
@@ -62,6 +62,9 @@ func (po *Setter) RunPostChecks() error {
 	if err := tabledesc.ValidateRowLevelTTL(po.UpdatedRowLevelTTL); err != nil {
 		return err
 	}
+	if err := tabledesc.ValidatePartitionMaintenance(po.TableDesc.PartitionMaintenance); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -123,6 +126,13 @@ func (po *Setter) getOrCreateRowLevelTTL() *catpb.RowLevelTTL {
 	return rowLevelTTL
 }
 
+func (po *Setter) getOrCreatePartitionMaintenance() *catpb.PartitionMaintenance {
+	if po.TableDesc.PartitionMaintenance == nil {
+		po.TableDesc.PartitionMaintenance = &catpb.PartitionMaintenance{}
+	}
+	return po.TableDesc.PartitionMaintenance
+}
+
 type tableParam struct {
 	onSet   func(ctx context.Context, po *Setter, semaCtx *tree.SemaContext, evalCtx *eval.Context, key string, datum tree.Datum) error
 	onReset func(ctx context.Context, po *Setter, evalCtx *eval.Context, key string) error
@@ -456,6 +466,66 @@ var tableParams = map[string]tableParam{
 			return nil
 		},
 	},
+	`partition_interval`: {
+		onSet: func(ctx context.Context, po *Setter, semaCtx *tree.SemaContext, evalCtx *eval.Context, key string, datum tree.Datum) error {
+			str, err := paramparse.DatumAsString(ctx, evalCtx, key, datum)
+			if err != nil {
+				return err
+			}
+			d, err := tabledesc.ParsePartitionInterval(key, str)
+			if err != nil {
+				return err
+			}
+			if err := tabledesc.ValidatePartitionInterval(key, d); err != nil {
+				return err
+			}
+			po.getOrCreatePartitionMaintenance().Interval = d.String()
+			return nil
+		},
+		onReset: func(_ context.Context, po *Setter, evalCtx *eval.Context, key string) error {
+			po.TableDesc.PartitionMaintenance = nil
+			return nil
+		},
+	},
+	`partition_retention`: {
+		onSet: func(ctx context.Context, po *Setter, semaCtx *tree.SemaContext, evalCtx *eval.Context, key string, datum tree.Datum) error {
+			str, err := paramparse.DatumAsString(ctx, evalCtx, key, datum)
+			if err != nil {
+				return err
+			}
+			d, err := tabledesc.ParsePartitionInterval(key, str)
+			if err != nil {
+				return err
+			}
+			po.getOrCreatePartitionMaintenance().Retention = d.String()
+			return nil
+		},
+		onReset: func(_ context.Context, po *Setter, evalCtx *eval.Context, key string) error {
+			if pm := po.TableDesc.PartitionMaintenance; pm != nil {
+				pm.Retention = ""
+			}
+			return nil
+		},
+	},
+	`partition_lookahead`: {
+		onSet: func(ctx context.Context, po *Setter, semaCtx *tree.SemaContext, evalCtx *eval.Context, key string, datum tree.Datum) error {
+			val, err := intFromDatum(ctx, evalCtx, key, datum)
+			if err != nil {
+				return err
+			}
+			if err := tabledesc.ValidatePartitionLookahead(key, val); err != nil {
+				return err
+			}
+			po.getOrCreatePartitionMaintenance().Lookahead = val
+			return nil
+		},
+		onReset: func(_ context.Context, po *Setter, evalCtx *eval.Context, key string) error {
+			if pm := po.TableDesc.PartitionMaintenance; pm != nil {
+				pm.Lookahead = 0
+			}
+			return nil
+		},
+	},
 	`exclude_data_from_backup`: {
 		onSet: func(ctx context.Context, po *Setter, semaCtx *tree.SemaContext,
 			evalCtx *eval.Context, key string, datum tree.Datum) error {
@@ -150,6 +150,9 @@ type tableWriterBase struct {
 	// originID is an identifier for the cluster that originally wrote the data
 	// being written by the table writer during Logical Data Replication.
 	originID uint32
+	// deferredKeys, if set, queues the keys of the written rows for the
+	// deferred constraint checks of the table.
+	deferredKeys *deferredKeyRecorder
 }
 
 var maxBatchBytes = settings.RegisterByteSizeSetting(
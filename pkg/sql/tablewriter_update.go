@@ -54,7 +54,12 @@ func (tu *tableUpdater) rowForUpdate(
 	traceKV bool,
 ) (tree.Datums, error) {
 	tu.currentBatchSize++
-	return tu.ru.UpdateRow(ctx, tu.b, oldValues, updateValues, pm, traceKV)
+	newValues, err := tu.ru.UpdateRow(ctx, tu.b, oldValues, updateValues, pm, traceKV)
+	if err != nil {
+		return nil, err
+	}
+	tu.deferredKeys.updated(oldValues, newValues, tu.ru.FetchColIDtoRowIndex)
+	return newValues, nil
 }
 
 // tableDesc is part of the tableWriter interface.
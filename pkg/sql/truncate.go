@@ -213,6 +213,14 @@ func (p *planner) truncateTable(ctx context.Context, id descpb.ID, jobDesc strin
 		}
 	}
 
+	// Unset the indexes backing exclusion constraints until the new index IDs
+	// are known.
+	exclusionIndexIDs := make([]descpb.IndexID, len(tableDesc.ExclusionConstraints))
+	for i := range tableDesc.ExclusionConstraints {
+		exclusionIndexIDs[i] = tableDesc.ExclusionConstraints[i].IndexID
+		tableDesc.ExclusionConstraints[i].IndexID = 0
+	}
+
 	// Create new ID's for all of the indexes in the table.
 	{
 		version := p.ExecCfg().Settings.Version.ActiveVersion(ctx)
@@ -232,6 +240,9 @@ func (p *planner) truncateTable(ctx context.Context, id descpb.ID, jobDesc strin
 	for _, idx := range tableDesc.ActiveIndexes() {
 		indexIDMapping[oldIndexes[idx.Ordinal()].ID] = idx.GetID()
 	}
+	for i, indexID := range exclusionIndexIDs {
+		tableDesc.ExclusionConstraints[i].IndexID = indexIDMapping[indexID]
+	}
 
 	// Create schema change GC jobs for all of the indexes.
 	dropTime := timeutil.Now().UnixNano()
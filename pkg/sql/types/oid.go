@@ -72,6 +72,8 @@ var OidToType = map[oid.Oid]*T{
 	oid.T_int2vector: Int2Vector,
 	oid.T_int4:       Int4,
 	oid.T_int8:       Int,
+	oid.T_int4range:  Int4Range,
+	oid.T_int8range:  Int8Range,
 	oid.T_inet:       INet,
 	oid.T_interval:   Interval,
 	// NOTE(sql-exp): Uncomment the line below if we support the JSON type.
@@ -81,6 +83,8 @@ var OidToType = map[oid.Oid]*T{
 	oid.T_jsonb:        Jsonb,
 	oid.T_name:         Name,
 	oid.T_numeric:      Decimal,
+	oid.T_numrange:     NumRange,
+	oid.T_daterange:    DateRange,
 	oid.T_oid:          Oid,
 	oid.T_oidvector:    OidVector,
 	oid.T_pg_lsn:       PGLSN,
@@ -97,8 +101,11 @@ var OidToType = map[oid.Oid]*T{
 	oid.T_timetz:       TimeTZ,
 	oid.T_timestamp:    Timestamp,
 	oid.T_timestamptz:  TimestampTZ,
+	oid.T_trigger:      Trigger,
 	oid.T_tsquery:      TSQuery,
 	oid.T_tsvector:     TSVector,
+	oid.T_tsrange:      TSRange,
+	oid.T_tstzrange:    TSTZRange,
 	oid.T_unknown:      Unknown,
 	oid.T_uuid:         Uuid,
 	oid.T_varbit:       VarBit,
@@ -120,6 +127,7 @@ var oidToArrayOid = map[oid.Oid]oid.Oid{
 	oid.T_bytea:        oid.T__bytea,
 	oid.T_char:         oid.T__char,
 	oid.T_date:         oid.T__date,
+	oid.T_daterange:    oid.T__daterange,
 	oid.T_float4:       oid.T__float4,
 	oid.T_float8:       oid.T__float8,
 	oid.T_inet:         oid.T__inet,
@@ -127,10 +135,13 @@ var oidToArrayOid = map[oid.Oid]oid.Oid{
 	oid.T_int2vector:   oid.T__int2vector,
 	oid.T_int4:         oid.T__int4,
 	oid.T_int8:         oid.T__int8,
+	oid.T_int4range:    oid.T__int4range,
+	oid.T_int8range:    oid.T__int8range,
 	oid.T_interval:     oid.T__interval,
 	oid.T_jsonb:        oid.T__jsonb,
 	oid.T_name:         oid.T__name,
 	oid.T_numeric:      oid.T__numeric,
+	oid.T_numrange:     oid.T__numrange,
 	oid.T_oid:          oid.T__oid,
 	oid.T_oidvector:    oid.T__oidvector,
 	oid.T_pg_lsn:       oid.T__pg_lsn,
@@ -149,6 +160,8 @@ var oidToArrayOid = map[oid.Oid]oid.Oid{
 	oid.T_timestamptz:  oid.T__timestamptz,
 	oid.T_tsquery:      oid.T__tsquery,
 	oid.T_tsvector:     oid.T__tsvector,
+	oid.T_tsrange:      oid.T__tsrange,
+	oid.T_tstzrange:    oid.T__tstzrange,
 	oid.T_uuid:         oid.T__uuid,
 	oid.T_varbit:       oid.T__varbit,
 	oid.T_varchar:      oid.T__varchar,
@@ -187,6 +200,8 @@ var familyToOid = map[Family]oid.Oid{
 	TSQueryFamily:        oid.T_tsquery,
 	TSVectorFamily:       oid.T_tsvector,
 	TupleFamily:          oid.T_record,
+	TriggerFamily:        oid.T_trigger,
+	RangeFamily:          oid.T_int8range,
 	BitFamily:            oid.T_bit,
 	AnyFamily:            oid.T_anyelement,
 
@@ -210,6 +225,11 @@ func init() {
 // type.
 func CalcArrayOid(elemTyp *T) oid.Oid {
 	o := elemTyp.Oid()
+	if elemTyp.IsDomain() {
+		// Domains have their own implicit array types, regardless of the family
+		// of their base type.
+		return elemTyp.UserDefinedArrayOID()
+	}
 	switch elemTyp.Family() {
 	case ArrayFamily:
 		// Postgres nested arrays return the OID of the nested array (i.e. the
@@ -232,6 +252,11 @@ func CalcArrayOid(elemTyp *T) oid.Oid {
 	case EnumFamily:
 		return elemTyp.UserDefinedArrayOID()
 
+	case RangeFamily:
+		if elemTyp.UserDefined() {
+			return elemTyp.UserDefinedArrayOID()
+		}
+
 	case TupleFamily:
 		if elemTyp.UserDefined() {
 			if elemTyp.TypeMeta.ImplicitRecordType {
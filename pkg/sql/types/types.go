@@ -215,6 +215,9 @@ type UserDefinedTypeMetadata struct {
 	// EnumData is non-nil iff the metadata is for an ENUM type.
 	EnumData *EnumMetadata
 
+	// DomainData is non-nil iff the metadata is for a DOMAIN type.
+	DomainData *DomainMetadata
+
 	// Version is the descriptor version of the descriptor used to construct
 	// this version of the type metadata.
 	Version uint32
@@ -241,6 +244,22 @@ type EnumMetadata struct {
 	//  should occur, if at all.
 }
 
+// DomainMetadata is metadata about a DOMAIN needed to enforce its
+// constraints.
+type DomainMetadata struct {
+	// NotNull is true if the domain does not allow NULL values.
+	NotNull bool
+	// Checks contains the CHECK constraints of the domain.
+	Checks []DomainCheck
+}
+
+// DomainCheck is a CHECK constraint of a DOMAIN. The expression refers to the
+// value being checked with the VALUE keyword.
+type DomainCheck struct {
+	Name string
+	Expr string
+}
+
 func (e *EnumMetadata) debugString() string {
 	return fmt.Sprintf(
 		"PhysicalReps: %v; LogicalReps: %s",
@@ -505,6 +524,16 @@ var (
 		},
 	}
 
+	// Trigger is the type representing a trigger. It is only used as the return
+	// type of trigger functions.
+	Trigger = &T{
+		InternalType: InternalType{
+			Family: TriggerFamily,
+			Oid:    oid.T_trigger,
+			Locale: &emptyLocale,
+		},
+	}
+
 	// EncodedKey is a special type used internally for passing encoded key data.
 	// It behaves similarly to Bytes in most circumstances, except
 	// encoding/decoding. It is currently used to pass around inverted index keys,
@@ -546,6 +575,63 @@ var (
 		},
 	}
 
+	// Int4Range is the type of a range of INT4 values.
+	Int4Range = &T{
+		InternalType: InternalType{
+			Family: RangeFamily,
+			Oid:    oid.T_int4range,
+			Locale: &emptyLocale,
+		},
+	}
+
+	// Int8Range is the type of a range of INT8 values.
+	Int8Range = &T{
+		InternalType: InternalType{
+			Family: RangeFamily,
+			Oid:    oid.T_int8range,
+			Locale: &emptyLocale,
+		},
+	}
+
+	// NumRange is the type of a range of DECIMAL values.
+	NumRange = &T{
+		InternalType: InternalType{
+			Family: RangeFamily,
+			Oid:    oid.T_numrange,
+			Locale: &emptyLocale,
+		},
+	}
+
+	// TSRange is the type of a range of TIMESTAMP values.
+	TSRange = &T{
+		InternalType: InternalType{
+			Family: RangeFamily,
+			Oid:    oid.T_tsrange,
+			Locale: &emptyLocale,
+		},
+	}
+
+	// TSTZRange is the type of a range of TIMESTAMPTZ values.
+	TSTZRange = &T{
+		InternalType: InternalType{
+			Family: RangeFamily,
+			Oid:    oid.T_tstzrange,
+			Locale: &emptyLocale,
+		},
+	}
+
+	// DateRange is the type of a range of DATE values.
+	DateRange = &T{
+		InternalType: InternalType{
+			Family: RangeFamily,
+			Oid:    oid.T_daterange,
+			Locale: &emptyLocale,
+		},
+	}
+
+	// RangeTypes contains all the built-in range types.
+	RangeTypes = []*T{Int4Range, Int8Range, NumRange, TSRange, TSTZRange, DateRange}
+
 	// Scalar contains all types that meet this criteria:
 	//
 	//   1. Scalar type (no ArrayFamily or TupleFamily types).
@@ -597,6 +683,13 @@ var (
 	AnyEnum = &T{InternalType: InternalType{
 		Family: EnumFamily, Locale: &emptyLocale, Oid: oid.T_anyenum}}
 
+	// AnyRange is a special type only used during static analysis as a
+	// wildcard type that matches any range value, including values of
+	// user-defined range types. Execution-time values should never have this
+	// type.
+	AnyRange = &T{InternalType: InternalType{
+		Family: RangeFamily, Locale: &emptyLocale, Oid: oid.T_anyrange}}
+
 	// AnyTuple is a special type used only during static analysis as a wildcard
 	// type that matches a tuple with any number of fields of any type (including
 	// tuple types). Execution-time values should never have this type.
@@ -1187,6 +1280,36 @@ func MakeEnum(typeOID, arrayTypeOID oid.Oid) *T {
 	}}
 }
 
+// MakeRange constructs a new instance of a user-defined RangeFamily type over
+// the given element type, with the given stable type ID. Note that it does not
+// hydrate cached fields on the type.
+func MakeRange(typeOID, arrayTypeOID oid.Oid, contents *T) *T {
+	return &T{InternalType: InternalType{
+		Family:        RangeFamily,
+		Oid:           typeOID,
+		Locale:        &emptyLocale,
+		RangeContents: contents,
+		UDTMetadata: &PersistentUserDefinedTypeMetadata{
+			ArrayTypeOID: arrayTypeOID,
+		},
+	}}
+}
+
+// MakeDomain constructs a new instance of a domain type over the given base
+// type, with the given stable type ID. The domain has the same family, width,
+// precision and locale as its base type, so it behaves like the base type
+// everywhere but for its OID. Note that it does not hydrate cached fields on
+// the type.
+func MakeDomain(typeOID, arrayTypeOID oid.Oid, base *T) *T {
+	internalType := base.InternalType
+	internalType.Oid = typeOID
+	internalType.UDTMetadata = &PersistentUserDefinedTypeMetadata{
+		ArrayTypeOID:  arrayTypeOID,
+		DomainBaseOID: base.Oid(),
+	}
+	return &T{InternalType: internalType}
+}
+
 // MakeArray constructs a new instance of an ArrayFamily type with the given
 // element type (which may itself be an ArrayFamily type).
 func MakeArray(typ *T) *T {
@@ -1463,6 +1586,36 @@ func (t *T) ArrayContents() *T {
 	return t.InternalType.ArrayContents
 }
 
+// RangeContents returns the type of the bounds of a range. This is nil for
+// types that are not in the RangeFamily. The element type of the built-in range
+// types is determined by their Oid, while user-defined range types store it.
+func (t *T) RangeContents() *T {
+	if t.Family() != RangeFamily {
+		return nil
+	}
+	if t.InternalType.RangeContents != nil {
+		return t.InternalType.RangeContents
+	}
+	switch t.Oid() {
+	case oid.T_anyrange:
+		return Any
+	case oid.T_int4range:
+		return Int4
+	case oid.T_int8range:
+		return Int
+	case oid.T_numrange:
+		return Decimal
+	case oid.T_tsrange:
+		return Timestamp
+	case oid.T_tstzrange:
+		return TimestampTZ
+	case oid.T_daterange:
+		return Date
+	default:
+		panic(errors.AssertionFailedf("unexpected range Oid: %d", t.Oid()))
+	}
+}
+
 // TupleContents returns a slice containing the type of each tuple field. This
 // is nil for non-TupleFamily types.
 func (t *T) TupleContents() []*T {
@@ -1487,6 +1640,23 @@ func (t *T) UserDefinedArrayOID() oid.Oid {
 	return t.InternalType.UDTMetadata.ArrayTypeOID
 }
 
+// IsDomain returns whether or not t is a domain type.
+func (t *T) IsDomain() bool {
+	return t.InternalType.UDTMetadata != nil && t.InternalType.UDTMetadata.DomainBaseOID != 0
+}
+
+// DomainBaseType returns the base type of a domain type, and nil for all other
+// types.
+func (t *T) DomainBaseType() *T {
+	if !t.IsDomain() {
+		return nil
+	}
+	base := &T{InternalType: t.InternalType}
+	base.InternalType.Oid = t.InternalType.UDTMetadata.DomainBaseOID
+	base.InternalType.UDTMetadata = nil
+	return base
+}
+
 // RemapUserDefinedTypeOIDs is used to remap OIDs stored within a types.T
 // that is a user defined type. The newArrayOID argument is ignored if the
 // input type is an Array type. It mutates the input types.T and should only
@@ -1533,12 +1703,14 @@ var familyNames = map[Family]redact.SafeString{
 	OidFamily:            "oid",
 	PGLSNFamily:          "pg_lsn",
 	PGVectorFamily:       "vector",
+	RangeFamily:          "range",
 	RefCursorFamily:      "refcursor",
 	StringFamily:         "string",
 	TimeFamily:           "time",
 	TimestampFamily:      "timestamp",
 	TimestampTZFamily:    "timestamptz",
 	TimeTZFamily:         "timetz",
+	TriggerFamily:        "trigger",
 	TSQueryFamily:        "tsquery",
 	TSVectorFamily:       "tsvector",
 	TupleFamily:          "tuple",
@@ -1567,6 +1739,13 @@ func (f Family) Name() redact.SafeString {
 //
 // TODO(andyk): Should these be changed to be the same as SQLStandardName?
 func (t *T) Name() string {
+	if t.IsDomain() {
+		// This can be nil during unit testing.
+		if t.TypeMeta.Name == nil {
+			return t.DomainBaseType().Name()
+		}
+		return t.TypeMeta.Name.Basename()
+	}
 	switch fam := t.Family(); fam {
 	case AnyFamily:
 		return "anyelement"
@@ -1629,6 +1808,13 @@ func (t *T) Name() string {
 		}
 		panic(errors.AssertionFailedf("unexpected OID: %d", t.Oid()))
 
+	case RangeFamily:
+		if t.UserDefined() && t.TypeMeta.Name == nil {
+			// This can be nil during unit testing.
+			return "unknown_range"
+		}
+		return t.PGName()
+
 	case TupleFamily:
 		return t.SQLStandardName()
 
@@ -1706,6 +1892,9 @@ func (t *T) TelemetryName() string {
 // This function is full of special cases. See backend/utils/adt/format_type.c
 // in Postgres.
 func (t *T) SQLStandardNameWithTypmod(haveTypmod bool, typmod int) string {
+	if t.IsDomain() && t.TypeMeta.Name != nil {
+		return t.TypeMeta.Name.Basename()
+	}
 	var buf strings.Builder
 	switch t.Family() {
 	case AnyFamily:
@@ -1875,10 +2064,14 @@ func (t *T) SQLStandardNameWithTypmod(haveTypmod bool, typmod int) string {
 			return "timestamp with time zone"
 		}
 		return fmt.Sprintf("timestamp(%d) with time zone", typmod)
+	case RangeFamily:
+		return t.PGName()
 	case TSQueryFamily:
 		return "tsquery"
 	case TSVectorFamily:
 		return "tsvector"
+	case TriggerFamily:
+		return "trigger"
 	case TupleFamily:
 		if t.UserDefined() {
 			// If we have a user-defined tuple type, use its user-defined name.
@@ -1919,6 +2112,12 @@ func (t *T) InformationSchemaName() string {
 // reproduce the type via parsing the string as a type. It is used in error
 // messages and also to produce the output of SHOW CREATE.
 func (t *T) SQLString() string {
+	if t.IsDomain() {
+		if t.TypeMeta.Name == nil {
+			return fmt.Sprintf("@%d", t.Oid())
+		}
+		return t.TypeMeta.Name.FQName(false /* explicitCatalog */)
+	}
 	switch t.Family() {
 	case BitFamily:
 		o := t.Oid()
@@ -2008,6 +2207,14 @@ func (t *T) SQLString() string {
 			return t.ArrayContents().collatedStringTypeSQL(true /* isArray */)
 		}
 		return t.ArrayContents().SQLString() + "[]"
+	case RangeFamily:
+		if !t.UserDefined() {
+			break
+		}
+		if t.TypeMeta.Name == nil {
+			return fmt.Sprintf("@%d", t.Oid())
+		}
+		return t.TypeMeta.Name.FQName(false /* explicitCatalog */)
 	case EnumFamily:
 		// TODO(125934): Include composite type names in this branch as well, so
 		// they can be properly identified in SHOW CREATE.
@@ -2079,7 +2286,8 @@ func (t *T) SQLStringForError() redact.RedactableString {
 		IntervalFamily, StringFamily, BytesFamily, TimestampTZFamily, CollatedStringFamily, OidFamily,
 		UnknownFamily, UuidFamily, INetFamily, TimeFamily, JsonFamily, TimeTZFamily, BitFamily,
 		GeometryFamily, GeographyFamily, Box2DFamily, VoidFamily, EncodedKeyFamily, TSQueryFamily,
-		TSVectorFamily, AnyFamily, PGLSNFamily, PGVectorFamily, RefCursorFamily:
+		TSVectorFamily, AnyFamily, PGLSNFamily, PGVectorFamily, RefCursorFamily, TriggerFamily,
+		RangeFamily:
 		// These types do not contain other types, and do not require redaction.
 		return redact.Sprint(redact.SafeString(t.SQLString()))
 	}
@@ -2154,6 +2362,16 @@ func (t *T) Equivalent(other *T) bool {
 		if t.Oid() != other.Oid() {
 			return false
 		}
+
+	case RangeFamily:
+		// If one of the types is anyrange, then allow the comparison to go
+		// through -- anyrange is used when matching overloads.
+		if t.Oid() == oid.T_anyrange || other.Oid() == oid.T_anyrange {
+			return true
+		}
+		if t.Oid() != other.Oid() {
+			return false
+		}
 	}
 
 	return true
@@ -2217,7 +2435,7 @@ func (t *T) Equal(other *T) bool {
 // static analysis, and cannot be used during execution.
 func (t *T) IsWildcardType() bool {
 	for _, wildcard := range []*T{
-		Any, AnyArray, AnyCollatedString, AnyEnum, AnyEnumArray, AnyTuple, AnyTupleArray,
+		Any, AnyArray, AnyCollatedString, AnyEnum, AnyEnumArray, AnyRange, AnyTuple, AnyTupleArray,
 	} {
 		// Note that pointer comparison is insufficient since we might have
 		// deserialized t from disk.
@@ -2310,6 +2528,15 @@ func (t *InternalType) Identical(other *InternalType) bool {
 	} else if other.ArrayContents != nil {
 		return false
 	}
+	if t.RangeContents != nil && other.RangeContents != nil {
+		if !t.RangeContents.Identical(other.RangeContents) {
+			return false
+		}
+	} else if t.RangeContents != nil {
+		return false
+	} else if other.RangeContents != nil {
+		return false
+	}
 	if len(t.TupleContents) != len(other.TupleContents) {
 		return false
 	}
@@ -2330,6 +2557,9 @@ func (t *InternalType) Identical(other *InternalType) bool {
 		if t.UDTMetadata.ArrayTypeOID != other.UDTMetadata.ArrayTypeOID {
 			return false
 		}
+		if t.UDTMetadata.DomainBaseOID != other.UDTMetadata.DomainBaseOID {
+			return false
+		}
 	} else if t.UDTMetadata != nil {
 		return false
 	} else if other.UDTMetadata != nil {
@@ -2362,6 +2592,10 @@ func (t *T) Unmarshal(data []byte) error {
 // setting required values. This is necessary to preserve backwards-
 // compatibility with older formats (e.g. restoring database from old backup).
 func (t *T) upgradeType() error {
+	if t.IsDomain() {
+		// Domain types are stored like their base type, except for the OID.
+		return t.withDomainBaseType((*T).upgradeType)
+	}
 	switch t.Family() {
 	case IntFamily:
 		// Check VisibleType field that was populated in previous versions.
@@ -2496,11 +2730,6 @@ func (t *T) upgradeType() error {
 			t.InternalType.Oid = CalcArrayOid(t.ArrayContents())
 		}
 
-		// Marshaling/unmarshaling nested arrays is not yet supported.
-		if t.ArrayContents().Family() == ArrayFamily {
-			return errors.AssertionFailedf("nested array should never be unmarshaled")
-		}
-
 		// Zero out fields that may have been used to store information about
 		// the array element type, or which are no longer in use.
 		t.InternalType.Width = 0
@@ -2599,6 +2828,9 @@ func (t *T) MarshalTo(data []byte) (int, error) {
 // CRDB. This is necessary to preserve backwards-compatibility in mixed-version
 // scenarios, such as during upgrade.
 func (t *T) downgradeType() error {
+	if t.IsDomain() {
+		return t.withDomainBaseType((*T).downgradeType)
+	}
 	// Set Family and VisibleType for 19.1 backwards-compatibility.
 	switch t.Family() {
 	case BitFamily:
@@ -2629,11 +2861,6 @@ func (t *T) downgradeType() error {
 		}
 
 	case ArrayFamily:
-		// Marshaling/unmarshaling nested arrays is not yet supported.
-		if t.ArrayContents().Family() == ArrayFamily {
-			return errors.AssertionFailedf("nested array should never be marshaled")
-		}
-
 		// Downgrade to array representation used before 19.2, in which the array
 		// type fields specified the width, locale, etc. of the element type.
 		temp := *t.InternalType.ArrayContents
@@ -2662,6 +2889,18 @@ func (t *T) downgradeType() error {
 	return nil
 }
 
+// withDomainBaseType calls fn on t after temporarily replacing its OID with
+// the OID of its domain base type, so that the fields of the base type can be
+// upgraded or downgraded like those of any other type.
+func (t *T) withDomainBaseType(fn func(*T) error) error {
+	domainOID, md := t.InternalType.Oid, t.InternalType.UDTMetadata
+	t.InternalType.Oid = md.DomainBaseOID
+	t.InternalType.UDTMetadata = nil
+	err := fn(t)
+	t.InternalType.Oid, t.InternalType.UDTMetadata = domainOID, md
+	return err
+}
+
 // String returns the name of the type, similar to the Name method. However, it
 // expands CollatedStringFamily, ArrayFamily, and TupleFamily types to be more
 // descriptive.
@@ -2763,6 +3002,8 @@ func (t *T) IsAmbiguous() bool {
 		return t.ArrayContents().IsAmbiguous()
 	case EnumFamily:
 		return t.Oid() == oid.T_anyenum
+	case RangeFamily:
+		return t.Oid() == oid.T_anyrange
 	}
 	return false
 }
@@ -13,17 +13,43 @@ package sql
 import (
 	"context"
 
-	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgnotify"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
-	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 )
 
+// Unlisten implements the UNLISTEN statement.
+// See https://www.postgresql.org/docs/current/sql-unlisten.html for details.
 func (p *planner) Unlisten(ctx context.Context, n *tree.Unlisten) (planNode, error) {
-	p.BufferClientNotice(ctx,
-		pgerror.WithSeverity(
-			unimplemented.NewWithIssuef(41522, "CRDB does not support LISTEN, making UNLISTEN a no-op"),
-			"NOTICE",
-		),
-	)
-	return newZeroNode(nil /* columns */), nil
+	var listener *pgnotify.Listener
+	if p.notificationListener != nil {
+		listener = p.notificationListener()
+	}
+	if listener == nil {
+		// The session cannot be listening on any channel.
+		return newZeroNode(nil /* columns */), nil
+	}
+	node := &unlistenNode{listener: listener, all: n.Star}
+	if !n.Star {
+		node.channel = n.ChannelName.Object()
+	}
+	return node, nil
 }
+
+type unlistenNode struct {
+	listener *pgnotify.Listener
+	channel  string
+	all      bool
+}
+
+func (n *unlistenNode) startExec(params runParams) error {
+	if n.all {
+		n.listener.UnlistenAll(params.p.Txn())
+	} else {
+		n.listener.Unlisten(params.p.Txn(), n.channel)
+	}
+	return nil
+}
+
+func (n *unlistenNode) Next(_ runParams) (bool, error) { return false, nil }
+func (n *unlistenNode) Values() tree.Datums            { return nil }
+func (n *unlistenNode) Close(_ context.Context)        {}
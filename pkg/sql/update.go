@@ -14,6 +14,7 @@ import (
 	"context"
 	"sync"
 
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
@@ -135,6 +136,7 @@ func (u *updateNode) startExec(params runParams) error {
 			colinfo.ColTypeInfoFromResCols(u.columns),
 		)
 	}
+	u.run.tu.deferredKeys = params.p.queueDeferredConstraintChecks(u.run.tu.tableDesc(), true /* insertion */, true /* deletion */)
 	return u.run.tu.init(params.ctx, params.p.txn, params.EvalContext())
 }
 
@@ -286,6 +288,11 @@ func (u *updateNode) processSourceRow(params runParams, sourceVals tree.Datums)
 	if err := enforceLocalColumnConstraints(u.run.updateValues, u.run.tu.ru.UpdateCols); err != nil {
 		return err
 	}
+	if err := enforceArrayValuesSupported(
+		params.ctx, params.ExecCfg().Settings, u.run.updateValues,
+	); err != nil {
+		return err
+	}
 
 	// Run the CHECK constraints, if any. CheckHelper will either evaluate the
 	// constraints itself, or else inspect boolean columns from the input that
@@ -432,3 +439,17 @@ func enforceLocalColumnConstraints(row tree.Datums, cols []catalog.Column) error
 	}
 	return nil
 }
+
+// enforceArrayValuesSupported returns an error if the row contains an array
+// that cannot be stored until the cluster is upgraded. See
+// colinfo.ValidateArrayValue.
+func enforceArrayValuesSupported(
+	ctx context.Context, st *cluster.Settings, row tree.Datums,
+) error {
+	for _, d := range row {
+		if err := colinfo.ValidateArrayValue(ctx, st, d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
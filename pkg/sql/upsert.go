@@ -59,6 +59,7 @@ func (n *upsertNode) startExec(params runParams) error {
 	// cache traceKV during execution, to avoid re-evaluating it for every row.
 	n.run.traceKV = params.p.ExtendedEvalContext().Tracing.KVTracingEnabled()
 
+	n.run.tw.deferredKeys = params.p.queueDeferredConstraintChecks(n.run.tw.tableDesc(), true /* insertion */, true /* deletion */)
 	return n.run.tw.init(params.ctx, params.p.txn, params.EvalContext())
 }
 
@@ -141,6 +142,10 @@ func (n *upsertNode) processSourceRow(params runParams, rowVals tree.Datums) err
 	if err := enforceLocalColumnConstraints(rowVals, n.run.insertCols); err != nil {
 		return err
 	}
+	// The row also contains the values of the update columns, if any.
+	if err := enforceArrayValuesSupported(params.ctx, params.ExecCfg().Settings, rowVals); err != nil {
+		return err
+	}
 
 	// Create a set of partial index IDs to not add or remove entries from.
 	var pm row.PartialIndexUpdateHelper
@@ -81,6 +81,14 @@ import (
 // mvccScanFetchAdapter which - via the singleResults struct - exposes access to
 // the current KV that the pebbleMVCCScanner is pointing at.
 //
+// If the fetchpb.IndexFetchSpec has the pushdown set, then the cFetcherWrapper
+// additionally evaluates the filter over the decoded SQL rows and, if
+// requested, accumulates the aggregates, so that only the rows passing the
+// filter (or a single row with the partial aggregates) are included into the
+// response. Note that the limits of the pebbleMVCCScanner (like MaxKeys and
+// TargetBytes) still apply to all scanned KVs, regardless of whether they
+// ended up being included into the response.
+//
 // Note that there is an additional "implicit synchronization" between
 // components that is not shown on this diagram. In particular,
 // storage.singleResults.maybeTrimPartialLastRow must be in sync with the
@@ -245,6 +245,12 @@ func MsgsToJSONWithIgnore(msgs []pgproto3.BackendMessage, args *datadriven.TestD
 					m.ConstraintName = ""
 				}
 			}
+		case "ignore_notification_pids":
+			for _, msg := range msgs {
+				if m, ok := msg.(*pgproto3.NotificationResponse); ok {
+					m.PID = 0
+				}
+			}
 		case "ignore":
 			for _, typ := range arg.Vals {
 				ignore[fmt.Sprintf("*pgproto3.%s", typ)] = true
@@ -326,6 +332,8 @@ func toMessage(typ string) interface{} {
 		return &pgproto3.ReadyForQuery{}
 	case "Sync":
 		return &pgproto3.Sync{}
+	case "NotificationResponse":
+		return &pgproto3.NotificationResponse{}
 	case "ParameterStatus":
 		return &pgproto3.ParameterStatus{}
 	case "BindComplete":
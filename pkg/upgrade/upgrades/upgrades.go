@@ -156,6 +156,22 @@ var upgrades = []upgradebase.Upgrade{
 		upgrade.RestoreActionImplemented("bad row skipped when restoring system.tenant_settings"),
 	),
 
+	upgrade.NewTenantUpgrade(
+		"create the system.notifications table",
+		clusterversion.V24_2_NotificationsTable.Version(),
+		upgrade.NoPrecondition,
+		createNotificationsTable,
+		upgrade.RestoreActionNotRequired("cluster restore does not restore this table"),
+	),
+
+	upgrade.NewTenantUpgrade(
+		"create the system.publications and system.replication_slots tables",
+		clusterversion.V24_2_LogicalReplicationTables.Version(),
+		upgrade.NoPrecondition,
+		createLogicalReplicationTables,
+		upgrade.RestoreActionNotRequired("cluster restore does not restore these tables"),
+	),
+
 	// Note: when starting a new release version, the first upgrade (for
 	// Vxy_zStart) must be a newFirstUpgrade. Keep this comment at the bottom.
 }
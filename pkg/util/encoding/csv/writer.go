@@ -27,11 +27,13 @@ import (
 // newline and uses ',' as the field delimiter. The exported fields can be
 // changed to customize the details before the first call to Write or WriteAll.
 //
-// Comma is the field delimiter.
+// Comma is the field delimiter and Quote is the character used to quote
+// fields.
 //
 // If UseCRLF is true, the Writer ends each record with \r\n instead of \n.
 type Writer struct {
 	Comma                    rune // Field delimiter (set to ',' by NewWriter)
+	Quote                    rune // Quote character (set to '"' by NewWriter)
 	Escape                   rune
 	UseCRLF                  bool // True to use \r\n as the line terminator
 	SkipNewline              bool // True to skip \n as the line terminator
@@ -47,6 +49,7 @@ type Writer struct {
 func NewWriter(w io.Writer) *Writer {
 	return &Writer{
 		Comma:   ',',
+		Quote:   '"',
 		Escape:  '"',
 		w:       bufio.NewWriter(w),
 		scratch: new(bytes.Buffer),
@@ -84,7 +87,17 @@ func (w *Writer) FinishRecord() (err error) {
 }
 
 // WriteField writes an individual field.
-func (w *Writer) WriteField(field *bytes.Buffer) (e error) {
+func (w *Writer) WriteField(field *bytes.Buffer) error {
+	return w.writeField(field, false /* forceQuotes */)
+}
+
+// WriteQuotedField writes an individual field, enclosing it in quotes even if
+// it does not need them.
+func (w *Writer) WriteQuotedField(field *bytes.Buffer) error {
+	return w.writeField(field, true /* forceQuotes */)
+}
+
+func (w *Writer) writeField(field *bytes.Buffer, forceQuotes bool) (e error) {
 	if w.midRow {
 		if _, err := w.w.WriteRune(w.Comma); err != nil {
 			return err
@@ -99,7 +112,7 @@ func (w *Writer) WriteField(field *bytes.Buffer) (e error) {
 	// modifying linebreaks as configured by w.UseCRLF, and tracking
 	// whether the string as a whole needs to be enclosed in quotes.
 	// We write to a scratch buffer instead of directly to w since we
-	// don't know yet if the first byte needs to be '"'.
+	// don't know yet if the first byte needs to be the quote character.
 	var r rune
 	for ; e == nil; w.i++ {
 		r, _, e = field.ReadRune()
@@ -109,7 +122,7 @@ func (w *Writer) WriteField(field *bytes.Buffer) (e error) {
 		// Check if the string exactly equals the Postgres terminator string \.
 		w.maybeTerminatorString = w.maybeTerminatorString && ((w.i == 0 && r == '\\') || (w.i == 1 && r == '.'))
 		switch r {
-		case '"', w.Escape:
+		case w.Quote, w.Escape:
 			w.currentRecordNeedsQuotes = true
 			_, e = w.scratch.WriteRune(w.Escape)
 			if e == nil {
@@ -146,7 +159,7 @@ func (w *Writer) WriteField(field *bytes.Buffer) (e error) {
 	}
 
 	w.maybeTerminatorString = w.maybeTerminatorString && w.i == 2
-	w.currentRecordNeedsQuotes = w.currentRecordNeedsQuotes || w.maybeTerminatorString
+	w.currentRecordNeedsQuotes = w.currentRecordNeedsQuotes || w.maybeTerminatorString || forceQuotes
 
 	// By now we know whether or not the entire field needs to be quoted.
 	// Fields with a Comma, fields with a quote or newline, and
@@ -161,14 +174,14 @@ func (w *Writer) WriteField(field *bytes.Buffer) (e error) {
 	// of Microsoft Excel and Google Drive.
 	// For Postgres, quote the data terminating string `\.`.
 	if w.currentRecordNeedsQuotes {
-		e = w.w.WriteByte('"')
+		_, e = w.w.WriteRune(w.Quote)
 		if e != nil {
 			return e
 		}
 	}
 	_, e = w.scratch.WriteTo(w.w)
 	if w.currentRecordNeedsQuotes {
-		e = w.w.WriteByte('"')
+		_, e = w.w.WriteRune(w.Quote)
 	}
 
 	return e
@@ -185,7 +198,10 @@ func (w *Writer) ForceEmptyField() error {
 	w.currentRecordNeedsQuotes = false
 	w.scratch.Reset()
 	w.maybeTerminatorString = true
-	_, err := w.w.WriteString(`""`)
+	if _, err := w.w.WriteRune(w.Quote); err != nil {
+		return err
+	}
+	_, err := w.w.WriteRune(w.Quote)
 	return err
 }
 
@@ -24,6 +24,7 @@ import (
 var writeTests = []struct {
 	Input   [][]string
 	Output  string
+	Quote   rune
 	Escape  rune
 	UseCRLF bool
 }{
@@ -55,6 +56,7 @@ var writeTests = []struct {
 	// Previous versions of csv.Writer didn't quote a string containing a custom escape character, which was
 	// probably a bug despite previously being asserted in this test. But also nothing actually used a custom escape character.
 	{Input: [][]string{{`"`, `,`, `x"`, `x`, `xx,`}}, Escape: 'x', Output: `"x"",",","xxx"","xx","xxxx,"` + "\n"},
+	{Input: [][]string{{`a'b`, `"c"`, `d,e`}}, Quote: '\'', Escape: '\'', Output: `'a''b',"c",'d,e'` + "\n"},
 }
 
 func TestWrite(t *testing.T) {
@@ -62,6 +64,9 @@ func TestWrite(t *testing.T) {
 		b := &bytes.Buffer{}
 		f := NewWriter(b)
 		f.UseCRLF = tt.UseCRLF
+		if tt.Quote != 0 {
+			f.Quote = tt.Quote
+		}
 		if tt.Escape != 0 {
 			f.Escape = tt.Escape
 		}
@@ -0,0 +1,254 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package parquet
+
+import (
+	"github.com/apache/arrow/go/v11/parquet"
+	"github.com/apache/arrow/go/v11/parquet/file"
+	"github.com/apache/arrow/go/v11/parquet/metadata"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// A Reader reads the rows of a parquet file written by a Writer. Only the
+// requested columns are read, and they are decoded as the requested types,
+// which must match the types the file was written with.
+//
+// Row groups are read one at a time, so the memory used by a Reader is
+// proportional to the size of the largest row group in the file.
+type Reader struct {
+	reader *file.Reader
+
+	// colIdxs are the indexes of the physical columns of the file read for
+	// each requested column.
+	colIdxs  []int
+	decoders []decoder
+	// arrayContents is the type of the elements of each requested array
+	// column, and nil for other columns.
+	arrayContents []*types.T
+
+	// filter, if set, is used to skip row groups. See SetRowGroupFilter.
+	filter RowGroupFilter
+
+	// rows are the rows of the row group at index rowGroup-1, of which the
+	// first pos have been returned.
+	rowGroup int
+	rows     [][]tree.Datum
+	pos      int
+}
+
+// ColumnBounds are the smallest and largest non-NULL values of a column in a
+// row group, as recorded in the statistics of the file. Min and Max are nil if
+// they are unknown.
+type ColumnBounds struct {
+	Min, Max tree.Datum
+}
+
+// A RowGroupFilter returns whether a row group, given the bounds of each of the
+// requested columns in it, may contain rows that are needed by the caller.
+type RowGroupFilter func(bounds []ColumnBounds) (bool, error)
+
+// NewReader returns a Reader for the given columns of the parquet file read
+// from r. Columns are identified by name. Tuple columns are not supported.
+func NewReader(
+	r parquet.ReaderAtSeeker, columnNames []string, columnTypes []*types.T,
+) (*Reader, error) {
+	if len(columnTypes) != len(columnNames) {
+		return nil, errors.AssertionFailedf("the number of column names must match the number of column types")
+	}
+	reader, err := file.NewParquetReader(r)
+	if err != nil {
+		return nil, pgerror.Wrap(err, pgcode.InvalidParameterValue, "invalid parquet file")
+	}
+	res := &Reader{
+		reader:        reader,
+		colIdxs:       make([]int, len(columnNames)),
+		decoders:      make([]decoder, len(columnNames)),
+		arrayContents: make([]*types.T, len(columnNames)),
+	}
+	sch := reader.MetaData().Schema
+	for i, name := range columnNames {
+		res.colIdxs[i] = -1
+		for j := 0; j < sch.NumColumns(); j++ {
+			if path := sch.Column(j).ColumnPath(); len(path) > 0 && path[0] == name {
+				res.colIdxs[i] = j
+				break
+			}
+		}
+		if res.colIdxs[i] == -1 {
+			return nil, errors.CombineErrors(
+				pgerror.Newf(pgcode.UndefinedColumn, "column %q not found in parquet file", name),
+				reader.Close(),
+			)
+		}
+		typ := columnTypes[i]
+		switch sch.Column(res.colIdxs[i]).MaxDefinitionLevel() {
+		case 1:
+		case 3:
+			if typ.Family() != types.ArrayFamily {
+				return nil, errors.CombineErrors(
+					pgerror.Newf(pgcode.DatatypeMismatch,
+						"column %q is an array in parquet file but has type %s", name, typ.SQLString()),
+					reader.Close(),
+				)
+			}
+			typ = typ.ArrayContents()
+			res.arrayContents[i] = typ
+		default:
+			return nil, errors.CombineErrors(
+				pgerror.Newf(pgcode.FeatureNotSupported,
+					"column %q of parquet file has an unsupported type", name),
+				reader.Close(),
+			)
+		}
+		if res.decoders[i], err = decoderFromFamilyAndType(typ.Oid(), typ.Family()); err != nil {
+			return nil, errors.CombineErrors(err, reader.Close())
+		}
+	}
+	return res, nil
+}
+
+// SetRowGroupFilter sets a filter that is used to skip the row groups of the
+// file for which it returns false, without reading them.
+func (r *Reader) SetRowGroupFilter(filter RowGroupFilter) {
+	r.filter = filter
+}
+
+// Next returns the next row of the file, or nil if there are no more rows.
+// The returned row is only valid until the next call to Next.
+func (r *Reader) Next() (tree.Datums, error) {
+	for r.pos == len(r.rows) {
+		if r.rowGroup == r.reader.NumRowGroups() {
+			return nil, nil
+		}
+		if err := r.readRowGroup(); err != nil {
+			return nil, err
+		}
+	}
+	r.pos++
+	return r.rows[r.pos-1], nil
+}
+
+// readRowGroup reads the next row group of the file into rows.
+func (r *Reader) readRowGroup() error {
+	rgr := r.reader.RowGroup(r.rowGroup)
+	r.rowGroup++
+	if r.filter != nil {
+		bounds, err := r.rowGroupBounds(rgr.MetaData())
+		if err != nil {
+			return err
+		}
+		if ok, err := r.filter(bounds); err != nil || !ok {
+			r.rows, r.pos = r.rows[:0], 0
+			return err
+		}
+	}
+	numRows := rgr.NumRows()
+	r.rows = make([][]tree.Datum, numRows)
+	for i := range r.rows {
+		r.rows[i] = make([]tree.Datum, len(r.colIdxs))
+	}
+	r.pos = 0
+	for i, colIdx := range r.colIdxs {
+		col, err := rgr.Column(colIdx)
+		if err != nil {
+			return err
+		}
+		isArray := r.arrayContents[i] != nil
+		datums, err := readColInRowGroup(col, r.decoders[i], numRows, isArray, false /* isTuple */)
+		if err != nil {
+			return err
+		}
+		if isArray {
+			for _, d := range datums {
+				if arr, ok := d.(*tree.DArray); ok {
+					arr.ParamTyp = r.arrayContents[i]
+					for _, elem := range arr.Array {
+						if elem == tree.DNull {
+							arr.HasNulls = true
+						} else {
+							arr.HasNonNulls = true
+						}
+					}
+				}
+			}
+		}
+		decodeValuesIntoDatumsHelper(datums, r.rows, i, 0 /* startingRowIdx */)
+	}
+	return nil
+}
+
+// rowGroupBounds returns the bounds of the requested columns in the row group
+// with the given metadata. The bounds are only known for the columns whose
+// statistics order values the same way as the datums they are decoded to:
+// integers and strings. In particular, many types are written as strings
+// which do not sort like their values.
+func (r *Reader) rowGroupBounds(md *metadata.RowGroupMetaData) ([]ColumnBounds, error) {
+	bounds := make([]ColumnBounds, len(r.colIdxs))
+	for i, colIdx := range r.colIdxs {
+		switch r.decoders[i].(type) {
+		case int64Decoder, int32Decoder, stringDecoder:
+		default:
+			continue
+		}
+		if r.arrayContents[i] != nil {
+			continue
+		}
+		chunk, err := md.ColumnChunk(colIdx)
+		if err != nil {
+			return nil, err
+		}
+		if ok, err := chunk.StatsSet(); err != nil {
+			return nil, err
+		} else if !ok {
+			continue
+		}
+		stats, err := chunk.Statistics()
+		if err != nil {
+			return nil, err
+		}
+		if stats == nil || !stats.HasMinMax() {
+			continue
+		}
+		switch s := stats.(type) {
+		case *metadata.Int64Statistics:
+			bounds[i], err = decodeBounds(r.decoders[i], s.Min(), s.Max())
+		case *metadata.Int32Statistics:
+			bounds[i], err = decodeBounds(r.decoders[i], s.Min(), s.Max())
+		case *metadata.ByteArrayStatistics:
+			bounds[i], err = decodeBounds(r.decoders[i], s.Min(), s.Max())
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return bounds, nil
+}
+
+func decodeBounds[T parquetDatatypes](dec decoder, min, max T) (ColumnBounds, error) {
+	var b ColumnBounds
+	var err error
+	if b.Min, err = decode(dec, min); err != nil {
+		return ColumnBounds{}, err
+	}
+	if b.Max, err = decode(dec, max); err != nil {
+		return ColumnBounds{}, err
+	}
+	return b, nil
+}
+
+// Close closes the Reader.
+func (r *Reader) Close() error {
+	return r.reader.Close()
+}
@@ -0,0 +1,115 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package parquet
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReader(t *testing.T) {
+	colNames := []string{"a", "b", "c"}
+	colTypes := []*types.T{types.Int, types.String, types.IntArray}
+	schemaDef, err := NewSchema(colNames, colTypes)
+	require.NoError(t, err)
+
+	var rows [][]tree.Datum
+	for i := 0; i < 10; i++ {
+		arr := tree.NewDArray(types.Int)
+		require.NoError(t, arr.Append(tree.NewDInt(tree.DInt(i))))
+		require.NoError(t, arr.Append(tree.DNull))
+		row := []tree.Datum{tree.NewDInt(tree.DInt(i)), tree.NewDString("row"), arr}
+		if i%3 == 0 {
+			row[1] = tree.DNull
+		}
+		rows = append(rows, row)
+	}
+
+	var buf bytes.Buffer
+	writer, err := NewWriter(schemaDef, &buf, WithMaxRowGroupLength(4))
+	require.NoError(t, err)
+	for _, row := range rows {
+		require.NoError(t, writer.AddRow(row))
+	}
+	require.NoError(t, writer.Close())
+
+	t.Run("all columns", func(t *testing.T) {
+		reader, err := NewReader(bytes.NewReader(buf.Bytes()), colNames, colTypes)
+		require.NoError(t, err)
+		defer func() { require.NoError(t, reader.Close()) }()
+		for _, expected := range rows {
+			row, err := reader.Next()
+			require.NoError(t, err)
+			require.Len(t, row, len(colNames))
+			for i := range row {
+				ValidateDatum(t, expected[i], row[i])
+			}
+			require.True(t, row[2].ResolvedType().Identical(types.IntArray))
+		}
+		row, err := reader.Next()
+		require.NoError(t, err)
+		require.Nil(t, row)
+	})
+
+	t.Run("subset of columns", func(t *testing.T) {
+		reader, err := NewReader(
+			bytes.NewReader(buf.Bytes()), []string{"b", "a"}, []*types.T{types.String, types.Int},
+		)
+		require.NoError(t, err)
+		defer func() { require.NoError(t, reader.Close()) }()
+		for _, expected := range rows {
+			row, err := reader.Next()
+			require.NoError(t, err)
+			ValidateDatum(t, expected[1], row[0])
+			ValidateDatum(t, expected[0], row[1])
+		}
+	})
+
+	t.Run("row group filter", func(t *testing.T) {
+		reader, err := NewReader(bytes.NewReader(buf.Bytes()), colNames, colTypes)
+		require.NoError(t, err)
+		defer func() { require.NoError(t, reader.Close()) }()
+		var groups [][]ColumnBounds
+		reader.SetRowGroupFilter(func(bounds []ColumnBounds) (bool, error) {
+			groups = append(groups, bounds)
+			// Skip the row groups in which a is always less than 6.
+			return bounds[0].Max == nil || *bounds[0].Max.(*tree.DInt) >= 6, nil
+		})
+		for _, expected := range rows[4:] {
+			row, err := reader.Next()
+			require.NoError(t, err)
+			ValidateDatum(t, expected[0], row[0])
+		}
+		row, err := reader.Next()
+		require.NoError(t, err)
+		require.Nil(t, row)
+
+		// The rows are written in row groups of 4 rows. The bounds of the array
+		// column are unknown.
+		require.Len(t, groups, 3)
+		for i, bounds := range groups {
+			require.Equal(t, tree.NewDInt(tree.DInt(4*i)), bounds[0].Min)
+			require.Equal(t, tree.NewDInt(tree.DInt(min(4*i+3, 9))), bounds[0].Max)
+			require.Equal(t, tree.NewDString("row"), bounds[1].Min)
+			require.Equal(t, tree.NewDString("row"), bounds[1].Max)
+			require.Equal(t, ColumnBounds{}, bounds[2])
+		}
+	})
+
+	t.Run("missing column", func(t *testing.T) {
+		_, err := NewReader(bytes.NewReader(buf.Bytes()), []string{"d"}, []*types.T{types.Int})
+		require.ErrorContains(t, err, `column "d" not found in parquet file`)
+	})
+}
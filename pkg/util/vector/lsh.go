@@ -0,0 +1,163 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package vector
+
+import (
+	"math"
+	"math/bits"
+	"math/rand"
+	"sort"
+	"sync"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/errors"
+)
+
+// Vector indexes created with CREATE INDEX ... USING lsh assign vectors to
+// lists with random hyperplane locality-sensitive hashing (LSH): the space is
+// divided by random, but deterministic, hyperplanes through the origin, and
+// each list corresponds to one combination of the sides of the hyperplanes.
+// The list of a vector is therefore a pure function of the vector, which lets
+// the index be maintained as an ordinary expression index, unlike pgvector's
+// IVFFlat indexes, whose lists are clusters trained with k-means.
+//
+// Since the list of a vector only depends on its direction, the index can only
+// find the nearest neighbors of a vector by cosine distance.
+
+// MaxLSHLists is the maximum number of lists of a vector index.
+const MaxLSHLists = 4096
+
+// DefaultLSHLists is the number of lists of a vector index if it is not
+// specified.
+const DefaultLSHLists = 64
+
+// DefaultLSHProbes is the default number of lists of a vector index that are
+// searched for the nearest neighbors of a vector.
+const DefaultLSHProbes = 8
+
+// ValidateLSHLists checks that the given number of lists of a vector index is
+// valid. It must be a power of two, since each list corresponds to a
+// combination of the sides of a set of hyperplanes.
+func ValidateLSHLists(lists int64) error {
+	if lists < 1 || lists > MaxLSHLists || bits.OnesCount64(uint64(lists)) != 1 {
+		return errors.WithHint(
+			pgerror.Newf(pgcode.InvalidParameterValue,
+				"lists must be a power of two between 1 and %d", MaxLSHLists),
+			"Vectors are assigned to lists by random hyperplanes rather than by "+
+				"k-means clustering, so each list corresponds to a combination of "+
+				"the sides of log2(lists) hyperplanes.",
+		)
+	}
+	return nil
+}
+
+type hyperplanesKey struct {
+	dims, count int
+}
+
+// hyperplanesCache caches the hyperplanes used to assign vectors with a given
+// number of dimensions to lists, since they are needed for every vector
+// written to the index.
+var hyperplanesCache sync.Map // hyperplanesKey -> []T
+
+// hyperplanes returns count hyperplanes through the origin in a space with the
+// given number of dimensions, represented by their normal vectors. The
+// hyperplanes are random, but deterministic, so that the same vector is
+// always assigned to the same list.
+func hyperplanes(dims, count int) []T {
+	key := hyperplanesKey{dims: dims, count: count}
+	if planes, ok := hyperplanesCache.Load(key); ok {
+		return planes.([]T)
+	}
+	rng := rand.New(rand.NewSource(int64(dims)))
+	planes := make([]T, count)
+	for i := range planes {
+		planes[i] = make(T, dims)
+		for j := range planes[i] {
+			planes[i][j] = float32(rng.NormFloat64())
+		}
+	}
+	planes2, _ := hyperplanesCache.LoadOrStore(key, planes)
+	return planes2.([]T)
+}
+
+// projections returns the dot products of v with the normal vectors of the
+// hyperplanes which divide the space into the given number of lists.
+func projections(v T, lists int64) ([]float64, error) {
+	if err := ValidateLSHLists(lists); err != nil {
+		return nil, err
+	}
+	planes := hyperplanes(len(v), bits.TrailingZeros64(uint64(lists)))
+	ret := make([]float64, len(planes))
+	for i, p := range planes {
+		for j := range v {
+			ret[i] += float64(p[j]) * float64(v[j])
+		}
+	}
+	return ret, nil
+}
+
+// LSHList returns the list of a vector index with the given number of lists to
+// which v is assigned. v is assigned to the list for the side of each
+// hyperplane it lies on, so vectors which are at a small angle to each other
+// are likely to be assigned to the same list.
+func LSHList(v T, lists int64) (int64, error) {
+	proj, err := projections(v, lists)
+	if err != nil {
+		return 0, err
+	}
+	var list int64
+	for i, p := range proj {
+		if p >= 0 {
+			list |= 1 << i
+		}
+	}
+	return list, nil
+}
+
+// LSHProbes returns the lists of a vector index with the given number of lists
+// which should be searched for the nearest neighbors of q, in ascending order.
+// The lists are ranked by the total distance of q from the hyperplanes which
+// separate it from each list, and the best probes lists are returned, starting
+// with the list to which q itself is assigned.
+func LSHProbes(q T, lists int64, probes int) ([]int64, error) {
+	proj, err := projections(q, lists)
+	if err != nil {
+		return nil, err
+	}
+	own, err := LSHList(q, lists)
+	if err != nil {
+		return nil, err
+	}
+	if probes > int(lists) {
+		probes = int(lists)
+	}
+	costs := make([]float64, lists)
+	ret := make([]int64, lists)
+	for list := range ret {
+		ret[list] = int64(list)
+		diff := int64(list) ^ own
+		for i, p := range proj {
+			if diff&(1<<i) != 0 {
+				costs[list] += math.Abs(p)
+			}
+		}
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		return costs[ret[i]] < costs[ret[j]]
+	})
+	ret = ret[:probes]
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i] < ret[j]
+	})
+	return ret, nil
+}
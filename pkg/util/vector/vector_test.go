@@ -206,3 +206,53 @@ func TestPointwiseOps(t *testing.T) {
 		}
 	}
 }
+
+func TestLSH(t *testing.T) {
+	for _, lists := range []int64{0, 3, 100, MaxLSHLists * 2} {
+		assert.Error(t, ValidateLSHLists(lists))
+	}
+	for _, lists := range []int64{1, 2, 64, MaxLSHLists} {
+		assert.NoError(t, ValidateLSHLists(lists))
+	}
+
+	rng, _ := randutil.NewTestRand()
+	for i := 0; i < 100; i++ {
+		dims := 1 + rng.Intn(20)
+		lists := int64(1) << rng.Intn(8)
+		v := make(T, dims)
+		for j := range v {
+			v[j] = float32(rng.NormFloat64())
+		}
+		list, err := LSHList(v, lists)
+		assert.NoError(t, err)
+		assert.True(t, list >= 0 && list < lists)
+
+		// Assignment is deterministic, and scaling a vector does not change its
+		// list.
+		scaled, err := Mult(v, T(repeat(2, dims)))
+		assert.NoError(t, err)
+		list2, err := LSHList(scaled, lists)
+		assert.NoError(t, err)
+		assert.Equal(t, list, list2)
+
+		// The first probe is always the vector's own list, and probing every list
+		// returns all of them.
+		probes, err := LSHProbes(v, lists, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{list}, probes)
+		probes, err = LSHProbes(v, lists, int(lists)+1)
+		assert.NoError(t, err)
+		assert.Len(t, probes, int(lists))
+		for j, p := range probes {
+			assert.Equal(t, int64(j), p)
+		}
+	}
+}
+
+func repeat(x float32, n int) []float32 {
+	ret := make([]float32, n)
+	for i := range ret {
+		ret[i] = x
+	}
+	return ret
+}